@@ -0,0 +1,28 @@
+package boil
+
+import "time"
+
+// QueryCache stores the results of queries using qm.Cache. Keys are built
+// from the SQL and the arguments of a query, values are the rows bound by it
+// and must be kept for at most ttl.
+//
+// The boil/lrucache package has an in-memory implementation.
+type QueryCache interface {
+	Get(key string) (value interface{}, ok bool)
+	Set(key string, value interface{}, ttl time.Duration)
+}
+
+// currentCache is the cache used by queries using qm.Cache, by default they
+// are not cached
+var currentCache QueryCache
+
+// SetQueryCache sets the cache used by queries using qm.Cache, a nil cache
+// disables caching.
+func SetQueryCache(cache QueryCache) {
+	currentCache = cache
+}
+
+// GetQueryCache retrieves the cache used by queries using qm.Cache
+func GetQueryCache() QueryCache {
+	return currentCache
+}
@@ -21,6 +21,13 @@ type ContextExecutor interface {
 	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
 }
 
+// wrapper is implemented by the executors of this package wrapping another
+// one, what is looked up on an executor, like its identity map, is looked up
+// on the executors it wraps too
+type wrapper interface {
+	unwrap() Executor
+}
+
 // Transactor can commit and rollback, on top of being able to execute queries.
 type Transactor interface {
 	Commit() error
@@ -29,6 +36,21 @@ type Transactor interface {
 	Executor
 }
 
+// InTransaction reports whether exec is a Transactor, also when it is wrapped
+// by the other executors of boil, like the traced, measured and retried ones
+func InTransaction(exec Executor) bool {
+	for {
+		if _, ok := exec.(Transactor); ok {
+			return true
+		}
+		w, ok := exec.(wrapper)
+		if !ok {
+			return false
+		}
+		exec = w.unwrap()
+	}
+}
+
 // Beginner begins transactions.
 type Beginner interface {
 	Begin() (*sql.Tx, error)
@@ -2,8 +2,11 @@ package boil
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"io"
 	"os"
+	"time"
 )
 
 // DebugMode is a flag controlling whether generated sql statements and
@@ -47,3 +50,109 @@ func DebugWriterFrom(ctx context.Context) io.Writer {
 	}
 	return DebugWriter
 }
+
+// DebugExec executes the query on exec and, if DebugMode is true, writes the
+// query, its arguments and how long it took to DebugWriter. It is reported
+// as slow if it took longer than the threshold of the SlowQueryOptions.
+func DebugExec(exec Executor, query string, args ...interface{}) (sql.Result, error) {
+	if !DebugMode && currentSlowQueries == nil {
+		return exec.Exec(query, args...)
+	}
+
+	start := time.Now()
+	res, err := exec.Exec(query, args...)
+	finishDebug(context.Background(), DebugMode, DebugWriter, exec, query, args, time.Since(start), err)
+	return res, err
+}
+
+// DebugQuery queries exec and, if DebugMode is true, writes the query, its
+// arguments and how long it took to DebugWriter. It is reported as slow if
+// it took longer than the threshold of the SlowQueryOptions.
+func DebugQuery(exec Executor, query string, args ...interface{}) (*sql.Rows, error) {
+	if !DebugMode && currentSlowQueries == nil {
+		return exec.Query(query, args...)
+	}
+
+	start := time.Now()
+	rows, err := exec.Query(query, args...)
+	finishDebug(context.Background(), DebugMode, DebugWriter, exec, query, args, time.Since(start), err)
+	return rows, err
+}
+
+// DebugQueryRow queries exec for a single row and, if DebugMode is true,
+// writes the query, its arguments and how long it took to DebugWriter. It is
+// reported as slow if it took longer than the threshold of the
+// SlowQueryOptions.
+func DebugQueryRow(exec Executor, query string, args ...interface{}) *sql.Row {
+	if !DebugMode && currentSlowQueries == nil {
+		return exec.QueryRow(query, args...)
+	}
+
+	start := time.Now()
+	row := exec.QueryRow(query, args...)
+	finishDebug(context.Background(), DebugMode, DebugWriter, exec, query, args, time.Since(start), row.Err())
+	return row
+}
+
+// DebugExecContext executes the query on exec and, if debugging is enabled
+// for ctx, writes the query, its arguments and how long it took to the
+// writer returned by DebugWriterFrom. It is reported as slow if it took
+// longer than the threshold of the SlowQueryOptions.
+func DebugExecContext(ctx context.Context, exec ContextExecutor, query string, args ...interface{}) (sql.Result, error) {
+	debug := IsDebug(ctx)
+	if !debug && currentSlowQueries == nil {
+		return exec.ExecContext(ctx, query, args...)
+	}
+
+	start := time.Now()
+	res, err := exec.ExecContext(ctx, query, args...)
+	finishDebug(ctx, debug, DebugWriterFrom(ctx), exec, query, args, time.Since(start), err)
+	return res, err
+}
+
+// DebugQueryContext queries exec and, if debugging is enabled for ctx,
+// writes the query, its arguments and how long it took to the writer
+// returned by DebugWriterFrom. It is reported as slow if it took longer than
+// the threshold of the SlowQueryOptions.
+func DebugQueryContext(ctx context.Context, exec ContextExecutor, query string, args ...interface{}) (*sql.Rows, error) {
+	debug := IsDebug(ctx)
+	if !debug && currentSlowQueries == nil {
+		return exec.QueryContext(ctx, query, args...)
+	}
+
+	start := time.Now()
+	rows, err := exec.QueryContext(ctx, query, args...)
+	finishDebug(ctx, debug, DebugWriterFrom(ctx), exec, query, args, time.Since(start), err)
+	return rows, err
+}
+
+// DebugQueryRowContext queries exec for a single row and, if debugging is
+// enabled for ctx, writes the query, its arguments and how long it took to
+// the writer returned by DebugWriterFrom. It is reported as slow if it took
+// longer than the threshold of the SlowQueryOptions.
+func DebugQueryRowContext(ctx context.Context, exec ContextExecutor, query string, args ...interface{}) *sql.Row {
+	debug := IsDebug(ctx)
+	if !debug && currentSlowQueries == nil {
+		return exec.QueryRowContext(ctx, query, args...)
+	}
+
+	start := time.Now()
+	row := exec.QueryRowContext(ctx, query, args...)
+	finishDebug(ctx, debug, DebugWriterFrom(ctx), exec, query, args, time.Since(start), row.Err())
+	return row
+}
+
+// finishDebug writes the query to writer when debugging and reports it when
+// it was slow
+func finishDebug(ctx context.Context, debug bool, writer io.Writer, exec interface{}, query string, args []interface{}, took time.Duration, err error) {
+	if debug {
+		writeDebug(writer, query, args, took)
+	}
+	reportSlowQuery(ctx, exec, query, args, took, err)
+}
+
+func writeDebug(writer io.Writer, query string, args []interface{}, took time.Duration) {
+	fmt.Fprintln(writer, query)
+	fmt.Fprintln(writer, args)
+	fmt.Fprintf(writer, "-- took %s\n", took)
+}
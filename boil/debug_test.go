@@ -0,0 +1,89 @@
+package boil
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+type debugExecutor struct {
+	ContextExecutor
+
+	queries []string
+}
+
+func (d *debugExecutor) Exec(query string, args ...interface{}) (sql.Result, error) {
+	d.queries = append(d.queries, query)
+	return nil, nil
+}
+
+func (d *debugExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	d.queries = append(d.queries, query)
+	return nil, nil
+}
+
+func TestDebugExec(t *testing.T) {
+	buf := &bytes.Buffer{}
+	oldMode, oldWriter := DebugMode, DebugWriter
+	DebugWriter = buf
+	defer func() { DebugMode, DebugWriter = oldMode, oldWriter }()
+
+	exec := &debugExecutor{}
+
+	DebugMode = false
+	if _, err := DebugExec(exec, "delete from a where id = ?", 1); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output with debug mode off, got: %q", buf.String())
+	}
+
+	DebugMode = true
+	if _, err := DebugExec(exec, "delete from a where id = ?", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected query, args and duration lines, got: %q", buf.String())
+	}
+	if lines[0] != "delete from a where id = ?" {
+		t.Error("wrong query:", lines[0])
+	}
+	if lines[1] != "[2]" {
+		t.Error("wrong args:", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "-- took ") {
+		t.Error("wrong duration:", lines[2])
+	}
+
+	if len(exec.queries) != 2 {
+		t.Error("expected both queries to be executed, got:", len(exec.queries))
+	}
+}
+
+func TestDebugExecContext(t *testing.T) {
+	buf := &bytes.Buffer{}
+	exec := &debugExecutor{}
+
+	ctx := WithDebugWriter(WithDebug(context.Background(), true), buf)
+	if _, err := DebugExecContext(ctx, exec, "update a set b = $1", "c"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "update a set b = $1\n[c]\n-- took ") {
+		t.Errorf("wrong output: %q", out)
+	}
+
+	buf.Reset()
+	ctx = WithDebug(ctx, false)
+	if _, err := DebugExecContext(ctx, exec, "update a set b = $1", "c"); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output with debugging off, got: %q", buf.String())
+	}
+}
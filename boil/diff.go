@@ -0,0 +1,36 @@
+package boil
+
+// Change is a column whose value differs between two models, as returned by
+// their generated Diff method
+type Change struct {
+	Column string
+	Old    interface{}
+	New    interface{}
+}
+
+// Changes are the changed columns of a model in the order of its columns
+type Changes []Change
+
+// Columns returns the names of the changed columns, to update only them with
+// Whitelist
+func (c Changes) Columns() []string {
+	if len(c) == 0 {
+		return nil
+	}
+
+	columns := make([]string, len(c))
+	for i, change := range c {
+		columns[i] = change.Column
+	}
+	return columns
+}
+
+// Has tells if the column changed
+func (c Changes) Has(column string) bool {
+	for _, change := range c {
+		if change.Column == column {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package boil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChanges(t *testing.T) {
+	t.Parallel()
+
+	var none Changes
+	if none.Columns() != nil || none.Has("name") {
+		t.Error("want no columns of no changes")
+	}
+
+	changes := Changes{
+		{Column: "name", Old: "a", New: "b"},
+		{Column: "age", Old: 1, New: 2},
+	}
+	if got := changes.Columns(); !reflect.DeepEqual(got, []string{"name", "age"}) {
+		t.Error("columns were wrong:", got)
+	}
+	if !changes.Has("age") || changes.Has("id") {
+		t.Error("has was wrong")
+	}
+	if list := Whitelist(changes.Columns()...); !list.IsWhitelist() || len(list.Cols) != 2 {
+		t.Error("want a whitelist of the changed columns:", list)
+	}
+}
@@ -0,0 +1,33 @@
+package boil
+
+import "time"
+
+// EqualOptions configures how the generated EqualWith methods compare two
+// models, their Equal methods compare them with the zero EqualOptions.
+type EqualOptions struct {
+	// TimeTolerance is how far apart two times can be and still be equal,
+	// like a microsecond to compare the times rounded by the database with
+	// the ones of constructed models
+	TimeTolerance time.Duration
+	// IgnoreColumns are left out of the comparison, like updated_at
+	IgnoreColumns []string
+}
+
+// TimesEqual tells if the times are equal within the tolerance
+func (e EqualOptions) TimesEqual(a, b time.Time) bool {
+	d := a.Sub(b)
+	if d < 0 {
+		d = -d
+	}
+	return d <= e.TimeTolerance
+}
+
+// Ignores tells if the column is left out of the comparison
+func (e EqualOptions) Ignores(column string) bool {
+	for _, c := range e.IgnoreColumns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package boil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEqualOptions(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	utc := now.UTC()
+
+	var exact EqualOptions
+	if !exact.TimesEqual(now, utc) {
+		t.Error("want the same instant in two locations equal")
+	}
+	if exact.TimesEqual(now, now.Add(time.Nanosecond)) {
+		t.Error("want different times not equal without tolerance")
+	}
+
+	tolerant := EqualOptions{TimeTolerance: time.Microsecond}
+	if !tolerant.TimesEqual(now, now.Add(-time.Microsecond)) || !tolerant.TimesEqual(now.Add(time.Microsecond), now) {
+		t.Error("want the times within the tolerance equal")
+	}
+	if tolerant.TimesEqual(now, now.Add(2*time.Microsecond)) {
+		t.Error("want the times beyond the tolerance not equal")
+	}
+
+	ignoring := EqualOptions{IgnoreColumns: []string{"updated_at"}}
+	if !ignoring.Ignores("updated_at") || ignoring.Ignores("created_at") {
+		t.Error("ignores was wrong")
+	}
+}
@@ -1,5 +1,12 @@
 package boil
 
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"regexp"
+)
+
 type boilErr struct {
 	error
 }
@@ -21,3 +28,174 @@ func IsBoilErr(err error) bool {
 	_, ok := err.(boilErr)
 	return ok
 }
+
+// ErrNotFound is returned when a generated finder finds no rows. It is
+// sql.ErrNoRows so comparisons to either keep working.
+var ErrNotFound = sql.ErrNoRows
+
+// ErrUniqueViolation is returned by generated operations when the database
+// rejects a row because it violates a unique constraint or primary key.
+type ErrUniqueViolation struct {
+	// Constraint is the name of the violated constraint or index, or its
+	// columns for sqlite, when the database reports it
+	Constraint string
+	Err        error
+}
+
+// Error returns the database's error message
+func (e *ErrUniqueViolation) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the driver's error
+func (e *ErrUniqueViolation) Unwrap() error {
+	return e.Err
+}
+
+// ErrForeignKeyViolation is returned by generated operations when the
+// database rejects a row because it violates a foreign key.
+type ErrForeignKeyViolation struct {
+	// Constraint is the name of the violated foreign key, when the database
+	// reports it
+	Constraint string
+	Err        error
+}
+
+// Error returns the database's error message
+func (e *ErrForeignKeyViolation) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the driver's error
+func (e *ErrForeignKeyViolation) Unwrap() error {
+	return e.Err
+}
+
+type violation int
+
+const (
+	noViolation violation = iota
+	uniqueViolation
+	foreignKeyViolation
+)
+
+var (
+	rgxMySQLUniqueKey     = regexp.MustCompile("for key '([^']+)'")
+	rgxMySQLForeignKey    = regexp.MustCompile("CONSTRAINT `([^`]+)`")
+	rgxMSSQLUniqueKey     = regexp.MustCompile(`(?:constraint|index) '([^']+)'`)
+	rgxMSSQLForeignKey    = regexp.MustCompile(`(?:FOREIGN KEY|REFERENCE) constraint "([^"]+)"`)
+	rgxSQLiteUniqueColumn = regexp.MustCompile(`(?:UNIQUE|PRIMARY KEY) constraint failed: ([\w.]+(?:, [\w.]+)*)`)
+)
+
+// ConvertError returns err as an *ErrUniqueViolation or an
+// *ErrForeignKeyViolation when it is such a violation reported by the
+// postgres (lib/pq or pgx), mysql, mssql or sqlite driver, and err as is
+// otherwise. The drivers' errors are recognized without importing them.
+func ConvertError(err error) error {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		switch e.(type) {
+		case *ErrUniqueViolation, *ErrForeignKeyViolation:
+			return err
+		}
+
+		switch kind, constraint := classifyError(e); kind {
+		case uniqueViolation:
+			return &ErrUniqueViolation{Constraint: constraint, Err: err}
+		case foreignKeyViolation:
+			return &ErrForeignKeyViolation{Constraint: constraint, Err: err}
+		}
+	}
+
+	return err
+}
+
+func classifyError(err error) (violation, string) {
+	switch e := err.(type) {
+	case interface{ SQLState() string }:
+		// lib/pq and pgx
+		constraint := stringField(err, "Constraint", "ConstraintName")
+		switch e.SQLState() {
+		case "23505":
+			return uniqueViolation, constraint
+		case "23503":
+			return foreignKeyViolation, constraint
+		}
+		return noViolation, ""
+	case interface{ SQLErrorNumber() int32 }:
+		// go-mssqldb
+		switch e.SQLErrorNumber() {
+		case 2601, 2627:
+			return uniqueViolation, submatch(rgxMSSQLUniqueKey, err.Error())
+		case 547:
+			if constraint := submatch(rgxMSSQLForeignKey, err.Error()); len(constraint) != 0 {
+				return foreignKeyViolation, constraint
+			}
+		}
+		return noViolation, ""
+	case interface{ Code() int }:
+		// modernc.org/sqlite
+		return classifySQLite(int64(e.Code()), err.Error())
+	}
+
+	val := reflect.Indirect(reflect.ValueOf(err))
+	if val.Kind() != reflect.Struct {
+		return noViolation, ""
+	}
+
+	switch typ := val.Type(); {
+	case typ.PkgPath() == "github.com/go-sql-driver/mysql" && typ.Name() == "MySQLError":
+		switch uintField(val, "Number") {
+		case 1062, 1586:
+			return uniqueViolation, submatch(rgxMySQLUniqueKey, err.Error())
+		case 1216, 1217, 1451, 1452:
+			return foreignKeyViolation, submatch(rgxMySQLForeignKey, err.Error())
+		}
+	case typ.PkgPath() == "github.com/mattn/go-sqlite3" && typ.Name() == "Error":
+		if f := val.FieldByName("ExtendedCode"); f.IsValid() && f.Kind() == reflect.Int {
+			return classifySQLite(f.Int(), err.Error())
+		}
+	}
+
+	return noViolation, ""
+}
+
+func classifySQLite(extendedCode int64, msg string) (violation, string) {
+	switch extendedCode {
+	case 1555, 2067:
+		return uniqueViolation, submatch(rgxSQLiteUniqueColumn, msg)
+	case 787:
+		return foreignKeyViolation, ""
+	}
+	return noViolation, ""
+}
+
+func stringField(err error, names ...string) string {
+	val := reflect.Indirect(reflect.ValueOf(err))
+	if val.Kind() != reflect.Struct {
+		return ""
+	}
+
+	for _, name := range names {
+		if f := val.FieldByName(name); f.IsValid() && f.Kind() == reflect.String {
+			return f.String()
+		}
+	}
+	return ""
+}
+
+func uintField(val reflect.Value, name string) uint64 {
+	f := val.FieldByName(name)
+	switch f.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return f.Uint()
+	}
+	return 0
+}
+
+func submatch(rgx *regexp.Regexp, s string) string {
+	m := rgx.FindStringSubmatch(s)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
@@ -1,8 +1,15 @@
 package boil
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
+	"reflect"
 	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	mssql "github.com/microsoft/go-mssqldb"
 )
 
 func TestErrors(t *testing.T) {
@@ -22,3 +29,135 @@ func TestErrors(t *testing.T) {
 		t.Errorf("Expected true")
 	}
 }
+
+type sqliteError struct {
+	code int
+	msg  string
+}
+
+func (e sqliteError) Error() string { return e.msg }
+func (e sqliteError) Code() int     { return e.code }
+
+func TestConvertError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		unique     bool
+		foreignKey bool
+		constraint string
+	}{
+		{
+			name:       "pq unique",
+			err:        &pq.Error{Code: "23505", Constraint: "pilots_name_key"},
+			unique:     true,
+			constraint: "pilots_name_key",
+		},
+		{
+			name:       "pq foreign key",
+			err:        &pq.Error{Code: "23503", Constraint: "jets_pilot_id_fkey"},
+			foreignKey: true,
+			constraint: "jets_pilot_id_fkey",
+		},
+		{
+			name: "pq other",
+			err:  &pq.Error{Code: "23502"},
+		},
+		{
+			name:       "mysql unique",
+			err:        &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'Tim' for key 'pilots_name_key'"},
+			unique:     true,
+			constraint: "pilots_name_key",
+		},
+		{
+			name:       "mysql foreign key",
+			err:        &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails (`db`.`jets`, CONSTRAINT `jets_pilot_id_fkey` FOREIGN KEY (`pilot_id`) REFERENCES `pilots` (`id`))"},
+			foreignKey: true,
+			constraint: "jets_pilot_id_fkey",
+		},
+		{
+			name:       "mssql unique",
+			err:        mssql.Error{Number: 2627, Message: "Violation of UNIQUE KEY constraint 'UQ_pilots_name'. Cannot insert duplicate key in object 'dbo.pilots'."},
+			unique:     true,
+			constraint: "UQ_pilots_name",
+		},
+		{
+			name:       "mssql foreign key",
+			err:        mssql.Error{Number: 547, Message: `The INSERT statement conflicted with the FOREIGN KEY constraint "FK_jets_pilots". The conflict occurred in database "db", table "dbo.pilots", column 'id'.`},
+			foreignKey: true,
+			constraint: "FK_jets_pilots",
+		},
+		{
+			name: "mssql check",
+			err:  mssql.Error{Number: 547, Message: `The INSERT statement conflicted with the CHECK constraint "CK_pilots_age".`},
+		},
+		{
+			name:       "sqlite unique",
+			err:        sqliteError{code: 2067, msg: "constraint failed: UNIQUE constraint failed: pilots.name (2067)"},
+			unique:     true,
+			constraint: "pilots.name",
+		},
+		{
+			name:       "sqlite foreign key",
+			err:        sqliteError{code: 787, msg: "FOREIGN KEY constraint failed"},
+			foreignKey: true,
+		},
+		{
+			name:       "wrapped",
+			err:        fmt.Errorf("models: unable to insert into pilots: %w", &pq.Error{Code: "23505", Constraint: "pilots_pkey"}),
+			unique:     true,
+			constraint: "pilots_pkey",
+		},
+		{
+			name: "not found",
+			err:  sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		err := ConvertError(test.err)
+
+		var unique *ErrUniqueViolation
+		var foreignKey *ErrForeignKeyViolation
+		isUnique, isForeignKey := errors.As(err, &unique), errors.As(err, &foreignKey)
+
+		if isUnique != test.unique || isForeignKey != test.foreignKey {
+			t.Errorf("%s: want unique %t and foreign key %t, got: %#v", test.name, test.unique, test.foreignKey, err)
+			continue
+		}
+		if !isUnique && !isForeignKey {
+			if !reflect.DeepEqual(err, test.err) {
+				t.Errorf("%s: want the error returned as is, got: %#v", test.name, err)
+			}
+			continue
+		}
+
+		constraint := ""
+		if isUnique {
+			constraint = unique.Constraint
+		} else {
+			constraint = foreignKey.Constraint
+		}
+		if constraint != test.constraint {
+			t.Errorf("%s: want constraint %q, got: %q", test.name, test.constraint, constraint)
+		}
+		if !reflect.DeepEqual(errors.Unwrap(err), test.err) {
+			t.Errorf("%s: want the driver error to be unwrappable", test.name)
+		}
+	}
+
+	if !errors.Is(ErrNotFound, sql.ErrNoRows) {
+		t.Error("ErrNotFound should be sql.ErrNoRows")
+	}
+}
+
+func TestConvertErrorTwice(t *testing.T) {
+	t.Parallel()
+
+	err := ConvertError(&pq.Error{Code: "23505"})
+	wrapped := fmt.Errorf("failed to insert into foreign table: %w", err)
+	if got := ConvertError(wrapped); got != wrapped {
+		t.Errorf("want an already converted error returned as is, got: %#v", got)
+	}
+}
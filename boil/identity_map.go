@@ -0,0 +1,172 @@
+package boil
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// IdentityMap keeps the models found by their primary key with an executor
+// wrapped by IdentityMapExecutor, so that finding them again with it returns
+// the same instance without querying the database. It is meant to live as
+// long as a transaction or a request, the zero value is ready to use.
+//
+// Models generated with --with-identity-map invalidate their rows when they
+// update, upsert or delete them. Other writes, like raw queries and the
+// relationship setters, need Invalidate or InvalidateTable.
+type IdentityMap struct {
+	mut    sync.Mutex
+	tables map[string]map[interface{}]interface{}
+}
+
+// Get returns the model of the table with the primary key, the map of a nil
+// IdentityMap is always empty
+func (m *IdentityMap) Get(table string, pk ...interface{}) (interface{}, bool) {
+	if m == nil {
+		return nil, false
+	}
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	row, ok := m.tables[table][identityKey(pk)]
+	return row, ok
+}
+
+// Set keeps the model of the table with the primary key
+func (m *IdentityMap) Set(table string, row interface{}, pk ...interface{}) {
+	if m == nil {
+		return
+	}
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if m.tables == nil {
+		m.tables = make(map[string]map[interface{}]interface{})
+	}
+	rows, ok := m.tables[table]
+	if !ok {
+		rows = make(map[interface{}]interface{})
+		m.tables[table] = rows
+	}
+	rows[identityKey(pk)] = row
+}
+
+// Invalidate removes the model of the table with the primary key, it is
+// found in the database again the next time
+func (m *IdentityMap) Invalidate(table string, pk ...interface{}) {
+	if m == nil {
+		return
+	}
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	delete(m.tables[table], identityKey(pk))
+}
+
+// InvalidateTable removes the models of the table
+func (m *IdentityMap) InvalidateTable(table string) {
+	if m == nil {
+		return
+	}
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	delete(m.tables, table)
+}
+
+// Clear removes every model
+func (m *IdentityMap) Clear() {
+	if m == nil {
+		return
+	}
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	m.tables = nil
+}
+
+// identityKey returns the key of a primary key in the map of its table, the
+// value of a single column when it can key a map and the types and values of
+// the columns otherwise
+func identityKey(pk []interface{}) interface{} {
+	if len(pk) == 1 && pk[0] != nil && reflect.TypeOf(pk[0]).Comparable() {
+		return pk[0]
+	}
+	return fmt.Sprintf("%#v", pk)
+}
+
+// IdentityMapExecutor wraps exec so the generated finders keep the models
+// they find with it in m, and find them there.
+func IdentityMapExecutor(exec Executor, m *IdentityMap) Executor {
+	return identityMapExecutor{Executor: exec, identityMap: m}
+}
+
+// IdentityMapContextExecutor is IdentityMapExecutor for a ContextExecutor
+func IdentityMapContextExecutor(exec ContextExecutor, m *IdentityMap) ContextExecutor {
+	return identityMapContextExecutor{ContextExecutor: exec, identityMap: m}
+}
+
+// IdentityMapFrom returns the identity map of the executor, also when it is
+// wrapped by the other executors of boil, like the traced, measured and
+// retried ones, nil if it has none
+func IdentityMapFrom(exec Executor) *IdentityMap {
+	for {
+		switch e := exec.(type) {
+		case identityMapExecutor:
+			return e.identityMap
+		case identityMapContextExecutor:
+			return e.identityMap
+		case wrapper:
+			exec = e.unwrap()
+		default:
+			return nil
+		}
+	}
+}
+
+type identityMapExecutor struct {
+	Executor
+	identityMap *IdentityMap
+}
+
+type identityMapContextExecutor struct {
+	ContextExecutor
+	identityMap *IdentityMap
+}
+
+func (e identityMapExecutor) unwrap() Executor {
+	return e.Executor
+}
+
+func (e identityMapContextExecutor) unwrap() Executor {
+	return e.ContextExecutor
+}
+
+// ReadExecutor implements Router, reads of a routed executor keep their
+// identity map
+func (e identityMapExecutor) ReadExecutor() Executor {
+	return identityMapExecutor{Executor: RouteRead(e.Executor), identityMap: e.identityMap}
+}
+
+// WriteExecutor implements Router, writes of a routed executor keep their
+// identity map
+func (e identityMapExecutor) WriteExecutor() Executor {
+	return identityMapExecutor{Executor: RouteWrite(e.Executor), identityMap: e.identityMap}
+}
+
+// ReadExecutor implements Router, reads of a routed executor keep their
+// identity map
+func (e identityMapContextExecutor) ReadExecutor() Executor {
+	return identityMapContextExecutor{ContextExecutor: routeContext(e.ContextExecutor, RouteRead), identityMap: e.identityMap}
+}
+
+// WriteExecutor implements Router, writes of a routed executor keep their
+// identity map
+func (e identityMapContextExecutor) WriteExecutor() Executor {
+	return identityMapContextExecutor{ContextExecutor: routeContext(e.ContextExecutor, RouteWrite), identityMap: e.identityMap}
+}
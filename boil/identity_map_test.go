@@ -0,0 +1,74 @@
+package boil
+
+import (
+	"testing"
+)
+
+func TestIdentityMap(t *testing.T) {
+	t.Parallel()
+
+	m := &IdentityMap{}
+	row := &struct{ ID int }{ID: 1}
+	m.Set("pilots", row, 1)
+	m.Set("pilot_languages", "both", 1, 2)
+
+	if got, ok := m.Get("pilots", 1); !ok || got != row {
+		t.Errorf("want the row, got: %v %t", got, ok)
+	}
+	if _, ok := m.Get("pilots", int64(1)); ok {
+		t.Error("want keys of another type to be another key")
+	}
+	if got, ok := m.Get("pilot_languages", 1, 2); !ok || got != "both" {
+		t.Errorf("want the row of the composite key, got: %v %t", got, ok)
+	}
+
+	m.Invalidate("pilots", 1)
+	if _, ok := m.Get("pilots", 1); ok {
+		t.Error("want the invalidated row gone")
+	}
+	m.InvalidateTable("pilot_languages")
+	if _, ok := m.Get("pilot_languages", 1, 2); ok {
+		t.Error("want the rows of the invalidated table gone")
+	}
+
+	var none *IdentityMap
+	none.Set("pilots", row, 1)
+	if _, ok := none.Get("pilots", 1); ok {
+		t.Error("want a nil map to be empty")
+	}
+}
+
+func TestIdentityMapFrom(t *testing.T) {
+	m := &IdentityMap{}
+	if IdentityMapFrom(&debugExecutor{}) != nil {
+		t.Error("want no identity map for an unwrapped executor")
+	}
+
+	exec := IdentityMapContextExecutor(&debugExecutor{}, m)
+	if IdentityMapFrom(exec) != m {
+		t.Error("want the identity map of the executor")
+	}
+
+	SetQueryMetrics(&testMetrics{})
+	defer SetQueryMetrics(nil)
+	if IdentityMapFrom(MeasureContextExecutor(exec, "pilots", "find")) != m {
+		t.Error("want the identity map of a measured executor")
+	}
+	if IdentityMapFrom(RouteRead(exec)) != m {
+		t.Error("want the identity map of a routed executor")
+	}
+
+	retried := WithRetry(exec, RetryOptions{MaxAttempts: 3})
+	if IdentityMapFrom(retried) != m {
+		t.Error("want the identity map of a retried executor")
+	}
+	if IdentityMapFrom(MeasureContextExecutor(retried, "pilots", "find")) != m {
+		t.Error("want the identity map of a measured retried executor")
+	}
+	if IdentityMapFrom(RouteRead(retried)) != m {
+		t.Error("want the identity map of a routed retried executor")
+	}
+	if IdentityMapFrom(WithRetry(&debugExecutor{}, RetryOptions{})) != nil {
+		t.Error("want no identity map for a retried executor without one")
+	}
+}
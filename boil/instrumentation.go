@@ -0,0 +1,239 @@
+package boil
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// QueryTracer instruments the queries run by models generated with
+// --with-otel. StartQuery is called before every query with the table it
+// runs against and its SQL statement, the returned function is called with
+// the query's error once it completes.
+//
+// This makes it possible to start OpenTelemetry spans with db.table and
+// db.statement attributes, or to use any other tracing library, without
+// sqlboiler depending on it.
+type QueryTracer interface {
+	StartQuery(ctx context.Context, table, statement string) (context.Context, func(error))
+}
+
+// QueryMetrics records the queries run by models generated with
+// --with-metrics. ObserveQuery is called once every query completes with the
+// table and model operation it ran for, how long it took and its error.
+//
+// The prometheus subpackage has an implementation that keeps a counter and a
+// histogram per table and operation.
+type QueryMetrics interface {
+	ObserveQuery(table, operation string, took time.Duration, err error)
+}
+
+var (
+	// currentTracer is the tracer used by TraceExecutor
+	currentTracer QueryTracer
+	// currentMetrics is the metrics used by MeasureExecutor, by default
+	// nothing is recorded
+	currentMetrics QueryMetrics
+)
+
+// SetQueryTracer sets the tracer used by generated models, a nil tracer
+// disables tracing.
+func SetQueryTracer(tracer QueryTracer) {
+	currentTracer = tracer
+}
+
+// GetQueryTracer retrieves the tracer used by generated models
+func GetQueryTracer() QueryTracer {
+	return currentTracer
+}
+
+// SetQueryMetrics sets the metrics used by generated models, nil metrics
+// disable recording.
+func SetQueryMetrics(metrics QueryMetrics) {
+	currentMetrics = metrics
+}
+
+// GetQueryMetrics retrieves the metrics used by generated models
+func GetQueryMetrics() QueryMetrics {
+	return currentMetrics
+}
+
+// TraceExecutor wraps exec so every query it runs is reported to the current
+// QueryTracer as a query against table. exec is returned as is when there is
+// nothing to report to, the table is also reported with the slow queries.
+// Wrapping an executor that is already traced or
+// measured replaces its table.
+func TraceExecutor(exec ContextExecutor, table string) ContextExecutor {
+	i, exec := unwrapContextExecutor(exec)
+	i.table = table
+	i.tracer = currentTracer
+	return i.wrapContextExecutor(exec)
+}
+
+// MeasureExecutor wraps exec so every query it runs is reported to the
+// current QueryMetrics as a query against table for operation. exec is
+// returned as is when there is nothing to report to, the table and operation
+// are also reported with the slow queries. Wrapping an executor that is
+// already measured replaces its table and operation.
+func MeasureExecutor(exec Executor, table, operation string) Executor {
+	var i instrumentation
+	if e, ok := exec.(instrumentedExecutor); ok {
+		i, exec = e.instrumentation, e.exec
+	}
+	i.table = table
+	i.operation = operation
+	i.metrics = currentMetrics
+	if i.metrics == nil && currentSlowQueries == nil {
+		return exec
+	}
+
+	return instrumentedExecutor{exec: exec, instrumentation: i}
+}
+
+// MeasureContextExecutor is MeasureExecutor for a ContextExecutor, queries
+// are also still reported to the tracer of an executor that is already traced.
+func MeasureContextExecutor(exec ContextExecutor, table, operation string) ContextExecutor {
+	i, exec := unwrapContextExecutor(exec)
+	i.table = table
+	i.operation = operation
+	i.metrics = currentMetrics
+	return i.wrapContextExecutor(exec)
+}
+
+// instrumentation is what the queries of a traced or measured executor
+// are reported to
+type instrumentation struct {
+	table     string
+	operation string
+	tracer    QueryTracer
+	metrics   QueryMetrics
+}
+
+func unwrapContextExecutor(exec ContextExecutor) (instrumentation, ContextExecutor) {
+	if e, ok := exec.(instrumentedContextExecutor); ok {
+		return e.instrumentation, e.exec
+	}
+	return instrumentation{}, exec
+}
+
+func (i instrumentation) wrapContextExecutor(exec ContextExecutor) ContextExecutor {
+	if i.tracer == nil && i.metrics == nil && currentSlowQueries == nil {
+		return exec
+	}
+	return instrumentedContextExecutor{exec: exec, instrumentation: i}
+}
+
+// start reports the start of a query and returns the function that reports
+// its end
+func (i instrumentation) start(ctx context.Context, query string) (context.Context, func(error)) {
+	var endSpan func(error)
+	if i.tracer != nil {
+		ctx, endSpan = i.tracer.StartQuery(ctx, i.table, query)
+	}
+
+	start := time.Now()
+	return ctx, func(err error) {
+		if i.metrics != nil {
+			i.metrics.ObserveQuery(i.table, i.operation, time.Since(start), err)
+		}
+		if endSpan != nil {
+			endSpan(err)
+		}
+	}
+}
+
+type instrumentedExecutor struct {
+	exec Executor
+	instrumentation
+}
+
+func (e instrumentedExecutor) Exec(query string, args ...interface{}) (sql.Result, error) {
+	_, end := e.start(context.Background(), query)
+	res, err := e.exec.Exec(query, args...)
+	end(err)
+	return res, err
+}
+
+func (e instrumentedExecutor) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	_, end := e.start(context.Background(), query)
+	rows, err := e.exec.Query(query, args...)
+	end(err)
+	return rows, err
+}
+
+func (e instrumentedExecutor) QueryRow(query string, args ...interface{}) *sql.Row {
+	_, end := e.start(context.Background(), query)
+	row := e.exec.QueryRow(query, args...)
+	end(row.Err())
+	return row
+}
+
+func (e instrumentedExecutor) unwrap() Executor {
+	return e.exec
+}
+
+type instrumentedContextExecutor struct {
+	exec ContextExecutor
+	instrumentation
+}
+
+func (e instrumentedContextExecutor) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return e.ExecContext(context.Background(), query, args...)
+}
+
+func (e instrumentedContextExecutor) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return e.QueryContext(context.Background(), query, args...)
+}
+
+func (e instrumentedContextExecutor) QueryRow(query string, args ...interface{}) *sql.Row {
+	return e.QueryRowContext(context.Background(), query, args...)
+}
+
+func (e instrumentedContextExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, end := e.start(ctx, query)
+	res, err := e.exec.ExecContext(ctx, query, args...)
+	end(err)
+	return res, err
+}
+
+func (e instrumentedContextExecutor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, end := e.start(ctx, query)
+	rows, err := e.exec.QueryContext(ctx, query, args...)
+	end(err)
+	return rows, err
+}
+
+func (e instrumentedContextExecutor) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, end := e.start(ctx, query)
+	row := e.exec.QueryRowContext(ctx, query, args...)
+	end(row.Err())
+	return row
+}
+
+func (e instrumentedContextExecutor) unwrap() Executor {
+	return e.exec
+}
+
+// ReadExecutor implements Router, reads of a routed executor are still
+// instrumented
+func (e instrumentedExecutor) ReadExecutor() Executor {
+	return instrumentedExecutor{exec: RouteRead(e.exec), instrumentation: e.instrumentation}
+}
+
+// WriteExecutor implements Router, writes of a routed executor are still
+// instrumented
+func (e instrumentedExecutor) WriteExecutor() Executor {
+	return instrumentedExecutor{exec: RouteWrite(e.exec), instrumentation: e.instrumentation}
+}
+
+// ReadExecutor implements Router, reads of a routed executor are still
+// instrumented
+func (e instrumentedContextExecutor) ReadExecutor() Executor {
+	return instrumentedContextExecutor{exec: routeContext(e.exec, RouteRead), instrumentation: e.instrumentation}
+}
+
+// WriteExecutor implements Router, writes of a routed executor are still
+// instrumented
+func (e instrumentedContextExecutor) WriteExecutor() Executor {
+	return instrumentedContextExecutor{exec: routeContext(e.exec, RouteWrite), instrumentation: e.instrumentation}
+}
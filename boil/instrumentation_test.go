@@ -0,0 +1,110 @@
+package boil
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+type testTracer struct {
+	tables     []string
+	statements []string
+	errs       []error
+}
+
+func (t *testTracer) StartQuery(ctx context.Context, table, statement string) (context.Context, func(error)) {
+	t.tables = append(t.tables, table)
+	t.statements = append(t.statements, statement)
+	return ctx, func(err error) {
+		t.errs = append(t.errs, err)
+	}
+}
+
+type failingExecutor struct {
+	ContextExecutor
+}
+
+var errExecFailed = errors.New("exec failed")
+
+func (failingExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, errExecFailed
+}
+
+func TestTraceExecutor(t *testing.T) {
+	exec := failingExecutor{}
+
+	SetQueryTracer(nil)
+	if got := TraceExecutor(exec, "pilots"); got != ContextExecutor(exec) {
+		t.Error("expected the executor to be returned as is without a tracer")
+	}
+
+	tracer := &testTracer{}
+	SetQueryTracer(tracer)
+	defer SetQueryTracer(nil)
+
+	traced := TraceExecutor(exec, "pilots")
+	traced = TraceExecutor(traced, "jets")
+
+	if _, err := traced.ExecContext(context.Background(), "delete from jets"); err != errExecFailed {
+		t.Error("expected the executor's error, got:", err)
+	}
+
+	if len(tracer.tables) != 1 || tracer.tables[0] != "jets" {
+		t.Error("expected one query against jets, got:", tracer.tables)
+	}
+	if tracer.statements[0] != "delete from jets" {
+		t.Error("wrong statement:", tracer.statements[0])
+	}
+	if tracer.errs[0] != errExecFailed {
+		t.Error("expected the tracer to be given the error, got:", tracer.errs[0])
+	}
+}
+
+type testMetrics struct {
+	tables     []string
+	operations []string
+	errs       []error
+}
+
+func (m *testMetrics) ObserveQuery(table, operation string, took time.Duration, err error) {
+	m.tables = append(m.tables, table)
+	m.operations = append(m.operations, operation)
+	m.errs = append(m.errs, err)
+}
+
+func TestMeasureExecutor(t *testing.T) {
+	exec := failingExecutor{}
+
+	SetQueryMetrics(nil)
+	if got := MeasureContextExecutor(exec, "pilots", "insert"); got != ContextExecutor(exec) {
+		t.Error("expected the executor to be returned as is without metrics")
+	}
+
+	tracer := &testTracer{}
+	metrics := &testMetrics{}
+	SetQueryTracer(tracer)
+	SetQueryMetrics(metrics)
+	defer SetQueryTracer(nil)
+	defer SetQueryMetrics(nil)
+
+	wrapped := TraceExecutor(exec, "pilots")
+	wrapped = MeasureContextExecutor(wrapped, "pilots", "add")
+	wrapped = TraceExecutor(wrapped, "jets")
+	wrapped = MeasureContextExecutor(wrapped, "jets", "insert")
+
+	if _, err := wrapped.ExecContext(context.Background(), "insert into jets"); err != errExecFailed {
+		t.Error("expected the executor's error, got:", err)
+	}
+
+	if len(metrics.tables) != 1 || metrics.tables[0] != "jets" || metrics.operations[0] != "insert" {
+		t.Error("expected one insert into jets, got:", metrics.tables, metrics.operations)
+	}
+	if metrics.errs[0] != errExecFailed {
+		t.Error("expected the metrics to be given the error, got:", metrics.errs[0])
+	}
+	if len(tracer.tables) != 1 || tracer.tables[0] != "jets" {
+		t.Error("expected the query to still be traced once, got:", tracer.tables)
+	}
+}
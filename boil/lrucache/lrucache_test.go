@@ -0,0 +1,40 @@
+package lrucache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	c := New(2)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1, time.Minute)
+	c.Set("b", 2, time.Minute)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Error("want a to be cached, got:", v, ok)
+	}
+
+	// b is the least recently used
+	c.Set("c", 3, time.Minute)
+	if _, ok := c.Get("b"); ok {
+		t.Error("want b to be evicted")
+	}
+	if v, ok := c.Get("c"); !ok || v != 3 {
+		t.Error("want c to be cached, got:", v, ok)
+	}
+	if n := c.Len(); n != 2 {
+		t.Error("want 2 results, got:", n)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := c.Get("a"); ok {
+		t.Error("want a to be expired")
+	}
+	if n := c.Len(); n != 1 {
+		t.Error("want the expired result to be removed, got:", n)
+	}
+}
@@ -0,0 +1,142 @@
+package boil
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+)
+
+// Router is implemented by executors that send read-only queries to a
+// different connection than writes, like DBPool.
+//
+// The queries built by generated models for All, One, Count, Exists and eager
+// loading are sent to ReadExecutor unless they use qm.UsePrimary or qm.For.
+// Everything else, including raw queries, Find and transactions, is sent to
+// WriteExecutor.
+type Router interface {
+	ReadExecutor() Executor
+	WriteExecutor() Executor
+}
+
+// RouteRead returns the executor exec sends read-only queries to, or exec
+// itself if it does not route them.
+func RouteRead(exec Executor) Executor {
+	if r, ok := exec.(Router); ok {
+		return r.ReadExecutor()
+	}
+	return exec
+}
+
+// RouteWrite returns the executor exec sends writes to, or exec itself if it
+// does not route them.
+func RouteWrite(exec Executor) Executor {
+	if r, ok := exec.(Router); ok {
+		return r.WriteExecutor()
+	}
+	return exec
+}
+
+// routeContext routes exec and keeps the result a ContextExecutor
+func routeContext(exec ContextExecutor, route func(Executor) Executor) ContextExecutor {
+	if routed, ok := route(exec).(ContextExecutor); ok {
+		return routed
+	}
+	return exec
+}
+
+// DBPool splits reads and writes between a primary database and its read
+// replicas. Read-only queries of the generated models are sent to the
+// replicas in turn, everything else to the primary.
+//
+// A DBPool can be used anywhere a *sql.DB is, including boil.SetDB. Used
+// directly as an executor it runs every query on the primary.
+type DBPool struct {
+	primary  *sql.DB
+	replicas []*sql.DB
+	next     uint32
+}
+
+var (
+	_ ContextExecutor = (*DBPool)(nil)
+	_ ContextBeginner = (*DBPool)(nil)
+	_ Router          = (*DBPool)(nil)
+)
+
+// NewDBPool creates a pool that writes to primary and reads from replicas,
+// reads go to the primary when there are no replicas.
+func NewDBPool(primary *sql.DB, replicas ...*sql.DB) *DBPool {
+	return &DBPool{
+		primary:  primary,
+		replicas: replicas,
+	}
+}
+
+// Primary returns the primary database
+func (p *DBPool) Primary() *sql.DB {
+	return p.primary
+}
+
+// Replica returns the next replica in turn, or the primary if there are
+// no replicas
+func (p *DBPool) Replica() *sql.DB {
+	if len(p.replicas) == 0 {
+		return p.primary
+	}
+
+	n := atomic.AddUint32(&p.next, 1)
+	return p.replicas[(n-1)%uint32(len(p.replicas))]
+}
+
+// ReadExecutor implements Router, it returns the next replica
+func (p *DBPool) ReadExecutor() Executor {
+	return p.Replica()
+}
+
+// WriteExecutor implements Router, it returns the primary
+func (p *DBPool) WriteExecutor() Executor {
+	return p.primary
+}
+
+// Exec runs the query on the primary
+func (p *DBPool) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return p.primary.Exec(query, args...)
+}
+
+// Query runs the query on the primary
+func (p *DBPool) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return p.primary.Query(query, args...)
+}
+
+// QueryRow runs the query on the primary
+func (p *DBPool) QueryRow(query string, args ...interface{}) *sql.Row {
+	return p.primary.QueryRow(query, args...)
+}
+
+// ExecContext runs the query on the primary
+func (p *DBPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return p.primary.ExecContext(ctx, query, args...)
+}
+
+// QueryContext runs the query on the primary
+func (p *DBPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return p.primary.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext runs the query on the primary
+func (p *DBPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return p.primary.QueryRowContext(ctx, query, args...)
+}
+
+// Begin starts a transaction on the primary
+func (p *DBPool) Begin() (*sql.Tx, error) {
+	return p.primary.Begin()
+}
+
+// BeginTx starts a transaction on the primary
+func (p *DBPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return p.primary.BeginTx(ctx, opts)
+}
+
+func (p *DBPool) unwrap() Executor {
+	return p.primary
+}
@@ -0,0 +1,95 @@
+package boil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestDBPool(t *testing.T) {
+	t.Parallel()
+
+	primary, primaryMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer primary.Close()
+	replica1, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replica1.Close()
+	replica2, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replica2.Close()
+
+	pool := NewDBPool(primary, replica1, replica2)
+
+	if got := RouteRead(pool); got != replica1 {
+		t.Error("want the first replica")
+	}
+	if got := RouteRead(pool); got != replica2 {
+		t.Error("want the second replica")
+	}
+	if got := RouteRead(pool); got != replica1 {
+		t.Error("want the first replica again")
+	}
+	if got := RouteWrite(pool); got != primary {
+		t.Error("want the primary")
+	}
+
+	primaryMock.ExpectExec("update pilots").WillReturnResult(sqlmock.NewResult(0, 1))
+	if _, err := pool.ExecContext(context.Background(), "update pilots"); err != nil {
+		t.Error(err)
+	}
+	if err := primaryMock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	if got := NewDBPool(primary).Replica(); got != primary {
+		t.Error("want reads to go to the primary without replicas")
+	}
+	if got := RouteRead(primary); got != primary {
+		t.Error("want an executor that does not route to be returned as is")
+	}
+}
+
+func TestRouteWrappedExecutor(t *testing.T) {
+	primary, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer primary.Close()
+	replica, replicaMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replica.Close()
+
+	tracer := &testTracer{}
+	SetQueryTracer(tracer)
+	defer SetQueryTracer(nil)
+
+	exec := TraceExecutor(NewDBPool(primary, replica), "pilots")
+	read, ok := RouteRead(exec).(ContextExecutor)
+	if !ok {
+		t.Fatal("want a ContextExecutor")
+	}
+
+	replicaMock.ExpectQuery("select").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	rows, err := read.QueryContext(context.Background(), "select * from pilots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows.Close()
+
+	if err := replicaMock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+	if len(tracer.tables) != 1 || tracer.tables[0] != "pilots" {
+		t.Error("want the read to still be traced for pilots, got:", tracer.tables)
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/volatiletech/sqlboiler/v4/boil/prometheus
+
+go 1.16
+
+require (
+	github.com/prometheus/client_golang v1.11.1
+	github.com/volatiletech/sqlboiler/v4 v4.14.2
+)
+
+replace github.com/volatiletech/sqlboiler/v4 => ../..
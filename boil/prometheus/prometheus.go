@@ -0,0 +1,69 @@
+// Package prometheus records the queries of models generated with
+// --with-metrics as prometheus metrics. It is a module of its own requiring
+// client_golang, so the prometheus client is not a dependency of sqlboiler:
+//
+//	go get github.com/volatiletech/sqlboiler/v4/boil/prometheus
+package prometheus
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// Metrics implements boil.QueryMetrics with a counter of queries and a
+// histogram of their latency per table and operation.
+type Metrics struct {
+	queries  *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+var _ boil.QueryMetrics = (*Metrics)(nil)
+
+// New creates the metrics and registers them with reg
+func New(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		queries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "sqlboiler_queries_total",
+			Help: "Number of queries run by the generated models.",
+		}, []string{"table", "operation", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "sqlboiler_query_duration_seconds",
+			Help:    "Latency of the queries run by the generated models.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"table", "operation"}),
+	}
+
+	if err := reg.Register(m.queries); err != nil {
+		return nil, err
+	}
+	if err := reg.Register(m.duration); err != nil {
+		reg.Unregister(m.queries)
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// MustNew creates the metrics and registers them with reg, it panics if they
+// cannot be registered
+func MustNew(reg prometheus.Registerer) *Metrics {
+	m, err := New(reg)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+// ObserveQuery counts the query as ok or error, and records its duration
+func (m *Metrics) ObserveQuery(table, operation string, took time.Duration, err error) {
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+
+	m.queries.WithLabelValues(table, operation, status).Inc()
+	m.duration.WithLabelValues(table, operation).Observe(took.Seconds())
+}
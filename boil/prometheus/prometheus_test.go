@@ -0,0 +1,38 @@
+package prometheus
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestObserveQuery(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	m, err := New(reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.ObserveQuery("pilots", "insert", time.Millisecond, nil)
+	m.ObserveQuery("pilots", "insert", time.Millisecond, errors.New("failed"))
+	m.ObserveQuery("jets", "all", time.Millisecond, nil)
+
+	if got := testutil.ToFloat64(m.queries.WithLabelValues("pilots", "insert", "ok")); got != 1 {
+		t.Error("want 1 ok pilots insert, got:", got)
+	}
+	if got := testutil.ToFloat64(m.queries.WithLabelValues("pilots", "insert", "error")); got != 1 {
+		t.Error("want 1 failed pilots insert, got:", got)
+	}
+	if got := testutil.CollectAndCount(m.duration); got != 2 {
+		t.Error("want a histogram for pilots insert and jets all, got:", got)
+	}
+
+	if _, err := New(reg); err == nil {
+		t.Error("expected registering the metrics twice to fail")
+	}
+}
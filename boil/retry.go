@@ -0,0 +1,175 @@
+package boil
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"time"
+)
+
+// RetryOptions configures how WithRetry and RetryTx retry serialization
+// failures and deadlocks.
+type RetryOptions struct {
+	// MaxAttempts is how many times an operation is tried, 3 if zero
+	MaxAttempts int
+	// Backoff is the wait before the first retry, it doubles for every
+	// retry after that. 10ms if zero
+	Backoff time.Duration
+	// MaxBackoff caps the wait between two attempts, 1s if zero
+	MaxBackoff time.Duration
+}
+
+func (r RetryOptions) withDefaults() RetryOptions {
+	if r.MaxAttempts <= 0 {
+		r.MaxAttempts = 3
+	}
+	if r.Backoff <= 0 {
+		r.Backoff = 10 * time.Millisecond
+	}
+	if r.MaxBackoff <= 0 {
+		r.MaxBackoff = time.Second
+	}
+	return r
+}
+
+// retry calls fn until it succeeds, fails with an error that is not
+// retryable, runs out of attempts or ctx is done.
+func (r RetryOptions) retry(ctx context.Context, fn func() error) error {
+	r = r.withDefaults()
+
+	backoff := r.Backoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= r.MaxAttempts || !IsRetryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		if backoff *= 2; backoff > r.MaxBackoff {
+			backoff = r.MaxBackoff
+		}
+	}
+}
+
+// IsRetryable reports whether err is a serialization failure or deadlock
+// that goes away when the operation is tried again: postgres 40001 and
+// 40P01, mysql 1213 and mssql 1205.
+func IsRetryable(err error) bool {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		switch v := e.(type) {
+		case interface{ SQLState() string }:
+			state := v.SQLState()
+			return state == "40001" || state == "40P01"
+		case interface{ SQLErrorNumber() int32 }:
+			return v.SQLErrorNumber() == 1205
+		}
+
+		val := reflect.Indirect(reflect.ValueOf(e))
+		if val.Kind() != reflect.Struct {
+			continue
+		}
+		if typ := val.Type(); typ.PkgPath() == "github.com/go-sql-driver/mysql" && typ.Name() == "MySQLError" {
+			return uintField(val, "Number") == 1213
+		}
+	}
+
+	return false
+}
+
+// WithRetry wraps exec so that statements failing with a serialization
+// failure or deadlock are retried with exponential backoff.
+//
+// Only statements that run on their own can be retried, use it with a
+// *sql.DB. In postgres a failed statement aborts its transaction, so
+// transactions have to be retried as a whole with RetryTx.
+func WithRetry(exec ContextExecutor, opts RetryOptions) ContextExecutor {
+	return retryExecutor{exec: exec, opts: opts}
+}
+
+type retryExecutor struct {
+	exec ContextExecutor
+	opts RetryOptions
+}
+
+func (r retryExecutor) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return r.ExecContext(context.Background(), query, args...)
+}
+
+func (r retryExecutor) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return r.QueryContext(context.Background(), query, args...)
+}
+
+func (r retryExecutor) QueryRow(query string, args ...interface{}) *sql.Row {
+	return r.QueryRowContext(context.Background(), query, args...)
+}
+
+func (r retryExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	var res sql.Result
+	err := r.opts.retry(ctx, func() (err error) {
+		res, err = r.exec.ExecContext(ctx, query, args...)
+		return err
+	})
+	return res, err
+}
+
+func (r retryExecutor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := r.opts.retry(ctx, func() (err error) {
+		rows, err = r.exec.QueryContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+func (r retryExecutor) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	var row *sql.Row
+	_ = r.opts.retry(ctx, func() error {
+		row = r.exec.QueryRowContext(ctx, query, args...)
+		return row.Err()
+	})
+	return row
+}
+
+// ReadExecutor implements Router, reads of a routed executor are still
+// retried
+func (r retryExecutor) ReadExecutor() Executor {
+	return retryExecutor{exec: routeContext(r.exec, RouteRead), opts: r.opts}
+}
+
+// WriteExecutor implements Router, writes of a routed executor are still
+// retried
+func (r retryExecutor) WriteExecutor() Executor {
+	return retryExecutor{exec: routeContext(r.exec, RouteWrite), opts: r.opts}
+}
+
+func (r retryExecutor) unwrap() Executor {
+	return r.exec
+}
+
+// RetryTx runs fn in a transaction and commits it. When fn or the commit
+// fails with a serialization failure or deadlock the transaction is rolled
+// back and fn is run again in a new one, with exponential backoff. fn may be
+// called more than once so it must not have side effects outside of tx.
+func RetryTx(ctx context.Context, db ContextBeginner, txOpts *sql.TxOptions, opts RetryOptions, fn func(tx ContextTransactor) error) error {
+	return opts.retry(ctx, func() error {
+		tx, err := db.BeginTx(ctx, txOpts)
+		if err != nil {
+			return err
+		}
+
+		if err = fn(tx); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		return tx.Commit()
+	})
+}
@@ -0,0 +1,139 @@
+package boil
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+type flakyExecutor struct {
+	ContextExecutor
+
+	errs  []error
+	calls int
+}
+
+func (f *flakyExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	f.calls++
+	if len(f.errs) == 0 {
+		return nil, nil
+	}
+	err := f.errs[0]
+	f.errs = f.errs[1:]
+	return nil, err
+}
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&pq.Error{Code: "40001"}, true},
+		{&pq.Error{Code: "40P01"}, true},
+		{&pq.Error{Code: "23505"}, false},
+		{&mysql.MySQLError{Number: 1213}, true},
+		{&mysql.MySQLError{Number: 1062}, false},
+		{fmt.Errorf("models: unable to update pilots: %w", &pq.Error{Code: "40001"}), true},
+		{sql.ErrNoRows, false},
+		{nil, false},
+	}
+
+	for i, test := range tests {
+		if got := IsRetryable(test.err); got != test.want {
+			t.Errorf("%d) want %t, got %t for: %v", i, test.want, got, test.err)
+		}
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	t.Parallel()
+
+	serialization := &pq.Error{Code: "40001"}
+	opts := RetryOptions{MaxAttempts: 3, Backoff: time.Microsecond}
+
+	exec := &flakyExecutor{errs: []error{serialization, serialization}}
+	if _, err := WithRetry(exec, opts).ExecContext(context.Background(), "update pilots"); err != nil {
+		t.Error(err)
+	}
+	if exec.calls != 3 {
+		t.Error("want 3 calls, got:", exec.calls)
+	}
+
+	exec = &flakyExecutor{errs: []error{serialization, serialization, serialization}}
+	if _, err := WithRetry(exec, opts).ExecContext(context.Background(), "update pilots"); err != serialization {
+		t.Error("want the last error after running out of attempts, got:", err)
+	}
+	if exec.calls != 3 {
+		t.Error("want 3 calls, got:", exec.calls)
+	}
+
+	unique := &pq.Error{Code: "23505"}
+	exec = &flakyExecutor{errs: []error{unique}}
+	if _, err := WithRetry(exec, opts).ExecContext(context.Background(), "update pilots"); err != unique {
+		t.Error("want the error that cannot be retried, got:", err)
+	}
+	if exec.calls != 1 {
+		t.Error("want 1 call, got:", exec.calls)
+	}
+}
+
+func TestRetryTx(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("update pilots").WillReturnError(&pq.Error{Code: "40001"})
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec("update pilots").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	calls := 0
+	err = RetryTx(context.Background(), db, nil, RetryOptions{Backoff: time.Microsecond}, func(tx ContextTransactor) error {
+		calls++
+		_, err := tx.ExecContext(context.Background(), "update pilots")
+		return err
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if calls != 2 {
+		t.Error("want the transaction to run twice, got:", calls)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	calls = 0
+	errFailed := errors.New("failed")
+	err = RetryTx(context.Background(), db, nil, RetryOptions{}, func(tx ContextTransactor) error {
+		calls++
+		return errFailed
+	})
+	if err != errFailed {
+		t.Error("want the error that cannot be retried, got:", err)
+	}
+	if calls != 1 {
+		t.Error("want the transaction to run once, got:", calls)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
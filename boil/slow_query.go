@@ -0,0 +1,110 @@
+package boil
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// SlowQuery is a generated query that took longer than the threshold of the
+// SlowQueryOptions
+type SlowQuery struct {
+	Query string
+	// Args are the arguments of the query, each of them is "[redacted]" when
+	// the options redact them
+	Args []interface{}
+	Took time.Duration
+	Err  error
+
+	// Table and Operation are the table and the model operation the query ran
+	// for, like pilots and update_all. The operation is only known for models
+	// generated with --with-metrics and the table for models generated with
+	// --with-metrics or --with-otel, they are empty otherwise.
+	Table     string
+	Operation string
+}
+
+// SlowQueryOptions configures the reporting of the generated queries taking
+// longer than Threshold. It is done at runtime and needs no instrumentation
+// besides the one of the generated queries.
+type SlowQueryOptions struct {
+	// Threshold is the duration above which a query is slow
+	Threshold time.Duration
+	// Hook is called with every slow query, when it is nil the slow queries
+	// are written to Writer
+	Hook func(ctx context.Context, q SlowQuery)
+	// Writer is where the slow queries are written when there is no Hook,
+	// os.Stderr if nil
+	Writer io.Writer
+	// RedactArgs replaces the arguments of the slow queries so they do not
+	// leak sensitive data
+	RedactArgs bool
+}
+
+// currentSlowQueries are the options of the slow query reporting, by
+// default nothing is reported
+var currentSlowQueries *SlowQueryOptions
+
+// SetSlowQueryOptions sets the options of the slow query reporting, nil
+// options disable it.
+func SetSlowQueryOptions(opts *SlowQueryOptions) {
+	currentSlowQueries = opts
+}
+
+// GetSlowQueryOptions retrieves the options of the slow query reporting
+func GetSlowQueryOptions() *SlowQueryOptions {
+	return currentSlowQueries
+}
+
+// reportSlowQuery reports the query run on exec if it took longer than the
+// threshold
+func reportSlowQuery(ctx context.Context, exec interface{}, query string, args []interface{}, took time.Duration, err error) {
+	opts := currentSlowQueries
+	if opts == nil || took <= opts.Threshold {
+		return
+	}
+
+	q := SlowQuery{Query: query, Args: args, Took: took, Err: err}
+	switch e := exec.(type) {
+	case instrumentedExecutor:
+		q.Table, q.Operation = e.table, e.operation
+	case instrumentedContextExecutor:
+		q.Table, q.Operation = e.table, e.operation
+	}
+	if opts.RedactArgs {
+		q.Args = make([]interface{}, len(args))
+		for i := range q.Args {
+			q.Args[i] = "[redacted]"
+		}
+	}
+
+	if opts.Hook != nil {
+		opts.Hook(ctx, q)
+		return
+	}
+
+	writer := opts.Writer
+	if writer == nil {
+		writer = os.Stderr
+	}
+	writeSlowQuery(writer, q)
+}
+
+func writeSlowQuery(writer io.Writer, q SlowQuery) {
+	origin := ""
+	switch {
+	case len(q.Operation) != 0:
+		origin = fmt.Sprintf(" on %s (%s)", q.Table, q.Operation)
+	case len(q.Table) != 0:
+		origin = fmt.Sprintf(" on %s", q.Table)
+	}
+
+	fmt.Fprintf(writer, "-- slow query%s took %s\n", origin, q.Took)
+	fmt.Fprintln(writer, q.Query)
+	fmt.Fprintln(writer, q.Args)
+	if q.Err != nil {
+		fmt.Fprintf(writer, "-- error: %v\n", q.Err)
+	}
+}
@@ -0,0 +1,83 @@
+package boil
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+)
+
+type slowExecutor struct {
+	ContextExecutor
+
+	took time.Duration
+}
+
+func (s slowExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	time.Sleep(s.took)
+	return nil, nil
+}
+
+func TestSlowQueryHook(t *testing.T) {
+	var got []SlowQuery
+	SetSlowQueryOptions(&SlowQueryOptions{
+		Threshold:  time.Millisecond,
+		Hook:       func(ctx context.Context, q SlowQuery) { got = append(got, q) },
+		RedactArgs: true,
+	})
+	defer SetSlowQueryOptions(nil)
+
+	ctx := WithDebug(context.Background(), false)
+	if _, err := DebugExecContext(ctx, slowExecutor{}, "delete from pilots where id = ?", 1); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected no slow query, got: %v", got)
+	}
+
+	exec := MeasureContextExecutor(slowExecutor{took: 5 * time.Millisecond}, "pilots", "delete")
+	if _, err := DebugExecContext(ctx, exec, "delete from pilots where id = ?", 2); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected a slow query, got: %v", got)
+	}
+
+	q := got[0]
+	if q.Query != "delete from pilots where id = ?" || q.Took < 5*time.Millisecond {
+		t.Errorf("wrong query: %s %s", q.Query, q.Took)
+	}
+	if len(q.Args) != 1 || q.Args[0] != "[redacted]" {
+		t.Error("args were not redacted:", q.Args)
+	}
+	if q.Table != "pilots" || q.Operation != "delete" {
+		t.Errorf("wrong origin: %s %s", q.Table, q.Operation)
+	}
+}
+
+func TestSlowQueryWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	SetSlowQueryOptions(&SlowQueryOptions{Threshold: time.Millisecond, Writer: buf})
+	defer SetSlowQueryOptions(nil)
+
+	exec := TraceExecutor(slowExecutor{took: 5 * time.Millisecond}, "jets")
+	if _, err := DebugExecContext(context.Background(), exec, "delete from jets where id = ?", 3); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected duration, query and args lines, got: %q", buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "-- slow query on jets took ") {
+		t.Error("wrong duration:", lines[0])
+	}
+	if lines[1] != "delete from jets where id = ?" {
+		t.Error("wrong query:", lines[1])
+	}
+	if lines[2] != "[3]" {
+		t.Error("wrong args:", lines[2])
+	}
+}
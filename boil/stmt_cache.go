@@ -0,0 +1,156 @@
+package boil
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// ContextPreparer can prepare statements on top of executing context-aware
+// queries, *sql.DB and *sql.Tx both conform to it.
+type ContextPreparer interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+
+	ContextExecutor
+}
+
+// StmtCache is an executor that prepares every statement it runs once and
+// reuses the prepared statement whenever the same SQL runs again, which saves
+// a round trip to the database on hot paths.
+//
+// The statements stay prepared until Close is called. Statements prepared on
+// a *sql.Tx can only be used in that transaction, so a cache on a
+// transaction must be closed before it ends.
+type StmtCache struct {
+	db   ContextPreparer
+	size int
+
+	mu     sync.Mutex
+	stmts  map[string]*sql.Stmt
+	closed bool
+}
+
+var _ ContextExecutor = (*StmtCache)(nil)
+
+// NewStmtCache creates a statement cache on db that keeps at most size
+// prepared statements, or any number of them if size is 0. Once the cache is
+// full, statements that are not in it run without being prepared.
+func NewStmtCache(db ContextPreparer, size int) *StmtCache {
+	return &StmtCache{
+		db:    db,
+		size:  size,
+		stmts: make(map[string]*sql.Stmt),
+	}
+}
+
+// Len returns the number of prepared statements in the cache
+func (c *StmtCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.stmts)
+}
+
+// Close closes all the prepared statements, queries that are still running
+// on them fail. Queries run after Close are not prepared anymore.
+func (c *StmtCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var err error
+	for query, stmt := range c.stmts {
+		if closeErr := stmt.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		delete(c.stmts, query)
+	}
+	c.closed = true
+
+	return err
+}
+
+// stmt returns the prepared statement for query, preparing it if it is not
+// in the cache yet. It returns nil when query should run unprepared.
+func (c *StmtCache) stmt(ctx context.Context, query string) (*sql.Stmt, error) {
+	c.mu.Lock()
+	stmt, ok := c.stmts[query]
+	full := c.closed || (c.size > 0 && len(c.stmts) >= c.size)
+	c.mu.Unlock()
+	if ok || full {
+		return stmt, nil
+	}
+
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Another goroutine may have prepared the same query in the meantime, or
+	// closed the cache
+	if cached, ok := c.stmts[query]; ok || c.closed {
+		_ = stmt.Close()
+		return cached, nil
+	}
+	c.stmts[query] = stmt
+
+	return stmt, nil
+}
+
+// Exec runs the query as a prepared statement
+func (c *StmtCache) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.ExecContext(context.Background(), query, args...)
+}
+
+// Query runs the query as a prepared statement
+func (c *StmtCache) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.QueryContext(context.Background(), query, args...)
+}
+
+// QueryRow runs the query as a prepared statement
+func (c *StmtCache) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.QueryRowContext(context.Background(), query, args...)
+}
+
+// ExecContext runs the query as a prepared statement
+func (c *StmtCache) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	stmt, err := c.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if stmt == nil {
+		return c.db.ExecContext(ctx, query, args...)
+	}
+
+	return stmt.ExecContext(ctx, args...)
+}
+
+// QueryContext runs the query as a prepared statement
+func (c *StmtCache) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	stmt, err := c.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if stmt == nil {
+		return c.db.QueryContext(ctx, query, args...)
+	}
+
+	return stmt.QueryContext(ctx, args...)
+}
+
+// QueryRowContext runs the query as a prepared statement. When the query
+// cannot be prepared it runs unprepared so the error is returned by the row.
+func (c *StmtCache) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	stmt, err := c.stmt(ctx, query)
+	if err != nil || stmt == nil {
+		return c.db.QueryRowContext(ctx, query, args...)
+	}
+
+	return stmt.QueryRowContext(ctx, args...)
+}
+
+func (c *StmtCache) unwrap() Executor {
+	return c.db
+}
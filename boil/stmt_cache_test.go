@@ -0,0 +1,54 @@
+package boil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestStmtCache(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	prep := mock.ExpectPrepare("update pilots").WillBeClosed()
+	prep.ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	prep.ExpectExec().WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("update jets").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	cache := NewStmtCache(db, 1)
+	ctx := context.Background()
+	if _, err := cache.ExecContext(ctx, "update pilots", 1); err != nil {
+		t.Error(err)
+	}
+	if _, err := cache.ExecContext(ctx, "update pilots", 2); err != nil {
+		t.Error(err)
+	}
+	if _, err := cache.ExecContext(ctx, "update jets"); err != nil {
+		t.Error(err)
+	}
+	if n := cache.Len(); n != 1 {
+		t.Error("want one statement to be prepared in a full cache, got:", n)
+	}
+
+	if err := cache.Close(); err != nil {
+		t.Error(err)
+	}
+	if n := cache.Len(); n != 0 {
+		t.Error("want no statements after Close, got:", n)
+	}
+
+	mock.ExpectExec("update pilots").WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+	if _, err := cache.ExecContext(ctx, "update pilots", 3); err != nil {
+		t.Error(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
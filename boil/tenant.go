@@ -0,0 +1,40 @@
+package boil
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoTenant is returned by the generated queries of the tables with the
+// tenant column when their context has no tenant, see WithTenant.
+var ErrNoTenant = errors.New("boil: no tenant in the context")
+
+// ErrOtherTenant is returned by the generated inserts, updates, upserts and
+// deletes of rows whose tenant column is not the tenant of their context.
+var ErrOtherTenant = errors.New("boil: the row belongs to another tenant")
+
+// WithTenant modifies a context to scope the generated queries of the tables
+// with the tenant column to the tenant, the value of the column. The tenant
+// must be of the type of the column, or a string of it.
+func WithTenant(ctx context.Context, tenant interface{}) context.Context {
+	return context.WithValue(ctx, ctxTenant, tenant)
+}
+
+// TenantFrom returns the tenant of the context, false if it has none
+func TenantFrom(ctx context.Context) (interface{}, bool) {
+	tenant := ctx.Value(ctxTenant)
+	return tenant, tenant != nil
+}
+
+// SkipTenancy modifies a context to run the generated queries of the tables
+// with the tenant column over the rows of every tenant, for jobs spanning
+// tenants and for tests.
+func SkipTenancy(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxSkipTenancy, true)
+}
+
+// TenancyIsSkipped returns true if the context skips tenancy
+func TenancyIsSkipped(ctx context.Context) bool {
+	skip := ctx.Value(ctxSkipTenancy)
+	return skip != nil && skip.(bool)
+}
@@ -0,0 +1,37 @@
+package boil
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithTenant(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	if _, ok := TenantFrom(ctx); ok {
+		t.Error("there should be no tenant")
+	}
+
+	ctx = WithTenant(ctx, int64(5))
+
+	tenant, ok := TenantFrom(ctx)
+	if !ok || tenant.(int64) != 5 {
+		t.Errorf("want tenant 5, got: %v", tenant)
+	}
+}
+
+func TestSkipTenancy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	if TenancyIsSkipped(ctx) {
+		t.Error("it should not be skipped")
+	}
+
+	ctx = SkipTenancy(ctx)
+
+	if !TenancyIsSkipped(ctx) {
+		t.Error("it should be skipped")
+	}
+}
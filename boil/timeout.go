@@ -0,0 +1,20 @@
+package boil
+
+import "time"
+
+// queryTimeout is the timeout of the generated queries without one of their
+// own, by default they have none
+var queryTimeout time.Duration
+
+// SetQueryTimeout sets the timeout of the generated queries that do not set
+// their own with qm.Timeout, zero disables it. See qm.Timeout for how it is
+// applied.
+func SetQueryTimeout(timeout time.Duration) {
+	queryTimeout = timeout
+}
+
+// GetQueryTimeout retrieves the timeout of the generated queries that do not
+// set their own
+func GetQueryTimeout() time.Duration {
+	return queryTimeout
+}
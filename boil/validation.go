@@ -0,0 +1,48 @@
+package boil
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// ErrValidation is returned by the generated Validate methods, and the
+// inserts, updates and upserts calling them, when a column holds a value the
+// database would reject, before the query is run.
+type ErrValidation struct {
+	Table  string
+	Column string
+	// Reason tells what is wrong with the value, like "is longer than 10
+	// characters"
+	Reason string
+}
+
+// Error returns the table, column and reason of the error
+func (e *ErrValidation) Error() string {
+	return fmt.Sprintf("boil: %s.%s %s", e.Table, e.Column, e.Reason)
+}
+
+// ValidateLength returns an *ErrValidation when s has more than max
+// characters, the length of the text column it is the value of.
+func ValidateLength(table, column, s string, max int) error {
+	if n := utf8.RuneCountInString(s); n > max {
+		return &ErrValidation{Table: table, Column: column, Reason: fmt.Sprintf("is longer than %d characters", max)}
+	}
+	return nil
+}
+
+// ValidateDecimal returns an *ErrValidation when d has more digits before the
+// decimal point than the decimal column of the precision and scale it is the
+// value of allows. The digits after the scale are left to the database to
+// round, and nil is valid.
+func ValidateDecimal(table, column string, d *decimal.Big, precision, scale int) error {
+	if d == nil || !d.IsFinite() || d.Sign() == 0 {
+		return nil
+	}
+
+	if digits := d.Precision() - d.Scale(); digits > precision-scale {
+		return &ErrValidation{Table: table, Column: column, Reason: fmt.Sprintf("has more than %d digits before the decimal point", precision-scale)}
+	}
+	return nil
+}
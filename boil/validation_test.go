@@ -0,0 +1,62 @@
+package boil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestValidateLength(t *testing.T) {
+	t.Parallel()
+
+	if err := ValidateLength("pilots", "name", "héllo", 5); err != nil {
+		t.Errorf("want characters counted, got: %v", err)
+	}
+
+	err := ValidateLength("pilots", "name", "hello!", 5)
+	var validation *ErrValidation
+	if !errors.As(err, &validation) {
+		t.Fatalf("want an *ErrValidation, got: %#v", err)
+	}
+	if validation.Table != "pilots" || validation.Column != "name" {
+		t.Errorf("want the table and column, got: %#v", validation)
+	}
+	if got := err.Error(); got != "boil: pilots.name is longer than 5 characters" {
+		t.Errorf("wrong message: %s", got)
+	}
+}
+
+func TestValidateDecimal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{"9.9", true},
+		{"-9.9", true},
+		{"0.05", true},
+		{"1.25", true},
+		{"0", true},
+		{"10", false},
+		{"10.0", false},
+		{"-12.5", false},
+		{"1E1", false},
+	}
+
+	for _, test := range tests {
+		d, ok := new(decimal.Big).SetString(test.value)
+		if !ok {
+			t.Fatalf("cannot parse %s", test.value)
+		}
+		err := ValidateDecimal("jets", "price", d, 2, 1)
+		if (err == nil) != test.valid {
+			t.Errorf("%s: want valid %t, got: %v", test.value, test.valid, err)
+		}
+	}
+
+	if err := ValidateDecimal("jets", "price", nil, 2, 1); err != nil {
+		t.Errorf("want nil valid, got: %v", err)
+	}
+}
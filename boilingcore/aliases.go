@@ -12,6 +12,28 @@ import (
 // Aliases defines aliases for the generation run
 type Aliases struct {
 	Tables map[string]TableAlias `toml:"tables,omitempty" json:"tables,omitempty"`
+
+	// ReservedSuffix is appended to the names of the columns colliding with
+	// the fields and methods of the models, "_" when empty
+	ReservedSuffix string `toml:"reserved_suffix,omitempty" json:"reserved_suffix,omitempty"`
+	// ResolveCollisions appends the reserved suffix to the names of the tables
+	// and columns colliding with others instead of failing with a report
+	ResolveCollisions bool `toml:"resolve_collisions,omitempty" json:"resolve_collisions,omitempty"`
+}
+
+// reservedColumnAliases are the names of the fields and methods of the
+// models, the columns named like them get the reserved suffix
+var reservedColumnAliases = map[string]struct{}{
+	"R": {}, "L": {},
+	"Insert": {}, "InsertG": {}, "InsertP": {}, "InsertGP": {},
+	"Update": {}, "UpdateG": {}, "UpdateP": {}, "UpdateGP": {}, "UpdateBuilder": {},
+	"Upsert": {}, "UpsertG": {}, "UpsertP": {}, "UpsertGP": {},
+	"Delete": {}, "DeleteG": {}, "DeleteP": {}, "DeleteGP": {},
+	"Reload": {}, "ReloadG": {}, "ReloadP": {}, "ReloadGP": {},
+	"Exists": {}, "Copy": {}, "Equal": {}, "EqualWith": {}, "Diff": {},
+	"ToMap": {}, "FromMap": {}, "ToCSVRecord": {}, "FromCSVRecord": {},
+	"ToProto": {}, "FromProto": {}, "Validate": {}, "Masked": {}, "Scrub": {},
+	"Audits": {}, "Versions": {}, "TableName": {}, "GetID": {},
 }
 
 // TableAlias defines the spellings for a table name in Go
@@ -54,18 +76,19 @@ func FillAliases(a *Aliases, tables []drivers.Table) {
 		}
 
 		table := a.Tables[t.Name]
+		name := transliterate(t.Name)
 
 		if len(table.UpPlural) == 0 {
-			table.UpPlural = strmangle.TitleCase(strmangle.Plural(t.Name))
+			table.UpPlural = strmangle.TitleCase(strmangle.Plural(name))
 		}
 		if len(table.UpSingular) == 0 {
-			table.UpSingular = strmangle.TitleCase(strmangle.Singular(t.Name))
+			table.UpSingular = strmangle.TitleCase(strmangle.Singular(name))
 		}
 		if len(table.DownPlural) == 0 {
-			table.DownPlural = strmangle.CamelCase(strmangle.Plural(t.Name))
+			table.DownPlural = strmangle.CamelCase(strmangle.Plural(name))
 		}
 		if len(table.DownSingular) == 0 {
-			table.DownSingular = strmangle.CamelCase(strmangle.Singular(t.Name))
+			table.DownSingular = strmangle.CamelCase(strmangle.Singular(name))
 		}
 
 		if table.Columns == nil {
@@ -77,7 +100,7 @@ func FillAliases(a *Aliases, tables []drivers.Table) {
 
 		for _, c := range t.Columns {
 			if _, ok := table.Columns[c.Name]; !ok {
-				table.Columns[c.Name] = strmangle.TitleCase(c.Name)
+				table.Columns[c.Name] = goColumnAlias(c.Name, a.reservedSuffix())
 			}
 
 			r, _ := utf8.DecodeRuneInString(table.Columns[c.Name])
@@ -166,6 +189,26 @@ func FillAliases(a *Aliases, tables []drivers.Table) {
 	}
 }
 
+// goColumnAlias returns the Go name of a column, the title case of its
+// transliterated name with the suffix when it collides with a field or method
+// of the models
+func goColumnAlias(name, suffix string) string {
+	alias := titleCase(name)
+	if _, ok := reservedColumnAliases[alias]; !ok {
+		return alias
+	}
+	return alias + suffix
+}
+
+// reservedSuffix returns the suffix of the names colliding with others, "_"
+// unless the config has one
+func (a Aliases) reservedSuffix() string {
+	if len(a.ReservedSuffix) == 0 {
+		return "_"
+	}
+	return a.ReservedSuffix
+}
+
 // Table gets a table alias, panics if not found.
 func (a Aliases) Table(table string) TableAlias {
 	t, ok := a.Tables[table]
@@ -66,6 +66,45 @@ func TestAliasesTables(t *testing.T) {
 	})
 }
 
+func TestAliasesReservedColumns(t *testing.T) {
+	t.Parallel()
+
+	tables := []drivers.Table{
+		{
+			Name: "user",
+			Columns: []drivers.Column{
+				{Name: "First Name"},
+				{Name: "type"},
+				{Name: "r"},
+				{Name: "insert"},
+				{Name: "reload"},
+			},
+		},
+	}
+
+	a := Aliases{}
+	FillAliases(&a, tables)
+
+	expect := map[string]string{
+		"First Name": "FirstName",
+		"type":       "Type",
+		"r":          "R_",
+		"insert":     "Insert_",
+		"reload":     "Reload_",
+	}
+	if got := a.Tables["user"].Columns; !reflect.DeepEqual(expect, got) {
+		t.Errorf("want the reserved columns suffixed, got: %#v", got)
+	}
+
+	a = Aliases{ReservedSuffix: "Col"}
+	a.Tables = map[string]TableAlias{"user": {Columns: map[string]string{"reload": "Reload"}}}
+	FillAliases(&a, tables)
+
+	if got := a.Tables["user"].Columns; got["r"] != "RCol" || got["insert"] != "InsertCol" || got["reload"] != "Reload" {
+		t.Errorf("want the suffix of the config and the aliases of the user kept, got: %#v", got)
+	}
+}
+
 func TestAliasesRelationships(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,60 @@
+package boilingcore
+
+import (
+	"github.com/friendsofgo/errors"
+
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// auditTemplate is the singleton with the helpers writing to the audit
+// table, it names its entry in the singleton imports
+const auditTemplate = "boil_audit"
+
+// initAuditLog checks that the audited tables can be audited and sets the
+// imports of the audit helpers
+func (s *State) initAuditLog() error {
+	if len(s.Config.AuditLog.Tables) == 0 {
+		return nil
+	}
+
+	if s.Config.NoHooks {
+		return errors.New("the audit log is recorded by hooks and cannot be used with no-hooks")
+	}
+	if len(s.Config.AuditLog.Table) == 0 {
+		s.Config.AuditLog.Table = "audit_log"
+	}
+
+	for _, name := range s.Config.AuditLog.Tables {
+		found := false
+		for _, t := range s.Tables {
+			if t.Name != name {
+				continue
+			}
+			if t.IsView || t.IsJoinTable {
+				return errors.Errorf("audited table %s must be a table with a model, not a view or join table", name)
+			}
+			found = true
+			break
+		}
+		if !found {
+			return errors.Errorf("audited table %s was not found", name)
+		}
+		if name == s.Config.AuditLog.Table {
+			return errors.Errorf("audit table %s cannot be audited", name)
+		}
+	}
+
+	imps := importers.Set{
+		Standard:   importers.List{`"encoding/json"`, `"time"`},
+		ThirdParty: importers.List{`"github.com/friendsofgo/errors"`, `"github.com/volatiletech/sqlboiler/v4/boil"`},
+	}
+	if !s.Config.NoContext {
+		imps.Standard = append(imps.Standard, `"context"`)
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[auditTemplate] = imps
+
+	return nil
+}
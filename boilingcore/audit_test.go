@@ -0,0 +1,53 @@
+package boilingcore
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestInitAuditLog(t *testing.T) {
+	t.Parallel()
+
+	tables := []drivers.Table{
+		{Name: "pilots"},
+		{Name: "pilot_languages", IsJoinTable: true},
+		{Name: "pilot_view", IsView: true},
+		{Name: "audit_log"},
+	}
+
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{name: "disabled", config: Config{}},
+		{name: "table", config: Config{AuditLog: AuditLog{Tables: []string{"pilots"}}}},
+		{name: "nohooks", config: Config{NoHooks: true, AuditLog: AuditLog{Tables: []string{"pilots"}}}, wantErr: true},
+		{name: "missing", config: Config{AuditLog: AuditLog{Tables: []string{"jets"}}}, wantErr: true},
+		{name: "join table", config: Config{AuditLog: AuditLog{Tables: []string{"pilot_languages"}}}, wantErr: true},
+		{name: "view", config: Config{AuditLog: AuditLog{Tables: []string{"pilot_view"}}}, wantErr: true},
+		{name: "audit table", config: Config{AuditLog: AuditLog{Tables: []string{"audit_log"}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			s := &State{Config: &tt.config, Tables: tables}
+			err := s.initAuditLog()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %t, got: %v", tt.wantErr, err)
+			}
+			if err != nil || len(tt.config.AuditLog.Tables) == 0 {
+				return
+			}
+
+			if s.Config.AuditLog.Table != "audit_log" {
+				t.Errorf("want the default audit table, got: %q", s.Config.AuditLog.Table)
+			}
+			if _, ok := s.Config.Imports.Singleton[auditTemplate]; !ok {
+				t.Error("want imports for the audit helpers")
+			}
+		})
+	}
+}
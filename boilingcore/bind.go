@@ -0,0 +1,28 @@
+package boilingcore
+
+import (
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// bindTemplate is the singleton binding the rows of any query into tagged
+// structs, it names its entry in the singleton imports
+const bindTemplate = "boil_bind"
+
+// initBind sets the imports of the binding helpers, whose Bind takes a
+// context unless NoContext
+func (s *State) initBind() {
+	imps := importers.Set{
+		Standard: importers.List{`"database/sql"`},
+		ThirdParty: importers.List{
+			`"github.com/volatiletech/sqlboiler/v4/boil"`,
+			`"github.com/volatiletech/sqlboiler/v4/queries"`,
+		},
+	}
+	if !s.Config.NoContext {
+		imps.Standard = append(imps.Standard, `"context"`)
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[bindTemplate] = imps
+}
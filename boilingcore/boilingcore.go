@@ -38,6 +38,45 @@ type State struct {
 
 	Templates     *templateList
 	TestTemplates *templateList
+
+	CustomQueries []CustomQuery
+	Functions     []Function
+	ProtoMessages map[string]ProtoMessage
+	GraphQLTypes  map[string]GraphQLType
+	JSONSchemas   map[string]JSONSchema
+
+	TypeScriptInterfaces map[string]TypeScriptInterface
+	CSVRecords           map[string]CSVRecord
+	SeedTables           []SeedTable
+	ScrubTables          []ScrubTable
+	MaskTables           []MaskTable
+	StreamTables         []StreamTable
+	RetentionTables      []RetentionTable
+	HistoryTables        []HistoryTable
+	SearchTables         []SearchTable
+	HTTPHandlers         map[string]HTTPHandler
+	DTOMappers           []DTOMapper
+	JoinViews            []JoinView
+	Polymorphics         []Polymorphic
+	Inheritances         []Inheritance
+	Tenancy              Tenancy
+	RLS                  RLS
+
+	// Stamp is written in the header of the generated Go files
+	Stamp Stamp
+
+	// StructLayouts are the sizes of the model structs with and without the
+	// optimized layout, when it is enabled
+	StructLayouts []StructLayout
+
+	// changed are the files whose contents the generation changed
+	changed []string
+
+	// functions are the functions loaded by the driver
+	functions []drivers.Function
+	// packages are the states of the packages when tables are routed to
+	// packages of their own
+	packages []*State
 }
 
 // New creates a new state based off of the config
@@ -74,14 +113,40 @@ func New(config *Config) (*State, error) {
 		}
 	}()
 
-	if len(config.Version) > 0 {
-		noEditDisclaimer = []byte(
-			fmt.Sprintf(noEditDisclaimerFmt, " "+config.Version+" "),
-		)
+	if config.WithOTel && config.NoContext {
+		return nil, errors.New("with-otel traces queries through their context and cannot be used with no-context")
+	}
+	if config.WithGraphQL && config.NoContext {
+		return nil, errors.New("with-graphql resolvers take the context of their request and cannot be used with no-context")
+	}
+	if config.WithDataloader && config.NoContext {
+		return nil, errors.New("with-dataloader loads the batches with the context of their first model and cannot be used with no-context")
+	}
+	if config.WithGRPC && config.NoContext {
+		return nil, errors.New("with-grpc services take the context of their request and cannot be used with no-context")
+	}
+	if len(config.TenantColumn) != 0 && config.NoContext {
+		return nil, errors.New("tenant-column reads the tenant of the queries from their context and cannot be used with no-context")
+	}
+	if config.AddFactories && config.NoTests {
+		return nil, errors.New("add-factories generates the factories with the tests and cannot be used with no-tests")
+	}
+	if config.AddSeeds && config.NoExists {
+		return nil, errors.New("add-seeds checks the rows with the Exists functions and cannot be used with no-exists")
+	}
+	if config.WithGenerics {
+		if err := checkGenerics(config.OutFolder); err != nil {
+			return nil, err
+		}
 	}
 
-	s.Driver = drivers.GetDriver(config.DriverName)
+	driver, ok := drivers.LookupDriver(config.DriverName)
+	if !ok {
+		return nil, errors.Errorf("driver %q has not been registered", config.DriverName)
+	}
+	s.Driver = driver
 	s.initInflections()
+	setTransliterations(s.Config.Transliterations)
 
 	err := s.initDBInfo(config.DriverConfig)
 	if err != nil {
@@ -99,12 +164,33 @@ func New(config *Config) (*State, error) {
 	if !s.Config.NoContext {
 		s.Config.Imports.All.Standard = append(s.Config.Imports.All.Standard, `"context"`)
 		s.Config.Imports.Test.Standard = append(s.Config.Imports.Test.Standard, `"context"`)
+		if examples, ok := s.Config.Imports.TestSingleton["examples_test"]; ok {
+			examples.Standard = append(examples.Standard, `"context"`)
+			s.Config.Imports.TestSingleton["examples_test"] = examples
+		}
+	} else if s.Config.WithGenerics && !s.Config.NoHooks {
+		// The hooks of the generic finders take a context, nil without them
+		s.Config.Imports.All.Standard = append(s.Config.Imports.All.Standard, `"context"`)
 	}
 
 	if err := s.processTypeReplacements(); err != nil {
 		return nil, err
 	}
 
+	if err := s.initEncryption(); err != nil {
+		return nil, errors.Wrap(err, "unable to initialize the encrypted columns")
+	}
+
+	if err := s.loadCustomQueries(); err != nil {
+		return nil, errors.Wrap(err, "unable to load custom queries")
+	}
+
+	s.loadFunctions(s.functions)
+
+	if err := s.initAuditLog(); err != nil {
+		return nil, errors.Wrap(err, "unable to initialize the audit log")
+	}
+
 	templates, err = s.initTemplates()
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to initialize templates")
@@ -125,12 +211,187 @@ func New(config *Config) (*State, error) {
 		return nil, errors.Wrap(err, "unable to initialize aliases")
 	}
 
+	err = s.initOutbox()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize the outbox")
+	}
+
+	err = s.initTenancy()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize tenancy")
+	}
+
+	err = s.initRLS()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize row level security")
+	}
+
+	s.initInterfaces()
+
+	err = s.initProto()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize protobuf messages")
+	}
+
+	s.initDataloader()
+	s.initBind()
+
+	err = s.initGraphQL()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize GraphQL types")
+	}
+
+	err = s.initJSONSchemas()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize JSON Schemas")
+	}
+
+	err = s.initTypeScript()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize TypeScript interfaces")
+	}
+
+	err = s.initCSV()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize CSV records")
+	}
+
+	err = s.initSeeds()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize seeds")
+	}
+
+	err = s.initScrub()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize scrub")
+	}
+
+	err = s.initMasking()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize masking")
+	}
+
+	err = s.initStreaming()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize streaming")
+	}
+
+	err = s.initRetention()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize retention")
+	}
+
+	err = s.initHistory()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize history")
+	}
+
+	err = s.initSearch()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize full text search")
+	}
+
+	err = s.initHTTP()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize HTTP handlers")
+	}
+
+	err = s.initDTOs()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize DTOs")
+	}
+
+	err = s.initJoins()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize joins")
+	}
+
+	err = s.initPolymorphics()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize polymorphic associations")
+	}
+
+	err = s.initInheritances()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize the inheritances")
+	}
+
+	err = s.initMeta()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize the metadata")
+	}
+
+	err = s.initStructFieldOrder()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize the struct field order")
+	}
+
+	s.initStructLayouts()
+
+	err = s.initStamp()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize the stamp")
+	}
+
+	err = s.initPackages(templates)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize packages")
+	}
+
 	return s, nil
 }
 
 // Run executes the sqlboiler templates and outputs them to files based on the
 // state given.
 func (s *State) Run() error {
+	return s.runTables(nil)
+}
+
+// RunTables executes the templates of the named tables only, the files of the
+// other tables are left untouched. The singleton files are rendered from all
+// the tables and only written when their contents changed.
+func (s *State) RunTables(names ...string) error {
+	if len(names) == 0 {
+		return errors.New("no tables were given to generate")
+	}
+
+	only := make(map[string]bool)
+	for _, name := range names {
+		found := false
+		for _, t := range s.Tables {
+			if t.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.Errorf("table %s was not found", name)
+		}
+		only[name] = true
+	}
+
+	return s.runTables(only)
+}
+
+// runTables generates the state, or the states of its packages, with the
+// files of the tables that are in only, or of all tables when only is nil
+func (s *State) runTables(only map[string]bool) error {
+	if len(s.packages) == 0 {
+		return s.run(only)
+	}
+
+	for _, p := range s.packages {
+		if err := p.run(only); err != nil {
+			return errors.Wrapf(err, "package %s", p.Config.PkgName)
+		}
+	}
+
+	return nil
+}
+
+// run generates the tables of the state in its output folder, only the ones
+// in only unless it is nil
+func (s *State) run(only map[string]bool) error {
 	data := &templateData{
 		Tables:            s.Tables,
 		Aliases:           s.Config.Aliases,
@@ -140,6 +401,30 @@ func (s *State) Run() error {
 		AddPanic:          s.Config.AddPanic,
 		AddSoftDeletes:    s.Config.AddSoftDeletes,
 		AddEnumTypes:      s.Config.AddEnumTypes,
+		AddFactories:      s.Config.AddFactories,
+		AddSQLMockTests:   s.Config.AddSQLMockTests,
+		AddCSV:            s.Config.AddCSV,
+		AddSeeds:          s.Config.AddSeeds,
+		AddRepositories:   s.Config.AddRepositories,
+		AddImmutable:      s.Config.AddImmutable,
+		AddInterfaces:     s.Config.AddInterfaces,
+		AddDiff:           s.Config.AddDiff,
+		AddCopyEqual:      s.Config.AddCopyEqual,
+		AddMaps:           s.Config.AddMaps,
+		AddValidation:     s.Config.AddValidation,
+		WithBenchmarks:    s.Config.WithBenchmarks,
+		WithOTel:          s.Config.WithOTel,
+		WithMetrics:       s.Config.WithMetrics,
+		WithProto:         s.Config.WithProto,
+		WithGRPC:          s.Config.WithGRPC,
+		WithGraphQL:       s.Config.WithGraphQL,
+		WithJSONSchema:    s.Config.WithJSONSchema,
+		WithTypeScript:    s.Config.WithTypeScript,
+		WithHTTP:          s.Config.WithHTTP,
+		WithDocs:          s.Config.WithDocs,
+		WithGenerics:      s.Config.WithGenerics,
+		WithDataloader:    s.Config.WithDataloader,
+		WithIdentityMap:   s.Config.WithIdentityMap,
 		EnumNullPrefix:    s.Config.EnumNullPrefix,
 		NoContext:         s.Config.NoContext,
 		NoHooks:           s.Config.NoHooks,
@@ -147,6 +432,9 @@ func (s *State) Run() error {
 		NoRowsAffected:    s.Config.NoRowsAffected,
 		NoDriverTemplates: s.Config.NoDriverTemplates,
 		NoBackReferencing: s.Config.NoBackReferencing,
+		NoReload:          s.Config.NoReload,
+		NoExists:          s.Config.NoExists,
+		NoUpsert:          s.Config.NoUpsert,
 		AlwaysWrapErrors:  s.Config.AlwaysWrapErrors,
 		StructTagCasing:   s.Config.StructTagCasing,
 		TagIgnore:         make(map[string]struct{}),
@@ -158,9 +446,45 @@ func (s *State) Run() error {
 		RQ:                strmangle.QuoteCharacter(s.Dialect.RQ),
 		OutputDirDepth:    s.Config.OutputDirDepth(),
 
+		StructFieldOrder:     s.Config.StructFieldOrder,
+		GroupNullableFields:  s.Config.GroupNullableFields,
+		OptimizeStructLayout: s.Config.OptimizeStructLayout,
+
+		NoRelationshipSetters: s.Config.NoRelationshipSetters,
+
 		DBTypes:     make(once),
 		StringFuncs: templateStringMappers,
 		AutoColumns: s.Config.AutoColumns,
+
+		CustomQueries: s.CustomQueries,
+		Functions:     s.Functions,
+		AuditLog:      s.Config.AuditLog,
+		Outbox:        s.Config.Outbox,
+		Encryption:    s.Config.Encryption,
+		Tenancy:       s.Tenancy,
+		RLS:           s.RLS,
+		Proto:         s.Config.Proto,
+		ProtoMessages: s.ProtoMessages,
+		GraphQLTypes:  s.GraphQLTypes,
+		JSONSchemas:   s.JSONSchemas,
+
+		TypeScriptInterfaces: s.TypeScriptInterfaces,
+		CSVRecords:           s.CSVRecords,
+		SeedTables:           s.SeedTables,
+		ScrubTables:          s.ScrubTables,
+		MaskTables:           s.MaskTables,
+		StreamTables:         s.StreamTables,
+		RetentionTables:      s.RetentionTables,
+		HistoryTables:        s.HistoryTables,
+		SearchTables:         s.SearchTables,
+		HTTPHandlers:         s.HTTPHandlers,
+		DTOMappers:           s.DTOMappers,
+		JoinViews:            s.JoinViews,
+		Polymorphics:         s.Polymorphics,
+		Inheritances:         s.Inheritances,
+
+		Meta:      s.Config.Meta,
+		TableMeta: s.Config.TableMeta,
 	}
 
 	for _, v := range s.Config.TagIgnore {
@@ -187,7 +511,7 @@ func (s *State) Run() error {
 	}
 
 	for _, table := range s.Tables {
-		if table.IsJoinTable {
+		if table.IsJoinTable || (only != nil && !only[table.Name]) {
 			continue
 		}
 
@@ -215,6 +539,28 @@ func (s *State) Cleanup() error {
 	return nil
 }
 
+// Changed returns the files whose contents were changed by Run, the files
+// generated with the same contents are left untouched
+func (s *State) Changed() []string {
+	if len(s.packages) == 0 {
+		return s.changed
+	}
+
+	var changed []string
+	for _, p := range s.packages {
+		changed = append(changed, p.changed...)
+	}
+	return changed
+}
+
+// warnf writes a warning or notice to stderr unless the config is quiet
+func (s *State) warnf(format string, args ...interface{}) {
+	if s.Config.Quiet {
+		return
+	}
+	fmt.Fprintf(os.Stderr, format, args...)
+}
+
 // initTemplates loads all template folders into the state object.
 //
 // If TemplateDirs is set it uses those, else it pulls from assets.
@@ -301,6 +647,68 @@ func (s *State) initTemplates() ([]lazyTemplate, error) {
 		templates[original] = fileLoader(replacement)
 	}
 
+	// The protobuf messages, GraphQL schema, JSON Schemas, TypeScript
+	// interfaces and the docs directory are only generated when they are
+	// enabled
+	enabled := map[string]bool{
+		".proto.tpl":       s.Config.WithProto,
+		".graphql.tpl":     s.Config.WithGraphQL,
+		".schema.json.tpl": s.Config.WithJSONSchema,
+		".d.ts.tpl":        s.Config.WithTypeScript,
+	}
+	// The singletons of the features are left out when they are off, instead
+	// of being rendered empty and skipped
+	singletons := map[string]bool{
+		auditTemplate:          len(s.Config.AuditLog.Tables) != 0,
+		csvTemplate:            s.Config.AddCSV,
+		customQueriesTemplate:  len(s.Config.QueriesDir) != 0,
+		dataloaderTemplate:     s.Config.WithDataloader,
+		dtoTemplate:            len(s.Config.DTOs) != 0,
+		encryptionTemplate:     len(s.Config.Encryption.Columns) != 0,
+		functionsTemplate:      s.Config.AddFunctions,
+		graphQLTemplate:        s.Config.WithGraphQL,
+		grpcTemplate:           s.Config.WithGRPC,
+		historyTemplate:        len(s.Config.History.Tables) != 0,
+		httpTemplate:           s.Config.WithHTTP,
+		interfacesTemplate:     s.Config.AddInterfaces,
+		joinTemplate:           len(s.Config.Joins) != 0,
+		maskingTemplate:        len(s.Config.Masking.Columns) != 0,
+		outboxTemplate:         len(s.Config.Outbox.Tables) != 0,
+		protoTemplate:          s.Config.WithProto,
+		"boil_repositories":    s.Config.AddRepositories,
+		retentionTemplate:      len(s.Config.Retention.Columns) != 0,
+		rlsTemplate:            len(s.Config.RLSSetting) != 0,
+		scrubTemplate:          len(s.Config.Scrub.Columns) != 0,
+		seedsTemplate:          s.Config.AddSeeds,
+		streamingTemplate:      len(s.Config.Streaming.Columns) != 0,
+		tenancyTemplate:        len(s.Config.TenantColumn) != 0,
+		"boil_benchmarks_test": s.Config.WithBenchmarks,
+		"boil_encryption_test": len(s.Config.Encryption.Columns) != 0,
+		"boil_factories_test":  s.Config.AddFactories,
+		"boil_sqlmock_test":    s.Config.AddSQLMockTests,
+	}
+	docsDir := normalizeSlashes("main/docs/")
+	for name := range templates {
+		if !s.Config.WithDocs && strings.HasPrefix(name, docsDir) {
+			delete(templates, name)
+			continue
+		}
+		for ext, on := range enabled {
+			if !on && strings.HasSuffix(name, ext) {
+				delete(templates, name)
+			}
+		}
+
+		fragments := strings.Split(name, string(os.PathSeparator))
+		if len(fragments) < 2 || fragments[len(fragments)-2] != "singleton" {
+			continue
+		}
+		base := fragments[len(fragments)-1]
+		if on, ok := singletons[base[:strings.IndexByte(base, '.')]]; ok && !on {
+			delete(templates, name)
+		}
+	}
+
 	// For stability, sort keys to traverse the map and turn it into a slice
 	keys := make([]string, 0, len(templates))
 	for k := range templates {
@@ -405,7 +813,16 @@ func findTemplates(root, base string) (map[string]templateLoader, error) {
 
 // initDBInfo retrieves information about the database
 func (s *State) initDBInfo(config drivers.Config) error {
-	dbInfo, err := s.Driver.Assemble(config)
+	var dbInfo *drivers.DBInfo
+	var err error
+	if len(s.Config.SchemaSnapshot) != 0 {
+		dbInfo, err = ReadSchemaSnapshot(s.Config.SchemaSnapshot)
+	} else {
+		if err := drivers.LoadCredentials(&config); err != nil {
+			return err
+		}
+		dbInfo, err = s.Driver.Assemble(config)
+	}
 	if err != nil {
 		return errors.Wrap(err, "unable to fetch table data")
 	}
@@ -418,13 +835,30 @@ func (s *State) initDBInfo(config drivers.Config) error {
 		return err
 	}
 
+	if s.Config.InferForeignKeys {
+		s.inferForeignKeys(dbInfo.Tables)
+	}
+
 	s.Schema = dbInfo.Schema
 	s.Tables = dbInfo.Tables
 	s.Dialect = dbInfo.Dialect
+	s.functions = dbInfo.Functions
 
 	return nil
 }
 
+// inferForeignKeys adds the foreign keys inferred from the names of the
+// columns to the tables and reports them, so they can be checked against the
+// schema before the generated relationships are relied on
+func (s *State) inferForeignKeys(tables []drivers.Table) {
+	fkeys := drivers.InferForeignKeys(tables)
+	for _, fkey := range fkeys {
+		s.warnf("inferred foreign key: %s.%s -> %s.%s\n", fkey.Table, fkey.Column, fkey.ForeignTable, fkey.ForeignColumn)
+	}
+
+	drivers.AddForeignKeys(tables, fkeys)
+}
+
 // mergeDriverImports calls the driver and asks for its set
 // of imports, then merges it into the current configuration's
 // imports.
@@ -578,8 +1012,9 @@ func shouldReplaceInTable(t drivers.Table, r TypeReplace) bool {
 
 // initOutFolders creates the folders that will hold the generated output.
 func (s *State) initOutFolders(lazyTemplates []lazyTemplate) error {
+	fsys := s.outputFS()
 	if s.Config.Wipe {
-		if err := os.RemoveAll(s.Config.OutFolder); err != nil {
+		if err := fsys.RemoveAll(s.Config.OutFolder); err != nil {
 			return err
 		}
 	}
@@ -604,12 +1039,12 @@ func (s *State) initOutFolders(lazyTemplates []lazyTemplate) error {
 		newDirs[strings.Join(fragments, string(os.PathSeparator))] = struct{}{}
 	}
 
-	if err := os.MkdirAll(s.Config.OutFolder, os.ModePerm); err != nil {
+	if err := fsys.MkdirAll(s.Config.OutFolder, os.ModePerm); err != nil {
 		return err
 	}
 
 	for d := range newDirs {
-		if err := os.MkdirAll(filepath.Join(s.Config.OutFolder, d), os.ModePerm); err != nil {
+		if err := fsys.MkdirAll(filepath.Join(s.Config.OutFolder, d), os.ModePerm); err != nil {
 			return err
 		}
 	}
@@ -655,6 +1090,10 @@ func (s *State) initTags(tags []string) error {
 
 func (s *State) initAliases(a *Aliases) error {
 	FillAliases(a, s.Tables)
+
+	if collisions := resolveCollisions(a, s.Tables); len(collisions) != 0 {
+		return errors.Errorf("names collide in Go, alias them or set resolve_collisions in the aliases:\n\t%s", strings.Join(collisions, "\n\t"))
+	}
 	return nil
 }
 
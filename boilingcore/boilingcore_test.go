@@ -349,3 +349,211 @@ func TestProcessTypeReplacements(t *testing.T) {
 		t.Error("imports were not adjusted")
 	}
 }
+
+// testState returns a state of the config over the tables of testTables,
+// with their aliases filled in as New does
+func testState(config Config) *State {
+	s := &State{Config: &config, Tables: testTables()}
+	FillAliases(&s.Config.Aliases, s.Tables)
+
+	return s
+}
+
+// testTables returns the schema the tests of the features configured by
+// table or column run against, a fresh copy every time so tests can change it
+func testTables() []drivers.Table {
+	id := drivers.Column{Name: "id", Type: "int"}
+	pkey := func(columns ...string) *drivers.PrimaryKey {
+		return &drivers.PrimaryKey{Columns: columns}
+	}
+	fkey := func(name, table, column, foreignTable string) drivers.ForeignKey {
+		return drivers.ForeignKey{Name: name, Table: table, Column: column, ForeignTable: foreignTable, ForeignColumn: "id"}
+	}
+
+	return []drivers.Table{
+		{
+			Name: "pilots",
+			Columns: []drivers.Column{
+				id,
+				{Name: "name", Type: "string"},
+				{Name: "nick", Type: "null.String", Nullable: true},
+				{Name: "rank", Type: "null.Int", Nullable: true},
+				{Name: "avatar", Type: "null.Bytes", Nullable: true},
+				{Name: "password", Type: "string"},
+			},
+			PKey: pkey("id"),
+		},
+		{
+			Name:        "pilot_jets",
+			IsJoinTable: true,
+			FKeys: []drivers.ForeignKey{
+				fkey("pilot_jets_pilot_fkey", "pilot_jets", "pilot_id", "pilots"),
+				fkey("pilot_jets_jet_fkey", "pilot_jets", "jet_id", "jets"),
+			},
+		},
+		{
+			Name: "users",
+			Columns: []drivers.Column{
+				id,
+				{Name: "email", Type: "string", FullDBType: "character varying(100)", Unique: true},
+				{Name: "full_name", Type: "null.String", Nullable: true},
+				{Name: "ip_address", Type: "string"},
+				{Name: "born_at", Type: "null.Time", Nullable: true},
+				{Name: "avatar", Type: "[]byte"},
+				{Name: "card_number", Type: "null.String", Nullable: true},
+				{Name: "ssn", Type: "EncryptedString"},
+				{Name: "salary", Type: "int"},
+				{Name: "notes", Type: "string"},
+				{Name: "team_id", Type: "int"},
+				{Name: "visits", Type: "int"},
+			},
+			PKey:  pkey("id"),
+			FKeys: []drivers.ForeignKey{fkey("users_team_fkey", "users", "team_id", "teams")},
+		},
+		{
+			Name:    "teams",
+			Columns: []drivers.Column{id, {Name: "email", Type: "string"}},
+			PKey:    pkey("id"),
+		},
+		{
+			Name:    "team_histories",
+			Columns: []drivers.Column{id},
+			PKey:    pkey("id"),
+		},
+		{
+			Name:        "user_teams",
+			IsJoinTable: true,
+			Columns:     []drivers.Column{{Name: "user_id", Type: "int"}, {Name: "team_id", Type: "int"}},
+			PKey:        pkey("user_id", "team_id"),
+			FKeys: []drivers.ForeignKey{
+				fkey("user_teams_user_fkey", "user_teams", "user_id", "users"),
+				fkey("user_teams_team_fkey", "user_teams", "team_id", "teams"),
+			},
+		},
+		{
+			Name:    "user_emails",
+			IsView:  true,
+			Columns: []drivers.Column{{Name: "email", Type: "string"}},
+		},
+		{
+			Name:    "profiles",
+			Columns: []drivers.Column{id, {Name: "user_id", Type: "int"}},
+			PKey:    pkey("id"),
+			FKeys:   []drivers.ForeignKey{fkey("profiles_user_fkey", "profiles", "user_id", "users")},
+		},
+		{
+			Name:    "transfers",
+			Columns: []drivers.Column{id, {Name: "from_id", Type: "int"}, {Name: "to_id", Type: "int"}},
+			PKey:    pkey("id"),
+			FKeys: []drivers.ForeignKey{
+				fkey("transfers_from_fkey", "transfers", "from_id", "users"),
+				fkey("transfers_to_fkey", "transfers", "to_id", "users"),
+			},
+		},
+		{
+			Name:    "accounts",
+			Columns: []drivers.Column{id, {Name: "owner", Type: "string"}},
+			PKey:    pkey("id"),
+		},
+		{
+			Name:    "rates",
+			Columns: []drivers.Column{{Name: "currency", Type: "string"}, {Name: "day", Type: "time.Time"}, {Name: "rate", Type: "float64"}},
+			PKey:    pkey("currency", "day"),
+		},
+		{
+			Name:    "account_owners",
+			IsView:  true,
+			Columns: []drivers.Column{{Name: "owner", Type: "string"}},
+		},
+		{
+			Name: "events",
+			Columns: []drivers.Column{
+				id,
+				{Name: "name", Type: "string"},
+				{Name: "created_at", Type: "time.Time"},
+				{Name: "handled_at", Type: "null.Time", Nullable: true},
+			},
+			PKey: pkey("id"),
+		},
+		{
+			Name:    "logs",
+			Columns: []drivers.Column{id, {Name: "created_at", Type: "time.Time"}},
+			PKey:    pkey("id"),
+		},
+		{
+			Name:    "recent_events",
+			IsView:  true,
+			Columns: []drivers.Column{{Name: "created_at", Type: "time.Time"}},
+		},
+		{
+			Name: "files",
+			Columns: []drivers.Column{
+				id,
+				{Name: "name", Type: "string"},
+				{Name: "content", Type: "[]byte"},
+				{Name: "preview", Type: "null.Bytes", Nullable: true},
+				{Name: "video", Type: "null.Uint32", Nullable: true},
+				{Name: "owner_id", Type: "int"},
+			},
+			PKey:  pkey("id"),
+			FKeys: []drivers.ForeignKey{fkey("files_owner_fkey", "files", "owner_id", "owners")},
+		},
+		{
+			Name:    "owners",
+			Columns: []drivers.Column{id, {Name: "avatar", Type: "[]byte"}},
+			PKey:    pkey("id"),
+		},
+		{
+			Name:    "file_contents",
+			IsView:  true,
+			Columns: []drivers.Column{{Name: "content", Type: "[]byte"}},
+		},
+		{
+			Name:    "posts",
+			Columns: []drivers.Column{id},
+			PKey:    pkey("id"),
+		},
+		{
+			Name:    "videos",
+			Columns: []drivers.Column{{Name: "uuid", Type: "string"}},
+			PKey:    pkey("uuid"),
+		},
+		{
+			Name: "comments",
+			Columns: []drivers.Column{
+				id,
+				{Name: "commentable_id", Type: "int"},
+				{Name: "commentable_type", Type: "string"},
+				{Name: "owner_ref", Type: "null.String"},
+				{Name: "owner_kind", Type: "null.String"},
+				{Name: "rating", Type: "int"},
+			},
+			PKey: pkey("id"),
+		},
+		{
+			Name:    "post_tags",
+			Columns: []drivers.Column{{Name: "post_id", Type: "int"}, {Name: "tag_id", Type: "int"}},
+			PKey:    pkey("post_id", "tag_id"),
+		},
+		{
+			Name: "vehicles",
+			Columns: []drivers.Column{
+				id,
+				{Name: "type", Type: "string"},
+				{Name: "kind", Type: "null.String"},
+				{Name: "wheels", Type: "int"},
+			},
+			PKey: pkey("id"),
+		},
+		{
+			Name:    "cars",
+			Columns: []drivers.Column{id},
+			PKey:    pkey("id"),
+		},
+		{
+			Name:    "vehicle_names",
+			IsView:  true,
+			Columns: []drivers.Column{{Name: "type", Type: "string"}},
+		},
+	}
+}
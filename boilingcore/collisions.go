@@ -0,0 +1,80 @@
+package boilingcore
+
+import (
+	"fmt"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+// resolveCollisions finds the tables named alike in Go, by their singular
+// or plural names, and the columns of a table named alike or like a field or
+// method of the models. With ResolveCollisions the later names get the
+// reserved suffix until they are unique, otherwise the collisions are
+// returned to be reported.
+func resolveCollisions(a *Aliases, tables []drivers.Table) []string {
+	var collisions []string
+	suffix := a.reservedSuffix()
+
+	owners := make(map[string]string)
+	for _, t := range tables {
+		if t.IsJoinTable {
+			continue
+		}
+
+		table := a.Tables[t.Name]
+		for {
+			other, taken := owners[table.UpSingular]
+			if !taken {
+				other, taken = owners[table.UpPlural]
+			}
+
+			switch {
+			case taken && a.ResolveCollisions:
+				table.UpSingular += suffix
+				table.UpPlural += suffix
+				table.DownSingular += suffix
+				table.DownPlural += suffix
+				continue
+			case taken:
+				collisions = append(collisions, fmt.Sprintf("tables %s and %s are both named %s or %s", other, t.Name, table.UpSingular, table.UpPlural))
+			case table.UpSingular == table.UpPlural && a.ResolveCollisions:
+				table.UpPlural += suffix
+				table.DownPlural += suffix
+				continue
+			case table.UpSingular == table.UpPlural:
+				collisions = append(collisions, fmt.Sprintf("table %s has the same singular and plural name %s", t.Name, table.UpSingular))
+			}
+			break
+		}
+
+		owners[table.UpSingular] = t.Name
+		owners[table.UpPlural] = t.Name
+
+		columns := make(map[string]string)
+		for _, c := range t.Columns {
+			alias := table.Columns[c.Name]
+			for {
+				other, taken := columns[alias]
+				_, reserved := reservedColumnAliases[alias]
+
+				switch {
+				case (taken || reserved) && a.ResolveCollisions:
+					alias += suffix
+					continue
+				case taken:
+					collisions = append(collisions, fmt.Sprintf("columns %s.%s and %s.%s are both named %s", t.Name, other, t.Name, c.Name, alias))
+				case reserved:
+					collisions = append(collisions, fmt.Sprintf("column %s.%s is named %s like a field or method of the models", t.Name, c.Name, alias))
+				}
+				break
+			}
+
+			table.Columns[c.Name] = alias
+			columns[alias] = c.Name
+		}
+
+		a.Tables[t.Name] = table
+	}
+
+	return collisions
+}
@@ -0,0 +1,59 @@
+package boilingcore
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestResolveCollisions(t *testing.T) {
+	t.Parallel()
+
+	tables := []drivers.Table{
+		{Name: "person", Columns: []drivers.Column{{Name: "id"}, {Name: "user_id"}, {Name: "userID"}}},
+		{Name: "people", Columns: []drivers.Column{{Name: "id"}, {Name: "insert"}}},
+		{Name: "sheep", Columns: []drivers.Column{{Name: "id"}}},
+		{Name: "pilots", Columns: []drivers.Column{{Name: "id"}}},
+	}
+	aliases := func() Aliases {
+		return Aliases{Tables: map[string]TableAlias{
+			"people": {Columns: map[string]string{"insert": "Insert"}},
+			"sheep":  {UpPlural: "Sheep", UpSingular: "Sheep", DownPlural: "sheep", DownSingular: "sheep"},
+		}}
+	}
+
+	a := aliases()
+	FillAliases(&a, tables)
+	collisions := resolveCollisions(&a, tables)
+
+	expect := []string{
+		"columns person.user_id and person.userID are both named UserID",
+		"tables person and people are both named Person or People",
+		"column people.insert is named Insert like a field or method of the models",
+		"table sheep has the same singular and plural name Sheep",
+	}
+	if !reflect.DeepEqual(expect, collisions) {
+		t.Errorf("want the collisions reported, got: %#v", collisions)
+	}
+
+	a = aliases()
+	a.ResolveCollisions = true
+	FillAliases(&a, tables)
+	if collisions := resolveCollisions(&a, tables); len(collisions) != 0 {
+		t.Fatalf("want the collisions resolved, got: %#v", collisions)
+	}
+
+	if got := a.Tables["person"].Columns["userID"]; got != "UserID_" {
+		t.Errorf("want the later column suffixed, got: %s", got)
+	}
+	if got := a.Tables["people"]; got.UpSingular != "Person_" || got.UpPlural != "People_" || got.DownSingular != "person_" || got.Columns["insert"] != "Insert_" {
+		t.Errorf("want the later table and the reserved column suffixed, got: %#v", got)
+	}
+	if got := a.Tables["sheep"]; got.UpSingular != "Sheep" || got.UpPlural != "Sheep_" {
+		t.Errorf("want the plural suffixed, got: %#v", got)
+	}
+	if got := a.Tables["pilots"]; got.UpSingular != "Pilot" || got.UpPlural != "Pilots" {
+		t.Errorf("want the other tables left, got: %#v", got)
+	}
+}
@@ -1,9 +1,11 @@
 package boilingcore
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/fs"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -29,6 +31,30 @@ type Config struct {
 	AddPanic          bool     `toml:"add_panic,omitempty" json:"add_panic,omitempty"`
 	AddSoftDeletes    bool     `toml:"add_soft_deletes,omitempty" json:"add_soft_deletes,omitempty"`
 	AddEnumTypes      bool     `toml:"add_enum_types,omitempty" json:"add_enum_types,omitempty"`
+	AddFactories      bool     `toml:"add_factories,omitempty" json:"add_factories,omitempty"`
+	AddSQLMockTests   bool     `toml:"add_sqlmock_tests,omitempty" json:"add_sqlmock_tests,omitempty"`
+	AddCSV            bool     `toml:"add_csv,omitempty" json:"add_csv,omitempty"`
+	AddSeeds          bool     `toml:"add_seeds,omitempty" json:"add_seeds,omitempty"`
+	AddRepositories   bool     `toml:"add_repositories,omitempty" json:"add_repositories,omitempty"`
+	AddImmutable      bool     `toml:"add_immutable,omitempty" json:"add_immutable,omitempty"`
+	AddInterfaces     bool     `toml:"add_interfaces,omitempty" json:"add_interfaces,omitempty"`
+	AddDiff           bool     `toml:"add_diff,omitempty" json:"add_diff,omitempty"`
+	AddCopyEqual      bool     `toml:"add_copy_equal,omitempty" json:"add_copy_equal,omitempty"`
+	AddMaps           bool     `toml:"add_maps,omitempty" json:"add_maps,omitempty"`
+	AddValidation     bool     `toml:"add_validation,omitempty" json:"add_validation,omitempty"`
+	WithBenchmarks    bool     `toml:"with_benchmarks,omitempty" json:"with_benchmarks,omitempty"`
+	WithOTel          bool     `toml:"with_otel,omitempty" json:"with_otel,omitempty"`
+	WithMetrics       bool     `toml:"with_metrics,omitempty" json:"with_metrics,omitempty"`
+	WithProto         bool     `toml:"with_proto,omitempty" json:"with_proto,omitempty"`
+	WithGRPC          bool     `toml:"with_grpc,omitempty" json:"with_grpc,omitempty"`
+	WithGraphQL       bool     `toml:"with_graphql,omitempty" json:"with_graphql,omitempty"`
+	WithJSONSchema    bool     `toml:"with_json_schema,omitempty" json:"with_json_schema,omitempty"`
+	WithTypeScript    bool     `toml:"with_typescript,omitempty" json:"with_typescript,omitempty"`
+	WithHTTP          bool     `toml:"with_http,omitempty" json:"with_http,omitempty"`
+	WithDocs          bool     `toml:"with_docs,omitempty" json:"with_docs,omitempty"`
+	WithGenerics      bool     `toml:"with_generics,omitempty" json:"with_generics,omitempty"`
+	WithDataloader    bool     `toml:"with_dataloader,omitempty" json:"with_dataloader,omitempty"`
+	WithIdentityMap   bool     `toml:"with_identity_map,omitempty" json:"with_identity_map,omitempty"`
 	EnumNullPrefix    string   `toml:"enum_null_prefix,omitempty" json:"enum_null_prefix,omitempty"`
 	NoContext         bool     `toml:"no_context,omitempty" json:"no_context,omitempty"`
 	NoTests           bool     `toml:"no_tests,omitempty" json:"no_tests,omitempty"`
@@ -37,21 +63,73 @@ type Config struct {
 	NoRowsAffected    bool     `toml:"no_rows_affected,omitempty" json:"no_rows_affected,omitempty"`
 	NoDriverTemplates bool     `toml:"no_driver_templates,omitempty" json:"no_driver_templates,omitempty"`
 	NoBackReferencing bool     `toml:"no_back_reference,omitempty" json:"no_back_reference,omitempty"`
+	NoReload          bool     `toml:"no_reload,omitempty" json:"no_reload,omitempty"`
+	NoExists          bool     `toml:"no_exists,omitempty" json:"no_exists,omitempty"`
+	NoUpsert          bool     `toml:"no_upsert,omitempty" json:"no_upsert,omitempty"`
 	AlwaysWrapErrors  bool     `toml:"always_wrap_errors,omitempty" json:"always_wrap_errors,omitempty"`
 	Wipe              bool     `toml:"wipe,omitempty" json:"wipe,omitempty"`
 	StructTagCasing   string   `toml:"struct_tag_casing,omitempty" json:"struct_tag_casing,omitempty"`
+	StructFieldOrder  string   `toml:"struct_field_order,omitempty" json:"struct_field_order,omitempty"`
 	RelationTag       string   `toml:"relation_tag,omitempty" json:"relation_tag,omitempty"`
 	TagIgnore         []string `toml:"tag_ignore,omitempty" json:"tag_ignore,omitempty"`
+	QueriesDir        string   `toml:"queries_dir,omitempty" json:"queries_dir,omitempty"`
+	AddFunctions      bool     `toml:"add_functions,omitempty" json:"add_functions,omitempty"`
+	TenantColumn      string   `toml:"tenant_column,omitempty" json:"tenant_column,omitempty"`
+	RLSSetting        string   `toml:"rls_setting,omitempty" json:"rls_setting,omitempty"`
+	// Quiet suppresses the warnings and notices of the generation, errors
+	// are still returned
+	Quiet bool `toml:"quiet,omitempty" json:"quiet,omitempty"`
+	// SchemaSnapshot is a snapshot written by sqlboiler dump-schema the schema is
+	// read from instead of the database
+	SchemaSnapshot string `toml:"schema_snapshot,omitempty" json:"schema_snapshot,omitempty"`
+	// GroupNullableFields puts the nullable fields of the structs after the
+	// others
+	GroupNullableFields bool `toml:"group_nullable_fields,omitempty" json:"group_nullable_fields,omitempty"`
+	// OptimizeStructLayout orders the fields of the structs by descending
+	// alignment to minimize their padding
+	OptimizeStructLayout bool `toml:"optimize_struct_layout,omitempty" json:"optimize_struct_layout,omitempty"`
+	// NoRelationshipSetters leaves out the methods setting, adding and
+	// removing the related models
+	NoRelationshipSetters bool `toml:"no_relationship_setters,omitempty" json:"no_relationship_setters,omitempty"`
+	// InferForeignKeys treats the columns named after a table and its primary
+	// key, like user_id for users.id, as foreign keys when they are not
+	InferForeignKeys bool `toml:"infer_foreign_keys,omitempty" json:"infer_foreign_keys,omitempty"`
 
 	Imports importers.Collection `toml:"imports,omitempty" json:"imports,omitempty"`
 
 	DefaultTemplates    fs.FS            `toml:"-" json:"-"`
 	CustomTemplateFuncs template.FuncMap `toml:"-" json:"-"`
+	// OutputFS is the filesystem the generated files are written to, the
+	// disk by default
+	OutputFS OutputFS `toml:"-" json:"-"`
 
 	Aliases      Aliases       `toml:"aliases,omitempty" json:"aliases,omitempty"`
 	TypeReplaces []TypeReplace `toml:"type_replaces,omitempty" json:"type_replaces,omitempty"`
 	AutoColumns  AutoColumns   `toml:"auto_columns,omitempty" json:"auto_columns,omitempty"`
 	Inflections  Inflections   `toml:"inflections,omitempty" json:"inflections,omitempty"`
+	AuditLog     AuditLog      `toml:"audit_log,omitempty" json:"audit_log,omitempty"`
+	Outbox       Outbox        `toml:"outbox,omitempty" json:"outbox,omitempty"`
+	Scrub        Scrub         `toml:"scrub,omitempty" json:"scrub,omitempty"`
+	Masking      Masking       `toml:"masking,omitempty" json:"masking,omitempty"`
+	Encryption   Encryption    `toml:"encryption,omitempty" json:"encryption,omitempty"`
+	Streaming    Streaming     `toml:"streaming,omitempty" json:"streaming,omitempty"`
+	Retention    Retention     `toml:"retention,omitempty" json:"retention,omitempty"`
+	History      History       `toml:"history,omitempty" json:"history,omitempty"`
+	Packages     []Package     `toml:"packages,omitempty" json:"packages,omitempty"`
+	DTOs         []DTO         `toml:"dtos,omitempty" json:"dtos,omitempty"`
+	Joins        []Join        `toml:"joins,omitempty" json:"joins,omitempty"`
+	Polymorphics []Polymorphic `toml:"polymorphic,omitempty" json:"polymorphic,omitempty"`
+	Inheritances []Inheritance `toml:"inheritance,omitempty" json:"inheritance,omitempty"`
+	Proto        Proto         `toml:"proto,omitempty" json:"proto,omitempty"`
+
+	// Transliterations replace the words of the database names before the Go
+	// names are made of them, the letters they leave are transliterated
+	Transliterations map[string]string `toml:"transliterations,omitempty" json:"transliterations,omitempty"`
+
+	// Meta is the metadata of the config for the templates of the user, and
+	// TableMeta the metadata of each table by name
+	Meta      map[string]interface{}            `toml:"meta,omitempty" json:"meta,omitempty"`
+	TableMeta map[string]map[string]interface{} `toml:"table_meta,omitempty" json:"table_meta,omitempty"`
 
 	Version string `toml:"version" json:"version"`
 }
@@ -62,6 +140,98 @@ type AutoColumns struct {
 	Deleted string `toml:"deleted,omitempty" json:"deleted,omitempty"`
 }
 
+// AuditLog lists the tables whose changes are recorded in the audit table
+type AuditLog struct {
+	Tables []string `toml:"tables,omitempty" json:"tables,omitempty"`
+	// Table the changes are recorded in, audit_log by default
+	Table string `toml:"table,omitempty" json:"table,omitempty"`
+}
+
+// Outbox lists the tables whose changes are written as events to the outbox
+// table, in the transaction of the change
+type Outbox struct {
+	Tables []string `toml:"tables,omitempty" json:"tables,omitempty"`
+	// Table the events are written to, outbox by default
+	Table string `toml:"table,omitempty" json:"table,omitempty"`
+}
+
+// Scrub lists the sensitive columns the generated Scrub methods overwrite with
+// fakes
+type Scrub struct {
+	// Columns are table.column, or column for the columns of every table,
+	// followed by :kind to choose the kind of fake of a string column
+	Columns []string `toml:"columns,omitempty" json:"columns,omitempty"`
+}
+
+// Masking lists the sensitive columns the generated Masked methods mask in the
+// copies of models they return
+type Masking struct {
+	// Columns are table.column, or column for the columns of every table,
+	// followed by :rule to choose how the column is masked
+	Columns []string `toml:"columns,omitempty" json:"columns,omitempty"`
+}
+
+// Encryption lists the columns whose values are encrypted in the database by
+// the encryptor set with the generated SetEncryptor
+type Encryption struct {
+	// Columns are table.column, or column for the columns of every table
+	Columns []string `toml:"columns,omitempty" json:"columns,omitempty"`
+}
+
+// Streaming lists the bytes and postgres large object columns the generated
+// readers and writers stream in chunks
+type Streaming struct {
+	// Columns are table.column, or column for the columns of every table
+	Columns []string `toml:"columns,omitempty" json:"columns,omitempty"`
+}
+
+// Retention lists the timestamp columns the generated purge and archive
+// helpers read the age of the rows of their tables from
+type Retention struct {
+	// Columns are table.column, or column for every table that has it
+	Columns []string `toml:"columns,omitempty" json:"columns,omitempty"`
+}
+
+// History lists the tables whose versions the generated hooks keep in their
+// history tables, with the times the versions were valid from and to
+type History struct {
+	Tables []string `toml:"tables,omitempty" json:"tables,omitempty"`
+	// Suffix of the names of the history tables, _history by default
+	Suffix string `toml:"suffix,omitempty" json:"suffix,omitempty"`
+}
+
+// Audits checks if the changes of the table are recorded
+func (a AuditLog) Audits(table string) bool {
+	for _, t := range a.Tables {
+		if t == table {
+			return true
+		}
+	}
+	return false
+}
+
+// Publishes checks if the changes of the table are written to the outbox
+func (o Outbox) Publishes(table string) bool {
+	for _, t := range o.Tables {
+		if t == table {
+			return true
+		}
+	}
+	return false
+}
+
+// Proto configures the protobuf messages generated with WithProto
+type Proto struct {
+	// Package of the .proto files, PkgName by default
+	Package string `toml:"package,omitempty" json:"package,omitempty"`
+	// GoPackage is the import path of the Go code protoc generates from the
+	// .proto files
+	GoPackage string `toml:"go_package,omitempty" json:"go_package,omitempty"`
+	// Nullable columns are optional fields, or google.protobuf wrappers
+	// with "wrappers"
+	Nullable string `toml:"nullable,omitempty" json:"nullable,omitempty"`
+}
+
 // TypeReplace replaces a column type with something else
 type TypeReplace struct {
 	Tables  []string       `toml:"tables,omitempty" json:"tables,omitempty"`
@@ -120,6 +290,8 @@ func ConvertAliases(i interface{}) (a Aliases) {
 	}
 
 	topLevel := cast.ToStringMap(i)
+	a.ReservedSuffix = cast.ToString(topLevel["reserved_suffix"])
+	a.ResolveCollisions = cast.ToBool(topLevel["resolve_collisions"])
 
 	tablesIntf := topLevel["tables"]
 
@@ -305,27 +477,50 @@ func columnFromInterface(i interface{}) (col drivers.Column) {
 //	column = "column_name"
 //	foreign_table = "foreign_table_name"
 //	foreign_column = "foreign_column_name"
+//
+// unique and foreign_column_unique hint that the columns are unique when the
+// schema has no unique index on them. A foreign key without hints can also be
+// written in one line:
+//
+//	[foreign_keys]
+//	fk_1 = "table_name.column_name -> foreign_table_name.foreign_column_name"
 func ConvertForeignKeys(i interface{}) (fks []drivers.ForeignKey) {
 	if i == nil {
 		return nil
 	}
 
 	iterateMapOrSlice(i, func(name string, obj interface{}) {
-		t := cast.ToStringMap(obj)
-
-		fk := drivers.ForeignKey{
-			Table:         cast.ToString(t["table"]),
-			Name:          name,
-			Column:        cast.ToString(t["column"]),
-			ForeignTable:  cast.ToString(t["foreign_table"]),
-			ForeignColumn: cast.ToString(t["foreign_column"]),
+		var fk drivers.ForeignKey
+		if arrow, ok := obj.(string); ok {
+			var err error
+			if fk, err = parseForeignKeyArrow(arrow); err != nil {
+				panic(errors.Errorf("invalid foreign key %s: %s", name, err))
+			}
+		} else {
+			t := cast.ToStringMap(obj)
+			fk = drivers.ForeignKey{
+				Table:                 cast.ToString(t["table"]),
+				Column:                cast.ToString(t["column"]),
+				Nullable:              cast.ToBool(t["nullable"]),
+				Unique:                cast.ToBool(t["unique"]),
+				ForeignTable:          cast.ToString(t["foreign_table"]),
+				ForeignColumn:         cast.ToString(t["foreign_column"]),
+				ForeignColumnNullable: cast.ToBool(t["foreign_column_nullable"]),
+				ForeignColumnUnique:   cast.ToBool(t["foreign_column_unique"]),
+			}
 		}
+
+		fk.Name = name
 		if err := validateForeignKey(fk); err != nil {
 			panic(errors.Errorf("invalid foreign key %s: %s", name, err))
 		}
 		fks = append(fks, fk)
 	})
 
+	sort.Slice(fks, func(i, j int) bool {
+		return fks[i].Name < fks[j].Name
+	})
+
 	if err := validateDuplicateForeignKeys(fks); err != nil {
 		panic(errors.Errorf("invalid foreign keys: %s", err))
 	}
@@ -333,6 +528,285 @@ func ConvertForeignKeys(i interface{}) (fks []drivers.ForeignKey) {
 	return fks
 }
 
+// ConvertPackages is necessary because viper
+//
+// It also supports two different syntaxes, because of viper:
+//
+//	[packages.authmodels]
+//	output = "internal/authmodels"
+//	tables = ["users", "sessions"]
+//
+// Or alternatively:
+//
+//	[[packages]]
+//	name = "authmodels"
+//	output = "internal/authmodels"
+//	tables = ["users", "sessions"]
+func ConvertPackages(i interface{}) (packages []Package) {
+	if i == nil {
+		return nil
+	}
+
+	iterateMapOrSlice(i, func(name string, obj interface{}) {
+		t := cast.ToStringMap(obj)
+
+		packages = append(packages, Package{
+			Name:   name,
+			Output: cast.ToString(t["output"]),
+			Tables: cast.ToStringSlice(t["tables"]),
+		})
+	})
+
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+
+	return packages
+}
+
+// ConvertDTOs is necessary because viper
+//
+// It converts the DTOs keyed by name, or in an array with a name key:
+//
+//	[dtos.PilotResponse]
+//	table = "pilots"
+//	type = "example.com/app/api.PilotResponse"
+//	fields = { name = "FullName" }
+func ConvertDTOs(i interface{}) (dtos []DTO) {
+	if i == nil {
+		return nil
+	}
+
+	iterateMapOrSlice(i, func(name string, obj interface{}) {
+		t := cast.ToStringMap(obj)
+
+		dtos = append(dtos, DTO{
+			Name:   name,
+			Table:  cast.ToString(t["table"]),
+			Type:   cast.ToString(t["type"]),
+			Nulls:  cast.ToString(t["nulls"]),
+			Fields: cast.ToStringMapString(t["fields"]),
+			Ignore: cast.ToStringSlice(t["ignore"]),
+		})
+	})
+
+	sort.SliceStable(dtos, func(i, j int) bool {
+		return dtos[i].Name < dtos[j].Name
+	})
+
+	return dtos
+}
+
+// ConvertJoins is necessary because viper
+//
+// It converts the joins keyed by name, or in an array with a name key:
+//
+//	[joins.UserProfile]
+//	table = "users"
+//	foreign_table = "profiles"
+func ConvertJoins(i interface{}) (joins []Join) {
+	if i == nil {
+		return nil
+	}
+
+	iterateMapOrSlice(i, func(name string, obj interface{}) {
+		t := cast.ToStringMap(obj)
+
+		joins = append(joins, Join{
+			Name:         name,
+			Table:        cast.ToString(t["table"]),
+			ForeignTable: cast.ToString(t["foreign_table"]),
+			ForeignKey:   cast.ToString(t["foreign_key"]),
+		})
+	})
+
+	sort.SliceStable(joins, func(i, j int) bool {
+		return joins[i].Name < joins[j].Name
+	})
+
+	return joins
+}
+
+// ConvertPolymorphics is necessary because viper
+//
+// It converts the polymorphic pairs keyed by name, or in an array with a name
+// key. The targets are the names of their tables, or the values of the type
+// column naming the tables keyed by table, since viper lowercases the keys:
+//
+//	[polymorphic.commentable]
+//	table = "comments"
+//	targets = ["posts", "videos"]
+//
+//	[polymorphic.commentable.targets]
+//	posts = "BlogPost"
+func ConvertPolymorphics(i interface{}) (polymorphics []Polymorphic) {
+	if i == nil {
+		return nil
+	}
+
+	iterateMapOrSlice(i, func(name string, obj interface{}) {
+		t := cast.ToStringMap(obj)
+
+		p := Polymorphic{
+			Name:       name,
+			Table:      cast.ToString(t["table"]),
+			IDColumn:   cast.ToString(t["id_column"]),
+			TypeColumn: cast.ToString(t["type_column"]),
+		}
+
+		switch targets := t["targets"].(type) {
+		case []interface{}:
+			for _, table := range targets {
+				p.Targets = append(p.Targets, PolymorphicTarget{Table: cast.ToString(table)})
+			}
+		case map[string]interface{}, map[interface{}]interface{}:
+			for table, typ := range cast.ToStringMap(targets) {
+				p.Targets = append(p.Targets, PolymorphicTarget{Type: cast.ToString(typ), Table: table})
+			}
+			sort.SliceStable(p.Targets, func(i, j int) bool {
+				return p.Targets[i].Table < p.Targets[j].Table
+			})
+		}
+
+		polymorphics = append(polymorphics, p)
+	})
+
+	sort.SliceStable(polymorphics, func(i, j int) bool {
+		return polymorphics[i].Name < polymorphics[j].Name
+	})
+
+	return polymorphics
+}
+
+// ConvertInheritances is necessary because viper
+//
+// It converts the inheritances keyed by table, or in an array with a table
+// key. The subtypes are their names, or the values of the discriminator
+// column keyed by subtype, since viper lowercases the keys:
+//
+//	[inheritance.vehicles]
+//	column = "kind"
+//	subtypes = ["Car", "Truck"]
+//
+//	[inheritance.vehicles.subtypes]
+//	pickup_truck = "pickup"
+func ConvertInheritances(i interface{}) (inheritances []Inheritance) {
+	if i == nil {
+		return nil
+	}
+
+	add := func(table string, obj interface{}) {
+		t := cast.ToStringMap(obj)
+
+		inh := Inheritance{
+			Table:  table,
+			Column: cast.ToString(t["column"]),
+		}
+
+		switch subtypes := t["subtypes"].(type) {
+		case []interface{}:
+			for _, name := range subtypes {
+				inh.Subtypes = append(inh.Subtypes, Subtype{Name: cast.ToString(name)})
+			}
+		case map[string]interface{}, map[interface{}]interface{}:
+			for name, value := range cast.ToStringMap(subtypes) {
+				inh.Subtypes = append(inh.Subtypes, Subtype{Name: name, Value: cast.ToString(value)})
+			}
+			sort.SliceStable(inh.Subtypes, func(i, j int) bool {
+				return inh.Subtypes[i].Name < inh.Subtypes[j].Name
+			})
+		}
+
+		inheritances = append(inheritances, inh)
+	}
+
+	switch t := i.(type) {
+	case []interface{}:
+		for _, obj := range t {
+			add(cast.ToString(cast.ToStringMap(obj)["table"]), obj)
+		}
+	default:
+		iterateMapOrSlice(i, add)
+	}
+
+	sort.SliceStable(inheritances, func(i, j int) bool {
+		return inheritances[i].Table < inheritances[j].Table
+	})
+
+	return inheritances
+}
+
+// ConvertTableMeta is necessary because viper
+//
+// It converts the metadata keyed by table, or in an array with a name key,
+// which the metadata keeps:
+//
+//	[table_meta.pilots]
+//	owner = "flight-ops"
+func ConvertTableMeta(i interface{}) map[string]map[string]interface{} {
+	if i == nil {
+		return nil
+	}
+
+	meta := make(map[string]map[string]interface{})
+	iterateMapOrSlice(i, func(name string, obj interface{}) {
+		meta[name] = cast.ToStringMap(obj)
+	})
+
+	return meta
+}
+
+// ConvertMockTables is necessary because viper
+//
+// It converts the tables of the mock driver defined in the config file, the
+// keys are the same as the json output of a driver's tables:
+//
+//	[[mock.tables]]
+//	name = "pilots"
+//	p_key = { name = "pilots_pkey", columns = ["id"] }
+//	columns = [
+//	  { name = "id", db_type = "integer" },
+//	  { name = "name", db_type = "text", nullable = true },
+//	]
+func ConvertMockTables(i interface{}) (tables []drivers.Table) {
+	if i == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(i)
+	if err != nil {
+		panic(errors.Wrap(err, "invalid mock tables"))
+	}
+
+	if err := json.Unmarshal(b, &tables); err != nil {
+		panic(errors.Wrap(err, "invalid mock tables"))
+	}
+
+	return tables
+}
+
+// parseForeignKeyArrow parses a foreign key written as
+// table.column -> foreign_table.foreign_column
+func parseForeignKeyArrow(arrow string) (drivers.ForeignKey, error) {
+	sides := strings.Split(arrow, "->")
+	if len(sides) != 2 {
+		return drivers.ForeignKey{}, errors.Errorf("%q is not table.column -> foreign_table.foreign_column", arrow)
+	}
+
+	local := strings.Split(strings.TrimSpace(sides[0]), ".")
+	foreign := strings.Split(strings.TrimSpace(sides[1]), ".")
+	if len(local) != 2 || len(foreign) != 2 {
+		return drivers.ForeignKey{}, errors.Errorf("%q is not table.column -> foreign_table.foreign_column", arrow)
+	}
+
+	return drivers.ForeignKey{
+		Table:         local[0],
+		Column:        local[1],
+		ForeignTable:  foreign[0],
+		ForeignColumn: foreign[1],
+	}, nil
+}
+
 func validateForeignKey(fk drivers.ForeignKey) error {
 	if fk.Name == "" {
 		return errors.New("foreign key must have a name")
@@ -46,6 +46,8 @@ func TestConvertAliases(t *testing.T) {
 	t.Parallel()
 
 	var intf interface{} = map[string]interface{}{
+		"reserved_suffix":    "Col",
+		"resolve_collisions": true,
 		"tables": map[string]interface{}{
 			"table_name": map[string]interface{}{
 				"up_plural":     "a",
@@ -71,6 +73,9 @@ func TestConvertAliases(t *testing.T) {
 	if len(aliases.Tables) != 1 {
 		t.Fatalf("should have one table alias: %#v", aliases.Tables)
 	}
+	if aliases.ReservedSuffix != "Col" || !aliases.ResolveCollisions {
+		t.Error("reserved suffix or collision resolution was wrong:", aliases.ReservedSuffix, aliases.ResolveCollisions)
+	}
 
 	table := aliases.Tables["table_name"]
 	if table.UpPlural != "a" {
@@ -333,3 +338,307 @@ func TestConvertForeignKeysAltSyntax(t *testing.T) {
 		t.Error("value was wrong:", fk)
 	}
 }
+
+func TestConvertForeignKeysArrow(t *testing.T) {
+	t.Parallel()
+
+	var intf interface{} = map[string]interface{}{
+		"jets_pilot": "jets.pilot_id -> pilots.id",
+	}
+
+	fks := ConvertForeignKeys(intf)
+	want := []drivers.ForeignKey{
+		{Name: "jets_pilot", Table: "jets", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"},
+	}
+	if !reflect.DeepEqual(fks, want) {
+		t.Error("value was wrong:", fks)
+	}
+
+	for _, arrow := range []string{"jets.pilot_id pilots.id", "jets -> pilots.id", "jets.pilot_id -> pilots"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%q should be invalid", arrow)
+				}
+			}()
+			ConvertForeignKeys(map[string]interface{}{"fk": arrow})
+		}()
+	}
+}
+
+func TestConvertForeignKeysSorted(t *testing.T) {
+	t.Parallel()
+
+	fk := func(column string) map[string]interface{} {
+		return map[string]interface{}{
+			"table":          "table_name",
+			"column":         column,
+			"foreign_table":  "foreign_table_name",
+			"foreign_column": "id",
+		}
+	}
+	var intf interface{} = map[string]interface{}{
+		"fk_c": fk("c"),
+		"fk_a": fk("a"),
+		"fk_b": fk("b"),
+	}
+
+	fks := ConvertForeignKeys(intf)
+	var names []string
+	for _, fk := range fks {
+		names = append(names, fk.Name)
+	}
+	if want := []string{"fk_a", "fk_b", "fk_c"}; !reflect.DeepEqual(names, want) {
+		t.Error("foreign keys were not sorted by name:", names)
+	}
+}
+
+func TestConvertMockTables(t *testing.T) {
+	t.Parallel()
+
+	var intf interface{} = []interface{}{
+		map[string]interface{}{
+			"name": "pilots",
+			"columns": []interface{}{
+				map[string]interface{}{"name": "id", "db_type": "integer"},
+				map[string]interface{}{"name": "name", "db_type": "text", "nullable": true},
+			},
+			"p_key": map[string]interface{}{
+				"name":    "pilots_pkey",
+				"columns": []interface{}{"id"},
+			},
+		},
+	}
+
+	tables := ConvertMockTables(intf)
+	if len(tables) != 1 {
+		t.Fatal("should have one entry")
+	}
+
+	table := tables[0]
+	if table.Name != "pilots" {
+		t.Error("name was wrong:", table.Name)
+	}
+	if len(table.Columns) != 2 || table.Columns[1].DBType != "text" || !table.Columns[1].Nullable {
+		t.Error("columns were wrong:", table.Columns)
+	}
+	if table.PKey == nil || table.PKey.Columns[0] != "id" {
+		t.Error("primary key was wrong:", table.PKey)
+	}
+}
+
+func TestConvertPackages(t *testing.T) {
+	t.Parallel()
+
+	var intf interface{} = map[string]interface{}{
+		"authmodels": map[string]interface{}{
+			"output": "internal/authmodels",
+			"tables": []interface{}{"users", "sessions"},
+		},
+		"billing": map[string]interface{}{
+			"tables": []interface{}{"invoices"},
+		},
+	}
+
+	packages := ConvertPackages(intf)
+	if len(packages) != 2 {
+		t.Fatal("should have two entries")
+	}
+
+	p := packages[0]
+	if p.Name != "authmodels" || p.Output != "internal/authmodels" || len(p.Tables) != 2 || p.Tables[1] != "sessions" {
+		t.Error("value was wrong:", p)
+	}
+	if p := packages[1]; p.Name != "billing" || p.Output != "" || len(p.Tables) != 1 {
+		t.Error("value was wrong:", p)
+	}
+}
+
+func TestConvertPackagesAltSyntax(t *testing.T) {
+	t.Parallel()
+
+	var intf interface{} = []interface{}{
+		map[string]interface{}{
+			"name":   "authmodels",
+			"output": "internal/authmodels",
+			"tables": []interface{}{"users"},
+		},
+	}
+
+	packages := ConvertPackages(intf)
+	if len(packages) != 1 {
+		t.Fatal("should have one entry")
+	}
+
+	if p := packages[0]; p.Name != "authmodels" || p.Output != "internal/authmodels" || len(p.Tables) != 1 || p.Tables[0] != "users" {
+		t.Error("value was wrong:", p)
+	}
+}
+
+func TestConvertDTOs(t *testing.T) {
+	t.Parallel()
+
+	var intf interface{} = map[string]interface{}{
+		"PilotView": map[string]interface{}{
+			"table":  "pilots",
+			"type":   "example.com/app/api.Pilot",
+			"nulls":  "zero",
+			"fields": map[string]interface{}{"name": "FullName"},
+			"ignore": []interface{}{"password"},
+		},
+		"JetView": map[string]interface{}{
+			"table": "jets",
+			"type":  "JetView",
+		},
+	}
+
+	dtos := ConvertDTOs(intf)
+	if len(dtos) != 2 {
+		t.Fatal("should have two entries")
+	}
+
+	if d := dtos[0]; d.Name != "JetView" || d.Table != "jets" || d.Type != "JetView" || len(d.Fields) != 0 {
+		t.Error("value was wrong:", d)
+	}
+	d := dtos[1]
+	if d.Name != "PilotView" || d.Table != "pilots" || d.Type != "example.com/app/api.Pilot" || d.Nulls != "zero" {
+		t.Error("value was wrong:", d)
+	}
+	if d.Fields["name"] != "FullName" || len(d.Ignore) != 1 || d.Ignore[0] != "password" {
+		t.Error("value was wrong:", d)
+	}
+}
+
+func TestConvertJoins(t *testing.T) {
+	t.Parallel()
+
+	var intf interface{} = []interface{}{
+		map[string]interface{}{
+			"name":          "UserProfile",
+			"table":         "users",
+			"foreign_table": "profiles",
+		},
+		map[string]interface{}{
+			"name":          "TransferSender",
+			"table":         "transfers",
+			"foreign_table": "users",
+			"foreign_key":   "transfers_from_fkey",
+		},
+	}
+
+	joins := ConvertJoins(intf)
+	if len(joins) != 2 {
+		t.Fatal("should have two entries")
+	}
+
+	if j := joins[0]; j.Name != "TransferSender" || j.Table != "transfers" || j.ForeignTable != "users" || j.ForeignKey != "transfers_from_fkey" {
+		t.Error("value was wrong:", j)
+	}
+	if j := joins[1]; j.Name != "UserProfile" || j.Table != "users" || j.ForeignTable != "profiles" || len(j.ForeignKey) != 0 {
+		t.Error("value was wrong:", j)
+	}
+}
+
+func TestConvertPolymorphics(t *testing.T) {
+	t.Parallel()
+
+	var intf interface{} = map[string]interface{}{
+		"commentable": map[string]interface{}{
+			"table":   "comments",
+			"targets": []interface{}{"videos", "posts"},
+		},
+		"owner": map[string]interface{}{
+			"table":       "comments",
+			"id_column":   "owner_ref",
+			"type_column": "owner_kind",
+			"targets": map[string]interface{}{
+				"videos": "Clip",
+				"posts":  "BlogPost",
+			},
+		},
+	}
+
+	polymorphics := ConvertPolymorphics(intf)
+	if len(polymorphics) != 2 {
+		t.Fatal("should have two entries")
+	}
+
+	p := polymorphics[0]
+	if p.Name != "commentable" || p.Table != "comments" || len(p.IDColumn) != 0 || len(p.TypeColumn) != 0 {
+		t.Error("value was wrong:", p)
+	}
+	if want := []PolymorphicTarget{{Table: "videos"}, {Table: "posts"}}; !reflect.DeepEqual(p.Targets, want) {
+		t.Errorf("want the targets in order: %#v, got: %#v", want, p.Targets)
+	}
+
+	p = polymorphics[1]
+	if p.Name != "owner" || p.IDColumn != "owner_ref" || p.TypeColumn != "owner_kind" {
+		t.Error("value was wrong:", p)
+	}
+	if want := []PolymorphicTarget{{Type: "BlogPost", Table: "posts"}, {Type: "Clip", Table: "videos"}}; !reflect.DeepEqual(p.Targets, want) {
+		t.Errorf("want the targets sorted by table: %#v, got: %#v", want, p.Targets)
+	}
+}
+
+func TestConvertInheritances(t *testing.T) {
+	t.Parallel()
+
+	var intf interface{} = map[string]interface{}{
+		"vehicles": map[string]interface{}{
+			"column": "kind",
+			"subtypes": map[string]interface{}{
+				"pickup_truck": "pickup",
+				"car":          "Car",
+			},
+		},
+		"animals": map[string]interface{}{
+			"subtypes": []interface{}{"Dog", "Cat"},
+		},
+	}
+
+	inheritances := ConvertInheritances(intf)
+	if len(inheritances) != 2 {
+		t.Fatal("should have two entries")
+	}
+
+	inh := inheritances[0]
+	if inh.Table != "animals" || len(inh.Column) != 0 {
+		t.Error("value was wrong:", inh)
+	}
+	if want := []Subtype{{Name: "Dog"}, {Name: "Cat"}}; !reflect.DeepEqual(inh.Subtypes, want) {
+		t.Errorf("want the subtypes in order: %#v, got: %#v", want, inh.Subtypes)
+	}
+
+	inh = inheritances[1]
+	if inh.Table != "vehicles" || inh.Column != "kind" {
+		t.Error("value was wrong:", inh)
+	}
+	if want := []Subtype{{Name: "car", Value: "Car"}, {Name: "pickup_truck", Value: "pickup"}}; !reflect.DeepEqual(inh.Subtypes, want) {
+		t.Errorf("want the subtypes sorted by name: %#v, got: %#v", want, inh.Subtypes)
+	}
+
+	inheritances = ConvertInheritances([]interface{}{
+		map[string]interface{}{"table": "vehicles", "subtypes": []interface{}{"Car"}},
+	})
+	if len(inheritances) != 1 || inheritances[0].Table != "vehicles" || len(inheritances[0].Subtypes) != 1 {
+		t.Error("value was wrong:", inheritances)
+	}
+}
+
+func TestConvertTableMeta(t *testing.T) {
+	t.Parallel()
+
+	meta := ConvertTableMeta(map[string]interface{}{
+		"pilots": map[string]interface{}{"owner": "flight-ops", "tier": 1},
+	})
+	if meta["pilots"]["owner"] != "flight-ops" || meta["pilots"]["tier"] != 1 {
+		t.Errorf("metadata was wrong: %#v", meta)
+	}
+
+	meta = ConvertTableMeta([]interface{}{
+		map[string]interface{}{"name": "jets", "owner": "hangar"},
+	})
+	if meta["jets"]["owner"] != "hangar" {
+		t.Errorf("metadata was wrong: %#v", meta)
+	}
+}
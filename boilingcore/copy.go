@@ -0,0 +1,53 @@
+package boilingcore
+
+import (
+	"fmt"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+// copySlices are the Go types of columns that are slices of values, copied
+// with append, by the fields holding them
+var copySlices = map[string][2]string{
+	"[]byte":             {"", "[]byte"},
+	"types.JSON":         {"", "types.JSON"},
+	"EncryptedBytes":     {"", "EncryptedBytes"},
+	"null.Bytes":         {".Bytes", "[]byte"},
+	"null.JSON":          {".JSON", "[]byte"},
+	"NullEncryptedBytes": {".Bytes", "[]byte"},
+	"types.BoolArray":    {"", "types.BoolArray"},
+	"types.Int64Array":   {"", "types.Int64Array"},
+	"types.Float64Array": {"", "types.Float64Array"},
+	"types.StringArray":  {"", "types.StringArray"},
+	"pgeo.Polygon":       {"", "pgeo.Polygon"},
+	"pgeo.NullPolygon":   {".Polygon", "pgeo.Polygon"},
+	"pgeo.Path":          {".Points", "[]pgeo.Point"},
+	"pgeo.NullPath":      {".Path.Points", "[]pgeo.Point"},
+}
+
+// copyColumn returns the Go statements copying the memory the value src of
+// the column shares with dst once dst was assigned src, empty when the
+// assignment copies it all. Replaced types are left to the assignment.
+func copyColumn(dst, src string, c drivers.Column) string {
+	if v, ok := copySlices[c.Type]; ok {
+		field, typ := v[0], v[1]
+		return fmt.Sprintf("if %[2]s%[3]s != nil {\n%[1]s%[3]s = append(make(%[4]s, 0, len(%[2]s%[3]s)), %[2]s%[3]s...)\n}",
+			dst, src, field, typ)
+	}
+
+	switch c.Type {
+	case "types.Decimal", "types.NullDecimal":
+		return fmt.Sprintf("%s = %s.Clone()", dst, src)
+	case "types.BytesArray":
+		return fmt.Sprintf("if %[2]s != nil {\n%[1]s = make(types.BytesArray, len(%[2]s))\n"+
+			"for i, b := range %[2]s {\nif b != nil {\n%[1]s[i] = append(make([]byte, 0, len(b)), b...)\n}\n}\n}", dst, src)
+	case "types.DecimalArray":
+		return fmt.Sprintf("if %[2]s != nil {\n%[1]s = make(types.DecimalArray, len(%[2]s))\n"+
+			"for i, d := range %[2]s {\n%[1]s[i] = d.Clone()\n}\n}", dst, src)
+	case "types.HStore":
+		return fmt.Sprintf("if %[2]s != nil {\n%[1]s = make(types.HStore, len(%[2]s))\n"+
+			"for k, v := range %[2]s {\n%[1]s[k] = v\n}\n}", dst, src)
+	}
+
+	return ""
+}
@@ -0,0 +1,32 @@
+package boilingcore
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestCopyColumn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		typ  string
+		want string
+	}{
+		{"int", ""},
+		{"null.String", ""},
+		{"time.Time", ""},
+		{"uuid.UUID", ""},
+		{"[]byte", "if b != nil {\na = append(make([]byte, 0, len(b)), b...)\n}"},
+		{"null.JSON", "if b.JSON != nil {\na.JSON = append(make([]byte, 0, len(b.JSON)), b.JSON...)\n}"},
+		{"pgeo.NullPath", "if b.Path.Points != nil {\na.Path.Points = append(make([]pgeo.Point, 0, len(b.Path.Points)), b.Path.Points...)\n}"},
+		{"types.NullDecimal", "a = b.Clone()"},
+		{"types.DecimalArray", "if b != nil {\na = make(types.DecimalArray, len(b))\nfor i, d := range b {\na[i] = d.Clone()\n}\n}"},
+	}
+
+	for _, test := range tests {
+		if got := copyColumn("a", "b", drivers.Column{Type: test.typ}); got != test.want {
+			t.Errorf("%s: want %q, got: %q", test.typ, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package boilingcore
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/volatiletech/strmangle"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// csvTemplate is the singleton with the CSV formatting and parsing helpers,
+// it names its entry in the singleton imports
+const csvTemplate = "boil_csv"
+
+// csvBases maps the Go types of columns to the helpers formatting and parsing
+// them, and the Go type of the values of the helpers
+var csvBases = map[string]struct{ Helper, Type, Bits string }{
+	"int":       {"int", "int64", "64"},
+	"int8":      {"int", "int64", "8"},
+	"int16":     {"int", "int64", "16"},
+	"int32":     {"int", "int64", "32"},
+	"int64":     {"int", "int64", "64"},
+	"uint":      {"uint", "uint64", "64"},
+	"uint8":     {"uint", "uint64", "8"},
+	"byte":      {"uint", "uint64", "8"},
+	"uint16":    {"uint", "uint64", "16"},
+	"uint32":    {"uint", "uint64", "32"},
+	"uint64":    {"uint", "uint64", "64"},
+	"float32":   {"float", "float64", "32"},
+	"float64":   {"float", "float64", "64"},
+	"bool":      {"bool", "bool", ""},
+	"string":    {"string", "string", ""},
+	"time.Time": {"time", "time.Time", ""},
+	"[]byte":    {"bytes", "[]byte", ""},
+}
+
+// csvNullBases are the Go types of the values of the null package types, and
+// the names of their fields holding them
+var csvNullBases = map[string]string{
+	"null.Int":     "int",
+	"null.Int8":    "int8",
+	"null.Int16":   "int16",
+	"null.Int32":   "int32",
+	"null.Int64":   "int64",
+	"null.Uint":    "uint",
+	"null.Uint8":   "uint8",
+	"null.Uint16":  "uint16",
+	"null.Uint32":  "uint32",
+	"null.Uint64":  "uint64",
+	"null.Byte":    "byte",
+	"null.Float32": "float32",
+	"null.Float64": "float64",
+	"null.Bool":    "bool",
+	"null.String":  "string",
+	"null.Time":    "time.Time",
+	"null.Bytes":   "[]byte",
+}
+
+// csvJSONTypes are the array types written to CSV fields as JSON
+var csvJSONTypes = map[string]bool{
+	"types.Int64Array":   true,
+	"types.Float64Array": true,
+	"types.BoolArray":    true,
+	"types.StringArray":  true,
+	"types.BytesArray":   true,
+}
+
+// CSVRecord is the CSV record of the model of a table
+type CSVRecord struct {
+	Columns []CSVColumn
+	// Required are the indexes of the columns that cannot be empty, separated
+	// by commas
+	Required string
+}
+
+// CSVColumn is a column of the CSV records of a model
+type CSVColumn struct {
+	Name  string
+	Index int
+
+	// Format is the field of the column of the model o
+	Format string
+	// Parse sets the column of the model o from the record read by the
+	// parser p, and Check validates it
+	Parse string
+	Check string
+	// Required columns cannot be empty, they are the columns that are not
+	// nullable except for strings and arrays
+	Required bool
+}
+
+// initCSV builds the CSV record of every table and sets the imports of the
+// CSV helpers
+func (s *State) initCSV() error {
+	if !s.Config.AddCSV {
+		return nil
+	}
+
+	s.CSVRecords = make(map[string]CSVRecord)
+	for _, t := range s.Tables {
+		if t.IsJoinTable {
+			continue
+		}
+
+		alias := s.Config.Aliases.Table(t.Name)
+		var record CSVRecord
+		var required []string
+		for _, c := range t.Columns {
+			col, ok := s.csvColumn(t, c, alias.Column(c.Name), len(record.Columns))
+			if !ok {
+				continue
+			}
+			record.Columns = append(record.Columns, col)
+			if col.Required {
+				required = append(required, strconv.Itoa(col.Index))
+			}
+		}
+		record.Required = strings.Join(required, ", ")
+
+		s.CSVRecords[t.Name] = record
+	}
+
+	s.Config.Imports.All.Standard = append(s.Config.Imports.All.Standard, `"encoding/csv"`, `"io"`)
+
+	imps := importers.Set{
+		Standard:   importers.List{`"encoding/base64"`, `"encoding/json"`, `"strconv"`, `"time"`},
+		ThirdParty: importers.List{`"github.com/friendsofgo/errors"`},
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[csvTemplate] = imps
+
+	return nil
+}
+
+// csvColumn returns the formatting and parsing of the column at the index of
+// the records, it is false for columns of types without them
+func (s *State) csvColumn(t drivers.Table, c drivers.Column, goField string, index int) (CSVColumn, bool) {
+	col := CSVColumn{Name: c.Name, Index: index, Required: !c.Nullable}
+	o := "o." + goField
+
+	// format and parse return the helpers formatting and parsing the value
+	// of the Go type base
+	format := func(base, value string) string {
+		h := csvBases[base]
+		switch h.Helper {
+		case "string":
+			return value
+		case "float":
+			return fmt.Sprintf("csvFloat(%s, %s)", protoConvert(h.Type, base, value), h.Bits)
+		}
+		return fmt.Sprintf("csv%s(%s)", strmangle.TitleCase(h.Helper), protoConvert(h.Type, base, value))
+	}
+	parse := func(base string) string {
+		h := csvBases[base]
+		args := fmt.Sprint(index)
+		if len(h.Bits) != 0 {
+			args += ", " + h.Bits
+		}
+		return protoConvert(base, h.Type, fmt.Sprintf("p.%s(%s)", h.Helper, args))
+	}
+
+	switch {
+	case s.Config.AddEnumTypes && drivers.IsEnumDBType(c.DBType) && c.Type != "string" && c.Type != "null.String":
+		enumName := titleCase(strmangle.ParseEnumName(c.DBType))
+		if len(enumName) == 0 {
+			enumName = titleCase(t.Name) + titleCase(c.Name)
+		}
+		if c.Nullable {
+			col.Format = fmt.Sprintf("csvNull(%s.Valid, string(%s.Val))", o, o)
+			col.Parse = fmt.Sprintf("%s = New%s(%s(p.string(%d)), p.valid(%d))", o, c.Type, enumName, index, index)
+			col.Check = fmt.Sprintf("p.check(%d, %s.Valid, %s.Val.IsValid())", index, o, o)
+		} else {
+			col.Format = "string(" + o + ")"
+			col.Parse = fmt.Sprintf("%s = %s(p.string(%d))", o, enumName, index)
+			col.Check = fmt.Sprintf("p.check(%d, true, %s.IsValid())", index, o)
+		}
+	case c.Type == "types.JSON":
+		col.Format = "string(" + o + ")"
+		col.Parse = fmt.Sprintf("%s = types.JSON(p.json(%d))", o, index)
+	case c.Type == "null.JSON":
+		col.Format = fmt.Sprintf("csvNull(%s.Valid, string(%s.JSON))", o, o)
+		col.Parse = fmt.Sprintf("%s = null.NewJSON(p.json(%d), p.valid(%d))", o, index, index)
+	case c.Type == "types.Decimal":
+		col.Format = fmt.Sprintf("csvNull(%s.Big != nil, %s.String())", o, o)
+		col.Parse = fmt.Sprintf("p.unmarshal(%d, &%s)", index, o)
+	case c.Type == "types.NullDecimal":
+		col.Format = fmt.Sprintf("csvNull(%s.Big != nil, %s.String())", o, o)
+		col.Parse = fmt.Sprintf("p.unmarshal(%d, &%s)", index, o)
+	case csvJSONTypes[c.Type]:
+		col.Format = "csvJSON(" + o + ")"
+		col.Parse = fmt.Sprintf("p.unmarshal(%d, &%s)", index, o)
+		// Empty fields are nil arrays
+		col.Required = false
+	case csvNullBases[c.Type] != "":
+		base := csvNullBases[c.Type]
+		suffix := strings.TrimPrefix(c.Type, "null.")
+		col.Format = fmt.Sprintf("csvNull(%s.Valid, %s)", o, format(base, o+"."+suffix))
+		col.Parse = fmt.Sprintf("%s = null.New%s(%s, p.valid(%d))", o, suffix, parse(base), index)
+	case csvBases[c.Type].Helper != "":
+		col.Format = format(c.Type, o)
+		col.Parse = fmt.Sprintf("%s = %s", o, parse(c.Type))
+		// Empty fields are empty strings
+		col.Required = col.Required && c.Type != "string"
+	default:
+		return CSVColumn{}, false
+	}
+
+	return col, true
+}
@@ -0,0 +1,74 @@
+package boilingcore
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestInitCSV(t *testing.T) {
+	t.Parallel()
+
+	tables := []drivers.Table{
+		{
+			Name: "pilots",
+			Columns: []drivers.Column{
+				{Name: "id", Type: "int"},
+				{Name: "name", Type: "string"},
+				{Name: "rating", Type: "null.Float32", Nullable: true},
+				{Name: "born_at", Type: "time.Time"},
+				{Name: "tags", Type: "types.StringArray"},
+				{Name: "rank", Type: "Rank", DBType: "enum.rank('a','b')"},
+				{Name: "salary", Type: "types.NullDecimal", Nullable: true},
+				{Name: "point", Type: "pgeo.Point"},
+			},
+		},
+		{
+			Name:        "pilot_jets",
+			IsJoinTable: true,
+			FKeys: []drivers.ForeignKey{
+				{Name: "pilot_jets_pilot_fkey", Table: "pilot_jets", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"},
+				{Name: "pilot_jets_jet_fkey", Table: "pilot_jets", Column: "jet_id", ForeignTable: "jets", ForeignColumn: "id"},
+			},
+		},
+	}
+
+	s := &State{
+		Config: &Config{PkgName: "models", AddCSV: true, AddEnumTypes: true},
+		Tables: tables,
+	}
+	FillAliases(&s.Config.Aliases, tables)
+	if err := s.initCSV(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := s.CSVRecords["pilot_jets"]; ok {
+		t.Error("join tables have no record")
+	}
+
+	record := s.CSVRecords["pilots"]
+	want := []CSVColumn{
+		{Name: "id", Index: 0, Format: "csvInt(int64(o.ID))", Parse: "o.ID = int(p.int(0, 64))", Required: true},
+		{Name: "name", Index: 1, Format: "o.Name", Parse: "o.Name = p.string(1)"},
+		{Name: "rating", Index: 2, Format: "csvNull(o.Rating.Valid, csvFloat(float64(o.Rating.Float32), 32))", Parse: "o.Rating = null.NewFloat32(float32(p.float(2, 32)), p.valid(2))"},
+		{Name: "born_at", Index: 3, Format: "csvTime(o.BornAt)", Parse: "o.BornAt = p.time(3)", Required: true},
+		{Name: "tags", Index: 4, Format: "csvJSON(o.Tags)", Parse: "p.unmarshal(4, &o.Tags)"},
+		{Name: "rank", Index: 5, Format: "string(o.Rank)", Parse: "o.Rank = Rank(p.string(5))", Check: "p.check(5, true, o.Rank.IsValid())", Required: true},
+		{Name: "salary", Index: 6, Format: "csvNull(o.Salary.Big != nil, o.Salary.String())", Parse: "p.unmarshal(6, &o.Salary)"},
+	}
+	if len(record.Columns) != len(want) {
+		t.Fatalf("want %d columns without point, got: %#v", len(want), record.Columns)
+	}
+	for i, w := range want {
+		if record.Columns[i] != w {
+			t.Errorf("%s:\nwant %#v\ngot  %#v", w.Name, w, record.Columns[i])
+		}
+	}
+	if record.Required != "0, 3, 5" {
+		t.Errorf("want the required columns 0, 3, 5, got: %s", record.Required)
+	}
+
+	if _, ok := s.Config.Imports.Singleton[csvTemplate]; !ok {
+		t.Error("want the imports of the CSV helpers")
+	}
+}
@@ -0,0 +1,279 @@
+package boilingcore
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/strmangle"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// customQueriesTemplate is the singleton that custom queries are generated
+// into, it names its entry in the singleton imports
+const customQueriesTemplate = "boil_custom_queries"
+
+var (
+	rgxQueryName  = regexp.MustCompile(`^--\s*name:\s*(\S+)\s+:(\w+)\s*$`)
+	rgxQueryParam = regexp.MustCompile(`^--\s*param:\s*(\w+)\s+(\S+)\s*$`)
+	rgxQueryTable = regexp.MustCompile("(?i)\\b(?:from|update|into)\\s+([\"`\\[]?[\\w.]+[\"`\\]]?)")
+	rgxQueryArg   = regexp.MustCompile(`@(\w+)`)
+	rgxGoIdent    = regexp.MustCompile(`^[A-Z][A-Za-z0-9_]*$`)
+)
+
+// CustomQuery is a query written by hand in an annotated .sql file of the
+// queries directory, a typed function is generated to run it.
+type CustomQuery struct {
+	// Name of the generated function
+	Name string
+	// Kind is what the function returns: one, many, exec or execrows
+	Kind string
+	// Comment lines written between the name annotation and the query
+	Comment []string
+	// File the query was read from
+	File string
+	// SQL with its named parameters replaced by placeholders
+	SQL string
+	// Table is the table the query runs against, its model is returned by
+	// one and many queries
+	Table string
+	// Params of the generated function in the order they first appear
+	Params []CustomQueryParam
+	// Args are the Go names of the params passed for each placeholder
+	Args []string
+
+	// declared are the types given with -- param: name type
+	declared map[string]string
+}
+
+// CustomQueryParam is a named parameter of a custom query
+type CustomQueryParam struct {
+	// Name is the name in the query, without @
+	Name string
+	// GoName is the name of the function argument
+	GoName string
+	// Type is the Go type of the function argument
+	Type string
+}
+
+// loadCustomQueries reads the annotated .sql files of the queries directory
+// and resolves the tables and parameter types of their queries.
+func (s *State) loadCustomQueries() error {
+	if len(s.Config.QueriesDir) == 0 {
+		return nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(s.Config.QueriesDir, "*.sql"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	names := make(map[string]string)
+	var types []string
+	returnsOne := false
+	for _, file := range files {
+		parsed, err := parseQueryFile(file)
+		if err != nil {
+			return err
+		}
+
+		for i := range parsed {
+			q := &parsed[i]
+			if other, ok := names[q.Name]; ok {
+				return errors.Errorf("query %s in %s is also defined in %s", q.Name, file, other)
+			}
+			names[q.Name] = file
+
+			if err := s.resolveCustomQuery(q); err != nil {
+				return errors.Wrapf(err, "query %s in %s", q.Name, file)
+			}
+			for _, p := range q.Params {
+				types = append(types, p.Type)
+			}
+			returnsOne = returnsOne || q.Kind == "one"
+		}
+
+		s.CustomQueries = append(s.CustomQueries, parsed...)
+	}
+
+	imps := importers.Set{
+		ThirdParty: importers.List{`"github.com/friendsofgo/errors"`, `"github.com/volatiletech/sqlboiler/v4/boil"`, `"github.com/volatiletech/sqlboiler/v4/queries"`},
+	}
+	// sql.ErrNoRows is returned as is by :one queries
+	if returnsOne && !s.Config.AlwaysWrapErrors {
+		imps.Standard = append(imps.Standard, `"database/sql"`)
+	}
+	if !s.Config.NoContext {
+		imps.Standard = append(imps.Standard, `"context"`)
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[customQueriesTemplate] = importers.AddTypeImports(imps, s.Config.Imports.BasedOnType, types)
+
+	return nil
+}
+
+// parseQueryFile splits a .sql file into its queries, every query starts
+// with a "-- name: Name :kind" line and runs until the next one.
+func parseQueryFile(file string) ([]CustomQuery, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var parsed []CustomQuery
+	var body []string
+	inHeader := false
+
+	finish := func() error {
+		if len(parsed) == 0 {
+			return nil
+		}
+		q := &parsed[len(parsed)-1]
+		sql := strings.TrimSuffix(strings.TrimSpace(strings.Join(body, "\n")), ";")
+		if len(sql) == 0 {
+			return errors.Errorf("query %s in %s has no sql", q.Name, file)
+		}
+		q.SQL = sql
+		return nil
+	}
+
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if m := rgxQueryName.FindStringSubmatch(line); m != nil {
+			if err := finish(); err != nil {
+				return nil, err
+			}
+
+			name, kind := m[1], m[2]
+			if !rgxGoIdent.MatchString(name) {
+				return nil, errors.Errorf("%s:%d: query name %q must be an exported Go identifier", file, lineNum, name)
+			}
+			switch kind {
+			case "one", "many", "exec", "execrows":
+			default:
+				return nil, errors.Errorf("%s:%d: query %s has unknown kind :%s, use :one, :many, :exec or :execrows", file, lineNum, name, kind)
+			}
+
+			parsed = append(parsed, CustomQuery{Name: name, Kind: kind, File: filepath.Base(file), declared: make(map[string]string)})
+			body, inHeader = nil, true
+			continue
+		}
+
+		if len(parsed) == 0 {
+			continue
+		}
+
+		if inHeader {
+			q := &parsed[len(parsed)-1]
+			if m := rgxQueryParam.FindStringSubmatch(line); m != nil {
+				q.declared[m[1]] = m[2]
+				continue
+			}
+			if strings.HasPrefix(line, "--") {
+				q.Comment = append(q.Comment, strings.TrimSpace(strings.TrimPrefix(line, "--")))
+				continue
+			}
+			if len(line) == 0 {
+				continue
+			}
+			inHeader = false
+		}
+
+		body = append(body, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := finish(); err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+// resolveCustomQuery finds the table of q, gives its params a type and
+// replaces them with the dialect's placeholders
+func (s *State) resolveCustomQuery(q *CustomQuery) error {
+	var table *drivers.Table
+	if m := rgxQueryTable.FindStringSubmatch(q.SQL); m != nil {
+		name := strings.Trim(m[1], "\"`[]")
+		if i := strings.LastIndexByte(name, '.'); i >= 0 {
+			name = name[i+1:]
+		}
+		for i := range s.Tables {
+			if s.Tables[i].Name == name {
+				table = &s.Tables[i]
+				break
+			}
+		}
+	}
+
+	if q.Kind == "one" || q.Kind == "many" {
+		if table == nil || table.IsJoinTable {
+			return errors.New("unable to find the table whose model it returns, :one and :many queries must select from a generated table")
+		}
+	}
+	if table != nil {
+		q.Table = table.Name
+	}
+
+	index := make(map[string]int)
+	var err error
+	q.SQL = rgxQueryArg.ReplaceAllStringFunc(q.SQL, func(arg string) string {
+		name := arg[1:]
+
+		if _, ok := index[name]; !ok {
+			typ, ok := q.declared[name]
+			if !ok && table != nil {
+				for _, c := range table.Columns {
+					if c.Name == name {
+						typ, ok = c.Type, true
+						break
+					}
+				}
+			}
+			if !ok {
+				err = errors.Errorf("unable to find the type of @%s, name it after a column of the table or declare it with -- param: %s <type>", name, name)
+				return arg
+			}
+
+			index[name] = len(q.Params)
+			q.Params = append(q.Params, CustomQueryParam{
+				Name:   name,
+				GoName: strmangle.ReplaceReservedWords(camelCase(name)),
+				Type:   typ,
+			})
+		}
+
+		if !s.Dialect.UseIndexPlaceholders {
+			q.Args = append(q.Args, q.Params[index[name]].GoName)
+			return "?"
+		}
+		return "$" + strconv.Itoa(index[name]+1)
+	})
+	if err != nil {
+		return err
+	}
+
+	if s.Dialect.UseIndexPlaceholders {
+		for _, p := range q.Params {
+			q.Args = append(q.Args, p.GoName)
+		}
+	}
+
+	return nil
+}
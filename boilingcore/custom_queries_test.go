@@ -0,0 +1,140 @@
+package boilingcore
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestParseQueryFile(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "pilots.sql")
+	contents := `-- a comment before the first query is ignored
+
+-- name: PilotsByName :many
+-- PilotsByName finds pilots
+-- param: name string
+SELECT *
+FROM pilots WHERE name = @name;
+
+-- name: DeletePilot :exec
+DELETE FROM pilots WHERE id = @id;
+`
+	if err := os.WriteFile(file, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := parseQueryFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []CustomQuery{
+		{
+			Name:     "PilotsByName",
+			Kind:     "many",
+			Comment:  []string{"PilotsByName finds pilots"},
+			File:     "pilots.sql",
+			SQL:      "SELECT *\nFROM pilots WHERE name = @name",
+			declared: map[string]string{"name": "string"},
+		},
+		{
+			Name:     "DeletePilot",
+			Kind:     "exec",
+			File:     "pilots.sql",
+			SQL:      "DELETE FROM pilots WHERE id = @id",
+			declared: map[string]string{},
+		},
+	}
+	if !reflect.DeepEqual(parsed, want) {
+		t.Errorf("want:\n%#v\ngot:\n%#v", want, parsed)
+	}
+}
+
+func TestParseQueryFileErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"unknown kind":   "-- name: Pilots :all\nSELECT * FROM pilots",
+		"unexported":     "-- name: pilots :many\nSELECT * FROM pilots",
+		"missing query":  "-- name: Pilots :many\n-- name: Jets :many\nSELECT * FROM jets",
+		"missing at end": "-- name: Pilots :many\n",
+	}
+
+	for name, contents := range tests {
+		file := filepath.Join(t.TempDir(), "queries.sql")
+		if err := os.WriteFile(file, []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := parseQueryFile(file); err == nil {
+			t.Errorf("%s: want an error", name)
+		}
+	}
+}
+
+func TestResolveCustomQuery(t *testing.T) {
+	t.Parallel()
+
+	s := &State{
+		Tables: []drivers.Table{{
+			Name: "pilots",
+			Columns: []drivers.Column{
+				{Name: "id", Type: "int"},
+				{Name: "name", Type: "null.String"},
+			},
+		}},
+	}
+
+	q := CustomQuery{
+		Kind:     "many",
+		SQL:      "SELECT * FROM `pilots` WHERE name = @name OR @name IS NULL AND id > @min_id AND type = @type",
+		declared: map[string]string{"min_id": "int64", "type": "string"},
+	}
+	if err := s.resolveCustomQuery(&q); err != nil {
+		t.Fatal(err)
+	}
+	if q.Table != "pilots" {
+		t.Error("wrong table:", q.Table)
+	}
+	if want := "SELECT * FROM `pilots` WHERE name = ? OR ? IS NULL AND id > ? AND type = ?"; q.SQL != want {
+		t.Error("wrong sql:", q.SQL)
+	}
+	wantParams := []CustomQueryParam{
+		{Name: "name", GoName: "name", Type: "null.String"},
+		{Name: "min_id", GoName: "minID", Type: "int64"},
+		{Name: "type", GoName: "type_", Type: "string"},
+	}
+	if !reflect.DeepEqual(q.Params, wantParams) {
+		t.Errorf("wrong params: %#v", q.Params)
+	}
+	if want := []string{"name", "name", "minID", "type_"}; !reflect.DeepEqual(q.Args, want) {
+		t.Error("wrong args:", q.Args)
+	}
+
+	s.Dialect.UseIndexPlaceholders = true
+	q = CustomQuery{Kind: "exec", SQL: "UPDATE schema.pilots SET name = @name WHERE id = @id OR @name IS NULL"}
+	if err := s.resolveCustomQuery(&q); err != nil {
+		t.Fatal(err)
+	}
+	if want := "UPDATE schema.pilots SET name = $1 WHERE id = $2 OR $1 IS NULL"; q.SQL != want {
+		t.Error("wrong sql:", q.SQL)
+	}
+	if want := []string{"name", "id"}; !reflect.DeepEqual(q.Args, want) {
+		t.Error("wrong args:", q.Args)
+	}
+
+	q = CustomQuery{Kind: "one", SQL: "SELECT * FROM jets"}
+	if err := s.resolveCustomQuery(&q); err == nil || !strings.Contains(err.Error(), "table") {
+		t.Error("want an error for a model that is not generated, got:", err)
+	}
+
+	q = CustomQuery{Kind: "exec", SQL: "UPDATE pilots SET name = @new_name"}
+	if err := s.resolveCustomQuery(&q); err == nil || !strings.Contains(err.Error(), "@new_name") {
+		t.Error("want an error for a param without a type, got:", err)
+	}
+}
@@ -0,0 +1,29 @@
+package boilingcore
+
+import (
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// dataloaderTemplate is the singleton with the Loader of the relationships,
+// it names its entry in the singleton imports
+const dataloaderTemplate = "boil_dataloader"
+
+// initDataloader sets the imports of the Loader of the relationships
+func (s *State) initDataloader() {
+	if !s.Config.WithDataloader {
+		return
+	}
+
+	imps := importers.Set{
+		Standard: importers.List{`"context"`, `"sync"`, `"time"`},
+		ThirdParty: importers.List{
+			`"github.com/volatiletech/sqlboiler/v4/boil"`,
+			`"github.com/volatiletech/sqlboiler/v4/queries"`,
+			`"github.com/volatiletech/sqlboiler/v4/queries/qm"`,
+		},
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[dataloaderTemplate] = imps
+}
@@ -0,0 +1,124 @@
+package boilingcore
+
+import (
+	"fmt"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+// diffComparable are the Go types of columns whose values are equal when ==
+// says so
+var diffComparable = map[string]bool{
+	"int":                    true,
+	"int8":                   true,
+	"int16":                  true,
+	"int32":                  true,
+	"int64":                  true,
+	"uint":                   true,
+	"uint8":                  true,
+	"byte":                   true,
+	"uint16":                 true,
+	"uint32":                 true,
+	"uint64":                 true,
+	"float32":                true,
+	"float64":                true,
+	"bool":                   true,
+	"string":                 true,
+	"types.Byte":             true,
+	"mssql.UniqueIdentifier": true,
+	"pgeo.Point":             true,
+	"pgeo.Box":               true,
+	"pgeo.Circle":            true,
+	"pgeo.Line":              true,
+	"pgeo.Lseg":              true,
+	"EncryptedString":        true,
+}
+
+// diffBytes are the Go types of columns holding bytes
+var diffBytes = map[string]bool{
+	"[]byte":         true,
+	"types.JSON":     true,
+	"EncryptedBytes": true,
+}
+
+// diffNullValues are the fields and the Go types of the values of the
+// nullable types, which are only compared when both are valid
+var diffNullValues = map[string][2]string{
+	"null.Int":            {"Int", "int"},
+	"null.Int8":           {"Int8", "int8"},
+	"null.Int16":          {"Int16", "int16"},
+	"null.Int32":          {"Int32", "int32"},
+	"null.Int64":          {"Int64", "int64"},
+	"null.Uint":           {"Uint", "uint"},
+	"null.Uint8":          {"Uint8", "uint8"},
+	"null.Uint16":         {"Uint16", "uint16"},
+	"null.Uint32":         {"Uint32", "uint32"},
+	"null.Uint64":         {"Uint64", "uint64"},
+	"null.Byte":           {"Byte", "byte"},
+	"null.Float32":        {"Float32", "float32"},
+	"null.Float64":        {"Float64", "float64"},
+	"null.Bool":           {"Bool", "bool"},
+	"null.String":         {"String", "string"},
+	"null.Time":           {"Time", "time.Time"},
+	"null.Bytes":          {"Bytes", "[]byte"},
+	"null.JSON":           {"JSON", "[]byte"},
+	"pgeo.NullPoint":      {"Point", "pgeo.Point"},
+	"pgeo.NullBox":        {"Box", "pgeo.Box"},
+	"pgeo.NullCircle":     {"Circle", "pgeo.Circle"},
+	"pgeo.NullLine":       {"Line", "pgeo.Line"},
+	"pgeo.NullLseg":       {"Lseg", "pgeo.Lseg"},
+	"NullEncryptedString": {"String", "string"},
+	"NullEncryptedBytes":  {"Bytes", "[]byte"},
+}
+
+// diffChanged returns the Go expression telling if the values a and b of the
+// column differ. The known types are compared without reflection, null
+// values are equal whatever their zero values hold, and the other types, like
+// arrays and replaced types, fall back on reflect.DeepEqual.
+func diffChanged(a, b string, c drivers.Column) string {
+	return columnChanged(a, b, c, "")
+}
+
+// equalChanged is diffChanged comparing the times with the TimesEqual method
+// of the boil.EqualOptions named opts
+func equalChanged(a, b string, c drivers.Column) string {
+	return columnChanged(a, b, c, "opts.TimesEqual")
+}
+
+// columnChanged returns the Go expression telling if the values a and b of
+// the column differ, the times are compared with the timesEqual function or
+// with their Equal method when it is empty
+func columnChanged(a, b string, c drivers.Column, timesEqual string) string {
+	if v, ok := diffNullValues[c.Type]; ok {
+		field, typ := v[0], v[1]
+		return fmt.Sprintf("%s.Valid != %s.Valid || (%s.Valid && %s)",
+			a, b, a, diffValuesChanged(a+"."+field, b+"."+field, typ, timesEqual))
+	}
+
+	switch {
+	case c.Type == "types.Decimal" || c.Type == "types.NullDecimal":
+		return fmt.Sprintf("(%s.Big == nil) != (%s.Big == nil) || (%s.Big != nil && %s.Big.Cmp(%s.Big) != 0)",
+			a, b, a, a, b)
+	case diffComparable[c.Type] || diffBytes[c.Type] || c.Type == "time.Time":
+		return diffValuesChanged(a, b, c.Type, timesEqual)
+	case drivers.IsEnumDBType(c.DBType):
+		return fmt.Sprintf("%s != %s", a, b)
+	}
+
+	return fmt.Sprintf("!reflect.DeepEqual(%s, %s)", a, b)
+}
+
+// diffValuesChanged returns the Go expression telling if the values a and b
+// of a known type differ
+func diffValuesChanged(a, b, typ, timesEqual string) string {
+	switch {
+	case typ == "time.Time" && timesEqual != "":
+		return fmt.Sprintf("!%s(%s, %s)", timesEqual, a, b)
+	case typ == "time.Time":
+		return fmt.Sprintf("!%s.Equal(%s)", a, b)
+	case diffBytes[typ]:
+		return fmt.Sprintf("string(%s) != string(%s)", a, b)
+	default:
+		return fmt.Sprintf("%s != %s", a, b)
+	}
+}
@@ -0,0 +1,49 @@
+package boilingcore
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestDiffChanged(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		column drivers.Column
+		want   string
+	}{
+		{drivers.Column{Type: "int64"}, "a != b"},
+		{drivers.Column{Type: "time.Time"}, "!a.Equal(b)"},
+		{drivers.Column{Type: "[]byte"}, "string(a) != string(b)"},
+		{drivers.Column{Type: "types.JSON"}, "string(a) != string(b)"},
+		{drivers.Column{Type: "null.String"}, "a.Valid != b.Valid || (a.Valid && a.String != b.String)"},
+		{drivers.Column{Type: "null.Time"}, "a.Valid != b.Valid || (a.Valid && !a.Time.Equal(b.Time))"},
+		{drivers.Column{Type: "null.JSON"}, "a.Valid != b.Valid || (a.Valid && string(a.JSON) != string(b.JSON))"},
+		{drivers.Column{Type: "NullEncryptedBytes"}, "a.Valid != b.Valid || (a.Valid && string(a.Bytes) != string(b.Bytes))"},
+		{drivers.Column{Type: "types.NullDecimal"}, "(a.Big == nil) != (b.Big == nil) || (a.Big != nil && a.Big.Cmp(b.Big) != 0)"},
+		{drivers.Column{Type: "JetColor", DBType: "enum.jet_color('red','blue')"}, "a != b"},
+		{drivers.Column{Type: "types.StringArray"}, "!reflect.DeepEqual(a, b)"},
+		{drivers.Column{Type: "uuid.UUID"}, "!reflect.DeepEqual(a, b)"},
+	}
+
+	for _, test := range tests {
+		if got := diffChanged("a", "b", test.column); got != test.want {
+			t.Errorf("%s: want %s, got: %s", test.column.Type, test.want, got)
+		}
+	}
+}
+
+func TestEqualChanged(t *testing.T) {
+	t.Parallel()
+
+	if got := equalChanged("a", "b", drivers.Column{Type: "time.Time"}); got != "!opts.TimesEqual(a, b)" {
+		t.Errorf("want the times compared with the options, got: %s", got)
+	}
+	if got := equalChanged("a", "b", drivers.Column{Type: "null.Time"}); got != "a.Valid != b.Valid || (a.Valid && !opts.TimesEqual(a.Time, b.Time))" {
+		t.Errorf("want the null times compared with the options, got: %s", got)
+	}
+	if got := equalChanged("a", "b", drivers.Column{Type: "string"}); got != "a != b" {
+		t.Errorf("want the other types compared like diffs, got: %s", got)
+	}
+}
@@ -0,0 +1,19 @@
+package boilingcore
+
+import "strings"
+
+// markdownCellReplacer escapes the pipes that would end the cells of the
+// tables of the docs
+var markdownCellReplacer = strings.NewReplacer("|", `\|`)
+
+// markdownCell writes s, like a comment or a default of a column, on one
+// line of a markdown table
+func markdownCell(s string) string {
+	return markdownCellReplacer.Replace(strings.Join(strings.Fields(s), " "))
+}
+
+// docsPage is the file name of the page of a table in the docs directory,
+// named like the files of the other per table templates
+func docsPage(table string) string {
+	return getOutputFilename(table, false, false) + ".md"
+}
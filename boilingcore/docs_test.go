@@ -0,0 +1,36 @@
+package boilingcore
+
+import "testing"
+
+func TestMarkdownCell(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In, Out string
+	}{
+		{"", ""},
+		{"the name of the pilot", "the name of the pilot"},
+		{"the name\n  of the\tpilot ", "the name of the pilot"},
+		{"'a'::text || 'b'", `'a'::text \|\| 'b'`},
+	}
+
+	for i, test := range tests {
+		if got := markdownCell(test.In); got != test.Out {
+			t.Errorf("%d) want %q, got %q", i, test.Out, got)
+		}
+	}
+}
+
+func TestDocsPage(t *testing.T) {
+	t.Parallel()
+
+	if got := docsPage("pilots"); got != "pilots.md" {
+		t.Errorf("want the table name, got: %s", got)
+	}
+	if got := docsPage("_jets"); got != "und_jets.md" {
+		t.Errorf("want the file name of the table, got: %s", got)
+	}
+	if got := docsPage("jets_test"); got != "jets_test.md" {
+		t.Errorf("want no build constraint suffix on markdown, got: %s", got)
+	}
+}
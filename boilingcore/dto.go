@@ -0,0 +1,241 @@
+package boilingcore
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/strmangle"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// dtoTemplate is the singleton with the conversions between the models and
+// the DTOs, it names its entry in the singleton imports
+const dtoTemplate = "boil_dtos"
+
+// DTO maps the columns of the model of a table to the fields of a struct,
+// like the struct of an API, to generate the conversions between them
+type DTO struct {
+	// Name names the conversions, To<Name> and From<Name>
+	Name  string `toml:"name,omitempty" json:"name,omitempty"`
+	Table string `toml:"table,omitempty" json:"table,omitempty"`
+	// Type is the import path and the name of the struct, like
+	// example.com/app/api.Pilot, or only its name for a struct of the
+	// package of the models
+	Type string `toml:"type,omitempty" json:"type,omitempty"`
+	// Nulls are pointer, nil for null, or zero, the zero value for null
+	Nulls string `toml:"nulls,omitempty" json:"nulls,omitempty"`
+	// Fields renames the fields of columns, the fields are named like the
+	// fields of the model by default
+	Fields map[string]string `toml:"fields,omitempty" json:"fields,omitempty"`
+	// Ignore are the columns the struct has no field for
+	Ignore []string `toml:"ignore,omitempty" json:"ignore,omitempty"`
+}
+
+// DTOMapper is the conversions between the model of a table and a DTO
+type DTOMapper struct {
+	Name  string
+	Table string
+	// Type is the struct qualified by the alias of its Import
+	Type   string
+	Import string
+	Fields []DTOField
+	// Null is true when the fields are converted with the null package
+	Null bool
+}
+
+// DTOField is the conversion of a column to and from its field, statements
+// on the model o and the DTO d
+type DTOField struct {
+	ToDTO   string
+	FromDTO string
+}
+
+// dtoZeros are the comparisons of the values of the null package types to
+// the zero value of their type
+var dtoZeros = map[string]string{
+	"null.String": `%s != ""`,
+	"null.Bool":   `%s`,
+	"null.Time":   `!%s.IsZero()`,
+}
+
+// initDTOs builds the conversions of the DTOs and sets the imports of their
+// packages
+func (s *State) initDTOs() error {
+	if len(s.Config.DTOs) == 0 {
+		return nil
+	}
+
+	tables := make(map[string]drivers.Table)
+	for _, t := range s.Tables {
+		if !t.IsJoinTable {
+			tables[t.Name] = t
+		}
+	}
+
+	// The null package is imported by the singleton too
+	aliases := map[string]string{"github.com/volatiletech/null/v8": "null"}
+	names := make(map[string]bool)
+	s.DTOMappers = nil
+	for _, dto := range s.Config.DTOs {
+		if !rgxProtoIdent.MatchString(dto.Name) {
+			return errors.Errorf("dto name %q is not a valid identifier", dto.Name)
+		}
+		if names[dto.Table+"."+dto.Name] {
+			return errors.Errorf("dto %s is defined twice for table %s", dto.Name, dto.Table)
+		}
+		names[dto.Table+"."+dto.Name] = true
+
+		t, ok := tables[dto.Table]
+		if !ok {
+			return errors.Errorf("table %s of dto %s was not found", dto.Table, dto.Name)
+		}
+		if len(dto.Type) == 0 {
+			return errors.Errorf("dto %s needs the type of its struct", dto.Name)
+		}
+
+		mapper := DTOMapper{Name: dto.Name, Table: t.Name, Type: dto.Type}
+		if i := strings.LastIndex(dto.Type, "."); i >= 0 {
+			importPath := dto.Type[:i]
+			alias, ok := aliases[importPath]
+			if !ok {
+				alias = dtoAlias(importPath, aliases)
+				aliases[importPath] = alias
+			}
+			mapper.Type = alias + dto.Type[i:]
+			mapper.Import = fmt.Sprintf(`%s "%s"`, alias, importPath)
+		}
+
+		fields, null, err := s.dtoFields(t, dto)
+		if err != nil {
+			return errors.Wrapf(err, "invalid dto %s", dto.Name)
+		}
+		mapper.Fields = fields
+		mapper.Null = null
+
+		s.DTOMappers = append(s.DTOMappers, mapper)
+	}
+
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[dtoTemplate] = dtoImports(s.DTOMappers)
+
+	return nil
+}
+
+// dtoImports returns the imports of the conversions of the mappers
+func dtoImports(mappers []DTOMapper) importers.Set {
+	var imps importers.Set
+	seen := make(map[string]bool)
+	null := false
+	for _, m := range mappers {
+		if len(m.Import) != 0 && !seen[m.Import] {
+			seen[m.Import] = true
+			imps.ThirdParty = append(imps.ThirdParty, m.Import)
+		}
+		null = null || m.Null
+	}
+	if null {
+		imps.ThirdParty = append(imps.ThirdParty, `"github.com/volatiletech/null/v8"`)
+	}
+	return imps
+}
+
+// dtoFields returns the conversions of the columns of the table the dto has
+// fields for, and whether they use the null package
+func (s *State) dtoFields(t drivers.Table, dto DTO) ([]DTOField, bool, error) {
+	switch dto.Nulls {
+	case "", "pointer", "zero":
+	default:
+		return nil, false, errors.Errorf("nulls must be pointer or zero, got: %s", dto.Nulls)
+	}
+
+	columns := make(map[string]bool)
+	for _, c := range t.Columns {
+		columns[c.Name] = true
+	}
+	for _, name := range dto.Ignore {
+		if !columns[name] {
+			return nil, false, errors.Errorf("ignored column %s was not found", name)
+		}
+	}
+	for name := range dto.Fields {
+		if !columns[name] {
+			return nil, false, errors.Errorf("column %s of field %s was not found", name, dto.Fields[name])
+		}
+	}
+
+	alias := s.Config.Aliases.Table(t.Name)
+	var fields []DTOField
+	usesNull := false
+	for _, c := range t.Columns {
+		if strmangle.SetInclude(c.Name, dto.Ignore) {
+			continue
+		}
+
+		model := "o." + alias.Column(c.Name)
+		field := "d." + alias.Column(c.Name)
+		if name, ok := dto.Fields[c.Name]; ok {
+			field = "d." + name
+		}
+
+		// Encrypted columns are converted from and to the types of their
+		// plaintexts
+		typ, read, write := c.Type, model, "%s"
+		if plain, ok := encryptedPlainTypes[c.Type]; ok {
+			typ, read, write = plain, fmt.Sprintf("%s(%s)", plain, model), c.Type+"(%s)"
+		}
+
+		f := DTOField{
+			ToDTO:   fmt.Sprintf("%s = %s", field, read),
+			FromDTO: fmt.Sprintf("%s = %s", model, fmt.Sprintf(write, field)),
+		}
+
+		if base, ok := protoNullBases[typ]; ok {
+			usesNull = true
+			suffix := strings.TrimPrefix(typ, "null.")
+			var value string
+			switch {
+			case base == "[]byte":
+				// Bytes are nil when they are null
+				f.ToDTO = fmt.Sprintf("if %s.Valid {\n\t\t%s = %s.%s\n\t}", model, field, model, suffix)
+				value = fmt.Sprintf("null.New%s(%s, %s != nil)", suffix, field, field)
+			case dto.Nulls == "zero":
+				valid := `%s != 0`
+				if z, ok := dtoZeros[typ]; ok {
+					valid = z
+				}
+				f.ToDTO = fmt.Sprintf("if %s.Valid {\n\t\t%s = %s.%s\n\t}", model, field, model, suffix)
+				value = fmt.Sprintf("null.New%s(%s, %s)", suffix, field, fmt.Sprintf(valid, field))
+			default:
+				f.ToDTO = fmt.Sprintf("%s = %s.Ptr()", field, read)
+				value = fmt.Sprintf("null.%sFromPtr(%s)", suffix, field)
+			}
+			f.FromDTO = fmt.Sprintf("%s = %s", model, fmt.Sprintf(write, value))
+		}
+
+		fields = append(fields, f)
+	}
+
+	return fields, usesNull, nil
+}
+
+// dtoAlias returns the alias of the import of the package of DTOs, the name
+// of its last element numbered when another package has it
+func dtoAlias(importPath string, aliases map[string]string) string {
+	base := strmangle.CamelCase(strings.ReplaceAll(path.Base(importPath), "-", "_"))
+	taken := make(map[string]bool)
+	for _, a := range aliases {
+		taken[a] = true
+	}
+
+	alias := base
+	for i := 2; taken[alias]; i++ {
+		alias = fmt.Sprintf("%s%d", base, i)
+	}
+	return alias
+}
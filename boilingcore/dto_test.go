@@ -0,0 +1,87 @@
+package boilingcore
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInitDTOs(t *testing.T) {
+	t.Parallel()
+
+	s := testState(Config{
+		PkgName: "models",
+		DTOs: []DTO{
+			{Name: "PilotView", Table: "pilots", Type: "example.com/api.Pilot", Fields: map[string]string{"name": "FullName"}, Ignore: []string{"password"}},
+			{Name: "PilotRow", Table: "pilots", Type: "example.com/v2/api.Pilot", Nulls: "zero", Ignore: []string{"avatar", "password"}},
+			{Name: "PilotModel", Table: "pilots", Type: "PilotModel", Ignore: []string{"nick", "rank", "avatar"}},
+		},
+	})
+	if err := s.initDTOs(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []DTOMapper{
+		{
+			Name: "PilotView", Table: "pilots", Type: "api.Pilot", Import: `api "example.com/api"`, Null: true,
+			Fields: []DTOField{
+				{ToDTO: "d.ID = o.ID", FromDTO: "o.ID = d.ID"},
+				{ToDTO: "d.FullName = o.Name", FromDTO: "o.Name = d.FullName"},
+				{ToDTO: "d.Nick = o.Nick.Ptr()", FromDTO: "o.Nick = null.StringFromPtr(d.Nick)"},
+				{ToDTO: "d.Rank = o.Rank.Ptr()", FromDTO: "o.Rank = null.IntFromPtr(d.Rank)"},
+				{ToDTO: "if o.Avatar.Valid {\n\t\td.Avatar = o.Avatar.Bytes\n\t}", FromDTO: "o.Avatar = null.NewBytes(d.Avatar, d.Avatar != nil)"},
+			},
+		},
+		{
+			Name: "PilotRow", Table: "pilots", Type: "api2.Pilot", Import: `api2 "example.com/v2/api"`, Null: true,
+			Fields: []DTOField{
+				{ToDTO: "d.ID = o.ID", FromDTO: "o.ID = d.ID"},
+				{ToDTO: "d.Name = o.Name", FromDTO: "o.Name = d.Name"},
+				{ToDTO: "if o.Nick.Valid {\n\t\td.Nick = o.Nick.String\n\t}", FromDTO: `o.Nick = null.NewString(d.Nick, d.Nick != "")`},
+				{ToDTO: "if o.Rank.Valid {\n\t\td.Rank = o.Rank.Int\n\t}", FromDTO: "o.Rank = null.NewInt(d.Rank, d.Rank != 0)"},
+			},
+		},
+		{
+			Name: "PilotModel", Table: "pilots", Type: "PilotModel",
+			Fields: []DTOField{
+				{ToDTO: "d.ID = o.ID", FromDTO: "o.ID = d.ID"},
+				{ToDTO: "d.Name = o.Name", FromDTO: "o.Name = d.Name"},
+				{ToDTO: "d.Password = o.Password", FromDTO: "o.Password = d.Password"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(s.DTOMappers, want) {
+		t.Errorf("want: %#v\ngot: %#v", want, s.DTOMappers)
+	}
+
+	imps := s.Config.Imports.Singleton[dtoTemplate].ThirdParty
+	if want := []string{`api "example.com/api"`, `api2 "example.com/v2/api"`, `"github.com/volatiletech/null/v8"`}; !reflect.DeepEqual([]string(imps), want) {
+		t.Errorf("want imports %v, got: %v", want, imps)
+	}
+}
+
+func TestInitDTOsErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dto DTO
+		err string
+	}{
+		{DTO{Name: "Pilot-View", Table: "pilots", Type: "Pilot"}, "not a valid identifier"},
+		{DTO{Name: "PilotView", Table: "jets", Type: "Pilot"}, "table jets of dto PilotView was not found"},
+		{DTO{Name: "PilotView", Table: "pilot_jets", Type: "Pilot"}, "table pilot_jets of dto PilotView was not found"},
+		{DTO{Name: "PilotView", Table: "pilots"}, "needs the type of its struct"},
+		{DTO{Name: "PilotView", Table: "pilots", Type: "Pilot", Nulls: "empty"}, "nulls must be pointer or zero"},
+		{DTO{Name: "PilotView", Table: "pilots", Type: "Pilot", Ignore: []string{"email"}}, "ignored column email was not found"},
+		{DTO{Name: "PilotView", Table: "pilots", Type: "Pilot", Fields: map[string]string{"email": "Email"}}, "column email of field Email was not found"},
+	}
+
+	for _, test := range tests {
+		s := testState(Config{PkgName: "models", DTOs: []DTO{test.dto}})
+
+		err := s.initDTOs()
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%#v: want error containing %q, got: %v", test.dto, test.err, err)
+		}
+	}
+}
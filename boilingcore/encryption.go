@@ -0,0 +1,118 @@
+package boilingcore
+
+import (
+	"strings"
+
+	"github.com/friendsofgo/errors"
+
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// encryptionTemplate is the singleton with the encrypted column types, it
+// names its entry in the singleton imports
+const encryptionTemplate = "boil_encryption"
+
+// encryptedTypes are the types of the encrypted columns by the type of the
+// column
+var encryptedTypes = map[string]string{
+	"string":      "EncryptedString",
+	"null.String": "NullEncryptedString",
+	"[]byte":      "EncryptedBytes",
+	"null.Bytes":  "NullEncryptedBytes",
+}
+
+// encryptedPlainTypes are the types of the plaintexts of the encrypted column
+// types
+var encryptedPlainTypes = map[string]string{
+	"EncryptedString":     "string",
+	"NullEncryptedString": "null.String",
+	"EncryptedBytes":      "[]byte",
+	"NullEncryptedBytes":  "null.Bytes",
+}
+
+// isEncryptedType tells if a type is an encrypted column type, whose values
+// cannot be compared by the database
+func isEncryptedType(typ string) bool {
+	_, ok := encryptedPlainTypes[typ]
+	return ok
+}
+
+// initEncryption replaces the types of the encrypted columns with the
+// encrypted column types, whose values are encrypted in the database
+func (s *State) initEncryption() error {
+	if len(s.Config.Encryption.Columns) == 0 {
+		return nil
+	}
+
+	// columns has the encrypted columns by table, the tables of the columns
+	// without a table are the empty string
+	columns := make(map[string]map[string]bool)
+	for _, name := range s.Config.Encryption.Columns {
+		var table, column string
+		if i := strings.LastIndexByte(name, '.'); i >= 0 {
+			table, column = name[:i], name[i+1:]
+		} else {
+			column = name
+		}
+		if columns[table] == nil {
+			columns[table] = make(map[string]bool)
+		}
+		columns[table][column] = true
+	}
+
+	found := make(map[string]bool)
+	for i := range s.Tables {
+		t := &s.Tables[i]
+		for j, c := range t.Columns {
+			if columns[t.Name][c.Name] {
+				found[t.Name+"."+c.Name] = true
+			} else if columns[""][c.Name] {
+				found[c.Name] = true
+			} else {
+				continue
+			}
+
+			if scrubIsKey(*t, c.Name) {
+				return errors.Errorf("encrypted column %s.%s cannot be part of the primary key or a foreign key", t.Name, c.Name)
+			}
+			typ, ok := encryptedTypes[c.Type]
+			if !ok {
+				return errors.Errorf("encrypted column %s.%s must be a string or bytes column, not %s", t.Name, c.Name, c.Type)
+			}
+			t.Columns[j].Type = typ
+		}
+	}
+
+	for table, cols := range columns {
+		for column := range cols {
+			name := column
+			if len(table) != 0 {
+				name = table + "." + column
+			}
+			if !found[name] {
+				return errors.Errorf("encrypted column %s was not found", name)
+			}
+		}
+	}
+
+	imps := importers.Set{
+		Standard: importers.List{`"database/sql/driver"`},
+		ThirdParty: importers.List{
+			`"github.com/volatiletech/null/v8"`,
+			`"github.com/volatiletech/sqlboiler/v4/encryption"`,
+		},
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[encryptionTemplate] = imps
+
+	if s.Config.Imports.TestSingleton == nil {
+		s.Config.Imports.TestSingleton = make(importers.Map)
+	}
+	s.Config.Imports.TestSingleton[encryptionTemplate+"_test"] = importers.Set{
+		ThirdParty: importers.List{`"github.com/volatiletech/sqlboiler/v4/encryption"`},
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package boilingcore
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestInitEncryption(t *testing.T) {
+	t.Parallel()
+
+	newTables := func() []drivers.Table {
+		return []drivers.Table{
+			{
+				Name: "patients",
+				PKey: &drivers.PrimaryKey{Columns: []string{"id"}},
+				FKeys: []drivers.ForeignKey{
+					{Column: "doctor_id", ForeignTable: "doctors", ForeignColumn: "id"},
+				},
+				Columns: []drivers.Column{
+					{Name: "id", Type: "int"},
+					{Name: "doctor_id", Type: "string"},
+					{Name: "ssn", Type: "null.String"},
+					{Name: "photo", Type: "[]byte"},
+					{Name: "age", Type: "int"},
+				},
+			},
+			{
+				Name: "doctors",
+				Columns: []drivers.Column{
+					{Name: "id", Type: "int"},
+					{Name: "ssn", Type: "string"},
+					{Name: "notes", Type: "null.Bytes"},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		columns []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "disabled"},
+		{
+			name:    "columns",
+			columns: []string{"ssn", "patients.photo", "doctors.notes"},
+			want: map[string]string{
+				"patients.ssn":   "NullEncryptedString",
+				"patients.photo": "EncryptedBytes",
+				"doctors.ssn":    "EncryptedString",
+				"doctors.notes":  "NullEncryptedBytes",
+				"patients.age":   "int",
+			},
+		},
+		{name: "primary key", columns: []string{"patients.id"}, wantErr: true},
+		{name: "foreign key", columns: []string{"doctor_id"}, wantErr: true},
+		{name: "type", columns: []string{"patients.age"}, wantErr: true},
+		{name: "missing", columns: []string{"patients.name"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			s := &State{
+				Config: &Config{Encryption: Encryption{Columns: tt.columns}},
+				Tables: newTables(),
+			}
+			err := s.initEncryption()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %t, got: %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			for _, table := range s.Tables {
+				for _, c := range table.Columns {
+					if want, ok := tt.want[table.Name+"."+c.Name]; ok && c.Type != want {
+						t.Errorf("want %s.%s of type %s, got: %s", table.Name, c.Name, want, c.Type)
+					}
+				}
+			}
+			_, ok := s.Config.Imports.Singleton[encryptionTemplate]
+			if ok != (len(tt.columns) != 0) {
+				t.Errorf("want imports for the encrypted column types %t, got: %t", len(tt.columns) != 0, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package boilingcore
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+var (
+	rgxERDIdent   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	rgxERDNonWord = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+	rgxERDNonType = regexp.MustCompile(`[^A-Za-z0-9_()\[\]-]+`)
+)
+
+// ERDFormats are the formats WriteERD renders the schema to
+var ERDFormats = []string{"dot", "mermaid", "plantuml"}
+
+// NewSchema creates a state with the tables of the database as the templates
+// would see them, after the type replacements, without loading the templates
+// or creating the output folder
+func NewSchema(config *Config) (*State, error) {
+	s := &State{
+		Config: config,
+	}
+
+	driver, ok := drivers.LookupDriver(config.DriverName)
+	if !ok {
+		return nil, errors.Errorf("driver %q has not been registered", config.DriverName)
+	}
+	s.Driver = driver
+	s.initInflections()
+
+	if err := s.initDBInfo(config.DriverConfig); err != nil {
+		return nil, errors.Wrap(err, "unable to initialize tables")
+	}
+
+	if err := s.processTypeReplacements(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// WriteERD renders the tables of the state, their columns and their primary,
+// foreign and unique keys as an entity relationship diagram in one of the
+// ERDFormats
+func (s *State) WriteERD(w io.Writer, format string) error {
+	buf := bufio.NewWriter(w)
+
+	switch format {
+	case "dot":
+		writeERDDot(buf, s.Tables)
+	case "mermaid":
+		writeERDMermaid(buf, s.Tables)
+	case "plantuml":
+		writeERDPlantUML(buf, s.Tables)
+	default:
+		return errors.Errorf("unknown ER diagram format %q, want one of: %s", format, strings.Join(ERDFormats, ", "))
+	}
+
+	return buf.Flush()
+}
+
+// erdColumn is a column of a table of the diagram with its keys
+type erdColumn struct {
+	drivers.Column
+	Type string
+	Keys []string
+}
+
+// erdColumns returns the columns of the table with their full types and the
+// keys they are part of, PK, FK or UK
+func erdColumns(t drivers.Table) []erdColumn {
+	pkey := make(map[string]bool)
+	if t.PKey != nil {
+		for _, c := range t.PKey.Columns {
+			pkey[c] = true
+		}
+	}
+	fkey := make(map[string]bool)
+	for _, fk := range t.FKeys {
+		fkey[fk.Column] = true
+	}
+
+	cols := make([]erdColumn, len(t.Columns))
+	for i, c := range t.Columns {
+		col := erdColumn{Column: c, Type: c.FullDBType}
+		if len(col.Type) == 0 {
+			col.Type = c.DBType
+		}
+
+		if pkey[c.Name] {
+			col.Keys = append(col.Keys, "PK")
+		}
+		if fkey[c.Name] {
+			col.Keys = append(col.Keys, "FK")
+		}
+		// Single column primary keys are unique already
+		if c.Unique && !(pkey[c.Name] && len(pkey) == 1) {
+			col.Keys = append(col.Keys, "UK")
+		}
+
+		cols[i] = col
+	}
+
+	return cols
+}
+
+// erdCardinality returns whether the rows a foreign key refers to are
+// optional, when the column is nullable, and whether many rows can refer to
+// the same row, when the column is not unique
+func erdCardinality(fk drivers.ForeignKey) (optional, many bool) {
+	return fk.Nullable, !fk.Unique
+}
+
+// erdCrowsFoot returns the crow's foot notation of the ends of a foreign key,
+// the end of the row it refers to and the end of the rows referring to it
+func erdCrowsFoot(fk drivers.ForeignKey) (one, other string) {
+	optional, many := erdCardinality(fk)
+	one, other = "||", "o|"
+	if optional {
+		one = "|o"
+	}
+	if many {
+		other = "o{"
+	}
+	return one, other
+}
+
+func writeERDDot(w io.Writer, tables []drivers.Table) {
+	fmt.Fprintln(w, "digraph schema {")
+	fmt.Fprintln(w, "\tgraph [rankdir=LR];")
+	fmt.Fprintln(w, "\tnode [shape=plaintext];")
+
+	for _, t := range tables {
+		title := html.EscapeString(t.Name)
+		if t.IsView {
+			title += " (view)"
+		}
+
+		fmt.Fprintf(w, "\n\t%s [label=<\n", strconv.Quote(t.Name))
+		fmt.Fprintln(w, "\t\t<table border=\"0\" cellborder=\"1\" cellspacing=\"0\">")
+		fmt.Fprintf(w, "\t\t<tr><td bgcolor=\"lightgrey\"><b>%s</b></td></tr>\n", title)
+		for _, c := range erdColumns(t) {
+			text := html.EscapeString(c.Name + " " + c.Type)
+			if len(c.Keys) != 0 {
+				text += " <b>" + strings.Join(c.Keys, ", ") + "</b>"
+			}
+			if c.Nullable {
+				text += " <i>null</i>"
+			}
+			fmt.Fprintf(w, "\t\t<tr><td port=%s align=\"left\">%s</td></tr>\n", strconv.Quote(c.Name), text)
+		}
+		fmt.Fprintln(w, "\t\t</table>")
+		fmt.Fprintln(w, "\t>];")
+	}
+
+	first := true
+	for _, t := range tables {
+		for _, fk := range t.FKeys {
+			if first {
+				fmt.Fprintln(w)
+				first = false
+			}
+
+			optional, many := erdCardinality(fk)
+			tail, head := "tee", "tee"
+			if many {
+				tail = "crow"
+			}
+			if optional {
+				head = "teeodot"
+			}
+			fmt.Fprintf(w, "\t%s:%s -> %s:%s [label=%s, dir=both, arrowtail=%s, arrowhead=%s];\n",
+				strconv.Quote(fk.Table), strconv.Quote(fk.Column),
+				strconv.Quote(fk.ForeignTable), strconv.Quote(fk.ForeignColumn),
+				strconv.Quote(fk.Name), tail, head,
+			)
+		}
+	}
+
+	fmt.Fprintln(w, "}")
+}
+
+func writeERDMermaid(w io.Writer, tables []drivers.Table) {
+	fmt.Fprintln(w, "erDiagram")
+
+	for _, t := range tables {
+		fmt.Fprintf(w, "\t%s {\n", erdMermaidName(t.Name))
+		for _, c := range erdColumns(t) {
+			fmt.Fprintf(w, "\t\t%s %s", rgxERDNonType.ReplaceAllString(c.Type, "_"), erdWord(c.Name))
+			if len(c.Keys) != 0 {
+				fmt.Fprintf(w, " %s", strings.Join(c.Keys, ", "))
+			}
+
+			var comment []string
+			if c.Nullable {
+				comment = append(comment, "null")
+			}
+			if erdWord(c.Name) != c.Name {
+				comment = append(comment, c.Name)
+			}
+			if len(comment) != 0 {
+				fmt.Fprintf(w, " %s", strconv.Quote(strings.Join(comment, ", ")))
+			}
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, "\t}")
+	}
+
+	for _, t := range tables {
+		for _, fk := range t.FKeys {
+			one, other := erdCrowsFoot(fk)
+			fmt.Fprintf(w, "\t%s %s--%s %s : %s\n",
+				erdMermaidName(fk.ForeignTable), one, other, erdMermaidName(fk.Table), strconv.Quote(fk.Name))
+		}
+	}
+}
+
+func writeERDPlantUML(w io.Writer, tables []drivers.Table) {
+	fmt.Fprintln(w, "@startuml")
+	fmt.Fprintln(w, "hide circle")
+	fmt.Fprintln(w, "skinparam linetype ortho")
+
+	for _, t := range tables {
+		stereotype := ""
+		if t.IsView {
+			stereotype = " <<view>>"
+		}
+		fmt.Fprintf(w, "\nentity %s as %s%s {\n", strconv.Quote(t.Name), erdWord(t.Name), stereotype)
+
+		cols := erdColumns(t)
+		var keys, others []erdColumn
+		for _, c := range cols {
+			if len(c.Keys) != 0 && c.Keys[0] == "PK" {
+				keys = append(keys, c)
+			} else {
+				others = append(others, c)
+			}
+		}
+
+		// Primary keys are above the line and * marks the columns that are not
+		// nullable
+		line := func(c erdColumn) {
+			mandatory := "  "
+			if !c.Nullable {
+				mandatory = "* "
+			}
+			fmt.Fprintf(w, "  %s%s : %s", mandatory, c.Name, c.Type)
+			for _, k := range c.Keys {
+				fmt.Fprintf(w, " <<%s>>", k)
+			}
+			fmt.Fprintln(w)
+		}
+		for _, c := range keys {
+			line(c)
+		}
+		if len(keys) != 0 {
+			fmt.Fprintln(w, "  --")
+		}
+		for _, c := range others {
+			line(c)
+		}
+		fmt.Fprintln(w, "}")
+	}
+
+	first := true
+	for _, t := range tables {
+		for _, fk := range t.FKeys {
+			if first {
+				fmt.Fprintln(w)
+				first = false
+			}
+
+			one, other := erdCrowsFoot(fk)
+			fmt.Fprintf(w, "%s %s--%s %s : %s\n", erdWord(fk.ForeignTable), one, other, erdWord(fk.Table), fk.Name)
+		}
+	}
+
+	fmt.Fprintln(w, "@enduml")
+}
+
+// erdWord replaces the characters of a name that diagrams do not allow in
+// identifiers with underscores
+func erdWord(s string) string {
+	return rgxERDNonWord.ReplaceAllString(s, "_")
+}
+
+// erdMermaidName quotes the names of tables that are not identifiers
+func erdMermaidName(name string) string {
+	if rgxERDIdent.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
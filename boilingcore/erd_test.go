@@ -0,0 +1,110 @@
+package boilingcore
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+var erdTables = []drivers.Table{
+	{
+		Name: "pilots",
+		Columns: []drivers.Column{
+			{Name: "id", DBType: "integer", Unique: true},
+			{Name: "name", DBType: "character varying", FullDBType: "character varying(255)", Unique: true},
+		},
+		PKey: &drivers.PrimaryKey{Name: "pilots_pkey", Columns: []string{"id"}},
+	},
+	{
+		Name: "jets",
+		Columns: []drivers.Column{
+			{Name: "id", DBType: "integer"},
+			{Name: "pilot_id", DBType: "integer", Nullable: true},
+			{Name: "license_id", DBType: "integer", Unique: true},
+		},
+		PKey: &drivers.PrimaryKey{Name: "jets_pkey", Columns: []string{"id"}},
+		FKeys: []drivers.ForeignKey{
+			{Name: "jets_pilot_fkey", Table: "jets", Column: "pilot_id", Nullable: true, ForeignTable: "pilots", ForeignColumn: "id"},
+			{Name: "jets_license_fkey", Table: "jets", Column: "license_id", Unique: true, ForeignTable: "licenses", ForeignColumn: "id"},
+		},
+	},
+	{
+		Name:    "pilot names",
+		IsView:  true,
+		Columns: []drivers.Column{{Name: "full name", DBType: "text", Nullable: true}},
+	},
+}
+
+func TestWriteERDMermaid(t *testing.T) {
+	t.Parallel()
+
+	s := &State{Tables: erdTables}
+	buf := &bytes.Buffer{}
+	if err := s.WriteERD(buf, "mermaid"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `erDiagram
+	pilots {
+		integer id PK
+		character_varying(255) name UK
+	}
+	jets {
+		integer id PK
+		integer pilot_id FK "null"
+		integer license_id FK, UK
+	}
+	"pilot names" {
+		text full_name "null, full name"
+	}
+	pilots |o--o{ jets : "jets_pilot_fkey"
+	licenses ||--o| jets : "jets_license_fkey"
+`
+	if got := buf.String(); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestWriteERD(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string][]string{
+		"dot": {
+			"digraph schema {",
+			`<tr><td bgcolor="lightgrey"><b>pilot names (view)</b></td></tr>`,
+			`<tr><td port="name" align="left">name character varying(255) <b>UK</b></td></tr>`,
+			`<tr><td port="pilot_id" align="left">pilot_id integer <b>FK</b> <i>null</i></td></tr>`,
+			`"jets":"pilot_id" -> "pilots":"id" [label="jets_pilot_fkey", dir=both, arrowtail=crow, arrowhead=teeodot];`,
+			`"jets":"license_id" -> "licenses":"id" [label="jets_license_fkey", dir=both, arrowtail=tee, arrowhead=tee];`,
+		},
+		"plantuml": {
+			"@startuml",
+			`entity "pilot names" as pilot_names <<view>> {`,
+			"  * id : integer <<PK>>\n  --\n  * name : character varying(255) <<UK>>\n",
+			"    pilot_id : integer <<FK>>",
+			"pilots |o--o{ jets : jets_pilot_fkey",
+			"@enduml",
+		},
+	}
+
+	for format, lines := range tests {
+		s := &State{Tables: erdTables}
+		buf := &bytes.Buffer{}
+		if err := s.WriteERD(buf, format); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, line := range lines {
+			if !strings.Contains(buf.String(), line) {
+				t.Errorf("%s: want %q in:\n%s", format, line, buf.String())
+			}
+		}
+	}
+
+	s := &State{Tables: erdTables}
+	if err := s.WriteERD(&bytes.Buffer{}, "svg"); err == nil {
+		t.Error("want an error for an unknown format")
+	}
+}
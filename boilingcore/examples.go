@@ -0,0 +1,11 @@
+package boilingcore
+
+import "strings"
+
+// hasExampleNames tells if the examples of the model of a table can be named
+// after it. go vet reads the name of an example up to its first underscore as
+// the name it documents, so the names resolving collisions with the reserved
+// suffix, like Users_, cannot be named.
+func hasExampleNames(alias TableAlias) bool {
+	return !strings.Contains(alias.UpSingular, "_") && !strings.Contains(alias.UpPlural, "_")
+}
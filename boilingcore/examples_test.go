@@ -0,0 +1,22 @@
+package boilingcore
+
+import "testing"
+
+func TestHasExampleNames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Alias TableAlias
+		Out   bool
+	}{
+		{TableAlias{UpSingular: "Pilot", UpPlural: "Pilots"}, true},
+		{TableAlias{UpSingular: "User_", UpPlural: "Users_"}, false},
+		{TableAlias{UpSingular: "Fish", UpPlural: "Fish_"}, false},
+	}
+
+	for i, test := range tests {
+		if got := hasExampleNames(test.Alias); got != test.Out {
+			t.Errorf("%d) want %t, got %t", i, test.Out, got)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package boilingcore
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+// StructFieldOrders are the orders of the fields of the generated structs:
+// the ordinal position of the columns, their names, or the primary key
+// columns first and the others by name
+var StructFieldOrders = []string{"ordinal", "name", "pk-first"}
+
+// initStructFieldOrder checks the order of the struct fields, the ordinal
+// position of the columns when it is empty
+func (s *State) initStructFieldOrder() error {
+	if len(s.Config.StructFieldOrder) == 0 {
+		return nil
+	}
+
+	for _, order := range StructFieldOrders {
+		if s.Config.StructFieldOrder == order {
+			return nil
+		}
+	}
+
+	return errors.Errorf("unknown struct field order %q, want one of: %s", s.Config.StructFieldOrder, strings.Join(StructFieldOrders, ", "))
+}
+
+// structColumns returns the columns of the table in the order of the fields of
+// its struct. With groupNullable the nullable columns, whose types are the
+// larger null wrappers, follow the others in the same order.
+func structColumns(t drivers.Table, order string, groupNullable bool) []drivers.Column {
+	columns := make([]drivers.Column, len(t.Columns))
+	copy(columns, t.Columns)
+
+	var pkey map[string]bool
+	if order == "pk-first" && t.PKey != nil {
+		pkey = make(map[string]bool, len(t.PKey.Columns))
+		for _, c := range t.PKey.Columns {
+			pkey[c] = true
+		}
+	}
+
+	rank := func(c drivers.Column) int {
+		r := 0
+		if groupNullable && c.Nullable {
+			r += 2
+		}
+		if pkey != nil && !pkey[c.Name] {
+			r++
+		}
+		return r
+	}
+
+	sort.SliceStable(columns, func(i, j int) bool {
+		ri, rj := rank(columns[i]), rank(columns[j])
+		if ri != rj {
+			return ri < rj
+		}
+		if order == "ordinal" || len(order) == 0 {
+			return false
+		}
+		if pkey != nil && pkey[columns[i].Name] {
+			// the primary key columns keep their ordinal order
+			return false
+		}
+		return columns[i].Name < columns[j].Name
+	})
+
+	return columns
+}
+
+// StructColumns returns the columns of the table in the order of the fields of
+// its struct
+func (t templateData) StructColumns() []drivers.Column {
+	columns := structColumns(t.Table, t.StructFieldOrder, t.GroupNullableFields)
+	if t.OptimizeStructLayout {
+		return optimizeLayout(columns)
+	}
+	return columns
+}
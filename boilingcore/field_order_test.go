@@ -0,0 +1,67 @@
+package boilingcore
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestStructColumns(t *testing.T) {
+	t.Parallel()
+
+	table := drivers.Table{
+		Name: "pilots",
+		Columns: []drivers.Column{
+			{Name: "name"},
+			{Name: "nickname", Nullable: true},
+			{Name: "id"},
+			{Name: "born_at", Nullable: true},
+			{Name: "airline_id"},
+		},
+		PKey: &drivers.PrimaryKey{Columns: []string{"id"}},
+	}
+
+	tests := []struct {
+		Order         string
+		GroupNullable bool
+		Want          []string
+	}{
+		{"ordinal", false, []string{"name", "nickname", "id", "born_at", "airline_id"}},
+		{"ordinal", true, []string{"name", "id", "airline_id", "nickname", "born_at"}},
+		{"name", false, []string{"airline_id", "born_at", "id", "name", "nickname"}},
+		{"name", true, []string{"airline_id", "id", "name", "born_at", "nickname"}},
+		{"pk-first", false, []string{"id", "airline_id", "born_at", "name", "nickname"}},
+		{"pk-first", true, []string{"id", "airline_id", "name", "born_at", "nickname"}},
+	}
+
+	for _, test := range tests {
+		var got []string
+		for _, c := range structColumns(table, test.Order, test.GroupNullable) {
+			got = append(got, c.Name)
+		}
+		if !reflect.DeepEqual(got, test.Want) {
+			t.Errorf("%s (group nullable %t): want: %v\ngot: %v", test.Order, test.GroupNullable, test.Want, got)
+		}
+	}
+
+	if table.Columns[0].Name != "name" {
+		t.Error("the columns of the table were reordered")
+	}
+}
+
+func TestInitStructFieldOrder(t *testing.T) {
+	t.Parallel()
+
+	for _, order := range append([]string{""}, StructFieldOrders...) {
+		s := &State{Config: &Config{StructFieldOrder: order}}
+		if err := s.initStructFieldOrder(); err != nil {
+			t.Errorf("%q: %v", order, err)
+		}
+	}
+
+	s := &State{Config: &Config{StructFieldOrder: "size"}}
+	if err := s.initStructFieldOrder(); err == nil {
+		t.Error("want an error for an unknown order")
+	}
+}
@@ -0,0 +1,131 @@
+package boilingcore
+
+import (
+	"fmt"
+
+	"github.com/volatiletech/strmangle"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// functionsTemplate is the singleton that function wrappers are generated
+// into, it names its entry in the singleton imports
+const functionsTemplate = "boil_functions"
+
+// Function is a stored function or procedure of the database that a typed
+// wrapper is generated for.
+type Function struct {
+	drivers.Function
+
+	// GoName is the name of the wrapper
+	GoName string
+	// Kind is what the wrapper returns: model for rows of a generated table,
+	// row for rows of the results, value for the return value and exec for
+	// nothing
+	Kind string
+	// SQL calls the function with a placeholder for each param
+	SQL string
+	// RowName is the struct results are scanned into for row wrappers
+	RowName string
+	// Args are the params of the wrapper
+	Args []CustomQueryParam
+	// Fields of the row struct
+	Fields []CustomQueryParam
+}
+
+// loadFunctions builds the wrappers of the functions loaded by the driver.
+// Overloaded functions share a name that only one wrapper can have, so only
+// the first of them is kept.
+func (s *State) loadFunctions(fns []drivers.Function) {
+	if !s.Config.AddFunctions {
+		return
+	}
+
+	lq, rq := string(s.Dialect.LQ), string(s.Dialect.RQ)
+	seen := make(map[string]bool)
+	var types []string
+
+	for _, fn := range fns {
+		if seen[fn.Name] {
+			s.warnf("warning: skipping overload of function %s, wrappers are only generated for the first one\n", fn.Name)
+			continue
+		}
+		seen[fn.Name] = true
+
+		if len(fn.Table) != 0 && !s.generatesTable(fn.Table) {
+			s.warnf("warning: skipping function %s, no model is generated for the table %s it returns\n", fn.Name, fn.Table)
+			continue
+		}
+
+		f := Function{
+			Function: fn,
+			GoName:   "Call" + titleCase(fn.Name),
+		}
+
+		for _, p := range fn.Params {
+			f.Args = append(f.Args, CustomQueryParam{
+				Name:   p.Name,
+				GoName: strmangle.ReplaceReservedWords(camelCase(p.Name)),
+				Type:   p.Type,
+			})
+			types = append(types, p.Type)
+		}
+
+		call := fmt.Sprintf("%s(%s)",
+			strmangle.SchemaTable(lq, rq, s.Dialect.UseSchema, s.Schema, fn.Name),
+			strmangle.Placeholders(s.Dialect.UseIndexPlaceholders, len(fn.Params), 1, 1),
+		)
+
+		switch {
+		case fn.IsProcedure:
+			f.Kind, f.SQL = "exec", "CALL "+call
+		case len(fn.Table) != 0:
+			f.Kind, f.SQL = "model", "SELECT * FROM "+call
+		case len(fn.Results) != 0:
+			f.Kind, f.SQL = "row", "SELECT * FROM "+call
+			f.RowName = titleCase(fn.Name) + "Row"
+			for _, r := range fn.Results {
+				f.Fields = append(f.Fields, CustomQueryParam{
+					Name:   r.Name,
+					GoName: titleCase(r.Name),
+					Type:   r.Type,
+				})
+				types = append(types, r.Type)
+			}
+		case fn.Return != nil:
+			f.Kind = "value"
+			if fn.ReturnsSet {
+				f.SQL = "SELECT * FROM " + call
+			} else {
+				f.SQL = "SELECT " + call
+			}
+			types = append(types, fn.Return.Type)
+		default:
+			f.Kind, f.SQL = "exec", "SELECT "+call
+		}
+
+		s.Functions = append(s.Functions, f)
+	}
+
+	imps := importers.Set{
+		ThirdParty: importers.List{`"github.com/friendsofgo/errors"`, `"github.com/volatiletech/sqlboiler/v4/boil"`, `"github.com/volatiletech/sqlboiler/v4/queries"`},
+	}
+	if !s.Config.NoContext {
+		imps.Standard = append(imps.Standard, `"context"`)
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[functionsTemplate] = importers.AddTypeImports(imps, s.Config.Imports.BasedOnType, types)
+}
+
+// generatesTable reports whether a model is generated for the table
+func (s *State) generatesTable(name string) bool {
+	for _, t := range s.Tables {
+		if t.Name == name {
+			return !t.IsJoinTable
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package boilingcore
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+func TestLoadFunctions(t *testing.T) {
+	t.Parallel()
+
+	s := &State{
+		Config:  &Config{AddFunctions: true, Imports: importers.NewDefaultImports()},
+		Dialect: drivers.Dialect{LQ: '`', RQ: '`'},
+		Tables: []drivers.Table{
+			{Name: "pilots"},
+			{Name: "pilot_languages", IsJoinTable: true},
+		},
+	}
+
+	s.loadFunctions([]drivers.Function{
+		{Name: "pilot_count", Params: []drivers.Column{{Name: "type", Type: "string"}, {Name: "min_age", Type: "int"}}, Return: &drivers.Column{Type: "null.Int64"}},
+		{Name: "pilot_count", Return: &drivers.Column{Type: "null.Int64"}},
+		{Name: "retire_pilot", IsProcedure: true, Params: []drivers.Column{{Name: "id", Type: "int"}}},
+		{Name: "pilot_languages_of", ReturnsSet: true, Table: "pilot_languages"},
+		{Name: "touch_pilots"},
+	})
+
+	if len(s.Functions) != 3 {
+		t.Fatalf("want the overload and the join table function skipped, got: %#v", s.Functions)
+	}
+
+	count := s.Functions[0]
+	if count.GoName != "CallPilotCount" || count.Kind != "value" {
+		t.Errorf("wrong wrapper: %#v", count)
+	}
+	if count.SQL != "SELECT `pilot_count`(?,?)" {
+		t.Errorf("wrong sql: %s", count.SQL)
+	}
+	if count.Args[0].GoName != "type_" || count.Args[1].GoName != "minAge" {
+		t.Errorf("wrong args: %#v", count.Args)
+	}
+
+	if retire := s.Functions[1]; retire.Kind != "exec" || retire.SQL != "CALL `retire_pilot`(?)" {
+		t.Errorf("wrong wrapper: %#v", retire)
+	}
+	if touch := s.Functions[2]; touch.Kind != "exec" || touch.SQL != "SELECT `touch_pilots`()" {
+		t.Errorf("wrong wrapper: %#v", touch)
+	}
+
+	if _, ok := s.Config.Imports.Singleton[functionsTemplate]; !ok {
+		t.Error("want imports for the functions template")
+	}
+}
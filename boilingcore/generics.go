@@ -0,0 +1,91 @@
+package boilingcore
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+)
+
+// genericsMinor is the minor version of Go 1 the generic finders of
+// --with-generics need
+const genericsMinor = 18
+
+// checkGenerics checks that the module the models are generated into is on
+// Go 1.18 or later, the go directive of its go.mod. Without a go.mod above the
+// output folder there is no version to check.
+func checkGenerics(outFolder string) error {
+	gomod, version, err := goModVersion(outFolder)
+	if err != nil || len(gomod) == 0 {
+		return err
+	}
+
+	minor, ok := goMinor(version)
+	if !ok {
+		return errors.Errorf("unable to read the go version %q of %s", version, gomod)
+	}
+	if minor < genericsMinor {
+		return errors.Errorf("with-generics generates code that needs go 1.%d, but %s is on go %s", genericsMinor, gomod, version)
+	}
+	return nil
+}
+
+// goModVersion returns the closest go.mod from dir up and the version of its
+// go directive, "1.16" when it has none like the go command assumes. Both are
+// empty when there is no go.mod.
+func goModVersion(dir string) (string, string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", err
+	}
+
+	for {
+		gomod := filepath.Join(dir, "go.mod")
+		f, err := os.Open(gomod)
+		if err == nil {
+			defer f.Close()
+
+			scanner := bufio.NewScanner(f)
+			for scanner.Scan() {
+				fields := strings.Fields(scanner.Text())
+				if len(fields) >= 2 && fields[0] == "go" {
+					return gomod, fields[1], nil
+				}
+			}
+			if err := scanner.Err(); err != nil {
+				return "", "", errors.Wrapf(err, "unable to read %s", gomod)
+			}
+			return gomod, "1.16", nil
+		} else if !os.IsNotExist(err) {
+			return "", "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", nil
+		}
+		dir = parent
+	}
+}
+
+// goMinor returns the minor version of a Go 1 version like 1.18, 1.21.0 or
+// 1.21rc1
+func goMinor(version string) (int, bool) {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 || parts[0] != "1" {
+		return 0, false
+	}
+
+	digits := strings.IndexFunc(parts[1], func(r rune) bool { return r < '0' || r > '9' })
+	if digits == -1 {
+		digits = len(parts[1])
+	}
+	minor, err := strconv.Atoi(parts[1][:digits])
+	if err != nil {
+		return 0, false
+	}
+	return minor, true
+}
@@ -0,0 +1,60 @@
+package boilingcore
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckGenerics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		gomod string
+		err   bool
+	}{
+		{gomod: "module example.com/app\n\ngo 1.16\n", err: true},
+		{gomod: "module example.com/app\n", err: true},
+		{gomod: "module example.com/app\n\ngo 1.18\n"},
+		{gomod: "module example.com/app\n\ngo 1.21.0\n\ntoolchain go1.21.5\n"},
+		{gomod: "module example.com/app\n\ngo 1.21rc1\n"},
+	}
+
+	for _, test := range tests {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(test.gomod), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		// the models are generated into a package below the root of the module
+		err := checkGenerics(filepath.Join(dir, "models"))
+		if test.err && (err == nil || !strings.Contains(err.Error(), "needs go 1.18")) {
+			t.Errorf("%q: want an error for the go version, got: %v", test.gomod, err)
+		} else if !test.err && err != nil {
+			t.Errorf("%q: %v", test.gomod, err)
+		}
+	}
+}
+
+func TestCheckGenericsNoModule(t *testing.T) {
+	t.Parallel()
+
+	if err := checkGenerics(filepath.Join(t.TempDir(), "models")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNewGenericsGoVersion(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n\ngo 1.17\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := New(&Config{DriverName: "mock", OutFolder: filepath.Join(dir, "models"), WithGenerics: true})
+	if err == nil || !strings.Contains(err.Error(), "with-generics") {
+		t.Errorf("want New to reject with-generics on go 1.17, got: %v", err)
+	}
+}
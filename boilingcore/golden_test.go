@@ -0,0 +1,357 @@
+package boilingcore
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+var flagUpdateGolden = flag.Bool("update", false, "Overwrite the golden files in testdata/golden with the current template output")
+
+// TestGolden runs every template against the mock driver's schema and
+// compares the output to the files in testdata/golden. Run with -update
+// after changing a template and review the diff of the golden files. The
+// output is vetted too, except the output importing the protoc generated
+// packages and the types of the DTOs that only exist in a real application.
+func TestGolden(t *testing.T) {
+	tests := []struct {
+		name     string
+		external bool
+		config   Config
+	}{
+		{
+			name: "default",
+			config: Config{
+				AddFactories:    true,
+				AddSQLMockTests: true,
+				AddCSV:          true,
+				AddSeeds:        true,
+				AddRepositories: true,
+				AddImmutable:    true,
+				AddInterfaces:   true,
+				AddDiff:         true,
+				AddCopyEqual:    true,
+				AddMaps:         true,
+				AddValidation:   true,
+				WithBenchmarks:  true,
+				QueriesDir:      filepath.Join("testdata", "queries"),
+				AddFunctions:    true,
+				AuditLog:        AuditLog{Tables: []string{"pilots"}},
+				History:         History{Tables: []string{"pilots"}},
+				Outbox:          Outbox{Tables: []string{"jets"}},
+				TenantColumn:    "pilot_id",
+				RLSSetting:      "app.current_pilot",
+				Encryption:      Encryption{Columns: []string{"jets.color", "jets.manifest"}},
+				Scrub:           Scrub{Columns: []string{"name", "jets.color:city", "jets.manifest"}},
+				Masking:         Masking{Columns: []string{"name:first1", "jets.color:last2", "jets.identifier:full", "jets.cargo"}},
+				Streaming:       Streaming{Columns: []string{"jets.cargo"}},
+				WithGraphQL:     true,
+				WithJSONSchema:  true,
+				WithTypeScript:  true,
+				WithHTTP:        true,
+				WithDocs:        true,
+			},
+		},
+		{
+			name: "nocontext_nohooks",
+			config: Config{
+				NoContext:       true,
+				NoHooks:         true,
+				AddSQLMockTests: true,
+				QueriesDir:      filepath.Join("testdata", "queries"),
+				AddFunctions:    true,
+				WithHTTP:        true,
+				Scrub:           Scrub{Columns: []string{"pilots.name"}},
+				Masking:         Masking{Columns: []string{"pilots.name:email"}},
+				Streaming:       Streaming{Columns: []string{"cargo"}},
+				AddRepositories: true,
+				AddImmutable:    true,
+				AddInterfaces:   true,
+			},
+		},
+		{
+			name:     "proto",
+			external: true,
+			config: Config{
+				NoTests:   true,
+				WithProto: true,
+				WithGRPC:  true,
+				Proto:     Proto{GoPackage: "example.com/app/pb"},
+				DTOs: []DTO{
+					{Name: "PilotView", Table: "pilots", Type: "example.com/app/api.Pilot", Fields: map[string]string{"name": "FullName"}},
+					{Name: "JetRow", Table: "jets", Type: "example.com/app/api.Jet", Nulls: "zero", Ignore: []string{"cargo"}},
+				},
+			},
+		},
+		{
+			name:     "proto_nocontext_nohooks",
+			external: true,
+			config: Config{
+				NoContext: true,
+				NoHooks:   true,
+				NoTests:   true,
+				WithProto: true,
+				Proto:     Proto{GoPackage: "example.com/app/pb", Nullable: "wrappers"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			config.DriverName = "mock"
+			config.PkgName = "models"
+			config.OutFolder = t.TempDir()
+			config.Imports = importers.NewDefaultImports()
+			config.DriverConfig = drivers.Config{
+				Schema:       "schema",
+				AddFunctions: config.AddFunctions,
+			}
+
+			s, err := New(&config)
+			if err != nil {
+				t.Fatalf("unable to create state: %s", err)
+			}
+			if err = s.Run(); err != nil {
+				t.Fatalf("unable to run state: %s", err)
+			}
+
+			got, err := readGoldenDir(config.OutFolder)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !tt.external && !testing.Short() {
+				vetGoldenDir(t, config.OutFolder)
+			}
+
+			goldenDir := filepath.Join("testdata", "golden", tt.name)
+			if *flagUpdateGolden {
+				if err := writeGoldenDir(goldenDir, got); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := readGoldenDir(goldenDir)
+			if err != nil {
+				t.Fatalf("unable to read golden files, run with -update to create them: %s", err)
+			}
+
+			for _, name := range sortedKeys(want) {
+				if _, ok := got[name]; !ok {
+					t.Errorf("%s: file is no longer generated", name)
+				}
+			}
+			for _, name := range sortedKeys(got) {
+				wantFile, ok := want[name]
+				if !ok {
+					t.Errorf("%s: file has no golden file", name)
+					continue
+				}
+				if !bytes.Equal(wantFile, got[name]) {
+					t.Errorf("%s: output differs from the golden file, run with -update and review the diff\n%s", name, unifiedDiff(name, wantFile, got[name]))
+				}
+			}
+		})
+	}
+}
+
+// vetGoldenDir makes a module of the output in dir using this checkout of
+// sqlboiler and runs go vet on it, which compiles its tests too
+func vetGoldenDir(t *testing.T, dir string) {
+	t.Helper()
+
+	goMod, err := exec.Command("go", "env", "GOMOD").Output()
+	if err != nil {
+		t.Fatalf("go env GOMOD cmd execution failed: %s", err)
+	}
+
+	cmds := [][]string{
+		{"go", "mod", "init", "github.com/volatiletech/sqlboiler-test"},
+		{"go", "mod", "edit", "-replace=github.com/volatiletech/sqlboiler/v4=" + filepath.Dir(strings.TrimSpace(string(goMod)))},
+		{"go", "mod", "tidy"},
+		{"go", "vet", "./..."},
+	}
+	for _, args := range cmds {
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("%s failed: %s\n%s", strings.Join(args, " "), err, out)
+			return
+		}
+	}
+}
+
+// unifiedDiff returns the lines changed from want to got in the unified
+// format of diff -u, with three lines of context around each hunk
+func unifiedDiff(name string, want, got []byte) string {
+	a := strings.SplitAfter(string(want), "\n")
+	b := strings.SplitAfter(string(got), "\n")
+	if last := len(a) - 1; a[last] == "" {
+		a = a[:last]
+	}
+	if last := len(b) - 1; b[last] == "" {
+		b = b[:last]
+	}
+
+	// The longest common subsequence of the lines, lcs[i*(len(b)+1)+j]
+	// being its length for a[i:] and b[j:]
+	w := len(b) + 1
+	lcs := make([]int32, (len(a)+1)*w)
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i*w+j] = lcs[(i+1)*w+j+1] + 1
+			case lcs[(i+1)*w+j] >= lcs[i*w+j+1]:
+				lcs[i*w+j] = lcs[(i+1)*w+j]
+			default:
+				lcs[i*w+j] = lcs[i*w+j+1]
+			}
+		}
+	}
+
+	type line struct {
+		op   byte
+		text string
+		i, j int
+	}
+	var lines []line
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, line{' ', a[i], i, j})
+			i++
+			j++
+		case j == len(b) || i < len(a) && lcs[(i+1)*w+j] >= lcs[i*w+j+1]:
+			lines = append(lines, line{'-', a[i], i, j})
+			i++
+		default:
+			lines = append(lines, line{'+', b[j], i, j})
+			j++
+		}
+	}
+
+	const context = 3
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "--- %s (golden)\n+++ %s (generated)\n", name, name)
+	for k := 0; k < len(lines); {
+		if lines[k].op == ' ' {
+			k++
+			continue
+		}
+
+		start := k - context
+		if start < 0 {
+			start = 0
+		}
+		end := k
+		for end < len(lines) {
+			if lines[end].op != ' ' {
+				end++
+				continue
+			}
+			next := end
+			for next < len(lines) && next-end <= 2*context && lines[next].op == ' ' {
+				next++
+			}
+			if next == len(lines) || next-end > 2*context {
+				break
+			}
+			end = next
+		}
+		stop := end + context
+		if stop > len(lines) {
+			stop = len(lines)
+		}
+
+		var nA, nB int
+		for _, l := range lines[start:stop] {
+			if l.op != '+' {
+				nA++
+			}
+			if l.op != '-' {
+				nB++
+			}
+		}
+		fmt.Fprintf(buf, "@@ -%d,%d +%d,%d @@\n", lines[start].i+1, nA, lines[start].j+1, nB)
+		for _, l := range lines[start:stop] {
+			buf.WriteByte(l.op)
+			buf.WriteString(strings.TrimSuffix(l.text, "\n"))
+			buf.WriteByte('\n')
+		}
+		k = stop
+	}
+
+	return buf.String()
+}
+
+// readGoldenDir reads every file under dir keyed by its slash separated path
+// relative to dir. Golden files carry a .golden suffix so that go tooling
+// does not try to compile them.
+func readGoldenDir(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		rel = filepath.ToSlash(rel)
+		if ext := filepath.Ext(rel); ext == ".golden" {
+			rel = rel[:len(rel)-len(ext)]
+		}
+		files[rel] = b
+		return nil
+	})
+
+	return files, err
+}
+
+func writeGoldenDir(dir string, files map[string][]byte) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+
+	for name, b := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name)+".golden")
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, b, 0664); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sortedKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,254 @@
+package boilingcore
+
+import (
+	"strings"
+
+	"github.com/volatiletech/strmangle"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// graphQLTemplate is the singleton with the GraphQL resolver and pagination
+// helpers, it names its entry in the singleton imports
+const graphQLTemplate = "boil_graphql"
+
+// graphQLScalars maps the Go types of columns to GraphQL scalar types
+var graphQLScalars = map[string]string{
+	"int":       "Int",
+	"int8":      "Int",
+	"int16":     "Int",
+	"int32":     "Int",
+	"int64":     "Int",
+	"uint":      "Int",
+	"uint8":     "Int",
+	"byte":      "Int",
+	"uint16":    "Int",
+	"uint32":    "Int",
+	"uint64":    "Int",
+	"float32":   "Float",
+	"float64":   "Float",
+	"bool":      "Boolean",
+	"string":    "String",
+	"time.Time": "Time",
+}
+
+// graphQLGoTypes are the Go types gqlgen binds the scalar types to
+var graphQLGoTypes = map[string]string{
+	"Int":     "int",
+	"Float":   "float64",
+	"Boolean": "bool",
+	"String":  "string",
+	"Time":    "time.Time",
+}
+
+// graphQLNullFields name the field holding the value of the null package types
+var graphQLNullFields = map[string]string{
+	"null.Int":     "int",
+	"null.Int8":    "int8",
+	"null.Int16":   "int16",
+	"null.Int32":   "int32",
+	"null.Int64":   "int64",
+	"null.Uint":    "uint",
+	"null.Uint8":   "uint8",
+	"null.Uint16":  "uint16",
+	"null.Uint32":  "uint32",
+	"null.Uint64":  "uint64",
+	"null.Byte":    "byte",
+	"null.Float32": "float32",
+	"null.Float64": "float64",
+	"null.Bool":    "bool",
+	"null.String":  "string",
+	"null.Time":    "time.Time",
+}
+
+// graphQLArrays maps the array types to the GraphQL types of their elements
+var graphQLArrays = map[string]string{
+	"types.Int64Array":   "Int",
+	"types.Float64Array": "Float",
+	"types.BoolArray":    "Boolean",
+	"types.StringArray":  "String",
+}
+
+// GraphQLType is the GraphQL object type of the model of a table
+type GraphQLType struct {
+	Name   string
+	Fields []GraphQLField
+	// Keys are the arguments of the query of a row by its primary key, there
+	// is no such query when they are empty
+	Keys []GraphQLField
+	// Skipped are the columns whose types have no GraphQL type
+	Skipped []drivers.Column
+}
+
+// GraphQLField is a field of a GraphQL object type, named after its column
+type GraphQLField struct {
+	Name   string
+	Column string
+	Type   string
+
+	// GoName is the method or field of the model gqlgen binds the field to,
+	// empty when it matches the name of the field
+	GoName string
+	// Getter is the value of the method GoName of the model o, of the Go
+	// type GoType, for the columns whose types gqlgen cannot bind. The
+	// method returns nil when Valid is false.
+	Getter string
+	Valid  string
+	GoType string
+
+	// Param is the Go parameter of a key, of the Go type GoType, and Arg
+	// converts it to the type of its column
+	Param string
+	Arg   string
+}
+
+// initGraphQL builds the GraphQL type of every table and sets the imports of
+// the resolver helpers
+func (s *State) initGraphQL() error {
+	if !s.Config.WithGraphQL {
+		return nil
+	}
+
+	s.GraphQLTypes = make(map[string]GraphQLType)
+	for _, t := range s.Tables {
+		if t.IsJoinTable {
+			continue
+		}
+
+		alias := s.Config.Aliases.Table(t.Name)
+		typ := GraphQLType{Name: alias.UpSingular}
+		for _, c := range t.Columns {
+			field, ok := s.graphQLField(c, alias.Column(c.Name))
+			if !ok {
+				typ.Skipped = append(typ.Skipped, c)
+				continue
+			}
+			typ.Fields = append(typ.Fields, field)
+		}
+
+		if !t.IsView && t.PKey != nil {
+			typ.Keys = graphQLKeys(t, alias, typ.Fields)
+		}
+
+		s.GraphQLTypes[t.Name] = typ
+	}
+
+	imps := importers.Set{
+		Standard: importers.List{`"encoding/base64"`, `"strconv"`, `"strings"`},
+		ThirdParty: importers.List{
+			`"github.com/friendsofgo/errors"`,
+			`"github.com/volatiletech/sqlboiler/v4/boil"`,
+			`"github.com/volatiletech/sqlboiler/v4/queries/qm"`,
+		},
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[graphQLTemplate] = imps
+
+	return nil
+}
+
+// graphQLField maps the column to a field of the GraphQL type, it is false for
+// columns of types without a mapping
+func (s *State) graphQLField(c drivers.Column, goField string) (GraphQLField, bool) {
+	field := GraphQLField{Name: camelCase(c.Name), Column: c.Name}
+	o := "o." + goField
+
+	// getter binds the field to a method of the model returning the value
+	getter := func(typ, goType, value, valid string) {
+		field.Type = typ
+		field.GoName = "GraphQL" + goField
+		field.GoType = goType
+		field.Getter = value
+		field.Valid = valid
+	}
+
+	switch {
+	case s.Config.AddEnumTypes && drivers.IsEnumDBType(c.DBType) && c.Type != "string" && c.Type != "null.String":
+		if c.Nullable {
+			getter("String", "string", "string("+o+".Val)", o+".Valid")
+		} else {
+			getter("String!", "string", "string("+o+")", "")
+		}
+	case graphQLArrays[c.Type] != "":
+		elem := graphQLArrays[c.Type]
+		typ := "[" + elem + "!]"
+		if !c.Nullable {
+			typ += "!"
+		}
+		getter(typ, "[]"+graphQLGoTypes[elem], "[]"+graphQLGoTypes[elem]+"("+o+")", "")
+	case graphQLNullFields[c.Type] != "":
+		base := graphQLNullFields[c.Type]
+		scalar := graphQLScalars[base]
+		value := o + "." + strings.TrimPrefix(c.Type, "null.")
+		getter(scalar, graphQLGoTypes[scalar], graphQLConvert(graphQLGoTypes[scalar], base, value), o+".Valid")
+	case graphQLScalars[c.Type] != "":
+		scalar := graphQLScalars[c.Type]
+		goType := graphQLGoTypes[scalar]
+		if goType != c.Type {
+			getter(scalar+"!", goType, graphQLConvert(goType, c.Type, o), "")
+			break
+		}
+		field.Type = scalar + "!"
+		if !strings.EqualFold(field.Name, goField) {
+			field.GoName = goField
+		}
+	default:
+		return GraphQLField{}, false
+	}
+
+	return field, true
+}
+
+// graphQLKeys returns the arguments of the query of a row of the table by its
+// primary key, none if a key column is not a non-null scalar
+func graphQLKeys(t drivers.Table, alias TableAlias, fields []GraphQLField) []GraphQLField {
+	var keys []GraphQLField
+	for _, name := range t.PKey.Columns {
+		c := t.GetColumn(name)
+		scalar := graphQLScalars[c.Type]
+		if len(scalar) == 0 || c.Nullable {
+			return nil
+		}
+
+		for _, f := range fields {
+			if f.Column != name {
+				continue
+			}
+			key := f
+			key.Type = scalar + "!"
+			key.GoType = graphQLGoTypes[scalar]
+			key.Param = strmangle.ReplaceReservedWords(strmangle.CamelCase(alias.Column(name)))
+			key.Arg = graphQLConvert(c.Type, graphQLGoTypes[scalar], key.Param)
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// graphQLName returns the GraphQL name of a field named name in Go, its
+// leading upper case letters are lower cased like an initialism
+func graphQLName(name string) string {
+	b := []byte(name)
+	for i := range b {
+		if b[i] < 'A' || b[i] > 'Z' {
+			break
+		}
+		if i != 0 && i+1 < len(b) && b[i+1] >= 'a' && b[i+1] <= 'z' {
+			break
+		}
+		b[i] += 'a' - 'A'
+	}
+	return string(b)
+}
+
+// graphQLConvert converts the value of the Go type from to the Go type to
+func graphQLConvert(to, from, value string) string {
+	if to == from {
+		return value
+	}
+	return to + "(" + value + ")"
+}
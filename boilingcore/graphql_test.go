@@ -0,0 +1,112 @@
+package boilingcore
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestGraphQLName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"Jets":           "jets",
+		"PilotLanguages": "pilotLanguages",
+		"ID":             "id",
+		"IDCard":         "idCard",
+		"jets":           "jets",
+	}
+
+	for name, want := range tests {
+		if got := graphQLName(name); got != want {
+			t.Errorf("%s: want %s, got: %s", name, want, got)
+		}
+	}
+}
+
+func TestInitGraphQL(t *testing.T) {
+	t.Parallel()
+
+	tables := []drivers.Table{
+		{
+			Name: "pilots",
+			Columns: []drivers.Column{
+				{Name: "id", Type: "int64"},
+				{Name: "name", Type: "string"},
+				{Name: "nick", Type: "null.String", Nullable: true},
+				{Name: "rating", Type: "null.Float32", Nullable: true},
+				{Name: "born_at", Type: "time.Time"},
+				{Name: "tags", Type: "types.StringArray"},
+				{Name: "rank", Type: "Rank", DBType: "enum.rank('a','b')"},
+				{Name: "salary", Type: "types.Decimal"},
+			},
+			PKey: &drivers.PrimaryKey{Name: "pilots_pkey", Columns: []string{"id"}},
+		},
+		{
+			Name:    "pilot_views",
+			IsView:  true,
+			Columns: []drivers.Column{{Name: "name", Type: "string"}},
+		},
+		{
+			Name:        "pilot_jets",
+			IsJoinTable: true,
+			FKeys: []drivers.ForeignKey{
+				{Name: "pilot_jets_pilot_fkey", Table: "pilot_jets", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"},
+				{Name: "pilot_jets_jet_fkey", Table: "pilot_jets", Column: "jet_id", ForeignTable: "jets", ForeignColumn: "id"},
+			},
+		},
+	}
+
+	s := &State{
+		Config: &Config{PkgName: "models", WithGraphQL: true, AddEnumTypes: true},
+		Tables: tables,
+	}
+	FillAliases(&s.Config.Aliases, tables)
+	if err := s.initGraphQL(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := s.GraphQLTypes["pilot_jets"]; ok {
+		t.Error("join tables have no type")
+	}
+	if view := s.GraphQLTypes["pilot_views"]; len(view.Fields) != 1 || len(view.Keys) != 0 {
+		t.Errorf("want a view without keys, got: %#v", view)
+	}
+
+	typ := s.GraphQLTypes["pilots"]
+	if typ.Name != "Pilot" {
+		t.Errorf("want Pilot, got: %s", typ.Name)
+	}
+	if len(typ.Skipped) != 1 || typ.Skipped[0].Name != "salary" {
+		t.Errorf("want salary skipped, got: %v", typ.Skipped)
+	}
+
+	got := make(map[string]GraphQLField)
+	for _, f := range typ.Fields {
+		got[f.Column] = f
+	}
+	want := map[string]GraphQLField{
+		"id":      {Name: "id", Column: "id", Type: "Int!", GoName: "GraphQLID", Getter: "int(o.ID)", GoType: "int"},
+		"name":    {Name: "name", Column: "name", Type: "String!"},
+		"nick":    {Name: "nick", Column: "nick", Type: "String", GoName: "GraphQLNick", Getter: "o.Nick.String", Valid: "o.Nick.Valid", GoType: "string"},
+		"rating":  {Name: "rating", Column: "rating", Type: "Float", GoName: "GraphQLRating", Getter: "float64(o.Rating.Float32)", Valid: "o.Rating.Valid", GoType: "float64"},
+		"born_at": {Name: "bornAt", Column: "born_at", Type: "Time!"},
+		"tags":    {Name: "tags", Column: "tags", Type: "[String!]!", GoName: "GraphQLTags", Getter: "[]string(o.Tags)", GoType: "[]string"},
+		"rank":    {Name: "rank", Column: "rank", Type: "String!", GoName: "GraphQLRank", Getter: "string(o.Rank)", GoType: "string"},
+	}
+	for name, w := range want {
+		if got[name] != w {
+			t.Errorf("%s:\nwant %#v\ngot  %#v", name, w, got[name])
+		}
+	}
+
+	if len(typ.Keys) != 1 {
+		t.Fatalf("want one key, got: %#v", typ.Keys)
+	}
+	if key := typ.Keys[0]; key.Name != "id" || key.Type != "Int!" || key.GoType != "int" || key.Param != "iD" || key.Arg != "int64(iD)" {
+		t.Errorf("key was wrong: %#v", key)
+	}
+	if _, ok := s.Config.Imports.Singleton[graphQLTemplate]; !ok {
+		t.Error("want the imports of the resolver helpers")
+	}
+}
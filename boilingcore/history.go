@@ -0,0 +1,133 @@
+package boilingcore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/strmangle"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// historyTemplate is the singleton with the history models of the tables, it
+// names its entry in the singleton imports
+const historyTemplate = "boil_history"
+
+// HistoryTable is a table whose versions are kept in its history table, with
+// the times they were valid from and to
+type HistoryTable struct {
+	Name string
+	// Table is the name of the history table
+	Table string
+
+	// From is the quoted history table
+	From string
+	// Insert inserts a version, its arguments are the columns of the table
+	// and valid_from
+	Insert string
+	// Close ends the current version of a row, its arguments are valid_to
+	// and the primary key
+	Close string
+	// Key selects the versions of a row, its arguments are the primary key,
+	// and AsOf the versions valid at a time, its arguments are the time twice
+	Key  string
+	AsOf string
+	// Order orders the versions of a row from the oldest
+	Order string
+}
+
+// initHistory builds the history tables from the history config and sets the
+// imports of their models
+func (s *State) initHistory() error {
+	if len(s.Config.History.Tables) == 0 {
+		return nil
+	}
+
+	if s.Config.NoHooks {
+		return errors.New("the history is recorded by hooks and cannot be used with no-hooks")
+	}
+	suffix := s.Config.History.Suffix
+	if len(suffix) == 0 {
+		suffix = "_history"
+	}
+
+	s.HistoryTables = nil
+	for _, name := range s.Config.History.Tables {
+		found := false
+		for _, t := range s.Tables {
+			if t.Name != name {
+				continue
+			}
+			if t.IsView || t.IsJoinTable || t.PKey == nil {
+				return errors.Errorf("history table %s must be a table with a primary key, not a view or join table", name)
+			}
+			found = true
+
+			history := s.historyTable(name, name+suffix, t.PKey.Columns, drivers.ColumnNames(t.Columns))
+			s.HistoryTables = append(s.HistoryTables, history)
+			break
+		}
+		if !found {
+			return errors.Errorf("history table %s was not found", name)
+		}
+
+		model := s.Config.Aliases.Table(name).UpSingular + "History"
+		for _, t := range s.Tables {
+			if s.Config.Aliases.Table(t.Name).UpSingular == model {
+				return errors.Errorf("history model %s of %s is the model of the table %s", model, name, t.Name)
+			}
+		}
+	}
+
+	imps := importers.Set{
+		Standard: importers.List{`"time"`},
+		ThirdParty: importers.List{
+			`"github.com/friendsofgo/errors"`,
+			`"github.com/volatiletech/null/v8"`,
+			`"github.com/volatiletech/sqlboiler/v4/boil"`,
+			`"github.com/volatiletech/sqlboiler/v4/queries"`,
+			`"github.com/volatiletech/sqlboiler/v4/queries/qm"`,
+		},
+	}
+	if !s.Config.NoContext {
+		imps.Standard = append(imps.Standard, `"context"`)
+	}
+	if !s.Config.AlwaysWrapErrors {
+		imps.Standard = append(imps.Standard, `"database/sql"`)
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[historyTemplate] = imps
+
+	return nil
+}
+
+// historyTable returns the queries of the history table of the table in the
+// SQL dialect
+func (s *State) historyTable(name, table string, pkey, columns []string) HistoryTable {
+	lq, rq := string(s.Dialect.LQ), string(s.Dialect.RQ)
+	from := strmangle.SchemaTable(lq, rq, s.Dialect.UseSchema, s.Schema, table)
+	validFrom, validTo := lq+"valid_from"+rq, lq+"valid_to"+rq
+
+	// The primary key of the close query follows valid_to
+	keyStart := 0
+	if s.Dialect.UseIndexPlaceholders {
+		keyStart = 2
+	}
+	cols := append(strmangle.IdentQuoteSlice(s.Dialect.LQ, s.Dialect.RQ, columns), validFrom)
+
+	h := HistoryTable{Name: name, Table: table, From: from}
+	h.Insert = fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", from, strings.Join(cols, ", "),
+		strmangle.Placeholders(s.Dialect.UseIndexPlaceholders, len(cols), 1, 1))
+	h.Close = fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s IS NULL AND %s", from, validTo,
+		strmangle.Placeholders(s.Dialect.UseIndexPlaceholders, 1, 1, 1), validTo,
+		strmangle.WhereClause(lq, rq, keyStart, pkey))
+	h.Key = strmangle.WhereClause(lq, rq, 0, pkey)
+	h.Order = from + "." + validFrom
+	h.AsOf = fmt.Sprintf("%[1]s.%[2]s <= ? AND (%[1]s.%[3]s IS NULL OR %[1]s.%[3]s > ?)", from, validFrom, validTo)
+
+	return h
+}
@@ -0,0 +1,75 @@
+package boilingcore
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestInitHistory(t *testing.T) {
+	t.Parallel()
+
+	s := testState(Config{
+		PkgName: "models",
+		History: History{Tables: []string{"accounts", "rates"}, Suffix: "_versions"},
+	})
+	s.Dialect = drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true}
+	if err := s.initHistory(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []HistoryTable{
+		{
+			Name:   "accounts",
+			Table:  "accounts_versions",
+			From:   `"accounts_versions"`,
+			Insert: `INSERT INTO "accounts_versions" ("id", "owner", "valid_from") VALUES ($1,$2,$3)`,
+			Close:  `UPDATE "accounts_versions" SET "valid_to" = $1 WHERE "valid_to" IS NULL AND "id"=$2`,
+			Key:    `"id"=?`,
+			AsOf:   `"accounts_versions"."valid_from" <= ? AND ("accounts_versions"."valid_to" IS NULL OR "accounts_versions"."valid_to" > ?)`,
+			Order:  `"accounts_versions"."valid_from"`,
+		},
+		{
+			Name:   "rates",
+			Table:  "rates_versions",
+			From:   `"rates_versions"`,
+			Insert: `INSERT INTO "rates_versions" ("currency", "day", "rate", "valid_from") VALUES ($1,$2,$3,$4)`,
+			Close:  `UPDATE "rates_versions" SET "valid_to" = $1 WHERE "valid_to" IS NULL AND "currency"=$2 AND "day"=$3`,
+			Key:    `"currency"=? AND "day"=?`,
+			AsOf:   `"rates_versions"."valid_from" <= ? AND ("rates_versions"."valid_to" IS NULL OR "rates_versions"."valid_to" > ?)`,
+			Order:  `"rates_versions"."valid_from"`,
+		},
+	}
+	if !reflect.DeepEqual(s.HistoryTables, want) {
+		t.Errorf("want: %#v\ngot: %#v", want, s.HistoryTables)
+	}
+	if _, ok := s.Config.Imports.Singleton[historyTemplate]; !ok {
+		t.Error("history imports are not set")
+	}
+}
+
+func TestInitHistoryErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		config Config
+		err    string
+	}{
+		{Config{History: History{Tables: []string{"ledgers"}}}, "ledgers was not found"},
+		{Config{History: History{Tables: []string{"account_owners"}}}, "not a view or join table"},
+		{Config{History: History{Tables: []string{"teams"}}}, "is the model of the table team_histories"},
+		{Config{History: History{Tables: []string{"rates"}}, NoHooks: true}, "cannot be used with no-hooks"},
+	}
+
+	for _, test := range tests {
+		s := testState(test.config)
+		s.Dialect = drivers.Dialect{LQ: '"', RQ: '"'}
+
+		err := s.initHistory()
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%v: want error containing %q, got: %v", test.config.History.Tables, test.err, err)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package boilingcore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/volatiletech/strmangle"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// httpTemplate is the singleton with the helpers of the HTTP handlers, it
+// names its entry in the singleton imports
+const httpTemplate = "boil_http"
+
+// HTTPHandler is the HTTP handler of the model of a table
+type HTTPHandler struct {
+	// Keys parse the primary key of the row a request is for from its path,
+	// there are none when the types of its columns cannot be parsed
+	Keys []HTTPKey
+	// Filters are the columns the list handler filters by with query
+	// parameters named after them
+	Filters []string
+}
+
+// HTTPKey is a column of the primary key of the rows of a handler
+type HTTPKey struct {
+	// Parse parses the value of the column in the path into the variable Var,
+	// it is empty for strings
+	Parse string
+	Var   string
+	// Arg is the argument of the finder of the column
+	Arg string
+}
+
+// initHTTP builds the HTTP handler of every table and sets the imports of the
+// handlers
+func (s *State) initHTTP() error {
+	if !s.Config.WithHTTP {
+		return nil
+	}
+
+	ignore := make(map[string]struct{})
+	for _, v := range s.Config.TagIgnore {
+		ignore[v] = struct{}{}
+	}
+
+	s.HTTPHandlers = make(map[string]HTTPHandler)
+	for _, t := range s.Tables {
+		if t.IsJoinTable {
+			continue
+		}
+
+		var h HTTPHandler
+		for _, c := range t.Columns {
+			if !strmangle.Ignore(t.Name, c.Name, ignore) {
+				h.Filters = append(h.Filters, c.Name)
+			}
+		}
+		if !t.IsView && t.PKey != nil {
+			h.Keys = httpKeys(t)
+		}
+
+		s.HTTPHandlers[t.Name] = h
+	}
+
+	s.Config.Imports.All.Standard = append(s.Config.Imports.All.Standard, `"net/http"`)
+
+	imps := importers.Set{
+		Standard:   importers.List{`"database/sql"`, `"encoding/json"`, `"net/http"`, `"strconv"`, `"strings"`},
+		ThirdParty: importers.List{`"github.com/friendsofgo/errors"`, `"github.com/volatiletech/sqlboiler/v4/queries/qm"`},
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[httpTemplate] = imps
+
+	return nil
+}
+
+// httpKeys returns the parsing of the primary key of the table from paths,
+// nil when a column has a type that is not an integer, a bool or a string
+func httpKeys(t drivers.Table) []HTTPKey {
+	columns := make(map[string]drivers.Column)
+	for _, c := range t.Columns {
+		columns[c.Name] = c
+	}
+
+	keys := make([]HTTPKey, len(t.PKey.Columns))
+	for i, name := range t.PKey.Columns {
+		c := columns[name]
+		base, null := c.Type, ""
+		if b, ok := csvNullBases[c.Type]; ok {
+			base, null = b, strings.TrimPrefix(c.Type, "null.")
+		}
+
+		key := HTTPKey{Var: fmt.Sprintf("k%d", i)}
+		value := fmt.Sprintf("key[%d]", i)
+		switch h := csvBases[base]; h.Helper {
+		case "int", "uint":
+			key.Parse = fmt.Sprintf("http%s(%s, %s)", strmangle.TitleCase(h.Helper), value, h.Bits)
+			value = protoConvert(base, h.Type, key.Var)
+		case "bool":
+			key.Parse = fmt.Sprintf("httpBool(%s)", value)
+			value = key.Var
+		case "string":
+		default:
+			return nil
+		}
+
+		key.Arg = value
+		if len(null) != 0 {
+			key.Arg = fmt.Sprintf("null.%sFrom(%s)", null, value)
+		}
+		keys[i] = key
+	}
+
+	return keys
+}
@@ -0,0 +1,81 @@
+package boilingcore
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestInitHTTP(t *testing.T) {
+	t.Parallel()
+
+	tables := []drivers.Table{
+		{
+			Name: "pilots",
+			Columns: []drivers.Column{
+				{Name: "id", Type: "int"},
+				{Name: "name", Type: "string"},
+				{Name: "password", Type: "string"},
+			},
+			PKey: &drivers.PrimaryKey{Columns: []string{"id"}},
+		},
+		{
+			Name: "licenses",
+			Columns: []drivers.Column{
+				{Name: "pilot_id", Type: "null.Int64", Nullable: true},
+				{Name: "code", Type: "string"},
+				{Name: "class", Type: "uint8"},
+				{Name: "active", Type: "bool"},
+			},
+			PKey: &drivers.PrimaryKey{Columns: []string{"pilot_id", "code", "class", "active"}},
+		},
+		{
+			Name:    "flights",
+			Columns: []drivers.Column{{Name: "departed_at", Type: "time.Time"}},
+			PKey:    &drivers.PrimaryKey{Columns: []string{"departed_at"}},
+		},
+		{
+			Name:    "pilot_names",
+			IsView:  true,
+			Columns: []drivers.Column{{Name: "name", Type: "string"}},
+		},
+		{
+			Name:        "pilot_languages",
+			IsJoinTable: true,
+		},
+	}
+
+	s := &State{
+		Config: &Config{PkgName: "models", WithHTTP: true, TagIgnore: []string{"password"}},
+		Tables: tables,
+	}
+	if err := s.initHTTP(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]HTTPHandler{
+		"pilots": {
+			Keys:    []HTTPKey{{Parse: "httpInt(key[0], 64)", Var: "k0", Arg: "int(k0)"}},
+			Filters: []string{"id", "name"},
+		},
+		"licenses": {
+			Keys: []HTTPKey{
+				{Parse: "httpInt(key[0], 64)", Var: "k0", Arg: "null.Int64From(k0)"},
+				{Var: "k1", Arg: "key[1]"},
+				{Parse: "httpUint(key[2], 8)", Var: "k2", Arg: "uint8(k2)"},
+				{Parse: "httpBool(key[3])", Var: "k3", Arg: "k3"},
+			},
+			Filters: []string{"pilot_id", "code", "class", "active"},
+		},
+		// Times cannot be parsed from paths, the rows are only listed
+		"flights":     {Filters: []string{"departed_at"}},
+		"pilot_names": {Filters: []string{"name"}},
+	}
+	if !reflect.DeepEqual(s.HTTPHandlers, want) {
+		t.Errorf("want: %#v\ngot: %#v", want, s.HTTPHandlers)
+	}
+	if _, ok := s.Config.Imports.Singleton[httpTemplate]; !ok {
+		t.Error("HTTP imports are not set")
+	}
+}
@@ -0,0 +1,113 @@
+package boilingcore
+
+import (
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/strmangle"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+// Inheritance maps the rows of a table to subtypes by the value of its
+// discriminator column, like the single table inheritance of Rails. The
+// model of the table is the base of the structs of the subtypes.
+type Inheritance struct {
+	Table string `toml:"table,omitempty" json:"table,omitempty"`
+	// Column is the discriminator column, type by default
+	Column   string    `toml:"column,omitempty" json:"column,omitempty"`
+	Subtypes []Subtype `toml:"subtypes,omitempty" json:"subtypes,omitempty"`
+}
+
+// Subtype is a subtype of the rows of a table whose discriminator column
+// holds its Value
+type Subtype struct {
+	// Name names the struct of the subtype, it is title cased so
+	// pickup_truck names PickupTruck
+	Name string `toml:"name,omitempty" json:"name,omitempty"`
+	// Value defaults to the name, which is the class name Rails stores
+	Value string `toml:"value,omitempty" json:"value,omitempty"`
+}
+
+// initInheritances checks the subtypes of the tables against their
+// discriminator columns and the names of the models
+func (s *State) initInheritances() error {
+	s.Inheritances = nil
+	if len(s.Config.Inheritances) == 0 {
+		return nil
+	}
+
+	tables := make(map[string]drivers.Table)
+	models := make(map[string]string)
+	for _, t := range s.Tables {
+		if !t.IsJoinTable && !t.IsView {
+			tables[t.Name] = t
+		}
+		if !t.IsJoinTable {
+			models[s.Config.Aliases.Table(t.Name).UpSingular] = t.Name
+		}
+	}
+
+	inherited := make(map[string]bool)
+	names := make(map[string]string)
+	for _, inh := range s.Config.Inheritances {
+		if len(inh.Column) == 0 {
+			inh.Column = "type"
+		}
+
+		t, ok := tables[inh.Table]
+		if !ok {
+			return errors.Errorf("table %s of the inheritance was not found", inh.Table)
+		}
+		if inherited[t.Name] {
+			return errors.Errorf("inheritance of table %s is defined twice", t.Name)
+		}
+		inherited[t.Name] = true
+
+		found := false
+		for _, c := range t.Columns {
+			if c.Name == inh.Column {
+				found = true
+				if c.Type != "string" && c.Type != "null.String" {
+					return errors.Errorf("discriminator column %s.%s is of type %s, want a string", t.Name, c.Name, c.Type)
+				}
+			}
+		}
+		if !found {
+			return errors.Errorf("discriminator column %s of table %s was not found", inh.Column, t.Name)
+		}
+
+		if len(inh.Subtypes) == 0 {
+			return errors.Errorf("inheritance of table %s has no subtypes", t.Name)
+		}
+
+		subtypes := make([]Subtype, len(inh.Subtypes))
+		values := make(map[string]bool)
+		for i, sub := range inh.Subtypes {
+			sub.Name = strmangle.TitleCase(sub.Name)
+			if !rgxProtoIdent.MatchString(sub.Name) {
+				return errors.Errorf("subtype name %q of table %s is not a valid identifier", sub.Name, t.Name)
+			}
+			if table, ok := models[sub.Name]; ok {
+				return errors.Errorf("subtype %s of table %s has the name of the model of table %s", sub.Name, t.Name, table)
+			}
+			if table, ok := names[sub.Name]; ok {
+				return errors.Errorf("subtype %s of table %s is already a subtype of table %s", sub.Name, t.Name, table)
+			}
+			names[sub.Name] = t.Name
+
+			if len(sub.Value) == 0 {
+				sub.Value = sub.Name
+			}
+			if values[sub.Value] {
+				return errors.Errorf("value %s of the discriminator column of table %s names several subtypes", sub.Value, t.Name)
+			}
+			values[sub.Value] = true
+
+			subtypes[i] = sub
+		}
+		inh.Subtypes = subtypes
+
+		s.Inheritances = append(s.Inheritances, inh)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package boilingcore
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInitInheritances(t *testing.T) {
+	t.Parallel()
+
+	s := testState(Config{Inheritances: []Inheritance{
+		{
+			Table:    "vehicles",
+			Subtypes: []Subtype{{Name: "Truck"}, {Name: "pickup_truck", Value: "pickup"}},
+		},
+	}})
+	if err := s.initInheritances(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Inheritance{
+		{
+			Table:    "vehicles",
+			Column:   "type",
+			Subtypes: []Subtype{{Name: "Truck", Value: "Truck"}, {Name: "PickupTruck", Value: "pickup"}},
+		},
+	}
+	if !reflect.DeepEqual(s.Inheritances, want) {
+		t.Errorf("want: %#v\ngot: %#v", want, s.Inheritances)
+	}
+}
+
+func TestInitInheritancesErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inheritances []Inheritance
+		err          string
+	}{
+		{[]Inheritance{{Table: "boats"}}, "table boats of the inheritance was not found"},
+		{[]Inheritance{{Table: "vehicle_names"}}, "table vehicle_names of the inheritance was not found"},
+		{[]Inheritance{{Table: "cars"}}, "discriminator column type of table cars was not found"},
+		{[]Inheritance{{Table: "vehicles", Column: "wheels"}}, "discriminator column vehicles.wheels is of type int, want a string"},
+		{[]Inheritance{{Table: "vehicles", Column: "kind"}}, "inheritance of table vehicles has no subtypes"},
+		{[]Inheritance{{Table: "vehicles", Subtypes: []Subtype{{Name: "2Wheeler"}}}}, "not a valid identifier"},
+		{[]Inheritance{{Table: "vehicles", Subtypes: []Subtype{{Name: "car"}}}}, "subtype Car of table vehicles has the name of the model of table cars"},
+		{[]Inheritance{{Table: "vehicles", Subtypes: []Subtype{{Name: "Truck"}, {Name: "Van", Value: "Truck"}}}}, "value Truck of the discriminator column of table vehicles names several subtypes"},
+		{
+			[]Inheritance{
+				{Table: "vehicles", Subtypes: []Subtype{{Name: "Truck"}}},
+				{Table: "vehicles", Column: "kind", Subtypes: []Subtype{{Name: "Van"}}},
+			},
+			"inheritance of table vehicles is defined twice",
+		},
+	}
+
+	for _, test := range tests {
+		s := testState(Config{Inheritances: test.inheritances})
+
+		err := s.initInheritances()
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%#v: want error containing %q, got: %v", test.inheritances, test.err, err)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package boilingcore
+
+import (
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// interfacesTemplate is the singleton with the interfaces the models
+// implement, it names its entry in the singleton imports
+const interfacesTemplate = "boil_interfaces"
+
+// initInterfaces sets the imports of the interfaces the models implement
+func (s *State) initInterfaces() {
+	if !s.Config.AddInterfaces {
+		return
+	}
+
+	imps := importers.Set{
+		Standard:   importers.List{`"time"`},
+		ThirdParty: importers.List{`"github.com/volatiletech/sqlboiler/v4/boil"`},
+	}
+	if !s.Config.NoContext {
+		imps.Standard = append(imps.Standard, `"context"`)
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[interfacesTemplate] = imps
+}
@@ -0,0 +1,144 @@
+package boilingcore
+
+import (
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/strmangle"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// joinTemplate is the singleton with the structs and queries of the joins, it
+// names its entry in the singleton imports
+const joinTemplate = "boil_joins"
+
+// Join selects the rows of a table with the related rows of another table in
+// a single query, binding both into a struct with a field per model
+type Join struct {
+	// Name names the struct of the join, its slice and its query, it is title
+	// cased so user_profile names UserProfile
+	Name         string `toml:"name,omitempty" json:"name,omitempty"`
+	Table        string `toml:"table,omitempty" json:"table,omitempty"`
+	ForeignTable string `toml:"foreign_table,omitempty" json:"foreign_table,omitempty"`
+	// ForeignKey is the name of the foreign key joining the tables, it is
+	// only needed when the tables have several
+	ForeignKey string `toml:"foreign_key,omitempty" json:"foreign_key,omitempty"`
+}
+
+// JoinView is a join with the columns its tables are joined on, the Column
+// of the Table equals the ForeignColumn of the ForeignTable
+type JoinView struct {
+	Name          string
+	Table         string
+	ForeignTable  string
+	Column        string
+	ForeignColumn string
+}
+
+// initJoins resolves the foreign keys of the joins and sets the imports of
+// their queries
+func (s *State) initJoins() error {
+	if len(s.Config.Joins) == 0 {
+		return nil
+	}
+
+	tables := make(map[string]drivers.Table)
+	for _, t := range s.Tables {
+		if !t.IsJoinTable && !t.IsView {
+			tables[t.Name] = t
+		}
+	}
+
+	names := make(map[string]bool)
+	softDeletes := false
+	s.JoinViews = nil
+	for _, j := range s.Config.Joins {
+		j.Name = strmangle.TitleCase(j.Name)
+		if !rgxProtoIdent.MatchString(j.Name) {
+			return errors.Errorf("join name %q is not a valid identifier", j.Name)
+		}
+		if names[j.Name] {
+			return errors.Errorf("join %s is defined twice", j.Name)
+		}
+		names[j.Name] = true
+
+		t, ok := tables[j.Table]
+		if !ok {
+			return errors.Errorf("table %s of join %s was not found", j.Table, j.Name)
+		}
+		ft, ok := tables[j.ForeignTable]
+		if !ok {
+			return errors.Errorf("foreign table %s of join %s was not found", j.ForeignTable, j.Name)
+		}
+		if t.Name == ft.Name {
+			return errors.Errorf("join %s cannot join table %s with itself", j.Name, t.Name)
+		}
+
+		view, err := joinView(j, t, ft)
+		if err != nil {
+			return errors.Wrapf(err, "invalid join %s", j.Name)
+		}
+		s.JoinViews = append(s.JoinViews, view)
+
+		deleted := s.Config.AutoColumns.Deleted
+		softDeletes = softDeletes || t.CanSoftDelete(deleted) || ft.CanSoftDelete(deleted)
+	}
+
+	imps := importers.Set{
+		ThirdParty: importers.List{
+			`"github.com/friendsofgo/errors"`,
+			`"github.com/volatiletech/sqlboiler/v4/boil"`,
+			`"github.com/volatiletech/sqlboiler/v4/queries"`,
+			`"github.com/volatiletech/sqlboiler/v4/queries/qm"`,
+		},
+	}
+	if !s.Config.NoContext {
+		imps.Standard = append(imps.Standard, `"context"`)
+	}
+	if !s.Config.AlwaysWrapErrors {
+		imps.Standard = append(imps.Standard, `"database/sql"`)
+	}
+	if s.Config.AddSoftDeletes && softDeletes {
+		imps.ThirdParty = append(imps.ThirdParty, `"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"`)
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[joinTemplate] = imps
+
+	return nil
+}
+
+// joinView finds the foreign key joining the tables of the join, from the
+// table to the foreign table or the other way around
+func joinView(j Join, t, ft drivers.Table) (JoinView, error) {
+	var views []JoinView
+	var fkeys []string
+	for _, fk := range t.FKeys {
+		if fk.ForeignTable == ft.Name && (len(j.ForeignKey) == 0 || fk.Name == j.ForeignKey) {
+			views = append(views, JoinView{Column: fk.Column, ForeignColumn: fk.ForeignColumn})
+			fkeys = append(fkeys, fk.Name)
+		}
+	}
+	for _, fk := range ft.FKeys {
+		if fk.ForeignTable == t.Name && (len(j.ForeignKey) == 0 || fk.Name == j.ForeignKey) {
+			views = append(views, JoinView{Column: fk.ForeignColumn, ForeignColumn: fk.Column})
+			fkeys = append(fkeys, fk.Name)
+		}
+	}
+
+	switch {
+	case len(views) == 0 && len(j.ForeignKey) != 0:
+		return JoinView{}, errors.Errorf("foreign key %s between %s and %s was not found", j.ForeignKey, t.Name, ft.Name)
+	case len(views) == 0:
+		return JoinView{}, errors.Errorf("no foreign key between %s and %s was found", t.Name, ft.Name)
+	case len(views) > 1:
+		return JoinView{}, errors.Errorf("tables %s and %s have several foreign keys, choose one of %v with foreign_key", t.Name, ft.Name, fkeys)
+	}
+
+	view := views[0]
+	view.Name = j.Name
+	view.Table = t.Name
+	view.ForeignTable = ft.Name
+	return view, nil
+}
@@ -0,0 +1,61 @@
+package boilingcore
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInitJoins(t *testing.T) {
+	t.Parallel()
+
+	s := testState(Config{
+		Joins: []Join{
+			{Name: "user_profile", Table: "users", ForeignTable: "profiles"},
+			{Name: "ProfileUser", Table: "profiles", ForeignTable: "users"},
+			{Name: "TransferSender", Table: "transfers", ForeignTable: "users", ForeignKey: "transfers_from_fkey"},
+		},
+	})
+	if err := s.initJoins(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []JoinView{
+		{Name: "UserProfile", Table: "users", ForeignTable: "profiles", Column: "id", ForeignColumn: "user_id"},
+		{Name: "ProfileUser", Table: "profiles", ForeignTable: "users", Column: "user_id", ForeignColumn: "id"},
+		{Name: "TransferSender", Table: "transfers", ForeignTable: "users", Column: "from_id", ForeignColumn: "id"},
+	}
+	if !reflect.DeepEqual(s.JoinViews, want) {
+		t.Errorf("want: %#v\ngot: %#v", want, s.JoinViews)
+	}
+
+	imps := s.Config.Imports.Singleton[joinTemplate]
+	if want := []string{`"context"`, `"database/sql"`}; !reflect.DeepEqual([]string(imps.Standard), want) {
+		t.Errorf("want imports %v, got: %v", want, imps.Standard)
+	}
+}
+
+func TestInitJoinsErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		join Join
+		err  string
+	}{
+		{Join{Name: "2UserProfile", Table: "users", ForeignTable: "profiles"}, "not a valid identifier"},
+		{Join{Name: "UserLedger", Table: "users", ForeignTable: "ledgers"}, "foreign table ledgers of join UserLedger was not found"},
+		{Join{Name: "UserUser", Table: "users", ForeignTable: "users"}, "cannot join table users with itself"},
+		{Join{Name: "UserLog", Table: "users", ForeignTable: "logs"}, "no foreign key between users and logs was found"},
+		{Join{Name: "TransferUser", Table: "transfers", ForeignTable: "users"}, "have several foreign keys"},
+		{Join{Name: "TransferUser", Table: "transfers", ForeignTable: "users", ForeignKey: "profiles_user_fkey"}, "foreign key profiles_user_fkey between transfers and users was not found"},
+	}
+
+	for _, test := range tests {
+		s := testState(Config{Joins: []Join{test.join}})
+
+		err := s.initJoins()
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%#v: want error containing %q, got: %v", test.join, test.err, err)
+		}
+	}
+}
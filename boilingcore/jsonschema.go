@@ -0,0 +1,268 @@
+package boilingcore
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/strmangle"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+// rgxColumnLength matches the length of a column type, like varchar(255)
+var rgxColumnLength = regexp.MustCompile(`\((\d+)\)$`)
+
+// jsonSchemaTypes maps the Go types of columns to JSON Schema types
+var jsonSchemaTypes = map[string]string{
+	"int":           "integer",
+	"int8":          "integer",
+	"int16":         "integer",
+	"int32":         "integer",
+	"int64":         "integer",
+	"uint":          "integer",
+	"uint8":         "integer",
+	"byte":          "integer",
+	"uint16":        "integer",
+	"uint32":        "integer",
+	"uint64":        "integer",
+	"float32":       "number",
+	"float64":       "number",
+	"bool":          "boolean",
+	"string":        "string",
+	"time.Time":     "string",
+	"[]byte":        "string",
+	"types.Decimal": "string",
+}
+
+// jsonSchemaRanges are the minimums and maximums of the sized integer types
+var jsonSchemaRanges = map[string][2]string{
+	"int8":   {"-128", "127"},
+	"int16":  {"-32768", "32767"},
+	"int32":  {"-2147483648", "2147483647"},
+	"uint":   {"0", ""},
+	"uint8":  {"0", "255"},
+	"byte":   {"0", "255"},
+	"uint16": {"0", "65535"},
+	"uint32": {"0", "4294967295"},
+	"uint64": {"0", ""},
+}
+
+// jsonSchemaNullBases are the Go types of the values of the nullable types
+var jsonSchemaNullBases = map[string]string{
+	"null.Int":          "int",
+	"null.Int8":         "int8",
+	"null.Int16":        "int16",
+	"null.Int32":        "int32",
+	"null.Int64":        "int64",
+	"null.Uint":         "uint",
+	"null.Uint8":        "uint8",
+	"null.Uint16":       "uint16",
+	"null.Uint32":       "uint32",
+	"null.Uint64":       "uint64",
+	"null.Byte":         "byte",
+	"null.Float32":      "float32",
+	"null.Float64":      "float64",
+	"null.Bool":         "bool",
+	"null.String":       "string",
+	"null.Time":         "time.Time",
+	"null.Bytes":        "[]byte",
+	"types.NullDecimal": "types.Decimal",
+}
+
+// jsonSchemaArrays maps the array types to the JSON Schema types of their
+// elements
+var jsonSchemaArrays = map[string]string{
+	"types.Int64Array":   "integer",
+	"types.Float64Array": "number",
+	"types.BoolArray":    "boolean",
+	"types.StringArray":  "string",
+	"types.BytesArray":   "string",
+}
+
+// JSONSchema is the JSON Schema of the JSON of the model of a table
+type JSONSchema struct {
+	Title      string
+	Properties []JSONSchemaProperty
+	// Required are the properties of the columns that are not nullable and
+	// have no default
+	Required []string
+}
+
+// JSONSchemaProperty is a property of a JSON Schema, named after the json tag
+// of its column
+type JSONSchemaProperty struct {
+	Name string
+	// Schema is the JSON of the schema of the property
+	Schema string
+}
+
+// jsonSchema is the schema of a property, its fields are in the order they
+// are written in
+type jsonSchema struct {
+	Description     string        `json:"description,omitempty"`
+	Type            interface{}   `json:"type,omitempty"`
+	Format          string        `json:"format,omitempty"`
+	ContentEncoding string        `json:"contentEncoding,omitempty"`
+	Enum            []interface{} `json:"enum,omitempty"`
+	MaxLength       int           `json:"maxLength,omitempty"`
+	Minimum         json.Number   `json:"minimum,omitempty"`
+	Maximum         json.Number   `json:"maximum,omitempty"`
+	Items           *jsonSchema   `json:"items,omitempty"`
+}
+
+// initJSONSchemas builds the JSON Schema of every table
+func (s *State) initJSONSchemas() error {
+	if !s.Config.WithJSONSchema {
+		return nil
+	}
+
+	ignore := make(map[string]struct{})
+	for _, v := range s.Config.TagIgnore {
+		ignore[v] = struct{}{}
+	}
+
+	s.JSONSchemas = make(map[string]JSONSchema)
+	for _, t := range s.Tables {
+		if t.IsJoinTable {
+			continue
+		}
+
+		alias := s.Config.Aliases.Table(t.Name)
+		schema := JSONSchema{Title: alias.UpSingular}
+		for _, c := range t.Columns {
+			if strmangle.Ignore(t.Name, c.Name, ignore) {
+				continue
+			}
+
+			name := jsonTagName(s.Config.StructTagCasing, c.Name, alias.Column(c.Name))
+			b, err := json.Marshal(columnJSONSchema(c))
+			if err != nil {
+				return errors.Wrapf(err, "unable to marshal the JSON Schema of %s.%s", t.Name, c.Name)
+			}
+
+			schema.Properties = append(schema.Properties, JSONSchemaProperty{Name: name, Schema: string(b)})
+			if !c.Nullable && len(c.Default) == 0 && !c.AutoGenerated {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+
+		s.JSONSchemas[t.Name] = schema
+	}
+
+	return nil
+}
+
+// jsonTagName returns the name of a column in the json tags of the models
+func jsonTagName(casing, column, alias string) string {
+	switch casing {
+	case "title":
+		return strmangle.TitleCase(column)
+	case "camel":
+		return strmangle.CamelCase(column)
+	case "alias":
+		return alias
+	default:
+		return column
+	}
+}
+
+// columnJSONSchema returns the schema of the JSON of a column from its type,
+// nullability, length and enum values. Columns of types it does not know
+// accept any value.
+func columnJSONSchema(c drivers.Column) *jsonSchema {
+	schema := &jsonSchema{Description: c.Comment}
+
+	if drivers.IsEnumDBType(c.DBType) {
+		for _, v := range strmangle.ParseEnumVals(c.DBType) {
+			schema.Enum = append(schema.Enum, v)
+		}
+		if c.Nullable {
+			schema.Enum = append(schema.Enum, nil)
+		}
+		return schema
+	}
+
+	if elem, ok := jsonSchemaArrays[c.Type]; ok {
+		schema.Type = jsonSchemaNullable("array", c.Nullable)
+		schema.Items = &jsonSchema{Type: elem}
+		if c.Type == "types.BytesArray" {
+			schema.Items.ContentEncoding = "base64"
+		}
+		return schema
+	}
+
+	// encrypted columns are marshaled as their plaintexts, the length of the
+	// column limits their ciphertexts instead
+	typ, encrypted := encryptedPlainTypes[c.Type]
+	if !encrypted {
+		typ = c.Type
+	}
+	nullable := false
+	if base, ok := jsonSchemaNullBases[typ]; ok {
+		typ, nullable = base, true
+	}
+
+	jsonType, ok := jsonSchemaTypes[typ]
+	if !ok {
+		return schema
+	}
+	schema.Type = jsonSchemaNullable(jsonType, nullable)
+
+	switch typ {
+	case "time.Time":
+		schema.Format = "date-time"
+	case "[]byte":
+		schema.ContentEncoding = "base64"
+	case "string":
+		if !encrypted {
+			schema.MaxLength = columnMaxLength(c)
+		}
+	}
+	if r, ok := jsonSchemaRanges[typ]; ok {
+		schema.Minimum, schema.Maximum = json.Number(r[0]), json.Number(r[1])
+	}
+
+	return schema
+}
+
+// jsonSchemaNullable returns the JSON Schema type, or the type and null if it
+// is nullable
+func jsonSchemaNullable(typ string, nullable bool) interface{} {
+	if nullable {
+		return []string{typ, "null"}
+	}
+	return typ
+}
+
+// columnMaxLength returns the length of a column the driver read, or the one of
+// its full type, like 255 for varchar(255), or 0 when it has none
+func columnMaxLength(c drivers.Column) int {
+	if c.MaxLength > 0 {
+		return c.MaxLength
+	}
+
+	fullType := c.FullDBType
+	if len(fullType) == 0 {
+		fullType = c.DBType
+	}
+
+	match := rgxColumnLength.FindStringSubmatch(strings.TrimSpace(fullType))
+	if match == nil {
+		return 0
+	}
+
+	n, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// columnMaxBytes returns the most bytes the text of a column takes, its length
+// in the characters of its charset, or 0 when either is not known
+func columnMaxBytes(c drivers.Column) int {
+	return columnMaxLength(c) * drivers.CharsetMaxBytes(c.Charset)
+}
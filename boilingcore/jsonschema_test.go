@@ -0,0 +1,122 @@
+package boilingcore
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestColumnMaxLength(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Column drivers.Column
+		Want   int
+	}{
+		{drivers.Column{DBType: "character varying", FullDBType: "character varying(255)"}, 255},
+		{drivers.Column{DBType: "varchar", FullDBType: "VARCHAR(20)"}, 20},
+		{drivers.Column{DBType: "char(36)"}, 36},
+		{drivers.Column{DBType: "decimal", FullDBType: "decimal(10,2)"}, 0},
+		{drivers.Column{DBType: "text"}, 0},
+		{drivers.Column{DBType: "text", MaxLength: 65535}, 65535},
+		{drivers.Column{DBType: "varchar", FullDBType: "varchar(20)", MaxLength: 10}, 10},
+	}
+
+	for i, test := range tests {
+		if got := columnMaxLength(test.Column); got != test.Want {
+			t.Errorf("%d) want %d, got: %d", i, test.Want, got)
+		}
+	}
+}
+
+func TestColumnMaxBytes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Column drivers.Column
+		Want   int
+	}{
+		{drivers.Column{DBType: "varchar", FullDBType: "varchar(20)", Charset: "utf8mb4"}, 80},
+		{drivers.Column{DBType: "varchar", FullDBType: "varchar(20)", Charset: "latin1"}, 20},
+		{drivers.Column{DBType: "varchar", FullDBType: "varchar(20)"}, 0},
+		{drivers.Column{DBType: "text", Charset: "utf8mb4"}, 0},
+	}
+
+	for i, test := range tests {
+		if got := columnMaxBytes(test.Column); got != test.Want {
+			t.Errorf("%d) want %d, got: %d", i, test.Want, got)
+		}
+	}
+}
+
+func TestColumnJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Column drivers.Column
+		Want   string
+	}{
+		{drivers.Column{Type: "int"}, `{"type":"integer"}`},
+		{drivers.Column{Type: "null.Uint8", Nullable: true}, `{"type":["integer","null"],"minimum":0,"maximum":255}`},
+		{drivers.Column{Type: "string", FullDBType: "varchar(20)", Comment: "the name"}, `{"description":"the name","type":"string","maxLength":20}`},
+		{drivers.Column{Type: "null.Time", Nullable: true}, `{"type":["string","null"],"format":"date-time"}`},
+		{drivers.Column{Type: "[]byte"}, `{"type":"string","contentEncoding":"base64"}`},
+		{drivers.Column{Type: "types.StringArray", Nullable: true}, `{"type":["array","null"],"items":{"type":"string"}}`},
+		{drivers.Column{Type: "string", DBType: "enum.rank('a','b')", Nullable: true}, `{"enum":["a","b",null]}`},
+		{drivers.Column{Type: "types.NullDecimal", Nullable: true}, `{"type":["string","null"]}`},
+		{drivers.Column{Type: "types.JSON"}, `{}`},
+	}
+
+	for i, test := range tests {
+		b, err := json.Marshal(columnJSONSchema(test.Column))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(b); got != test.Want {
+			t.Errorf("%d) want %s, got: %s", i, test.Want, got)
+		}
+	}
+}
+
+func TestInitJSONSchemas(t *testing.T) {
+	t.Parallel()
+
+	tables := []drivers.Table{
+		{
+			Name: "pilots",
+			Columns: []drivers.Column{
+				{Name: "id", Type: "int", Default: "nextval('pilots_id_seq')"},
+				{Name: "first_name", Type: "string"},
+				{Name: "nick", Type: "null.String", Nullable: true},
+				{Name: "password", Type: "string"},
+			},
+			PKey: &drivers.PrimaryKey{Name: "pilots_pkey", Columns: []string{"id"}},
+		},
+	}
+
+	s := &State{
+		Config: &Config{WithJSONSchema: true, StructTagCasing: "camel", TagIgnore: []string{"pilots.password"}},
+		Tables: tables,
+	}
+	FillAliases(&s.Config.Aliases, tables)
+	if err := s.initJSONSchemas(); err != nil {
+		t.Fatal(err)
+	}
+
+	schema := s.JSONSchemas["pilots"]
+	if schema.Title != "Pilot" {
+		t.Errorf("want Pilot, got: %s", schema.Title)
+	}
+
+	var names []string
+	for _, p := range schema.Properties {
+		names = append(names, p.Name)
+	}
+	if len(names) != 3 || names[0] != "id" || names[1] != "firstName" || names[2] != "nick" {
+		t.Errorf("want the json tag names without ignored columns, got: %v", names)
+	}
+	if len(schema.Required) != 1 || schema.Required[0] != "firstName" {
+		t.Errorf("want firstName required, got: %v", schema.Required)
+	}
+}
@@ -0,0 +1,47 @@
+package boilingcore
+
+import (
+	"fmt"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+// mapNullFields are the fields holding the values of the nullable types
+// diffNullValues leaves out, whose values are not comparable
+var mapNullFields = map[string]string{
+	"pgeo.NullPath":    "Path",
+	"pgeo.NullPolygon": "Polygon",
+}
+
+// toMapColumn returns the Go statements setting the key of the map m to the
+// value v of the column, nil when it is null and the value of the valid
+// nullable types otherwise
+func toMapColumn(m, key, v string, c drivers.Column) string {
+	field, ok := mapNullFields[c.Type]
+	if nullValue, isNull := diffNullValues[c.Type]; isNull {
+		field, ok = nullValue[0], true
+	}
+
+	var null, value string
+	switch {
+	case ok:
+		null, value = "!"+v+".Valid", v+"."+field
+	case c.Type == "types.NullDecimal":
+		null, value = v+".Big == nil", v
+	case c.Nullable && isNilable(c.Type):
+		null, value = v+" == nil", v
+	default:
+		return fmt.Sprintf("%s[%s] = %s", m, key, v)
+	}
+
+	return fmt.Sprintf("if %[4]s {\n%[1]s[%[2]s] = nil\n} else {\n%[1]s[%[2]s] = %[3]s\n}", m, key, value, null)
+}
+
+// isNilable tells if the values of the type are slices or maps, which are nil
+// for null
+func isNilable(typ string) bool {
+	if v, ok := copySlices[typ]; ok {
+		return v[0] == ""
+	}
+	return typ == "types.BytesArray" || typ == "types.DecimalArray" || typ == "types.HStore"
+}
@@ -0,0 +1,30 @@
+package boilingcore
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestToMapColumn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		column drivers.Column
+		want   string
+	}{
+		{drivers.Column{Type: "int"}, `m["id"] = o.ID`},
+		{drivers.Column{Type: "[]byte"}, `m["id"] = o.ID`},
+		{drivers.Column{Type: "null.String", Nullable: true}, "if !o.ID.Valid {\nm[\"id\"] = nil\n} else {\nm[\"id\"] = o.ID.String\n}"},
+		{drivers.Column{Type: "pgeo.NullPath", Nullable: true}, "if !o.ID.Valid {\nm[\"id\"] = nil\n} else {\nm[\"id\"] = o.ID.Path\n}"},
+		{drivers.Column{Type: "types.NullDecimal", Nullable: true}, "if o.ID.Big == nil {\nm[\"id\"] = nil\n} else {\nm[\"id\"] = o.ID\n}"},
+		{drivers.Column{Type: "types.StringArray", Nullable: true}, "if o.ID == nil {\nm[\"id\"] = nil\n} else {\nm[\"id\"] = o.ID\n}"},
+		{drivers.Column{Type: "uuid.NullUUID", Nullable: true}, `m["id"] = o.ID`},
+	}
+
+	for _, test := range tests {
+		if got := toMapColumn("m", `"id"`, "o.ID", test.column); got != test.want {
+			t.Errorf("%s: want %q, got: %q", test.column.Type, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package boilingcore
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/strmangle"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// maskingTemplate is the singleton with the Masked methods, it names its entry
+// in the singleton imports
+const maskingTemplate = "boil_masking"
+
+// The rules of columns masked without one
+const (
+	maskFull = "full"
+	maskHide = "hide"
+)
+
+// maskRuleRgx matches the masking rules: full, email and hide, and first and
+// last followed by the number of runes they show, like last4
+var maskRuleRgx = regexp.MustCompile(`^(full|email|hide|(first|last)([0-9]+))$`)
+
+// MaskTable is a table with masked columns
+type MaskTable struct {
+	Name    string
+	Columns []MaskColumn
+	// Zero tells if a column is hidden with the value of a zero model
+	Zero bool
+}
+
+// MaskColumn is a masked column
+type MaskColumn struct {
+	Name string
+	Rule string
+	// Mask masks the column of the copy m of a model
+	Mask string
+}
+
+// initMasking builds the masked columns of the tables from the masking config
+// and sets the imports of the Masked methods
+func (s *State) initMasking() error {
+	if len(s.Config.Masking.Columns) == 0 {
+		return nil
+	}
+
+	// rules has the rules of the masked columns by table, the tables of the
+	// columns without a table are the empty string
+	rules := make(map[string]map[string]string)
+	for _, v := range s.Config.Masking.Columns {
+		name, rule := v, ""
+		if i := strings.IndexByte(v, ':'); i >= 0 {
+			name, rule = v[:i], v[i+1:]
+			if !maskRuleRgx.MatchString(rule) {
+				return errors.Errorf("masked column %s has an unknown rule %q, want full, email, hide, firstN or lastN", name, rule)
+			}
+		}
+
+		var table, column string
+		if i := strings.LastIndexByte(name, '.'); i >= 0 {
+			table, column = name[:i], name[i+1:]
+		} else {
+			column = name
+		}
+		if rules[table] == nil {
+			rules[table] = make(map[string]string)
+		}
+		rules[table][column] = rule
+	}
+
+	found := make(map[string]bool)
+	s.MaskTables = nil
+	for _, t := range s.Tables {
+		// Columns of join tables are only masked when they are named with
+		// their table, which is an error below
+		if _, ok := rules[t.Name]; !ok && t.IsJoinTable {
+			continue
+		}
+
+		alias := s.Config.Aliases.Table(t.Name)
+		table := MaskTable{Name: t.Name}
+		for _, c := range t.Columns {
+			rule, ok := rules[t.Name][c.Name]
+			if ok {
+				found[t.Name+"."+c.Name] = true
+			} else if rule, ok = rules[""][c.Name]; ok {
+				found[c.Name] = true
+			} else {
+				continue
+			}
+
+			if t.IsJoinTable {
+				return errors.Errorf("masked column %s.%s must be in a table with a model, not a join table", t.Name, c.Name)
+			}
+			if scrubIsKey(t, c.Name) {
+				return errors.Errorf("masked column %s.%s cannot be part of the primary key or a foreign key", t.Name, c.Name)
+			}
+
+			col, err := maskColumn(c, alias.Column(c.Name), rule)
+			if err != nil {
+				return errors.Wrapf(err, "unable to mask column %s.%s", t.Name, c.Name)
+			}
+			table.Columns = append(table.Columns, col)
+			table.Zero = table.Zero || col.Rule == maskHide
+		}
+
+		if len(table.Columns) != 0 {
+			s.MaskTables = append(s.MaskTables, table)
+		}
+	}
+
+	for table, columns := range rules {
+		for column := range columns {
+			name := column
+			if len(table) != 0 {
+				name = table + "." + column
+			}
+			if !found[name] {
+				return errors.Errorf("masked column %s was not found", name)
+			}
+		}
+	}
+
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[maskingTemplate] = importers.Set{
+		ThirdParty: importers.List{`"github.com/volatiletech/sqlboiler/v4/mask"`},
+	}
+
+	return nil
+}
+
+// maskColumn returns the masking of the column with the rule, string columns
+// are masked fully and others hidden when it is empty
+func maskColumn(c drivers.Column, goField, rule string) (MaskColumn, error) {
+	m := "m." + goField
+	col := MaskColumn{Name: c.Name, Rule: rule}
+
+	typ, ok := encryptedPlainTypes[c.Type]
+	if !ok {
+		typ = c.Type
+	}
+	isString := typ == "string" || typ == "null.String"
+
+	if len(col.Rule) == 0 {
+		col.Rule = maskHide
+		if isString {
+			col.Rule = maskFull
+		}
+	}
+	if col.Rule == maskHide {
+		col.Mask = fmt.Sprintf("%s = zero.%s", m, goField)
+		return col, nil
+	}
+	if !isString {
+		return MaskColumn{}, errors.Errorf("columns of type %s can only be hidden, the rule %s is for strings", c.Type, col.Rule)
+	}
+
+	if typ == "null.String" {
+		m += ".String"
+	}
+	value := m
+	if c.Type == "EncryptedString" {
+		value = "string(" + m + ")"
+	}
+
+	var call string
+	if match := maskRuleRgx.FindStringSubmatch(col.Rule); len(match[2]) != 0 {
+		n, err := strconv.Atoi(match[3])
+		if err != nil {
+			return MaskColumn{}, errors.Wrapf(err, "invalid number of runes in the rule %s", col.Rule)
+		}
+		call = fmt.Sprintf("mask.%s(%s, %d)", strmangle.TitleCase(match[2]), value, n)
+	} else {
+		call = fmt.Sprintf("mask.%s(%s)", strmangle.TitleCase(col.Rule), value)
+	}
+
+	if c.Type == "EncryptedString" {
+		call = "EncryptedString(" + call + ")"
+	}
+	col.Mask = m + " = " + call
+
+	return col, nil
+}
@@ -0,0 +1,68 @@
+package boilingcore
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInitMasking(t *testing.T) {
+	t.Parallel()
+
+	s := testState(Config{
+		PkgName: "models",
+		Masking: Masking{Columns: []string{"email:email", "users.card_number:last4", "users.ssn:first3", "users.salary", "users.notes"}},
+	})
+	if err := s.initMasking(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []MaskTable{
+		{Name: "users", Zero: true, Columns: []MaskColumn{
+			{Name: "email", Rule: "email", Mask: `m.Email = mask.Email(m.Email)`},
+			{Name: "card_number", Rule: "last4", Mask: `m.CardNumber.String = mask.Last(m.CardNumber.String, 4)`},
+			{Name: "ssn", Rule: "first3", Mask: `m.SSN = EncryptedString(mask.First(string(m.SSN), 3))`},
+			{Name: "salary", Rule: "hide", Mask: `m.Salary = zero.Salary`},
+			{Name: "notes", Rule: "full", Mask: `m.Notes = mask.Full(m.Notes)`},
+		}},
+		{Name: "teams", Columns: []MaskColumn{
+			{Name: "email", Rule: "email", Mask: `m.Email = mask.Email(m.Email)`},
+		}},
+		{Name: "user_emails", Columns: []MaskColumn{
+			{Name: "email", Rule: "email", Mask: `m.Email = mask.Email(m.Email)`},
+		}},
+	}
+	if !reflect.DeepEqual(s.MaskTables, want) {
+		t.Errorf("want: %#v\ngot: %#v", want, s.MaskTables)
+	}
+	if _, ok := s.Config.Imports.Singleton[maskingTemplate]; !ok {
+		t.Error("masking imports are not set")
+	}
+}
+
+func TestInitMaskingErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		columns []string
+		err     string
+	}{
+		{[]string{"users.email:last"}, "unknown rule"},
+		{[]string{"users.email:partial"}, "unknown rule"},
+		{[]string{"users.password"}, "users.password was not found"},
+		{[]string{"passcode"}, "passcode was not found"},
+		{[]string{"users.id"}, "primary key or a foreign key"},
+		{[]string{"users.team_id:hide"}, "primary key or a foreign key"},
+		{[]string{"users.salary:last4"}, "can only be hidden"},
+		{[]string{"user_teams.user_id"}, "not a join table"},
+	}
+
+	for _, test := range tests {
+		s := testState(Config{PkgName: "models", Masking: Masking{Columns: test.columns}})
+
+		err := s.initMasking()
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%v: want error containing %q, got: %v", test.columns, test.err, err)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package boilingcore
+
+import "github.com/friendsofgo/errors"
+
+// initMeta checks the tables of the metadata of the config exist
+func (s *State) initMeta() error {
+	for name := range s.Config.TableMeta {
+		found := false
+		for _, t := range s.Tables {
+			if t.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.Errorf("table %s of the metadata was not found", name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package boilingcore
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestInitMeta(t *testing.T) {
+	t.Parallel()
+
+	s := &State{
+		Config: &Config{
+			TableMeta: map[string]map[string]interface{}{"pilots": {"owner": "flight-ops"}},
+		},
+		Tables: []drivers.Table{{Name: "pilots"}},
+	}
+	if err := s.initMeta(); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Config.TableMeta["jets"] = map[string]interface{}{"owner": "hangar"}
+	if err := s.initMeta(); err == nil || !strings.Contains(err.Error(), "table jets") {
+		t.Errorf("want the unknown table reported, got: %v", err)
+	}
+}
+
+func TestTemplateDataMeta(t *testing.T) {
+	t.Parallel()
+
+	data := templateData{
+		Table:     drivers.Table{Name: "pilots"},
+		Meta:      map[string]interface{}{"service_owner": "payments"},
+		TableMeta: map[string]map[string]interface{}{"pilots": {"owner": "flight-ops"}},
+	}
+
+	tpl := template.Must(template.New("").Parse(`{{.Meta.service_owner}} {{(index .TableMeta .Table.Name).owner}}`))
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "payments flight-ops" {
+		t.Errorf("want the metadata in the templates, got: %s", got)
+	}
+}
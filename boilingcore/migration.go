@@ -0,0 +1,359 @@
+package boilingcore
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/strmangle"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+var rgxMigrationName = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// MigrationFormats are the migration tools Migration.Write lays out the files
+// of a migration for
+var MigrationFormats = []string{"golang-migrate", "goose"}
+
+// migrationPseudoDefaults are the defaults drivers give the columns without a
+// default value, like identity and generated columns, they are left out of
+// the definitions of columns
+var migrationPseudoDefaults = map[string]bool{
+	"NULL":           true,
+	"auto_increment": true,
+	"AUTO_GENERATED": true,
+	"IDENTITY":       true,
+	"GENERATED":      true,
+}
+
+// migrationVersionFormat is the timestamp both tools order migrations by
+const migrationVersionFormat = "20060102150405"
+
+// Migration has the statements migrating a schema to another one and back,
+// they are stubs to review since renames look like drops and additions and
+// changed columns are only described
+type Migration struct {
+	Up   []string
+	Down []string
+}
+
+// migrationStep is a change of the schema with the statements applying and
+// reverting it
+type migrationStep struct {
+	up, down string
+}
+
+// migrationFKey identifies a foreign key, without the nullability and
+// uniqueness of its columns which are part of their definitions
+type migrationFKey struct {
+	Name, Column, ForeignTable, ForeignColumn string
+}
+
+func newMigrationFKey(fk drivers.ForeignKey) migrationFKey {
+	return migrationFKey{Name: fk.Name, Column: fk.Column, ForeignTable: fk.ForeignTable, ForeignColumn: fk.ForeignColumn}
+}
+
+// migrationDialect quotes the identifiers of statements
+type migrationDialect struct {
+	drivers.Dialect
+	Schema string
+}
+
+// DBInfo returns the schema of the state as a driver returns it, the format
+// of the snapshots of DiffSchemas and of the schema_file of the mock driver
+func (s *State) DBInfo() *drivers.DBInfo {
+	return &drivers.DBInfo{
+		Schema:    s.Schema,
+		Tables:    s.Tables,
+		Functions: s.functions,
+		Dialect:   s.Dialect,
+	}
+}
+
+// ReadSchemaSnapshot reads a snapshot of a schema written as JSON
+func ReadSchemaSnapshot(path string) (*drivers.DBInfo, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read schema snapshot")
+	}
+
+	var dbinfo drivers.DBInfo
+	if err := json.Unmarshal(b, &dbinfo); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse schema snapshot %s", path)
+	}
+
+	return &dbinfo, nil
+}
+
+// DiffSchemas returns the migration from a schema to another, quoted with the
+// dialect of the new schema. Views are not migrated.
+//
+// Foreign keys are dropped first and added last so the tables they refer to
+// exist, and the down statements revert the up statements in reverse order.
+func DiffSchemas(from, to *drivers.DBInfo) Migration {
+	d := migrationDialect{Dialect: to.Dialect, Schema: to.Schema}
+
+	fromTables := migrationTables(from.Tables)
+	toTables := migrationTables(to.Tables)
+
+	var dropFKeys, createTables, addColumns, alterColumns, addFKeys, dropColumns, dropTables []migrationStep
+
+	for _, old := range from.Tables {
+		if _, ok := fromTables[old.Name]; !ok {
+			continue
+		}
+		if _, ok := toTables[old.Name]; ok {
+			continue
+		}
+
+		for _, fk := range old.FKeys {
+			dropFKeys = append(dropFKeys, migrationStep{up: d.dropFKey(fk), down: d.addFKey(fk)})
+		}
+		dropTables = append(dropTables, migrationStep{up: d.dropTable(old), down: d.createTable(old)})
+	}
+
+	for _, t := range to.Tables {
+		if _, ok := toTables[t.Name]; !ok {
+			continue
+		}
+
+		old, ok := fromTables[t.Name]
+		if !ok {
+			createTables = append(createTables, migrationStep{up: d.createTable(t), down: d.dropTable(t)})
+			for _, fk := range t.FKeys {
+				addFKeys = append(addFKeys, migrationStep{up: d.addFKey(fk), down: d.dropFKey(fk)})
+			}
+			continue
+		}
+
+		oldColumns := migrationColumns(old)
+		columns := migrationColumns(t)
+		for _, c := range old.Columns {
+			if _, ok := columns[c.Name]; !ok {
+				dropColumns = append(dropColumns, migrationStep{up: d.dropColumn(t, c), down: d.addColumn(t, c)})
+			}
+		}
+		for _, c := range t.Columns {
+			oldCol, ok := oldColumns[c.Name]
+			switch {
+			case !ok:
+				addColumns = append(addColumns, migrationStep{up: d.addColumn(t, c), down: d.dropColumn(t, c)})
+			case d.columnDefinition(oldCol) != d.columnDefinition(c):
+				alterColumns = append(alterColumns, migrationStep{up: d.alterColumn(t, oldCol, c), down: d.alterColumn(t, c, oldCol)})
+			}
+		}
+
+		if oldPKey, pkey := migrationPKey(old), migrationPKey(t); oldPKey != pkey {
+			alterColumns = append(alterColumns, migrationStep{
+				up:   fmt.Sprintf("-- TODO: change the primary key of %s from (%s) to (%s)", t.Name, oldPKey, pkey),
+				down: fmt.Sprintf("-- TODO: change the primary key of %s from (%s) to (%s)", t.Name, pkey, oldPKey),
+			})
+		}
+
+		oldFKeys := make(map[migrationFKey]bool)
+		for _, fk := range old.FKeys {
+			oldFKeys[newMigrationFKey(fk)] = true
+		}
+		fkeys := make(map[migrationFKey]bool)
+		for _, fk := range t.FKeys {
+			fkeys[newMigrationFKey(fk)] = true
+		}
+		for _, fk := range old.FKeys {
+			if !fkeys[newMigrationFKey(fk)] {
+				dropFKeys = append(dropFKeys, migrationStep{up: d.dropFKey(fk), down: d.addFKey(fk)})
+			}
+		}
+		for _, fk := range t.FKeys {
+			if !oldFKeys[newMigrationFKey(fk)] {
+				addFKeys = append(addFKeys, migrationStep{up: d.addFKey(fk), down: d.dropFKey(fk)})
+			}
+		}
+	}
+
+	var steps []migrationStep
+	for _, s := range [][]migrationStep{dropFKeys, createTables, addColumns, alterColumns, addFKeys, dropColumns, dropTables} {
+		steps = append(steps, s...)
+	}
+
+	var m Migration
+	for i := range steps {
+		m.Up = append(m.Up, steps[i].up)
+		m.Down = append(m.Down, steps[len(steps)-1-i].down)
+	}
+
+	return m
+}
+
+// Write writes the migration to the directory in the layout of one
+// of the MigrationFormats, named after the version and the name, and returns
+// the paths of the files. Existing files are not overwritten.
+func (m Migration) Write(dir, name, format string, version time.Time) ([]string, error) {
+	base := version.UTC().Format(migrationVersionFormat) + "_" + strings.Trim(rgxMigrationName.ReplaceAllString(name, "_"), "_")
+	header := "-- Generated by sqlboiler from a schema diff, review it before applying it\n"
+
+	files := make(map[string]string)
+	var paths []string
+	switch format {
+	case "golang-migrate":
+		up, down := filepath.Join(dir, base+".up.sql"), filepath.Join(dir, base+".down.sql")
+		files[up] = header + migrationStatements(m.Up)
+		files[down] = header + migrationStatements(m.Down)
+		paths = []string{up, down}
+	case "goose":
+		path := filepath.Join(dir, base+".sql")
+		files[path] = header + "\n-- +goose Up\n" + migrationStatements(m.Up) + "\n-- +goose Down\n" + migrationStatements(m.Down)
+		paths = []string{path}
+	default:
+		return nil, errors.Errorf("unknown migration format %q, want one of: %s", format, strings.Join(MigrationFormats, ", "))
+	}
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, errors.Wrap(err, "unable to create the migrations folder")
+	}
+
+	for _, path := range paths {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0664)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to create migration")
+		}
+		if _, err := f.WriteString(files[path]); err != nil {
+			f.Close()
+			return nil, errors.Wrapf(err, "unable to write migration %s", path)
+		}
+		if err := f.Close(); err != nil {
+			return nil, errors.Wrapf(err, "unable to write migration %s", path)
+		}
+	}
+
+	return paths, nil
+}
+
+// migrationStatements writes the statements a line each
+func migrationStatements(statements []string) string {
+	if len(statements) == 0 {
+		return ""
+	}
+	return strings.Join(statements, "\n") + "\n"
+}
+
+// migrationTables returns the tables that are not views by name
+func migrationTables(tables []drivers.Table) map[string]drivers.Table {
+	byName := make(map[string]drivers.Table)
+	for _, t := range tables {
+		if !t.IsView {
+			byName[t.Name] = t
+		}
+	}
+	return byName
+}
+
+// migrationColumns returns the columns of the table by name
+func migrationColumns(t drivers.Table) map[string]drivers.Column {
+	byName := make(map[string]drivers.Column, len(t.Columns))
+	for _, c := range t.Columns {
+		byName[c.Name] = c
+	}
+	return byName
+}
+
+// migrationPKey returns the columns of the primary key of the table
+func migrationPKey(t drivers.Table) string {
+	if t.PKey == nil {
+		return ""
+	}
+	return strings.Join(t.PKey.Columns, ", ")
+}
+
+func (d migrationDialect) quote(name string) string {
+	if d.LQ == 0 {
+		return name
+	}
+	return string(d.LQ) + name + string(d.RQ)
+}
+
+func (d migrationDialect) table(t string) string {
+	if d.LQ == 0 {
+		return t
+	}
+	return strmangle.SchemaTable(string(d.LQ), string(d.RQ), d.UseSchema && len(d.Schema) != 0, d.Schema, t)
+}
+
+// columnDefinition returns the definition of a column like in CREATE TABLE
+func (d migrationDialect) columnDefinition(c drivers.Column) string {
+	typ := c.FullDBType
+	if len(typ) == 0 {
+		typ = c.DBType
+	}
+
+	def := d.quote(c.Name) + " " + typ
+	if !c.Nullable {
+		def += " NOT NULL"
+	}
+	if len(c.Default) != 0 && !migrationPseudoDefaults[c.Default] {
+		def += " DEFAULT " + c.Default
+	}
+	if c.Unique {
+		def += " UNIQUE"
+	}
+	return def
+}
+
+func (d migrationDialect) createTable(t drivers.Table) string {
+	pkey := make(map[string]bool)
+	if t.PKey != nil {
+		for _, c := range t.PKey.Columns {
+			pkey[c] = true
+		}
+	}
+
+	lines := make([]string, 0, len(t.Columns)+1)
+	for _, c := range t.Columns {
+		// Single column primary keys are unique already
+		if pkey[c.Name] && len(pkey) == 1 {
+			c.Unique = false
+		}
+		lines = append(lines, "  "+d.columnDefinition(c))
+	}
+	if t.PKey != nil {
+		cols := make([]string, len(t.PKey.Columns))
+		for i, c := range t.PKey.Columns {
+			cols[i] = d.quote(c)
+		}
+		lines = append(lines, "  PRIMARY KEY ("+strings.Join(cols, ", ")+")")
+	}
+
+	return fmt.Sprintf("CREATE TABLE %s (\n%s\n);", d.table(t.Name), strings.Join(lines, ",\n"))
+}
+
+func (d migrationDialect) dropTable(t drivers.Table) string {
+	return fmt.Sprintf("DROP TABLE %s;", d.table(t.Name))
+}
+
+func (d migrationDialect) addColumn(t drivers.Table, c drivers.Column) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", d.table(t.Name), d.columnDefinition(c))
+}
+
+func (d migrationDialect) dropColumn(t drivers.Table, c drivers.Column) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", d.table(t.Name), d.quote(c.Name))
+}
+
+// alterColumn describes the change of a column, the statements altering
+// columns differ too much between databases to write them
+func (d migrationDialect) alterColumn(t drivers.Table, from, to drivers.Column) string {
+	return fmt.Sprintf("-- TODO: alter column %s.%s from %s to %s", t.Name, from.Name, d.columnDefinition(from), d.columnDefinition(to))
+}
+
+func (d migrationDialect) addFKey(fk drivers.ForeignKey) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s);",
+		d.table(fk.Table), d.quote(fk.Name), d.quote(fk.Column), d.table(fk.ForeignTable), d.quote(fk.ForeignColumn))
+}
+
+func (d migrationDialect) dropFKey(fk drivers.ForeignKey) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", d.table(fk.Table), d.quote(fk.Name))
+}
@@ -0,0 +1,141 @@
+package boilingcore
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestDiffSchemas(t *testing.T) {
+	t.Parallel()
+
+	dialect := drivers.Dialect{LQ: '"', RQ: '"', UseSchema: true}
+	from := &drivers.DBInfo{
+		Schema:  "public",
+		Dialect: dialect,
+		Tables: []drivers.Table{
+			{
+				Name: "pilots",
+				Columns: []drivers.Column{
+					{Name: "id", DBType: "integer", Default: "IDENTITY"},
+					{Name: "name", DBType: "text"},
+					{Name: "nick", DBType: "text", Nullable: true},
+				},
+				PKey: &drivers.PrimaryKey{Columns: []string{"id"}},
+			},
+			{
+				Name:    "hangars",
+				Columns: []drivers.Column{{Name: "id", DBType: "integer"}},
+				PKey:    &drivers.PrimaryKey{Columns: []string{"id"}},
+			},
+			{
+				Name:    "pilot_names",
+				IsView:  true,
+				Columns: []drivers.Column{{Name: "name", DBType: "text"}},
+			},
+		},
+	}
+	to := &drivers.DBInfo{
+		Schema:  "public",
+		Dialect: dialect,
+		Tables: []drivers.Table{
+			{
+				Name: "pilots",
+				Columns: []drivers.Column{
+					{Name: "id", DBType: "integer", Default: "IDENTITY"},
+					{Name: "name", DBType: "character varying", FullDBType: "character varying(255)"},
+					{Name: "rank", DBType: "text", Default: "'captain'"},
+				},
+				PKey: &drivers.PrimaryKey{Columns: []string{"id"}},
+			},
+			{
+				Name: "jets",
+				Columns: []drivers.Column{
+					{Name: "id", DBType: "integer", Unique: true},
+					{Name: "pilot_id", DBType: "integer", Nullable: true, Unique: true},
+				},
+				PKey: &drivers.PrimaryKey{Columns: []string{"id"}},
+				FKeys: []drivers.ForeignKey{
+					{Name: "jets_pilot_fkey", Table: "jets", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"},
+				},
+			},
+		},
+	}
+
+	m := DiffSchemas(from, to)
+	wantUp := []string{
+		"CREATE TABLE \"public\".\"jets\" (\n  \"id\" integer NOT NULL,\n  \"pilot_id\" integer UNIQUE,\n  PRIMARY KEY (\"id\")\n);",
+		`ALTER TABLE "public"."pilots" ADD COLUMN "rank" text NOT NULL DEFAULT 'captain';`,
+		`-- TODO: alter column pilots.name from "name" text NOT NULL to "name" character varying(255) NOT NULL`,
+		`ALTER TABLE "public"."jets" ADD CONSTRAINT "jets_pilot_fkey" FOREIGN KEY ("pilot_id") REFERENCES "public"."pilots" ("id");`,
+		`ALTER TABLE "public"."pilots" DROP COLUMN "nick";`,
+		`DROP TABLE "public"."hangars";`,
+	}
+	wantDown := []string{
+		"CREATE TABLE \"public\".\"hangars\" (\n  \"id\" integer NOT NULL,\n  PRIMARY KEY (\"id\")\n);",
+		`ALTER TABLE "public"."pilots" ADD COLUMN "nick" text;`,
+		`ALTER TABLE "public"."jets" DROP CONSTRAINT "jets_pilot_fkey";`,
+		`-- TODO: alter column pilots.name from "name" character varying(255) NOT NULL to "name" text NOT NULL`,
+		`ALTER TABLE "public"."pilots" DROP COLUMN "rank";`,
+		`DROP TABLE "public"."jets";`,
+	}
+	if !reflect.DeepEqual(m.Up, wantUp) {
+		t.Errorf("up:\nwant %q\ngot  %q", wantUp, m.Up)
+	}
+	if !reflect.DeepEqual(m.Down, wantDown) {
+		t.Errorf("down:\nwant %q\ngot  %q", wantDown, m.Down)
+	}
+
+	if m := DiffSchemas(to, to); len(m.Up) != 0 || len(m.Down) != 0 {
+		t.Errorf("want no changes, got: %#v", m)
+	}
+}
+
+func TestMigrationWrite(t *testing.T) {
+	t.Parallel()
+
+	m := Migration{Up: []string{"ALTER TABLE a ADD COLUMN b int;"}, Down: []string{"ALTER TABLE a DROP COLUMN b;"}}
+	version := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	dir := t.TempDir()
+
+	paths, err := m.Write(dir, "add b!", "golang-migrate", version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "20200102030405_add_b.up.sql"), filepath.Join(dir, "20200102030405_add_b.down.sql")}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("want %v, got: %v", want, paths)
+	}
+	b, err := os.ReadFile(want[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(b), "\nALTER TABLE a DROP COLUMN b;\n") {
+		t.Errorf("down migration was wrong:\n%s", b)
+	}
+
+	if _, err := m.Write(dir, "add b", "golang-migrate", version); err == nil {
+		t.Error("want an error for existing migrations")
+	}
+
+	paths, err = m.Write(dir, "add b", "goose", version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err = os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(b), "\n-- +goose Up\nALTER TABLE a ADD COLUMN b int;\n\n-- +goose Down\nALTER TABLE a DROP COLUMN b;\n") {
+		t.Errorf("goose migration was wrong:\n%s", b)
+	}
+
+	if _, err := m.Write(dir, "add b", "flyway", version); err == nil {
+		t.Error("want an error for an unknown format")
+	}
+}
@@ -0,0 +1,75 @@
+package boilingcore
+
+import (
+	"github.com/friendsofgo/errors"
+
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// outboxTemplate is the singleton with the outbox event and its relay, it
+// names its entry in the singleton imports
+const outboxTemplate = "boil_outbox"
+
+// outboxEvent is the name of the struct of the events of the outbox
+const outboxEvent = "OutboxEvent"
+
+// initOutbox checks that the changes of the tables can be written to the
+// outbox and sets the imports of the outbox helpers
+func (s *State) initOutbox() error {
+	if len(s.Config.Outbox.Tables) == 0 {
+		return nil
+	}
+
+	if s.Config.NoHooks {
+		return errors.New("the outbox is written by hooks and cannot be used with no-hooks")
+	}
+	if len(s.Config.Outbox.Table) == 0 {
+		s.Config.Outbox.Table = "outbox"
+	}
+
+	for _, t := range s.Tables {
+		if !t.IsJoinTable && s.Config.Aliases.Table(t.Name).UpSingular == outboxEvent {
+			return errors.Errorf("the model of table %s is named like the outbox events %s, alias it or leave it out", t.Name, outboxEvent)
+		}
+	}
+
+	for _, name := range s.Config.Outbox.Tables {
+		found := false
+		for _, t := range s.Tables {
+			if t.Name != name {
+				continue
+			}
+			if t.IsView || t.IsJoinTable {
+				return errors.Errorf("outbox table %s must be a table with a model, not a view or join table", name)
+			}
+			found = true
+			break
+		}
+		if !found {
+			return errors.Errorf("outbox table %s was not found", name)
+		}
+		if name == s.Config.Outbox.Table {
+			return errors.Errorf("the changes of the outbox table %s cannot be written to it", name)
+		}
+	}
+
+	imps := importers.Set{
+		Standard: importers.List{`"encoding/json"`, `"time"`},
+		ThirdParty: importers.List{
+			`"github.com/friendsofgo/errors"`,
+			`"github.com/volatiletech/null/v8"`,
+			`"github.com/volatiletech/sqlboiler/v4/boil"`,
+			`"github.com/volatiletech/sqlboiler/v4/queries/qm"`,
+			`"github.com/volatiletech/sqlboiler/v4/types"`,
+		},
+	}
+	if !s.Config.NoContext {
+		imps.Standard = append(imps.Standard, `"context"`)
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[outboxTemplate] = imps
+
+	return nil
+}
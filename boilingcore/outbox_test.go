@@ -0,0 +1,69 @@
+package boilingcore
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestInitOutbox(t *testing.T) {
+	t.Parallel()
+
+	tables := []drivers.Table{
+		{Name: "pilots"},
+		{Name: "pilot_languages", IsJoinTable: true},
+		{Name: "pilot_view", IsView: true},
+		{Name: "outbox"},
+	}
+	aliases := Aliases{Tables: map[string]TableAlias{
+		"pilots":          {UpSingular: "Pilot"},
+		"pilot_languages": {UpSingular: "PilotLanguage"},
+		"pilot_view":      {UpSingular: "PilotView"},
+		"outbox":          {UpSingular: "Outbox"},
+	}}
+	clash := Aliases{Tables: map[string]TableAlias{
+		"pilots":          {UpSingular: "Pilot"},
+		"pilot_languages": {UpSingular: "PilotLanguage"},
+		"pilot_view":      {UpSingular: "PilotView"},
+		"outbox":          {UpSingular: "OutboxEvent"},
+	}}
+
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{name: "disabled", config: Config{Aliases: aliases}},
+		{name: "table", config: Config{Aliases: aliases, Outbox: Outbox{Tables: []string{"pilots"}}}},
+		{name: "nohooks", config: Config{Aliases: aliases, NoHooks: true, Outbox: Outbox{Tables: []string{"pilots"}}}, wantErr: true},
+		{name: "missing", config: Config{Aliases: aliases, Outbox: Outbox{Tables: []string{"jets"}}}, wantErr: true},
+		{name: "join table", config: Config{Aliases: aliases, Outbox: Outbox{Tables: []string{"pilot_languages"}}}, wantErr: true},
+		{name: "view", config: Config{Aliases: aliases, Outbox: Outbox{Tables: []string{"pilot_view"}}}, wantErr: true},
+		{name: "outbox table", config: Config{Aliases: aliases, Outbox: Outbox{Tables: []string{"outbox"}}}, wantErr: true},
+		{name: "event model", config: Config{Aliases: clash, Outbox: Outbox{Tables: []string{"pilots"}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			s := &State{Config: &tt.config, Tables: tables}
+			err := s.initOutbox()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %t, got: %v", tt.wantErr, err)
+			}
+			if err != nil || len(tt.config.Outbox.Tables) == 0 {
+				return
+			}
+
+			if s.Config.Outbox.Table != "outbox" {
+				t.Errorf("want the default outbox table, got: %q", s.Config.Outbox.Table)
+			}
+			if !s.Config.Outbox.Publishes("pilots") || s.Config.Outbox.Publishes("outbox") {
+				t.Error("want only the changes of pilots published")
+			}
+			if _, ok := s.Config.Imports.Singleton[outboxTemplate]; !ok {
+				t.Error("want imports for the outbox helpers")
+			}
+		})
+	}
+}
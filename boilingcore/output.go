@@ -24,13 +24,23 @@ var (
 	goarchList = stringSliceToMap(strings.Fields("386 amd64 amd64p32 arm armbe arm64 arm64be loong64 mips mipsle mips64 mips64le mips64p32 mips64p32le ppc ppc64 ppc64le riscv riscv64 s390 s390x sparc sparc64 wasm"))
 )
 
-var (
-	noEditDisclaimerFmt = `// Code generated by SQLBoiler%s(https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+const noEditDisclaimerFmt = `// Code generated by SQLBoiler%s(https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
 // This file is meant to be re-generated in place and/or deleted at any time.
-
 `
-	noEditDisclaimer = []byte(fmt.Sprintf(noEditDisclaimerFmt, " "))
-)
+
+// textComments are the comments of the text files, like SQL or Markdown,
+// the disclaimer is written in. Text files of other extensions are written
+// as the templates output them.
+var textComments = map[string]struct{ start, end string }{
+	".sql":  {"-- ", ""},
+	".md":   {"<!-- ", " -->"},
+	".html": {"<!-- ", " -->"},
+	".xml":  {"<!-- ", " -->"},
+	".yaml": {"# ", ""},
+	".yml":  {"# ", ""},
+	".toml": {"# ", ""},
+	".sh":   {"# ", ""},
+}
 
 var (
 	// templateByteBuffer is re-used by all template construction to avoid
@@ -65,6 +75,7 @@ func generateOutput(state *State, dirExts dirExtMap, data *templateData) error {
 		data:                 data,
 		templates:            state.Templates,
 		importSet:            state.Config.Imports.All,
+		importNamedSet:       state.Config.Imports.Singleton,
 		combineImportsOnType: true,
 		dirExtensions:        dirExts,
 	})
@@ -77,6 +88,7 @@ func generateTestOutput(state *State, dirExts dirExtMap, data *templateData) err
 		data:                 data,
 		templates:            state.TestTemplates,
 		importSet:            state.Config.Imports.Test,
+		importNamedSet:       state.Config.Imports.TestSingleton,
 		combineImportsOnType: false,
 		isTest:               true,
 		dirExtensions:        dirExts,
@@ -129,14 +141,36 @@ func executeTemplates(e executeTemplateData) error {
 			out.Reset()
 
 			isGo := filepath.Ext(ext) == ".go"
+
+			// A qualified Go extension, like .examples.go, is a file of its
+			// own per table, table_examples.go, with the named imports of the
+			// qualified name, examples or examples_test
+			tableName, fileExt, fileImps := e.data.Table.Name, ext, imps
+			if isGo && ext != ".go" {
+				qualifier := strings.TrimSuffix(ext[1:], ".go")
+				tableName += "_" + qualifier
+				fileExt = ".go"
+
+				named := qualifier
+				if e.isTest {
+					named += "_test"
+				}
+				fileImps = importers.Set{
+					Standard:   e.importNamedSet[named].Standard,
+					ThirdParty: e.importNamedSet[named].ThirdParty,
+				}
+			}
+
 			if isGo {
 				pkgName := e.state.Config.PkgName
 				if len(dir) != 0 {
 					pkgName = filepath.Base(dir)
 				}
-				writeFileDisclaimer(out)
+				writeFileDisclaimer(out, e.state)
 				writePackageName(out, pkgName)
-				writeImports(out, imps)
+				writeImports(out, fileImps)
+			} else {
+				writeTextDisclaimer(out, e.state, filepath.Ext(ext))
 			}
 
 			prevLen := out.Len()
@@ -146,19 +180,19 @@ func executeTemplates(e executeTemplateData) error {
 				}
 			}
 
-			fName := getOutputFilename(e.data.Table.Name, e.isTest, isGo)
-			fName += ext
+			fName := getOutputFilename(tableName, e.isTest, isGo)
+			fName += fileExt
 			if len(dir) != 0 {
 				fName = filepath.Join(dir, fName)
 			}
 
 			// Skip writing the file if the content is empty
 			if out.Len()-prevLen < 1 {
-				fmt.Fprintf(os.Stderr, "skipping empty file: %s/%s\n", e.state.Config.OutFolder, fName)
+				e.state.warnf("skipping empty file: %s/%s\n", e.state.Config.OutFolder, fName)
 				continue
 			}
 
-			if err := writeFile(e.state.Config.OutFolder, fName, out, isGo); err != nil {
+			if err := e.state.writeFile(fName, out, isGo); err != nil {
 				return err
 			}
 		}
@@ -194,16 +228,25 @@ func executeSingletonTemplates(e executeTemplateData) error {
 			if !usePkg {
 				pkgName = filepath.Base(dir)
 			}
-			writeFileDisclaimer(out)
+			writeFileDisclaimer(out, e.state)
 			writePackageName(out, pkgName)
 			writeImports(out, imps)
+		} else {
+			writeTextDisclaimer(out, e.state, filepath.Ext(normalized))
 		}
 
+		prevLen := out.Len()
 		if err := executeTemplate(out, e.templates.Template, tplName, e.data); err != nil {
 			return err
 		}
 
-		if err := writeFile(e.state.Config.OutFolder, normalized, out, isGo); err != nil {
+		// Skip writing the file if the content is empty
+		if out.Len()-prevLen < 1 {
+			e.state.warnf("skipping empty file: %s/%s\n", e.state.Config.OutFolder, normalized)
+			continue
+		}
+
+		if err := e.state.writeFile(normalized, out, isGo); err != nil {
 			return err
 		}
 	}
@@ -211,10 +254,36 @@ func executeSingletonTemplates(e executeTemplateData) error {
 	return nil
 }
 
-// writeFileDisclaimer writes the disclaimer at the top with a trailing
-// newline so the package name doesn't get attached to it.
-func writeFileDisclaimer(out *bytes.Buffer) {
-	_, _ = out.Write(noEditDisclaimer)
+// writeFileDisclaimer writes the disclaimer with the version and the stamp of
+// the state at the top with a trailing newline so the package name doesn't get
+// attached to it.
+func writeFileDisclaimer(out *bytes.Buffer, s *State) {
+	version := " "
+	if len(s.Config.Version) > 0 {
+		version = " " + s.Config.Version + " "
+	}
+	_, _ = fmt.Fprintf(out, noEditDisclaimerFmt, version)
+	_, _ = fmt.Fprintf(out, "%s\n\n", s.Stamp)
+}
+
+// writeTextDisclaimer writes the disclaimer and the stamp of the state in the
+// comments of the text files with the extension, if it has any
+func writeTextDisclaimer(out *bytes.Buffer, s *State, ext string) {
+	comment, ok := textComments[ext]
+	if !ok {
+		return
+	}
+
+	disclaimer := &bytes.Buffer{}
+	writeFileDisclaimer(disclaimer, s)
+	for _, line := range strings.Split(strings.TrimSpace(disclaimer.String()), "\n") {
+		line = strings.TrimPrefix(line, "// ")
+		if len(line) == 0 {
+			continue
+		}
+		_, _ = fmt.Fprintf(out, "%s%s%s\n", comment.start, line, comment.end)
+	}
+	out.WriteByte('\n')
 }
 
 // writePackageName writes the package name correctly, ignores errors
@@ -231,26 +300,43 @@ func writeImports(out *bytes.Buffer, imps importers.Set) {
 	}
 }
 
-// writeFile writes to the given folder and filename, formatting the buffer
-// given.
-func writeFile(outFolder string, fileName string, input *bytes.Buffer, format bool) error {
+// writeFile writes the file to the output folder of the state and records it
+// as changed, unless it already has the contents
+func (s *State) writeFile(fileName string, input *bytes.Buffer, format bool) error {
+	changed, err := writeFile(s.outputFS(), s.Config.OutFolder, fileName, input, format)
+	if err != nil {
+		return err
+	}
+	if changed {
+		s.changed = append(s.changed, filepath.Join(s.Config.OutFolder, fileName))
+	}
+	return nil
+}
+
+// writeFile writes to the given folder and filename of the filesystem,
+// formatting the buffer given. A file that already has the contents is not
+// written again, so its modification time is kept.
+func writeFile(fsys OutputFS, outFolder string, fileName string, input *bytes.Buffer, format bool) (changed bool, err error) {
 	var byt []byte
-	var err error
 	if format {
 		byt, err = formatBuffer(input)
 		if err != nil {
-			return err
+			return false, err
 		}
 	} else {
 		byt = input.Bytes()
 	}
 
 	path := filepath.Join(outFolder, fileName)
-	if err := testHarnessWriteFile(path, byt, 0664); err != nil {
-		return errors.Wrapf(err, "failed to write output file %s", path)
+	if existing, err := fsys.ReadFile(path); err == nil && bytes.Equal(existing, byt) {
+		return false, nil
 	}
 
-	return nil
+	if err := fsys.WriteFile(path, byt, 0664); err != nil {
+		return false, errors.Wrapf(err, "failed to write output file %s", path)
+	}
+
+	return true, nil
 }
 
 // executeTemplate takes a template and returns the output of the template
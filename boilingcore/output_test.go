@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -43,7 +44,7 @@ func TestWriteFile(t *testing.T) {
 	writePackageName(buf, "pkg")
 	fmt.Fprintf(buf, "func hello() {}\n\n\nfunc world() {\nreturn\n}\n\n\n\n")
 
-	if err := writeFile("", "", buf, true); err != nil {
+	if _, err := writeFile(osFS{}, "", "", buf, true); err != nil {
 		t.Error(err)
 	}
 
@@ -52,6 +53,59 @@ func TestWriteFile(t *testing.T) {
 	}
 }
 
+func TestWriteFileUnchanged(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	s := &State{Config: &Config{OutFolder: dir}}
+
+	for i, contents := range []string{"package a\n", "package a\n", "package b\n"} {
+		if err := s.writeFile("a.go", bytes.NewBufferString(contents), true); err != nil {
+			t.Fatal(err)
+		}
+		if want := []int{1, 1, 2}[i]; len(s.Changed()) != want {
+			t.Errorf("%d: want %d changed files, got: %v", i, want, s.Changed())
+		}
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "a.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "package b\n" {
+		t.Errorf("Wrong output: %q", b)
+	}
+}
+
+func TestWriteTextDisclaimer(t *testing.T) {
+	t.Parallel()
+
+	s := &State{Config: &Config{Version: "4.0.0"}, Stamp: Stamp{Version: "4.0.0", Driver: "psql", Hash: "abc"}}
+
+	buf := &bytes.Buffer{}
+	writeTextDisclaimer(buf, s, ".md")
+	want := `<!-- Code generated by SQLBoiler 4.0.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT. -->
+<!-- This file is meant to be re-generated in place and/or deleted at any time. -->
+<!-- SQLBoiler stamp: version=4.0.0 driver=psql hash=abc -->
+
+`
+	if got := buf.String(); got != want {
+		t.Errorf("want the disclaimer in html comments, got:\n%s", got)
+	}
+
+	buf.Reset()
+	writeTextDisclaimer(buf, s, ".sql")
+	if got := buf.String(); !strings.HasPrefix(got, "-- Code generated by SQLBoiler 4.0.0") {
+		t.Errorf("want the disclaimer in sql comments, got:\n%s", got)
+	}
+
+	buf.Reset()
+	writeTextDisclaimer(buf, s, ".txt")
+	if buf.Len() != 0 {
+		t.Errorf("want no disclaimer without comments, got:\n%s", buf.String())
+	}
+}
+
 func TestFormatBuffer(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,121 @@
+package boilingcore
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// OutputFS is the filesystem the generated files are written to, the paths
+// are the ones of the output folders joined with the names of the files
+type OutputFS interface {
+	MkdirAll(path string, perm fs.FileMode) error
+	RemoveAll(path string) error
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+}
+
+// osFS writes the generated files to the disk, it is the default OutputFS
+type osFS struct{}
+
+func (osFS) MkdirAll(path string, perm fs.FileMode) error { return os.MkdirAll(path, perm) }
+func (osFS) RemoveAll(path string) error                  { return os.RemoveAll(path) }
+func (osFS) ReadFile(name string) ([]byte, error)         { return os.ReadFile(name) }
+func (osFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return testHarnessWriteFile(name, data, perm)
+}
+
+// MemFS is an OutputFS keeping the generated files in memory, for the
+// programs post-processing or testing the generated code without touching
+// the disk
+type MemFS struct {
+	mut   sync.Mutex
+	files map[string][]byte
+}
+
+// NewMemFS creates an empty in memory filesystem
+func NewMemFS() *MemFS {
+	return &MemFS{files: make(map[string][]byte)}
+}
+
+// memFSKey normalizes a path to the slash separated key of its file
+func memFSKey(path string) string {
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// MkdirAll implements OutputFS, directories are implied by the files
+func (m *MemFS) MkdirAll(path string, perm fs.FileMode) error {
+	return nil
+}
+
+// RemoveAll implements OutputFS, it removes the file or the files in the
+// directory
+func (m *MemFS) RemoveAll(path string) error {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	key := memFSKey(path)
+	for name := range m.files {
+		if name == key || key == "." || strings.HasPrefix(name, key+"/") {
+			delete(m.files, name)
+		}
+	}
+	return nil
+}
+
+// ReadFile implements OutputFS
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	data, ok := m.files[memFSKey(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// WriteFile implements OutputFS
+func (m *MemFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	m.files[memFSKey(name)] = append([]byte(nil), data...)
+	return nil
+}
+
+// Files returns the contents of the files by their slash separated paths
+func (m *MemFS) Files() map[string][]byte {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	files := make(map[string][]byte, len(m.files))
+	for name, data := range m.files {
+		files[name] = append([]byte(nil), data...)
+	}
+	return files
+}
+
+// Names returns the sorted slash separated paths of the files
+func (m *MemFS) Names() []string {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	names := make([]string, 0, len(m.files))
+	for name := range m.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// outputFS returns the filesystem of the config, the disk by default
+func (s *State) outputFS() OutputFS {
+	if s.Config.OutputFS != nil {
+		return s.Config.OutputFS
+	}
+	return osFS{}
+}
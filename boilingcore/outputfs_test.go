@@ -0,0 +1,123 @@
+package boilingcore
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+func TestMemFS(t *testing.T) {
+	t.Parallel()
+
+	m := NewMemFS()
+	for _, name := range []string{"models/a.go", "models/sub/b.go", "other/c.go"} {
+		if err := m.WriteFile(filepath.FromSlash(name), []byte(name), 0664); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b, err := m.ReadFile(filepath.Join("models", "sub", "b.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "models/sub/b.go" {
+		t.Errorf("Wrong contents: %q", b)
+	}
+
+	if err := m.RemoveAll("models"); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"other/c.go"}; !reflect.DeepEqual(m.Names(), want) {
+		t.Errorf("want: %v\ngot: %v", want, m.Names())
+	}
+
+	if _, err := m.ReadFile("models/a.go"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("want a not exist error, got: %v", err)
+	}
+}
+
+func TestRunInMemory(t *testing.T) {
+	t.Parallel()
+
+	out := filepath.Join(t.TempDir(), "models")
+	files, err := RunInMemory(Config{
+		DriverName:   "mock",
+		OutFolder:    out,
+		NoTests:      true,
+		Quiet:        true,
+		DriverConfig: drivers.Config{Schema: "schema"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pilots, ok := files[filepath.ToSlash(filepath.Join(out, "pilots.go"))]
+	if !ok {
+		t.Fatalf("pilots.go was not generated, got: %d files", len(files))
+	}
+	if !strings.Contains(string(pilots), "type Pilot struct") {
+		t.Error("pilots.go does not have the model")
+	}
+
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Errorf("want the output folder not to be created, got: %v", err)
+	}
+}
+
+func TestRunTables(t *testing.T) {
+	t.Parallel()
+
+	fsys := NewMemFS()
+	state, err := New(&Config{
+		DriverName:      "mock",
+		PkgName:         "models",
+		OutFolder:       "models",
+		OutputFS:        fsys,
+		NoTests:         true,
+		Quiet:           true,
+		Imports:         importers.NewDefaultImports(),
+		StructTagCasing: "snake",
+		RelationTag:     "-",
+		DriverConfig:    drivers.Config{Schema: "schema"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := state.RunTables("pilots"); err != nil {
+		t.Fatal(err)
+	}
+
+	names := fsys.Names()
+	has := func(name string) bool {
+		for _, n := range names {
+			if n == name {
+				return true
+			}
+		}
+		return false
+	}
+	if !has("models/pilots.go") {
+		t.Errorf("pilots.go was not generated, got: %v", names)
+	}
+	if has("models/jets.go") {
+		t.Error("jets.go was generated")
+	}
+	if !has("models/boil_queries.go") {
+		t.Errorf("the singletons were not generated, got: %v", names)
+	}
+
+	if err := state.RunTables("unknown"); err == nil {
+		t.Error("want an error for an unknown table")
+	}
+	if err := state.RunTables(); err == nil {
+		t.Error("want an error without tables")
+	}
+}
@@ -0,0 +1,255 @@
+package boilingcore
+
+import (
+	"path/filepath"
+
+	"github.com/friendsofgo/errors"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// Package routes tables to a package of their own instead of the one of
+// PkgName, it is generated in its own output folder
+type Package struct {
+	Name string `toml:"name,omitempty" json:"name,omitempty"`
+	// Output is the folder of the package, its name by default
+	Output string   `toml:"output,omitempty" json:"output,omitempty"`
+	Tables []string `toml:"tables,omitempty" json:"tables,omitempty"`
+}
+
+// initPackages splits the state into a state per package when tables are
+// routed to other packages. Every package only has the relationships between
+// its own tables, the foreign keys to tables of other packages are left as
+// plain ID fields. Join tables have no model and are part of every package.
+func (s *State) initPackages(lazyTemplates []lazyTemplate) error {
+	if len(s.Config.Packages) == 0 {
+		return nil
+	}
+
+	// tablePackages has the index of the package of each routed table, 0
+	// is the package of PkgName
+	tablePackages := make(map[string]int)
+	outputs := map[string]string{filepath.Clean(s.Config.OutFolder): s.Config.PkgName}
+	packages := make([]Package, len(s.Config.Packages))
+	for i, p := range s.Config.Packages {
+		if len(p.Name) == 0 {
+			return errors.New("a package must have a name")
+		}
+		if len(p.Output) == 0 {
+			p.Output = p.Name
+		}
+
+		output := filepath.Clean(p.Output)
+		if other, ok := outputs[output]; ok {
+			return errors.Errorf("packages %s and %s cannot share the output folder %s", other, p.Name, p.Output)
+		}
+		outputs[output] = p.Name
+
+		for _, name := range p.Tables {
+			if other, ok := tablePackages[name]; ok {
+				return errors.Errorf("table %s cannot be in both packages %s and %s", name, s.Config.Packages[other-1].Name, p.Name)
+			}
+
+			found := false
+			for _, t := range s.Tables {
+				if t.Name == name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return errors.Errorf("table %s of package %s was not found", name, p.Name)
+			}
+
+			tablePackages[name] = i + 1
+		}
+
+		packages[i] = p
+	}
+
+	for _, j := range s.Config.Joins {
+		if tablePackages[j.Table] != tablePackages[j.ForeignTable] {
+			return errors.Errorf("join %s cannot join tables %s and %s of different packages", j.Name, j.Table, j.ForeignTable)
+		}
+	}
+
+	for _, p := range s.Config.Polymorphics {
+		for _, target := range p.Targets {
+			if tablePackages[p.Table] != tablePackages[target.Table] {
+				return errors.Errorf("polymorphic %s cannot refer from table %s to table %s of another package", p.Name, p.Table, target.Table)
+			}
+		}
+	}
+
+	s.packages = []*State{s.packageState(Package{Name: s.Config.PkgName, Output: s.Config.OutFolder}, 0, tablePackages)}
+	for i, p := range packages {
+		state := s.packageState(p, i+1, tablePackages)
+		if err := state.initOutFolders(lazyTemplates); err != nil {
+			return errors.Wrapf(err, "unable to initialize the output folder of package %s", p.Name)
+		}
+		s.packages = append(s.packages, state)
+	}
+
+	return nil
+}
+
+// packageState returns a state generating the tables of the package at the
+// index, the tables without a package are in the package of PkgName at 0
+func (s *State) packageState(p Package, index int, tablePackages map[string]int) *State {
+	in := make(map[string]bool)
+	for _, t := range s.Tables {
+		in[t.Name] = tablePackages[t.Name] == index || t.IsJoinTable
+	}
+
+	config := *s.Config
+	config.PkgName = p.Name
+	config.OutFolder = p.Output
+	config.AuditLog.Tables = nil
+	for _, name := range s.Config.AuditLog.Tables {
+		if in[name] {
+			config.AuditLog.Tables = append(config.AuditLog.Tables, name)
+		}
+	}
+	config.Outbox.Tables = nil
+	for _, name := range s.Config.Outbox.Tables {
+		if in[name] {
+			config.Outbox.Tables = append(config.Outbox.Tables, name)
+		}
+	}
+
+	state := *s
+	state.Config = &config
+	state.Tables = packageTables(s.Tables, in)
+	state.packages = nil
+	state.Tenancy.Tables = nil
+	for _, name := range s.Tenancy.Tables {
+		if in[name] {
+			state.Tenancy.Tables = append(state.Tenancy.Tables, name)
+		}
+	}
+	state.RLS.Tables = nil
+	for _, name := range s.RLS.Tables {
+		if in[name] {
+			state.RLS.Tables = append(state.RLS.Tables, name)
+		}
+	}
+
+	// Queries and functions returning a model go with it, the others stay in
+	// the package of PkgName
+	state.CustomQueries = nil
+	for _, q := range s.CustomQueries {
+		if (len(q.Table) != 0 && in[q.Table]) || (len(q.Table) == 0 && index == 0) {
+			state.CustomQueries = append(state.CustomQueries, q)
+		}
+	}
+	state.Functions = nil
+	for _, fn := range s.Functions {
+		if (fn.Kind == "model" && in[fn.Table]) || (fn.Kind != "model" && index == 0) {
+			state.Functions = append(state.Functions, fn)
+		}
+	}
+	state.SeedTables = nil
+	for _, t := range s.SeedTables {
+		if in[t.Name] {
+			state.SeedTables = append(state.SeedTables, t)
+		}
+	}
+	state.ScrubTables = nil
+	for _, t := range s.ScrubTables {
+		if in[t.Name] {
+			state.ScrubTables = append(state.ScrubTables, t)
+		}
+	}
+	state.MaskTables = nil
+	for _, t := range s.MaskTables {
+		if in[t.Name] {
+			state.MaskTables = append(state.MaskTables, t)
+		}
+	}
+	state.StreamTables = nil
+	for _, t := range s.StreamTables {
+		if in[t.Name] {
+			state.StreamTables = append(state.StreamTables, t)
+		}
+	}
+	state.SearchTables = nil
+	for _, t := range s.SearchTables {
+		if in[t.Name] {
+			state.SearchTables = append(state.SearchTables, t)
+		}
+	}
+	state.DTOMappers = nil
+	for _, m := range s.DTOMappers {
+		if in[m.Table] {
+			state.DTOMappers = append(state.DTOMappers, m)
+		}
+	}
+	state.JoinViews = nil
+	for _, j := range s.JoinViews {
+		if in[j.Table] {
+			state.JoinViews = append(state.JoinViews, j)
+		}
+	}
+	state.Polymorphics = nil
+	for _, p := range s.Polymorphics {
+		if in[p.Table] {
+			state.Polymorphics = append(state.Polymorphics, p)
+		}
+	}
+	state.Inheritances = nil
+	for _, inh := range s.Inheritances {
+		if in[inh.Table] {
+			state.Inheritances = append(state.Inheritances, inh)
+		}
+	}
+	if len(s.DTOMappers) != 0 {
+		// The DTOs of every package import their own packages
+		config.Imports.Singleton = make(importers.Map, len(s.Config.Imports.Singleton))
+		for name, imps := range s.Config.Imports.Singleton {
+			config.Imports.Singleton[name] = imps
+		}
+		config.Imports.Singleton[dtoTemplate] = dtoImports(state.DTOMappers)
+	}
+
+	return &state
+}
+
+// packageTables returns the tables in the package without their relationships
+// to tables of other packages
+func packageTables(tables []drivers.Table, in map[string]bool) []drivers.Table {
+	var pkgTables []drivers.Table
+	for _, t := range tables {
+		if !in[t.Name] {
+			continue
+		}
+
+		fkeys := t.FKeys
+		t.FKeys = nil
+		for _, fk := range fkeys {
+			if in[fk.ForeignTable] {
+				t.FKeys = append(t.FKeys, fk)
+			}
+		}
+
+		toOne := t.ToOneRelationships
+		t.ToOneRelationships = nil
+		for _, r := range toOne {
+			if in[r.ForeignTable] {
+				t.ToOneRelationships = append(t.ToOneRelationships, r)
+			}
+		}
+
+		toMany := t.ToManyRelationships
+		t.ToManyRelationships = nil
+		for _, r := range toMany {
+			if in[r.ForeignTable] {
+				t.ToManyRelationships = append(t.ToManyRelationships, r)
+			}
+		}
+
+		pkgTables = append(pkgTables, t)
+	}
+
+	return pkgTables
+}
@@ -0,0 +1,149 @@
+package boilingcore
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestInitPackages(t *testing.T) {
+	t.Parallel()
+
+	tables := []drivers.Table{
+		{
+			Name:  "users",
+			FKeys: []drivers.ForeignKey{{Name: "users_team_fkey", Table: "users", Column: "team_id", ForeignTable: "teams", ForeignColumn: "id"}},
+			ToManyRelationships: []drivers.ToManyRelationship{
+				{Table: "users", ForeignTable: "posts", ForeignColumn: "user_id"},
+				{Table: "users", ForeignTable: "sessions", ForeignColumn: "user_id"},
+				{Table: "users", ForeignTable: "roles", ToJoinTable: true, JoinTable: "user_roles"},
+			},
+		},
+		{
+			Name:  "sessions",
+			FKeys: []drivers.ForeignKey{{Name: "sessions_user_fkey", Table: "sessions", Column: "user_id", ForeignTable: "users", ForeignColumn: "id"}},
+		},
+		{
+			Name:  "posts",
+			FKeys: []drivers.ForeignKey{{Name: "posts_user_fkey", Table: "posts", Column: "user_id", ForeignTable: "users", ForeignColumn: "id"}},
+		},
+		{Name: "teams"},
+		{Name: "roles"},
+		{Name: "user_roles", IsJoinTable: true},
+	}
+
+	out := t.TempDir()
+	config := &Config{
+		PkgName:   "models",
+		OutFolder: filepath.Join(out, "models"),
+		Packages: []Package{
+			{Name: "authmodels", Output: filepath.Join(out, "authmodels"), Tables: []string{"users", "sessions", "roles"}},
+		},
+		AuditLog: AuditLog{Tables: []string{"posts", "sessions"}},
+	}
+	s := &State{
+		Config: config,
+		Tables: tables,
+		CustomQueries: []CustomQuery{
+			{Name: "UserByEmail", Table: "users"},
+			{Name: "CountRows"},
+		},
+		DTOMappers: []DTOMapper{
+			{Name: "PostDTO", Table: "posts", Import: `api "example.com/api"`},
+			{Name: "UserDTO", Table: "users", Import: `auth "example.com/auth"`},
+		},
+	}
+
+	if err := s.initPackages(nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.packages) != 2 {
+		t.Fatalf("want two packages, got: %d", len(s.packages))
+	}
+
+	names := func(tables []drivers.Table) []string {
+		var names []string
+		for _, t := range tables {
+			names = append(names, t.Name)
+		}
+		return names
+	}
+
+	models, auth := s.packages[0], s.packages[1]
+	if got := names(models.Tables); !reflect.DeepEqual(got, []string{"posts", "teams", "user_roles"}) {
+		t.Errorf("tables of models were wrong: %v", got)
+	}
+	if got := names(auth.Tables); !reflect.DeepEqual(got, []string{"users", "sessions", "roles", "user_roles"}) {
+		t.Errorf("tables of authmodels were wrong: %v", got)
+	}
+	if auth.Config.PkgName != "authmodels" || models.Config.PkgName != "models" {
+		t.Errorf("package names were wrong: %s, %s", models.Config.PkgName, auth.Config.PkgName)
+	}
+
+	if fkeys := models.Tables[0].FKeys; len(fkeys) != 0 {
+		t.Errorf("want the foreign key to users left as an id, got: %v", fkeys)
+	}
+	users := auth.Tables[0]
+	if len(users.FKeys) != 0 {
+		t.Errorf("want the foreign key to teams left as an id, got: %v", users.FKeys)
+	}
+	if len(users.ToManyRelationships) != 2 || users.ToManyRelationships[0].ForeignTable != "sessions" || users.ToManyRelationships[1].ForeignTable != "roles" {
+		t.Errorf("to many relationships of users were wrong: %v", users.ToManyRelationships)
+	}
+	if fkeys := auth.Tables[1].FKeys; len(fkeys) != 1 {
+		t.Errorf("want the foreign key of sessions to users, got: %v", fkeys)
+	}
+	if len(s.Tables[0].FKeys) != 1 || len(s.Tables[0].ToManyRelationships) != 3 {
+		t.Error("the tables of the state must not change")
+	}
+
+	if got := models.Config.AuditLog.Tables; !reflect.DeepEqual(got, []string{"posts"}) {
+		t.Errorf("audited tables of models were wrong: %v", got)
+	}
+	if got := auth.Config.AuditLog.Tables; !reflect.DeepEqual(got, []string{"sessions"}) {
+		t.Errorf("audited tables of authmodels were wrong: %v", got)
+	}
+	if len(models.CustomQueries) != 1 || models.CustomQueries[0].Name != "CountRows" {
+		t.Errorf("custom queries of models were wrong: %v", models.CustomQueries)
+	}
+	if len(auth.CustomQueries) != 1 || auth.CustomQueries[0].Name != "UserByEmail" {
+		t.Errorf("custom queries of authmodels were wrong: %v", auth.CustomQueries)
+	}
+	if len(auth.DTOMappers) != 1 || auth.DTOMappers[0].Name != "UserDTO" {
+		t.Errorf("dtos of authmodels were wrong: %v", auth.DTOMappers)
+	}
+	if got := auth.Config.Imports.Singleton[dtoTemplate].ThirdParty; !reflect.DeepEqual([]string(got), []string{`auth "example.com/auth"`}) {
+		t.Errorf("dto imports of authmodels were wrong: %v", got)
+	}
+	if got := models.Config.Imports.Singleton[dtoTemplate].ThirdParty; !reflect.DeepEqual([]string(got), []string{`api "example.com/api"`}) {
+		t.Errorf("dto imports of models were wrong: %v", got)
+	}
+}
+
+func TestInitPackagesErrors(t *testing.T) {
+	t.Parallel()
+
+	tables := []drivers.Table{{Name: "users"}, {Name: "posts"}}
+
+	tests := []struct {
+		name     string
+		packages []Package
+	}{
+		{name: "no name", packages: []Package{{Tables: []string{"users"}}}},
+		{name: "missing table", packages: []Package{{Name: "auth", Tables: []string{"jets"}}}},
+		{name: "same output", packages: []Package{{Name: "auth", Output: "models"}}},
+		{name: "table twice", packages: []Package{{Name: "auth", Tables: []string{"users"}}, {Name: "blog", Tables: []string{"users"}}}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			s := &State{Config: &Config{PkgName: "models", OutFolder: "models", Packages: tt.packages}, Tables: tables}
+			if err := s.initPackages(nil); err == nil {
+				t.Error("want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package boilingcore
+
+import (
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/strmangle"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+// Polymorphic is a pair of columns of a table referring to a row of one of
+// several tables, the id column holding its primary key and the type column
+// naming its table, like the polymorphic associations of Rails
+type Polymorphic struct {
+	// Name names the accessors of the targets, it is title cased so
+	// commentable names CommentablePost
+	Name  string `toml:"name,omitempty" json:"name,omitempty"`
+	Table string `toml:"table,omitempty" json:"table,omitempty"`
+	// IDColumn and TypeColumn default to <name>_id and <name>_type
+	IDColumn   string `toml:"id_column,omitempty" json:"id_column,omitempty"`
+	TypeColumn string `toml:"type_column,omitempty" json:"type_column,omitempty"`
+	// Targets are the tables the pair can refer to
+	Targets []PolymorphicTarget `toml:"targets,omitempty" json:"targets,omitempty"`
+}
+
+// PolymorphicTarget is a table a polymorphic pair can refer to, when the type
+// column holds Type the id column holds the value of the Column of the Table
+type PolymorphicTarget struct {
+	// Type defaults to the model name of the table, Post for posts, which is
+	// the class name Rails stores
+	Type  string `toml:"type,omitempty" json:"type,omitempty"`
+	Table string `toml:"table,omitempty" json:"table,omitempty"`
+	// Column is the primary key column of the table
+	Column string `toml:"-" json:"-"`
+}
+
+// initPolymorphics checks the polymorphic pairs against the tables and
+// resolves their columns and the types of their targets
+func (s *State) initPolymorphics() error {
+	s.Polymorphics = nil
+	if len(s.Config.Polymorphics) == 0 {
+		return nil
+	}
+
+	tables := make(map[string]drivers.Table)
+	for _, t := range s.Tables {
+		if !t.IsJoinTable && !t.IsView {
+			tables[t.Name] = t
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, p := range s.Config.Polymorphics {
+		if len(p.IDColumn) == 0 {
+			p.IDColumn = p.Name + "_id"
+		}
+		if len(p.TypeColumn) == 0 {
+			p.TypeColumn = p.Name + "_type"
+		}
+
+		p.Name = strmangle.TitleCase(p.Name)
+		if !rgxProtoIdent.MatchString(p.Name) {
+			return errors.Errorf("polymorphic name %q is not a valid identifier", p.Name)
+		}
+		if names[p.Table+"."+p.Name] {
+			return errors.Errorf("polymorphic %s of table %s is defined twice", p.Name, p.Table)
+		}
+		names[p.Table+"."+p.Name] = true
+
+		t, ok := tables[p.Table]
+		if !ok {
+			return errors.Errorf("table %s of polymorphic %s was not found", p.Table, p.Name)
+		}
+		columns := make(map[string]bool)
+		for _, c := range t.Columns {
+			columns[c.Name] = true
+		}
+		if !columns[p.IDColumn] {
+			return errors.Errorf("id column %s of polymorphic %s was not found in table %s", p.IDColumn, p.Name, p.Table)
+		}
+		if !columns[p.TypeColumn] {
+			return errors.Errorf("type column %s of polymorphic %s was not found in table %s", p.TypeColumn, p.Name, p.Table)
+		}
+		if typ := t.GetColumn(p.TypeColumn).Type; typ != "string" && typ != "null.String" {
+			return errors.Errorf("type column %s of polymorphic %s is of type %s, want a string", p.TypeColumn, p.Name, typ)
+		}
+
+		if len(p.Targets) == 0 {
+			return errors.Errorf("polymorphic %s has no targets", p.Name)
+		}
+
+		targets := make([]PolymorphicTarget, len(p.Targets))
+		types := make(map[string]bool)
+		for i, target := range p.Targets {
+			ft, ok := tables[target.Table]
+			if !ok {
+				return errors.Errorf("target %s of polymorphic %s was not found", target.Table, p.Name)
+			}
+			if ft.PKey == nil || len(ft.PKey.Columns) != 1 {
+				return errors.Errorf("target %s of polymorphic %s needs a single column primary key", ft.Name, p.Name)
+			}
+
+			if len(target.Type) == 0 {
+				target.Type = s.Config.Aliases.Table(ft.Name).UpSingular
+			}
+			if types[target.Type] {
+				return errors.Errorf("type %s of polymorphic %s names several targets", target.Type, p.Name)
+			}
+			types[target.Type] = true
+
+			target.Column = ft.PKey.Columns[0]
+			targets[i] = target
+		}
+		p.Targets = targets
+
+		s.Polymorphics = append(s.Polymorphics, p)
+	}
+
+	return nil
+}
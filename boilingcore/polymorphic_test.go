@@ -0,0 +1,80 @@
+package boilingcore
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInitPolymorphics(t *testing.T) {
+	t.Parallel()
+
+	s := testState(Config{Polymorphics: []Polymorphic{
+		{
+			Name:    "commentable",
+			Table:   "comments",
+			Targets: []PolymorphicTarget{{Table: "posts"}, {Table: "videos"}},
+		},
+		{
+			Name:       "owner",
+			Table:      "comments",
+			IDColumn:   "owner_ref",
+			TypeColumn: "owner_kind",
+			Targets:    []PolymorphicTarget{{Type: "BlogPost", Table: "posts"}},
+		},
+	}})
+	if err := s.initPolymorphics(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Polymorphic{
+		{
+			Name:       "Commentable",
+			Table:      "comments",
+			IDColumn:   "commentable_id",
+			TypeColumn: "commentable_type",
+			Targets: []PolymorphicTarget{
+				{Type: "Post", Table: "posts", Column: "id"},
+				{Type: "Video", Table: "videos", Column: "uuid"},
+			},
+		},
+		{
+			Name:       "Owner",
+			Table:      "comments",
+			IDColumn:   "owner_ref",
+			TypeColumn: "owner_kind",
+			Targets:    []PolymorphicTarget{{Type: "BlogPost", Table: "posts", Column: "id"}},
+		},
+	}
+	if !reflect.DeepEqual(s.Polymorphics, want) {
+		t.Errorf("want: %#v\ngot: %#v", want, s.Polymorphics)
+	}
+}
+
+func TestInitPolymorphicsErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		polymorphic Polymorphic
+		err         string
+	}{
+		{Polymorphic{Name: "2able", Table: "comments"}, "not a valid identifier"},
+		{Polymorphic{Name: "commentable", Table: "notes"}, "table notes of polymorphic Commentable was not found"},
+		{Polymorphic{Name: "owner", Table: "comments"}, "id column owner_id of polymorphic Owner was not found"},
+		{Polymorphic{Name: "owner", Table: "comments", IDColumn: "owner_ref"}, "type column owner_type of polymorphic Owner was not found"},
+		{Polymorphic{Name: "commentable", Table: "comments", TypeColumn: "rating"}, "type column rating of polymorphic Commentable is of type int, want a string"},
+		{Polymorphic{Name: "commentable", Table: "comments"}, "polymorphic Commentable has no targets"},
+		{Polymorphic{Name: "commentable", Table: "comments", Targets: []PolymorphicTarget{{Table: "photos"}}}, "target photos of polymorphic Commentable was not found"},
+		{Polymorphic{Name: "commentable", Table: "comments", Targets: []PolymorphicTarget{{Table: "post_tags"}}}, "needs a single column primary key"},
+		{Polymorphic{Name: "commentable", Table: "comments", Targets: []PolymorphicTarget{{Table: "posts"}, {Type: "Post", Table: "videos"}}}, "type Post of polymorphic Commentable names several targets"},
+	}
+
+	for _, test := range tests {
+		s := testState(Config{Polymorphics: []Polymorphic{test.polymorphic}})
+
+		err := s.initPolymorphics()
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%#v: want error containing %q, got: %v", test.polymorphic, test.err, err)
+		}
+	}
+}
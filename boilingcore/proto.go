@@ -0,0 +1,385 @@
+package boilingcore
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/strmangle"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// protoTemplate is the singleton with the conversion helpers of the protobuf
+// messages, it names its entry in the singleton imports
+const protoTemplate = "boil_proto"
+
+// grpcTemplate is the singleton with the helpers of the gRPC services
+const grpcTemplate = "boil_grpc"
+
+var rgxProtoIdent = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// protoScalars maps the Go types of columns to protobuf scalar types
+var protoScalars = map[string]string{
+	"int":     "int64",
+	"int8":    "int32",
+	"int16":   "int32",
+	"int32":   "int32",
+	"int64":   "int64",
+	"uint":    "uint64",
+	"uint8":   "uint32",
+	"byte":    "uint32",
+	"uint16":  "uint32",
+	"uint32":  "uint32",
+	"uint64":  "uint64",
+	"float32": "float",
+	"float64": "double",
+	"bool":    "bool",
+	"string":  "string",
+	"[]byte":  "bytes",
+}
+
+// protoGoTypes are the Go types protoc generates for the scalar types
+var protoGoTypes = map[string]string{
+	"int32":  "int32",
+	"int64":  "int64",
+	"uint32": "uint32",
+	"uint64": "uint64",
+	"float":  "float32",
+	"double": "float64",
+	"bool":   "bool",
+	"string": "string",
+	"bytes":  "[]byte",
+}
+
+// protoWrappers are the google.protobuf wrapper messages of the scalar types
+var protoWrappers = map[string]string{
+	"int32":  "Int32Value",
+	"int64":  "Int64Value",
+	"uint32": "UInt32Value",
+	"uint64": "UInt64Value",
+	"float":  "FloatValue",
+	"double": "DoubleValue",
+	"bool":   "BoolValue",
+	"string": "StringValue",
+	"bytes":  "BytesValue",
+}
+
+// protoOptionals name the helpers converting values to optional fields of
+// the scalar types
+var protoOptionals = map[string]string{
+	"int32":  "Int32",
+	"int64":  "Int64",
+	"uint32": "Uint32",
+	"uint64": "Uint64",
+	"float":  "Float32",
+	"double": "Float64",
+	"bool":   "Bool",
+	"string": "String",
+	"bytes":  "Bytes",
+}
+
+// protoNullBases are the Go types of the values of the null package types
+var protoNullBases = map[string]string{
+	"null.Int":     "int",
+	"null.Int8":    "int8",
+	"null.Int16":   "int16",
+	"null.Int32":   "int32",
+	"null.Int64":   "int64",
+	"null.Uint":    "uint",
+	"null.Uint8":   "uint8",
+	"null.Uint16":  "uint16",
+	"null.Uint32":  "uint32",
+	"null.Uint64":  "uint64",
+	"null.Byte":    "byte",
+	"null.Float32": "float32",
+	"null.Float64": "float64",
+	"null.Bool":    "bool",
+	"null.String":  "string",
+	"null.Bytes":   "[]byte",
+	"null.JSON":    "[]byte",
+	"null.Time":    "time.Time",
+}
+
+// protoArrays maps the array types to the protobuf types of their elements
+var protoArrays = map[string]string{
+	"types.Int64Array":   "int64",
+	"types.Float64Array": "double",
+	"types.BoolArray":    "bool",
+	"types.StringArray":  "string",
+	"types.BytesArray":   "bytes",
+}
+
+// ProtoMessage is the protobuf message mirroring the model of a table
+type ProtoMessage struct {
+	Name    string
+	Fields  []ProtoField
+	Imports []string
+	// Skipped are the columns whose types have no protobuf mapping
+	Skipped []drivers.Column
+	// Keys are the fields of the primary key, the fields of the requests of
+	// the gRPC service for a row. They are nil when a column has no field,
+	// the rows are then only listed and created.
+	Keys []ProtoField
+	// Updates are the fields the gRPC service updates, the fields other than
+	// the Keys
+	Updates []ProtoField
+}
+
+// ProtoField is a field of a protobuf message, named after its column
+type ProtoField struct {
+	Name string
+	// GoName is the name of the field in the Go code protoc generates
+	GoName   string
+	Number   int
+	Type     string
+	Optional bool
+	Repeated bool
+
+	// ToProto converts the field of the model o
+	ToProto string
+	// FromProto converts the field of the message m
+	FromProto string
+}
+
+// initProto builds the protobuf message of every table and sets the imports
+// of the conversion helpers
+func (s *State) initProto() error {
+	if s.Config.WithGRPC && !s.Config.WithProto {
+		return errors.New("with-grpc needs the protobuf messages of with-proto")
+	}
+	if !s.Config.WithProto {
+		return nil
+	}
+
+	if len(s.Config.Proto.GoPackage) == 0 {
+		return errors.New("with-proto needs the import path of the Go code protoc generates in proto.go_package")
+	}
+	if len(s.Config.Proto.Package) == 0 {
+		s.Config.Proto.Package = s.Config.PkgName
+	}
+	switch s.Config.Proto.Nullable {
+	case "":
+		s.Config.Proto.Nullable = "optional"
+	case "optional", "wrappers":
+	default:
+		return errors.Errorf("proto.nullable must be optional or wrappers, got: %s", s.Config.Proto.Nullable)
+	}
+
+	s.ProtoMessages = make(map[string]ProtoMessage)
+	for _, t := range s.Tables {
+		if t.IsJoinTable {
+			continue
+		}
+
+		alias := s.Config.Aliases.Table(t.Name)
+		msg := ProtoMessage{Name: alias.UpSingular}
+		imports := make(map[string]bool)
+
+		var pkey []string
+		if !t.IsView && t.PKey != nil {
+			pkey = t.PKey.Columns
+		}
+		keys := make(map[string]ProtoField)
+		var updates []ProtoField
+
+		for i, c := range t.Columns {
+			field, ok := s.protoField(t, c, alias.Column(c.Name))
+			if !ok {
+				msg.Skipped = append(msg.Skipped, c)
+				continue
+			}
+			field.Number = i + 1
+			if strmangle.SetInclude(c.Name, pkey) {
+				keys[c.Name] = field
+			} else {
+				updates = append(updates, field)
+			}
+
+			switch {
+			case field.Type == "google.protobuf.Timestamp":
+				imports["google/protobuf/timestamp.proto"] = true
+			case strings.HasPrefix(field.Type, "google.protobuf."):
+				imports["google/protobuf/wrappers.proto"] = true
+			}
+
+			msg.Fields = append(msg.Fields, field)
+		}
+
+		if s.Config.WithGRPC && len(pkey) != 0 && len(keys) == len(pkey) {
+			for i, name := range pkey {
+				key := keys[name]
+				key.Number = i + 1
+				msg.Keys = append(msg.Keys, key)
+			}
+			msg.Updates = updates
+			imports["google/protobuf/field_mask.proto"] = len(updates) != 0
+		}
+
+		for _, imp := range []string{"google/protobuf/field_mask.proto", "google/protobuf/timestamp.proto", "google/protobuf/wrappers.proto"} {
+			if imports[imp] {
+				msg.Imports = append(msg.Imports, imp)
+			}
+		}
+
+		s.ProtoMessages[t.Name] = msg
+	}
+
+	s.Config.Imports.All.ThirdParty = append(s.Config.Imports.All.ThirdParty, fmt.Sprintf(`pb "%s"`, s.Config.Proto.GoPackage))
+
+	imps := importers.Set{
+		Standard:   importers.List{`"time"`},
+		ThirdParty: importers.List{`"google.golang.org/protobuf/types/known/timestamppb"`},
+	}
+	if s.Config.Proto.Nullable == "wrappers" {
+		imps.ThirdParty = append(imps.ThirdParty, `"google.golang.org/protobuf/types/known/wrapperspb"`)
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[protoTemplate] = imps
+
+	if s.Config.WithGRPC {
+		s.Config.Imports.Singleton[grpcTemplate] = importers.Set{
+			Standard: importers.List{`"database/sql"`, `"strconv"`},
+			ThirdParty: importers.List{
+				`"github.com/friendsofgo/errors"`,
+				`"github.com/volatiletech/sqlboiler/v4/queries/qm"`,
+				`"google.golang.org/grpc/codes"`,
+				`"google.golang.org/grpc/status"`,
+			},
+		}
+	}
+
+	return nil
+}
+
+// protoField maps the column to a field of the message and the conversions
+// between it and the field of the model, it is false for columns of types
+// without a mapping
+func (s *State) protoField(t drivers.Table, c drivers.Column, goField string) (ProtoField, bool) {
+	if !rgxProtoIdent.MatchString(c.Name) {
+		return ProtoField{}, false
+	}
+
+	goName := protoGoName(c.Name)
+	field := ProtoField{Name: c.Name, GoName: goName}
+	o := "o." + goField
+	get := "m.Get" + goName + "()"
+	present := "m." + goName + " != nil"
+	wrappers := s.Config.Proto.Nullable == "wrappers"
+
+	// nullable maps a value of the Go type base, converting a message value
+	// with from
+	nullable := func(base, value, valid, from string) bool {
+		if base == "time.Time" {
+			field.Type = "google.protobuf.Timestamp"
+			field.ToProto = fmt.Sprintf("protoTimestamp(%s, %s)", value, valid)
+			field.FromProto = fmt.Sprintf(from, get+".AsTime()")
+			return true
+		}
+
+		scalar, ok := protoScalars[base]
+		if !ok {
+			return false
+		}
+		goType := protoGoTypes[scalar]
+		if wrappers {
+			field.Type = "google.protobuf." + protoWrappers[scalar]
+			field.ToProto = fmt.Sprintf("proto%s(%s, %s)", protoWrappers[scalar], protoConvert(goType, base, value), valid)
+			field.FromProto = fmt.Sprintf(from, protoConvert(base, goType, get+".GetValue()"))
+		} else {
+			field.Type = scalar
+			field.Optional = true
+			field.ToProto = fmt.Sprintf("protoOptional%s(%s, %s)", protoOptionals[scalar], protoConvert(goType, base, value), valid)
+			field.FromProto = fmt.Sprintf(from, protoConvert(base, goType, get))
+		}
+		return true
+	}
+
+	switch {
+	case s.Config.AddEnumTypes && drivers.IsEnumDBType(c.DBType) && c.Type != "string" && c.Type != "null.String":
+		enumName := titleCase(strmangle.ParseEnumName(c.DBType))
+		if len(enumName) == 0 {
+			enumName = titleCase(t.Name) + titleCase(c.Name)
+		}
+		if c.Nullable {
+			return field, nullable("string", "string("+o+".Val)", o+".Valid", "New"+c.Type+"("+enumName+"(%s), "+present+")")
+		}
+		field.Type = "string"
+		field.ToProto = "string(" + o + ")"
+		field.FromProto = enumName + "(" + get + ")"
+	case c.Type == "time.Time":
+		field.Type = "google.protobuf.Timestamp"
+		field.ToProto = fmt.Sprintf("protoTimestamp(%s, true)", o)
+		field.FromProto = get + ".AsTime()"
+	case c.Type == "types.JSON":
+		field.Type = "bytes"
+		field.ToProto = "[]byte(" + o + ")"
+		field.FromProto = "types.JSON(" + get + ")"
+	case protoArrays[c.Type] != "":
+		field.Type = protoArrays[c.Type]
+		field.Repeated = true
+		field.ToProto = protoConvert("[]"+protoGoTypes[field.Type], c.Type, o)
+		field.FromProto = c.Type + "(" + get + ")"
+	case protoNullBases[c.Type] != "":
+		suffix := strings.TrimPrefix(c.Type, "null.")
+		return field, nullable(protoNullBases[c.Type], o+"."+suffix, o+".Valid", "null.New"+suffix+"(%s, "+present+")")
+	case protoScalars[c.Type] != "":
+		field.Type = protoScalars[c.Type]
+		goType := protoGoTypes[field.Type]
+		field.ToProto = protoConvert(goType, c.Type, o)
+		field.FromProto = protoConvert(c.Type, goType, get)
+	default:
+		return ProtoField{}, false
+	}
+
+	return field, true
+}
+
+// protoConvert converts the value of the Go type from to the Go type to
+func protoConvert(to, from, value string) string {
+	if to == from {
+		return value
+	}
+	if strings.HasPrefix(to, "[]") {
+		return "(" + to + ")(" + value + ")"
+	}
+	return to + "(" + value + ")"
+}
+
+// protoGoName returns the name protoc-gen-go gives to the Go field of a
+// protobuf field
+func protoGoName(name string) string {
+	var b []byte
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '_' && i == 0:
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(name) && isASCIILower(name[i+1]):
+			// the underscore is dropped and the next letter capitalized
+		case isASCIIDigit(c):
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(name) && isASCIILower(name[i+1]); i++ {
+				b = append(b, name[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func isASCIILower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
+
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
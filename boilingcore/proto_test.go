@@ -0,0 +1,211 @@
+package boilingcore
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestProtoGoName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"id":          "Id",
+		"pilot_id":    "PilotId",
+		"_private":    "XPrivate",
+		"address2":    "Address2",
+		"line_2":      "Line_2",
+		"createdAt":   "CreatedAt",
+		"HTTP_status": "HTTPStatus",
+	}
+
+	for name, want := range tests {
+		if got := protoGoName(name); got != want {
+			t.Errorf("%s: want %s, got: %s", name, want, got)
+		}
+	}
+}
+
+func TestInitProto(t *testing.T) {
+	t.Parallel()
+
+	tables := []drivers.Table{
+		{
+			Name: "pilots",
+			Columns: []drivers.Column{
+				{Name: "id", Type: "int"},
+				{Name: "name", Type: "string"},
+				{Name: "nick", Type: "null.String", Nullable: true},
+				{Name: "born_at", Type: "null.Time", Nullable: true},
+				{Name: "tags", Type: "types.StringArray"},
+				{Name: "rank", Type: "Rank", DBType: "enum.rank('a','b')"},
+				{Name: "grade", Type: "NullGrade", DBType: "enum.grade('x','y')", Nullable: true},
+				{Name: "salary", Type: "types.Decimal"},
+			},
+		},
+		{
+			Name:        "pilot_jets",
+			IsJoinTable: true,
+			FKeys: []drivers.ForeignKey{
+				{Name: "pilot_jets_pilot_fkey", Table: "pilot_jets", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"},
+				{Name: "pilot_jets_jet_fkey", Table: "pilot_jets", Column: "jet_id", ForeignTable: "jets", ForeignColumn: "id"},
+			},
+		},
+	}
+
+	fields := func(s *State) map[string]ProtoField {
+		m := make(map[string]ProtoField)
+		for _, f := range s.ProtoMessages["pilots"].Fields {
+			m[f.Name] = f
+		}
+		return m
+	}
+
+	s := &State{
+		Config: &Config{PkgName: "models", WithProto: true, AddEnumTypes: true, Proto: Proto{GoPackage: "example.com/pb"}},
+		Tables: tables,
+	}
+	FillAliases(&s.Config.Aliases, tables)
+	if err := s.initProto(); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Config.Proto.Package != "models" || s.Config.Proto.Nullable != "optional" {
+		t.Errorf("want the defaults, got: %#v", s.Config.Proto)
+	}
+	if _, ok := s.ProtoMessages["pilot_jets"]; ok {
+		t.Error("join tables have no message")
+	}
+
+	msg := s.ProtoMessages["pilots"]
+	if msg.Name != "Pilot" || len(msg.Imports) != 1 || msg.Imports[0] != "google/protobuf/timestamp.proto" {
+		t.Errorf("message was wrong: %#v", msg)
+	}
+	if len(msg.Skipped) != 1 || msg.Skipped[0].Name != "salary" {
+		t.Errorf("want salary skipped, got: %v", msg.Skipped)
+	}
+
+	got := fields(s)
+	want := map[string]ProtoField{
+		"id":      {Name: "id", GoName: "Id", Number: 1, Type: "int64", ToProto: "int64(o.ID)", FromProto: "int(m.GetId())"},
+		"name":    {Name: "name", GoName: "Name", Number: 2, Type: "string", ToProto: "o.Name", FromProto: "m.GetName()"},
+		"nick":    {Name: "nick", GoName: "Nick", Number: 3, Type: "string", Optional: true, ToProto: "protoOptionalString(o.Nick.String, o.Nick.Valid)", FromProto: "null.NewString(m.GetNick(), m.Nick != nil)"},
+		"born_at": {Name: "born_at", GoName: "BornAt", Number: 4, Type: "google.protobuf.Timestamp", ToProto: "protoTimestamp(o.BornAt.Time, o.BornAt.Valid)", FromProto: "null.NewTime(m.GetBornAt().AsTime(), m.BornAt != nil)"},
+		"tags":    {Name: "tags", GoName: "Tags", Number: 5, Type: "string", Repeated: true, ToProto: "([]string)(o.Tags)", FromProto: "types.StringArray(m.GetTags())"},
+		"rank":    {Name: "rank", GoName: "Rank", Number: 6, Type: "string", ToProto: "string(o.Rank)", FromProto: "Rank(m.GetRank())"},
+		"grade":   {Name: "grade", GoName: "Grade", Number: 7, Type: "string", Optional: true, ToProto: "protoOptionalString(string(o.Grade.Val), o.Grade.Valid)", FromProto: "NewNullGrade(Grade(m.GetGrade()), m.Grade != nil)"},
+	}
+	for name, w := range want {
+		if got[name] != w {
+			t.Errorf("%s:\nwant %#v\ngot  %#v", name, w, got[name])
+		}
+	}
+
+	s = &State{
+		Config: &Config{PkgName: "models", WithProto: true, Proto: Proto{GoPackage: "example.com/pb", Nullable: "wrappers"}},
+		Tables: tables,
+	}
+	FillAliases(&s.Config.Aliases, tables)
+	if err := s.initProto(); err != nil {
+		t.Fatal(err)
+	}
+	nick := fields(s)["nick"]
+	if nick.Type != "google.protobuf.StringValue" || nick.Optional || nick.ToProto != "protoStringValue(o.Nick.String, o.Nick.Valid)" || nick.FromProto != "null.NewString(m.GetNick().GetValue(), m.Nick != nil)" {
+		t.Errorf("wrapped field was wrong: %#v", nick)
+	}
+	if imps := s.ProtoMessages["pilots"].Imports; len(imps) != 2 {
+		t.Errorf("want the wrappers imported, got: %v", imps)
+	}
+
+	for _, config := range []Config{
+		{WithProto: true},
+		{WithProto: true, Proto: Proto{GoPackage: "example.com/pb", Nullable: "pointers"}},
+	} {
+		config := config
+		s := &State{Config: &config, Tables: tables}
+		if err := s.initProto(); err == nil {
+			t.Errorf("want an error for %#v", config.Proto)
+		}
+	}
+}
+
+func TestInitProtoGRPC(t *testing.T) {
+	t.Parallel()
+
+	tables := []drivers.Table{
+		{
+			Name: "licenses",
+			Columns: []drivers.Column{
+				{Name: "code", Type: "string"},
+				{Name: "pilot_id", Type: "int"},
+				{Name: "issued_at", Type: "time.Time"},
+			},
+			PKey: &drivers.PrimaryKey{Columns: []string{"pilot_id", "code"}},
+		},
+		{
+			Name:    "tags",
+			Columns: []drivers.Column{{Name: "id", Type: "int"}},
+			PKey:    &drivers.PrimaryKey{Columns: []string{"id"}},
+		},
+		{
+			Name:    "prices",
+			Columns: []drivers.Column{{Name: "amount", Type: "types.Decimal"}, {Name: "note", Type: "string"}},
+			PKey:    &drivers.PrimaryKey{Columns: []string{"amount"}},
+		},
+		{
+			Name:    "pilot_names",
+			IsView:  true,
+			Columns: []drivers.Column{{Name: "name", Type: "string"}},
+		},
+	}
+
+	s := &State{
+		Config: &Config{PkgName: "models", WithProto: true, WithGRPC: true, Proto: Proto{GoPackage: "example.com/pb"}},
+		Tables: tables,
+	}
+	FillAliases(&s.Config.Aliases, tables)
+	if err := s.initProto(); err != nil {
+		t.Fatal(err)
+	}
+
+	names := func(fields []ProtoField) []string {
+		var n []string
+		for _, f := range fields {
+			n = append(n, fmt.Sprintf("%s=%d", f.Name, f.Number))
+		}
+		return n
+	}
+
+	licenses := s.ProtoMessages["licenses"]
+	if got := names(licenses.Keys); !reflect.DeepEqual(got, []string{"pilot_id=1", "code=2"}) {
+		t.Errorf("keys were wrong: %v", got)
+	}
+	if got := names(licenses.Updates); !reflect.DeepEqual(got, []string{"issued_at=3"}) {
+		t.Errorf("updates were wrong: %v", got)
+	}
+	if want := []string{"google/protobuf/field_mask.proto", "google/protobuf/timestamp.proto"}; !reflect.DeepEqual(licenses.Imports, want) {
+		t.Errorf("want imports %v, got: %v", want, licenses.Imports)
+	}
+
+	// A table of only its primary key has nothing to update
+	if tags := s.ProtoMessages["tags"]; len(tags.Keys) != 1 || tags.Updates != nil || tags.Imports != nil {
+		t.Errorf("tags message was wrong: %#v", tags)
+	}
+	// Rows with a primary key that has no field are only listed and created
+	if prices := s.ProtoMessages["prices"]; prices.Keys != nil || prices.Updates != nil {
+		t.Errorf("prices message was wrong: %#v", prices)
+	}
+	if view := s.ProtoMessages["pilot_names"]; view.Keys != nil || view.Updates != nil {
+		t.Errorf("view message was wrong: %#v", view)
+	}
+	if _, ok := s.Config.Imports.Singleton[grpcTemplate]; !ok {
+		t.Error("gRPC imports are not set")
+	}
+
+	s = &State{Config: &Config{PkgName: "models", WithGRPC: true}, Tables: tables}
+	if err := s.initProto(); err == nil {
+		t.Error("want an error without the protobuf messages")
+	}
+}
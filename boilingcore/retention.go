@@ -0,0 +1,118 @@
+package boilingcore
+
+import (
+	"strings"
+
+	"github.com/friendsofgo/errors"
+
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// retentionTemplate is the singleton with the purge and archive helpers of
+// the retained tables, it names its entry in the singleton imports
+const retentionTemplate = "boil_retention"
+
+// retentionTypes are the types of the columns the age of the rows is read from
+var retentionTypes = map[string]bool{
+	"time.Time": true,
+	"null.Time": true,
+}
+
+// RetentionTable is a table whose old rows are purged or archived by the age
+// of a timestamp column
+type RetentionTable struct {
+	Name   string
+	Column string
+}
+
+// initRetention finds the timestamp columns of the retained tables from the
+// retention config and sets the imports of their helpers
+func (s *State) initRetention() error {
+	if len(s.Config.Retention.Columns) == 0 {
+		return nil
+	}
+
+	// columns has the retention column by table, the column of every table
+	// that has it is the one of the empty string
+	columns := make(map[string]string)
+	for _, name := range s.Config.Retention.Columns {
+		var table, column string
+		if i := strings.LastIndexByte(name, '.'); i >= 0 {
+			table, column = name[:i], name[i+1:]
+		} else {
+			column = name
+		}
+		if prev, ok := columns[table]; ok && prev != column {
+			return errors.Errorf("retention columns %s and %s are for the same tables, want one", prev, column)
+		}
+		columns[table] = column
+	}
+
+	found := make(map[string]bool)
+	s.RetentionTables = nil
+	for _, t := range s.Tables {
+		column, named := columns[t.Name]
+		if !named {
+			// Views and join tables are only retained when they are named,
+			// which is an error below
+			if t.IsView || t.IsJoinTable {
+				continue
+			}
+			column = columns[""]
+		}
+
+		var typ string
+		for _, c := range t.Columns {
+			if c.Name == column {
+				typ = c.Type
+			}
+		}
+		if len(typ) == 0 {
+			continue
+		}
+		if named {
+			found[t.Name+"."+column] = true
+		} else {
+			found[column] = true
+		}
+
+		if t.IsView || t.IsJoinTable || t.PKey == nil {
+			return errors.Errorf("retention column %s.%s must be in a table with a primary key, not a view or join table", t.Name, column)
+		}
+		if !retentionTypes[typ] {
+			return errors.Errorf("retention column %s.%s is of type %s, want a timestamp", t.Name, column, typ)
+		}
+
+		s.RetentionTables = append(s.RetentionTables, RetentionTable{Name: t.Name, Column: column})
+	}
+
+	for table, column := range columns {
+		name := column
+		if len(table) != 0 {
+			name = table + "." + column
+		}
+		if !found[name] {
+			return errors.Errorf("retention column %s was not found", name)
+		}
+	}
+
+	imps := importers.Set{
+		Standard: importers.List{`"time"`},
+		ThirdParty: importers.List{
+			`"github.com/friendsofgo/errors"`,
+			`"github.com/volatiletech/sqlboiler/v4/boil"`,
+			`"github.com/volatiletech/sqlboiler/v4/queries"`,
+			`"github.com/volatiletech/sqlboiler/v4/queries/qm"`,
+			`"github.com/volatiletech/strmangle"`,
+		},
+	}
+	if !s.Config.NoContext {
+		imps.Standard = append(imps.Standard, `"context"`)
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[retentionTemplate] = imps
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package boilingcore
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInitRetention(t *testing.T) {
+	t.Parallel()
+
+	s := testState(Config{
+		PkgName:   "models",
+		Retention: Retention{Columns: []string{"created_at", "events.handled_at"}},
+	})
+	if err := s.initRetention(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []RetentionTable{
+		{Name: "events", Column: "handled_at"},
+		{Name: "logs", Column: "created_at"},
+	}
+	if !reflect.DeepEqual(s.RetentionTables, want) {
+		t.Errorf("want: %#v\ngot: %#v", want, s.RetentionTables)
+	}
+	if _, ok := s.Config.Imports.Singleton[retentionTemplate]; !ok {
+		t.Error("retention imports are not set")
+	}
+}
+
+func TestInitRetentionErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		columns []string
+		err     string
+	}{
+		{[]string{"events.deleted_at"}, "events.deleted_at was not found"},
+		{[]string{"deleted_at"}, "deleted_at was not found"},
+		{[]string{"events.name"}, "want a timestamp"},
+		{[]string{"recent_events.created_at"}, "not a view or join table"},
+		{[]string{"events.created_at", "events.handled_at"}, "want one"},
+	}
+
+	for _, test := range tests {
+		s := testState(Config{PkgName: "models", Retention: Retention{Columns: test.columns}})
+
+		err := s.initRetention()
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%v: want error containing %q, got: %v", test.columns, test.err, err)
+		}
+	}
+}
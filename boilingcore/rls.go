@@ -0,0 +1,57 @@
+package boilingcore
+
+import (
+	"github.com/friendsofgo/errors"
+
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// rlsTemplate is the singleton with the helpers setting the session variable
+// read by row level security policies, it names its entry in the singleton
+// imports
+const rlsTemplate = "boil_rls"
+
+// RLS lists the tables with row level security enabled whose policies read
+// the setting
+type RLS struct {
+	// Setting is the session variable set for the policies, app.current_user
+	// for example
+	Setting string
+	// Tables have row level security enabled
+	Tables []string
+}
+
+// initRLS finds the tables with row level security enabled and sets the
+// imports of the helpers setting the session variable
+func (s *State) initRLS() error {
+	if len(s.Config.RLSSetting) == 0 {
+		return nil
+	}
+
+	s.RLS = RLS{Setting: s.Config.RLSSetting}
+	for _, t := range s.Tables {
+		if t.RowSecurity != nil {
+			s.RLS.Tables = append(s.RLS.Tables, t.Name)
+		}
+	}
+	if len(s.RLS.Tables) == 0 {
+		return errors.Errorf("no table has row level security enabled for the setting %s", s.Config.RLSSetting)
+	}
+
+	imps := importers.Set{
+		Standard: importers.List{`"database/sql"`},
+		ThirdParty: importers.List{
+			`"github.com/friendsofgo/errors"`,
+			`"github.com/volatiletech/sqlboiler/v4/boil"`,
+		},
+	}
+	if !s.Config.NoContext {
+		imps.Standard = append(imps.Standard, `"context"`)
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[rlsTemplate] = imps
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package boilingcore
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestInitRLS(t *testing.T) {
+	t.Parallel()
+
+	tables := []drivers.Table{
+		{Name: "accounts", RowSecurity: &drivers.RowSecurity{}},
+		{Name: "plans"},
+	}
+
+	tests := []struct {
+		name    string
+		config  Config
+		tables  []drivers.Table
+		want    []string
+		wantErr bool
+	}{
+		{name: "disabled", config: Config{}, tables: tables},
+		{name: "setting", config: Config{RLSSetting: "app.current_user"}, tables: tables, want: []string{"accounts"}},
+		{name: "no row security", config: Config{RLSSetting: "app.current_user"}, tables: tables[1:], wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			s := &State{Config: &tt.config, Tables: tt.tables}
+			err := s.initRLS()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %t, got: %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(s.RLS.Tables, tt.want) {
+				t.Errorf("want row security tables %v, got: %v", tt.want, s.RLS.Tables)
+			}
+			if s.RLS.Setting != tt.config.RLSSetting {
+				t.Errorf("want setting %q, got: %q", tt.config.RLSSetting, s.RLS.Setting)
+			}
+			_, ok := s.Config.Imports.Singleton[rlsTemplate]
+			if ok != (len(tt.want) != 0) {
+				t.Errorf("want imports for the row security helpers %t, got: %t", len(tt.want) != 0, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package boilingcore
+
+import (
+	"reflect"
+
+	"github.com/friendsofgo/errors"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// Version is the version of sqlboiler, it is stamped in the generated code
+const Version = "4.14.2"
+
+// Run generates the code of the config and returns the files it changed, it
+// is the entry point of the programs driving sqlboiler. The driver of the
+// config must be registered, by importing a driver package or with
+// drivers.RegisterBinary.
+//
+// The version, package name, output folder, imports, struct tag casing,
+// relationship tag and enum null prefix default to the ones of the command
+// line, and the driver config gets the options of the config it shares.
+func Run(config Config) ([]string, error) {
+	if _, ok := drivers.LookupDriver(config.DriverName); !ok {
+		return nil, errors.Errorf("driver %q has not been registered", config.DriverName)
+	}
+
+	if len(config.Version) == 0 {
+		config.Version = Version
+	}
+	if len(config.PkgName) == 0 {
+		config.PkgName = "models"
+	}
+	if len(config.OutFolder) == 0 {
+		config.OutFolder = "models"
+	}
+	if reflect.DeepEqual(config.Imports, importers.Collection{}) {
+		config.Imports = importers.NewDefaultImports()
+	}
+	if len(config.StructTagCasing) == 0 {
+		config.StructTagCasing = "snake"
+	}
+	if len(config.RelationTag) == 0 {
+		config.RelationTag = "-"
+	}
+	if len(config.EnumNullPrefix) == 0 {
+		config.EnumNullPrefix = "Null"
+	}
+
+	config.DriverConfig.AddEnumTypes = config.AddEnumTypes
+	config.DriverConfig.EnumNullPrefix = config.EnumNullPrefix
+	config.DriverConfig.AddFunctions = config.AddFunctions
+
+	state, err := New(&config)
+	if err != nil {
+		return nil, err
+	}
+	if err := state.Run(); err != nil {
+		return nil, err
+	}
+	if err := state.Cleanup(); err != nil {
+		return nil, err
+	}
+
+	return state.Changed(), nil
+}
+
+// RunInMemory generates the code of the config like Run, into memory instead
+// of the disk, and returns the contents of the files by their slash separated
+// paths, the output folder joined with the name of the file
+func RunInMemory(config Config) (map[string][]byte, error) {
+	fsys := NewMemFS()
+	config.OutputFS = fsys
+
+	if _, err := Run(config); err != nil {
+		return nil, err
+	}
+
+	return fsys.Files(), nil
+}
@@ -0,0 +1,53 @@
+package boilingcore
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	config := Config{
+		DriverName:   "mock",
+		OutFolder:    t.TempDir(),
+		NoTests:      true,
+		Quiet:        true,
+		DriverConfig: drivers.Config{Schema: "schema"},
+	}
+
+	changed, err := Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) == 0 {
+		t.Fatal("no files were generated")
+	}
+
+	b, err := os.ReadFile(filepath.Join(config.OutFolder, "pilots.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header := "// Code generated by SQLBoiler " + Version + " "; !strings.HasPrefix(string(b), header) {
+		t.Errorf("want the header to start with %q, got: %.100s", header, b)
+	}
+	if !strings.Contains(string(b), "package models\n") {
+		t.Error("the package name did not default to models")
+	}
+
+	changed, err = Run(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 0 {
+		t.Errorf("want no changed files, got: %v", changed)
+	}
+
+	if _, err := Run(Config{DriverName: "unregistered"}); err == nil || !strings.Contains(err.Error(), "has not been registered") {
+		t.Errorf("want an unregistered driver error, got: %v", err)
+	}
+}
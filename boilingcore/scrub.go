@@ -0,0 +1,241 @@
+package boilingcore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+	"github.com/volatiletech/sqlboiler/v4/scrub"
+)
+
+// scrubTemplate is the singleton with the Scrub methods, it names its entry
+// in the singleton imports
+const scrubTemplate = "boil_scrub"
+
+// scrubKinds are the kinds of fakes of columns named like the keys, the first
+// key a column name contains wins
+var scrubKinds = []struct{ Name, Kind string }{
+	{"email", scrub.Email},
+	{"first_name", scrub.FirstName},
+	{"firstname", scrub.FirstName},
+	{"given_name", scrub.FirstName},
+	{"last_name", scrub.LastName},
+	{"lastname", scrub.LastName},
+	{"family_name", scrub.LastName},
+	{"surname", scrub.LastName},
+	{"username", scrub.Username},
+	{"user_name", scrub.Username},
+	{"login", scrub.Username},
+	{"name", scrub.Name},
+	{"phone", scrub.Phone},
+	{"mobile", scrub.Phone},
+	{"fax", scrub.Phone},
+	{"address", scrub.Address},
+	{"street", scrub.Address},
+	{"city", scrub.City},
+	{"postal", scrub.PostalCode},
+	{"postcode", scrub.PostalCode},
+	{"zip", scrub.PostalCode},
+	{"url", scrub.URL},
+	{"website", scrub.URL},
+}
+
+// ScrubTable is a table with sensitive columns
+type ScrubTable struct {
+	Name    string
+	Columns []ScrubColumn
+}
+
+// ScrubColumn is a sensitive column
+type ScrubColumn struct {
+	Name string
+	Kind string
+	// Scrub overwrites the column of the model o with a fake
+	Scrub string
+}
+
+// initScrub builds the sensitive columns of the tables from the scrub config
+// and sets the imports of the Scrub methods
+func (s *State) initScrub() error {
+	if len(s.Config.Scrub.Columns) == 0 {
+		return nil
+	}
+
+	// kinds has the kinds of the sensitive columns by table, the tables of
+	// the columns without a table are the empty string
+	kinds := make(map[string]map[string]string)
+	for _, v := range s.Config.Scrub.Columns {
+		name, kind := v, ""
+		if i := strings.IndexByte(v, ':'); i >= 0 {
+			name, kind = v[:i], v[i+1:]
+			if !scrub.IsKind(kind) {
+				return errors.Errorf("scrubbed column %s has an unknown kind of fake %q, want one of: %s", name, kind, strings.Join(scrub.Kinds, ", "))
+			}
+		}
+
+		var table, column string
+		if i := strings.LastIndexByte(name, '.'); i >= 0 {
+			table, column = name[:i], name[i+1:]
+		} else {
+			column = name
+		}
+		if kinds[table] == nil {
+			kinds[table] = make(map[string]string)
+		}
+		kinds[table][column] = kind
+	}
+
+	found := make(map[string]bool)
+	s.ScrubTables = nil
+	for _, t := range s.Tables {
+		// Columns of views and join tables are only scrubbed when they are
+		// named with their table, which is an error below
+		if _, ok := kinds[t.Name]; !ok && (t.IsView || t.IsJoinTable) {
+			continue
+		}
+
+		alias := s.Config.Aliases.Table(t.Name)
+		table := ScrubTable{Name: t.Name}
+		for _, c := range t.Columns {
+			kind, ok := kinds[t.Name][c.Name]
+			if ok {
+				found[t.Name+"."+c.Name] = true
+			} else if kind, ok = kinds[""][c.Name]; ok {
+				found[c.Name] = true
+			} else {
+				continue
+			}
+
+			if t.IsView || t.IsJoinTable {
+				return errors.Errorf("scrubbed column %s.%s must be in a table with a model, not a view or join table", t.Name, c.Name)
+			}
+			if scrubIsKey(t, c.Name) {
+				return errors.Errorf("scrubbed column %s.%s cannot be part of the primary key or a foreign key", t.Name, c.Name)
+			}
+
+			col, err := scrubColumn(c, alias.Column(c.Name), kind)
+			if err != nil {
+				return errors.Wrapf(err, "unable to scrub column %s.%s", t.Name, c.Name)
+			}
+			table.Columns = append(table.Columns, col)
+		}
+
+		if len(table.Columns) != 0 {
+			s.ScrubTables = append(s.ScrubTables, table)
+		}
+	}
+
+	for table, columns := range kinds {
+		for column := range columns {
+			name := column
+			if len(table) != 0 {
+				name = table + "." + column
+			}
+			if !found[name] {
+				return errors.Errorf("scrubbed column %s was not found", name)
+			}
+		}
+	}
+
+	imps := importers.Set{
+		ThirdParty: importers.List{
+			`"github.com/friendsofgo/errors"`,
+			`"github.com/volatiletech/sqlboiler/v4/boil"`,
+			`"github.com/volatiletech/sqlboiler/v4/queries/qm"`,
+			`"github.com/volatiletech/sqlboiler/v4/scrub"`,
+		},
+	}
+	if !s.Config.NoContext {
+		imps.Standard = append(imps.Standard, `"context"`)
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[scrubTemplate] = imps
+
+	return nil
+}
+
+// scrubColumn returns the overwriting of the column with a fake of the kind,
+// the kind is inferred from the name of the column when it is empty
+func scrubColumn(c drivers.Column, goField, kind string) (ScrubColumn, error) {
+	o := "o." + goField
+	col := ScrubColumn{Name: c.Name}
+
+	typ := c.Type
+	if plain, ok := encryptedPlainTypes[c.Type]; ok {
+		typ = plain
+	}
+
+	switch typ {
+	case "string", "null.String":
+		if len(kind) == 0 {
+			kind = scrubKind(c.Name)
+		}
+		if typ == "null.String" {
+			o += ".String"
+		}
+		fn := "String"
+		if c.Unique {
+			fn = "Unique"
+		}
+		col.Scrub = fmt.Sprintf("%s = scrub.%s(%q, %s, %d)", o, fn, kind, o, columnMaxLength(c))
+		if c.Type == "EncryptedString" {
+			col.Scrub = fmt.Sprintf("%s = EncryptedString(scrub.%s(%q, string(%s), %d))", o, fn, kind, o, columnMaxLength(c))
+		}
+	case "time.Time", "null.Time":
+		if c.Type == "null.Time" {
+			o += ".Time"
+		}
+		col.Scrub = fmt.Sprintf("%s = scrub.Time(%s)", o, o)
+	case "[]byte", "null.Bytes":
+		if typ == "null.Bytes" {
+			o += ".Bytes"
+		}
+		col.Scrub = fmt.Sprintf("%s = scrub.Bytes(%s)", o, o)
+	default:
+		return ScrubColumn{}, errors.Errorf("columns of type %s cannot be scrubbed", c.Type)
+	}
+
+	if len(kind) != 0 && typ != "string" && typ != "null.String" {
+		return ScrubColumn{}, errors.Errorf("kinds of fakes are for strings, not %s", c.Type)
+	}
+	col.Kind = kind
+
+	return col, nil
+}
+
+// scrubKind infers the kind of fake of a string column from its name
+func scrubKind(column string) string {
+	name := strings.ToLower(column)
+	if name == "ip" || strings.HasSuffix(name, "_ip") || strings.Contains(name, "ip_address") {
+		return scrub.IP
+	}
+	for _, k := range scrubKinds {
+		if strings.Contains(name, k.Name) {
+			return k.Kind
+		}
+	}
+	return scrub.Text
+}
+
+// scrubIsKey checks if the column is part of the primary key or a foreign key,
+// the rows are updated by their primary key and fakes would break the others
+func scrubIsKey(t drivers.Table, column string) bool {
+	if t.PKey != nil {
+		for _, c := range t.PKey.Columns {
+			if c == column {
+				return true
+			}
+		}
+	}
+	for _, fk := range t.FKeys {
+		if fk.Column == column {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package boilingcore
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInitScrub(t *testing.T) {
+	t.Parallel()
+
+	s := testState(Config{
+		PkgName: "models",
+		Scrub:   Scrub{Columns: []string{"email", "users.full_name", "users.ip_address", "users.born_at", "users.avatar", "teams.email:text"}},
+	})
+	if err := s.initScrub(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ScrubTable{
+		{Name: "users", Columns: []ScrubColumn{
+			{Name: "email", Kind: "email", Scrub: `o.Email = scrub.Unique("email", o.Email, 100)`},
+			{Name: "full_name", Kind: "name", Scrub: `o.FullName.String = scrub.String("name", o.FullName.String, 0)`},
+			{Name: "ip_address", Kind: "ip", Scrub: `o.IPAddress = scrub.String("ip", o.IPAddress, 0)`},
+			{Name: "born_at", Scrub: `o.BornAt.Time = scrub.Time(o.BornAt.Time)`},
+			{Name: "avatar", Scrub: `o.Avatar = scrub.Bytes(o.Avatar)`},
+		}},
+		{Name: "teams", Columns: []ScrubColumn{
+			{Name: "email", Kind: "text", Scrub: `o.Email = scrub.String("text", o.Email, 0)`},
+		}},
+	}
+	if !reflect.DeepEqual(s.ScrubTables, want) {
+		t.Errorf("want: %#v\ngot: %#v", want, s.ScrubTables)
+	}
+	if _, ok := s.Config.Imports.Singleton[scrubTemplate]; !ok {
+		t.Error("scrub imports are not set")
+	}
+}
+
+func TestInitScrubErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		columns []string
+		err     string
+	}{
+		{[]string{"users.email:secret"}, "unknown kind of fake"},
+		{[]string{"users.password"}, "users.password was not found"},
+		{[]string{"passcode"}, "passcode was not found"},
+		{[]string{"users.id"}, "primary key or a foreign key"},
+		{[]string{"users.team_id"}, "primary key or a foreign key"},
+		{[]string{"users.visits"}, "type int cannot be scrubbed"},
+		{[]string{"users.born_at:name"}, "kinds of fakes are for strings"},
+		{[]string{"user_emails.email"}, "not a view or join table"},
+	}
+
+	for _, test := range tests {
+		s := testState(Config{PkgName: "models", Scrub: Scrub{Columns: test.columns}})
+
+		err := s.initScrub()
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%v: want error containing %q, got: %v", test.columns, test.err, err)
+		}
+	}
+}
+
+func TestScrubKind(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"email_address": "email",
+		"FirstName":     "first_name",
+		"surname":       "last_name",
+		"username":      "username",
+		"display_name":  "name",
+		"mobile_phone":  "phone",
+		"ip_address":    "ip",
+		"last_login_ip": "ip",
+		"remote_ip":     "ip",
+		"street":        "address",
+		"zip_code":      "postal_code",
+		"website":       "url",
+		"notes":         "text",
+	}
+	for column, want := range tests {
+		if got := scrubKind(column); got != want {
+			t.Errorf("%s: want %s, got %s", column, want, got)
+		}
+	}
+}
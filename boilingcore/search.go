@@ -0,0 +1,48 @@
+package boilingcore
+
+import (
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// searchTemplate is the singleton with the full text search helpers, it names
+// its entry in the singleton imports
+const searchTemplate = "boil_search"
+
+// SearchTable is a table with postgres tsvector columns
+type SearchTable struct {
+	Name    string
+	Columns []string
+}
+
+// initSearch finds the tsvector columns of the tables and sets the imports of
+// their full text search helpers
+func (s *State) initSearch() error {
+	s.SearchTables = nil
+	for _, t := range s.Tables {
+		if t.IsJoinTable {
+			continue
+		}
+
+		table := SearchTable{Name: t.Name}
+		for _, c := range t.Columns {
+			if c.DBType == "tsvector" {
+				table.Columns = append(table.Columns, c.Name)
+			}
+		}
+		if len(table.Columns) != 0 {
+			s.SearchTables = append(s.SearchTables, table)
+		}
+	}
+	if len(s.SearchTables) == 0 {
+		return nil
+	}
+
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[searchTemplate] = importers.Set{
+		ThirdParty: importers.List{`"github.com/volatiletech/sqlboiler/v4/queries/qm"`},
+	}
+
+	return nil
+}
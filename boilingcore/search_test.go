@@ -0,0 +1,47 @@
+package boilingcore
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestInitSearch(t *testing.T) {
+	t.Parallel()
+
+	s := &State{
+		Config: &Config{PkgName: "models"},
+		Tables: []drivers.Table{
+			{
+				Name: "documents",
+				Columns: []drivers.Column{
+					{Name: "id", Type: "int", DBType: "integer"},
+					{Name: "title", Type: "string", DBType: "text"},
+					{Name: "title_search", Type: "string", DBType: "tsvector"},
+					{Name: "body_search", Type: "null.String", DBType: "tsvector", Nullable: true},
+				},
+			},
+			{
+				Name:    "tags",
+				Columns: []drivers.Column{{Name: "id", Type: "int", DBType: "integer"}},
+			},
+			{
+				Name:        "document_tags",
+				IsJoinTable: true,
+				Columns:     []drivers.Column{{Name: "search", Type: "string", DBType: "tsvector"}},
+			},
+		},
+	}
+	if err := s.initSearch(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []SearchTable{{Name: "documents", Columns: []string{"title_search", "body_search"}}}
+	if !reflect.DeepEqual(s.SearchTables, want) {
+		t.Errorf("want: %#v\ngot: %#v", want, s.SearchTables)
+	}
+	if _, ok := s.Config.Imports.Singleton[searchTemplate]; !ok {
+		t.Error("search imports are not set")
+	}
+}
@@ -0,0 +1,118 @@
+package boilingcore
+
+import (
+	"github.com/volatiletech/strmangle"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// seedsTemplate is the singleton with the seed loader, it names its entry in
+// the singleton imports
+const seedsTemplate = "boil_seeds"
+
+// SeedTable is a table the seed loader seeds
+type SeedTable struct {
+	Name string
+	// Keys are the keys of the rows of the seed files, the json tags of the
+	// model, with their columns
+	Keys []SeedKey
+}
+
+// SeedKey is a key of the rows of a seed file
+type SeedKey struct {
+	Key    string
+	Column string
+}
+
+// initSeeds orders the tables of the seed loader and sets its imports
+func (s *State) initSeeds() error {
+	if !s.Config.AddSeeds {
+		return nil
+	}
+
+	ignore := make(map[string]struct{})
+	for _, v := range s.Config.TagIgnore {
+		ignore[v] = struct{}{}
+	}
+
+	s.SeedTables = nil
+	for _, t := range seedOrder(s.Tables) {
+		alias := s.Config.Aliases.Table(t.Name)
+		table := SeedTable{Name: t.Name}
+		for _, c := range t.Columns {
+			if strmangle.Ignore(t.Name, c.Name, ignore) {
+				continue
+			}
+			table.Keys = append(table.Keys, SeedKey{
+				Key:    jsonTagName(s.Config.StructTagCasing, c.Name, alias.Column(c.Name)),
+				Column: c.Name,
+			})
+		}
+		s.SeedTables = append(s.SeedTables, table)
+	}
+
+	imps := importers.Set{
+		Standard:   importers.List{`"encoding/json"`, `"io/fs"`, `"os"`, `"sort"`},
+		ThirdParty: importers.List{`"github.com/friendsofgo/errors"`, `"github.com/volatiletech/sqlboiler/v4/boil"`, `"github.com/volatiletech/strmangle"`, `"gopkg.in/yaml.v3"`},
+	}
+	if !s.Config.NoContext {
+		imps.Standard = append(imps.Standard, `"context"`)
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[seedsTemplate] = imps
+
+	return nil
+}
+
+// seedOrder returns the tables with models ordered so the tables foreign keys
+// refer to come before the tables of the foreign keys. The tables of a cycle
+// of foreign keys keep their order after the others.
+func seedOrder(tables []drivers.Table) []drivers.Table {
+	var seeded []drivers.Table
+	names := make(map[string]bool)
+	for _, t := range tables {
+		if t.IsView || t.IsJoinTable {
+			continue
+		}
+		seeded = append(seeded, t)
+		names[t.Name] = true
+	}
+
+	done := make(map[string]bool)
+	order := make([]drivers.Table, 0, len(seeded))
+	for len(order) < len(seeded) {
+		progress := false
+		for _, t := range seeded {
+			if done[t.Name] || !seedParentsDone(t, names, done) {
+				continue
+			}
+			done[t.Name] = true
+			order = append(order, t)
+			progress = true
+		}
+
+		if !progress {
+			for _, t := range seeded {
+				if !done[t.Name] {
+					order = append(order, t)
+				}
+			}
+		}
+	}
+
+	return order
+}
+
+// seedParentsDone reports whether the tables the foreign keys of the table
+// refer to are done, other than itself and the tables that are not seeded
+func seedParentsDone(t drivers.Table, names, done map[string]bool) bool {
+	for _, fk := range t.FKeys {
+		if fk.ForeignTable != t.Name && names[fk.ForeignTable] && !done[fk.ForeignTable] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package boilingcore
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestSeedOrder(t *testing.T) {
+	t.Parallel()
+
+	fkey := func(table, foreignTable string) drivers.ForeignKey {
+		return drivers.ForeignKey{Table: table, Column: foreignTable + "_id", ForeignTable: foreignTable, ForeignColumn: "id"}
+	}
+
+	tables := []drivers.Table{
+		{Name: "licenses", FKeys: []drivers.ForeignKey{fkey("licenses", "pilots")}},
+		{Name: "jets", FKeys: []drivers.ForeignKey{fkey("jets", "pilots"), fkey("jets", "airports")}},
+		{Name: "pilots", FKeys: []drivers.ForeignKey{fkey("pilots", "pilots"), fkey("pilots", "schema_versions")}},
+		{Name: "airports"},
+		{Name: "pilot_languages", IsJoinTable: true, FKeys: []drivers.ForeignKey{fkey("pilot_languages", "pilots")}},
+		{Name: "jet_view", IsView: true},
+		{Name: "hangars", FKeys: []drivers.ForeignKey{fkey("hangars", "crews")}},
+		{Name: "crews", FKeys: []drivers.ForeignKey{fkey("crews", "hangars")}},
+	}
+
+	var got []string
+	for _, t := range seedOrder(tables) {
+		got = append(got, t.Name)
+	}
+
+	// Self references and tables that are not seeded do not hold tables back,
+	// and the cycle of hangars and crews keeps its order at the end
+	want := []string{"pilots", "airports", "licenses", "jets", "hangars", "crews"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestInitSeeds(t *testing.T) {
+	t.Parallel()
+
+	tables := []drivers.Table{
+		{
+			Name: "jets",
+			Columns: []drivers.Column{
+				{Name: "id", Type: "int"},
+				{Name: "pilot_id", Type: "int"},
+				{Name: "secret", Type: "string"},
+			},
+			FKeys: []drivers.ForeignKey{
+				{Name: "jets_pilot_fkey", Table: "jets", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"},
+			},
+		},
+		{
+			Name:    "pilots",
+			Columns: []drivers.Column{{Name: "id", Type: "int"}, {Name: "first_name", Type: "string"}},
+		},
+	}
+
+	s := &State{
+		Config: &Config{
+			PkgName:         "models",
+			AddSeeds:        true,
+			NoContext:       true,
+			StructTagCasing: "camel",
+			TagIgnore:       []string{"jets.secret"},
+		},
+		Tables: tables,
+	}
+	FillAliases(&s.Config.Aliases, tables)
+	if err := s.initSeeds(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []SeedTable{
+		{Name: "pilots", Keys: []SeedKey{{Key: "id", Column: "id"}, {Key: "firstName", Column: "first_name"}}},
+		{Name: "jets", Keys: []SeedKey{{Key: "id", Column: "id"}, {Key: "pilotID", Column: "pilot_id"}}},
+	}
+	if !reflect.DeepEqual(s.SeedTables, want) {
+		t.Errorf("want: %#v, got: %#v", want, s.SeedTables)
+	}
+
+	imps := s.Config.Imports.Singleton[seedsTemplate]
+	for _, imp := range imps.Standard {
+		if imp == `"context"` {
+			t.Error("context is imported without context")
+		}
+	}
+}
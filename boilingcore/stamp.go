@@ -0,0 +1,268 @@
+package boilingcore
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+// stampPrefix starts the line of the header of the generated Go files with
+// their stamp
+const stampPrefix = "// SQLBoiler stamp:"
+
+// stampDevelVersion is the version of the stamps of the generators without
+// a version, like the ones of the tests
+const stampDevelVersion = "devel"
+
+// Stamp identifies the generator and the inputs of the generated code, it is
+// written in the header of every generated Go file and compared by Check
+type Stamp struct {
+	Version string
+	Driver  string
+	// Hash is the start of the sha256 of the effective config and of the
+	// schema snapshot the code was generated from
+	Hash string
+}
+
+// String formats the stamp as the line of the header
+func (s Stamp) String() string {
+	return fmt.Sprintf("%s version=%s driver=%s hash=%s", stampPrefix, s.Version, s.Driver, s.Hash)
+}
+
+// ParseStamp parses the stamp of a line of a header, it returns false if the
+// line is not a stamp
+func ParseStamp(line string) (Stamp, bool) {
+	if !strings.HasPrefix(line, stampPrefix) {
+		return Stamp{}, false
+	}
+
+	var stamp Stamp
+	for _, field := range strings.Fields(strings.TrimPrefix(line, stampPrefix)) {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "version":
+			stamp.Version = kv[1]
+		case "driver":
+			stamp.Driver = kv[1]
+		case "hash":
+			stamp.Hash = kv[1]
+		}
+	}
+
+	return stamp, true
+}
+
+// initStamp stamps the state with the version, the driver and the hash of its
+// config and schema. The output folder, debugging, wiping and the driver
+// config, whose effect is the schema, are left out of the hash, and so are
+// the fields left at their zero value, so adding a field to the config or the
+// schema does not change the stamp of the code generated without it.
+func (s *State) initStamp() error {
+	config := *s.Config
+	config.DriverConfig = drivers.Config{}
+	config.OutFolder = ""
+	config.Debug = false
+	config.Wipe = false
+	config.Version = ""
+	config.Quiet = false
+	config.SchemaSnapshot = ""
+
+	hash, err := stampHash(struct {
+		Config    *Config            `json:"config"`
+		Schema    string             `json:"schema"`
+		Dialect   drivers.Dialect    `json:"dialect"`
+		Tables    []drivers.Table    `json:"tables"`
+		Functions []drivers.Function `json:"functions"`
+	}{
+		Config:    &config,
+		Schema:    s.Schema,
+		Dialect:   s.Dialect,
+		Tables:    s.Tables,
+		Functions: s.functions,
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to hash the config and schema")
+	}
+
+	s.Stamp = Stamp{
+		Version: s.Config.Version,
+		Driver:  s.Config.DriverName,
+		Hash:    hash,
+	}
+	if len(s.Stamp.Version) == 0 {
+		s.Stamp.Version = stampDevelVersion
+	}
+
+	return nil
+}
+
+// stampHash returns the start of the sha256 of the JSON of v without the
+// fields at their zero value
+func stampHash(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var tree interface{}
+	if err := dec.Decode(&tree); err != nil {
+		return "", err
+	}
+
+	// Objects are marshaled with their keys sorted
+	if b, err = json.Marshal(pruneZero(tree)); err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// pruneZero removes the zero values from the objects of a decoded JSON tree,
+// it returns nil if nothing is left of v. The elements of arrays are kept so
+// their positions do not change.
+func pruneZero(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if val = pruneZero(val); val == nil {
+				delete(v, k)
+			} else {
+				v[k] = val
+			}
+		}
+		if len(v) == 0 {
+			return nil
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+		for i, val := range v {
+			v[i] = pruneZero(val)
+		}
+	case bool:
+		if !v {
+			return nil
+		}
+	case string:
+		if len(v) == 0 {
+			return nil
+		}
+	case json.Number:
+		if f, err := v.Float64(); err == nil && f == 0 {
+			return nil
+		}
+	}
+
+	return v
+}
+
+// Check compares the stamps of the generated Go files in the output folders
+// with the stamp of the state. It returns a problem per file generated by
+// another version, driver, config or schema, and one per output folder
+// without generated files.
+func (s *State) Check() ([]string, error) {
+	if len(s.packages) == 0 {
+		return s.check()
+	}
+
+	var problems []string
+	for _, p := range s.packages {
+		pkgProblems, err := p.check()
+		if err != nil {
+			return nil, errors.Wrapf(err, "package %s", p.Config.PkgName)
+		}
+		problems = append(problems, pkgProblems...)
+	}
+
+	return problems, nil
+}
+
+// check compares the stamps of the generated Go files of the state's output
+// folder
+func (s *State) check() ([]string, error) {
+	var problems []string
+	generated := 0
+
+	err := filepath.WalkDir(s.Config.OutFolder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".go" {
+			return nil
+		}
+
+		isGenerated, stamp, found, err := readStamp(path)
+		if err != nil {
+			return err
+		}
+		if !isGenerated {
+			return nil
+		}
+		generated++
+
+		switch {
+		case !found:
+			problems = append(problems, fmt.Sprintf("%s: has no stamp, it was generated by an older version", path))
+		case stamp.Version != s.Stamp.Version:
+			problems = append(problems, fmt.Sprintf("%s: generated by version %s, not %s", path, stamp.Version, s.Stamp.Version))
+		case stamp.Driver != s.Stamp.Driver:
+			problems = append(problems, fmt.Sprintf("%s: generated by driver %s, not %s", path, stamp.Driver, s.Stamp.Driver))
+		case stamp.Hash != s.Stamp.Hash:
+			problems = append(problems, fmt.Sprintf("%s: generated from another config or schema", path))
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "unable to check the output folder %s", s.Config.OutFolder)
+	}
+
+	if generated == 0 {
+		problems = append(problems, fmt.Sprintf("%s: has no generated files", s.Config.OutFolder))
+	}
+
+	return problems, nil
+}
+
+// readStamp reads the header of a Go file, it returns if the file was
+// generated by sqlboiler and its stamp if it has one
+func readStamp(path string) (isGenerated bool, stamp Stamp, found bool, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, Stamp{}, false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for i := 0; scanner.Scan() && i < 3; i++ {
+		line := scanner.Text()
+		if i == 0 {
+			if !strings.HasPrefix(line, "// Code generated by SQLBoiler") {
+				return false, Stamp{}, false, nil
+			}
+			continue
+		}
+		if stamp, ok := ParseStamp(line); ok {
+			return true, stamp, true, nil
+		}
+	}
+
+	return true, Stamp{}, false, scanner.Err()
+}
@@ -0,0 +1,139 @@
+package boilingcore
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestParseStamp(t *testing.T) {
+	t.Parallel()
+
+	stamp := Stamp{Version: "4.14.2", Driver: "psql", Hash: "0123456789abcdef"}
+	got, ok := ParseStamp(stamp.String())
+	if !ok {
+		t.Fatal("stamp was not parsed")
+	}
+	if got != stamp {
+		t.Errorf("want: %#v\ngot: %#v", stamp, got)
+	}
+
+	if _, ok := ParseStamp("// This file is meant to be re-generated in place"); ok {
+		t.Error("a line without a stamp was parsed")
+	}
+}
+
+func TestInitStamp(t *testing.T) {
+	t.Parallel()
+
+	stamp := func(config Config) Stamp {
+		s := &State{Config: &config, Tables: testTables()}
+		if err := s.initStamp(); err != nil {
+			t.Fatal(err)
+		}
+		return s.Stamp
+	}
+
+	base := stamp(Config{DriverName: "psql", PkgName: "models", OutFolder: "models"})
+	if base.Version != stampDevelVersion || base.Driver != "psql" || len(base.Hash) != 16 {
+		t.Errorf("stamp was wrong: %#v", base)
+	}
+
+	moved := stamp(Config{DriverName: "psql", PkgName: "models", OutFolder: "other", Wipe: true})
+	if moved.Hash != base.Hash {
+		t.Error("the output folder and wiping changed the hash")
+	}
+
+	noHooks := stamp(Config{DriverName: "psql", PkgName: "models", OutFolder: "models", NoHooks: true})
+	if noHooks.Hash == base.Hash {
+		t.Error("the config did not change the hash")
+	}
+}
+
+func TestStampHash(t *testing.T) {
+	t.Parallel()
+
+	type table struct {
+		Name    string   `json:"name"`
+		Columns []string `json:"columns"`
+	}
+	type grownTable struct {
+		Name    string   `json:"name"`
+		Columns []string `json:"columns"`
+		Comment string   `json:"comment"`
+		Options struct {
+			Strict bool `json:"strict"`
+			Limit  int  `json:"limit"`
+		} `json:"options"`
+	}
+
+	hash := func(v interface{}) string {
+		h, err := stampHash(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+
+	base := hash(table{Name: "pilots", Columns: []string{"id", ""}})
+	if grown := hash(grownTable{Name: "pilots", Columns: []string{"id", ""}}); grown != base {
+		t.Error("fields at their zero value changed the hash")
+	}
+	if commented := hash(grownTable{Name: "pilots", Columns: []string{"id", ""}, Comment: "x"}); commented == base {
+		t.Error("a set field did not change the hash")
+	}
+	if moved := hash(table{Name: "pilots", Columns: []string{"", "id"}}); moved == base {
+		t.Error("the positions of the elements did not change the hash")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	stamp := Stamp{Version: "4.14.2", Driver: "psql", Hash: "0123456789abcdef"}
+	header := "// Code generated by SQLBoiler 4.14.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.\n" +
+		"// This file is meant to be re-generated in place and/or deleted at any time.\n"
+
+	files := map[string]string{
+		"pilots.go":  header + stamp.String() + "\n\npackage models\n",
+		"jets.go":    header + Stamp{Version: "4.14.1", Driver: "psql", Hash: stamp.Hash}.String() + "\n\npackage models\n",
+		"hangars.go": header + Stamp{Version: "4.14.2", Driver: "psql", Hash: "fedcba9876543210"}.String() + "\n\npackage models\n",
+		"old.go":     header + "\npackage models\n",
+		"custom.go":  "package models\n",
+		"README.md":  "# models\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0664); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := &State{Config: &Config{OutFolder: dir}, Stamp: stamp}
+	problems, err := s.Check()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(problems)
+
+	want := []string{
+		filepath.Join(dir, "hangars.go") + ": generated from another config or schema",
+		filepath.Join(dir, "jets.go") + ": generated by version 4.14.1, not 4.14.2",
+		filepath.Join(dir, "old.go") + ": has no stamp, it was generated by an older version",
+	}
+	if !reflect.DeepEqual(problems, want) {
+		t.Errorf("want:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(problems, "\n"))
+	}
+
+	empty := &State{Config: &Config{OutFolder: filepath.Join(dir, "missing")}, Stamp: stamp}
+	problems, err = empty.Check()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 1 || !strings.HasSuffix(problems[0], "has no generated files") {
+		t.Errorf("want a folder without generated files, got: %v", problems)
+	}
+}
@@ -0,0 +1,228 @@
+package boilingcore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/strmangle"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// streamingTemplate is the singleton with the readers and writers of the
+// streamed columns, it names its entry in the singleton imports
+const streamingTemplate = "boil_streaming"
+
+// streamBytesTypes are the types of the columns streamed in chunks of their
+// bytes
+var streamBytesTypes = map[string]bool{
+	"[]byte":     true,
+	"null.Bytes": true,
+}
+
+// streamLargeObjectTypes are the types of the postgres oid columns streamed
+// as large objects
+var streamLargeObjectTypes = map[string]bool{
+	"uint32":      true,
+	"null.Uint32": true,
+}
+
+// StreamTable is a table with streamed columns
+type StreamTable struct {
+	Name    string
+	Columns []StreamColumn
+	// NonStreamed are the other columns of the table
+	NonStreamed []string
+}
+
+// StreamColumn is a streamed column, the bytes of a bytea or blob column read
+// and written in chunks or the oid of a postgres large object
+type StreamColumn struct {
+	Name        string
+	LargeObject bool
+	Nullable    bool
+
+	// Read selects the chunk of a bytes column at an offset, its arguments
+	// are the offset counted from 1, the length of the chunk and the primary
+	// key
+	Read string
+	// Set sets a bytes column to the first chunk, or a large object column to
+	// its oid, and Append appends the other chunks to a bytes column, their
+	// arguments are the chunk or oid and the primary key
+	Set    string
+	Append string
+}
+
+// initStreaming builds the streamed columns of the tables from the streaming
+// config and sets the imports of their readers and writers
+func (s *State) initStreaming() error {
+	if len(s.Config.Streaming.Columns) == 0 {
+		return nil
+	}
+
+	// columns has the streamed columns by table, the tables of the columns
+	// without a table are the empty string
+	columns := make(map[string]map[string]bool)
+	for _, name := range s.Config.Streaming.Columns {
+		var table, column string
+		if i := strings.LastIndexByte(name, '.'); i >= 0 {
+			table, column = name[:i], name[i+1:]
+		} else {
+			column = name
+		}
+		if columns[table] == nil {
+			columns[table] = make(map[string]bool)
+		}
+		columns[table][column] = true
+	}
+
+	dialect := streamDialect(s.Dialect)
+	found := make(map[string]bool)
+	nullable := false
+	s.StreamTables = nil
+	for _, t := range s.Tables {
+		// Columns of views and join tables are only streamed when they are
+		// named with their table, which is an error below
+		if _, ok := columns[t.Name]; !ok && (t.IsView || t.IsJoinTable) {
+			continue
+		}
+
+		table := StreamTable{Name: t.Name}
+		for _, c := range t.Columns {
+			if columns[t.Name][c.Name] {
+				found[t.Name+"."+c.Name] = true
+			} else if columns[""][c.Name] {
+				found[c.Name] = true
+			} else {
+				table.NonStreamed = append(table.NonStreamed, c.Name)
+				continue
+			}
+
+			if t.IsView || t.IsJoinTable || t.PKey == nil {
+				return errors.Errorf("streamed column %s.%s must be in a table with a primary key, not a view or join table", t.Name, c.Name)
+			}
+			if scrubIsKey(t, c.Name) {
+				return errors.Errorf("streamed column %s.%s cannot be part of the primary key or a foreign key", t.Name, c.Name)
+			}
+
+			col, err := s.streamColumn(t, c, dialect)
+			if err != nil {
+				return errors.Wrapf(err, "unable to stream column %s.%s", t.Name, c.Name)
+			}
+			table.Columns = append(table.Columns, col)
+			nullable = nullable || (col.LargeObject && col.Nullable)
+		}
+
+		if len(table.Columns) != 0 {
+			s.StreamTables = append(s.StreamTables, table)
+		}
+	}
+
+	for table, cols := range columns {
+		for column := range cols {
+			name := column
+			if len(table) != 0 {
+				name = table + "." + column
+			}
+			if !found[name] {
+				return errors.Errorf("streamed column %s was not found", name)
+			}
+		}
+	}
+
+	imps := importers.Set{
+		Standard: importers.List{`"io"`},
+		ThirdParty: importers.List{
+			`"github.com/friendsofgo/errors"`,
+			`"github.com/volatiletech/sqlboiler/v4/boil"`,
+		},
+	}
+	if !s.Config.NoContext {
+		imps.Standard = append(imps.Standard, `"context"`)
+	}
+	if nullable {
+		imps.ThirdParty = append(imps.ThirdParty, `"github.com/volatiletech/null/v8"`)
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[streamingTemplate] = imps
+
+	return nil
+}
+
+// streamColumn returns the queries streaming the column of the table in the
+// SQL dialect
+func (s *State) streamColumn(t drivers.Table, c drivers.Column, dialect string) (StreamColumn, error) {
+	lq, rq := string(s.Dialect.LQ), string(s.Dialect.RQ)
+	table := strmangle.SchemaTable(lq, rq, s.Dialect.UseSchema, s.Schema, t.Name)
+	column := lq + c.Name + rq
+
+	// where returns the where clause of the primary key after the first
+	// arguments
+	where := func(first int) string {
+		start := 0
+		if s.Dialect.UseIndexPlaceholders {
+			start = first + 1
+		}
+		return strmangle.WhereClause(lq, rq, start, t.PKey.Columns)
+	}
+	placeholder := func(i int) string {
+		if s.Dialect.UseIndexPlaceholders {
+			return fmt.Sprintf("$%d", i)
+		}
+		return "?"
+	}
+
+	col := StreamColumn{Name: c.Name, Nullable: strings.HasPrefix(c.Type, "null.")}
+	col.Set = fmt.Sprintf("UPDATE %s SET %s=%s WHERE %s", table, column, placeholder(1), where(1))
+
+	switch {
+	case streamLargeObjectTypes[c.Type]:
+		if dialect != "psql" {
+			return StreamColumn{}, errors.Errorf("columns of type %s are only streamed as large objects by postgres", c.Type)
+		}
+		col.LargeObject = true
+		return col, nil
+	case !streamBytesTypes[c.Type]:
+		return StreamColumn{}, errors.Errorf("columns of type %s cannot be streamed, want bytes or a postgres oid", c.Type)
+	}
+
+	var substring, appended string
+	switch dialect {
+	case "psql":
+		substring = fmt.Sprintf("substring(%s from %s for %s)", column, placeholder(1), placeholder(2))
+		appended = fmt.Sprintf("%s || %s", column, placeholder(1))
+	case "mysql":
+		substring = fmt.Sprintf("SUBSTRING(%s, ?, ?)", column)
+		appended = fmt.Sprintf("CONCAT(%s, ?)", column)
+	case "mssql":
+		substring = fmt.Sprintf("SUBSTRING(%s, %s, %s)", column, placeholder(1), placeholder(2))
+		appended = fmt.Sprintf("%s + %s", column, placeholder(1))
+	default:
+		// The || of sqlite concatenates text, casting it back keeps a blob
+		substring = fmt.Sprintf("substr(%s, ?, ?)", column)
+		appended = fmt.Sprintf("CAST(%s || ? AS BLOB)", column)
+	}
+	col.Read = fmt.Sprintf("SELECT %s FROM %s WHERE %s", substring, table, where(2))
+	col.Append = fmt.Sprintf("UPDATE %s SET %s=%s WHERE %s", table, column, appended, where(1))
+
+	return col, nil
+}
+
+// streamDialect names the SQL dialect of the driver from its dialect flags,
+// the mock driver's is postgres
+func streamDialect(d drivers.Dialect) string {
+	switch {
+	case d.UseTopClause:
+		return "mssql"
+	case d.LQ == '`':
+		return "mysql"
+	case d.UseIndexPlaceholders:
+		return "psql"
+	default:
+		return "sqlite"
+	}
+}
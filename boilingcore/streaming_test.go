@@ -0,0 +1,127 @@
+package boilingcore
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestInitStreaming(t *testing.T) {
+	t.Parallel()
+
+	s := testState(Config{
+		PkgName:   "models",
+		Streaming: Streaming{Columns: []string{"files.content", "files.preview", "files.video"}},
+	})
+	s.Dialect = drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true}
+	if err := s.initStreaming(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []StreamTable{
+		{
+			Name: "files",
+			Columns: []StreamColumn{
+				{
+					Name:   "content",
+					Read:   `SELECT substring("content" from $1 for $2) FROM "files" WHERE "id"=$3`,
+					Set:    `UPDATE "files" SET "content"=$1 WHERE "id"=$2`,
+					Append: `UPDATE "files" SET "content"="content" || $1 WHERE "id"=$2`,
+				},
+				{
+					Name:     "preview",
+					Nullable: true,
+					Read:     `SELECT substring("preview" from $1 for $2) FROM "files" WHERE "id"=$3`,
+					Set:      `UPDATE "files" SET "preview"=$1 WHERE "id"=$2`,
+					Append:   `UPDATE "files" SET "preview"="preview" || $1 WHERE "id"=$2`,
+				},
+				{
+					Name:        "video",
+					LargeObject: true,
+					Nullable:    true,
+					Set:         `UPDATE "files" SET "video"=$1 WHERE "id"=$2`,
+				},
+			},
+			NonStreamed: []string{"id", "name", "owner_id"},
+		},
+	}
+	if !reflect.DeepEqual(s.StreamTables, want) {
+		t.Errorf("want: %#v\ngot: %#v", want, s.StreamTables)
+	}
+	if _, ok := s.Config.Imports.Singleton[streamingTemplate]; !ok {
+		t.Error("streaming imports are not set")
+	}
+}
+
+func TestInitStreamingDialects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dialect drivers.Dialect
+		read    string
+		append  string
+	}{
+		{
+			drivers.Dialect{LQ: '`', RQ: '`'},
+			"SELECT SUBSTRING(`avatar`, ?, ?) FROM `owners` WHERE `id`=?",
+			"UPDATE `owners` SET `avatar`=CONCAT(`avatar`, ?) WHERE `id`=?",
+		},
+		{
+			drivers.Dialect{LQ: '[', RQ: ']', UseIndexPlaceholders: true, UseTopClause: true},
+			"SELECT SUBSTRING([avatar], $1, $2) FROM [owners] WHERE [id]=$3",
+			"UPDATE [owners] SET [avatar]=[avatar] + $1 WHERE [id]=$2",
+		},
+		{
+			drivers.Dialect{LQ: '"', RQ: '"'},
+			`SELECT substr("avatar", ?, ?) FROM "owners" WHERE "id"=?`,
+			`UPDATE "owners" SET "avatar"=CAST("avatar" || ? AS BLOB) WHERE "id"=?`,
+		},
+	}
+
+	for _, test := range tests {
+		s := testState(Config{PkgName: "models", Streaming: Streaming{Columns: []string{"owners.avatar"}}})
+		s.Dialect = test.dialect
+		if err := s.initStreaming(); err != nil {
+			t.Fatal(err)
+		}
+
+		col := s.StreamTables[0].Columns[0]
+		if col.Read != test.read {
+			t.Errorf("want read: %s\ngot: %s", test.read, col.Read)
+		}
+		if col.Append != test.append {
+			t.Errorf("want append: %s\ngot: %s", test.append, col.Append)
+		}
+	}
+}
+
+func TestInitStreamingErrors(t *testing.T) {
+	t.Parallel()
+
+	psql := drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true}
+	tests := []struct {
+		columns []string
+		dialect drivers.Dialect
+		err     string
+	}{
+		{[]string{"files.blob"}, psql, "files.blob was not found"},
+		{[]string{"blob"}, psql, "blob was not found"},
+		{[]string{"files.name"}, psql, "cannot be streamed"},
+		{[]string{"files.id"}, psql, "primary key or a foreign key"},
+		{[]string{"files.owner_id"}, psql, "primary key or a foreign key"},
+		{[]string{"file_contents.content"}, psql, "not a view or join table"},
+		{[]string{"files.video"}, drivers.Dialect{LQ: '`', RQ: '`'}, "only streamed as large objects by postgres"},
+	}
+
+	for _, test := range tests {
+		s := testState(Config{PkgName: "models", Streaming: Streaming{Columns: test.columns}})
+		s.Dialect = test.dialect
+
+		err := s.initStreaming()
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%v: want error containing %q, got: %v", test.columns, test.err, err)
+		}
+	}
+}
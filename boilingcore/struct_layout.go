@@ -0,0 +1,175 @@
+package boilingcore
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+// fieldLayout is the size and alignment in bytes of the type of a field on
+// 64 bit platforms
+type fieldLayout struct {
+	Size  int
+	Align int
+}
+
+// fieldLayouts are the layouts of the types the drivers give the columns
+var fieldLayouts = map[string]fieldLayout{
+	"bool":    {1, 1},
+	"byte":    {1, 1},
+	"int8":    {1, 1},
+	"uint8":   {1, 1},
+	"int16":   {2, 2},
+	"uint16":  {2, 2},
+	"int32":   {4, 4},
+	"uint32":  {4, 4},
+	"float32": {4, 4},
+	"int":     {8, 8},
+	"uint":    {8, 8},
+	"int64":   {8, 8},
+	"uint64":  {8, 8},
+	"float64": {8, 8},
+	"string":  {16, 8},
+	"[]byte":  {24, 8},
+
+	"time.Time":              {24, 8},
+	"mssql.UniqueIdentifier": {16, 1},
+
+	"null.Bool":    {2, 1},
+	"null.Byte":    {2, 1},
+	"null.Int8":    {2, 1},
+	"null.Uint8":   {2, 1},
+	"null.Int16":   {4, 2},
+	"null.Uint16":  {4, 2},
+	"null.Int32":   {8, 4},
+	"null.Uint32":  {8, 4},
+	"null.Float32": {8, 4},
+	"null.Int":     {16, 8},
+	"null.Uint":    {16, 8},
+	"null.Int64":   {16, 8},
+	"null.Uint64":  {16, 8},
+	"null.Float64": {16, 8},
+	"null.String":  {24, 8},
+	"null.Bytes":   {32, 8},
+	"null.JSON":    {32, 8},
+	"null.Time":    {32, 8},
+
+	"types.Byte":        {1, 1},
+	"types.Decimal":     {8, 8},
+	"types.NullDecimal": {8, 8},
+	"types.JSON":        {24, 8},
+	"types.HStore":      {8, 8},
+
+	"pgeo.Point":       {16, 8},
+	"pgeo.Line":        {24, 8},
+	"pgeo.Circle":      {24, 8},
+	"pgeo.Polygon":     {24, 8},
+	"pgeo.Box":         {32, 8},
+	"pgeo.Lseg":        {32, 8},
+	"pgeo.Path":        {32, 8},
+	"pgeo.NullPoint":   {24, 8},
+	"pgeo.NullLine":    {32, 8},
+	"pgeo.NullCircle":  {32, 8},
+	"pgeo.NullPolygon": {32, 8},
+	"pgeo.NullBox":     {40, 8},
+	"pgeo.NullLseg":    {40, 8},
+	"pgeo.NullPath":    {40, 8},
+}
+
+// columnLayout returns the layout of the type of a column. The array types
+// are slices, and the types it does not know, like the enum types, are taken
+// for strings.
+func columnLayout(c drivers.Column) fieldLayout {
+	if l, ok := fieldLayouts[c.Type]; ok {
+		return l
+	}
+
+	switch {
+	case strings.HasPrefix(c.Type, "[]"), strings.HasSuffix(c.Type, "Array"):
+		return fieldLayout{24, 8}
+	case strings.HasPrefix(c.Type, "*"), strings.HasPrefix(c.Type, "map["):
+		return fieldLayout{8, 8}
+	}
+
+	return fieldLayout{16, 8}
+}
+
+// structSize returns the size of a struct with fields of the columns in order,
+// with the padding between them and at its end
+func structSize(columns []drivers.Column) int {
+	size, align := 0, 1
+	for _, c := range columns {
+		l := columnLayout(c)
+		size = alignTo(size, l.Align) + l.Size
+		if l.Align > align {
+			align = l.Align
+		}
+	}
+
+	return alignTo(size, align)
+}
+
+// alignTo rounds the offset up to a multiple of the alignment
+func alignTo(offset, align int) int {
+	return (offset + align - 1) / align * align
+}
+
+// optimizeLayout orders the columns by descending alignment, which leaves no
+// padding between the fields, the columns of the same alignment keep their
+// order
+func optimizeLayout(columns []drivers.Column) []drivers.Column {
+	optimized := make([]drivers.Column, len(columns))
+	copy(optimized, columns)
+
+	sort.SliceStable(optimized, func(i, j int) bool {
+		return columnLayout(optimized[i]).Align > columnLayout(optimized[j]).Align
+	})
+
+	return optimized
+}
+
+// StructLayout is the size of the fields of the columns of a model struct,
+// in the order of struct-field-order and with the optimized layout
+type StructLayout struct {
+	Table         string
+	Size          int
+	OptimizedSize int
+}
+
+// Saved returns the bytes the optimized layout saves per model
+func (l StructLayout) Saved() int {
+	return l.Size - l.OptimizedSize
+}
+
+// initStructLayouts computes the sizes of the model structs when their layout
+// is optimized, and reports the bytes it saves per model
+func (s *State) initStructLayouts() {
+	if !s.Config.OptimizeStructLayout {
+		return
+	}
+
+	s.StructLayouts = nil
+	for _, t := range s.Tables {
+		if t.IsJoinTable {
+			continue
+		}
+
+		columns := structColumns(t, s.Config.StructFieldOrder, s.Config.GroupNullableFields)
+		s.StructLayouts = append(s.StructLayouts, StructLayout{
+			Table:         t.Name,
+			Size:          structSize(columns),
+			OptimizedSize: structSize(optimizeLayout(columns)),
+		})
+	}
+
+	saved := 0
+	for _, l := range s.StructLayouts {
+		if l.Saved() == 0 {
+			continue
+		}
+		saved += l.Saved()
+		s.warnf("struct layout: %s is %d bytes instead of %d, %d bytes saved\n", l.Table, l.OptimizedSize, l.Size, l.Saved())
+	}
+	s.warnf("struct layout: %d bytes saved over one instance of every model\n", saved)
+}
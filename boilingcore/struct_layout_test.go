@@ -0,0 +1,78 @@
+package boilingcore
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestStructSize(t *testing.T) {
+	t.Parallel()
+
+	columns := []drivers.Column{
+		{Name: "active", Type: "bool"},
+		{Name: "id", Type: "int64"},
+		{Name: "rank", Type: "null.Int16"},
+		{Name: "name", Type: "string"},
+		{Name: "score", Type: "int32"},
+	}
+
+	// 1 + 7 padding + 8 + 4 + 4 padding + 16 + 4 + 4 padding at the end
+	if size := structSize(columns); size != 48 {
+		t.Errorf("want a size of 48, got: %d", size)
+	}
+
+	optimized := optimizeLayout(columns)
+	var names []string
+	for _, c := range optimized {
+		names = append(names, c.Name)
+	}
+	if want := []string{"id", "name", "score", "rank", "active"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want: %v\ngot: %v", want, names)
+	}
+
+	// 8 + 16 + 4 + 4 + 1 + 7 padding at the end
+	if size := structSize(optimized); size != 40 {
+		t.Errorf("want an optimized size of 40, got: %d", size)
+	}
+}
+
+func TestColumnLayout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Type string
+		Want fieldLayout
+	}{
+		{"null.Time", fieldLayout{32, 8}},
+		{"types.StringArray", fieldLayout{24, 8}},
+		{"[]int64", fieldLayout{24, 8}},
+		{"map[string]string", fieldLayout{8, 8}},
+		{"PilotKind", fieldLayout{16, 8}},
+	}
+
+	for _, test := range tests {
+		if got := columnLayout(drivers.Column{Type: test.Type}); got != test.Want {
+			t.Errorf("%s: want: %v, got: %v", test.Type, test.Want, got)
+		}
+	}
+}
+
+func TestInitStructLayouts(t *testing.T) {
+	t.Parallel()
+
+	s := &State{
+		Config: &Config{OptimizeStructLayout: true, StructFieldOrder: "ordinal", Quiet: true},
+		Tables: []drivers.Table{
+			{Name: "pilots", Columns: []drivers.Column{{Name: "active", Type: "bool"}, {Name: "id", Type: "int"}}},
+			{Name: "pilot_languages", IsJoinTable: true},
+		},
+	}
+	s.initStructLayouts()
+
+	want := []StructLayout{{Table: "pilots", Size: 16, OptimizedSize: 16}}
+	if !reflect.DeepEqual(s.StructLayouts, want) {
+		t.Errorf("want: %#v\ngot: %#v", want, s.StructLayouts)
+	}
+}
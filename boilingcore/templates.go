@@ -42,6 +42,30 @@ type templateData struct {
 	AddPanic          bool
 	AddSoftDeletes    bool
 	AddEnumTypes      bool
+	AddFactories      bool
+	AddSQLMockTests   bool
+	AddCSV            bool
+	AddSeeds          bool
+	AddRepositories   bool
+	AddImmutable      bool
+	AddInterfaces     bool
+	AddDiff           bool
+	AddCopyEqual      bool
+	AddMaps           bool
+	AddValidation     bool
+	WithBenchmarks    bool
+	WithOTel          bool
+	WithMetrics       bool
+	WithProto         bool
+	WithGRPC          bool
+	WithGraphQL       bool
+	WithJSONSchema    bool
+	WithTypeScript    bool
+	WithHTTP          bool
+	WithDocs          bool
+	WithGenerics      bool
+	WithDataloader    bool
+	WithIdentityMap   bool
 	EnumNullPrefix    string
 	NoContext         bool
 	NoHooks           bool
@@ -49,6 +73,9 @@ type templateData struct {
 	NoRowsAffected    bool
 	NoDriverTemplates bool
 	NoBackReferencing bool
+	NoReload          bool
+	NoExists          bool
+	NoUpsert          bool
 	AlwaysWrapErrors  bool
 
 	// Tags control which tags are added to the struct
@@ -60,6 +87,17 @@ type templateData struct {
 	// Generate struct tags as camelCase or snake_case
 	StructTagCasing string
 
+	// StructFieldOrder orders the fields of the structs, GroupNullableFields
+	// puts the nullable ones after the others and OptimizeStructLayout
+	// reorders them to minimize the padding
+	StructFieldOrder     string
+	GroupNullableFields  bool
+	OptimizeStructLayout bool
+
+	// NoRelationshipSetters leaves out the set, add and remove methods of
+	// the relationships
+	NoRelationshipSetters bool
+
 	// Contains field names that should have tags values set to '-'
 	TagIgnore map[string]struct{}
 
@@ -73,8 +111,89 @@ type templateData struct {
 	// StringFuncs are usable in templates with stringMap
 	StringFuncs map[string]func(string) string
 
+	// CustomQueries are the queries read from the .sql files of the queries
+	// directory
+	CustomQueries []CustomQuery
+
+	// Functions are the stored functions and procedures wrappers are
+	// generated for
+	Functions []Function
+
 	// AutoColumns set the name of the columns for auto timestamps and soft deletes
 	AutoColumns AutoColumns
+
+	// AuditLog lists the tables whose changes are recorded in the audit table
+	AuditLog AuditLog
+
+	// Outbox lists the tables whose changes are written to the outbox table
+	Outbox Outbox
+
+	// Encryption lists the columns whose values are encrypted in the database
+	Encryption Encryption
+
+	// Tenancy lists the tables whose rows are scoped to the tenant of the
+	// context by their tenant column
+	Tenancy Tenancy
+
+	// RLS lists the tables with row level security enabled and the setting
+	// their policies read
+	RLS RLS
+
+	// Proto configures the protobuf messages, ProtoMessages has the message
+	// of each table by name
+	Proto         Proto
+	ProtoMessages map[string]ProtoMessage
+
+	// GraphQLTypes has the GraphQL type of every table by name
+	GraphQLTypes map[string]GraphQLType
+
+	// JSONSchemas has the JSON Schema of every table by name
+	JSONSchemas map[string]JSONSchema
+
+	// TypeScriptInterfaces has the TypeScript interface of every table by
+	// name
+	TypeScriptInterfaces map[string]TypeScriptInterface
+
+	// CSVRecords has the CSV record of every table by name
+	CSVRecords map[string]CSVRecord
+
+	// SeedTables are the tables of the seed loader in the order they are
+	// seeded
+	SeedTables []SeedTable
+
+	// ScrubTables are the tables with sensitive columns
+	ScrubTables []ScrubTable
+	// MaskTables are the tables with masked columns
+	MaskTables []MaskTable
+	// StreamTables are the tables with streamed columns
+	StreamTables []StreamTable
+	// RetentionTables are the tables whose old rows are purged or archived
+	RetentionTables []RetentionTable
+	// HistoryTables are the tables whose versions are kept in history tables
+	HistoryTables []HistoryTable
+	// SearchTables are the tables with tsvector columns
+	SearchTables []SearchTable
+
+	// HTTPHandlers has the HTTP handler of every table by name
+	HTTPHandlers map[string]HTTPHandler
+
+	// DTOMappers are the conversions between the models and the DTOs
+	DTOMappers []DTOMapper
+
+	// JoinViews are the joins of the models selected in a single query
+	JoinViews []JoinView
+
+	// Polymorphics are the polymorphic pairs of columns with their targets
+	Polymorphics []Polymorphic
+
+	// Inheritances are the subtypes of the tables by their discriminator
+	// columns
+	Inheritances []Inheritance
+
+	// Meta is the metadata of the config and TableMeta the metadata of each
+	// table by name, for the templates of the user
+	Meta      map[string]interface{}
+	TableMeta map[string]map[string]interface{}
 }
 
 func (t templateData) Quotes(s string) string {
@@ -261,8 +380,8 @@ var templateStringMappers = map[string]func(string) string{
 	"replaceReserved": strmangle.ReplaceReservedWords,
 
 	// Casing
-	"titleCase": strmangle.TitleCase,
-	"camelCase": strmangle.CamelCase,
+	"titleCase": titleCase,
+	"camelCase": camelCase,
 }
 
 var goVarnameReplacer = strings.NewReplacer("[", "_", "]", "_", ".", "_")
@@ -281,10 +400,13 @@ var templateFunctions = template.FuncMap{
 	"plural":   strmangle.Plural,
 
 	// Casing
-	"titleCase": strmangle.TitleCase,
-	"camelCase": strmangle.CamelCase,
+	"titleCase": titleCase,
+	"camelCase": camelCase,
 	"ignore":    strmangle.Ignore,
 
+	// Math
+	"add": func(a, b int) int { return a + b },
+
 	// String Slice ops
 	"join":               func(sep string, slice []string) string { return strings.Join(slice, sep) },
 	"joinSlices":         strmangle.JoinSlices,
@@ -302,14 +424,44 @@ var templateFunctions = template.FuncMap{
 	"onceHas":       once.Has,
 	"isEnumDBType":  drivers.IsEnumDBType,
 
+	// GraphQL ops
+	"graphQLName": graphQLName,
+
+	// TypeScript ops
+	"typeScriptName": typeScriptName,
+
+	// Docs ops
+	"markdownCell": markdownCell,
+	"docsPage":     docsPage,
+
+	// Example ops
+	"hasExampleNames": hasExampleNames,
+
+	// Encryption ops
+	"isEncrypted": isEncryptedType,
+
+	// Diff ops
+	"diffChanged":  diffChanged,
+	"equalChanged": equalChanged,
+	"copyColumn":   copyColumn,
+
+	// Map ops
+	"toMapColumn": toMapColumn,
+
+	// Validation ops
+	"validateColumn": validateColumn,
+
 	// String Map ops
 	"makeStringMap": strmangle.MakeStringMap,
 
 	// Set operations
-	"setInclude": strmangle.SetInclude,
+	"setInclude":    strmangle.SetInclude,
+	"setComplement": strmangle.SetComplement,
 
 	// Database related mangling
-	"whereClause": strmangle.WhereClause,
+	"whereClause":   strmangle.WhereClause,
+	"setParamNames": strmangle.SetParamNames,
+	"placeholders":  strmangle.Placeholders,
 
 	// Alias and text helping
 	"aliasCols":              func(ta TableAlias) func(string) string { return ta.Column },
@@ -325,11 +477,15 @@ var templateFunctions = template.FuncMap{
 	},
 
 	// dbdrivers ops
-	"filterColumnsByAuto":    drivers.FilterColumnsByAuto,
-	"filterColumnsByDefault": drivers.FilterColumnsByDefault,
-	"filterColumnsByEnum":    drivers.FilterColumnsByEnum,
-	"sqlColDefinitions":      drivers.SQLColDefinitions,
-	"columnNames":            drivers.ColumnNames,
-	"columnDBTypes":          drivers.ColumnDBTypes,
-	"getTable":               drivers.GetTable,
+	"filterColumnsByAuto":     drivers.FilterColumnsByAuto,
+	"filterColumnsByDefault":  drivers.FilterColumnsByDefault,
+	"filterColumnsByEnum":     drivers.FilterColumnsByEnum,
+	"filterColumnsByIdentity": drivers.FilterColumnsByIdentity,
+	"sqlColDefinitions":       drivers.SQLColDefinitions,
+	"columnNames":             drivers.ColumnNames,
+	"columnDBTypes":           drivers.ColumnDBTypes,
+	"getTable":                drivers.GetTable,
+	"charsetMaxBytes":         drivers.CharsetMaxBytes,
+	"columnMaxLength":         columnMaxLength,
+	"columnMaxBytes":          columnMaxBytes,
 }
@@ -66,3 +66,38 @@ func TestTemplateList_Templates(t *testing.T) {
 		t.Error("don't want not")
 	}
 }
+
+func TestInitTemplatesFeatureSingletons(t *testing.T) {
+	t.Parallel()
+
+	s := &State{Config: &Config{NoDriverTemplates: true, AddCSV: true, AddSQLMockTests: true}}
+	lazyTemplates, err := s.initTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := make(map[string]bool)
+	for _, tpl := range lazyTemplates {
+		names[denormalizeSlashes(tpl.Name)] = true
+	}
+
+	for _, name := range []string{
+		"main/singleton/boil_queries.go.tpl",
+		"main/singleton/boil_csv.go.tpl",
+		"test/singleton/boil_main_test.go.tpl",
+		"test/singleton/boil_sqlmock_test.go.tpl",
+	} {
+		if !names[name] {
+			t.Errorf("want the template %s", name)
+		}
+	}
+	for _, name := range []string{
+		"main/singleton/boil_audit.go.tpl",
+		"main/singleton/boil_tenancy.go.tpl",
+		"test/singleton/boil_factories_test.go.tpl",
+	} {
+		if names[name] {
+			t.Errorf("want the template %s of a feature that is off left out", name)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package boilingcore
+
+import (
+	"github.com/friendsofgo/errors"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+// tenancyTemplate is the singleton with the tenant of the context and the
+// helpers scoping queries to it, it names its entry in the singleton imports
+const tenancyTemplate = "boil_tenancy"
+
+// Tenancy lists the tables whose rows belong to a tenant by the tenant column,
+// their queries are scoped to the tenant of their context
+type Tenancy struct {
+	// Column holds the tenant of the rows, org_id for example
+	Column string
+	// Tables have the column, views included
+	Tables []string
+}
+
+// Scopes checks if the rows of the table are scoped to the tenant
+func (t Tenancy) Scopes(table string) bool {
+	for _, name := range t.Tables {
+		if name == table {
+			return true
+		}
+	}
+	return false
+}
+
+// KeyColumns returns the columns rows of the table are identified by when they
+// are changed, its primary key and the tenant column
+func (t Tenancy) KeyColumns(table drivers.Table) []string {
+	cols := append([]string(nil), table.PKey.Columns...)
+	for _, c := range cols {
+		if c == t.Column {
+			return cols
+		}
+	}
+	return append(cols, t.Column)
+}
+
+// initTenancy finds the tables with the tenant column and sets the imports of
+// the tenancy helpers
+func (s *State) initTenancy() error {
+	if len(s.Config.TenantColumn) == 0 {
+		return nil
+	}
+
+	s.Tenancy = Tenancy{Column: s.Config.TenantColumn}
+	for _, t := range s.Tables {
+		if t.IsJoinTable {
+			continue
+		}
+		for _, c := range t.Columns {
+			if c.Name == s.Config.TenantColumn {
+				s.Tenancy.Tables = append(s.Tenancy.Tables, t.Name)
+				break
+			}
+		}
+	}
+	if len(s.Tenancy.Tables) == 0 {
+		return errors.Errorf("no table has the tenant column %s", s.Config.TenantColumn)
+	}
+
+	imps := importers.Set{
+		Standard: importers.List{`"context"`, `"fmt"`},
+		ThirdParty: importers.List{
+			`"github.com/volatiletech/sqlboiler/v4/boil"`,
+			`"github.com/volatiletech/sqlboiler/v4/queries"`,
+		},
+	}
+	if s.Config.Imports.Singleton == nil {
+		s.Config.Imports.Singleton = make(importers.Map)
+	}
+	s.Config.Imports.Singleton[tenancyTemplate] = imps
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package boilingcore
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestInitTenancy(t *testing.T) {
+	t.Parallel()
+
+	orgID := drivers.Column{Name: "org_id"}
+	tables := []drivers.Table{
+		{Name: "accounts", Columns: []drivers.Column{{Name: "id"}, orgID}},
+		{Name: "plans", Columns: []drivers.Column{{Name: "id"}}},
+		{Name: "account_plans", IsJoinTable: true, Columns: []drivers.Column{{Name: "account_id"}, {Name: "plan_id"}, orgID}},
+		{Name: "account_view", IsView: true, Columns: []drivers.Column{{Name: "id"}, orgID}},
+	}
+
+	tests := []struct {
+		name    string
+		config  Config
+		want    []string
+		wantErr bool
+	}{
+		{name: "disabled", config: Config{}},
+		{name: "column", config: Config{TenantColumn: "org_id"}, want: []string{"accounts", "account_view"}},
+		{name: "missing", config: Config{TenantColumn: "tenant_id"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			s := &State{Config: &tt.config, Tables: tables}
+			err := s.initTenancy()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %t, got: %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(s.Tenancy.Tables, tt.want) {
+				t.Errorf("want tenant tables %v, got: %v", tt.want, s.Tenancy.Tables)
+			}
+			_, ok := s.Config.Imports.Singleton[tenancyTemplate]
+			if ok != (len(tt.want) != 0) {
+				t.Errorf("want imports for the tenancy helpers %t, got: %t", len(tt.want) != 0, ok)
+			}
+		})
+	}
+}
+
+func TestTenancyKeyColumns(t *testing.T) {
+	t.Parallel()
+
+	tenancy := Tenancy{Column: "org_id"}
+
+	table := drivers.Table{PKey: &drivers.PrimaryKey{Columns: []string{"id"}}}
+	if got := tenancy.KeyColumns(table); !reflect.DeepEqual(got, []string{"id", "org_id"}) {
+		t.Errorf("want the primary key and the tenant column, got: %v", got)
+	}
+	if !reflect.DeepEqual(table.PKey.Columns, []string{"id"}) {
+		t.Errorf("want the primary key untouched, got: %v", table.PKey.Columns)
+	}
+
+	table = drivers.Table{PKey: &drivers.PrimaryKey{Columns: []string{"org_id", "id"}}}
+	if got := tenancy.KeyColumns(table); !reflect.DeepEqual(got, []string{"org_id", "id"}) {
+		t.Errorf("want only the primary key, got: %v", got)
+	}
+}
@@ -0,0 +1,16 @@
+-- name: PilotsByName :many
+-- PilotsByName finds the pilots with the given name
+SELECT * FROM pilots WHERE name = @name ORDER BY id;
+
+-- name: PilotWithJet :one
+-- param: jet_name string
+SELECT pilots.* FROM pilots
+INNER JOIN jets ON jets.pilot_id = pilots.id
+WHERE jets.name = @jet_name AND pilots.id > @id
+LIMIT 1;
+
+-- name: RenamePilot :exec
+UPDATE pilots SET name = @name WHERE id = @id;
+
+-- name: DeleteJetsOfPilot :execrows
+DELETE FROM jets WHERE pilot_id = @pilot_id OR color = @color;
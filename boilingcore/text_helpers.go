@@ -38,6 +38,8 @@ import (
 // fk == table = industry.Industry | industry.Industry
 // fk != table = industry.ParentIndustry | industry.Industry
 func txtNameToOne(fk drivers.ForeignKey) (localFn, foreignFn string) {
+	fk.Table, fk.Column, fk.ForeignTable = transliterate(fk.Table), transliterate(fk.Column), transliterate(fk.ForeignTable)
+
 	fkColumnTrimmedSuffixes := strmangle.Singular(trimSuffixes(fk.Column))
 	fkNotTableName := fkColumnTrimmedSuffixes != strmangle.Singular(fk.ForeignTable)
 	singularForeignTable := strmangle.Singular(fk.ForeignTable)
@@ -92,6 +94,9 @@ func txtNameToOne(fk drivers.ForeignKey) (localFn, foreignFn string) {
 // fk == table = industry.Industries
 // fk != table = industry.MappedIndustryIndustry
 func txtNameToMany(lhs, rhs drivers.ForeignKey) (lhsFn, rhsFn string) {
+	lhs.Column, lhs.ForeignTable = transliterate(lhs.Column), transliterate(lhs.ForeignTable)
+	rhs.Column, rhs.ForeignTable = transliterate(rhs.Column), transliterate(rhs.ForeignTable)
+
 	lhsKey := strmangle.Singular(trimSuffixes(lhs.Column))
 	rhsKey := strmangle.Singular(trimSuffixes(rhs.Column))
 
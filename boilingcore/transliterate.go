@@ -0,0 +1,134 @@
+package boilingcore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/volatiletech/strmangle"
+)
+
+// transliterationGroups are the letters of the latin, greek and cyrillic
+// alphabets and the ascii letters they are written with in Go names
+var transliterationGroups = []struct {
+	letters, ascii string
+}{
+	{"ÀÁÂÃÄÅĀĂĄ", "A"}, {"àáâãäåāăą", "a"}, {"Æ", "AE"}, {"æ", "ae"},
+	{"ÇĆĈĊČ", "C"}, {"çćĉċč", "c"}, {"ÐĎĐ", "D"}, {"ðďđ", "d"},
+	{"ÈÉÊËĒĔĖĘĚ", "E"}, {"èéêëēĕėęě", "e"}, {"ĜĞĠĢ", "G"}, {"ĝğġģ", "g"},
+	{"ĤĦ", "H"}, {"ĥħ", "h"}, {"ÌÍÎÏĨĪĬĮİ", "I"}, {"ìíîïĩīĭįı", "i"},
+	{"Ĳ", "IJ"}, {"ĳ", "ij"}, {"Ĵ", "J"}, {"ĵ", "j"}, {"Ķ", "K"}, {"ķĸ", "k"},
+	{"ĹĻĽĿŁ", "L"}, {"ĺļľŀł", "l"}, {"ÑŃŅŇŊ", "N"}, {"ñńņňŉŋ", "n"},
+	{"ÒÓÔÕÖØŌŎŐ", "O"}, {"òóôõöøōŏő", "o"}, {"Œ", "OE"}, {"œ", "oe"},
+	{"ŔŖŘ", "R"}, {"ŕŗř", "r"}, {"ŚŜŞŠ", "S"}, {"śŝşšſ", "s"}, {"ß", "ss"},
+	{"ŢŤŦ", "T"}, {"ţťŧ", "t"}, {"Þ", "TH"}, {"þ", "th"},
+	{"ÙÚÛÜŨŪŬŮŰŲ", "U"}, {"ùúûüũūŭůűų", "u"}, {"Ŵ", "W"}, {"ŵ", "w"},
+	{"ÝŶŸ", "Y"}, {"ýÿŷ", "y"}, {"ŹŻŽ", "Z"}, {"źżž", "z"},
+
+	{"ΑΆ", "A"}, {"αά", "a"}, {"Β", "V"}, {"β", "v"}, {"Γ", "G"}, {"γ", "g"},
+	{"Δ", "D"}, {"δ", "d"}, {"ΕΈ", "E"}, {"εέ", "e"}, {"Ζ", "Z"}, {"ζ", "z"},
+	{"ΗΙΉΊΪ", "I"}, {"ηιήίϊΐ", "i"}, {"Θ", "Th"}, {"θ", "th"}, {"Κ", "K"}, {"κ", "k"},
+	{"Λ", "L"}, {"λ", "l"}, {"Μ", "M"}, {"μ", "m"}, {"Ν", "N"}, {"ν", "n"},
+	{"Ξ", "X"}, {"ξ", "x"}, {"ΟΩΌΏ", "O"}, {"οωόώ", "o"}, {"Π", "P"}, {"π", "p"},
+	{"Ρ", "R"}, {"ρ", "r"}, {"Σ", "S"}, {"σς", "s"}, {"Τ", "T"}, {"τ", "t"},
+	{"ΥΎΫ", "Y"}, {"υύϋΰ", "y"}, {"Φ", "F"}, {"φ", "f"}, {"Χ", "Ch"}, {"χ", "ch"},
+	{"Ψ", "Ps"}, {"ψ", "ps"},
+
+	{"А", "A"}, {"а", "a"}, {"Б", "B"}, {"б", "b"}, {"В", "V"}, {"в", "v"},
+	{"ГҐ", "G"}, {"гґ", "g"}, {"Д", "D"}, {"д", "d"}, {"ЕЭЄ", "E"}, {"еэє", "e"},
+	{"Ё", "Yo"}, {"ё", "yo"}, {"Ж", "Zh"}, {"ж", "zh"}, {"З", "Z"}, {"з", "z"},
+	{"ИІ", "I"}, {"иі", "i"}, {"ЙЫ", "Y"}, {"йы", "y"}, {"Ї", "Yi"}, {"ї", "yi"},
+	{"К", "K"}, {"к", "k"}, {"Л", "L"}, {"л", "l"}, {"М", "M"}, {"м", "m"},
+	{"Н", "N"}, {"н", "n"}, {"О", "O"}, {"о", "o"}, {"П", "P"}, {"п", "p"},
+	{"Р", "R"}, {"р", "r"}, {"С", "S"}, {"с", "s"}, {"Т", "T"}, {"т", "t"},
+	{"У", "U"}, {"у", "u"}, {"Ф", "F"}, {"ф", "f"}, {"Х", "Kh"}, {"х", "kh"},
+	{"Ц", "Ts"}, {"ц", "ts"}, {"Ч", "Ch"}, {"ч", "ch"}, {"Ш", "Sh"}, {"ш", "sh"},
+	{"Щ", "Shch"}, {"щ", "shch"}, {"ЪЬъь", ""}, {"Ю", "Yu"}, {"ю", "yu"},
+	{"Я", "Ya"}, {"я", "ya"},
+}
+
+var (
+	transliterations = map[rune]string{}
+
+	// transliterationOverrides replaces the words of the transliterations of
+	// the config before the letters are transliterated
+	transliterationOverrides = strings.NewReplacer()
+)
+
+func init() {
+	for _, g := range transliterationGroups {
+		for _, r := range g.letters {
+			transliterations[r] = g.ascii
+		}
+	}
+}
+
+// setTransliterations sets the overrides of the transliterations of the
+// config, the longest words first
+func setTransliterations(words map[string]string) {
+	keys := make([]string, 0, len(words))
+	for k := range words {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	pairs := make([]string, 0, 2*len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k, words[k])
+	}
+	transliterationOverrides = strings.NewReplacer(pairs...)
+}
+
+// transliterate returns the name of a table, column or other database
+// object written in ascii for the names of the generated code. The latin,
+// greek and cyrillic letters are transliterated, the other letters and
+// digits become words of their code points, like u7528 for 用, and the
+// rest is dropped.
+func transliterate(name string) string {
+	name = transliterationOverrides.Replace(name)
+	if isASCII(name) {
+		return name
+	}
+
+	var b strings.Builder
+	for _, r := range name {
+		if r < utf8.RuneSelf {
+			b.WriteRune(r)
+			continue
+		}
+
+		if ascii, ok := transliterations[r]; ok {
+			b.WriteString(ascii)
+		} else if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			fmt.Fprintf(&b, "_u%04x_", r)
+		}
+	}
+
+	return b.String()
+}
+
+// titleCase is strmangle's TitleCase of the transliterated name
+func titleCase(name string) string {
+	return strmangle.TitleCase(transliterate(name))
+}
+
+// camelCase is strmangle's CamelCase of the transliterated name
+func camelCase(name string) string {
+	return strmangle.CamelCase(transliterate(name))
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package boilingcore
+
+import "testing"
+
+func TestTransliterate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In, Out string
+	}{
+		{"pilots", "pilots"},
+		{"straße", "strasse"},
+		{"Über_größe", "Uber_grosse"},
+		{"zażółć", "zazolc"},
+		{"пользователи", "polzovateli"},
+		{"χώρα", "chora"},
+		{"用户", "_u7528__u6237_"},
+		{"price €", "price "},
+	}
+
+	for i, test := range tests {
+		if got := transliterate(test.In); got != test.Out {
+			t.Errorf("%d) %s: want %q, got %q", i, test.In, test.Out, got)
+		}
+	}
+
+	if got := titleCase("用户"); got != "U7528U6237" {
+		t.Errorf("want the code points of the other letters as words, got: %s", got)
+	}
+	if got := camelCase("über_größe"); got != "uberGrosse" {
+		t.Errorf("want the camel case in ascii, got: %s", got)
+	}
+}
+
+func TestGoColumnAliasTransliterated(t *testing.T) {
+	t.Parallel()
+
+	if got := goColumnAlias("größe", ""); got != "Grosse" {
+		t.Errorf("want the transliterated title case, got: %s", got)
+	}
+	if got := goColumnAlias("имя", ""); got != "Imya" {
+		t.Errorf("want the transliterated title case, got: %s", got)
+	}
+}
+
+func TestSetTransliterations(t *testing.T) {
+	defer setTransliterations(nil)
+
+	setTransliterations(map[string]string{"用户": "user", "用": "use", "größe": "size"})
+
+	if got := transliterate("用户_größe"); got != "user_size" {
+		t.Errorf("want the longest words of the config replaced first, got: %s", got)
+	}
+	if got := transliterate("用"); got != "use" {
+		t.Errorf("want the words of the config replaced, got: %s", got)
+	}
+}
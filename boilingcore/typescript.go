@@ -0,0 +1,121 @@
+package boilingcore
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/volatiletech/strmangle"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+var rgxTypeScriptIdent = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// typeScriptTypes maps the JSON Schema types of columns to TypeScript types
+var typeScriptTypes = map[string]string{
+	"integer": "number",
+	"number":  "number",
+	"boolean": "boolean",
+	"string":  "string",
+}
+
+// TypeScriptInterface is the TypeScript interface of the JSON of the model of
+// a table, without its relationships
+type TypeScriptInterface struct {
+	Name   string
+	Fields []TypeScriptField
+}
+
+// TypeScriptField is a property of a TypeScript interface, named after the
+// json tag of its column
+type TypeScriptField struct {
+	// Name is quoted when it is not an identifier
+	Name    string
+	Type    string
+	Comment string
+}
+
+// initTypeScript builds the TypeScript interface of every table
+func (s *State) initTypeScript() error {
+	if !s.Config.WithTypeScript {
+		return nil
+	}
+
+	ignore := make(map[string]struct{})
+	for _, v := range s.Config.TagIgnore {
+		ignore[v] = struct{}{}
+	}
+
+	s.TypeScriptInterfaces = make(map[string]TypeScriptInterface)
+	for _, t := range s.Tables {
+		if t.IsJoinTable {
+			continue
+		}
+
+		alias := s.Config.Aliases.Table(t.Name)
+		iface := TypeScriptInterface{Name: alias.UpSingular}
+		for _, c := range t.Columns {
+			if strmangle.Ignore(t.Name, c.Name, ignore) {
+				continue
+			}
+
+			iface.Fields = append(iface.Fields, TypeScriptField{
+				Name:    typeScriptName(jsonTagName(s.Config.StructTagCasing, c.Name, alias.Column(c.Name))),
+				Type:    columnTypeScriptType(c),
+				Comment: strings.ReplaceAll(strings.Join(strings.Fields(c.Comment), " "), "*/", "* /"),
+			})
+		}
+
+		s.TypeScriptInterfaces[t.Name] = iface
+	}
+
+	return nil
+}
+
+// columnTypeScriptType returns the TypeScript type of the JSON of a column,
+// columns of types it does not know are unknown
+func columnTypeScriptType(c drivers.Column) string {
+	nullable := func(typ string, null bool) string {
+		if null {
+			return typ + " | null"
+		}
+		return typ
+	}
+
+	if drivers.IsEnumDBType(c.DBType) {
+		vals := strmangle.ParseEnumVals(c.DBType)
+		for i, v := range vals {
+			vals[i] = strconv.Quote(v)
+		}
+		return nullable(strings.Join(vals, " | "), c.Nullable)
+	}
+
+	if elem, ok := jsonSchemaArrays[c.Type]; ok {
+		return nullable(typeScriptTypes[elem]+"[]", c.Nullable)
+	}
+
+	// encrypted columns are marshaled as their plaintexts
+	typ, ok := encryptedPlainTypes[c.Type]
+	if !ok {
+		typ = c.Type
+	}
+	null := false
+	if base, ok := jsonSchemaNullBases[typ]; ok {
+		typ, null = base, true
+	}
+
+	tsType, ok := typeScriptTypes[jsonSchemaTypes[typ]]
+	if !ok {
+		return "unknown"
+	}
+	return nullable(tsType, null)
+}
+
+// typeScriptName quotes the name of a property when it is not an identifier
+func typeScriptName(name string) string {
+	if rgxTypeScriptIdent.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
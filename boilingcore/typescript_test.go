@@ -0,0 +1,82 @@
+package boilingcore
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestColumnTypeScriptType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Column drivers.Column
+		Want   string
+	}{
+		{drivers.Column{Type: "int64"}, "number"},
+		{drivers.Column{Type: "null.String", Nullable: true}, "string | null"},
+		{drivers.Column{Type: "null.Time", Nullable: true}, "string | null"},
+		{drivers.Column{Type: "bool"}, "boolean"},
+		{drivers.Column{Type: "types.Int64Array"}, "number[]"},
+		{drivers.Column{Type: "types.StringArray", Nullable: true}, "string[] | null"},
+		{drivers.Column{Type: "string", DBType: "enum.rank('a','b')"}, `"a" | "b"`},
+		{drivers.Column{Type: "null.String", DBType: "enum('a')", Nullable: true}, `"a" | null`},
+		{drivers.Column{Type: "types.JSON"}, "unknown"},
+	}
+
+	for i, test := range tests {
+		if got := columnTypeScriptType(test.Column); got != test.Want {
+			t.Errorf("%d) want %s, got: %s", i, test.Want, got)
+		}
+	}
+}
+
+func TestInitTypeScript(t *testing.T) {
+	t.Parallel()
+
+	tables := []drivers.Table{
+		{
+			Name: "pilots",
+			Columns: []drivers.Column{
+				{Name: "id", Type: "int", Comment: "the */ id"},
+				{Name: "first_name", Type: "string"},
+				{Name: "password", Type: "string"},
+			},
+			PKey: &drivers.PrimaryKey{Name: "pilots_pkey", Columns: []string{"id"}},
+		},
+	}
+
+	s := &State{
+		Config: &Config{WithTypeScript: true, StructTagCasing: "title", TagIgnore: []string{"password"}},
+		Tables: tables,
+	}
+	FillAliases(&s.Config.Aliases, tables)
+	if err := s.initTypeScript(); err != nil {
+		t.Fatal(err)
+	}
+
+	iface := s.TypeScriptInterfaces["pilots"]
+	want := []TypeScriptField{
+		{Name: "ID", Type: "number", Comment: "the * / id"},
+		{Name: "FirstName", Type: "string"},
+	}
+	if iface.Name != "Pilot" || len(iface.Fields) != len(want) {
+		t.Fatalf("interface was wrong: %#v", iface)
+	}
+	for i, f := range want {
+		if iface.Fields[i] != f {
+			t.Errorf("%d) want %#v, got: %#v", i, f, iface.Fields[i])
+		}
+	}
+}
+
+func TestTypeScriptName(t *testing.T) {
+	t.Parallel()
+
+	if got := typeScriptName("pilot_id"); got != "pilot_id" {
+		t.Errorf("want pilot_id, got: %s", got)
+	}
+	if got := typeScriptName("first name"); got != `"first name"` {
+		t.Errorf(`want "first name", got: %s`, got)
+	}
+}
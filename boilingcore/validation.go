@@ -0,0 +1,39 @@
+package boilingcore
+
+import (
+	"fmt"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+// validateColumn returns the Go statements returning the error of the value v
+// of the column of the table when it does not fit the length of a text column
+// or the precision and scale of a decimal column, empty when the column has
+// no limits. Replaced types are not validated.
+func validateColumn(table, v string, c drivers.Column) string {
+	var check string
+	switch c.Type {
+	case "string", "null.String":
+		max := columnMaxLength(c)
+		if max <= 0 {
+			return ""
+		}
+		value := v
+		if c.Type == "null.String" {
+			value = v + ".String"
+		}
+		check = fmt.Sprintf("boil.ValidateLength(%q, %q, %s, %d)", table, c.Name, value, max)
+	case "types.Decimal", "types.NullDecimal":
+		if c.Precision <= 0 {
+			return ""
+		}
+		check = fmt.Sprintf("boil.ValidateDecimal(%q, %q, %s.Big, %d, %d)", table, c.Name, v, c.Precision, c.Scale)
+	default:
+		return ""
+	}
+
+	if c.Type == "null.String" {
+		return fmt.Sprintf("if %s.Valid {\nif err := %s; err != nil {\nreturn err\n}\n}", v, check)
+	}
+	return fmt.Sprintf("if err := %s; err != nil {\nreturn err\n}", check)
+}
@@ -0,0 +1,38 @@
+package boilingcore
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestValidateColumn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Column drivers.Column
+		Want   string
+	}{
+		{
+			drivers.Column{Name: "name", Type: "string", FullDBType: "varchar(20)"},
+			"if err := boil.ValidateLength(\"pilots\", \"name\", o.Name, 20); err != nil {\nreturn err\n}",
+		},
+		{
+			drivers.Column{Name: "name", Type: "null.String", MaxLength: 10},
+			"if o.Name.Valid {\nif err := boil.ValidateLength(\"pilots\", \"name\", o.Name.String, 10); err != nil {\nreturn err\n}\n}",
+		},
+		{
+			drivers.Column{Name: "name", Type: "types.NullDecimal", Precision: 10, Scale: 2},
+			"if err := boil.ValidateDecimal(\"pilots\", \"name\", o.Name.Big, 10, 2); err != nil {\nreturn err\n}",
+		},
+		{drivers.Column{Name: "name", Type: "string", DBType: "text"}, ""},
+		{drivers.Column{Name: "name", Type: "types.Decimal", DBType: "numeric"}, ""},
+		{drivers.Column{Name: "name", Type: "[]byte", FullDBType: "binary(16)"}, ""},
+	}
+
+	for i, test := range tests {
+		if got := validateColumn("pilots", "o.Name", test.Column); got != test.Want {
+			t.Errorf("%d) want:\n%s\ngot:\n%s", i, test.Want, got)
+		}
+	}
+}
@@ -20,6 +20,18 @@ type Column struct {
 	Unique        bool   `json:"unique" toml:"unique"`
 	Validated     bool   `json:"validated" toml:"validated"`
 	AutoGenerated bool   `json:"auto_generated" toml:"auto_generated"`
+	// CaseInsensitive columns compare their text ignoring case, like the
+	// citext columns of postgres and the columns of _ci collations of mysql
+	CaseInsensitive bool `json:"case_insensitive" toml:"case_insensitive"`
+	// Collation and Charset of the text columns, empty for the columns of
+	// other types and the databases that do not report them
+	Collation string `json:"collation" toml:"collation"`
+	Charset   string `json:"charset" toml:"charset"`
+	// MaxLength is the most characters of the text columns, Precision and
+	// Scale the digits of the decimal columns, 0 when they have no limits
+	MaxLength int `json:"max_length" toml:"max_length"`
+	Precision int `json:"precision" toml:"precision"`
+	Scale     int `json:"scale" toml:"scale"`
 
 	// Postgres only extension bits
 	// ArrType is the underlying data type of the Postgres
@@ -85,6 +97,21 @@ func FilterColumnsByDefault(defaults bool, columns []Column) []Column {
 	return cols
 }
 
+// FilterColumnsByIdentity generates the list of identity columns that are
+// generated always, their Default is IDENTITY and they are AutoGenerated.
+// Inserts only set them overriding the system value.
+func FilterColumnsByIdentity(columns []Column) []Column {
+	var cols []Column
+
+	for _, c := range columns {
+		if c.AutoGenerated && c.Default == "IDENTITY" {
+			cols = append(cols, c)
+		}
+	}
+
+	return cols
+}
+
 // FilterColumnsByEnum generates the list of columns that are enum values.
 func FilterColumnsByEnum(columns []Column) []Column {
 	var cols []Column
@@ -102,3 +129,29 @@ func FilterColumnsByEnum(columns []Column) []Column {
 func IsEnumDBType(dbType string) bool {
 	return rgxEnum.MatchString(dbType)
 }
+
+// charsetBytes are the most bytes a character takes in the charsets as the
+// databases name them, mysql utf8 is utf8mb3 where postgres UTF8 is not
+var charsetBytes = map[string]int{
+	// mysql
+	"ascii": 1, "binary": 1, "latin1": 1, "latin2": 1, "latin5": 1, "latin7": 1,
+	"cp1250": 1, "cp1251": 1, "cp1256": 1, "cp1257": 1, "greek": 1, "hebrew": 1,
+	"koi8r": 1, "koi8u": 1, "ucs2": 2, "big5": 2, "gbk": 2, "sjis": 2, "cp932": 2,
+	"euckr": 2, "gb2312": 2, "ujis": 3, "eucjpms": 3, "utf8": 3, "utf8mb3": 3,
+	"utf8mb4": 4, "utf16": 4, "utf16le": 4, "utf32": 4, "gb18030": 4,
+	// postgres
+	"SQL_ASCII": 1, "LATIN1": 1, "LATIN2": 1, "LATIN3": 1, "LATIN4": 1, "LATIN5": 1,
+	"LATIN6": 1, "LATIN7": 1, "LATIN8": 1, "LATIN9": 1, "LATIN10": 1, "WIN1250": 1,
+	"WIN1251": 1, "WIN1252": 1, "WIN1253": 1, "WIN1254": 1, "WIN1255": 1,
+	"WIN1256": 1, "WIN1257": 1, "WIN1258": 1, "KOI8R": 1, "KOI8U": 1,
+	"EUC_CN": 3, "EUC_JP": 3, "EUC_KR": 3, "EUC_TW": 4, "UTF8": 4,
+	// mssql
+	"iso_1": 1, "UNICODE": 2,
+}
+
+// CharsetMaxBytes returns the most bytes a character of the charset takes, 0
+// when the charset is not known. The text of a column of n characters takes up
+// to n times as many bytes.
+func CharsetMaxBytes(charset string) int {
+	return charsetBytes[charset]
+}
@@ -86,3 +86,39 @@ func TestFilterColumnsByEnum(t *testing.T) {
 		t.Errorf("Invalid result: %#v", res)
 	}
 }
+
+func TestFilterColumnsByIdentity(t *testing.T) {
+	t.Parallel()
+
+	cols := []Column{
+		{Name: "col1", Default: "IDENTITY", AutoGenerated: true},
+		{Name: "col2", Default: "IDENTITY"},
+		{Name: "col3", Default: "GENERATED", AutoGenerated: true},
+		{Name: "col4"},
+	}
+
+	res := FilterColumnsByIdentity(cols)
+	if len(res) != 1 || res[0].Name != `col1` {
+		t.Errorf("Invalid result: %#v", res)
+	}
+}
+
+func TestCharsetMaxBytes(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]int{
+		"utf8mb4": 4,
+		"utf8":    3,
+		"UTF8":    4,
+		"latin1":  1,
+		"UNICODE": 2,
+		"":        0,
+		"klingon": 0,
+	}
+
+	for charset, want := range tests {
+		if got := CharsetMaxBytes(charset); got != want {
+			t.Errorf("%q: want %d, got %d", charset, want, got)
+		}
+	}
+}
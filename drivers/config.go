@@ -18,12 +18,19 @@ type Config struct {
 	DBName  string
 	SSLMode string
 
+	// Credentials load the user and password from a secret store instead
+	Credentials Credentials
+
 	BlackList      []string
 	WhiteList      []string
 	Schema         string
 	AddEnumTypes   bool
 	EnumNullPrefix string
 
+	// AddFunctions loads the stored functions and procedures of drivers
+	// that support them
+	AddFunctions bool
+
 	ForeignKeys []ForeignKey
 
 	// Concurrency defines amount of threads to use when loading tables info.
@@ -31,6 +38,13 @@ type Config struct {
 
 	// For mysql
 	TinyIntAsInt bool
+	// Vitess reads the foreign keys from ForeignKeys only, for Vitess and
+	// PlanetScale databases that have no foreign key constraints
+	Vitess bool
+
+	// For the mock driver, these replace the built in mock schema
+	MockTables     []Table
+	MockSchemaFile string
 }
 
 // DefaultInt retrieves a non-zero int or the default value provided.
@@ -0,0 +1,217 @@
+package drivers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+)
+
+// Credentials configures where the user and password of the connection are
+// loaded from when the code is generated, so the config does not need to
+// hold a long-lived password.
+type Credentials struct {
+	// Source is the name of a registered CredentialSource, like command,
+	// rds-iam, aws-secrets-manager or vault. Without a source the user and
+	// password of the config are used.
+	Source string
+	// Command is run by the command source, its output is the password
+	Command string
+	// Region of the rds-iam and aws-secrets-manager sources, the region of
+	// the aws cli by default
+	Region string
+	// SecretID is the name or ARN of the secret of the aws-secrets-manager
+	// source
+	SecretID string
+	// Path of the secret of the vault source
+	Path string
+	// Field of the secret holding the password, password by default
+	Field string
+}
+
+// CredentialSource loads the user and password of the connection of a
+// config, an empty user leaves the user of the config.
+type CredentialSource interface {
+	Credentials(config Config) (user, pass string, err error)
+}
+
+// CredentialSourceFunc is a function that is a CredentialSource
+type CredentialSourceFunc func(config Config) (user, pass string, err error)
+
+// Credentials calls the function
+func (f CredentialSourceFunc) Credentials(config Config) (user, pass string, err error) {
+	return f(config)
+}
+
+// credentialSources are all the credential sources currently registered
+var credentialSources = map[string]CredentialSource{
+	"command":             CredentialSourceFunc(commandCredentials),
+	"rds-iam":             CredentialSourceFunc(rdsIAMCredentials),
+	"aws-secrets-manager": CredentialSourceFunc(secretsManagerCredentials),
+	"vault":               CredentialSourceFunc(vaultCredentials),
+}
+
+// credentialCommand runs the commands of the credential sources, it is
+// replaced by the tests
+var credentialCommand = func(name string, args ...string) ([]byte, error) {
+	stderr := &bytes.Buffer{}
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s failed: %s", name, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// RegisterCredentialSource registers a credential source by name, for the
+// secret stores sqlboiler does not know.
+// Panics if a source with the same name has been previously registered.
+func RegisterCredentialSource(name string, source CredentialSource) {
+	if _, ok := credentialSources[name]; ok {
+		panic(fmt.Sprintf("drivers: credential source %s already registered", name))
+	}
+
+	credentialSources[name] = source
+}
+
+// LoadCredentials sets the user and password of the config from the source
+// of its credentials, it does nothing without a source.
+func LoadCredentials(config *Config) error {
+	if len(config.Credentials.Source) == 0 {
+		return nil
+	}
+
+	source, ok := credentialSources[config.Credentials.Source]
+	if !ok {
+		return errors.Errorf("credential source %q has not been registered", config.Credentials.Source)
+	}
+
+	user, pass, err := source.Credentials(*config)
+	if err != nil {
+		return errors.Wrapf(err, "unable to load the credentials of %s", config.Credentials.Source)
+	}
+
+	if len(user) != 0 {
+		config.User = user
+	}
+	config.Pass = pass
+	return nil
+}
+
+// commandCredentials runs the command of the credentials with the shell, its
+// output is the password
+func commandCredentials(config Config) (string, string, error) {
+	if len(config.Credentials.Command) == 0 {
+		return "", "", errors.New("the command source needs a command")
+	}
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	out, err := credentialCommand(shell, flag, config.Credentials.Command)
+	if err != nil {
+		return "", "", err
+	}
+	return "", strings.TrimSpace(string(out)), nil
+}
+
+// rdsIAMCredentials generates an IAM authentication token of RDS for the
+// host, port and user of the config with the aws cli
+func rdsIAMCredentials(config Config) (string, string, error) {
+	if len(config.Host) == 0 || config.Port == 0 || len(config.User) == 0 {
+		return "", "", errors.New("the rds-iam source needs the host, port and user")
+	}
+
+	args := []string{
+		"rds", "generate-db-auth-token",
+		"--hostname", config.Host,
+		"--port", strconv.Itoa(config.Port),
+		"--username", config.User,
+	}
+	if len(config.Credentials.Region) != 0 {
+		args = append(args, "--region", config.Credentials.Region)
+	}
+
+	out, err := credentialCommand("aws", args...)
+	if err != nil {
+		return "", "", err
+	}
+	return "", strings.TrimSpace(string(out)), nil
+}
+
+// secretsManagerCredentials reads the secret of AWS Secrets Manager with the
+// aws cli. A secret of JSON, like the ones of RDS, has the password in the
+// field of the credentials and the user in username, other secrets are the
+// password.
+func secretsManagerCredentials(config Config) (string, string, error) {
+	if len(config.Credentials.SecretID) == 0 {
+		return "", "", errors.New("the aws-secrets-manager source needs a secret_id")
+	}
+
+	args := []string{
+		"secretsmanager", "get-secret-value",
+		"--secret-id", config.Credentials.SecretID,
+		"--query", "SecretString",
+		"--output", "text",
+	}
+	if len(config.Credentials.Region) != 0 {
+		args = append(args, "--region", config.Credentials.Region)
+	}
+
+	out, err := credentialCommand("aws", args...)
+	if err != nil {
+		return "", "", err
+	}
+
+	secret := strings.TrimSpace(string(out))
+	if !strings.HasPrefix(secret, "{") {
+		return "", secret, nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(secret), &fields); err != nil {
+		return "", "", errors.Wrap(err, "unable to read the JSON of the secret")
+	}
+
+	field := credentialsField(config.Credentials)
+	pass, ok := fields[field].(string)
+	if !ok {
+		return "", "", errors.Errorf("the secret has no %s", field)
+	}
+
+	user, _ := fields["username"].(string)
+	if len(config.User) != 0 {
+		user = ""
+	}
+	return user, pass, nil
+}
+
+// vaultCredentials reads the field of the secret at the path with the vault
+// cli
+func vaultCredentials(config Config) (string, string, error) {
+	if len(config.Credentials.Path) == 0 {
+		return "", "", errors.New("the vault source needs a path")
+	}
+
+	out, err := credentialCommand("vault", "kv", "get", "-field="+credentialsField(config.Credentials), config.Credentials.Path)
+	if err != nil {
+		return "", "", err
+	}
+	return "", strings.TrimSpace(string(out)), nil
+}
+
+func credentialsField(c Credentials) string {
+	if len(c.Field) == 0 {
+		return "password"
+	}
+	return c.Field
+}
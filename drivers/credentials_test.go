@@ -0,0 +1,152 @@
+package drivers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// stubCredentialCommand replaces the commands of the credential sources with
+// one returning the output, it records the commands run
+func stubCredentialCommand(t *testing.T, output string) *[][]string {
+	t.Helper()
+
+	var commands [][]string
+	previous := credentialCommand
+	credentialCommand = func(name string, args ...string) ([]byte, error) {
+		commands = append(commands, append([]string{name}, args...))
+		return []byte(output), nil
+	}
+	t.Cleanup(func() { credentialCommand = previous })
+
+	return &commands
+}
+
+func TestLoadCredentialsNoSource(t *testing.T) {
+	config := Config{User: "bob", Pass: "secret"}
+	if err := LoadCredentials(&config); err != nil {
+		t.Fatal(err)
+	}
+	if config.User != "bob" || config.Pass != "secret" {
+		t.Errorf("want the credentials of the config, got: %s %s", config.User, config.Pass)
+	}
+}
+
+func TestLoadCredentialsUnknownSource(t *testing.T) {
+	config := Config{Credentials: Credentials{Source: "keychain"}}
+	err := LoadCredentials(&config)
+	if err == nil || !strings.Contains(err.Error(), `"keychain" has not been registered`) {
+		t.Errorf("want an error for the unknown source, got: %v", err)
+	}
+}
+
+func TestLoadCredentialsRDSIAM(t *testing.T) {
+	commands := stubCredentialCommand(t, "db.example.com:5432/?Action=connect&X-Amz-Signature=abc\n")
+
+	config := Config{
+		User:        "app",
+		Host:        "db.example.com",
+		Port:        5432,
+		Credentials: Credentials{Source: "rds-iam", Region: "eu-west-1"},
+	}
+	if err := LoadCredentials(&config); err != nil {
+		t.Fatal(err)
+	}
+
+	if config.User != "app" || config.Pass != "db.example.com:5432/?Action=connect&X-Amz-Signature=abc" {
+		t.Errorf("want the token as the password, got: %s %s", config.User, config.Pass)
+	}
+	want := [][]string{{"aws", "rds", "generate-db-auth-token", "--hostname", "db.example.com", "--port", "5432", "--username", "app", "--region", "eu-west-1"}}
+	if !reflect.DeepEqual(want, *commands) {
+		t.Errorf("want the token generated, got: %v", *commands)
+	}
+
+	config.Port = 0
+	if err := LoadCredentials(&config); err == nil {
+		t.Error("want an error without the port")
+	}
+}
+
+func TestLoadCredentialsSecretsManager(t *testing.T) {
+	commands := stubCredentialCommand(t, `{"username":"app","password":"s3cret","engine":"postgres"}`)
+
+	config := Config{Credentials: Credentials{Source: "aws-secrets-manager", SecretID: "prod/db"}}
+	if err := LoadCredentials(&config); err != nil {
+		t.Fatal(err)
+	}
+
+	if config.User != "app" || config.Pass != "s3cret" {
+		t.Errorf("want the user and password of the secret, got: %s %s", config.User, config.Pass)
+	}
+	want := [][]string{{"aws", "secretsmanager", "get-secret-value", "--secret-id", "prod/db", "--query", "SecretString", "--output", "text"}}
+	if !reflect.DeepEqual(want, *commands) {
+		t.Errorf("want the secret read, got: %v", *commands)
+	}
+
+	config = Config{User: "admin", Credentials: Credentials{Source: "aws-secrets-manager", SecretID: "prod/db"}}
+	if err := LoadCredentials(&config); err != nil {
+		t.Fatal(err)
+	}
+	if config.User != "admin" {
+		t.Errorf("want the user of the config kept, got: %s", config.User)
+	}
+
+	config = Config{Credentials: Credentials{Source: "aws-secrets-manager", SecretID: "prod/db", Field: "token"}}
+	if err := LoadCredentials(&config); err == nil || !strings.Contains(err.Error(), "the secret has no token") {
+		t.Errorf("want an error for the missing field, got: %v", err)
+	}
+}
+
+func TestLoadCredentialsVault(t *testing.T) {
+	commands := stubCredentialCommand(t, "s3cret\n")
+
+	config := Config{User: "app", Credentials: Credentials{Source: "vault", Path: "secret/db"}}
+	if err := LoadCredentials(&config); err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Pass != "s3cret" {
+		t.Errorf("want the field of the secret, got: %s", config.Pass)
+	}
+	want := [][]string{{"vault", "kv", "get", "-field=password", "secret/db"}}
+	if !reflect.DeepEqual(want, *commands) {
+		t.Errorf("want the secret read, got: %v", *commands)
+	}
+}
+
+func TestLoadCredentialsCommand(t *testing.T) {
+	config := Config{Credentials: Credentials{Source: "command", Command: "echo s3cret"}}
+	if err := LoadCredentials(&config); err != nil {
+		t.Fatal(err)
+	}
+	if config.Pass != "s3cret" {
+		t.Errorf("want the output of the command, got: %q", config.Pass)
+	}
+
+	config = Config{Credentials: Credentials{Source: "command", Command: "exit 3"}}
+	if err := LoadCredentials(&config); err == nil {
+		t.Error("want the error of the command")
+	}
+}
+
+func TestRegisterCredentialSource(t *testing.T) {
+	RegisterCredentialSource("test-source", CredentialSourceFunc(func(config Config) (string, string, error) {
+		return "user-of-" + config.DBName, "pass", nil
+	}))
+	defer delete(credentialSources, "test-source")
+
+	config := Config{DBName: "db", Credentials: Credentials{Source: "test-source"}}
+	if err := LoadCredentials(&config); err != nil {
+		t.Fatal(err)
+	}
+	if config.User != "user-of-db" || config.Pass != "pass" {
+		t.Errorf("want the credentials of the registered source, got: %s %s", config.User, config.Pass)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("want a panic registering a source twice")
+		}
+	}()
+	RegisterCredentialSource("vault", CredentialSourceFunc(vaultCredentials))
+}
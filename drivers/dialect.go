@@ -0,0 +1,67 @@
+package drivers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/volatiletech/strmangle"
+)
+
+// Placeholder returns the placeholder of the argument n of a query, counted
+// from 1, $n for the dialects of index placeholders and ? for the others
+func (d Dialect) Placeholder(n int) string {
+	if d.UseIndexPlaceholders {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// PlaceholderStart returns the index the placeholders of the arguments from
+// n on start at, n for the dialects of index placeholders and 0 for the
+// others, as strmangle's WhereClause and SetParamNames take it
+func (d Dialect) PlaceholderStart(n int) int {
+	if d.UseIndexPlaceholders {
+		return n
+	}
+	return 0
+}
+
+// Placeholders returns count placeholders of the arguments from start on, in
+// parenthesized groups of group when it is more than 1
+func (d Dialect) Placeholders(count, start, group int) string {
+	return strmangle.Placeholders(d.UseIndexPlaceholders, count, start, group)
+}
+
+// Quote returns the identifier quoted, the parts of the schema qualified
+// identifiers separately. Unlike strmangle's IdentQuote it quotes the names
+// of spaces and other characters too, doubling the quotes they hold, and
+// leaves the parts already quoted and * as they are.
+func (d Dialect) Quote(identifier string) string {
+	parts := strings.Split(identifier, ".")
+	for i, part := range parts {
+		if part == "*" || len(part) > 1 && rune(part[0]) == d.LQ && rune(part[len(part)-1]) == d.RQ {
+			continue
+		}
+
+		rq := string(d.RQ)
+		parts[i] = string(d.LQ) + strings.Replace(part, rq, rq+rq, -1) + rq
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// QuoteSlice returns the identifiers quoted with Quote
+func (d Dialect) QuoteSlice(identifiers []string) []string {
+	quoted := make([]string, len(identifiers))
+	for i, identifier := range identifiers {
+		quoted[i] = d.Quote(identifier)
+	}
+	return quoted
+}
+
+// UseReturningClause tells if the statements return the columns of the rows
+// they change with RETURNING, the dialects selecting them by their last
+// insert id or returning them with an OUTPUT clause do not
+func (d Dialect) UseReturningClause() bool {
+	return !d.UseLastInsertID && !d.UseOutputClause
+}
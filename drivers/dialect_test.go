@@ -0,0 +1,65 @@
+package drivers
+
+import "testing"
+
+func TestDialectPlaceholders(t *testing.T) {
+	t.Parallel()
+
+	index := Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true}
+	question := Dialect{LQ: '`', RQ: '`'}
+
+	if got := index.Placeholder(3); got != "$3" {
+		t.Errorf("want $3, got: %s", got)
+	}
+	if got := question.Placeholder(3); got != "?" {
+		t.Errorf("want ?, got: %s", got)
+	}
+
+	if got := index.PlaceholderStart(2); got != 2 {
+		t.Errorf("want 2, got: %d", got)
+	}
+	if got := question.PlaceholderStart(2); got != 0 {
+		t.Errorf("want 0, got: %d", got)
+	}
+
+	if got := index.Placeholders(4, 2, 2); got != "($2,$3),($4,$5)" {
+		t.Errorf("wrong placeholders: %s", got)
+	}
+	if got := question.Placeholders(2, 1, 1); got != "?,?" {
+		t.Errorf("wrong placeholders: %s", got)
+	}
+}
+
+func TestDialectQuote(t *testing.T) {
+	t.Parallel()
+
+	if got := (Dialect{LQ: '"', RQ: '"'}).Quote("public.pilots"); got != `"public"."pilots"` {
+		t.Errorf("wrong quoting: %s", got)
+	}
+	if got := (Dialect{LQ: '[', RQ: ']'}).Quote("pilots"); got != "[pilots]" {
+		t.Errorf("wrong quoting: %s", got)
+	}
+	if got := (Dialect{LQ: '`', RQ: '`'}).Quote("order.First Name"); got != "`order`.`First Name`" {
+		t.Errorf("wrong quoting: %s", got)
+	}
+	if got := (Dialect{LQ: '"', RQ: '"'}).Quote(`"user".*`); got != `"user".*` {
+		t.Errorf("wrong quoting: %s", got)
+	}
+	if got := (Dialect{LQ: '"', RQ: '"'}).QuoteSlice([]string{`say "hi"`}); got[0] != `"say ""hi"""` {
+		t.Errorf("wrong quoting: %s", got)
+	}
+}
+
+func TestDialectUseReturningClause(t *testing.T) {
+	t.Parallel()
+
+	if !(Dialect{UseIndexPlaceholders: true}).UseReturningClause() {
+		t.Error("want postgres to return with RETURNING")
+	}
+	if (Dialect{UseLastInsertID: true}).UseReturningClause() {
+		t.Error("want mysql to select by the last insert id")
+	}
+	if (Dialect{UseOutputClause: true}).UseReturningClause() {
+		t.Error("want mssql to return with OUTPUT")
+	}
+}
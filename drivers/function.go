@@ -0,0 +1,83 @@
+package drivers
+
+import (
+	"sort"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/strmangle"
+)
+
+// Function is a stored function or procedure of the database
+type Function struct {
+	Name string `json:"name"`
+	// IsProcedure is true for procedures, they are run with CALL
+	IsProcedure bool `json:"is_procedure"`
+	// ReturnsSet is true when the function returns any number of rows
+	ReturnsSet bool `json:"returns_set"`
+
+	// Params are the IN and INOUT parameters in order
+	Params []Column `json:"params"`
+	// Results are the OUT and INOUT parameters in order, or the columns of a
+	// function returning a table
+	Results []Column `json:"results"`
+	// Table is the table whose rows the function returns, if any
+	Table string `json:"table"`
+	// Return is the return type of a function without Results or Table, nil
+	// when it returns nothing
+	Return *Column `json:"return"`
+}
+
+// FunctionConstructor is implemented by drivers that can load the stored
+// functions and procedures of the database.
+type FunctionConstructor interface {
+	// Functions returns the functions and procedures of the schema with the
+	// database types of their params and results.
+	Functions(schema string) ([]Function, error)
+
+	// TranslateColumnType takes a Database column type and returns a go column type.
+	TranslateColumnType(Column) Column
+}
+
+// Functions returns the functions and procedures of the schema, minus the
+// ones in the blacklist, with the Go types of their params and results.
+// Results are nullable since functions can return null for any of them.
+func Functions(c FunctionConstructor, config Config) ([]Function, error) {
+	fns, err := c.Functions(config.Schema)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load functions")
+	}
+
+	translate := func(col Column, fnName string) Column {
+		if tr, ok := c.(TableColumnTypeTranslator); ok {
+			return tr.TranslateTableColumnType(col, fnName)
+		}
+		return c.TranslateColumnType(col)
+	}
+
+	ret := make([]Function, 0, len(fns))
+	for _, fn := range fns {
+		if strmangle.SetInclude(fn.Name, TablesFromList(config.BlackList)) {
+			continue
+		}
+
+		for i, p := range fn.Params {
+			fn.Params[i] = translate(p, fn.Name)
+		}
+		for i, r := range fn.Results {
+			r.Nullable = true
+			fn.Results[i] = translate(r, fn.Name)
+		}
+		if fn.Return != nil {
+			r := *fn.Return
+			r.Nullable = true
+			r = translate(r, fn.Name)
+			fn.Return = &r
+		}
+
+		ret = append(ret, fn)
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+
+	return ret, nil
+}
@@ -0,0 +1,30 @@
+package drivers
+
+// Hypertable is a TimescaleDB hypertable, the table is partitioned in chunks
+// by the time of its time column. A continuous aggregate, a view, is read like
+// the hypertable it materializes its buckets in.
+type Hypertable struct {
+	// TimeColumn is the column partitioning the table, or the column of the
+	// buckets of a continuous aggregate
+	TimeColumn string `json:"time_column"`
+	// ChunkInterval is the range of the time column in a chunk, like 7 days,
+	// or the range of the values of an integer time column
+	ChunkInterval string `json:"chunk_interval"`
+	// Aggregates is the hypertable aggregated by a continuous aggregate, it
+	// is empty for hypertables
+	Aggregates string `json:"aggregates,omitempty"`
+}
+
+// HypertableConstructor is implemented by drivers that can load the
+// hypertables of TimescaleDB. It returns nil for the tables and views that are
+// neither hypertables nor continuous aggregates, the hypertable is then set on
+// the tables and views returned by TablesConcurrently.
+type HypertableConstructor interface {
+	Hypertable(schema, tableName string) (*Hypertable, error)
+}
+
+// IsContinuousAggregate checks if the hypertable is the materialization of a
+// continuous aggregate
+func (h Hypertable) IsContinuousAggregate() bool {
+	return len(h.Aggregates) != 0
+}
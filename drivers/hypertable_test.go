@@ -0,0 +1,43 @@
+package drivers
+
+import "testing"
+
+type hypertableMockDriver struct {
+	testMockDriver
+}
+
+func (m hypertableMockDriver) Hypertable(schema, tableName string) (*Hypertable, error) {
+	if tableName == "jets" {
+		return &Hypertable{TimeColumn: "flown_at", ChunkInterval: "7 days"}, nil
+	}
+	return nil, nil
+}
+
+func TestTablesHypertable(t *testing.T) {
+	t.Parallel()
+
+	tables, err := TablesConcurrently(hypertableMockDriver{}, Config{Schema: "public", Concurrency: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jets := GetTable(tables, "jets")
+	if jets.Hypertable == nil || jets.Hypertable.TimeColumn != "flown_at" {
+		t.Errorf("want jets partitioned by flown_at, got: %#v", jets.Hypertable)
+	}
+	if jets.Hypertable.IsContinuousAggregate() {
+		t.Error("want a hypertable, not a continuous aggregate")
+	}
+
+	if pilots := GetTable(tables, "pilots"); pilots.Hypertable != nil {
+		t.Errorf("want pilots not to be a hypertable, got: %#v", pilots.Hypertable)
+	}
+}
+
+func TestHypertableIsContinuousAggregate(t *testing.T) {
+	t.Parallel()
+
+	if !(Hypertable{TimeColumn: "bucket", Aggregates: "conditions"}).IsContinuousAggregate() {
+		t.Error("want a continuous aggregate of conditions")
+	}
+}
@@ -0,0 +1,113 @@
+package drivers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/volatiletech/strmangle"
+)
+
+// InferForeignKeys finds the columns named after another table and its
+// primary key column, like user_id for users.id, that are not foreign keys,
+// for schemas relying on the naming convention instead of constraints.
+// The table is matched by the plural or the singular of the name before
+// _id, it must have a single column primary key of the same type as the
+// column and neither table can be a view.
+func InferForeignKeys(tables []Table) []ForeignKey {
+	var inferred []ForeignKey
+	for _, t := range tables {
+		if t.IsView {
+			continue
+		}
+
+		for _, c := range t.Columns {
+			if isForeignKeyColumn(t, c.Name) {
+				continue
+			}
+
+			foreignTable, ok := inferForeignTable(c.Name, tables)
+			if !ok || foreignTable.Name == t.Name {
+				continue
+			}
+
+			foreignColumn := foreignTable.GetColumn(foreignTable.PKey.Columns[0])
+			if baseType(c.Type) != baseType(foreignColumn.Type) {
+				continue
+			}
+
+			inferred = append(inferred, ForeignKey{
+				Table:         t.Name,
+				Name:          fmt.Sprintf("%s_%s_inferred_fkey", t.Name, c.Name),
+				Column:        c.Name,
+				ForeignTable:  foreignTable.Name,
+				ForeignColumn: foreignColumn.Name,
+			})
+		}
+	}
+
+	return inferred
+}
+
+// AddForeignKeys adds the foreign keys to their tables, and sets the join
+// tables, the constraints of the foreign keys and the relationships of all
+// the tables again
+func AddForeignKeys(tables []Table, fkeys []ForeignKey) {
+	if len(fkeys) == 0 {
+		return
+	}
+
+	for i := range tables {
+		t := &tables[i]
+		for _, fkey := range fkeys {
+			if fkey.Table == t.Name {
+				t.FKeys = append(t.FKeys, fkey)
+			}
+		}
+		sortForeignKeys(t)
+		setIsJoinTable(t)
+	}
+
+	for i := range tables {
+		setForeignKeyConstraints(&tables[i], tables)
+	}
+	for i := range tables {
+		setRelationships(&tables[i], tables)
+	}
+}
+
+// inferForeignTable returns the table a column named <name>_id refers to
+func inferForeignTable(column string, tables []Table) (Table, bool) {
+	lower := strings.ToLower(column)
+	if !strings.HasSuffix(lower, "_id") || len(lower) == len("_id") {
+		return Table{}, false
+	}
+
+	prefix := lower[:len(lower)-len("_id")]
+	for _, name := range []string{strmangle.Plural(prefix), prefix} {
+		for _, t := range tables {
+			if t.IsView || t.PKey == nil || len(t.PKey.Columns) != 1 {
+				continue
+			}
+			if strings.ToLower(t.Name) == name {
+				return t, true
+			}
+		}
+	}
+
+	return Table{}, false
+}
+
+func isForeignKeyColumn(t Table, column string) bool {
+	for _, fkey := range t.FKeys {
+		if fkey.Column == column {
+			return true
+		}
+	}
+	return false
+}
+
+// baseType is the go type of a column regardless of its nullability, so that
+// a null.Int64 column can refer to an int64 one
+func baseType(typ string) string {
+	return strings.TrimPrefix(strings.ToLower(typ), "null.")
+}
@@ -0,0 +1,103 @@
+package drivers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInferForeignKeys(t *testing.T) {
+	t.Parallel()
+
+	tables := []Table{
+		{
+			Name:    "pilots",
+			Columns: []Column{{Name: "id", Type: "int"}},
+			PKey:    &PrimaryKey{Columns: []string{"id"}},
+		},
+		{
+			Name: "jets",
+			Columns: []Column{
+				{Name: "id", Type: "int"},
+				{Name: "pilot_id", Type: "null.Int", Nullable: true},
+				{Name: "hangar_id", Type: "int"},
+				{Name: "airport_id", Type: "string"},
+			},
+			PKey: &PrimaryKey{Columns: []string{"id"}},
+		},
+		{
+			Name:    "airports",
+			Columns: []Column{{Name: "id", Type: "int"}},
+			PKey:    &PrimaryKey{Columns: []string{"id"}},
+		},
+		{
+			Name:    "person",
+			Columns: []Column{{Name: "id", Type: "int"}, {Name: "person_id", Type: "int"}},
+			PKey:    &PrimaryKey{Columns: []string{"id"}},
+		},
+		{
+			Name: "licenses",
+			Columns: []Column{
+				{Name: "pilot_id", Type: "int"},
+				{Name: "person_id", Type: "int"},
+			},
+			PKey: &PrimaryKey{Columns: []string{"pilot_id", "person_id"}},
+			FKeys: []ForeignKey{
+				{Table: "licenses", Name: "licenses_pilot_id_fkey", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"},
+			},
+		},
+		{
+			Name:    "pilot_names",
+			IsView:  true,
+			Columns: []Column{{Name: "pilot_id", Type: "int"}},
+		},
+	}
+
+	got := InferForeignKeys(tables)
+	want := []ForeignKey{
+		{Table: "jets", Name: "jets_pilot_id_inferred_fkey", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"},
+		{Table: "licenses", Name: "licenses_person_id_inferred_fkey", Column: "person_id", ForeignTable: "person", ForeignColumn: "id"},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want the columns matching a plural or singular table of the same type, got: %#v", got)
+	}
+}
+
+func TestAddForeignKeys(t *testing.T) {
+	t.Parallel()
+
+	tables := []Table{
+		{
+			Name:    "pilots",
+			Columns: []Column{{Name: "id", Type: "int", Unique: true}},
+			PKey:    &PrimaryKey{Columns: []string{"id"}},
+		},
+		{
+			Name:    "languages",
+			Columns: []Column{{Name: "id", Type: "int", Unique: true}},
+			PKey:    &PrimaryKey{Columns: []string{"id"}},
+		},
+		{
+			Name:    "pilot_languages",
+			Columns: []Column{{Name: "pilot_id", Type: "int"}, {Name: "language_id", Type: "int"}},
+			PKey:    &PrimaryKey{Columns: []string{"pilot_id", "language_id"}},
+		},
+	}
+
+	AddForeignKeys(tables, InferForeignKeys(tables))
+
+	join := GetTable(tables, "pilot_languages")
+	if len(join.FKeys) != 2 || join.FKeys[0].Column != "language_id" {
+		t.Fatalf("want the sorted inferred foreign keys, got: %#v", join.FKeys)
+	}
+	if !join.FKeys[0].ForeignColumnUnique {
+		t.Error("want the constraints of the inferred foreign keys set")
+	}
+	if !join.IsJoinTable {
+		t.Error("want pilot_languages to be a join table")
+	}
+
+	pilots := GetTable(tables, "pilots")
+	if len(pilots.ToManyRelationships) != 1 || pilots.ToManyRelationships[0].ForeignTable != "languages" {
+		t.Errorf("want pilots to have many languages through pilot_languages, got: %#v", pilots.ToManyRelationships)
+	}
+}
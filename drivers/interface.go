@@ -30,9 +30,10 @@ type Interface interface {
 
 // DBInfo is the database's table data and dialect.
 type DBInfo struct {
-	Schema  string  `json:"schema"`
-	Tables  []Table `json:"tables"`
-	Dialect Dialect `json:"dialect"`
+	Schema    string     `json:"schema"`
+	Tables    []Table    `json:"tables"`
+	Functions []Function `json:"functions,omitempty"`
+	Dialect   Dialect    `json:"dialect"`
 }
 
 // Dialect describes the databases requirements in terms of which features
@@ -49,6 +50,12 @@ type Dialect struct {
 	UseLastInsertID      bool `json:"use_last_insert_id"`
 	UseSchema            bool `json:"use_schema"`
 	UseDefaultKeyword    bool `json:"use_default_keyword"`
+	// UseNullsOrderClause orders nulls with NULLS FIRST and NULLS LAST,
+	// without it they are ordered with a CASE expression
+	UseNullsOrderClause bool `json:"use_nulls_order_clause"`
+	// UseStatementTimeout times out the queries of transactions with SET
+	// LOCAL statement_timeout, without it they get a context deadline
+	UseStatementTimeout bool `json:"use_statement_timeout"`
 
 	// The following is mostly for T-SQL/MSSQL, what a show
 	UseTopClause            bool `json:"use_top_clause"`
@@ -199,8 +206,27 @@ func table(c Constructor, schema string, name string, whitelist, blacklist []str
 	}
 	t.FKeys = mergeWithForeignKeyConfigs(name, t.FKeys, configForeignKeys)
 
+	if tc, ok := c.(TriggerConstructor); ok {
+		if t.Triggers, err = tc.Triggers(schema, name); err != nil {
+			return Table{}, errors.Wrapf(err, "unable to fetch table trigger info (%s)", name)
+		}
+	}
+
+	if rc, ok := c.(RowSecurityConstructor); ok {
+		if t.RowSecurity, err = rc.RowSecurity(schema, name); err != nil {
+			return Table{}, errors.Wrapf(err, "unable to fetch table row security info (%s)", name)
+		}
+	}
+
+	if hc, ok := c.(HypertableConstructor); ok {
+		if t.Hypertable, err = hc.Hypertable(schema, name); err != nil {
+			return Table{}, errors.Wrapf(err, "unable to fetch table hypertable info (%s)", name)
+		}
+	}
+
 	filterPrimaryKey(t, whitelist, blacklist)
 	filterForeignKeys(t, whitelist, blacklist)
+	sortForeignKeys(t)
 
 	setIsJoinTable(t)
 
@@ -300,6 +326,12 @@ func view(c ViewConstructor, schema string, name string, whitelist, blacklist []
 		}
 	}
 
+	if hc, ok := c.(HypertableConstructor); ok {
+		if t.Hypertable, err = hc.Hypertable(schema, name); err != nil {
+			return Table{}, errors.Wrapf(err, "unable to fetch view hypertable info (%s)", name)
+		}
+	}
+
 	return t, nil
 }
 
@@ -341,6 +373,17 @@ func filterForeignKeys(t *Table, whitelist, blacklist []string) {
 	t.FKeys = fkeys
 }
 
+// sortForeignKeys sorts the FKs by name and then column, so the order of the
+// relationships does not depend on the database or on the config's map
+func sortForeignKeys(t *Table) {
+	sort.SliceStable(t.FKeys, func(i, j int) bool {
+		if t.FKeys[i].Name != t.FKeys[j].Name {
+			return t.FKeys[i].Name < t.FKeys[j].Name
+		}
+		return t.FKeys[i].Column < t.FKeys[j].Column
+	})
+}
+
 // setIsJoinTable if there are:
 // A composite primary key involving two columns
 // Both primary key columns are also foreign keys
@@ -371,10 +414,13 @@ func setForeignKeyConstraints(t *Table, tables []Table) {
 		foreignTable := GetTable(tables, fkey.ForeignTable)
 		foreignColumn := foreignTable.GetColumn(fkey.ForeignColumn)
 
+		// The nullability follows the columns, whose types hold null or not.
+		// A foreign key of the config can be unique where the schema has no
+		// unique index, making the relationship one to one
 		t.FKeys[i].Nullable = localColumn.Nullable
-		t.FKeys[i].Unique = localColumn.Unique
+		t.FKeys[i].Unique = localColumn.Unique || fkey.Unique
 		t.FKeys[i].ForeignColumnNullable = foreignColumn.Nullable
-		t.FKeys[i].ForeignColumnUnique = foreignColumn.Unique
+		t.FKeys[i].ForeignColumnUnique = foreignColumn.Unique || fkey.ForeignColumnUnique
 	}
 }
 
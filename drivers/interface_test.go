@@ -1,6 +1,7 @@
 package drivers
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/volatiletech/strmangle"
@@ -256,6 +257,29 @@ func TestKnownColumn(t *testing.T) {
 
 }
 
+func TestSortForeignKeys(t *testing.T) {
+	t.Parallel()
+
+	table := &Table{
+		FKeys: []ForeignKey{
+			{Name: "fk_2", Column: "b"},
+			{Name: "fk_1", Column: "c"},
+			{Name: "fk_2", Column: "a"},
+		},
+	}
+
+	sortForeignKeys(table)
+
+	want := []ForeignKey{
+		{Name: "fk_1", Column: "c"},
+		{Name: "fk_2", Column: "a"},
+		{Name: "fk_2", Column: "b"},
+	}
+	if !reflect.DeepEqual(table.FKeys, want) {
+		t.Errorf("want: %#v\ngot: %#v", want, table.FKeys)
+	}
+}
+
 func TestSetIsJoinTable(t *testing.T) {
 	t.Parallel()
 
@@ -344,6 +368,34 @@ func TestSetForeignKeyConstraints(t *testing.T) {
 	}
 }
 
+func TestSetForeignKeyConstraintsHints(t *testing.T) {
+	t.Parallel()
+
+	tables := []Table{
+		{
+			Name:    "one",
+			Columns: []Column{{Name: "id", Type: "string"}},
+		},
+		{
+			Name:    "other",
+			Columns: []Column{{Name: "one_id", Type: "string"}},
+			FKeys: []ForeignKey{
+				{Column: "one_id", ForeignTable: "one", ForeignColumn: "id", Nullable: true, Unique: true, ForeignColumnUnique: true},
+			},
+		},
+	}
+
+	setForeignKeyConstraints(&tables[1], tables)
+
+	fkey := tables[1].FKeys[0]
+	if !fkey.Unique || !fkey.ForeignColumnUnique {
+		t.Error("the unique hints should be kept")
+	}
+	if fkey.Nullable {
+		t.Error("the nullability should follow the column")
+	}
+}
+
 func TestMergeWithForeignKeyConfigs(t *testing.T) {
 	t.Run("override current foreign key", func(t *testing.T) {
 		t.Parallel()
@@ -1,6 +1,9 @@
 package mocks
 
 import (
+	"encoding/json"
+	"os"
+
 	"github.com/friendsofgo/errors"
 	"github.com/volatiletech/strmangle"
 
@@ -13,7 +16,13 @@ func init() {
 }
 
 // MockDriver is a mock implementation of the bdb driver Interface
-type MockDriver struct{}
+//
+// It returns a built in schema unless tables are given in the driver config,
+// either inline (MockTables) or as a json file (MockSchemaFile) in the same
+// format as the "tables" of a driver's assemble output.
+type MockDriver struct {
+	tables []drivers.Table
+}
 
 // Templates returns the overriding templates for the driver
 func (m *MockDriver) Templates() (map[string]string, error) {
@@ -54,6 +63,8 @@ func (m *MockDriver) Assemble(config drivers.Config) (dbinfo *drivers.DBInfo, er
 			UseIndexPlaceholders: true,
 			UseLastInsertID:      false,
 			UseTopClause:         false,
+			UseNullsOrderClause:  true,
+			UseStatementTimeout:  true,
 		},
 	}
 
@@ -70,11 +81,23 @@ func (m *MockDriver) Assemble(config drivers.Config) (dbinfo *drivers.DBInfo, er
 
 	config.Concurrency = 1
 
+	m.tables, err = loadTables(config)
+	if err != nil {
+		return nil, err
+	}
+
 	dbinfo.Tables, err = drivers.TablesConcurrently(m, config)
 	if err != nil {
 		return nil, err
 	}
 
+	if config.AddFunctions {
+		dbinfo.Functions, err = drivers.Functions(m, config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return dbinfo, err
 }
 
@@ -85,71 +108,148 @@ func validateDriverConfig(config drivers.Config) error {
 	return nil
 }
 
+// loadTables returns the tables configured for the mock driver, nil means
+// the built in schema is used
+func loadTables(config drivers.Config) ([]drivers.Table, error) {
+	tables := config.MockTables
+
+	if len(config.MockSchemaFile) != 0 {
+		b, err := os.ReadFile(config.MockSchemaFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read mock schema file")
+		}
+
+		var dbinfo drivers.DBInfo
+		if err := json.Unmarshal(b, &dbinfo); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse mock schema file %s", config.MockSchemaFile)
+		}
+		tables = append(tables, dbinfo.Tables...)
+	}
+
+	for _, t := range tables {
+		if len(t.Name) == 0 {
+			return nil, errors.New("mock table must have a name")
+		}
+	}
+
+	return tables, nil
+}
+
+func (m *MockDriver) schema() []drivers.Table {
+	if m.tables != nil {
+		return m.tables
+	}
+	return defaultTables
+}
+
+func (m *MockDriver) table(name string) (drivers.Table, bool) {
+	for _, t := range m.schema() {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return drivers.Table{}, false
+}
+
 // TableNames returns a list of mock table names
 func (m *MockDriver) TableNames(schema string, whitelist, blacklist []string) ([]string, error) {
-	if len(whitelist) > 0 {
-		return whitelist, nil
+	if tables := drivers.TablesFromList(whitelist); len(tables) > 0 {
+		return tables, nil
+	}
+
+	tables := m.schema()
+	names := make([]string, len(tables))
+	for i, t := range tables {
+		names[i] = t.Name
 	}
-	tables := []string{"pilots", "jets", "airports", "licenses", "hangars", "languages", "pilot_languages"}
-	return strmangle.SetComplement(tables, blacklist), nil
+	return strmangle.SetComplement(names, blacklist), nil
 }
 
-// Columns returns a list of mock columns
+// Columns returns a list of mock columns, filtered by the table.column
+// entries of the whitelist or blacklist like the real drivers do
 func (m *MockDriver) Columns(schema, tableName string, whitelist, blacklist []string) ([]drivers.Column, error) {
-	return map[string][]drivers.Column{
-		"pilots": {
-			{Name: "id", Type: "int", DBType: "integer"},
-			{Name: "name", Type: "string", DBType: "character"},
-		},
-		"airports": {
-			{Name: "id", Type: "int", DBType: "integer"},
-			{Name: "size", Type: "null.Int", DBType: "integer", Nullable: true},
-		},
-		"jets": {
-			{Name: "id", Type: "int", DBType: "integer"},
-			{Name: "pilot_id", Type: "int", DBType: "integer", Nullable: true, Unique: true},
-			{Name: "airport_id", Type: "int", DBType: "integer"},
-			{Name: "name", Type: "string", DBType: "character", Nullable: false},
-			{Name: "color", Type: "null.String", DBType: "character", Nullable: true},
-			{Name: "uuid", Type: "string", DBType: "uuid", Nullable: true},
-			{Name: "identifier", Type: "string", DBType: "uuid", Nullable: false},
-			{Name: "cargo", Type: "[]byte", DBType: "bytea", Nullable: false},
-			{Name: "manifest", Type: "[]byte", DBType: "bytea", Nullable: true, Unique: true},
-		},
-		"licenses": {
-			{Name: "id", Type: "int", DBType: "integer"},
-			{Name: "pilot_id", Type: "int", DBType: "integer"},
-		},
-		"hangars": {
-			{Name: "id", Type: "int", DBType: "integer"},
-			{Name: "name", Type: "string", DBType: "character", Nullable: true, Unique: true},
-		},
-		"languages": {
-			{Name: "id", Type: "int", DBType: "integer"},
-			{Name: "language", Type: "string", DBType: "character", Nullable: false, Unique: true},
-		},
-		"pilot_languages": {
-			{Name: "pilot_id", Type: "int", DBType: "integer"},
-			{Name: "language_id", Type: "int", DBType: "integer"},
-		},
-	}[tableName], nil
+	t, ok := m.table(tableName)
+	if !ok {
+		return nil, nil
+	}
+
+	var filter []string
+	keep := false
+	if len(whitelist) > 0 {
+		filter, keep = drivers.ColumnsFromList(whitelist, tableName), true
+	} else if len(blacklist) > 0 {
+		filter = drivers.ColumnsFromList(blacklist, tableName)
+	}
+
+	if len(filter) == 0 {
+		return t.Columns, nil
+	}
+
+	var columns []drivers.Column
+	for _, c := range t.Columns {
+		if strmangle.SetInclude(c.Name, filter) == keep {
+			columns = append(columns, c)
+		}
+	}
+	return columns, nil
 }
 
 // ForeignKeyInfo returns a list of mock foreignkeys
 func (m *MockDriver) ForeignKeyInfo(schema, tableName string) ([]drivers.ForeignKey, error) {
-	return map[string][]drivers.ForeignKey{
-		"jets": {
-			{Table: "jets", Name: "jets_pilot_id_fk", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id", ForeignColumnUnique: true},
-			{Table: "jets", Name: "jets_airport_id_fk", Column: "airport_id", ForeignTable: "airports", ForeignColumn: "id"},
-		},
-		"licenses": {
-			{Table: "licenses", Name: "licenses_pilot_id_fk", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"},
-		},
-		"pilot_languages": {
-			{Table: "pilot_languages", Name: "pilot_id_fk", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"},
-			{Table: "pilot_languages", Name: "jet_id_fk", Column: "language_id", ForeignTable: "languages", ForeignColumn: "id"},
-		},
-	}[tableName], nil
+	t, ok := m.table(tableName)
+	if !ok {
+		return nil, nil
+	}
+
+	fkeys := make([]drivers.ForeignKey, len(t.FKeys))
+	for i, fkey := range t.FKeys {
+		if fkey.Table == "" {
+			fkey.Table = tableName
+		}
+		fkeys[i] = fkey
+	}
+	return fkeys, nil
+}
+
+// Triggers returns the triggers of the mock table
+func (m *MockDriver) Triggers(schema, tableName string) ([]drivers.Trigger, error) {
+	if t, ok := m.table(tableName); ok {
+		return t.Triggers, nil
+	}
+	return nil, nil
+}
+
+// RowSecurity returns the row level security of the mock table
+func (m *MockDriver) RowSecurity(schema, tableName string) (*drivers.RowSecurity, error) {
+	if t, ok := m.table(tableName); ok {
+		return t.RowSecurity, nil
+	}
+	return nil, nil
+}
+
+// Hypertable returns the hypertable of the mock table
+func (m *MockDriver) Hypertable(schema, tableName string) (*drivers.Hypertable, error) {
+	if t, ok := m.table(tableName); ok {
+		return t.Hypertable, nil
+	}
+	return nil, nil
+}
+
+// Functions returns the mock functions, only the built in schema has any
+func (m *MockDriver) Functions(schema string) ([]drivers.Function, error) {
+	if m.tables != nil {
+		return nil, nil
+	}
+
+	// Copy the params and results so translating their types leaves the
+	// defaults untouched
+	fns := make([]drivers.Function, len(defaultFunctions))
+	for i, fn := range defaultFunctions {
+		fn.Params = append([]drivers.Column(nil), fn.Params...)
+		fn.Results = append([]drivers.Column(nil), fn.Results...)
+		fns[i] = fn
+	}
+	return fns, nil
 }
 
 // TranslateColumnType converts a column to its "null." form if it is nullable
@@ -168,6 +268,8 @@ func (m *MockDriver) TranslateColumnType(c drivers.Column) drivers.Column {
 			c.Type = "null.Byte"
 		case "bytea":
 			c.Type = "null.Bytes"
+		case "json", "jsonb":
+			c.Type = "null.JSON"
 		case "boolean":
 			c.Type = "null.Bool"
 		case "date", "time", "timestamp without time zone", "timestamp with time zone":
@@ -189,6 +291,8 @@ func (m *MockDriver) TranslateColumnType(c drivers.Column) drivers.Column {
 			c.Type = "types.Byte"
 		case "bytea":
 			c.Type = "[]byte"
+		case "json", "jsonb":
+			c.Type = "types.JSON"
 		case "boolean":
 			c.Type = "bool"
 		case "date", "time", "timestamp without time zone", "timestamp with time zone":
@@ -203,36 +307,10 @@ func (m *MockDriver) TranslateColumnType(c drivers.Column) drivers.Column {
 
 // PrimaryKeyInfo returns mock primary key info for the passed in table name
 func (m *MockDriver) PrimaryKeyInfo(schema, tableName string) (*drivers.PrimaryKey, error) {
-	return map[string]*drivers.PrimaryKey{
-		"pilots": {
-			Name:    "pilot_id_pkey",
-			Columns: []string{"id"},
-		},
-		"airports": {
-			Name:    "airport_id_pkey",
-			Columns: []string{"id"},
-		},
-		"jets": {
-			Name:    "jet_id_pkey",
-			Columns: []string{"id"},
-		},
-		"licenses": {
-			Name:    "license_id_pkey",
-			Columns: []string{"id"},
-		},
-		"hangars": {
-			Name:    "hangar_id_pkey",
-			Columns: []string{"id"},
-		},
-		"languages": {
-			Name:    "language_id_pkey",
-			Columns: []string{"id"},
-		},
-		"pilot_languages": {
-			Name:    "pilot_languages_pkey",
-			Columns: []string{"pilot_id", "language_id"},
-		},
-	}[tableName], nil
+	if t, ok := m.table(tableName); ok {
+		return t.PKey, nil
+	}
+	return nil, nil
 }
 
 // UseLastInsertID returns a database mock LastInsertID compatibility flag
@@ -0,0 +1,212 @@
+package mocks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestMockDefaultSchema(t *testing.T) {
+	t.Parallel()
+
+	m := &MockDriver{}
+	dbinfo, err := m.Assemble(drivers.Config{Schema: "schema"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dbinfo.Tables) != len(defaultTables) {
+		t.Errorf("want %d tables, got: %d", len(defaultTables), len(dbinfo.Tables))
+	}
+}
+
+func TestMockConfiguredSchema(t *testing.T) {
+	t.Parallel()
+
+	schemaFile := filepath.Join(t.TempDir(), "schema.json")
+	err := os.WriteFile(schemaFile, []byte(`{"tables": [
+		{
+			"name": "orders",
+			"columns": [
+				{"name": "id", "db_type": "integer"},
+				{"name": "user_id", "db_type": "integer"}
+			],
+			"p_key": {"name": "orders_pkey", "columns": ["id"]},
+			"f_keys": [
+				{"name": "orders_user_id_fkey", "column": "user_id", "foreign_table": "users", "foreign_column": "id"}
+			]
+		}
+	]}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &MockDriver{}
+	dbinfo, err := m.Assemble(drivers.Config{
+		Schema: "schema",
+		MockTables: []drivers.Table{
+			{
+				Name: "users",
+				Columns: []drivers.Column{
+					{Name: "id", DBType: "integer"},
+					{Name: "email", DBType: "text", Nullable: true},
+				},
+				PKey: &drivers.PrimaryKey{Name: "users_pkey", Columns: []string{"id"}},
+			},
+		},
+		MockSchemaFile: schemaFile,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dbinfo.Tables) != 2 {
+		t.Fatalf("want 2 tables, got: %d", len(dbinfo.Tables))
+	}
+
+	orders := drivers.GetTable(dbinfo.Tables, "orders")
+	if len(orders.FKeys) != 1 || orders.FKeys[0].Table != "orders" {
+		t.Errorf("foreign key was wrong: %#v", orders.FKeys)
+	}
+	if orders.PKey == nil || orders.PKey.Name != "orders_pkey" {
+		t.Errorf("primary key was wrong: %#v", orders.PKey)
+	}
+
+	users := drivers.GetTable(dbinfo.Tables, "users")
+	if email := users.GetColumn("email"); email.Type != "null.String" {
+		t.Errorf("column type should be translated, got: %s", email.Type)
+	}
+	if len(users.ToManyRelationships) != 1 {
+		t.Errorf("users should have a to many relationship to orders, got: %#v", users.ToManyRelationships)
+	}
+}
+
+func TestMockInvalidSchema(t *testing.T) {
+	t.Parallel()
+
+	m := &MockDriver{}
+	_, err := m.Assemble(drivers.Config{
+		Schema:     "schema",
+		MockTables: []drivers.Table{{}},
+	})
+	if err == nil {
+		t.Error("expected an error for a table without a name")
+	}
+
+	_, err = m.Assemble(drivers.Config{
+		Schema:         "schema",
+		MockSchemaFile: filepath.Join(t.TempDir(), "missing.json"),
+	})
+	if err == nil {
+		t.Error("expected an error for a missing schema file")
+	}
+}
+
+func TestMockColumnFilters(t *testing.T) {
+	t.Parallel()
+
+	m := &MockDriver{}
+	dbinfo, err := m.Assemble(drivers.Config{
+		Schema:    "schema",
+		BlackList: []string{"hangars", "jets.color", "*.uuid"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jets := drivers.GetTable(dbinfo.Tables, "jets")
+	for _, c := range jets.Columns {
+		if c.Name == "color" || c.Name == "uuid" {
+			t.Errorf("column %s should have been blacklisted", c.Name)
+		}
+	}
+
+	dbinfo, err = m.Assemble(drivers.Config{
+		Schema:    "schema",
+		WhiteList: []string{"pilots", "pilots.id"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dbinfo.Tables) != 1 || len(dbinfo.Tables[0].Columns) != 1 {
+		t.Errorf("only pilots.id should have been whitelisted: %#v", dbinfo.Tables)
+	}
+}
+
+func TestMockFunctions(t *testing.T) {
+	t.Parallel()
+
+	m := &MockDriver{}
+	dbinfo, err := m.Assemble(drivers.Config{Schema: "schema", AddFunctions: true, BlackList: []string{"retire_pilot"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := len(dbinfo.Functions); got != len(defaultFunctions)-1 {
+		t.Fatalf("want %d functions, got: %d", len(defaultFunctions)-1, got)
+	}
+	for _, fn := range dbinfo.Functions {
+		if fn.Name == "retire_pilot" {
+			t.Error("blacklisted function was loaded")
+		}
+	}
+
+	count := dbinfo.Functions[2]
+	if count.Name != "pilot_count" || count.Return == nil {
+		t.Fatalf("want pilot_count with a return, got: %#v", count)
+	}
+	if count.Return.Type != "null.Int64" {
+		t.Errorf("want the return to be nullable, got: %s", count.Return.Type)
+	}
+
+	stats := dbinfo.Functions[4]
+	if stats.Params[0].Type != "int" || stats.Results[0].Type != "null.Int64" {
+		t.Errorf("wrong types: %#v", stats)
+	}
+	if defaultFunctions[4].Results[0].Type != "" {
+		t.Error("the default functions were modified")
+	}
+
+	dbinfo, err = m.Assemble(drivers.Config{Schema: "schema"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dbinfo.Functions) != 0 {
+		t.Error("want no functions unless they are added")
+	}
+}
+
+func TestMockTriggers(t *testing.T) {
+	t.Parallel()
+
+	trigger := drivers.Trigger{Name: "touch", Timing: "BEFORE", Events: []string{"UPDATE"}, ForEachRow: true, Body: "NEW.updated_at := now();"}
+
+	m := &MockDriver{}
+	dbinfo, err := m.Assemble(drivers.Config{
+		Schema: "schema",
+		MockTables: []drivers.Table{
+			{
+				Name: "videos",
+				Columns: []drivers.Column{
+					{Name: "id", DBType: "integer"},
+					{Name: "updated_at", DBType: "timestamp with time zone"},
+				},
+				PKey:     &drivers.PrimaryKey{Name: "videos_pkey", Columns: []string{"id"}},
+				Triggers: []drivers.Trigger{trigger},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := dbinfo.Tables[0].Triggers; len(got) != 1 || got[0].Name != "touch" {
+		t.Fatalf("want the trigger of the table, got: %#v", got)
+	}
+	if !dbinfo.Tables[0].TriggerSets("UPDATE", "updated_at") {
+		t.Error("want updated_at to be set by the trigger")
+	}
+}
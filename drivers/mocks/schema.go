@@ -0,0 +1,128 @@
+package mocks
+
+import "github.com/volatiletech/sqlboiler/v4/drivers"
+
+// defaultTables is the schema returned when no mock tables are configured
+var defaultTables = []drivers.Table{
+	{
+		Name: "pilots",
+		Columns: []drivers.Column{
+			{Name: "id", Type: "int", DBType: "integer"},
+			{Name: "name", Type: "string", DBType: "character"},
+		},
+		PKey: &drivers.PrimaryKey{Name: "pilot_id_pkey", Columns: []string{"id"}},
+	},
+	{
+		Name: "jets",
+		Columns: []drivers.Column{
+			{Name: "id", Type: "int", DBType: "integer"},
+			{Name: "pilot_id", Type: "int", DBType: "integer", Nullable: true, Unique: true},
+			{Name: "airport_id", Type: "int", DBType: "integer"},
+			{Name: "name", Type: "string", DBType: "character", Nullable: false},
+			{Name: "color", Type: "null.String", DBType: "character", Nullable: true},
+			{Name: "uuid", Type: "string", DBType: "uuid", Nullable: true},
+			{Name: "identifier", Type: "string", DBType: "uuid", Nullable: false},
+			{Name: "cargo", Type: "[]byte", DBType: "bytea", Nullable: false},
+			{Name: "manifest", Type: "[]byte", DBType: "bytea", Nullable: true, Unique: true},
+		},
+		PKey: &drivers.PrimaryKey{Name: "jet_id_pkey", Columns: []string{"id"}},
+		FKeys: []drivers.ForeignKey{
+			{Table: "jets", Name: "jets_pilot_id_fk", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id", ForeignColumnUnique: true},
+			{Table: "jets", Name: "jets_airport_id_fk", Column: "airport_id", ForeignTable: "airports", ForeignColumn: "id"},
+		},
+	},
+	{
+		Name: "airports",
+		Columns: []drivers.Column{
+			{Name: "id", Type: "int", DBType: "integer"},
+			{Name: "size", Type: "null.Int", DBType: "integer", Nullable: true},
+			{Name: "details", Type: "null.JSON", DBType: "jsonb", Nullable: true},
+		},
+		PKey: &drivers.PrimaryKey{Name: "airport_id_pkey", Columns: []string{"id"}},
+	},
+	{
+		Name: "licenses",
+		Columns: []drivers.Column{
+			{Name: "id", Type: "int", DBType: "integer"},
+			{Name: "pilot_id", Type: "int", DBType: "integer"},
+		},
+		PKey: &drivers.PrimaryKey{Name: "license_id_pkey", Columns: []string{"id"}},
+		FKeys: []drivers.ForeignKey{
+			{Table: "licenses", Name: "licenses_pilot_id_fk", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"},
+		},
+		RowSecurity: &drivers.RowSecurity{
+			Policies: []drivers.Policy{
+				{Name: "licenses_pilot", Command: "ALL", Roles: []string{"public"}, Using: "(pilot_id = (current_setting('app.current_pilot'::text))::integer)"},
+			},
+		},
+	},
+	{
+		Name: "hangars",
+		Columns: []drivers.Column{
+			{Name: "id", Type: "int", DBType: "integer"},
+			{Name: "name", Type: "string", DBType: "character", Nullable: true, Unique: true},
+			{Name: "search", Type: "string", DBType: "tsvector", Nullable: true, AutoGenerated: true},
+		},
+		PKey: &drivers.PrimaryKey{Name: "hangar_id_pkey", Columns: []string{"id"}},
+	},
+	{
+		Name: "languages",
+		Columns: []drivers.Column{
+			{Name: "id", Type: "int", DBType: "integer"},
+			{Name: "language", Type: "string", DBType: "character", Nullable: false, Unique: true},
+		},
+		PKey: &drivers.PrimaryKey{Name: "language_id_pkey", Columns: []string{"id"}},
+	},
+	{
+		Name: "pilot_languages",
+		Columns: []drivers.Column{
+			{Name: "pilot_id", Type: "int", DBType: "integer"},
+			{Name: "language_id", Type: "int", DBType: "integer"},
+		},
+		PKey: &drivers.PrimaryKey{Name: "pilot_languages_pkey", Columns: []string{"pilot_id", "language_id"}},
+		FKeys: []drivers.ForeignKey{
+			{Table: "pilot_languages", Name: "pilot_id_fk", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"},
+			{Table: "pilot_languages", Name: "jet_id_fk", Column: "language_id", ForeignTable: "languages", ForeignColumn: "id"},
+		},
+	},
+}
+
+// defaultFunctions are the functions of the built in schema
+var defaultFunctions = []drivers.Function{
+	{
+		Name:       "airport_sizes",
+		ReturnsSet: true,
+		Results: []drivers.Column{
+			{Name: "size", DBType: "integer"},
+			{Name: "airports", DBType: "bigint"},
+		},
+	},
+	{
+		Name:       "jets_of_pilot",
+		ReturnsSet: true,
+		Params:     []drivers.Column{{Name: "pilot_id", DBType: "integer"}},
+		Table:      "jets",
+	},
+	{
+		Name:   "pilot_count",
+		Return: &drivers.Column{Name: "pilot_count", DBType: "bigint"},
+	},
+	{
+		Name:       "pilot_names",
+		ReturnsSet: true,
+		Return:     &drivers.Column{Name: "pilot_names", DBType: "character"},
+	},
+	{
+		Name:   "pilot_stats",
+		Params: []drivers.Column{{Name: "pilot_id", DBType: "integer"}},
+		Results: []drivers.Column{
+			{Name: "jets", DBType: "bigint"},
+			{Name: "languages", DBType: "bigint"},
+		},
+	},
+	{
+		Name:        "retire_pilot",
+		IsProcedure: true,
+		Params:      []drivers.Column{{Name: "pilot_id", DBType: "integer"}},
+	},
+}
@@ -26,13 +26,20 @@ func RegisterFromInit(name string, driver Interface) {
 
 // GetDriver retrieves the driver by name
 func GetDriver(name string) Interface {
-	if d, ok := registeredDrivers[name]; ok {
+	if d, ok := LookupDriver(name); ok {
 		return d
 	}
 
 	panic(fmt.Sprintf("drivers: sqlboiler driver %s has not been registered", name))
 }
 
+// LookupDriver retrieves the driver by name, it returns false if it has not
+// been registered
+func LookupDriver(name string) (Interface, bool) {
+	d, ok := registeredDrivers[name]
+	return d, ok
+}
+
 func register(name string, driver Interface) {
 	if _, ok := registeredDrivers[name]; ok {
 		panic(fmt.Sprintf("drivers: sqlboiler driver %s already loaded", name))
@@ -0,0 +1,56 @@
+package drivers
+
+import (
+	"regexp"
+	"sort"
+)
+
+// RowSecurity is the row level security of a table
+type RowSecurity struct {
+	// Forced applies the policies to the owner of the table too
+	Forced   bool     `json:"forced"`
+	Policies []Policy `json:"policies"`
+}
+
+// Policy is a row level security policy of a table
+type Policy struct {
+	Name string `json:"name"`
+	// Command is the statement the policy applies to: ALL, SELECT, INSERT,
+	// UPDATE or DELETE
+	Command string   `json:"command"`
+	Roles   []string `json:"roles"`
+	// Using is the expression the existing rows must satisfy
+	Using string `json:"using"`
+	// Check is the expression the new rows must satisfy
+	Check string `json:"check"`
+}
+
+// RowSecurityConstructor is implemented by drivers that can load the row
+// level security of a table. It returns nil for tables without row level
+// security enabled, the row security is then set on the tables returned by
+// TablesConcurrently.
+type RowSecurityConstructor interface {
+	RowSecurity(schema, tableName string) (*RowSecurity, error)
+}
+
+var rxCurrentSetting = regexp.MustCompile(`(?i)current_setting\(\s*'([^']+)'`)
+
+// Settings returns the settings read with current_setting by the expressions
+// of the policies, app.current_user for example
+func (r RowSecurity) Settings() []string {
+	found := make(map[string]bool)
+	for _, p := range r.Policies {
+		for _, expr := range []string{p.Using, p.Check} {
+			for _, m := range rxCurrentSetting.FindAllStringSubmatch(expr, -1) {
+				found[m[1]] = true
+			}
+		}
+	}
+
+	settings := make([]string, 0, len(found))
+	for s := range found {
+		settings = append(settings, s)
+	}
+	sort.Strings(settings)
+	return settings
+}
@@ -0,0 +1,28 @@
+package drivers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRowSecuritySettings(t *testing.T) {
+	t.Parallel()
+
+	rs := RowSecurity{
+		Policies: []Policy{
+			{Name: "owner", Command: "ALL", Using: "(owner = current_setting('app.current_user'::text))"},
+			{Name: "org", Command: "INSERT", Check: "(org_id = (CURRENT_SETTING( 'app.current_org', true))::integer)"},
+			{Name: "both", Command: "UPDATE", Using: "(owner = current_setting('app.current_user'::text))", Check: "true"},
+			{Name: "public", Command: "SELECT", Using: "published"},
+		},
+	}
+
+	want := []string{"app.current_org", "app.current_user"}
+	if got := rs.Settings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	if got := (RowSecurity{}).Settings(); len(got) != 0 {
+		t.Errorf("want no settings without policies, got %v", got)
+	}
+}
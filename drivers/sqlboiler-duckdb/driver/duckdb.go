@@ -0,0 +1,578 @@
+// Package driver is a DuckDB driver, it reads the schema from the
+// duckdb_tables, duckdb_columns and duckdb_constraints functions of the
+// catalog through the database/sql driver of go-duckdb, which has to be
+// registered as duckdb.
+package driver
+
+import (
+	"database/sql"
+	"embed"
+	"encoding/base64"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+//go:embed override
+var templates embed.FS
+
+func init() {
+	drivers.RegisterFromInit("duckdb", &DuckDBDriver{})
+}
+
+// Assemble is more useful for calling into the library so you don't
+// have to instantiate an empty type.
+func Assemble(config drivers.Config) (dbinfo *drivers.DBInfo, err error) {
+	driver := DuckDBDriver{}
+	return driver.Assemble(config)
+}
+
+// DuckDBDriver holds the database connection string and a handle
+// to the database connection.
+type DuckDBDriver struct {
+	connStr string
+	conn    *sql.DB
+}
+
+// Templates that should be added/overridden
+func (DuckDBDriver) Templates() (map[string]string, error) {
+	tpls := make(map[string]string)
+	fs.WalkDir(templates, "override", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		b, err := fs.ReadFile(templates, path)
+		if err != nil {
+			return err
+		}
+		tpls[strings.Replace(path, "override/", "", 1)] = base64.StdEncoding.EncodeToString(b)
+
+		return nil
+	})
+
+	return tpls, nil
+}
+
+// Assemble all the information we need to provide back to the driver
+func (d *DuckDBDriver) Assemble(config drivers.Config) (dbinfo *drivers.DBInfo, err error) {
+	defer func() {
+		if r := recover(); r != nil && err == nil {
+			dbinfo = nil
+			err = r.(error)
+		}
+	}()
+
+	if err := validateDriverConfig(config); err != nil {
+		panic(errors.Wrap(err, "validate driver config"))
+	}
+	fillDefaultDriverConfig(&config)
+
+	d.connStr = DuckDBBuildQueryString(config.DBName)
+	d.conn, err = sql.Open("duckdb", d.connStr)
+	if err != nil {
+		return nil, errors.Wrap(err, "sqlboiler-duckdb failed to connect to database")
+	}
+
+	defer func() {
+		if e := d.conn.Close(); e != nil {
+			dbinfo = nil
+			err = e
+		}
+	}()
+
+	dbinfo = &drivers.DBInfo{
+		Schema: config.Schema,
+		Dialect: drivers.Dialect{
+			LQ: '"',
+			RQ: '"',
+
+			UseSchema:           config.Schema != "main",
+			UseDefaultKeyword:   true,
+			UseNullsOrderClause: true,
+		},
+	}
+
+	dbinfo.Tables, err = drivers.TablesConcurrently(d, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbinfo, err
+}
+
+func validateDriverConfig(config drivers.Config) error {
+	if config.DBName == "" {
+		return errors.New("missing dbname")
+	}
+	return nil
+}
+
+func fillDefaultDriverConfig(config *drivers.Config) {
+	if config.Schema == "" {
+		config.Schema = "main"
+	}
+	if config.Concurrency == 0 {
+		config.Concurrency = drivers.DefaultConcurrency
+	}
+}
+
+// DuckDBBuildQueryString builds a query string for DuckDB, the file is
+// opened read only so the generation can run next to the pipelines writing it.
+func DuckDBBuildQueryString(file string) string {
+	return file + "?access_mode=READ_ONLY"
+}
+
+// TableNames connects to the duckdb database and
+// retrieves all table names from duckdb_tables.
+func (d *DuckDBDriver) TableNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	query := `SELECT table_name FROM duckdb_tables() WHERE database_name = current_database() AND schema_name = ? AND NOT internal AND NOT temporary`
+	return d.names(query, "table_name", schema, whitelist, blacklist)
+}
+
+// ViewNames connects to the duckdb database and
+// retrieves all view names from duckdb_views.
+func (d *DuckDBDriver) ViewNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	query := `SELECT view_name FROM duckdb_views() WHERE database_name = current_database() AND schema_name = ? AND NOT internal AND NOT temporary`
+	return d.names(query, "view_name", schema, whitelist, blacklist)
+}
+
+func (d *DuckDBDriver) names(query, column, schema string, whitelist, blacklist []string) ([]string, error) {
+	var names []string
+
+	args := []interface{}{schema}
+	if len(whitelist) > 0 {
+		tables := drivers.TablesFromList(whitelist)
+		if len(tables) > 0 {
+			query += fmt.Sprintf(" AND %s IN (%s)", column, strings.Repeat(",?", len(tables))[1:])
+			for _, w := range tables {
+				args = append(args, w)
+			}
+		}
+	} else if len(blacklist) > 0 {
+		tables := drivers.TablesFromList(blacklist)
+		if len(tables) > 0 {
+			query += fmt.Sprintf(" AND %s NOT IN (%s)", column, strings.Repeat(",?", len(tables))[1:])
+			for _, b := range tables {
+				args = append(args, b)
+			}
+		}
+	}
+
+	query += ` ORDER BY ` + column
+
+	rows, err := d.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
+// ViewCapabilities return what actions are allowed for a view.
+func (d *DuckDBDriver) ViewCapabilities(schema, name string) (drivers.ViewCapabilities, error) {
+	// DuckDB views cannot be written to
+	capabilities := drivers.ViewCapabilities{
+		CanInsert: false,
+		CanUpsert: false,
+	}
+
+	return capabilities, nil
+}
+
+func (d *DuckDBDriver) ViewColumns(schema, tableName string, whitelist, blacklist []string) ([]drivers.Column, error) {
+	return d.Columns(schema, tableName, whitelist, blacklist)
+}
+
+// Columns takes a table name and attempts to retrieve the table information
+// from duckdb_columns. It retrieves the column names
+// and column types and returns those as a []Column after TranslateColumnType()
+// converts the SQL types to Go types, for example: "VARCHAR" to "string"
+func (d *DuckDBDriver) Columns(schema, tableName string, whitelist, blacklist []string) ([]drivers.Column, error) {
+	var columns []drivers.Column
+
+	args := []interface{}{schema, tableName}
+	query := `
+	SELECT c.column_name, c.data_type, c.is_nullable, c.column_default,
+		EXISTS (
+			SELECT 1 FROM duckdb_constraints() k
+			WHERE k.database_name = c.database_name AND k.schema_name = c.schema_name AND k.table_name = c.table_name
+				AND k.constraint_type IN ('PRIMARY KEY', 'UNIQUE')
+				AND len(k.constraint_column_names) = 1 AND k.constraint_column_names[1] = c.column_name
+		)
+	FROM duckdb_columns() c
+	WHERE c.database_name = current_database() AND c.schema_name = ? AND c.table_name = ?`
+
+	if len(whitelist) > 0 {
+		cols := drivers.ColumnsFromList(whitelist, tableName)
+		if len(cols) > 0 {
+			query += fmt.Sprintf(" AND c.column_name IN (%s)", strings.Repeat(",?", len(cols))[1:])
+			for _, w := range cols {
+				args = append(args, w)
+			}
+		}
+	} else if len(blacklist) > 0 {
+		cols := drivers.ColumnsFromList(blacklist, tableName)
+		if len(cols) > 0 {
+			query += fmt.Sprintf(" AND c.column_name NOT IN (%s)", strings.Repeat(",?", len(cols))[1:])
+			for _, w := range cols {
+				args = append(args, w)
+			}
+		}
+	}
+
+	query += ` ORDER BY c.column_index`
+
+	rows, err := d.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var colName, colType string
+		var nullable, unique bool
+		var defaultValue *string
+		if err := rows.Scan(&colName, &colType, &nullable, &defaultValue, &unique); err != nil {
+			return nil, errors.Wrapf(err, "unable to scan for table %s", tableName)
+		}
+
+		column := drivers.Column{
+			Name:       colName,
+			FullDBType: colType,
+			DBType:     colType,
+			Nullable:   nullable,
+			Unique:     unique,
+		}
+
+		if defaultValue != nil {
+			column.Default = *defaultValue
+		} else if nullable {
+			column.Default = "NULL"
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, rows.Err()
+}
+
+// PrimaryKeyInfo looks up the primary key for a table.
+func (d *DuckDBDriver) PrimaryKeyInfo(schema, tableName string) (*drivers.PrimaryKey, error) {
+	query := `
+	SELECT UNNEST(constraint_column_names) FROM duckdb_constraints()
+	WHERE database_name = current_database() AND schema_name = ? AND table_name = ? AND constraint_type = 'PRIMARY KEY'`
+
+	rows, err := d.conn.Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var pk *drivers.PrimaryKey
+	if len(columns) > 0 {
+		pk = &drivers.PrimaryKey{Name: tableName + "_pkey", Columns: columns}
+	}
+	return pk, nil
+}
+
+// ForeignKeyInfo retrieves the foreign keys for a given table name.
+func (d *DuckDBDriver) ForeignKeyInfo(schema, tableName string) ([]drivers.ForeignKey, error) {
+	var fkeys []drivers.ForeignKey
+
+	// The column names of a constraint and the ones it references are
+	// unnested side by side, one row per column
+	query := `
+	SELECT constraint_index, UNNEST(constraint_column_names), referenced_table, UNNEST(referenced_column_names)
+	FROM duckdb_constraints()
+	WHERE database_name = current_database() AND schema_name = ? AND table_name = ? AND constraint_type = 'FOREIGN KEY'
+	ORDER BY constraint_index`
+
+	rows, err := d.conn.Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var indexes []int64
+	var columns = make(map[int64][]string)
+	for rows.Next() {
+		var fkey drivers.ForeignKey
+		var index int64
+
+		fkey.Table = tableName
+		if err = rows.Scan(&index, &fkey.Column, &fkey.ForeignTable, &fkey.ForeignColumn); err != nil {
+			return nil, err
+		}
+
+		columns[index] = append(columns[index], fkey.Column)
+		indexes = append(indexes, index)
+		fkeys = append(fkeys, fkey)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, index := range indexes {
+		fkeys[i].Name = foreignKeyName(tableName, columns[index])
+	}
+
+	return fkeys, nil
+}
+
+// foreignKeyName names a foreign key after its table and columns like
+// postgres does, DuckDB does not name them
+func foreignKeyName(tableName string, columns []string) string {
+	return fmt.Sprintf("%s_%s_fkey", tableName, strings.Join(columns, "_"))
+}
+
+// TranslateColumnType converts duckdb database types to Go types, for example
+// "VARCHAR" to "string" and "HUGEINT" to "duckdb.HugeInt". It returns this
+// parsed data as a Column object.
+// https://duckdb.org/docs/sql/data_types/overview
+func (DuckDBDriver) TranslateColumnType(c drivers.Column) drivers.Column {
+	switch {
+	case strings.HasSuffix(c.DBType, "]"):
+		// The lists, like INTEGER[], and the arrays of a fixed size,
+		// like INTEGER[3], are nil when null
+		c.Type = "duckdb.List"
+		return c
+	case strings.HasPrefix(c.DBType, "STRUCT("):
+		c.Type = "duckdb.Struct"
+		return c
+	}
+
+	if c.Nullable {
+		switch strings.Split(c.DBType, "(")[0] {
+		case "TINYINT":
+			c.Type = "null.Int8"
+		case "SMALLINT":
+			c.Type = "null.Int16"
+		case "INTEGER":
+			c.Type = "null.Int32"
+		case "BIGINT":
+			c.Type = "null.Int64"
+		case "UTINYINT":
+			c.Type = "null.Uint8"
+		case "USMALLINT":
+			c.Type = "null.Uint16"
+		case "UINTEGER":
+			c.Type = "null.Uint32"
+		case "UBIGINT":
+			c.Type = "null.Uint64"
+		case "HUGEINT", "UHUGEINT":
+			c.Type = "duckdb.NullHugeInt"
+		case "FLOAT":
+			c.Type = "null.Float32"
+		case "DOUBLE":
+			c.Type = "null.Float64"
+		case "DECIMAL":
+			c.Type = "types.NullDecimal"
+		case "BOOLEAN":
+			c.Type = "null.Bool"
+		case "VARCHAR", "UUID", "ENUM":
+			c.Type = "null.String"
+		case "BLOB":
+			c.Type = "null.Bytes"
+		case "DATE", "TIME", "TIMESTAMP", "TIMESTAMP WITH TIME ZONE", "TIMESTAMP_S", "TIMESTAMP_MS", "TIMESTAMP_NS":
+			c.Type = "null.Time"
+		case "JSON":
+			c.Type = "null.JSON"
+		default:
+			c.Type = "null.String"
+		}
+	} else {
+		switch strings.Split(c.DBType, "(")[0] {
+		case "TINYINT":
+			c.Type = "int8"
+		case "SMALLINT":
+			c.Type = "int16"
+		case "INTEGER":
+			c.Type = "int32"
+		case "BIGINT":
+			c.Type = "int64"
+		case "UTINYINT":
+			c.Type = "uint8"
+		case "USMALLINT":
+			c.Type = "uint16"
+		case "UINTEGER":
+			c.Type = "uint32"
+		case "UBIGINT":
+			c.Type = "uint64"
+		case "HUGEINT", "UHUGEINT":
+			c.Type = "duckdb.HugeInt"
+		case "FLOAT":
+			c.Type = "float32"
+		case "DOUBLE":
+			c.Type = "float64"
+		case "DECIMAL":
+			c.Type = "types.Decimal"
+		case "BOOLEAN":
+			c.Type = "bool"
+		case "VARCHAR", "UUID", "ENUM":
+			c.Type = "string"
+		case "BLOB":
+			c.Type = "[]byte"
+		case "DATE", "TIME", "TIMESTAMP", "TIMESTAMP WITH TIME ZONE", "TIMESTAMP_S", "TIMESTAMP_MS", "TIMESTAMP_NS":
+			c.Type = "time.Time"
+		case "JSON":
+			c.Type = "types.JSON"
+		default:
+			c.Type = "string"
+		}
+	}
+
+	return c
+}
+
+// Imports returns important imports for the driver
+func (DuckDBDriver) Imports() (col importers.Collection, err error) {
+	col.All = importers.Set{
+		Standard: importers.List{
+			`"strconv"`,
+		},
+	}
+
+	col.Singleton = importers.Map{
+		"duckdb_upsert": {
+			Standard: importers.List{
+				`"fmt"`,
+				`"strings"`,
+			},
+			ThirdParty: importers.List{
+				`"github.com/volatiletech/strmangle"`,
+				`"github.com/volatiletech/sqlboiler/v4/drivers"`,
+			},
+		},
+	}
+
+	col.TestSingleton = importers.Map{
+		"duckdb_suites_test": {
+			Standard: importers.List{
+				`"testing"`,
+			},
+		},
+		"duckdb_main_test": {
+			Standard: importers.List{
+				`"database/sql"`,
+				`"fmt"`,
+				`"math/rand"`,
+				`"os"`,
+				`"path/filepath"`,
+				`"regexp"`,
+				`"strings"`,
+			},
+			ThirdParty: importers.List{
+				`"github.com/pkg/errors"`,
+				`"github.com/volatiletech/sqlboiler/v4/testharness"`,
+				`_ "github.com/marcboeker/go-duckdb"`,
+			},
+		},
+	}
+
+	col.BasedOnType = importers.Map{
+		"null.Float32": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.Float64": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.Int8": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.Int16": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.Int32": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.Int64": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.Uint8": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.Uint16": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.Uint32": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.Uint64": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.String": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.Bool": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.Time": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.Bytes": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.JSON": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+
+		"time.Time": {
+			Standard: importers.List{`"time"`},
+		},
+		"types.Decimal": {
+			ThirdParty: importers.List{`"github.com/volatiletech/sqlboiler/v4/types"`},
+		},
+		"types.NullDecimal": {
+			ThirdParty: importers.List{`"github.com/volatiletech/sqlboiler/v4/types"`},
+		},
+		"types.JSON": {
+			ThirdParty: importers.List{`"github.com/volatiletech/sqlboiler/v4/types"`},
+		},
+		"duckdb.HugeInt": {
+			ThirdParty: importers.List{`"github.com/volatiletech/sqlboiler/v4/types/duckdb"`},
+		},
+		"duckdb.NullHugeInt": {
+			ThirdParty: importers.List{`"github.com/volatiletech/sqlboiler/v4/types/duckdb"`},
+		},
+		"duckdb.List": {
+			ThirdParty: importers.List{`"github.com/volatiletech/sqlboiler/v4/types/duckdb"`},
+		},
+		"duckdb.Struct": {
+			ThirdParty: importers.List{`"github.com/volatiletech/sqlboiler/v4/types/duckdb"`},
+		},
+	}
+	return col, err
+}
@@ -0,0 +1,72 @@
+package driver
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestTranslateColumnType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dbType   string
+		nullable bool
+		want     string
+	}{
+		{"INTEGER", false, "int32"},
+		{"BIGINT", true, "null.Int64"},
+		{"UTINYINT", false, "uint8"},
+		{"HUGEINT", false, "duckdb.HugeInt"},
+		{"UHUGEINT", true, "duckdb.NullHugeInt"},
+		{"DOUBLE", false, "float64"},
+		{"DECIMAL(18,3)", true, "types.NullDecimal"},
+		{"VARCHAR", false, "string"},
+		{"ENUM('sad', 'happy')", true, "null.String"},
+		{"BLOB", false, "[]byte"},
+		{"TIMESTAMP WITH TIME ZONE", true, "null.Time"},
+		{"JSON", false, "types.JSON"},
+		{"INTEGER[]", true, "duckdb.List"},
+		{"VARCHAR[3]", false, "duckdb.List"},
+		{"STRUCT(a INTEGER, b VARCHAR[])", false, "duckdb.Struct"},
+		{"STRUCT(a INTEGER)[]", false, "duckdb.List"},
+		{"MAP(VARCHAR, INTEGER)", false, "string"},
+	}
+
+	for _, test := range tests {
+		c := DuckDBDriver{}.TranslateColumnType(drivers.Column{DBType: test.dbType, Nullable: test.nullable})
+		if c.Type != test.want {
+			t.Errorf("%s (nullable %t): want %s, got %s", test.dbType, test.nullable, test.want, c.Type)
+		}
+	}
+}
+
+func TestForeignKeyName(t *testing.T) {
+	t.Parallel()
+
+	if got := foreignKeyName("orders", []string{"customer_id"}); got != "orders_customer_id_fkey" {
+		t.Errorf("want the name of postgres, got: %s", got)
+	}
+	if got := foreignKeyName("lines", []string{"order_id", "line"}); got != "lines_order_id_line_fkey" {
+		t.Errorf("want the columns joined, got: %s", got)
+	}
+}
+
+func TestDuckDBBuildQueryString(t *testing.T) {
+	t.Parallel()
+
+	if got := DuckDBBuildQueryString("data/warehouse.duckdb"); got != "data/warehouse.duckdb?access_mode=READ_ONLY" {
+		t.Errorf("want the file opened read only, got: %s", got)
+	}
+}
+
+func TestValidateDriverConfig(t *testing.T) {
+	t.Parallel()
+
+	if err := validateDriverConfig(drivers.Config{DBName: "warehouse.duckdb"}); err != nil {
+		t.Error(err)
+	}
+	if err := validateDriverConfig(drivers.Config{}); err == nil {
+		t.Error("want an error for a missing file")
+	}
+}
@@ -0,0 +1,100 @@
+var rgxDuckDBFkey = regexp.MustCompile(`(?i),\s*foreign key\s*\([^)]*\)\s*references\s*[^(]*\([^)]*\)`)
+
+// duckdbTester keeps the test database in a temporary file rather than
+// using the configured test database name
+type duckdbTester struct {
+	testDBName string
+}
+
+func init() {
+	dbMain = &harnessTester{driver: &duckdbTester{
+		testDBName: filepath.Join(os.TempDir(), fmt.Sprintf("boil-duckdb-%d.db", rand.Int())),
+	}}
+}
+
+// CreateTestDB attaches the database read only and runs the statements
+// creating its types, sequences, tables, views and indexes in the test
+// database so that tests can be run against it using the generated sqlboiler
+// ORM package. The foreign keys are removed from the tables.
+func (d *duckdbTester) CreateTestDB(cfg testharness.Config) error {
+	db, err := sql.Open("duckdb", d.testDBName)
+	if err != nil {
+		return errors.Wrap(err, "failed to open the duckdb test database")
+	}
+	defer db.Close()
+
+	attach := fmt.Sprintf("ATTACH '%s' AS boil_source (READ_ONLY)", strings.Replace(cfg.DBName, "'", "''", -1))
+	if _, err = db.Exec(attach); err != nil {
+		return errors.Wrap(err, "failed to attach the duckdb database")
+	}
+
+	schema := []string{
+		`SELECT 'CREATE SCHEMA IF NOT EXISTS "' || schema_name || '"' FROM duckdb_schemas()
+		WHERE database_name = 'boil_source' AND NOT internal`,
+		`SELECT 'CREATE TYPE "' || schema_name || '"."' || type_name || '" AS ENUM (' ||
+			array_to_string(list_transform(labels, l -> '''' || replace(l, '''', '''''') || ''''), ', ') || ')'
+		FROM duckdb_types()
+		WHERE database_name = 'boil_source' AND logical_type = 'ENUM' AND NOT internal`,
+		`SELECT sql FROM duckdb_sequences() WHERE database_name = 'boil_source' AND NOT temporary ORDER BY sequence_oid`,
+		`SELECT sql FROM duckdb_tables() WHERE database_name = 'boil_source' AND NOT internal AND NOT temporary ORDER BY table_oid`,
+		`SELECT sql FROM duckdb_views() WHERE database_name = 'boil_source' AND NOT internal AND NOT temporary ORDER BY view_oid`,
+		`SELECT sql FROM duckdb_indexes() WHERE database_name = 'boil_source' AND NOT temporary AND sql IS NOT NULL ORDER BY index_oid`,
+	}
+
+	for _, query := range schema {
+		statements, err := duckdbStatements(db, query)
+		if err != nil {
+			return errors.Wrap(err, "failed to read the duckdb schema")
+		}
+
+		for _, statement := range statements {
+			statement = rgxDuckDBFkey.ReplaceAllString(statement, "")
+			if _, err = db.Exec(statement); err != nil {
+				return errors.Wrapf(err, "failed to run %q", statement)
+			}
+		}
+	}
+
+	if _, err = db.Exec("DETACH boil_source"); err != nil {
+		return errors.Wrap(err, "failed to detach the duckdb database")
+	}
+
+	return nil
+}
+
+// duckdbStatements reads the statements a query of the catalog returns,
+// they are all read before any is run on the same connection
+func duckdbStatements(db *sql.DB, query string) ([]string, error) {
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statements []string
+	for rows.Next() {
+		var statement string
+		if err := rows.Scan(&statement); err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+
+	return statements, rows.Err()
+}
+
+// DropTestDB removes the test database file and its write ahead log
+func (d *duckdbTester) DropTestDB(cfg testharness.Config) error {
+	for _, file := range []string{d.testDBName, d.testDBName + ".wal"} {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Open a connection to the test database
+func (d *duckdbTester) Open(cfg testharness.Config) (*sql.DB, error) {
+	return sql.Open("duckdb", d.testDBName)
+}
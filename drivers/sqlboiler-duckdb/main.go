@@ -0,0 +1,17 @@
+// The duckdb driver is a module of its own requiring go-duckdb, so the cgo
+// bindings of DuckDB are not dependencies of sqlboiler. It is installed from
+// its directory:
+//
+//	go install .
+package main
+
+import (
+	_ "github.com/marcboeker/go-duckdb"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/drivers/sqlboiler-duckdb/driver"
+)
+
+func main() {
+	drivers.DriverMain(&driver.DuckDBDriver{})
+}
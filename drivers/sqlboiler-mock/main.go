@@ -0,0 +1,10 @@
+package main
+
+import (
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/drivers/mocks"
+)
+
+func main() {
+	drivers.DriverMain(&mocks.MockDriver{})
+}
@@ -314,7 +314,15 @@ func (m *MSSQLDriver) Columns(schema, tableName string, whitelist, blacklist []s
          ELSE 0
        END AS is_unique,
 	   COLUMNPROPERTY(object_id($1 + '.' + $2), c.column_name, 'IsIdentity') as is_identity,
-	   COLUMNPROPERTY(object_id($1 + '.' + $2), c.column_name, 'IsComputed') as is_computed
+	   COLUMNPROPERTY(object_id($1 + '.' + $2), c.column_name, 'IsComputed') as is_computed,
+	   COALESCE(c.collation_name, '') AS collation_name,
+	   COALESCE(c.character_set_name, '') AS character_set_name,
+	   CASE
+	     WHEN c.character_maximum_length > 0 AND c.character_set_name IS NOT NULL THEN c.character_maximum_length
+	     ELSE 0
+	   END AS max_length,
+	   CASE WHEN data_type IN ('decimal', 'numeric') THEN COALESCE(c.numeric_precision, 0) ELSE 0 END AS numeric_precision,
+	   CASE WHEN data_type IN ('decimal', 'numeric') THEN COALESCE(c.numeric_scale, 0) ELSE 0 END AS numeric_scale
 	FROM information_schema.columns c
 	WHERE table_schema = $1 AND table_name = $2`
 
@@ -345,10 +353,11 @@ func (m *MSSQLDriver) Columns(schema, tableName string, whitelist, blacklist []s
 	defer rows.Close()
 
 	for rows.Next() {
-		var colName, colType, colFullType string
+		var colName, colType, colFullType, collation, charset string
 		var nullable, unique, identity, computed bool
+		var maxLength, precision, scale int
 		var defaultValue *string
-		if err := rows.Scan(&colName, &colFullType, &colType, &defaultValue, &nullable, &unique, &identity, &computed); err != nil {
+		if err := rows.Scan(&colName, &colFullType, &colType, &defaultValue, &nullable, &unique, &identity, &computed, &collation, &charset, &maxLength, &precision, &scale); err != nil {
 			return nil, errors.Wrapf(err, "unable to scan for table %s", tableName)
 		}
 
@@ -361,6 +370,11 @@ func (m *MSSQLDriver) Columns(schema, tableName string, whitelist, blacklist []s
 			Nullable:      nullable,
 			Unique:        unique,
 			AutoGenerated: computed || identity,
+			Collation:     collation,
+			Charset:       charset,
+			MaxLength:     maxLength,
+			Precision:     precision,
+			Scale:         scale,
 		}
 
 		if defaultValue != nil {
@@ -474,6 +488,54 @@ func (m *MSSQLDriver) ForeignKeyInfo(schema, tableName string) ([]drivers.Foreig
 	return fkeys, nil
 }
 
+// Triggers retrieves the triggers of a table, mssql triggers fire once per
+// statement
+func (m *MSSQLDriver) Triggers(schema, tableName string) ([]drivers.Trigger, error) {
+	query := `
+	SELECT tr.name,
+		CASE WHEN tr.is_instead_of_trigger = 1 THEN 'INSTEAD OF' ELSE 'AFTER' END,
+		te.type_desc,
+		COALESCE(OBJECT_DEFINITION(tr.object_id), '')
+	FROM sys.triggers tr
+	INNER JOIN sys.trigger_events te ON te.object_id = tr.object_id
+	INNER JOIN sys.tables t ON t.object_id = tr.parent_id
+	INNER JOIN sys.schemas s ON s.schema_id = t.schema_id
+	WHERE s.name = ? AND t.name = ?
+	ORDER BY tr.name, te.type
+	`
+
+	rows, err := m.conn.Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var triggers []drivers.Trigger
+	for rows.Next() {
+		var trigger drivers.Trigger
+		var event string
+		if err := rows.Scan(&trigger.Name, &trigger.Timing, &event, &trigger.Definition); err != nil {
+			return nil, err
+		}
+
+		// A trigger firing on several events has a row for each of them
+		if n := len(triggers); n != 0 && triggers[n-1].Name == trigger.Name {
+			triggers[n-1].Events = append(triggers[n-1].Events, event)
+			continue
+		}
+
+		trigger.Events = []string{event}
+		trigger.Body = trigger.Definition
+		triggers = append(triggers, trigger)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return triggers, nil
+}
+
 // TranslateColumnType converts postgres database types to Go types, for example
 // "varchar" to "string" and "bigint" to "int64". It returns this parsed data
 // as a Column object.
@@ -591,7 +653,7 @@ func (MSSQLDriver) Imports() (col importers.Collection, err error) {
 				`"github.com/friendsofgo/errors"`,
 				`"github.com/spf13/viper"`,
 				`"github.com/volatiletech/sqlboiler/v4/drivers/sqlboiler-mssql/driver"`,
-				`"github.com/volatiletech/randomize"`,
+				`"github.com/volatiletech/sqlboiler/v4/testharness"`,
 				`_ "github.com/microsoft/go-mssqldb"`,
 			},
 		},
@@ -1,6 +1,7 @@
-{{- if or (not .Table.IsView) .Table.ViewCapabilities.CanUpsert -}}
+{{- if and (not .NoUpsert) (or (not .Table.IsView) .Table.ViewCapabilities.CanUpsert) -}}
 {{- $alias := .Aliases.Table .Table.Name}}
 {{- $schemaTable := .Table.Name | .SchemaTable}}
+{{- $tenant := .Tenancy.Scopes .Table.Name}}
 {{if .AddGlobal -}}
 // UpsertG attempts an insert, and does an update or ignore on conflict.
 func (o *{{$alias.UpSingular}}) UpsertG({{if not .NoContext}}ctx context.Context, {{end -}} updateColumns, insertColumns boil.Columns) error {
@@ -32,6 +33,14 @@ func (o *{{$alias.UpSingular}}) UpsertP({{if .NoContext}}exec boil.Executor{{els
 
 // Upsert attempts an insert using an executor, and does an update or ignore on conflict.
 func (o *{{$alias.UpSingular}}) Upsert({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, updateColumns, insertColumns boil.Columns) error {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{.Table.Name}}", "upsert")
+
+	{{end -}}
 	if o == nil {
 		return errors.New("{{.PkgName}}: no {{.Table.Name}} provided for upsert")
 	}
@@ -43,6 +52,18 @@ func (o *{{$alias.UpSingular}}) Upsert({{if .NoContext}}exec boil.Executor{{else
 		return err
 	}
 	{{- end}}
+	{{- if $tenant}}
+
+	if err := tenantCheck(ctx, o.{{$alias.Column $.Tenancy.Column}}); err != nil {
+		return err
+	}
+	{{- end}}
+	{{- if .AddValidation}}
+
+	if err := o.Validate(); err != nil {
+		return err
+	}
+	{{- end}}
 
 	nzDefaults := queries.NonZeroDefaultSet({{$alias.DownSingular}}ColumnsWithDefault, o)
 
@@ -128,39 +149,30 @@ func (o *{{$alias.UpSingular}}) Upsert({{if .NoContext}}exec boil.Executor{{else
 		returns = queries.PtrsFromMapping(value, cache.retMapping)
 	}
 
-	{{if .NoContext -}}
-	if boil.DebugMode {
-		fmt.Fprintln(boil.DebugWriter, cache.query)
-		fmt.Fprintln(boil.DebugWriter, vals)
-	}
-	{{else -}}
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, cache.query)
-		fmt.Fprintln(writer, vals)
-	}
-	{{end -}}
-
 	if len(cache.retMapping) != 0 {
 		{{if .NoContext -}}
-		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		err = boil.DebugQueryRow(exec, cache.query, vals...).Scan(returns...)
 		{{else -}}
-		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		err = boil.DebugQueryRowContext(ctx, exec, cache.query, vals...).Scan(returns...)
 		{{end -}}
 		if errors.Is(err, sql.ErrNoRows) {
 			err = nil // MSSQL doesn't return anything when there's no update
 		}
 	} else {
 		{{if .NoContext -}}
-		_, err = exec.Exec(cache.query, vals...)
+		_, err = boil.DebugExec(exec, cache.query, vals...)
 		{{else -}}
-		_, err = exec.ExecContext(ctx, cache.query, vals...)
+		_, err = boil.DebugExecContext(ctx, exec, cache.query, vals...)
 		{{end -}}
 	}
 	if err != nil {
-		return errors.Wrap(err, "{{.PkgName}}: unable to upsert {{.Table.Name}}")
+		return errors.Wrap(boil.ConvertError(err), "{{.PkgName}}: unable to upsert {{.Table.Name}}")
 	}
 
+	{{if .WithIdentityMap -}}
+	boil.IdentityMapFrom(exec).Invalidate("{{.Table.Name}}", {{.Table.PKey.Columns | stringMap (aliasCols $alias) | prefixStringSlice "o." | join ", "}})
+
+	{{end -}}
 	if !cached {
 		{{$alias.DownSingular}}UpsertCacheMut.Lock()
 		{{$alias.DownSingular}}UpsertCache[key] = cache
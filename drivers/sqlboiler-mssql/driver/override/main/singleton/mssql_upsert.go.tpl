@@ -1,6 +1,7 @@
+{{- if not .NoUpsert -}}
 // buildUpsertQueryMSSQL builds a SQL statement string using the upsertData provided.
 func buildUpsertQueryMSSQL(dia drivers.Dialect, tableName string, primary, update, insert []string, output []string) string {
-	insert = strmangle.IdentQuoteSlice(dia.LQ, dia.RQ, insert)
+	insert = dia.QuoteSlice(insert)
 
 	buf := strmangle.GetBuffer()
 	defer strmangle.PutBuffer(buf)
@@ -9,7 +10,7 @@ func buildUpsertQueryMSSQL(dia drivers.Dialect, tableName string, primary, updat
 
 	fmt.Fprintf(buf, "MERGE INTO %s as [t]\n", tableName)
 	fmt.Fprintf(buf, "USING (SELECT %s) as [s] ([%s])\n",
-		strmangle.Placeholders(dia.UseIndexPlaceholders, len(primary), startIndex, 1),
+		dia.Placeholders(len(primary), startIndex, 1),
 		strings.Join(primary, string(dia.RQ)+","+string(dia.LQ)))
 	fmt.Fprint(buf, "ON (")
 	for i, v := range primary {
@@ -32,7 +33,7 @@ func buildUpsertQueryMSSQL(dia drivers.Dialect, tableName string, primary, updat
 	fmt.Fprint(buf, "WHEN NOT MATCHED THEN ")
 	fmt.Fprintf(buf, "INSERT (%s) VALUES (%s)",
 		strings.Join(insert, ", "),
-		strmangle.Placeholders(dia.UseIndexPlaceholders, len(insert), startIndex, 1))
+		dia.Placeholders(len(insert), startIndex, 1))
 
 	if len(output) > 0 {
 		fmt.Fprintf(buf, "\nOUTPUT INSERTED.[%s];", strings.Join(output, "],INSERTED.["))
@@ -42,3 +43,4 @@ func buildUpsertQueryMSSQL(dia drivers.Dialect, tableName string, primary, updat
 
 	return buf.String()
 }
+{{- end -}}
@@ -1,133 +1,81 @@
 var rgxMSSQLkey = regexp.MustCompile(`(?m)^ALTER TABLE .*ADD\s+CONSTRAINT .* FOREIGN KEY.*?.*\n?REFERENCES.*`)
 
-type mssqlTester struct {
-	dbConn     *sql.DB
-	dbName     string
-	host       string
-	user       string
-	pass       string
-	sslmode    string
-	port       int
-	testDBName string
-	skipSQLCmd bool
-}
+type mssqlTester struct{}
 
 func init() {
-	dbMain = &mssqlTester{}
-}
-
-func (m *mssqlTester) setup() error {
-	var err error
-
 	viper.SetDefault("mssql.schema", "dbo")
 	viper.SetDefault("mssql.sslmode", "true")
 	viper.SetDefault("mssql.port", 1433)
 
-	m.dbName = viper.GetString("mssql.dbname")
-	m.host = viper.GetString("mssql.host")
-	m.user = viper.GetString("mssql.user")
-	m.pass = viper.GetString("mssql.pass")
-	m.port = viper.GetInt("mssql.port")
-	m.sslmode = viper.GetString("mssql.sslmode")
-	m.testDBName = viper.GetString("mssql.testdbname")
-	m.skipSQLCmd = viper.GetBool("mssql.skipsqlcmd")
-
-	err = vala.BeginValidation().Validate(
-		vala.StringNotEmpty(viper.GetString("mssql.user"), "mssql.user"),
-		vala.StringNotEmpty(viper.GetString("mssql.host"), "mssql.host"),
-		vala.Not(vala.Equals(viper.GetInt("mssql.port"), 0, "mssql.port")),
-		vala.StringNotEmpty(viper.GetString("mssql.dbname"), "mssql.dbname"),
-		vala.StringNotEmpty(viper.GetString("mssql.sslmode"), "mssql.sslmode"),
+	dbMain = &harnessTester{driver: &mssqlTester{}}
+}
+
+// Validate the connection information used to create the test database
+func (m *mssqlTester) Validate(cfg testharness.Config) error {
+	return vala.BeginValidation().Validate(
+		vala.StringNotEmpty(cfg.User, "mssql.user"),
+		vala.StringNotEmpty(cfg.Host, "mssql.host"),
+		vala.Not(vala.Equals(cfg.Port, 0, "mssql.port")),
+		vala.StringNotEmpty(cfg.DBName, "mssql.dbname"),
+		vala.StringNotEmpty(cfg.SSLMode, "mssql.sslmode"),
 	).Check()
+}
 
-	if err != nil {
+// CreateTestDB creates the test database and loads tables_schema.sql into it
+// so that tests can be run against it using the generated sqlboiler ORM
+// package.
+func (m *mssqlTester) CreateTestDB(cfg testharness.Config) error {
+	sql := fmt.Sprintf(`
+	CREATE DATABASE %s;
+	GO
+	ALTER DATABASE %[1]s
+	SET READ_COMMITTED_SNAPSHOT ON;
+	GO`, cfg.TestDBName)
+	if err := m.runCmd(sql, "sqlcmd", "-S", cfg.Host, "-U", cfg.User, "-P", cfg.Pass); err != nil {
 		return err
 	}
 
-	// Create a randomized db name.
-	if len(m.testDBName) == 0 {
-		m.testDBName = randomize.StableDBName(m.dbName)
-	}
-
-	if !m.skipSQLCmd {
-		if err = m.dropTestDB(); err != nil {
-			return err
-		}
-		if err = m.createTestDB(); err != nil {
-			return err
-		}
-
-		createCmd := exec.Command("sqlcmd", "-S", m.host, "-U", m.user, "-P", m.pass, "-d", m.testDBName)
-
-		f, err := os.Open("tables_schema.sql")
-		if err != nil {
-			return errors.Wrap(err, "failed to open tables_schema.sql file")
-		}
+	createCmd := exec.Command("sqlcmd", "-S", cfg.Host, "-U", cfg.User, "-P", cfg.Pass, "-d", cfg.TestDBName)
 
-		defer func() { _ = f.Close() }()
+	f, err := os.Open("tables_schema.sql")
+	if err != nil {
+		return errors.Wrap(err, "failed to open tables_schema.sql file")
+	}
 
-		stderr := &bytes.Buffer{}
-		createCmd.Stdin = newFKeyDestroyer(rgxMSSQLkey, f)
-		createCmd.Stderr = stderr
+	defer func() { _ = f.Close() }()
 
-		if err = createCmd.Start(); err != nil {
-			return errors.Wrap(err, "failed to start sqlcmd command")
-		}
+	stderr := &bytes.Buffer{}
+	createCmd.Stdin = newFKeyDestroyer(rgxMSSQLkey, f)
+	createCmd.Stderr = stderr
 
-		if err = createCmd.Wait(); err != nil {
-			fmt.Println(err)
-			fmt.Println(stderr.String())
-			return errors.Wrap(err, "failed to wait for sqlcmd command")
-		}
+	if err = createCmd.Start(); err != nil {
+		return errors.Wrap(err, "failed to start sqlcmd command")
 	}
 
-	return nil
-}
-
-func (m *mssqlTester) sslMode(mode string) string {
-	switch mode {
-	case "true":
-		return "true"
-	case "false":
-		return "false"
-	default:
-		return "disable"
+	if err = createCmd.Wait(); err != nil {
+		fmt.Println(err)
+		fmt.Println(stderr.String())
+		return errors.Wrap(err, "failed to wait for sqlcmd command")
 	}
-}
 
-func (m *mssqlTester) createTestDB() error {
-	sql := fmt.Sprintf(`
-	CREATE DATABASE %s;
-	GO
-	ALTER DATABASE %[1]s
-	SET READ_COMMITTED_SNAPSHOT ON;
-	GO`, m.testDBName)
-	return m.runCmd(sql, "sqlcmd", "-S", m.host, "-U", m.user, "-P", m.pass)
+	return nil
 }
 
-func (m *mssqlTester) dropTestDB() error {
+// DropTestDB drops the test database if it exists
+func (m *mssqlTester) DropTestDB(cfg testharness.Config) error {
 	// Since MS SQL 2016 it can be done with
 	// DROP DATABASE [ IF EXISTS ] { database_name | database_snapshot_name } [ ,...n ] [;]
 	sql := fmt.Sprintf(`
 	IF EXISTS(SELECT name FROM sys.databases 
 		WHERE name = '%s')
 		DROP DATABASE %s
-	GO`, m.testDBName, m.testDBName)
-	return m.runCmd(sql, "sqlcmd", "-S", m.host, "-U", m.user, "-P", m.pass)
+	GO`, cfg.TestDBName, cfg.TestDBName)
+	return m.runCmd(sql, "sqlcmd", "-S", cfg.Host, "-U", cfg.User, "-P", cfg.Pass)
 }
 
-func (m *mssqlTester) teardown() error {
-	if m.dbConn != nil {
-		m.dbConn.Close()
-	}
-
-	if !m.skipSQLCmd {
-		if err := m.dropTestDB(); err != nil {
-			return err
-		}
-	}
-
-	return nil
+// Open a connection to the test database
+func (m *mssqlTester) Open(cfg testharness.Config) (*sql.DB, error) {
+	return sql.Open("mssql", driver.MSSQLBuildQueryString(cfg.User, cfg.Pass, cfg.TestDBName, cfg.Host, cfg.Port, cfg.SSLMode))
 }
 
 func (m *mssqlTester) runCmd(stdin, command string, args ...string) error {
@@ -147,17 +95,3 @@ func (m *mssqlTester) runCmd(stdin, command string, args ...string) error {
 
 	return nil
 }
-
-func (m *mssqlTester) conn() (*sql.DB, error) {
-	if m.dbConn != nil {
-		return m.dbConn, nil
-	}
-
-	var err error
-	m.dbConn, err = sql.Open("mssql", driver.MSSQLBuildQueryString(m.user, m.pass, m.testDBName, m.host, m.port, m.sslmode))
-	if err != nil {
-		return nil, err
-	}
-
-	return m.dbConn, nil
-}
@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io/fs"
+	"os"
 	"strconv"
 	"strings"
 
@@ -39,6 +40,7 @@ type MySQLDriver struct {
 	addEnumTypes   bool
 	enumNullPrefix string
 	tinyIntAsInt   bool
+	vitess         bool
 }
 
 // Templates that should be added/overridden
@@ -80,6 +82,7 @@ func (m *MySQLDriver) Assemble(config drivers.Config) (dbinfo *drivers.DBInfo, e
 	fillDefaultDriverConfig(&config)
 
 	m.tinyIntAsInt = config.TinyIntAsInt
+	m.vitess = config.Vitess
 
 	m.addEnumTypes = config.AddEnumTypes
 	m.enumNullPrefix = strmangle.TitleCase(config.EnumNullPrefix)
@@ -111,6 +114,19 @@ func (m *MySQLDriver) Assemble(config drivers.Config) (dbinfo *drivers.DBInfo, e
 		return nil, err
 	}
 
+	if m.vitess {
+		if err = validateForeignKeys(dbinfo.Tables, config); err != nil {
+			return nil, errors.Wrap(err, "sqlboiler-mysql found an invalid foreign key")
+		}
+	}
+
+	if config.AddFunctions {
+		dbinfo.Functions, err = drivers.Functions(m, config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return dbinfo, err
 }
 
@@ -299,6 +315,11 @@ func (m *MySQLDriver) Columns(schema, tableName string, whitelist, blacklist []s
 				c.column_default))),
 	c.is_nullable = 'YES',
 	(c.extra = 'STORED GENERATED' OR c.extra = 'VIRTUAL GENERATED') is_generated,
+	ifnull(c.collation_name, ''),
+	ifnull(c.character_set_name, ''),
+	if(c.data_type in ('char', 'varchar', 'tinytext', 'text', 'mediumtext', 'longtext'), ifnull(c.character_maximum_length, 0), 0),
+	if(c.data_type = 'decimal', ifnull(c.numeric_precision, 0), 0),
+	if(c.data_type = 'decimal', ifnull(c.numeric_scale, 0), 0),
 		exists (
 			select c.column_name
 			from information_schema.table_constraints tc
@@ -340,10 +361,11 @@ func (m *MySQLDriver) Columns(schema, tableName string, whitelist, blacklist []s
 	defer rows.Close()
 
 	for rows.Next() {
-		var colName, colFullType, colComment, colType string
+		var colName, colFullType, colComment, colType, collation, charset string
 		var nullable, generated, unique bool
+		var maxLength, precision, scale int
 		var defaultValue *string
-		if err := rows.Scan(&colName, &colFullType, &colComment, &colType, &defaultValue, &nullable, &generated, &unique); err != nil {
+		if err := rows.Scan(&colName, &colFullType, &colComment, &colType, &defaultValue, &nullable, &generated, &collation, &charset, &maxLength, &precision, &scale, &unique); err != nil {
 			return nil, errors.Wrapf(err, "unable to scan for table %s", tableName)
 		}
 
@@ -355,6 +377,13 @@ func (m *MySQLDriver) Columns(schema, tableName string, whitelist, blacklist []s
 			Nullable:      nullable,
 			Unique:        unique,
 			AutoGenerated: generated,
+			Collation:     collation,
+			Charset:       charset,
+			MaxLength:     maxLength,
+			Precision:     precision,
+			Scale:         scale,
+			// the _ci collations compare text ignoring case
+			CaseInsensitive: strings.HasSuffix(collation, "_ci"),
 		}
 
 		if defaultValue != nil {
@@ -423,10 +452,18 @@ func (m *MySQLDriver) PrimaryKeyInfo(schema, tableName string) (*drivers.Primary
 	return pkey, nil
 }
 
-// ForeignKeyInfo retrieves the foreign keys for a given table name.
+// ForeignKeyInfo retrieves the foreign keys for a given table name. With
+// vitess set there are none, the foreign keys of the config are the only ones.
 func (m *MySQLDriver) ForeignKeyInfo(schema, tableName string) ([]drivers.ForeignKey, error) {
 	var fkeys []drivers.ForeignKey
 
+	// Vitess and PlanetScale databases usually have no foreign key
+	// constraints, and the ones they have are not read either so that the
+	// relationships only depend on the config
+	if m.vitess {
+		return nil, nil
+	}
+
 	query := `
 	select constraint_name, table_name, column_name, referenced_table_name, referenced_column_name
 	from information_schema.key_column_usage
@@ -460,6 +497,160 @@ func (m *MySQLDriver) ForeignKeyInfo(schema, tableName string) ([]drivers.Foreig
 	return fkeys, nil
 }
 
+// validateForeignKeys checks the foreign keys of the config against the
+// tables, with vitess set they are the only relationships and a typo would
+// silently drop one. The foreign keys of the tables left out by the whitelist
+// or blacklist are skipped.
+func validateForeignKeys(tables []drivers.Table, config drivers.Config) error {
+	filtered := len(drivers.TablesFromList(config.WhiteList)) != 0
+	blacklist := drivers.TablesFromList(config.BlackList)
+
+	check := func(fkName, tableName, column string) error {
+		for _, t := range tables {
+			if t.Name != tableName {
+				continue
+			}
+			for _, c := range t.Columns {
+				if c.Name == column {
+					return nil
+				}
+			}
+			return errors.Errorf("%s: table %s has no column %s", fkName, tableName, column)
+		}
+
+		if filtered || strmangle.SetInclude(tableName, blacklist) {
+			return nil
+		}
+		return errors.Errorf("%s: there is no table %s", fkName, tableName)
+	}
+
+	for _, fk := range config.ForeignKeys {
+		if err := check(fk.Name, fk.Table, fk.Column); err != nil {
+			return err
+		}
+		if err := check(fk.Name, fk.ForeignTable, fk.ForeignColumn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Triggers retrieves the triggers of a table, mysql triggers fire for each
+// row on a single event.
+func (m *MySQLDriver) Triggers(schema, tableName string) ([]drivers.Trigger, error) {
+	query := `
+	select trigger_name, action_timing, event_manipulation, action_orientation = 'ROW', action_statement
+	from information_schema.triggers
+	where event_object_schema = ? and event_object_table = ?
+	order by trigger_name`
+
+	rows, err := m.conn.Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var triggers []drivers.Trigger
+	for rows.Next() {
+		var trigger drivers.Trigger
+		var event string
+		if err := rows.Scan(&trigger.Name, &trigger.Timing, &event, &trigger.ForEachRow, &trigger.Body); err != nil {
+			return nil, err
+		}
+		trigger.Events = []string{event}
+		trigger.Definition = fmt.Sprintf("CREATE TRIGGER `%s` %s %s ON `%s` FOR EACH ROW %s",
+			trigger.Name, trigger.Timing, event, tableName, trigger.Body)
+
+		triggers = append(triggers, trigger)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return triggers, nil
+}
+
+// Functions retrieves the stored functions and procedures of the schema.
+// Functions return a single value, procedures are only executed so the ones
+// with OUT parameters are left out.
+func (m *MySQLDriver) Functions(schema string) ([]drivers.Function, error) {
+	query := `
+	select r.routine_name, r.routine_type = 'PROCEDURE', coalesce(r.data_type, ''), coalesce(r.dtd_identifier, '')
+	from information_schema.routines r
+	where r.routine_schema = ?
+	order by r.routine_name`
+
+	rows, err := m.conn.Query(query, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fns []drivers.Function
+	for rows.Next() {
+		var fn drivers.Function
+		var dataType, fullType string
+		if err := rows.Scan(&fn.Name, &fn.IsProcedure, &dataType, &fullType); err != nil {
+			return nil, errors.Wrapf(err, "unable to scan function")
+		}
+		if !fn.IsProcedure {
+			fn.Return = &drivers.Column{Name: fn.Name, DBType: dataType, FullDBType: fullType}
+		}
+		fns = append(fns, fn)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ret := fns[:0]
+	for _, fn := range fns {
+		hasOut, err := m.functionParams(schema, &fn)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to load params of function %s", fn.Name)
+		}
+		if hasOut {
+			fmt.Fprintf(os.Stderr, "warning: skipping procedure %s, OUT parameters are not supported\n", fn.Name)
+			continue
+		}
+		ret = append(ret, fn)
+	}
+
+	return ret, nil
+}
+
+// functionParams loads the params of the function, it reports whether the
+// function has OUT or INOUT params
+func (m *MySQLDriver) functionParams(schema string, fn *drivers.Function) (bool, error) {
+	query := `
+	select coalesce(p.parameter_name, ''), coalesce(p.parameter_mode, 'IN'), p.data_type, p.dtd_identifier
+	from information_schema.parameters p
+	where p.specific_schema = ? and p.specific_name = ? and p.ordinal_position > 0
+	order by p.ordinal_position`
+
+	rows, err := m.conn.Query(query, schema, fn.Name)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	hasOut := false
+	for rows.Next() {
+		var mode string
+		var col drivers.Column
+		if err := rows.Scan(&col.Name, &mode, &col.DBType, &col.FullDBType); err != nil {
+			return false, err
+		}
+		if mode != "IN" {
+			hasOut = true
+		}
+		fn.Params = append(fn.Params, col)
+	}
+
+	return hasOut, rows.Err()
+}
+
 // TranslateColumnType converts mysql database types to Go types, for example
 // "varchar" to "string" and "bigint" to "int64". It returns this parsed data
 // as a Column object.
@@ -633,7 +824,7 @@ func (MySQLDriver) Imports() (col importers.Collection, err error) {
 				`"github.com/friendsofgo/errors"`,
 				`"github.com/spf13/viper"`,
 				`"github.com/volatiletech/sqlboiler/v4/drivers/sqlboiler-mysql/driver"`,
-				`"github.com/volatiletech/randomize"`,
+				`"github.com/volatiletech/sqlboiler/v4/testharness"`,
 				`_ "github.com/go-sql-driver/mysql"`,
 			},
 		},
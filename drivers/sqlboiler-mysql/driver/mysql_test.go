@@ -17,6 +17,7 @@ import (
 	"os"
 	"os/exec"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -121,3 +122,100 @@ func TestDriver(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslateTableColumnTypeIntegers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		fullType     string
+		nullable     bool
+		tinyIntAsInt bool
+		want         string
+	}{
+		{"tinyint(1)", false, false, "bool"},
+		{"tinyint(1)", true, false, "null.Bool"},
+		{"tinyint(1) unsigned", false, false, "uint8"},
+		{"tinyint(1)", false, true, "int8"},
+		{"tinyint(1)", true, true, "null.Int8"},
+		{"tinyint(1) unsigned", false, true, "uint8"},
+		{"tinyint(4)", false, false, "int8"},
+		{"tinyint unsigned", true, false, "null.Uint8"},
+		{"smallint unsigned", false, false, "uint16"},
+		{"mediumint(8) unsigned", true, false, "null.Uint32"},
+		{"int(10) unsigned zerofill", false, false, "uint"},
+		{"int unsigned", true, false, "null.Uint"},
+		{"bigint", false, false, "int64"},
+		{"bigint unsigned", false, false, "uint64"},
+		{"bigint(20) unsigned", true, false, "null.Uint64"},
+	}
+
+	for _, test := range tests {
+		m := &MySQLDriver{tinyIntAsInt: test.tinyIntAsInt}
+		dbType := strings.Fields(strings.SplitN(test.fullType, "(", 2)[0])[0]
+		c := m.TranslateTableColumnType(drivers.Column{
+			Name:       "c",
+			DBType:     dbType,
+			FullDBType: test.fullType,
+			Nullable:   test.nullable,
+		}, "t")
+		if c.Type != test.want {
+			t.Errorf("%s nullable=%t tinyint_as_int=%t: want %s, got %s", test.fullType, test.nullable, test.tinyIntAsInt, test.want, c.Type)
+		}
+	}
+}
+
+func TestForeignKeyInfoVitess(t *testing.T) {
+	t.Parallel()
+
+	// No connection is needed, the foreign keys are not read
+	m := &MySQLDriver{vitess: true}
+	fkeys, err := m.ForeignKeyInfo("db", "jets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fkeys) != 0 {
+		t.Errorf("want no foreign keys from the database, got: %#v", fkeys)
+	}
+}
+
+func TestValidateForeignKeys(t *testing.T) {
+	t.Parallel()
+
+	tables := []drivers.Table{
+		{Name: "pilots", Columns: []drivers.Column{{Name: "id"}}},
+		{Name: "jets", Columns: []drivers.Column{{Name: "id"}, {Name: "pilot_id"}}},
+	}
+	// fkeys changes the valid foreign key of jets to pilots
+	fkeys := func(change func(fk *drivers.ForeignKey)) []drivers.ForeignKey {
+		fk := drivers.ForeignKey{Name: "jets_pilot_fk", Table: "jets", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"}
+		change(&fk)
+		return []drivers.ForeignKey{fk}
+	}
+
+	tests := []struct {
+		name    string
+		config  drivers.Config
+		wantErr bool
+	}{
+		{"valid", drivers.Config{ForeignKeys: fkeys(func(fk *drivers.ForeignKey) {})}, false},
+		{"unknown column", drivers.Config{ForeignKeys: fkeys(func(fk *drivers.ForeignKey) { fk.Column = "pilot" })}, true},
+		{"unknown foreign table", drivers.Config{ForeignKeys: fkeys(func(fk *drivers.ForeignKey) { fk.ForeignTable = "pilot" })}, true},
+		{"blacklisted table", drivers.Config{
+			BlackList:   []string{"hangars"},
+			ForeignKeys: fkeys(func(fk *drivers.ForeignKey) { fk.Table = "hangars" }),
+		}, false},
+		{"not whitelisted table", drivers.Config{
+			WhiteList:   []string{"pilots", "jets"},
+			ForeignKeys: fkeys(func(fk *drivers.ForeignKey) { fk.ForeignTable = "airports" }),
+		}, false},
+	}
+
+	for _, test := range tests {
+		err := validateForeignKeys(tables, test.config)
+		if test.wantErr && err == nil {
+			t.Errorf("%s: want an error", test.name)
+		} else if !test.wantErr && err != nil {
+			t.Errorf("%s: %s", test.name, err)
+		}
+	}
+}
@@ -1,6 +1,7 @@
-{{- if or (not .Table.IsView) .Table.ViewCapabilities.CanUpsert -}}
+{{- if and (not .NoUpsert) (or (not .Table.IsView) .Table.ViewCapabilities.CanUpsert) -}}
 {{- $alias := .Aliases.Table .Table.Name}}
 {{- $schemaTable := .Table.Name | .SchemaTable}}
+{{- $tenant := .Tenancy.Scopes .Table.Name}}
 {{if .AddGlobal -}}
 // UpsertG attempts an insert, and does an update or ignore on conflict.
 func (o *{{$alias.UpSingular}}) UpsertG({{if not .NoContext}}ctx context.Context, {{end -}} updateColumns, insertColumns boil.Columns) error {
@@ -41,6 +42,14 @@ var mySQL{{$alias.UpSingular}}UniqueColumns = []string{
 // Upsert attempts an insert using an executor, and does an update or ignore on conflict.
 // See boil.Columns documentation for how to properly use updateColumns and insertColumns.
 func (o *{{$alias.UpSingular}}) Upsert({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, updateColumns, insertColumns boil.Columns) error {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{.Table.Name}}", "upsert")
+
+	{{end -}}
 	if o == nil {
 		return errors.New("{{.PkgName}}: no {{.Table.Name}} provided for upsert")
 	}
@@ -52,6 +61,18 @@ func (o *{{$alias.UpSingular}}) Upsert({{if .NoContext}}exec boil.Executor{{else
 		return err
 	}
 	{{- end}}
+	{{- if $tenant}}
+
+	if err := tenantCheck(ctx, o.{{$alias.Column $.Tenancy.Column}}); err != nil {
+		return err
+	}
+	{{- end}}
+	{{- if .AddValidation}}
+
+	if err := o.Validate(); err != nil {
+		return err
+	}
+	{{- end}}
 
 	nzDefaults := queries.NonZeroDefaultSet({{$alias.DownSingular}}ColumnsWithDefault, o)
 	nzUniques := queries.NonZeroDefaultSet(mySQL{{$alias.UpSingular}}UniqueColumns, o)
@@ -113,7 +134,7 @@ func (o *{{$alias.UpSingular}}) Upsert({{if .NoContext}}exec boil.Executor{{else
 		cache.query = buildUpsertQueryMySQL(dialect, "{{$schemaTable}}", update, insert)
 		cache.retQuery = fmt.Sprintf(
 			"SELECT %s FROM {{.LQ}}{{.Table.Name}}{{.RQ}} WHERE %s",
-			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strings.Join(dialect.QuoteSlice(ret), ","),
 			strmangle.WhereClause("{{.LQ}}", "{{.RQ}}", 0, nzUniques),
 		)
 
@@ -136,35 +157,22 @@ func (o *{{$alias.UpSingular}}) Upsert({{if .NoContext}}exec boil.Executor{{else
 		returns = queries.PtrsFromMapping(value, cache.retMapping)
 	}
 
-	{{if .NoContext -}}
-	if boil.DebugMode {
-		fmt.Fprintln(boil.DebugWriter, cache.query)
-		fmt.Fprintln(boil.DebugWriter, vals)
-	}
-	{{else -}}
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, cache.query)
-		fmt.Fprintln(writer, vals)
-	}
-	{{end -}}
-
 	{{$canLastInsertID := .Table.CanLastInsertID -}}
 	{{if $canLastInsertID -}}
 		{{if .NoContext -}}
-	result, err := exec.Exec(cache.query, vals...)
+	result, err := boil.DebugExec(exec, cache.query, vals...)
 		{{else -}}
-	result, err := exec.ExecContext(ctx, cache.query, vals...)
+	result, err := boil.DebugExecContext(ctx, exec, cache.query, vals...)
 		{{end -}}
 	{{else -}}
 		{{if .NoContext -}}
-	_, err = exec.Exec(cache.query, vals...)
+	_, err = boil.DebugExec(exec, cache.query, vals...)
 		{{else -}}
-	_, err = exec.ExecContext(ctx, cache.query, vals...)
+	_, err = boil.DebugExecContext(ctx, exec, cache.query, vals...)
 		{{end -}}
 	{{- end}}
 	if err != nil {
-		return errors.Wrap(err, "{{.PkgName}}: unable to upsert for {{.Table.Name}}")
+		return errors.Wrap(boil.ConvertError(err), "{{.PkgName}}: unable to upsert for {{.Table.Name}}")
 	}
 
 	{{if $canLastInsertID -}}
@@ -194,33 +202,24 @@ func (o *{{$alias.UpSingular}}) Upsert({{if .NoContext}}exec boil.Executor{{else
 
 	uniqueMap, err = queries.BindMapping({{$alias.DownSingular}}Type, {{$alias.DownSingular}}Mapping, nzUniques)
 	if err != nil {
-		return errors.Wrap(err, "{{.PkgName}}: unable to retrieve unique values for {{.Table.Name}}")
+		return errors.Wrap(boil.ConvertError(err), "{{.PkgName}}: unable to retrieve unique values for {{.Table.Name}}")
  	}
 	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
 
 	{{if .NoContext -}}
-	if boil.DebugMode {
-		fmt.Fprintln(boil.DebugWriter, cache.retQuery)
-		fmt.Fprintln(boil.DebugWriter, nzUniqueCols...)
-	}
+	err = boil.DebugQueryRow(exec, cache.retQuery, nzUniqueCols...).Scan(returns...)
 	{{else -}}
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, cache.retQuery)
-		fmt.Fprintln(writer, nzUniqueCols...)
-	}
-	{{end -}}
-
-	{{if .NoContext -}}
-	err = exec.QueryRow(cache.retQuery, nzUniqueCols...).Scan(returns...)
-	{{else -}}
-	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	err = boil.DebugQueryRowContext(ctx, exec, cache.retQuery, nzUniqueCols...).Scan(returns...)
 	{{end -}}
 	if err != nil {
-		return errors.Wrap(err, "{{.PkgName}}: unable to populate default values for {{.Table.Name}}")
+		return errors.Wrap(boil.ConvertError(err), "{{.PkgName}}: unable to populate default values for {{.Table.Name}}")
 	}
 
 CacheNoHooks:
+	{{if .WithIdentityMap -}}
+	boil.IdentityMapFrom(exec).Invalidate("{{.Table.Name}}", {{.Table.PKey.Columns | stringMap (aliasCols $alias) | prefixStringSlice "o." | join ", "}})
+
+	{{end -}}
 	if !cached {
 		{{$alias.DownSingular}}UpsertCacheMut.Lock()
 		{{$alias.DownSingular}}UpsertCache[key] = cache
@@ -1,7 +1,8 @@
+{{- if not .NoUpsert -}}
 // buildUpsertQueryMySQL builds a SQL statement string using the upsertData provided.
 func buildUpsertQueryMySQL(dia drivers.Dialect, tableName string, update, whitelist []string) string {
-	whitelist = strmangle.IdentQuoteSlice(dia.LQ, dia.RQ, whitelist)
-	tableName = strmangle.IdentQuote(dia.LQ, dia.RQ, tableName)
+	whitelist = dia.QuoteSlice(whitelist)
+	tableName = dia.Quote(tableName)
 
 	buf := strmangle.GetBuffer()
 	defer strmangle.PutBuffer(buf)
@@ -17,7 +18,7 @@ func buildUpsertQueryMySQL(dia drivers.Dialect, tableName string, update, whitel
 			"INSERT IGNORE INTO %s (%s) VALUES (%s)",
 			tableName,
 			columns,
-			strmangle.Placeholders(dia.UseIndexPlaceholders, len(whitelist), 1, 1),
+			dia.Placeholders(len(whitelist), 1, 1),
 		)
 		return buf.String()
 	}
@@ -27,14 +28,14 @@ func buildUpsertQueryMySQL(dia drivers.Dialect, tableName string, update, whitel
 		"INSERT INTO %s (%s) VALUES (%s) ON DUPLICATE KEY UPDATE ",
 		tableName,
 		columns,
-		strmangle.Placeholders(dia.UseIndexPlaceholders, len(whitelist), 1, 1),
+		dia.Placeholders(len(whitelist), 1, 1),
 	)
 
 	for i, v := range update {
 		if i != 0 {
 			buf.WriteByte(',')
 		}
-		quoted := strmangle.IdentQuote(dia.LQ, dia.RQ, v)
+		quoted := dia.Quote(v)
 		buf.WriteString(quoted)
 		buf.WriteString(" = VALUES(")
 		buf.WriteString(quoted)
@@ -43,3 +44,4 @@ func buildUpsertQueryMySQL(dia drivers.Dialect, tableName string, update, whitel
 
 	return buf.String()
 }
+{{- end -}}
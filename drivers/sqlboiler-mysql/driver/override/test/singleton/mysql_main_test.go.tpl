@@ -1,105 +1,96 @@
 var rgxMySQLkey = regexp.MustCompile(`(?m)((,\n)?\s+CONSTRAINT.*?FOREIGN KEY.*?\n)+`)
 
 type mysqlTester struct {
-	dbConn *sql.DB
-
-	dbName  string
-	host    string
-	user    string
-	pass    string
-	sslmode string
-	port    int
-
 	optionFile string
-
-	testDBName string
-	skipSQLCmd bool
 }
 
 func init() {
-	dbMain = &mysqlTester{}
-}
-
-func (m *mysqlTester) setup() error {
-	var err error
-
 	viper.SetDefault("mysql.sslmode", "true")
 	viper.SetDefault("mysql.port", 3306)
 
-	m.dbName = viper.GetString("mysql.dbname")
-	m.host = viper.GetString("mysql.host")
-	m.user = viper.GetString("mysql.user")
-	m.pass = viper.GetString("mysql.pass")
-	m.port = viper.GetInt("mysql.port")
-	m.sslmode = viper.GetString("mysql.sslmode")
-	m.testDBName = viper.GetString("mysql.testdbname")
-	m.skipSQLCmd = viper.GetBool("mysql.skipsqlcmd")
-
-	err = vala.BeginValidation().Validate(
-		vala.StringNotEmpty(m.user, "mysql.user"),
-		vala.StringNotEmpty(m.host, "mysql.host"),
-		vala.Not(vala.Equals(m.port, 0, "mysql.port")),
-		vala.StringNotEmpty(m.dbName, "mysql.dbname"),
-		vala.StringNotEmpty(m.sslmode, "mysql.sslmode"),
+	dbMain = &harnessTester{driver: &mysqlTester{}}
+}
+
+// Validate the connection information used to create the test database
+func (m *mysqlTester) Validate(cfg testharness.Config) error {
+	return vala.BeginValidation().Validate(
+		vala.StringNotEmpty(cfg.User, "mysql.user"),
+		vala.StringNotEmpty(cfg.Host, "mysql.host"),
+		vala.Not(vala.Equals(cfg.Port, 0, "mysql.port")),
+		vala.StringNotEmpty(cfg.DBName, "mysql.dbname"),
+		vala.StringNotEmpty(cfg.SSLMode, "mysql.sslmode"),
 	).Check()
+}
 
-	if err != nil {
+// CreateTestDB dumps the database schema and imports it into the test
+// database so that tests can be run against it using the generated
+// sqlboiler ORM package.
+func (m *mysqlTester) CreateTestDB(cfg testharness.Config) error {
+	sql := fmt.Sprintf("create database %s;", cfg.TestDBName)
+	if err := m.runCmd(cfg, sql, "mysql"); err != nil {
 		return err
 	}
 
-	// Create a randomized db name.
-	if len(m.testDBName) == 0 {
-		m.testDBName = randomize.StableDBName(m.dbName)
+	defaultsFile, err := m.defaultsFile(cfg)
+	if err != nil {
+		return err
 	}
 
-	if err = m.makeOptionFile(); err != nil {
-		return errors.Wrap(err, "couldn't make option file")
-	}
+	dumpCmd := exec.Command("mysqldump", defaultsFile, "--no-data", cfg.DBName)
+	createCmd := exec.Command("mysql", defaultsFile, "--database", cfg.TestDBName)
 
-	if !m.skipSQLCmd {
-		if err = m.dropTestDB(); err != nil {
-			return err
-		}
-		if err = m.createTestDB(); err != nil {
-			return err
-		}
+	r, w := io.Pipe()
+	dumpCmdStderr := &bytes.Buffer{}
+	createCmdStderr := &bytes.Buffer{}
 
-		dumpCmd := exec.Command("mysqldump", m.defaultsFile(), "--no-data", m.dbName)
-		createCmd := exec.Command("mysql", m.defaultsFile(), "--database", m.testDBName)
+	dumpCmd.Stdout = w
+	dumpCmd.Stderr = dumpCmdStderr
 
-		r, w := io.Pipe()
-		dumpCmdStderr := &bytes.Buffer{}
-		createCmdStderr := &bytes.Buffer{}
+	createCmd.Stdin = newFKeyDestroyer(rgxMySQLkey, r)
+	createCmd.Stderr = createCmdStderr
 
-		dumpCmd.Stdout = w
-		dumpCmd.Stderr = dumpCmdStderr
+	if err = dumpCmd.Start(); err != nil {
+		return errors.Wrap(err, "failed to start mysqldump command")
+	}
+	if err = createCmd.Start(); err != nil {
+		return errors.Wrap(err, "failed to start mysql command")
+	}
 
-		createCmd.Stdin = newFKeyDestroyer(rgxMySQLkey, r)
-		createCmd.Stderr = createCmdStderr
+	if err = dumpCmd.Wait(); err != nil {
+		fmt.Println(err)
+		fmt.Println(dumpCmdStderr.String())
+		return errors.Wrap(err, "failed to wait for mysqldump command")
+	}
 
-		if err = dumpCmd.Start(); err != nil {
-			return errors.Wrap(err, "failed to start mysqldump command")
-		}
-		if err = createCmd.Start(); err != nil {
-			return errors.Wrap(err, "failed to start mysql command")
-		}
+	_ = w.Close() // After dumpCmd is done, close the write end of the pipe
 
-		if err = dumpCmd.Wait(); err != nil {
-			fmt.Println(err)
-			fmt.Println(dumpCmdStderr.String())
-			return errors.Wrap(err, "failed to wait for mysqldump command")
-		}
+	if err = createCmd.Wait(); err != nil {
+		fmt.Println(err)
+		fmt.Println(createCmdStderr.String())
+		return errors.Wrap(err, "failed to wait for mysql command")
+	}
 
-		_ = w.Close() // After dumpCmd is done, close the write end of the pipe
+	return nil
+}
 
-		if err = createCmd.Wait(); err != nil {
-			fmt.Println(err)
-			fmt.Println(createCmdStderr.String())
-			return errors.Wrap(err, "failed to wait for mysql command")
-		}
+// DropTestDB drops the test database if it exists
+func (m *mysqlTester) DropTestDB(cfg testharness.Config) error {
+	sql := fmt.Sprintf("drop database if exists %s;", cfg.TestDBName)
+	return m.runCmd(cfg, sql, "mysql")
+}
+
+// Open a connection to the test database
+func (m *mysqlTester) Open(cfg testharness.Config) (*sql.DB, error) {
+	return sql.Open("mysql", driver.MySQLBuildQueryString(cfg.User, cfg.Pass, cfg.TestDBName, cfg.Host, cfg.Port, cfg.SSLMode))
+}
+
+// Close removes the option file
+func (m *mysqlTester) Close() error {
+	if len(m.optionFile) == 0 {
+		return nil
 	}
 
-	return nil
+	return os.Remove(m.optionFile)
 }
 
 func (m *mysqlTester) sslMode(mode string) string {
@@ -115,18 +106,24 @@ func (m *mysqlTester) sslMode(mode string) string {
 	}
 }
 
-func (m *mysqlTester) defaultsFile() string {
-	return fmt.Sprintf("--defaults-file=%s", m.optionFile)
+func (m *mysqlTester) defaultsFile(cfg testharness.Config) (string, error) {
+	if len(m.optionFile) == 0 {
+		if err := m.makeOptionFile(cfg); err != nil {
+			return "", errors.Wrap(err, "couldn't make option file")
+		}
+	}
+
+	return fmt.Sprintf("--defaults-file=%s", m.optionFile), nil
 }
 
-func (m *mysqlTester) makeOptionFile() error {
+func (m *mysqlTester) makeOptionFile(cfg testharness.Config) error {
 	tmp, err := os.CreateTemp("", "optionfile")
 	if err != nil {
 		return errors.Wrap(err, "failed to create option file")
 	}
 
 	isTCP := false
-	_, err = os.Stat(m.host)
+	_, err = os.Stat(cfg.Host)
 	if os.IsNotExist(err) {
 		isTCP = true
 	} else if err != nil {
@@ -134,13 +131,13 @@ func (m *mysqlTester) makeOptionFile() error {
 	}
 
 	fmt.Fprintln(tmp, "[client]")
-	fmt.Fprintf(tmp, "host=%s\n", m.host)
-	fmt.Fprintf(tmp, "port=%d\n", m.port)
-	fmt.Fprintf(tmp, "user=%s\n", m.user)
-	if len(m.pass) != 0 {
-		fmt.Fprintf(tmp, "password=%s\n", m.pass)
+	fmt.Fprintf(tmp, "host=%s\n", cfg.Host)
+	fmt.Fprintf(tmp, "port=%d\n", cfg.Port)
+	fmt.Fprintf(tmp, "user=%s\n", cfg.User)
+	if len(cfg.Pass) != 0 {
+		fmt.Fprintf(tmp, "password=%s\n", cfg.Pass)
 	}
-	if mode := m.sslMode(m.sslmode); mode != "" {
+	if mode := m.sslMode(cfg.SSLMode); mode != "" {
 		fmt.Fprintf(tmp, "ssl-mode=%s\n", mode)
 	}
 	if isTCP {
@@ -148,13 +145,13 @@ func (m *mysqlTester) makeOptionFile() error {
 	}
 
 	fmt.Fprintln(tmp, "[mysqldump]")
-	fmt.Fprintf(tmp, "host=%s\n", m.host)
-	fmt.Fprintf(tmp, "port=%d\n", m.port)
-	fmt.Fprintf(tmp, "user=%s\n", m.user)
-	if len(m.pass) != 0 {
-		fmt.Fprintf(tmp, "password=%s\n", m.pass)
+	fmt.Fprintf(tmp, "host=%s\n", cfg.Host)
+	fmt.Fprintf(tmp, "port=%d\n", cfg.Port)
+	fmt.Fprintf(tmp, "user=%s\n", cfg.User)
+	if len(cfg.Pass) != 0 {
+		fmt.Fprintf(tmp, "password=%s\n", cfg.Pass)
 	}
-	if mode := m.sslMode(m.sslmode); mode != "" {
+	if mode := m.sslMode(cfg.SSLMode); mode != "" {
 		fmt.Fprintf(tmp, "ssl-mode=%s\n", mode)
 	}
 	if isTCP {
@@ -166,32 +163,12 @@ func (m *mysqlTester) makeOptionFile() error {
 	return tmp.Close()
 }
 
-func (m *mysqlTester) createTestDB() error {
-	sql := fmt.Sprintf("create database %s;", m.testDBName)
-	return m.runCmd(sql, "mysql")
-}
-
-func (m *mysqlTester) dropTestDB() error {
-	sql := fmt.Sprintf("drop database if exists %s;", m.testDBName)
-	return m.runCmd(sql, "mysql")
-}
-
-func (m *mysqlTester) teardown() error {
-	if m.dbConn != nil {
-		return m.dbConn.Close()
-	}
-
-	if !m.skipSQLCmd {
-		if err := m.dropTestDB(); err != nil {
-			return err
-		}
+func (m *mysqlTester) runCmd(cfg testharness.Config, stdin, command string, args ...string) error {
+	defaultsFile, err := m.defaultsFile(cfg)
+	if err != nil {
+		return err
 	}
-
-	return os.Remove(m.optionFile)
-}
-
-func (m *mysqlTester) runCmd(stdin, command string, args ...string) error {
-	args = append([]string{m.defaultsFile()}, args...)
+	args = append([]string{defaultsFile}, args...)
 
 	cmd := exec.Command(command, args...)
 	cmd.Stdin = strings.NewReader(stdin)
@@ -201,25 +178,11 @@ func (m *mysqlTester) runCmd(stdin, command string, args ...string) error {
 	cmd.Stdout = stdout
 	cmd.Stderr = stderr
 	if err := cmd.Run(); err != nil {
-	fmt.Println("failed running:", command, args)
-	fmt.Println(stdout.String())
-	fmt.Println(stderr.String())
-	return err
+		fmt.Println("failed running:", command, args)
+		fmt.Println(stdout.String())
+		fmt.Println(stderr.String())
+		return err
 	}
 
 	return nil
 }
-
-func (m *mysqlTester) conn() (*sql.DB, error) {
-	if m.dbConn != nil {
-	return m.dbConn, nil
-	}
-
-	var err error
-	m.dbConn, err = sql.Open("mysql", driver.MySQLBuildQueryString(m.user, m.pass, m.testDBName, m.host, m.port, m.sslmode))
-	if err != nil {
-	return nil, err
-	}
-
-	return m.dbConn, nil
-}
@@ -1,3 +1,4 @@
+{{- if not .NoUpsert -}}
 {{- $alias := .Aliases.Table .Table.Name}}
 func test{{$alias.UpPlural}}Upsert(t *testing.T) {
 	t.Parallel()
@@ -17,7 +18,7 @@ func test{{$alias.UpPlural}}Upsert(t *testing.T) {
 		t.Errorf("Unable to randomize {{$alias.UpSingular}} struct: %s", err)
 	}
 
-	{{if not .NoContext}}ctx := context.Background(){{end}}
+	{{if not .NoContext}}ctx := {{template "test_context" $}}{{end}}
 	tx := MustTx({{if .NoContext}}{{if .NoContext}}boil.Begin(){{else}}boil.BeginTx(ctx, nil){{end}}{{else}}boil.BeginTx(ctx, nil){{end}})
 	defer func() { _ = tx.Rollback() }()
 	if err = o.Upsert({{if not .NoContext}}ctx, {{end -}} tx, boil.Infer(), boil.Infer()); err != nil {
@@ -49,3 +50,4 @@ func test{{$alias.UpPlural}}Upsert(t *testing.T) {
 		t.Error("want one record, got:", count)
 	}
 }
+{{- end -}}
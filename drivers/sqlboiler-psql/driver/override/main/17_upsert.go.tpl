@@ -1,6 +1,7 @@
-{{- if or (not .Table.IsView) .Table.ViewCapabilities.CanUpsert -}}
+{{- if and (not .NoUpsert) (or (not .Table.IsView) .Table.ViewCapabilities.CanUpsert) -}}
 {{- $alias := .Aliases.Table .Table.Name}}
 {{- $schemaTable := .Table.Name | .SchemaTable}}
+{{- $tenant := .Tenancy.Scopes .Table.Name}}
 {{if .AddGlobal -}}
 // UpsertG attempts an insert, and does an update or ignore on conflict.
 func (o *{{$alias.UpSingular}}) UpsertG({{if not .NoContext}}ctx context.Context, {{end -}} updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
@@ -33,6 +34,14 @@ func (o *{{$alias.UpSingular}}) UpsertP({{if .NoContext}}exec boil.Executor{{els
 // Upsert attempts an insert using an executor, and does an update or ignore on conflict.
 // See boil.Columns documentation for how to properly use updateColumns and insertColumns.
 func (o *{{$alias.UpSingular}}) Upsert({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{.Table.Name}}", "upsert")
+
+	{{end -}}
 	if o == nil {
 		return errors.New("{{.PkgName}}: no {{.Table.Name}} provided for upsert")
 	}
@@ -44,6 +53,18 @@ func (o *{{$alias.UpSingular}}) Upsert({{if .NoContext}}exec boil.Executor{{else
 		return err
 	}
 	{{- end}}
+	{{- if $tenant}}
+
+	if err := tenantCheck(ctx, o.{{$alias.Column $.Tenancy.Column}}); err != nil {
+		return err
+	}
+	{{- end}}
+	{{- if .AddValidation}}
+
+	if err := o.Validate(); err != nil {
+		return err
+	}
+	{{- end}}
 
 	nzDefaults := queries.NonZeroDefaultSet({{$alias.DownSingular}}ColumnsWithDefault, o)
 
@@ -93,7 +114,10 @@ func (o *{{$alias.UpSingular}}) Upsert({{if .NoContext}}exec boil.Executor{{else
 			{{$alias.DownSingular}}AllColumns,
 			{{$alias.DownSingular}}PrimaryKeyColumns,
 		)
-		{{if filterColumnsByAuto true .Table.Columns }}
+		{{if filterColumnsByIdentity .Table.Columns -}}
+		insert = strmangle.SetComplement(insert, strmangle.SetComplement({{$alias.DownSingular}}GeneratedColumns, {{$alias.DownSingular}}IdentityColumns))
+		update = strmangle.SetComplement(update, {{$alias.DownSingular}}GeneratedColumns)
+		{{- else if filterColumnsByAuto true .Table.Columns }}
 		insert = strmangle.SetComplement(insert, {{$alias.DownSingular}}GeneratedColumns)
 		update = strmangle.SetComplement(update, {{$alias.DownSingular}}GeneratedColumns)
 		{{- end }}
@@ -107,7 +131,12 @@ func (o *{{$alias.UpSingular}}) Upsert({{if .NoContext}}exec boil.Executor{{else
 			conflict = make([]string, len({{$alias.DownSingular}}PrimaryKeyColumns))
 			copy(conflict, {{$alias.DownSingular}}PrimaryKeyColumns)
 		}
-		cache.query = buildUpsertQueryPostgres(dialect, "{{$schemaTable}}", updateOnConflict, ret, update, conflict, insert)
+		{{- if filterColumnsByIdentity .Table.Columns}}
+		overriding := len(strmangle.SetComplement(insert, {{$alias.DownSingular}}IdentityColumns)) != len(insert)
+		{{- else}}
+		overriding := false
+		{{- end}}
+		cache.query = buildUpsertQueryPostgres(dialect, "{{$schemaTable}}", updateOnConflict, overriding, ret, update, conflict, insert)
 
 		cache.valueMapping, err = queries.BindMapping({{$alias.DownSingular}}Type, {{$alias.DownSingular}}Mapping, insert)
 		if err != nil {
@@ -128,39 +157,30 @@ func (o *{{$alias.UpSingular}}) Upsert({{if .NoContext}}exec boil.Executor{{else
 		returns = queries.PtrsFromMapping(value, cache.retMapping)
 	}
 
-	{{if .NoContext -}}
-	if boil.DebugMode {
-		fmt.Fprintln(boil.DebugWriter, cache.query)
-		fmt.Fprintln(boil.DebugWriter, vals)
-	}
-	{{else -}}
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, cache.query)
-		fmt.Fprintln(writer, vals)
-	}
-	{{end -}}
-
 	if len(cache.retMapping) != 0 {
 		{{if .NoContext -}}
-		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
+		err = boil.DebugQueryRow(exec, cache.query, vals...).Scan(returns...)
 		{{else -}}
-		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		err = boil.DebugQueryRowContext(ctx, exec, cache.query, vals...).Scan(returns...)
 		{{end -}}
 		if errors.Is(err, sql.ErrNoRows) {
 			err = nil // Postgres doesn't return anything when there's no update
 		}
 	} else {
 		{{if .NoContext -}}
-		_, err = exec.Exec(cache.query, vals...)
+		_, err = boil.DebugExec(exec, cache.query, vals...)
 		{{else -}}
-		_, err = exec.ExecContext(ctx, cache.query, vals...)
+		_, err = boil.DebugExecContext(ctx, exec, cache.query, vals...)
 		{{end -}}
 	}
 	if err != nil {
-		return errors.Wrap(err, "{{.PkgName}}: unable to upsert {{.Table.Name}}")
+		return errors.Wrap(boil.ConvertError(err), "{{.PkgName}}: unable to upsert {{.Table.Name}}")
 	}
 
+	{{if .WithIdentityMap -}}
+	boil.IdentityMapFrom(exec).Invalidate("{{.Table.Name}}", {{.Table.PKey.Columns | stringMap (aliasCols $alias) | prefixStringSlice "o." | join ", "}})
+
+	{{end -}}
 	if !cached {
 		{{$alias.DownSingular}}UpsertCacheMut.Lock()
 		{{$alias.DownSingular}}UpsertCache[key] = cache
@@ -0,0 +1,33 @@
+{{- with .Table.Hypertable -}}
+{{- $alias := $.Aliases.Table $.Table.Name -}}
+{{- $column := $.Table.GetColumn .TimeColumn -}}
+{{- $field := printf "%s.%s" ($.Table.Name | $.SchemaTable) ($column.Name | $.Quotes) -}}
+{{- $integer := has $column.DBType (list "smallint" "integer" "bigint") -}}
+{{- $widthType := "string" -}}
+{{- if $integer}}{{$widthType = $column.Type | replace "null." "" | lower}}{{end -}}
+// {{$alias.UpPlural}}TimeBucket selects the start of the bucket of width,
+{{- if $integer}} a range of
+// {{.TimeColumn}} values,
+{{- else}} an interval
+// like "15 minutes",
+{{- end}} that the {{.TimeColumn}} of each row falls in, as alias,
+// and groups the rows by it. Select the aggregates of the buckets with it:
+//
+//	err := models.{{$alias.UpPlural}}(
+//		models.{{$alias.UpPlural}}TimeBucket({{if $integer}}100{{else}}"1 hour"{{end}}, "bucket"),
+//		qm.Select("count(*) AS count"),
+//		qm.OrderBy("bucket"),
+//	).Bind(ctx, db, &buckets)
+func {{$alias.UpPlural}}TimeBucket(width{{if $integer}} {{$widthType}},{{else}},{{end}} alias string) qm.QueryMod {
+	return qm.QueryModFunc(func(q *queries.Query) {
+		queries.AppendSelectExpr(q, "time_bucket(?::{{if $integer}}{{$column.DBType}}{{else}}interval{{end}}, {{$field}})", alias, width)
+		queries.AppendGroupBy(q, strmangle.IdentQuote(dialect.LQ, dialect.RQ, alias))
+	})
+}
+
+// {{$alias.UpPlural}}TimeRange limits the rows to the ones whose {{.TimeColumn}} is from start
+// up to end, so the chunks out of the range are not scanned.
+func {{$alias.UpPlural}}TimeRange(start, end {{$column.Type}}) qm.QueryMod {
+	return qm.Where("{{$field}} >= ? AND {{$field}} < ?", start, end)
+}
+{{end -}}
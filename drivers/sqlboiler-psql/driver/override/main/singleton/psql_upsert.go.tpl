@@ -1,17 +1,24 @@
-// buildUpsertQueryPostgres builds a SQL statement string using the upsertData provided.
-func buildUpsertQueryPostgres(dia drivers.Dialect, tableName string, updateOnConflict bool, ret, update, conflict, whitelist []string) string {
-	conflict = strmangle.IdentQuoteSlice(dia.LQ, dia.RQ, conflict)
-	whitelist = strmangle.IdentQuoteSlice(dia.LQ, dia.RQ, whitelist)
-	ret = strmangle.IdentQuoteSlice(dia.LQ, dia.RQ, ret)
+{{- if not .NoUpsert -}}
+// buildUpsertQueryPostgres builds a SQL statement string using the upsertData provided,
+// overriding the system value of the identity columns when overriding is set.
+func buildUpsertQueryPostgres(dia drivers.Dialect, tableName string, updateOnConflict, overriding bool, ret, update, conflict, whitelist []string) string {
+	conflict = dia.QuoteSlice(conflict)
+	whitelist = dia.QuoteSlice(whitelist)
+	ret = dia.QuoteSlice(ret)
 
 	buf := strmangle.GetBuffer()
 	defer strmangle.PutBuffer(buf)
 
 	columns := "DEFAULT VALUES"
 	if len(whitelist) != 0 {
-		columns = fmt.Sprintf("(%s) VALUES (%s)",
+		values := "VALUES"
+		if overriding {
+			values = "OVERRIDING SYSTEM VALUE VALUES"
+		}
+		columns = fmt.Sprintf("(%s) %s (%s)",
 			strings.Join(whitelist, ", "),
-			strmangle.Placeholders(dia.UseIndexPlaceholders, len(whitelist), 1, 1))
+			values,
+			dia.Placeholders(len(whitelist), 1, 1))
 	}
 
 	fmt.Fprintf(
@@ -36,7 +43,7 @@ func buildUpsertQueryPostgres(dia drivers.Dialect, tableName string, updateOnCon
 			if i != 0 {
 				buf.WriteByte(',')
 			}
-			quoted := strmangle.IdentQuote(dia.LQ, dia.RQ, v)
+			quoted := dia.Quote(v)
 			buf.WriteString(quoted)
 			buf.WriteString(" = EXCLUDED.")
 			buf.WriteString(quoted)
@@ -50,3 +57,4 @@ func buildUpsertQueryPostgres(dia drivers.Dialect, tableName string, updateOnCon
 
 	return buf.String()
 }
+{{- end -}}
@@ -1,117 +1,107 @@
 var rgxPGFkey = regexp.MustCompile(`(?m)^ALTER TABLE .*\n\s+ADD CONSTRAINT .*? FOREIGN KEY .*?;\n`)
 
 type pgTester struct {
-	dbConn *sql.DB
-
-	dbName  string
-	host    string
-	user    string
-	pass    string
-	sslmode string
-	port    int
-
 	pgPassFile string
-
-	testDBName string
-	skipSQLCmd bool
 }
 
 func init() {
-	dbMain = &pgTester{}
-}
-
-// setup dumps the database schema and imports it into a temporary randomly
-// generated test database so that tests can be run against it using the
-// generated sqlboiler ORM package.
-func (p *pgTester) setup() error {
-	var err error
-
 	viper.SetDefault("psql.schema", "public")
 	viper.SetDefault("psql.port", 5432)
 	viper.SetDefault("psql.sslmode", "require")
 
-	p.dbName = viper.GetString("psql.dbname")
-	p.host = viper.GetString("psql.host")
-	p.user = viper.GetString("psql.user")
-	p.pass = viper.GetString("psql.pass")
-	p.port = viper.GetInt("psql.port")
-	p.sslmode = viper.GetString("psql.sslmode")
-	p.testDBName = viper.GetString("psql.testdbname")
-	p.skipSQLCmd = viper.GetBool("psql.skipsqlcmd")
-
-	err = vala.BeginValidation().Validate(
-		vala.StringNotEmpty(p.user, "psql.user"),
-		vala.StringNotEmpty(p.host, "psql.host"),
-		vala.Not(vala.Equals(p.port, 0, "psql.port")),
-		vala.StringNotEmpty(p.dbName, "psql.dbname"),
-		vala.StringNotEmpty(p.sslmode, "psql.sslmode"),
+	dbMain = &harnessTester{driver: &pgTester{}}
+}
+
+// Validate the connection information used to create the test database
+func (p *pgTester) Validate(cfg testharness.Config) error {
+	return vala.BeginValidation().Validate(
+		vala.StringNotEmpty(cfg.User, "psql.user"),
+		vala.StringNotEmpty(cfg.Host, "psql.host"),
+		vala.Not(vala.Equals(cfg.Port, 0, "psql.port")),
+		vala.StringNotEmpty(cfg.DBName, "psql.dbname"),
+		vala.StringNotEmpty(cfg.SSLMode, "psql.sslmode"),
 	).Check()
+}
 
-	if err != nil {
+// CreateTestDB dumps the database schema and imports it into the test
+// database so that tests can be run against it using the generated
+// sqlboiler ORM package.
+func (p *pgTester) CreateTestDB(cfg testharness.Config) error {
+	if err := p.runCmd(cfg, "", "createdb", cfg.TestDBName); err != nil {
 		return err
 	}
 
-	// if no testing DB passed
-	if len(p.testDBName) == 0 {
-		// Create a randomized db name.
-		p.testDBName = randomize.StableDBName(p.dbName)
-	}
-
-	if err = p.makePGPassFile(); err != nil {
+	env, err := p.pgEnv(cfg)
+	if err != nil {
 		return err
 	}
 
-	if !p.skipSQLCmd {
-		if err = p.dropTestDB(); err != nil {
-			return err
-		}
-		if err = p.createTestDB(); err != nil {
-			return err
-		}
-
-		dumpCmd := exec.Command("pg_dump", "--schema-only", p.dbName)
-		dumpCmd.Env = append(os.Environ(), p.pgEnv()...)
-		createCmd := exec.Command("psql", p.testDBName)
-		createCmd.Env = append(os.Environ(), p.pgEnv()...)
+	dumpCmd := exec.Command("pg_dump", "--schema-only", cfg.DBName)
+	dumpCmd.Env = append(os.Environ(), env...)
+	createCmd := exec.Command("psql", cfg.TestDBName)
+	createCmd.Env = append(os.Environ(), env...)
 
-		r, w := io.Pipe()
-		dumpCmdStderr := &bytes.Buffer{}
-		createCmdStderr := &bytes.Buffer{}
+	r, w := io.Pipe()
+	dumpCmdStderr := &bytes.Buffer{}
+	createCmdStderr := &bytes.Buffer{}
 
-		dumpCmd.Stdout = w
-		dumpCmd.Stderr = dumpCmdStderr
+	dumpCmd.Stdout = w
+	dumpCmd.Stderr = dumpCmdStderr
 
-		createCmd.Stdin = newFKeyDestroyer(rgxPGFkey, r)
-		createCmd.Stderr = createCmdStderr
+	createCmd.Stdin = newFKeyDestroyer(rgxPGFkey, r)
+	createCmd.Stderr = createCmdStderr
 
-		if err = dumpCmd.Start(); err != nil {
-			return errors.Wrap(err, "failed to start pg_dump command")
-		}
-		if err = createCmd.Start(); err != nil {
-			return errors.Wrap(err, "failed to start psql command")
-		}
+	if err = dumpCmd.Start(); err != nil {
+		return errors.Wrap(err, "failed to start pg_dump command")
+	}
+	if err = createCmd.Start(); err != nil {
+		return errors.Wrap(err, "failed to start psql command")
+	}
 
-		if err = dumpCmd.Wait(); err != nil {
-			fmt.Println(err)
-			fmt.Println(dumpCmdStderr.String())
-			return errors.Wrap(err, "failed to wait for pg_dump command")
-		}
+	if err = dumpCmd.Wait(); err != nil {
+		fmt.Println(err)
+		fmt.Println(dumpCmdStderr.String())
+		return errors.Wrap(err, "failed to wait for pg_dump command")
+	}
 
-		_ = w.Close() // After dumpCmd is done, close the write end of the pipe
+	_ = w.Close() // After dumpCmd is done, close the write end of the pipe
 
-		if err = createCmd.Wait(); err != nil {
-			fmt.Println(err)
-			fmt.Println(createCmdStderr.String())
-			return errors.Wrap(err, "failed to wait for psql command")
-		}
+	if err = createCmd.Wait(); err != nil {
+		fmt.Println(err)
+		fmt.Println(createCmdStderr.String())
+		return errors.Wrap(err, "failed to wait for psql command")
 	}
 
 	return nil
 }
 
-func (p *pgTester) runCmd(stdin, command string, args ...string) error {
+// DropTestDB drops the test database if it exists
+func (p *pgTester) DropTestDB(cfg testharness.Config) error {
+	return p.runCmd(cfg, "", "dropdb", "--if-exists", cfg.TestDBName)
+}
+
+// Open a connection to the test database
+func (p *pgTester) Open(cfg testharness.Config) (*sql.DB, error) {
+	return sql.Open("postgres", driver.PSQLBuildQueryString(cfg.User, cfg.Pass, cfg.TestDBName, cfg.Host, cfg.Port, cfg.SSLMode))
+}
+
+// Close removes the pgpass file
+func (p *pgTester) Close() error {
+	if len(p.pgPassFile) == 0 {
+		return nil
+	}
+
+	return os.Remove(p.pgPassFile)
+}
+
+func (p *pgTester) runCmd(cfg testharness.Config, stdin, command string, args ...string) error {
+	env, err := p.pgEnv(cfg)
+	if err != nil {
+		return err
+	}
+
 	cmd := exec.Command(command, args...)
-	cmd.Env = append(os.Environ(), p.pgEnv()...)
+	cmd.Env = append(os.Environ(), env...)
 
 	if len(stdin) != 0 {
 		cmd.Stdin = strings.NewReader(stdin)
@@ -131,78 +121,45 @@ func (p *pgTester) runCmd(stdin, command string, args ...string) error {
 	return nil
 }
 
-func (p *pgTester) pgEnv() []string {
+func (p *pgTester) pgEnv(cfg testharness.Config) ([]string, error) {
+	if len(p.pgPassFile) == 0 {
+		if err := p.makePGPassFile(cfg); err != nil {
+			return nil, err
+		}
+	}
+
 	return []string{
-		fmt.Sprintf("PGHOST=%s", p.host),
-		fmt.Sprintf("PGPORT=%d", p.port),
-		fmt.Sprintf("PGUSER=%s", p.user),
+		fmt.Sprintf("PGHOST=%s", cfg.Host),
+		fmt.Sprintf("PGPORT=%d", cfg.Port),
+		fmt.Sprintf("PGUSER=%s", cfg.User),
 		fmt.Sprintf("PGPASSFILE=%s", p.pgPassFile),
-	}
+	}, nil
 }
 
-func (p *pgTester) makePGPassFile() error {
+func (p *pgTester) makePGPassFile(cfg testharness.Config) error {
 	tmp, err := os.CreateTemp("", "pgpass")
 	if err != nil {
 		return errors.Wrap(err, "failed to create option file")
 	}
 
-	fmt.Fprintf(tmp, "%s:%d:postgres:%s", p.host, p.port, p.user)
-	if len(p.pass) != 0 {
-		fmt.Fprintf(tmp, ":%s", p.pass)
+	fmt.Fprintf(tmp, "%s:%d:postgres:%s", cfg.Host, cfg.Port, cfg.User)
+	if len(cfg.Pass) != 0 {
+		fmt.Fprintf(tmp, ":%s", cfg.Pass)
 	}
 	fmt.Fprintln(tmp)
 
-	fmt.Fprintf(tmp, "%s:%d:%s:%s", p.host, p.port, p.dbName, p.user)
-	if len(p.pass) != 0 {
-		fmt.Fprintf(tmp, ":%s", p.pass)
+	fmt.Fprintf(tmp, "%s:%d:%s:%s", cfg.Host, cfg.Port, cfg.DBName, cfg.User)
+	if len(cfg.Pass) != 0 {
+		fmt.Fprintf(tmp, ":%s", cfg.Pass)
 	}
 	fmt.Fprintln(tmp)
 
-	fmt.Fprintf(tmp, "%s:%d:%s:%s", p.host, p.port, p.testDBName, p.user)
-	if len(p.pass) != 0 {
-		fmt.Fprintf(tmp, ":%s", p.pass)
+	fmt.Fprintf(tmp, "%s:%d:%s:%s", cfg.Host, cfg.Port, cfg.TestDBName, cfg.User)
+	if len(cfg.Pass) != 0 {
+		fmt.Fprintf(tmp, ":%s", cfg.Pass)
 	}
 	fmt.Fprintln(tmp)
 
 	p.pgPassFile = tmp.Name()
 	return tmp.Close()
 }
-
-func (p *pgTester) createTestDB() error {
-	return p.runCmd("", "createdb", p.testDBName)
-}
-
-func (p *pgTester) dropTestDB() error {
-	return p.runCmd("", "dropdb", "--if-exists", p.testDBName)
-}
-
-// teardown executes cleanup tasks when the tests finish running
-func (p *pgTester) teardown() error {
-	var err error
-	if err = p.dbConn.Close(); err != nil {
-		return err
-	}
-	p.dbConn = nil
-
-	if !p.skipSQLCmd {
-		if err = p.dropTestDB(); err != nil {
-			return err
-		}
-	}
-
-	return os.Remove(p.pgPassFile)
-}
-
-func (p *pgTester) conn() (*sql.DB, error) {
-	if p.dbConn != nil {
-		return p.dbConn, nil
-	}
-
-	var err error
-	p.dbConn, err = sql.Open("postgres", driver.PSQLBuildQueryString(p.user, p.pass, p.testDBName, p.host, p.port, p.sslmode))
-	if err != nil {
-		return nil, err
-	}
-
-	return p.dbConn, nil
-}
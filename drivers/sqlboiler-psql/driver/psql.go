@@ -45,6 +45,8 @@ type PostgresDriver struct {
 	version        int
 	addEnumTypes   bool
 	enumNullPrefix string
+	// timescale is set when the timescaledb extension is installed
+	timescale bool
 
 	uniqueColumns map[columnIdentifier]struct{}
 }
@@ -114,6 +116,14 @@ func (p *PostgresDriver) Assemble(config drivers.Config) (dbinfo *drivers.DBInfo
 	if err != nil {
 		return nil, errors.Wrap(err, "sqlboiler-psql failed to get database version")
 	}
+	if err = checkVersion(p.version); err != nil {
+		return nil, err
+	}
+
+	p.timescale, err = p.hasTimescale()
+	if err != nil {
+		return nil, errors.Wrap(err, "sqlboiler-psql failed to check for timescaledb")
+	}
 
 	if err = p.loadUniqueColumns(); err != nil {
 		return nil, errors.Wrap(err, "sqlboiler-psql failed to load unique columns")
@@ -128,11 +138,20 @@ func (p *PostgresDriver) Assemble(config drivers.Config) (dbinfo *drivers.DBInfo
 			UseIndexPlaceholders: true,
 			UseSchema:            useSchema,
 			UseDefaultKeyword:    true,
+			UseNullsOrderClause:  true,
+			UseStatementTimeout:  true,
 		},
 	}
 	dbinfo.Tables, err = drivers.TablesConcurrently(p, config)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrapf(err, "sqlboiler-psql failed to load the tables of postgres %s", formatVersion(p.version))
+	}
+
+	if config.AddFunctions {
+		dbinfo.Functions, err = drivers.Functions(p, config)
+		if err != nil {
+			return nil, errors.Wrapf(err, "sqlboiler-psql failed to load the functions of postgres %s", formatVersion(p.version))
+		}
 	}
 
 	return dbinfo, err
@@ -204,6 +223,9 @@ func (p *PostgresDriver) TableNames(schema string, whitelist, blacklist []string
 
 	query := `select table_name from information_schema.tables where table_schema = $1 and table_type = 'BASE TABLE'`
 	args := []interface{}{schema}
+	if p.timescale {
+		query += ` and table_name not in (` + timescaleInternalTables + `)`
+	}
 	if len(whitelist) > 0 {
 		tables := drivers.TablesFromList(whitelist)
 		if len(tables) > 0 {
@@ -241,6 +263,18 @@ func (p *PostgresDriver) TableNames(schema string, whitelist, blacklist []string
 	return names, nil
 }
 
+// timescaleInternalTables selects the tables of the schema $1 TimescaleDB
+// manages: the chunks of the hypertables, the hypertables storing compressed
+// chunks and the ones materializing continuous aggregates
+const timescaleInternalTables = `
+	select table_name from _timescaledb_catalog.chunk where schema_name = $1
+	union all
+	select h.table_name from _timescaledb_catalog.hypertable h
+	where h.schema_name = $1 and (
+		h.compression_state = 2 or
+		exists (select 1 from _timescaledb_catalog.continuous_agg ca where ca.mat_hypertable_id = h.id)
+	)`
+
 // ViewNames connects to the postgres database and
 // retrieves all view names from the information_schema where the
 // view schema is schema. It uses a whitelist and blacklist.
@@ -417,6 +451,12 @@ func (p *PostgresDriver) Columns(schema, tableName string, whitelist, blacklist
 	var columns []drivers.Column
 	args := []interface{}{schema, tableName}
 
+	// Identity columns are from 10
+	identity := "FALSE"
+	if p.version >= 100000 {
+		identity = "a.attidentity <> ''"
+	}
+
 	matviewQuery := `WITH cte_pg_attribute AS (
 		SELECT
 			pg_catalog.format_type(a.atttypid, NULL) LIKE '%[]' = TRUE as is_array,
@@ -499,7 +539,11 @@ func (p *PostgresDriver) Columns(schema, tableName string, whitelist, blacklist
 		'' as column_comment,
 		a.attnotnull = FALSE as is_nullable,
 		FALSE as is_generated,
-		a.attidentity <> '' as is_identity
+		` + identity + ` as is_identity,
+		COALESCE((SELECT collname FROM pg_collation WHERE oid = a.attcollation), '') as collation_name,
+		(case when a.atttypid in ('bpchar'::regtype, 'varchar'::regtype) then COALESCE(information_schema._pg_char_max_length(a.atttypid, a.atttypmod), 0) else 0 end) as max_length,
+		(case when a.atttypid = 'numeric'::regtype then COALESCE(information_schema._pg_numeric_precision(a.atttypid, a.atttypmod), 0) else 0 end) as numeric_precision,
+		(case when a.atttypid = 'numeric'::regtype then COALESCE(information_schema._pg_numeric_scale(a.atttypid, a.atttypmod), 0) else 0 end) as numeric_scale
 	FROM cte_pg_attribute a
 		JOIN pg_class c on a.attrelid = c.oid
 		JOIN pg_namespace cn on c.relnamespace = cn.oid
@@ -558,7 +602,15 @@ func (p *PostgresDriver) Columns(schema, tableName string, whitelist, blacklist
 			    false
 		    end as is_identity from information_schema.columns
 		    WHERE table_schema='information_schema' and table_name='columns' and column_name='is_identity') IS NULL then 'NO' else is_identity end
-		) = 'YES' as is_identity
+		) = 'YES' as is_identity,
+		COALESCE(c.collation_name, (
+			select coll.collname from pg_attribute pga
+			inner join pg_collation coll on coll.oid = pga.attcollation
+			where pga.attrelid = ('"'||c.table_schema||'"."'||c.table_name||'"')::regclass and pga.attname = c.column_name
+		), '') as collation_name,
+		(case when c.data_type in ('character', 'character varying') then COALESCE(c.character_maximum_length, 0) else 0 end) as max_length,
+		(case when c.data_type = 'numeric' then COALESCE(c.numeric_precision, 0) else 0 end) as numeric_precision,
+		(case when c.data_type = 'numeric' then COALESCE(c.numeric_scale, 0) else 0 end) as numeric_scale
 
 		from information_schema.columns as c
 		inner join pg_namespace as pgn on pgn.nspname = c.udt_schema
@@ -600,7 +652,12 @@ func (p *PostgresDriver) Columns(schema, tableName string, whitelist, blacklist
 		column_comment,
 		is_nullable,
 		is_generated,
-		is_identity
+		is_identity,
+		collation_name,
+		case when collation_name <> '' then getdatabaseencoding() else '' end as charset,
+		max_length,
+		numeric_precision,
+		numeric_scale
 	FROM (
 		%s
 		UNION
@@ -634,30 +691,40 @@ func (p *PostgresDriver) Columns(schema, tableName string, whitelist, blacklist
 	defer rows.Close()
 
 	for rows.Next() {
-		var colName, colType, colFullType, udtName, comment string
+		var colName, colType, colFullType, udtName, comment, collation, charset string
 		var defaultValue, arrayType, domainName *string
 		var nullable, generated, identity bool
-		if err := rows.Scan(&colName, &colType, &colFullType, &udtName, &arrayType, &domainName, &defaultValue, &comment, &nullable, &generated, &identity); err != nil {
+		var maxLength, precision, scale int
+		if err := rows.Scan(&colName, &colType, &colFullType, &udtName, &arrayType, &domainName, &defaultValue, &comment, &nullable, &generated, &identity, &collation, &charset, &maxLength, &precision, &scale); err != nil {
 			return nil, errors.Wrapf(err, "unable to scan for table %s", tableName)
 		}
 
 		_, unique := p.uniqueColumns[columnIdentifier{schema, tableName, colName}]
 		column := drivers.Column{
-			Name:          colName,
-			DBType:        colType,
-			FullDBType:    colFullType,
-			ArrType:       arrayType,
-			DomainName:    domainName,
-			UDTName:       udtName,
-			Comment:       comment,
-			Nullable:      nullable,
-			AutoGenerated: generated,
-			Unique:        unique,
+			Name:            colName,
+			DBType:          colType,
+			FullDBType:      colFullType,
+			ArrType:         arrayType,
+			DomainName:      domainName,
+			UDTName:         udtName,
+			Comment:         comment,
+			Nullable:        nullable,
+			AutoGenerated:   generated,
+			Unique:          unique,
+			CaseInsensitive: udtName == "citext",
+			Collation:       collation,
+			Charset:         charset,
+			MaxLength:       maxLength,
+			Precision:       precision,
+			Scale:           scale,
 		}
 		if defaultValue != nil {
 			column.Default = *defaultValue
 		}
 
+		// Identity columns are filled in like serials, the ones generated
+		// always are also AutoGenerated and only inserted overriding the
+		// system value
 		if identity {
 			column.Default = "IDENTITY"
 		}
@@ -729,10 +796,291 @@ func (p *PostgresDriver) PrimaryKeyInfo(schema, tableName string) (*drivers.Prim
 	return pkey, nil
 }
 
+// Triggers retrieves the triggers of a table, the body of a trigger is the
+// source of its trigger function.
+func (p *PostgresDriver) Triggers(schema, tableName string) ([]drivers.Trigger, error) {
+	query := `
+	select
+		t.tgname,
+		case when t.tgtype & 2 = 2 then 'BEFORE' when t.tgtype & 64 = 64 then 'INSTEAD OF' else 'AFTER' end,
+		t.tgtype & 1 = 1,
+		t.tgtype & 4 = 4,
+		t.tgtype & 16 = 16,
+		t.tgtype & 8 = 8,
+		t.tgtype & 32 = 32,
+		array_to_string(array(
+			select a.attname from pg_attribute a
+			where a.attrelid = t.tgrelid and a.attnum = any(t.tgattr)
+			order by a.attnum
+		), ','),
+		pg_get_triggerdef(t.oid),
+		coalesce(pr.prosrc, '')
+	from pg_trigger t
+	inner join pg_class c on c.oid = t.tgrelid
+	inner join pg_namespace n on n.oid = c.relnamespace
+	inner join pg_proc pr on pr.oid = t.tgfoid
+	where n.nspname = $1 and c.relname = $2 and not t.tgisinternal
+	order by t.tgname;`
+
+	rows, err := p.conn.Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var triggers []drivers.Trigger
+	for rows.Next() {
+		var trigger drivers.Trigger
+		var onInsert, onUpdate, onDelete, onTruncate bool
+		var columns string
+		err := rows.Scan(&trigger.Name, &trigger.Timing, &trigger.ForEachRow,
+			&onInsert, &onUpdate, &onDelete, &onTruncate, &columns, &trigger.Definition, &trigger.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range []struct {
+			fires bool
+			event string
+		}{{onInsert, "INSERT"}, {onUpdate, "UPDATE"}, {onDelete, "DELETE"}, {onTruncate, "TRUNCATE"}} {
+			if e.fires {
+				trigger.Events = append(trigger.Events, e.event)
+			}
+		}
+		if len(columns) != 0 {
+			trigger.Columns = strings.Split(columns, ",")
+		}
+
+		triggers = append(triggers, trigger)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return triggers, nil
+}
+
+// RowSecurity retrieves the row level security policies of a table, it returns
+// nil when row level security is not enabled on the table.
+func (p *PostgresDriver) RowSecurity(schema, tableName string) (*drivers.RowSecurity, error) {
+	// Row level security is available from 9.5
+	if p.version < 90500 {
+		return nil, nil
+	}
+
+	query := `
+	select c.relrowsecurity, c.relforcerowsecurity
+	from pg_class c
+	inner join pg_namespace n on n.oid = c.relnamespace
+	where n.nspname = $1 and c.relname = $2;`
+
+	var enabled bool
+	rs := &drivers.RowSecurity{}
+	if err := p.conn.QueryRow(query, schema, tableName).Scan(&enabled, &rs.Forced); err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, nil
+	}
+
+	query = `
+	select policyname, cmd, array_to_string(roles, ','), coalesce(qual, ''), coalesce(with_check, '')
+	from pg_policies
+	where schemaname = $1 and tablename = $2
+	order by policyname;`
+
+	rows, err := p.conn.Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var policy drivers.Policy
+		var roles string
+		if err := rows.Scan(&policy.Name, &policy.Command, &roles, &policy.Using, &policy.Check); err != nil {
+			return nil, err
+		}
+		if len(roles) != 0 {
+			policy.Roles = strings.Split(roles, ",")
+		}
+		rs.Policies = append(rs.Policies, policy)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rs, nil
+}
+
+// Hypertable retrieves the time dimension of a TimescaleDB hypertable, or of
+// the hypertable materializing a continuous aggregate, it returns nil for the
+// other tables and views and when timescaledb is not installed.
+func (p *PostgresDriver) Hypertable(schema, tableName string) (*drivers.Hypertable, error) {
+	if !p.timescale {
+		return nil, nil
+	}
+
+	query := `
+	select d.column_name, coalesce(d.time_interval::text, d.integer_interval::text, ''), ''
+	from timescaledb_information.dimensions d
+	where d.hypertable_schema = $1 and d.hypertable_name = $2 and d.dimension_number = 1
+	union all
+	select d.column_name, coalesce(d.time_interval::text, d.integer_interval::text, ''), ca.hypertable_name
+	from timescaledb_information.continuous_aggregates ca
+	inner join timescaledb_information.dimensions d
+		on d.hypertable_schema = ca.materialization_hypertable_schema
+		and d.hypertable_name = ca.materialization_hypertable_name
+		and d.dimension_number = 1
+	where ca.view_schema = $1 and ca.view_name = $2;`
+
+	ht := &drivers.Hypertable{}
+	err := p.conn.QueryRow(query, schema, tableName).Scan(&ht.TimeColumn, &ht.ChunkInterval, &ht.Aggregates)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ht, nil
+}
+
+// Functions retrieves the functions and procedures of the schema. Trigger
+// functions, aggregates, functions of extensions and functions returning
+// records without OUT parameters cannot be called by generated code and are
+// left out.
+func (p *PostgresDriver) Functions(schema string) ([]drivers.Function, error) {
+	query := `
+	select
+		r.specific_name,
+		r.routine_name,
+		r.routine_type = 'PROCEDURE' as is_procedure,
+		pr.proretset,
+		coalesce(r.data_type, 'void'),
+		coalesce(r.type_udt_name, ''),
+		coalesce((
+			select c.relname
+			from pg_type t
+			inner join pg_class c on c.oid = t.typrelid
+			where t.oid = pr.prorettype and c.relkind in ('r', 'v', 'm')
+		), '') as table_name
+	from information_schema.routines r
+	inner join pg_proc pr on pr.oid = substring(r.specific_name from '_([0-9]+)$')::oid
+	where r.routine_schema = $1
+	and r.data_type is distinct from 'trigger'
+	and not exists (select 1 from pg_aggregate a where a.aggfnoid = pr.oid)
+	and not exists (select 1 from pg_depend d where d.objid = pr.oid and d.deptype = 'e')
+	order by r.routine_name, r.specific_name;`
+
+	rows, err := p.conn.Query(query, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fns []drivers.Function
+	var specificNames []string
+	for rows.Next() {
+		var fn drivers.Function
+		var specificName, dataType, udtName string
+		if err := rows.Scan(&specificName, &fn.Name, &fn.IsProcedure, &fn.ReturnsSet, &dataType, &udtName, &fn.Table); err != nil {
+			return nil, errors.Wrapf(err, "unable to scan function")
+		}
+
+		if len(fn.Table) == 0 && dataType != "void" {
+			fn.Return = &drivers.Column{Name: fn.Name, DBType: dataType, UDTName: udtName}
+		}
+
+		fns = append(fns, fn)
+		specificNames = append(specificNames, specificName)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ret := fns[:0]
+	for i, fn := range fns {
+		if err := p.functionParams(schema, specificNames[i], &fn); err != nil {
+			return nil, errors.Wrapf(err, "unable to load params of function %s", fn.Name)
+		}
+
+		if len(fn.Results) != 0 {
+			fn.Table, fn.Return = "", nil
+		} else if fn.Return != nil && fn.Return.DBType == "record" {
+			fmt.Fprintf(os.Stderr, "warning: skipping function %s, it returns records without OUT parameters\n", fn.Name)
+			continue
+		}
+
+		ret = append(ret, fn)
+	}
+
+	return ret, nil
+}
+
+// functionParams loads the params and results of the function with the
+// specific name
+func (p *PostgresDriver) functionParams(schema, specificName string, fn *drivers.Function) error {
+	query := `
+	select
+		coalesce(p.parameter_name, ''),
+		p.parameter_mode,
+		p.data_type,
+		p.udt_name,
+		(
+			select e.data_type
+			from information_schema.element_types e
+			where e.object_schema = p.specific_schema
+			and e.object_name = p.specific_name
+			and e.object_type = 'ROUTINE'
+			and e.collection_type_identifier = p.dtd_identifier
+		) as array_type
+	from information_schema.parameters p
+	where p.specific_schema = $1 and p.specific_name = $2
+	order by p.ordinal_position;`
+
+	rows, err := p.conn.Query(query, schema, specificName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var mode string
+		var col drivers.Column
+		if err := rows.Scan(&col.Name, &mode, &col.DBType, &col.UDTName, &col.ArrType); err != nil {
+			return err
+		}
+
+		if mode == "IN" || mode == "INOUT" {
+			param := col
+			if len(param.Name) == 0 {
+				param.Name = fmt.Sprintf("arg%d", len(fn.Params)+1)
+			}
+			fn.Params = append(fn.Params, param)
+		}
+		if mode == "OUT" || mode == "INOUT" {
+			if len(col.Name) == 0 {
+				col.Name = fmt.Sprintf("column%d", len(fn.Results)+1)
+			}
+			fn.Results = append(fn.Results, col)
+		}
+	}
+
+	return rows.Err()
+}
+
 // ForeignKeyInfo retrieves the foreign keys for a given table name.
 func (p *PostgresDriver) ForeignKeyInfo(schema, tableName string) ([]drivers.ForeignKey, error) {
 	var fkeys []drivers.ForeignKey
 
+	// Partitioned tables are from 10 and generated columns from 12
+	relkinds := "'r'"
+	if p.version >= 100000 {
+		relkinds = "'r', 'p'"
+	}
 	whereConditions := []string{"pgn.nspname = $2", "pgc.relname = $1", "pgcon.contype = 'f'"}
 	if p.version >= 120000 {
 		whereConditions = append(whereConditions, "pgasrc.attgenerated = ''", "pgadst.attgenerated = ''")
@@ -746,14 +1094,14 @@ func (p *PostgresDriver) ForeignKeyInfo(schema, tableName string) ([]drivers.For
 		dstlookupname.relname as dest_table,
 		pgadst.attname as dest_column
 	from pg_namespace pgn
-		inner join pg_class pgc on pgn.oid = pgc.relnamespace and pgc.relkind = 'r'
+		inner join pg_class pgc on pgn.oid = pgc.relnamespace and pgc.relkind in (%s)
 		inner join pg_constraint pgcon on pgn.oid = pgcon.connamespace and pgc.oid = pgcon.conrelid
 		inner join pg_class dstlookupname on pgcon.confrelid = dstlookupname.oid
 		inner join pg_attribute pgasrc on pgc.oid = pgasrc.attrelid and pgasrc.attnum = ANY(pgcon.conkey)
 		inner join pg_attribute pgadst on pgcon.confrelid = pgadst.attrelid and pgadst.attnum = ANY(pgcon.confkey)
 	where %s
 	order by pgcon.conname, source_table, source_column, dest_table, dest_column`,
-		strings.Join(whereConditions, " and "),
+		relkinds, strings.Join(whereConditions, " and "),
 	)
 
 	var rows *sql.Rows
@@ -1010,7 +1358,7 @@ func (p PostgresDriver) Imports() (importers.Collection, error) {
 				`"github.com/friendsofgo/errors"`,
 				`"github.com/spf13/viper"`,
 				`"github.com/volatiletech/sqlboiler/v4/drivers/sqlboiler-psql/driver"`,
-				`"github.com/volatiletech/randomize"`,
+				`"github.com/volatiletech/sqlboiler/v4/testharness"`,
 				`_ "github.com/lib/pq"`,
 			},
 		},
@@ -1150,6 +1498,40 @@ func (p PostgresDriver) Imports() (importers.Collection, error) {
 	return col, nil
 }
 
+// minVersion is the oldest postgres the driver supports, the upserts of the
+// generated code need its ON CONFLICT
+const minVersion = 90500
+
+// checkVersion returns an error naming the version of the server when it is
+// older than minVersion
+func checkVersion(version int) error {
+	if version < minVersion {
+		return errors.Errorf("sqlboiler-psql needs postgres %s or newer, the server is postgres %s", formatVersion(minVersion), formatVersion(version))
+	}
+	return nil
+}
+
+// formatVersion formats a server_version_num, like 90624 as 9.6.24 and
+// 160002 as 16.2
+func formatVersion(version int) string {
+	if version >= 100000 {
+		return fmt.Sprintf("%d.%d", version/10000, version%10000)
+	}
+	return fmt.Sprintf("%d.%d.%d", version/10000, version/100%100, version%100)
+}
+
+// hasTimescale checks if the timescaledb extension is installed in the
+// database
+func (p *PostgresDriver) hasTimescale() (bool, error) {
+	var installed bool
+	row := p.conn.QueryRow("select exists (select 1 from pg_extension where extname = 'timescaledb')")
+	if err := row.Scan(&installed); err != nil {
+		return false, err
+	}
+
+	return installed, nil
+}
+
 // getVersion gets the version of underlying database
 func (p *PostgresDriver) getVersion() (int, error) {
 	type versionInfoType struct {
@@ -120,3 +120,37 @@ func TestAssemble(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckVersion(t *testing.T) {
+	t.Parallel()
+
+	if err := checkVersion(90500); err != nil {
+		t.Error(err)
+	}
+	if err := checkVersion(160002); err != nil {
+		t.Error(err)
+	}
+
+	err := checkVersion(90426)
+	if err == nil {
+		t.Fatal("want an error for postgres 9.4")
+	}
+	if want := "sqlboiler-psql needs postgres 9.5.0 or newer, the server is postgres 9.4.26"; err.Error() != want {
+		t.Errorf("want %q, got %q", want, err.Error())
+	}
+}
+
+func TestFormatVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := map[int]string{
+		90624:  "9.6.24",
+		100023: "10.23",
+		160002: "16.2",
+	}
+	for version, want := range tests {
+		if got := formatVersion(version); got != want {
+			t.Errorf("%d: want %s, got %s", version, want, got)
+		}
+	}
+}
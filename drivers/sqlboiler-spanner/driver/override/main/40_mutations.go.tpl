@@ -0,0 +1,111 @@
+{{- if .Table.IsView -}}
+// Views have no mutations
+var _ = spanner.Mutation{}
+{{- else -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $tableName := .Table.Name -}}{{- if .Schema}}{{$tableName = printf "%s.%s" .Schema .Table.Name}}{{end -}}
+var (
+	{{$alias.DownSingular}}CommitTimestampColumns = []string{ {{- range $column := .Table.Columns}}{{if contains "allow_commit_timestamp=true" $column.FullDBType}}"{{$column.Name}}", {{end}}{{end -}} }
+	{{$alias.DownSingular}}JSONColumns            = []string{ {{- range $column := .Table.Columns}}{{if eq $column.DBType "JSON"}}"{{$column.Name}}", {{end}}{{end -}} }
+)
+
+// InsertMutation returns a mutation inserting the {{$alias.DownSingular}}, applied with
+// Client.Apply or buffered in a read/write transaction instead of running
+// Insert. The columns are chosen like the ones of Insert, and the commit
+// timestamp columns left zero are set to the commit timestamp. No hooks are
+// run and the columns with defaults are not read back.
+func (o *{{$alias.UpSingular}}) InsertMutation(columns boil.Columns) (*spanner.Mutation, error) {
+	if o == nil {
+		return nil, errors.New("{{.PkgName}}: no {{.Table.Name}} provided for insertion")
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet({{$alias.DownSingular}}ColumnsWithDefault, o)
+	wl, _ := columns.InsertColumnSet(
+		{{$alias.DownSingular}}AllColumns,
+		{{$alias.DownSingular}}ColumnsWithDefault,
+		{{$alias.DownSingular}}ColumnsWithoutDefault,
+		nzDefaults,
+	)
+
+	vals, err := o.mutationValues(wl, {{$alias.DownSingular}}CommitTimestampColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	return spanner.Insert("{{$tableName}}", wl, vals), nil
+}
+
+// InsertOrUpdateMutation returns a mutation inserting the {{$alias.DownSingular}}, or updating
+// the columns of the row with its primary key, like Upsert.
+func (o *{{$alias.UpSingular}}) InsertOrUpdateMutation(columns boil.Columns) (*spanner.Mutation, error) {
+	if o == nil {
+		return nil, errors.New("{{.PkgName}}: no {{.Table.Name}} provided for upsert")
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet({{$alias.DownSingular}}ColumnsWithDefault, o)
+	wl, _ := columns.InsertColumnSet(
+		{{$alias.DownSingular}}AllColumns,
+		{{$alias.DownSingular}}ColumnsWithDefault,
+		{{$alias.DownSingular}}ColumnsWithoutDefault,
+		nzDefaults,
+	)
+
+	vals, err := o.mutationValues(wl, {{$alias.DownSingular}}CommitTimestampColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	return spanner.InsertOrUpdate("{{$tableName}}", wl, vals), nil
+}
+
+// UpdateMutation returns a mutation updating the columns of the {{$alias.DownSingular}} found
+// by its primary key, chosen like the ones of Update.
+func (o *{{$alias.UpSingular}}) UpdateMutation(columns boil.Columns) (*spanner.Mutation, error) {
+	if o == nil {
+		return nil, errors.New("{{.PkgName}}: no {{.Table.Name}} provided for update")
+	}
+
+	wl := columns.UpdateColumnSet(
+		{{$alias.DownSingular}}AllColumns,
+		{{$alias.DownSingular}}PrimaryKeyColumns,
+	)
+	if len(wl) == 0 {
+		return nil, errors.New("{{.PkgName}}: unable to update {{.Table.Name}}, could not build whitelist")
+	}
+
+	cols := append(append([]string{}, {{$alias.DownSingular}}PrimaryKeyColumns...), wl...)
+	vals, err := o.mutationValues(cols, {{$alias.DownSingular}}CommitTimestampColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	return spanner.Update("{{$tableName}}", cols, vals), nil
+}
+
+// DeleteMutation returns a mutation deleting the {{$alias.DownSingular}} by its primary key,
+// and the rows interleaved in it with ON DELETE CASCADE.
+func (o *{{$alias.UpSingular}}) DeleteMutation() (*spanner.Mutation, error) {
+	if o == nil {
+		return nil, errors.New("{{.PkgName}}: no {{$alias.UpSingular}} provided for delete")
+	}
+
+	key, err := o.mutationValues({{$alias.DownSingular}}PrimaryKeyColumns, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return spanner.Delete("{{$tableName}}", spanner.Key(key)), nil
+}
+
+// mutationValues returns the values of the columns of the {{$alias.DownSingular}} in the
+// types the mutations take
+func (o *{{$alias.UpSingular}}) mutationValues(columns, commitTimestamps []string) ([]interface{}, error) {
+	mapping, err := queries.BindMapping({{$alias.DownSingular}}Type, {{$alias.DownSingular}}Mapping, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), mapping)
+	return spannerMutationValues(columns, values, commitTimestamps, {{$alias.DownSingular}}JSONColumns)
+}
+{{- end -}}
@@ -0,0 +1,30 @@
+// spannerMutationValues converts the values of the columns for the mutations
+// of the client library, which do not take a driver.Valuer: the null types
+// and the types package are replaced by their values, JSON by its text, and
+// the zero or NULL times of the commit timestamp columns by
+// spanner.CommitTimestamp.
+func spannerMutationValues(columns []string, values []interface{}, commitTimestamps, jsonColumns []string) ([]interface{}, error) {
+	for i, value := range values {
+		if valuer, ok := value.(driver.Valuer); ok {
+			v, err := valuer.Value()
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to convert the value of %s", columns[i])
+			}
+			value = v
+		}
+
+		if b, ok := value.([]byte); ok && strmangle.SetInclude(columns[i], jsonColumns) {
+			value = string(b)
+		}
+
+		if strmangle.SetInclude(columns[i], commitTimestamps) {
+			if t, ok := value.(time.Time); value == nil || ok && t.IsZero() {
+				value = spanner.CommitTimestamp
+			}
+		}
+
+		values[i] = value
+	}
+
+	return values, nil
+}
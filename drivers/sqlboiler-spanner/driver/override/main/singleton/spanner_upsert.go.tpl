@@ -0,0 +1,31 @@
+{{- if not .NoUpsert -}}
+// buildUpsertQuerySpanner builds a SQL statement string using the upsertData provided.
+func buildUpsertQuerySpanner(dia drivers.Dialect, tableName string, updateOnConflict bool, ret, whitelist []string) string {
+	whitelist = dia.QuoteSlice(whitelist)
+	ret = dia.QuoteSlice(ret)
+
+	buf := strmangle.GetBuffer()
+	defer strmangle.PutBuffer(buf)
+
+	action := "IGNORE"
+	if updateOnConflict {
+		action = "UPDATE"
+	}
+
+	fmt.Fprintf(
+		buf,
+		"INSERT OR %s INTO %s (%s) VALUES (%s)",
+		action,
+		tableName,
+		strings.Join(whitelist, ", "),
+		dia.Placeholders(len(whitelist), 1, 1),
+	)
+
+	if len(ret) != 0 {
+		buf.WriteString(" THEN RETURN ")
+		buf.WriteString(strings.Join(ret, ", "))
+	}
+
+	return buf.String()
+}
+{{- end -}}
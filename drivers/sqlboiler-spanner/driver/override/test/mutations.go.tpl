@@ -0,0 +1,28 @@
+{{- if not .Table.IsView -}}
+{{- $alias := .Aliases.Table .Table.Name}}
+func test{{$alias.UpPlural}}Mutations(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &{{$alias.UpSingular}}{}
+	if err = randomize.Struct(seed, o, {{$alias.DownSingular}}DBTypes, true); err != nil {
+		t.Errorf("Unable to randomize {{$alias.UpSingular}} struct: %s", err)
+	}
+
+	if _, err = o.InsertMutation(boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if _, err = o.InsertOrUpdateMutation(boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if len({{$alias.DownSingular}}AllColumns) != len({{$alias.DownSingular}}PrimaryKeyColumns) {
+		if _, err = o.UpdateMutation(boil.Infer()); err != nil {
+			t.Error(err)
+		}
+	}
+	if _, err = o.DeleteMutation(); err != nil {
+		t.Error(err)
+	}
+}
+{{- end -}}
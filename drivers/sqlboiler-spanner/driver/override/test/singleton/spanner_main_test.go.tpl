@@ -0,0 +1,108 @@
+var (
+	rgxSpannerDatabase = regexp.MustCompile(`^projects/([^/]+)/instances/([^/]+)/databases/([^/]+)$`)
+	rgxSpannerFKey     = regexp.MustCompile(`(?m)^\s*CONSTRAINT \S+ FOREIGN KEY.*\n`)
+	rgxSpannerParent   = regexp.MustCompile(`,\s*INTERLEAVE IN (?:PARENT )?\S+(?: ON DELETE (?:CASCADE|NO ACTION))?`)
+)
+
+// spannerTester copies the schema of the database into a test database of
+// the same instance with the gcloud cli. The emulator is used by gcloud when
+// CLOUDSDK_API_ENDPOINT_OVERRIDES_SPANNER points to it.
+type spannerTester struct{}
+
+func init() {
+	dbMain = &harnessTester{driver: &spannerTester{}}
+}
+
+// Validate the connection information used to create the test database
+func (s *spannerTester) Validate(cfg testharness.Config) error {
+	if !rgxSpannerDatabase.MatchString(cfg.DBName) {
+		return errors.Errorf("spanner.dbname %q is not a database like projects/PROJECT/instances/INSTANCE/databases/DATABASE", cfg.DBName)
+	}
+	return nil
+}
+
+// CreateTestDB reads the DDL of the database and creates the test database
+// with it, without the foreign keys and the interleaving in parents so the
+// tests can insert rows on their own.
+func (s *spannerTester) CreateTestDB(cfg testharness.Config) error {
+	m := rgxSpannerDatabase.FindStringSubmatch(cfg.DBName)
+
+	ddl, err := s.runCmd("gcloud", "spanner", "databases", "ddl", "describe", m[3], "--instance="+m[2], "--project="+m[1])
+	if err != nil {
+		return errors.Wrap(err, "failed to describe the ddl of the database")
+	}
+
+	var statements []string
+	for _, statement := range strings.Split(ddl, ";") {
+		statement = strings.TrimSpace(statement)
+		if len(statement) == 0 || strings.HasPrefix(statement, "ALTER TABLE") && strings.Contains(statement, "FOREIGN KEY") {
+			continue
+		}
+
+		statement = rgxSpannerFKey.ReplaceAllString(statement, "")
+		statement = rgxSpannerParent.ReplaceAllString(statement, "")
+		statements = append(statements, statement)
+	}
+
+	ddlFile, err := os.CreateTemp("", "boil-spanner-*.sql")
+	if err != nil {
+		return errors.Wrap(err, "failed to create the ddl file")
+	}
+	defer os.Remove(ddlFile.Name())
+
+	fmt.Fprint(ddlFile, strings.Join(statements, ";\n\n"))
+	if err = ddlFile.Close(); err != nil {
+		return errors.Wrap(err, "failed to write the ddl file")
+	}
+
+	project, instance, database := s.testDatabase(cfg)
+	_, err = s.runCmd("gcloud", "spanner", "databases", "create", database, "--instance="+instance, "--project="+project, "--ddl-file="+ddlFile.Name())
+	return errors.Wrap(err, "failed to create the test database")
+}
+
+// DropTestDB deletes the test database if it exists
+func (s *spannerTester) DropTestDB(cfg testharness.Config) error {
+	project, instance, database := s.testDatabase(cfg)
+	_, err := s.runCmd("gcloud", "spanner", "databases", "delete", database, "--instance="+instance, "--project="+project, "--quiet")
+	if err != nil && strings.Contains(err.Error(), "NOT_FOUND") {
+		return nil
+	}
+	return err
+}
+
+// Open a connection to the test database
+func (s *spannerTester) Open(cfg testharness.Config) (*sql.DB, error) {
+	project, instance, database := s.testDatabase(cfg)
+	name := fmt.Sprintf("projects/%s/instances/%s/databases/%s", project, instance, database)
+	return sql.Open("spanner", driver.SpannerBuildQueryString(cfg.Host, cfg.Port, name, cfg.SSLMode))
+}
+
+// testDatabase returns the project, instance and id of the test database, the
+// test database name is made a database id of at most 30 characters unless
+// it is a full database name
+func (s *spannerTester) testDatabase(cfg testharness.Config) (string, string, string) {
+	if m := rgxSpannerDatabase.FindStringSubmatch(cfg.TestDBName); m != nil {
+		return m[1], m[2], m[3]
+	}
+
+	m := rgxSpannerDatabase.FindStringSubmatch(cfg.DBName)
+	database := strings.ToLower(cfg.TestDBName)
+	if len(database) > 30 {
+		database = database[:30]
+	}
+	return m[1], m[2], database
+}
+
+func (s *spannerTester) runCmd(command string, args ...string) (string, error) {
+	cmd := exec.Command(command, args...)
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrapf(err, "%s failed: %s", command, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
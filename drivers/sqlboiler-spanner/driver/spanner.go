@@ -0,0 +1,682 @@
+// Package driver is a Google Cloud Spanner driver, it reads the schema from
+// the INFORMATION_SCHEMA of a GoogleSQL database through the database/sql
+// driver of go-sql-spanner, which has to be registered as spanner.
+package driver
+
+import (
+	"database/sql"
+	"embed"
+	"encoding/base64"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/importers"
+)
+
+//go:embed override
+var templates embed.FS
+
+func init() {
+	drivers.RegisterFromInit("spanner", &SpannerDriver{})
+}
+
+// Assemble is more useful for calling into the library so you don't
+// have to instantiate an empty type.
+func Assemble(config drivers.Config) (dbinfo *drivers.DBInfo, err error) {
+	driver := SpannerDriver{}
+	return driver.Assemble(config)
+}
+
+// SpannerDriver holds the database connection string and a handle
+// to the database connection.
+type SpannerDriver struct {
+	connStr string
+	conn    *sql.DB
+}
+
+// commitTimestampOption is added to the FullDBType of the columns allowing
+// the commit timestamp, the templates write spanner.CommitTimestamp to them
+const commitTimestampOption = "OPTIONS (allow_commit_timestamp=true)"
+
+var rgxDatabase = regexp.MustCompile(`^projects/[^/]+/instances/[^/]+/databases/[^/]+$`)
+
+// Templates that should be added/overridden
+func (SpannerDriver) Templates() (map[string]string, error) {
+	tpls := make(map[string]string)
+	fs.WalkDir(templates, "override", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		b, err := fs.ReadFile(templates, path)
+		if err != nil {
+			return err
+		}
+		tpls[strings.Replace(path, "override/", "", 1)] = base64.StdEncoding.EncodeToString(b)
+
+		return nil
+	})
+
+	return tpls, nil
+}
+
+// Assemble all the information we need to provide back to the driver
+func (s *SpannerDriver) Assemble(config drivers.Config) (dbinfo *drivers.DBInfo, err error) {
+	defer func() {
+		if r := recover(); r != nil && err == nil {
+			dbinfo = nil
+			err = r.(error)
+		}
+	}()
+
+	if err := validateDriverConfig(config); err != nil {
+		panic(errors.Wrap(err, "validate driver config"))
+	}
+	fillDefaultDriverConfig(&config)
+
+	s.connStr = SpannerBuildQueryString(config.Host, config.Port, config.DBName, config.SSLMode)
+	s.conn, err = sql.Open("spanner", s.connStr)
+	if err != nil {
+		return nil, errors.Wrap(err, "sqlboiler-spanner failed to connect to database")
+	}
+
+	defer func() {
+		if e := s.conn.Close(); e != nil {
+			dbinfo = nil
+			err = e
+		}
+	}()
+
+	dbinfo = &drivers.DBInfo{
+		Schema: config.Schema,
+		Dialect: drivers.Dialect{
+			LQ: '`',
+			RQ: '`',
+
+			UseSchema: len(config.Schema) != 0,
+			// Spanner has no RETURNING, the columns with defaults are
+			// selected again by the primary key after an insert
+			UseLastInsertID: true,
+		},
+	}
+
+	dbinfo.Tables, err = drivers.TablesConcurrently(s, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbinfo, err
+}
+
+func validateDriverConfig(config drivers.Config) error {
+	if config.DBName == "" {
+		return errors.New("missing dbname")
+	}
+	if !rgxDatabase.MatchString(config.DBName) {
+		return errors.Errorf("dbname %q is not a database like projects/PROJECT/instances/INSTANCE/databases/DATABASE", config.DBName)
+	}
+	return nil
+}
+
+func fillDefaultDriverConfig(config *drivers.Config) {
+	if config.Concurrency == 0 {
+		config.Concurrency = drivers.DefaultConcurrency
+	}
+}
+
+// SpannerBuildQueryString builds the connection string of go-sql-spanner for
+// the database, which is projects/PROJECT/instances/INSTANCE/databases/NAME.
+// The host and port are only needed for an emulator or a private endpoint,
+// the connection is made without TLS when the sslmode is disable.
+func SpannerBuildQueryString(host string, port int, database, sslmode string) string {
+	if len(host) == 0 {
+		return database
+	}
+
+	endpoint := host
+	if port != 0 {
+		endpoint = fmt.Sprintf("%s:%d", host, port)
+	}
+
+	connStr := endpoint + "/" + database
+	if sslmode == "disable" {
+		connStr += ";usePlainText=true"
+	}
+	return connStr
+}
+
+// TableNames connects to the spanner database and
+// retrieves all table names from the information_schema.
+func (s *SpannerDriver) TableNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	return s.tableNames("BASE TABLE", schema, whitelist, blacklist)
+}
+
+// ViewNames connects to the spanner database and
+// retrieves all view names from the information_schema.
+func (s *SpannerDriver) ViewNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	return s.tableNames("VIEW", schema, whitelist, blacklist)
+}
+
+func (s *SpannerDriver) tableNames(tableType, schema string, whitelist, blacklist []string) ([]string, error) {
+	var names []string
+
+	query := `SELECT TABLE_NAME FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = ? AND TABLE_TYPE = ?`
+	args := []interface{}{schema, tableType}
+	if len(whitelist) > 0 {
+		tables := drivers.TablesFromList(whitelist)
+		if len(tables) > 0 {
+			query += fmt.Sprintf(" AND TABLE_NAME IN (%s)", strings.Repeat(",?", len(tables))[1:])
+			for _, w := range tables {
+				args = append(args, w)
+			}
+		}
+	} else if len(blacklist) > 0 {
+		tables := drivers.TablesFromList(blacklist)
+		if len(tables) > 0 {
+			query += fmt.Sprintf(" AND TABLE_NAME NOT IN (%s)", strings.Repeat(",?", len(tables))[1:])
+			for _, b := range tables {
+				args = append(args, b)
+			}
+		}
+	}
+
+	query += ` ORDER BY TABLE_NAME`
+
+	rows, err := s.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
+// ViewCapabilities return what actions are allowed for a view.
+func (s *SpannerDriver) ViewCapabilities(schema, name string) (drivers.ViewCapabilities, error) {
+	// Spanner views are read-only
+	capabilities := drivers.ViewCapabilities{
+		CanInsert: false,
+		CanUpsert: false,
+	}
+
+	return capabilities, nil
+}
+
+func (s *SpannerDriver) ViewColumns(schema, tableName string, whitelist, blacklist []string) ([]drivers.Column, error) {
+	return s.Columns(schema, tableName, whitelist, blacklist)
+}
+
+// Columns takes a table name and attempts to retrieve the table information
+// from the database information_schema.columns. It retrieves the column names
+// and column types and returns those as a []Column after TranslateColumnType()
+// converts the SQL types to Go types, for example: "STRING(MAX)" to "string"
+func (s *SpannerDriver) Columns(schema, tableName string, whitelist, blacklist []string) ([]drivers.Column, error) {
+	var columns []drivers.Column
+
+	unique, err := s.uniqueColumns(schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []interface{}{schema, tableName}
+	query := `
+	SELECT c.COLUMN_NAME, c.SPANNER_TYPE, c.IS_NULLABLE = 'YES', c.COLUMN_DEFAULT, c.IS_GENERATED = 'ALWAYS',
+		EXISTS (
+			SELECT 1 FROM INFORMATION_SCHEMA.COLUMN_OPTIONS o
+			WHERE o.TABLE_SCHEMA = c.TABLE_SCHEMA AND o.TABLE_NAME = c.TABLE_NAME AND o.COLUMN_NAME = c.COLUMN_NAME
+				AND o.OPTION_NAME = 'allow_commit_timestamp' AND o.OPTION_VALUE = 'TRUE'
+		)
+	FROM INFORMATION_SCHEMA.COLUMNS c
+	WHERE c.TABLE_SCHEMA = ? AND c.TABLE_NAME = ?`
+
+	if len(whitelist) > 0 {
+		cols := drivers.ColumnsFromList(whitelist, tableName)
+		if len(cols) > 0 {
+			query += fmt.Sprintf(" AND c.COLUMN_NAME IN (%s)", strings.Repeat(",?", len(cols))[1:])
+			for _, w := range cols {
+				args = append(args, w)
+			}
+		}
+	} else if len(blacklist) > 0 {
+		cols := drivers.ColumnsFromList(blacklist, tableName)
+		if len(cols) > 0 {
+			query += fmt.Sprintf(" AND c.COLUMN_NAME NOT IN (%s)", strings.Repeat(",?", len(cols))[1:])
+			for _, w := range cols {
+				args = append(args, w)
+			}
+		}
+	}
+
+	query += ` ORDER BY c.ORDINAL_POSITION`
+
+	rows, err := s.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var colName, colType string
+		var nullable, generated, commitTimestamp bool
+		var defaultValue *string
+		if err := rows.Scan(&colName, &colType, &nullable, &defaultValue, &generated, &commitTimestamp); err != nil {
+			return nil, errors.Wrapf(err, "unable to scan for table %s", tableName)
+		}
+
+		column := drivers.Column{
+			Name:          colName,
+			FullDBType:    fullDBType(colType, commitTimestamp),
+			DBType:        colType,
+			Nullable:      nullable,
+			Unique:        unique[colName],
+			AutoGenerated: generated,
+		}
+
+		if defaultValue != nil {
+			column.Default = *defaultValue
+		} else if generated {
+			column.Default = "auto_generated"
+		} else if nullable {
+			column.Default = "NULL"
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, rows.Err()
+}
+
+// uniqueColumns returns the columns that are the only key column of a unique
+// index, the stored columns of the indexes are not part of the key
+func (s *SpannerDriver) uniqueColumns(schema, tableName string) (map[string]bool, error) {
+	query := `
+	SELECT ic.COLUMN_NAME
+	FROM INFORMATION_SCHEMA.INDEXES i
+	JOIN INFORMATION_SCHEMA.INDEX_COLUMNS ic
+		ON ic.TABLE_SCHEMA = i.TABLE_SCHEMA AND ic.TABLE_NAME = i.TABLE_NAME AND ic.INDEX_NAME = i.INDEX_NAME
+	WHERE i.TABLE_SCHEMA = ? AND i.TABLE_NAME = ? AND i.IS_UNIQUE AND ic.ORDINAL_POSITION IS NOT NULL
+		AND (
+			SELECT COUNT(*) FROM INFORMATION_SCHEMA.INDEX_COLUMNS k
+			WHERE k.TABLE_SCHEMA = i.TABLE_SCHEMA AND k.TABLE_NAME = i.TABLE_NAME AND k.INDEX_NAME = i.INDEX_NAME
+				AND k.ORDINAL_POSITION IS NOT NULL
+		) = 1`
+
+	rows, err := s.conn.Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	unique := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		unique[name] = true
+	}
+
+	return unique, rows.Err()
+}
+
+// fullDBType is the type of the column with the option allowing the commit
+// timestamp, like it is declared
+func fullDBType(spannerType string, commitTimestamp bool) string {
+	if commitTimestamp {
+		return spannerType + " " + commitTimestampOption
+	}
+	return spannerType
+}
+
+// PrimaryKeyInfo looks up the primary key for a table.
+func (s *SpannerDriver) PrimaryKeyInfo(schema, tableName string) (*drivers.PrimaryKey, error) {
+	query := `
+	SELECT COLUMN_NAME FROM INFORMATION_SCHEMA.INDEX_COLUMNS
+	WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND INDEX_NAME = 'PRIMARY_KEY'
+	ORDER BY ORDINAL_POSITION`
+
+	rows, err := s.conn.Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var pk *drivers.PrimaryKey
+	if len(columns) > 0 {
+		pk = &drivers.PrimaryKey{Name: "PRIMARY_KEY", Columns: columns}
+	}
+	return pk, nil
+}
+
+// ForeignKeyInfo retrieves the foreign keys for a given table name, and the
+// ones of the tables it is interleaved in.
+func (s *SpannerDriver) ForeignKeyInfo(schema, tableName string) ([]drivers.ForeignKey, error) {
+	var fkeys []drivers.ForeignKey
+
+	query := `
+	SELECT rc.CONSTRAINT_NAME, kcu.COLUMN_NAME, ref.TABLE_NAME, ref.COLUMN_NAME
+	FROM INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS rc
+	JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu
+		ON kcu.CONSTRAINT_SCHEMA = rc.CONSTRAINT_SCHEMA AND kcu.CONSTRAINT_NAME = rc.CONSTRAINT_NAME
+	JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE ref
+		ON ref.CONSTRAINT_SCHEMA = rc.UNIQUE_CONSTRAINT_SCHEMA AND ref.CONSTRAINT_NAME = rc.UNIQUE_CONSTRAINT_NAME
+			AND ref.ORDINAL_POSITION = kcu.POSITION_IN_UNIQUE_CONSTRAINT
+	WHERE kcu.TABLE_SCHEMA = ? AND kcu.TABLE_NAME = ?
+	ORDER BY rc.CONSTRAINT_NAME, kcu.ORDINAL_POSITION`
+
+	rows, err := s.conn.Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var fkey drivers.ForeignKey
+
+		fkey.Table = tableName
+		if err = rows.Scan(&fkey.Name, &fkey.Column, &fkey.ForeignTable, &fkey.ForeignColumn); err != nil {
+			return nil, err
+		}
+
+		fkeys = append(fkeys, fkey)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ancestors, err := s.ancestors(schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(fkeys, interleaveForeignKeys(tableName, ancestors, fkeys)...), nil
+}
+
+// interleave is a table a table is interleaved in, directly or through
+// its parent
+type interleave struct {
+	Table string
+	PKey  []string
+}
+
+// ancestors returns the parent of an interleaved table, the parent of the
+// parent and so on
+func (s *SpannerDriver) ancestors(schema, tableName string) ([]interleave, error) {
+	var ancestors []interleave
+
+	for name := tableName; ; {
+		var parent sql.NullString
+		query := `SELECT PARENT_TABLE_NAME FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?`
+		if err := s.conn.QueryRow(query, schema, name).Scan(&parent); err != nil {
+			return nil, errors.Wrapf(err, "unable to find the parent of %s", name)
+		}
+		if !parent.Valid || len(parent.String) == 0 {
+			return ancestors, nil
+		}
+
+		pkey, err := s.PrimaryKeyInfo(schema, parent.String)
+		if err != nil {
+			return nil, err
+		}
+
+		ancestor := interleave{Table: parent.String}
+		if pkey != nil {
+			ancestor.PKey = pkey.Columns
+		}
+		ancestors = append(ancestors, ancestor)
+		name = parent.String
+	}
+}
+
+// interleaveForeignKeys makes the interleaving of a table in its ancestors
+// parent/child relationships. The primary key of an interleaved table starts
+// with the primary key of its parent, so the table references each ancestor
+// with a primary key of a single column by that column. Like composite
+// foreign keys, the ancestors with a composite primary key are skipped, and
+// the references already declared as a foreign key are not repeated.
+func interleaveForeignKeys(tableName string, ancestors []interleave, declared []drivers.ForeignKey) []drivers.ForeignKey {
+	var fkeys []drivers.ForeignKey
+
+Ancestors:
+	for _, ancestor := range ancestors {
+		if len(ancestor.PKey) != 1 {
+			continue
+		}
+
+		column := ancestor.PKey[0]
+		for _, fkey := range declared {
+			if fkey.Column == column && fkey.ForeignTable == ancestor.Table && fkey.ForeignColumn == column {
+				continue Ancestors
+			}
+		}
+
+		fkeys = append(fkeys, drivers.ForeignKey{
+			Table:         tableName,
+			Name:          fmt.Sprintf("%s_interleave_%s", tableName, ancestor.Table),
+			Column:        column,
+			ForeignTable:  ancestor.Table,
+			ForeignColumn: column,
+		})
+	}
+
+	return fkeys
+}
+
+// TranslateColumnType converts spanner database types to Go types, for example
+// "STRING(MAX)" to "string" and "INT64" to "int64". It returns this parsed data
+// as a Column object.
+// https://cloud.google.com/spanner/docs/reference/standard-sql/data-types
+func (SpannerDriver) TranslateColumnType(c drivers.Column) drivers.Column {
+	if strings.HasPrefix(c.DBType, "ARRAY<") {
+		c.Type = arrayType(c.DBType)
+		return c
+	}
+
+	if c.Nullable {
+		switch strings.Split(c.DBType, "(")[0] {
+		case "INT64":
+			c.Type = "null.Int64"
+		case "FLOAT32":
+			c.Type = "null.Float32"
+		case "FLOAT64":
+			c.Type = "null.Float64"
+		case "NUMERIC":
+			c.Type = "types.NullDecimal"
+		case "BOOL":
+			c.Type = "null.Bool"
+		case "STRING":
+			c.Type = "null.String"
+		case "BYTES":
+			c.Type = "null.Bytes"
+		case "DATE", "TIMESTAMP":
+			c.Type = "null.Time"
+		case "JSON":
+			c.Type = "null.JSON"
+		default:
+			c.Type = "null.String"
+		}
+	} else {
+		switch strings.Split(c.DBType, "(")[0] {
+		case "INT64":
+			c.Type = "int64"
+		case "FLOAT32":
+			c.Type = "float32"
+		case "FLOAT64":
+			c.Type = "float64"
+		case "NUMERIC":
+			c.Type = "types.Decimal"
+		case "BOOL":
+			c.Type = "bool"
+		case "STRING":
+			c.Type = "string"
+		case "BYTES":
+			c.Type = "[]byte"
+		case "DATE", "TIMESTAMP":
+			c.Type = "time.Time"
+		case "JSON":
+			c.Type = "types.JSON"
+		default:
+			c.Type = "string"
+		}
+	}
+
+	return c
+}
+
+// arrayType is the slice of the Go type of the elements of an array,
+// go-sql-spanner scans the arrays without NULL elements into them
+func arrayType(dbType string) string {
+	element := strings.TrimSuffix(strings.TrimPrefix(dbType, "ARRAY<"), ">")
+	switch strings.Split(element, "(")[0] {
+	case "INT64":
+		return "[]int64"
+	case "FLOAT32":
+		return "[]float32"
+	case "FLOAT64":
+		return "[]float64"
+	case "BOOL":
+		return "[]bool"
+	case "BYTES":
+		return "[][]byte"
+	case "DATE", "TIMESTAMP":
+		return "[]time.Time"
+	default:
+		return "[]string"
+	}
+}
+
+// Imports returns important imports for the driver
+func (SpannerDriver) Imports() (col importers.Collection, err error) {
+	col.All = importers.Set{
+		Standard: importers.List{
+			`"strconv"`,
+		},
+		ThirdParty: importers.List{
+			`"cloud.google.com/go/spanner"`,
+		},
+	}
+
+	col.Singleton = importers.Map{
+		"spanner_upsert": {
+			Standard: importers.List{
+				`"fmt"`,
+				`"strings"`,
+			},
+			ThirdParty: importers.List{
+				`"github.com/volatiletech/strmangle"`,
+				`"github.com/volatiletech/sqlboiler/v4/drivers"`,
+			},
+		},
+		"spanner_mutations": {
+			Standard: importers.List{
+				`"database/sql/driver"`,
+				`"time"`,
+			},
+			ThirdParty: importers.List{
+				`"cloud.google.com/go/spanner"`,
+				`"github.com/friendsofgo/errors"`,
+				`"github.com/volatiletech/strmangle"`,
+			},
+		},
+	}
+
+	col.TestSingleton = importers.Map{
+		"spanner_suites_test": {
+			Standard: importers.List{
+				`"testing"`,
+			},
+		},
+		"spanner_main_test": {
+			Standard: importers.List{
+				`"bytes"`,
+				`"database/sql"`,
+				`"fmt"`,
+				`"os"`,
+				`"os/exec"`,
+				`"regexp"`,
+				`"strings"`,
+			},
+			ThirdParty: importers.List{
+				`"github.com/pkg/errors"`,
+				`"github.com/volatiletech/sqlboiler/v4/drivers/sqlboiler-spanner/driver"`,
+				`"github.com/volatiletech/sqlboiler/v4/testharness"`,
+				`_ "github.com/googleapis/go-sql-spanner"`,
+			},
+		},
+	}
+
+	col.BasedOnType = importers.Map{
+		"null.Float32": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.Float64": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.Int64": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.String": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.Bool": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.Time": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.Bytes": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+		"null.JSON": {
+			ThirdParty: importers.List{`"github.com/volatiletech/null/v8"`},
+		},
+
+		"time.Time": {
+			Standard: importers.List{`"time"`},
+		},
+		"[]time.Time": {
+			Standard: importers.List{`"time"`},
+		},
+		"types.Decimal": {
+			ThirdParty: importers.List{`"github.com/volatiletech/sqlboiler/v4/types"`},
+		},
+		"types.NullDecimal": {
+			ThirdParty: importers.List{`"github.com/volatiletech/sqlboiler/v4/types"`},
+		},
+		"types.JSON": {
+			ThirdParty: importers.List{`"github.com/volatiletech/sqlboiler/v4/types"`},
+		},
+	}
+	return col, err
+}
@@ -0,0 +1,105 @@
+package driver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestInterleaveForeignKeys(t *testing.T) {
+	t.Parallel()
+
+	ancestors := []interleave{
+		{Table: "Albums", PKey: []string{"SingerId", "AlbumId"}},
+		{Table: "Singers", PKey: []string{"SingerId"}},
+	}
+
+	got := interleaveForeignKeys("Songs", ancestors, nil)
+	want := []drivers.ForeignKey{
+		{
+			Table:         "Songs",
+			Name:          "Songs_interleave_Singers",
+			Column:        "SingerId",
+			ForeignTable:  "Singers",
+			ForeignColumn: "SingerId",
+		},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want the reference of the ancestor with a single key column, got: %#v", got)
+	}
+
+	declared := []drivers.ForeignKey{
+		{Table: "Songs", Name: "FK_SongSinger", Column: "SingerId", ForeignTable: "Singers", ForeignColumn: "SingerId"},
+	}
+	if got := interleaveForeignKeys("Songs", ancestors, declared); len(got) != 0 {
+		t.Errorf("want no reference repeating a foreign key, got: %#v", got)
+	}
+
+	if got := interleaveForeignKeys("Singers", nil, nil); len(got) != 0 {
+		t.Errorf("want no reference for a table that is not interleaved, got: %#v", got)
+	}
+}
+
+func TestTranslateColumnType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dbType   string
+		nullable bool
+		want     string
+	}{
+		{"INT64", false, "int64"},
+		{"INT64", true, "null.Int64"},
+		{"STRING(MAX)", false, "string"},
+		{"STRING(36)", true, "null.String"},
+		{"BYTES(1024)", false, "[]byte"},
+		{"NUMERIC", true, "types.NullDecimal"},
+		{"TIMESTAMP", false, "time.Time"},
+		{"DATE", true, "null.Time"},
+		{"JSON", false, "types.JSON"},
+		{"ARRAY<STRING(MAX)>", true, "[]string"},
+		{"ARRAY<INT64>", false, "[]int64"},
+	}
+
+	for _, test := range tests {
+		c := SpannerDriver{}.TranslateColumnType(drivers.Column{DBType: test.dbType, Nullable: test.nullable})
+		if c.Type != test.want {
+			t.Errorf("%s (nullable %t): want %s, got %s", test.dbType, test.nullable, test.want, c.Type)
+		}
+	}
+}
+
+func TestFullDBType(t *testing.T) {
+	t.Parallel()
+
+	if got := fullDBType("TIMESTAMP", true); got != "TIMESTAMP OPTIONS (allow_commit_timestamp=true)" {
+		t.Errorf("want the commit timestamp option, got: %s", got)
+	}
+	if got := fullDBType("TIMESTAMP", false); got != "TIMESTAMP" {
+		t.Errorf("want the type alone, got: %s", got)
+	}
+}
+
+func TestSpannerBuildQueryString(t *testing.T) {
+	t.Parallel()
+
+	database := "projects/p/instances/i/databases/d"
+	if got := SpannerBuildQueryString("", 0, database, ""); got != database {
+		t.Errorf("want the database, got: %s", got)
+	}
+	if got := SpannerBuildQueryString("localhost", 9010, database, "disable"); got != "localhost:9010/"+database+";usePlainText=true" {
+		t.Errorf("want the emulator without tls, got: %s", got)
+	}
+}
+
+func TestValidateDriverConfig(t *testing.T) {
+	t.Parallel()
+
+	if err := validateDriverConfig(drivers.Config{DBName: "projects/p/instances/i/databases/d"}); err != nil {
+		t.Error(err)
+	}
+	if err := validateDriverConfig(drivers.Config{DBName: "d"}); err == nil {
+		t.Error("want an error for a database id without its instance")
+	}
+}
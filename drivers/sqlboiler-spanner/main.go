@@ -0,0 +1,17 @@
+// The spanner driver is a module of its own requiring go-sql-spanner, so the
+// client libraries of Cloud Spanner are not dependencies of sqlboiler. It is
+// installed from its directory:
+//
+//	go install .
+package main
+
+import (
+	_ "github.com/googleapis/go-sql-spanner"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/drivers/sqlboiler-spanner/driver"
+)
+
+func main() {
+	drivers.DriverMain(&driver.SpannerDriver{})
+}
@@ -1,8 +1,9 @@
+{{- if not .NoUpsert -}}
 // buildUpsertQuerySQLite builds a SQL statement string using the upsertData provided.
 func buildUpsertQuerySQLite(dia drivers.Dialect, tableName string, updateOnConflict bool, ret, update, conflict, whitelist []string) string {
-	conflict = strmangle.IdentQuoteSlice(dia.LQ, dia.RQ, conflict)
-	whitelist = strmangle.IdentQuoteSlice(dia.LQ, dia.RQ, whitelist)
-	ret = strmangle.IdentQuoteSlice(dia.LQ, dia.RQ, ret)
+	conflict = dia.QuoteSlice(conflict)
+	whitelist = dia.QuoteSlice(whitelist)
+	ret = dia.QuoteSlice(ret)
 
 	buf := strmangle.GetBuffer()
 	defer strmangle.PutBuffer(buf)
@@ -11,7 +12,7 @@ func buildUpsertQuerySQLite(dia drivers.Dialect, tableName string, updateOnConfl
 	if len(whitelist) != 0 {
 		columns = fmt.Sprintf("(%s) VALUES (%s)",
 			strings.Join(whitelist, ", "),
-			strmangle.Placeholders(dia.UseIndexPlaceholders, len(whitelist), 1, 1))
+			dia.Placeholders(len(whitelist), 1, 1))
 	}
 
 	fmt.Fprintf(
@@ -32,7 +33,7 @@ func buildUpsertQuerySQLite(dia drivers.Dialect, tableName string, updateOnConfl
 			if i != 0 {
 				buf.WriteByte(',')
 			}
-			quoted := strmangle.IdentQuote(dia.LQ, dia.RQ, v)
+			quoted := dia.Quote(v)
 			buf.WriteString(quoted)
 			buf.WriteString(" = EXCLUDED.")
 			buf.WriteString(quoted)
@@ -45,4 +46,5 @@ func buildUpsertQuerySQLite(dia drivers.Dialect, tableName string, updateOnConfl
 	}
 
 	return buf.String()
-}
\ No newline at end of file
+}
+{{- end -}}
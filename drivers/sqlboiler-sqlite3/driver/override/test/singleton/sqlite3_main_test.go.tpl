@@ -1,27 +1,23 @@
 var rgxSQLitekey = regexp.MustCompile(`(?mi)((,\n)?\s+foreign key.*?\n)+`)
 
+// sqliteTester keeps the test database in a temporary file rather than
+// using the configured test database name
 type sqliteTester struct {
-	dbConn *sql.DB
-
-	dbName	string
 	testDBName string
 }
 
 func init() {
-	dbMain = &sqliteTester{}
+	dbMain = &harnessTester{driver: &sqliteTester{
+		testDBName: filepath.Join(os.TempDir(), fmt.Sprintf("boil-sqlite3-%d.sql", rand.Int())),
+	}}
 }
 
-func (s *sqliteTester) setup() error {
+// CreateTestDB dumps the database and loads it into the test database so
+// that tests can be run against it using the generated sqlboiler ORM package.
+func (s *sqliteTester) CreateTestDB(cfg testharness.Config) error {
 	var err error
 
-    s.dbName = viper.GetString("sqlite3.dbname")
-    if len(s.dbName) == 0 {
-        return errors.New("no dbname specified")
-    }
-
-	s.testDBName = filepath.Join(os.TempDir(), fmt.Sprintf("boil-sqlite3-%d.sql", rand.Int()))
-
-	dumpCmd := exec.Command("sqlite3", "-cmd", ".dump", s.dbName)
+	dumpCmd := exec.Command("sqlite3", "-cmd", ".dump", cfg.DBName)
 	createCmd := exec.Command("sqlite3", s.testDBName)
 
 	r, w := io.Pipe()
@@ -50,24 +46,16 @@ func (s *sqliteTester) setup() error {
 	return nil
 }
 
-func (s *sqliteTester) teardown() error {
-	if s.dbConn != nil {
-		s.dbConn.Close()
+// DropTestDB removes the test database file
+func (s *sqliteTester) DropTestDB(cfg testharness.Config) error {
+	if err := os.Remove(s.testDBName); err != nil && !os.IsNotExist(err) {
+		return err
 	}
 
-	return os.Remove(s.testDBName)
+	return nil
 }
 
-func (s *sqliteTester) conn() (*sql.DB, error) {
-	if s.dbConn != nil {
-        return s.dbConn, nil
-	}
-
-	var err error
-	s.dbConn, err = sql.Open("sqlite", fmt.Sprintf("file:%s?cache=shared&_loc=UTC", s.testDBName))
-        if err != nil {
-        return nil, err
-	}
-
-	return s.dbConn, nil
+// Open a connection to the test database
+func (s *sqliteTester) Open(cfg testharness.Config) (*sql.DB, error) {
+	return sql.Open("sqlite", fmt.Sprintf("file:%s?cache=shared&_loc=UTC", s.testDBName))
 }
@@ -1,3 +1,4 @@
+{{- if not .NoUpsert -}}
 func TestUpsert(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if or $table.IsJoinTable $table.IsView -}}
@@ -7,3 +8,4 @@ func TestUpsert(t *testing.T) {
   {{end -}}
   {{- end -}}
 }
+{{- end -}}
@@ -7,6 +7,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io/fs"
+	"regexp"
 	"strings"
 
 	"github.com/friendsofgo/errors"
@@ -92,9 +93,10 @@ func (s SQLiteDriver) Assemble(config drivers.Config) (dbinfo *drivers.DBInfo, e
 			LQ: '"',
 			RQ: '"',
 
-			UseSchema:         false,
-			UseDefaultKeyword: true,
-			UseLastInsertID:   false,
+			UseSchema:           false,
+			UseDefaultKeyword:   true,
+			UseLastInsertID:     false,
+			UseNullsOrderClause: true,
 		},
 	}
 
@@ -499,6 +501,60 @@ func (s SQLiteDriver) ForeignKeyInfo(schema, tableName string) ([]drivers.Foreig
 	return fkeys, nil
 }
 
+var (
+	rgxTriggerHeader = regexp.MustCompile(`(?is)^\s*create\s+(?:temp(?:orary)?\s+)?trigger\s+(?:if\s+not\s+exists\s+)?\S+\s+(before\s+|after\s+|instead\s+of\s+)?(delete|insert|update)(?:\s+of\s+(.+?))?\s+on\s`)
+	rgxTriggerBody   = regexp.MustCompile(`(?is)\bbegin\b(.*)\bend\s*;?\s*$`)
+)
+
+// Triggers retrieves the triggers of a table, sqlite only has row level
+// triggers firing on a single event.
+func (s SQLiteDriver) Triggers(schema, tableName string) ([]drivers.Trigger, error) {
+	query := "SELECT name, sql FROM sqlite_master WHERE type = 'trigger' AND tbl_name = ? ORDER BY name"
+
+	rows, err := s.dbConn.Query(query, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var triggers []drivers.Trigger
+	for rows.Next() {
+		var trigger drivers.Trigger
+		if err := rows.Scan(&trigger.Name, &trigger.Definition); err != nil {
+			return nil, err
+		}
+
+		m := rgxTriggerHeader.FindStringSubmatch(trigger.Definition)
+		if m == nil {
+			return nil, errors.Errorf("unable to parse trigger %s", trigger.Name)
+		}
+
+		trigger.Timing = "BEFORE"
+		if len(m[1]) != 0 {
+			trigger.Timing = strings.ToUpper(strings.Join(strings.Fields(m[1]), " "))
+		}
+		trigger.Events = []string{strings.ToUpper(m[2])}
+		if len(m[3]) != 0 {
+			for _, col := range strings.Split(m[3], ",") {
+				trigger.Columns = append(trigger.Columns, strings.Trim(strings.TrimSpace(col), "\"`[]"))
+			}
+		}
+		trigger.ForEachRow = true
+
+		if m := rgxTriggerBody.FindStringSubmatch(trigger.Definition); m != nil {
+			trigger.Body = strings.TrimSpace(m[1])
+		}
+
+		triggers = append(triggers, trigger)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return triggers, nil
+}
+
 // TranslateColumnType converts sqlite database types to Go types, for example
 // "varchar" to "string" and "bigint" to "int64". It returns this parsed data
 // as a Column object.
@@ -615,7 +671,7 @@ func (SQLiteDriver) Imports() (col importers.Collection, err error) {
 			},
 			ThirdParty: importers.List{
 				`"github.com/pkg/errors"`,
-				`"github.com/spf13/viper"`,
+				`"github.com/volatiletech/sqlboiler/v4/testharness"`,
 				`_ "modernc.org/sqlite"`,
 			},
 		},
@@ -192,3 +192,15 @@ CREATE TABLE has_generated_columns (
    d INT GENERATED ALWAYS AS (a*abs(b)) VIRTUAL,
    e TEXT GENERATED ALWAYS AS (substr(c,b,b+1)) STORED
 );
+
+-- a trigger keeps updated_at current, generated updates should leave it alone
+create table trigger_timestamps (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	updated_at DATETIME
+);
+
+create trigger trigger_timestamps_updated_at after update of name on trigger_timestamps
+begin
+	update trigger_timestamps set updated_at = CURRENT_TIMESTAMP where id = NEW.id;
+end;
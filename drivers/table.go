@@ -17,6 +17,13 @@ type Table struct {
 
 	IsJoinTable bool `json:"is_join_table"`
 
+	Triggers []Trigger `json:"triggers,omitempty"`
+	// RowSecurity is nil unless row level security is enabled on the table
+	RowSecurity *RowSecurity `json:"row_security,omitempty"`
+	// Hypertable is nil unless the table is a TimescaleDB hypertable or the
+	// view a continuous aggregate
+	Hypertable *Hypertable `json:"hypertable,omitempty"`
+
 	ToOneRelationships  []ToOneRelationship  `json:"to_one_relationships"`
 	ToManyRelationships []ToManyRelationship `json:"to_many_relationships"`
 
@@ -0,0 +1,68 @@
+package drivers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Trigger is a trigger defined on a table
+type Trigger struct {
+	Name string `json:"name"`
+	// Timing is BEFORE, AFTER or INSTEAD OF
+	Timing string `json:"timing"`
+	// Events are the statements that fire the trigger: INSERT, UPDATE,
+	// DELETE or TRUNCATE
+	Events []string `json:"events"`
+	// Columns limit an UPDATE trigger to updates of these columns
+	Columns []string `json:"columns"`
+	// ForEachRow is false for triggers that fire once per statement
+	ForEachRow bool `json:"for_each_row"`
+
+	// Definition is the SQL creating the trigger
+	Definition string `json:"definition"`
+	// Body is the code the trigger runs, in postgres it is the source of the
+	// trigger function
+	Body string `json:"body"`
+}
+
+// TriggerConstructor is implemented by drivers that can load the triggers of
+// a table. The triggers are then set on the tables returned by
+// TablesConcurrently.
+type TriggerConstructor interface {
+	Triggers(schema, tableName string) ([]Trigger, error)
+}
+
+// FiresOn checks if the trigger fires on the event, INSERT, UPDATE, DELETE
+// or TRUNCATE
+func (t Trigger) FiresOn(event string) bool {
+	for _, e := range t.Events {
+		if strings.EqualFold(e, event) {
+			return true
+		}
+	}
+	return false
+}
+
+// Assigns checks if the body of the trigger assigns a value to the column,
+// either to NEW.column or in the SET clause of an UPDATE. It only looks at
+// the text of the body, so the assignment may be conditional.
+func (t Trigger) Assigns(column string) bool {
+	ident := fmt.Sprintf("[\"`\\[]?%s[\"`\\]]?", regexp.QuoteMeta(column))
+	// NEW.column = is a comparison unless it starts a statement
+	newAssign := regexp.MustCompile(`(?i)(?:^|[;\n]|\b(?:begin|then|else|loop|set))\s*new\s*\.\s*` + ident + `\s*:?=[^=]`)
+	setAssign := regexp.MustCompile(`(?is)\bset\b[^;]*?[\s,]` + ident + `\s*=[^=]`)
+
+	return newAssign.MatchString(t.Body) || setAssign.MatchString(t.Body)
+}
+
+// TriggerSets checks if a trigger firing on the event assigns the column, for
+// example a trigger keeping updated_at current on UPDATE.
+func (t Table) TriggerSets(event, column string) bool {
+	for _, trigger := range t.Triggers {
+		if trigger.FiresOn(event) && trigger.Assigns(column) {
+			return true
+		}
+	}
+	return false
+}
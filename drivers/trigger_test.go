@@ -0,0 +1,53 @@
+package drivers
+
+import "testing"
+
+func TestTriggerAssigns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		body   string
+		column string
+		want   bool
+	}{
+		{"BEGIN NEW.updated_at := now(); RETURN NEW; END;", "updated_at", true},
+		{"begin new.\"updated_at\" = now(); return new; end;", "updated_at", true},
+		{"SET NEW.updated_at = NOW()", "updated_at", true},
+		{"update videos set name = upper(NEW.name), updated_at = CURRENT_TIMESTAMP where id = NEW.id;", "updated_at", true},
+		{"UPDATE videos SET [updated_at] = GETDATE() FROM inserted", "updated_at", true},
+		{"IF NEW.updated_at = OLD.updated_at THEN RAISE EXCEPTION 'stale'; END IF;", "updated_at", false},
+		{"BEGIN NEW.last_updated_at := now(); RETURN NEW; END;", "updated_at", false},
+		{"INSERT INTO audit (table_name) VALUES ('videos');", "updated_at", false},
+	}
+
+	for i, test := range tests {
+		trigger := Trigger{Body: test.body}
+		if got := trigger.Assigns(test.column); got != test.want {
+			t.Errorf("%d) want %t, got %t: %s", i, test.want, got, test.body)
+		}
+	}
+}
+
+func TestTableTriggerSets(t *testing.T) {
+	t.Parallel()
+
+	table := Table{
+		Triggers: []Trigger{
+			{Name: "audit", Timing: "AFTER", Events: []string{"INSERT", "UPDATE", "DELETE"}, Body: "INSERT INTO audit (changed_at) VALUES (now());"},
+			{Name: "touch", Timing: "BEFORE", Events: []string{"UPDATE"}, ForEachRow: true, Body: "NEW.updated_at := now(); RETURN NEW;"},
+		},
+	}
+
+	if !table.TriggerSets("UPDATE", "updated_at") {
+		t.Error("want updated_at set on update")
+	}
+	if !table.TriggerSets("update", "updated_at") {
+		t.Error("want events to be case insensitive")
+	}
+	if table.TriggerSets("INSERT", "updated_at") {
+		t.Error("want updated_at not set on insert")
+	}
+	if table.TriggerSets("UPDATE", "changed_at") {
+		t.Error("an inserted column of another table is not set")
+	}
+}
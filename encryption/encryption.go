@@ -0,0 +1,142 @@
+// Package encryption encrypts the values of columns, it backs the encrypted
+// column types of models generated with an encryption config section.
+//
+// The values are encrypted by an Encryptor when they are written and
+// decrypted when they are read, so the database only stores ciphertexts.
+// Ciphertexts of string columns are base64 encoded, those of byte columns are
+// stored as they are. AESGCM is an Encryptor with a random nonce per value:
+// the same value is encrypted differently every time, so encrypted columns
+// cannot be compared, ordered or indexed by the database.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// ErrNoEncryptor is returned when an encrypted column is read or written
+// before an Encryptor is set
+var ErrNoEncryptor = errors.New("encryption: no encryptor is set for the encrypted columns")
+
+// Encryptor encrypts and decrypts the values of encrypted columns. The
+// ciphertexts must hold everything but the key needed to decrypt them, such as
+// their nonce.
+type Encryptor interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// AESGCM encrypts with AES in Galois/Counter Mode, its ciphertexts are the
+// random nonce followed by the sealed plaintext
+type AESGCM struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCM creates an AESGCM encryptor with a key of 16, 24 or 32 bytes for
+// AES-128, AES-192 or AES-256
+func NewAESGCM(key []byte) (*AESGCM, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("encryption: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("encryption: %w", err)
+	}
+
+	return &AESGCM{aead: aead}, nil
+}
+
+// Encrypt seals the plaintext with a random nonce
+func (a *AESGCM) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, a.aead.NonceSize(), a.aead.NonceSize()+len(plaintext)+a.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("encryption: unable to read a nonce: %w", err)
+	}
+
+	return a.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt opens a ciphertext of Encrypt
+func (a *AESGCM) Decrypt(ciphertext []byte) ([]byte, error) {
+	n := a.aead.NonceSize()
+	if len(ciphertext) < n+a.aead.Overhead() {
+		return nil, errors.New("encryption: ciphertext is too short")
+	}
+
+	plaintext, err := a.aead.Open(nil, ciphertext[:n], ciphertext[n:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("encryption: unable to decrypt: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// EncryptString encrypts the value of a string column to its base64 encoded
+// ciphertext
+func EncryptString(e Encryptor, plaintext string) (string, error) {
+	ciphertext, err := EncryptBytes(e, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// DecryptString decrypts the base64 encoded ciphertext of a string column
+// read from the database, a string or a byte slice
+func DecryptString(e Encryptor, src interface{}) (string, error) {
+	var encoded []byte
+	switch v := src.(type) {
+	case string:
+		encoded = []byte(v)
+	case []byte:
+		encoded = v
+	default:
+		return "", fmt.Errorf("encryption: unable to decrypt a %T, want a string or []byte", src)
+	}
+
+	ciphertext := make([]byte, base64.StdEncoding.DecodedLen(len(encoded)))
+	n, err := base64.StdEncoding.Decode(ciphertext, encoded)
+	if err != nil {
+		return "", fmt.Errorf("encryption: ciphertext is not base64 encoded: %w", err)
+	}
+
+	plaintext, err := DecryptBytes(e, ciphertext[:n])
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// EncryptBytes encrypts the value of a byte column
+func EncryptBytes(e Encryptor, plaintext []byte) ([]byte, error) {
+	if e == nil {
+		return nil, ErrNoEncryptor
+	}
+
+	return e.Encrypt(plaintext)
+}
+
+// DecryptBytes decrypts the ciphertext of a byte column read from the
+// database, a byte slice or a string
+func DecryptBytes(e Encryptor, src interface{}) ([]byte, error) {
+	if e == nil {
+		return nil, ErrNoEncryptor
+	}
+
+	switch v := src.(type) {
+	case []byte:
+		return e.Decrypt(v)
+	case string:
+		return e.Decrypt([]byte(v))
+	default:
+		return nil, fmt.Errorf("encryption: unable to decrypt a %T, want a []byte or string", src)
+	}
+}
@@ -0,0 +1,133 @@
+package encryption
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func testEncryptor(t *testing.T) *AESGCM {
+	t.Helper()
+
+	e, err := NewAESGCM(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestNewAESGCM(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{16, 24, 32} {
+		if _, err := NewAESGCM(make([]byte, n)); err != nil {
+			t.Errorf("key of %d bytes: %v", n, err)
+		}
+	}
+	if _, err := NewAESGCM(make([]byte, 10)); err == nil {
+		t.Error("want an error for a key of 10 bytes")
+	}
+}
+
+func TestAESGCM(t *testing.T) {
+	t.Parallel()
+
+	e := testEncryptor(t)
+	plaintext := []byte("123-45-6789")
+
+	c1, err := e.Encrypt(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, err := e.Encrypt(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(c1, c2) {
+		t.Error("want a different ciphertext for every encryption")
+	}
+	if bytes.Contains(c1, plaintext) {
+		t.Error("want the plaintext hidden in the ciphertext")
+	}
+
+	got, err := e.Decrypt(c1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("want %q, got %q", plaintext, got)
+	}
+
+	c1[len(c1)-1] ^= 1
+	if _, err := e.Decrypt(c1); err == nil {
+		t.Error("want an error for a tampered ciphertext")
+	}
+	if _, err := e.Decrypt([]byte("short")); err == nil {
+		t.Error("want an error for a short ciphertext")
+	}
+
+	other, err := NewAESGCM(make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.Decrypt(c2); err == nil {
+		t.Error("want an error for a ciphertext of another key")
+	}
+}
+
+func TestString(t *testing.T) {
+	t.Parallel()
+
+	e := testEncryptor(t)
+
+	ciphertext, err := EncryptString(e, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, src := range []interface{}{ciphertext, []byte(ciphertext)} {
+		got, err := DecryptString(e, src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != "secret" {
+			t.Errorf("want secret, got %q", got)
+		}
+	}
+
+	if _, err := DecryptString(e, "not base64!"); err == nil {
+		t.Error("want an error for a ciphertext that is not base64")
+	}
+	if _, err := DecryptString(e, 5); err == nil {
+		t.Error("want an error for an int")
+	}
+}
+
+func TestBytes(t *testing.T) {
+	t.Parallel()
+
+	e := testEncryptor(t)
+
+	ciphertext, err := EncryptBytes(e, []byte{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := DecryptBytes(e, ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, []byte{1, 2, 3}) {
+		t.Errorf("want [1 2 3], got %v", got)
+	}
+}
+
+func TestNoEncryptor(t *testing.T) {
+	t.Parallel()
+
+	if _, err := EncryptString(nil, "secret"); !errors.Is(err, ErrNoEncryptor) {
+		t.Errorf("want ErrNoEncryptor, got %v", err)
+	}
+	if _, err := DecryptBytes(nil, []byte{1}); !errors.Is(err, ErrNoEncryptor) {
+		t.Errorf("want ErrNoEncryptor, got %v", err)
+	}
+}
@@ -180,11 +180,13 @@ func (l List) Swap(i, j int) {
 // Less implements sort.Interface.Less
 func (l List) Less(i, j int) bool {
 	res := strings.Compare(strings.TrimLeft(l[i], "_ "), strings.TrimLeft(l[j], "_ "))
-	if res <= 0 {
-		return true
+	if res == 0 {
+		// Blank and plain imports of a package compare equal once trimmed,
+		// order them by the full import so the sort is stable
+		return l[i] < l[j]
 	}
 
-	return false
+	return res < 0
 }
 
 // NewDefaultImports returns a default Imports struct.
@@ -221,6 +223,11 @@ func NewDefaultImports() Collection {
 				`"github.com/volatiletech/sqlboiler/v4/queries/qm"`,
 			},
 		},
+		"boil_repositories": {
+			ThirdParty: List{
+				`"github.com/volatiletech/sqlboiler/v4/boil"`,
+			},
+		},
 		"boil_types": {
 			Standard: List{
 				`"strconv"`,
@@ -242,12 +249,38 @@ func NewDefaultImports() Collection {
 		ThirdParty: List{
 			`"github.com/volatiletech/sqlboiler/v4/boil"`,
 			`"github.com/volatiletech/sqlboiler/v4/queries"`,
-			`"github.com/volatiletech/randomize"`,
+			`"github.com/volatiletech/sqlboiler/v4/randomize"`,
 			`"github.com/volatiletech/strmangle"`,
 		},
 	}
 
 	col.TestSingleton = Map{
+		"examples_test": {
+			Standard: List{
+				`"fmt"`,
+			},
+			ThirdParty: List{
+				`"github.com/volatiletech/sqlboiler/v4/boil"`,
+				`"github.com/volatiletech/sqlboiler/v4/queries/qm"`,
+			},
+		},
+		"boil_benchmarks_test": {
+			Standard: List{
+				`"testing"`,
+			},
+		},
+		"boil_factories_test": {
+			Standard: List{
+				`"context"`,
+				`"reflect"`,
+				`"testing"`,
+			},
+			ThirdParty: List{
+				`"github.com/volatiletech/sqlboiler/v4/boil"`,
+				`"github.com/volatiletech/sqlboiler/v4/queries"`,
+				`"github.com/volatiletech/sqlboiler/v4/randomize"`,
+			},
+		},
 		"boil_main_test": {
 			Standard: List{
 				`"database/sql"`,
@@ -263,6 +296,7 @@ func NewDefaultImports() Collection {
 			ThirdParty: List{
 				`"github.com/spf13/viper"`,
 				`"github.com/volatiletech/sqlboiler/v4/boil"`,
+				`"github.com/volatiletech/sqlboiler/v4/testharness"`,
 			},
 		},
 		"boil_queries_test": {
@@ -277,6 +311,18 @@ func NewDefaultImports() Collection {
 				`"github.com/volatiletech/sqlboiler/v4/boil"`,
 			},
 		},
+		"boil_sqlmock_test": {
+			Standard: List{
+				`"database/sql"`,
+				`"database/sql/driver"`,
+				`"errors"`,
+				`"flag"`,
+				`"testing"`,
+			},
+			ThirdParty: List{
+				`"github.com/DATA-DOG/go-sqlmock"`,
+			},
+		},
 		"boil_suites_test": {
 			Standard: List{
 				`"testing"`,
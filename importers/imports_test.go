@@ -164,6 +164,14 @@ func TestImportsSort(t *testing.T) {
 			t.Errorf("Expected a2[%d] to match a2Expected[%d]:\n%s\n%s\n", i, i, v, a1Expected[i])
 		}
 	}
+
+	a3 := List{`_ "github.com/lib/pq"`, `"github.com/lib/pq"`}
+	a4 := List{`"github.com/lib/pq"`, `_ "github.com/lib/pq"`}
+	sort.Sort(a3)
+	sort.Sort(a4)
+	if !reflect.DeepEqual(a3, a4) {
+		t.Errorf("Expected blank and plain imports to sort the same, got: %v and %v", a3, a4)
+	}
 }
 
 func TestAddTypeImports(t *testing.T) {
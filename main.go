@@ -2,10 +2,13 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/friendsofgo/errors"
 	"github.com/spf13/cobra"
@@ -16,15 +19,32 @@ import (
 	"github.com/volatiletech/sqlboiler/v4/importers"
 )
 
-const sqlBoilerVersion = "4.14.2"
-
 var (
 	flagConfigFile string
-	cmdState       *boilingcore.State
-	cmdConfig      *boilingcore.Config
+	cmdStates      []*boilingcore.State
+	// exitCode is the status to exit with when the command succeeds
+	exitCode int
 )
 
+// exitCodeChanged is the status of a generation that changed files with
+// --detailed-exit-code
+const exitCodeChanged = 2
+
 func initConfig() {
+	// go generate runs in the directory of the file, the config and the
+	// relative paths are read from the root of its module instead
+	if viper.GetBool("go-generate") {
+		root, err := moduleRoot()
+		if err != nil {
+			fmt.Println("Can't find the module root:", err)
+			os.Exit(1)
+		}
+		if err := os.Chdir(root); err != nil {
+			fmt.Println("Can't change to the module root:", err)
+			os.Exit(1)
+		}
+	}
+
 	if len(flagConfigFile) != 0 {
 		viper.SetConfigFile(flagConfigFile)
 		if err := viper.ReadInConfig(); err != nil {
@@ -60,28 +80,63 @@ func initConfig() {
 	_ = viper.ReadInConfig()
 }
 
+// moduleRoot returns the closest directory with a go.mod, from the working
+// directory up
+func moduleRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("no go.mod in the working directory or above it")
+		}
+		dir = parent
+	}
+}
+
+// goFlagsVerbose checks if GOFLAGS has -v, like go generate -v, which shows
+// the notices of a generation run with --go-generate
+func goFlagsVerbose() bool {
+	for _, flag := range strings.Fields(os.Getenv("GOFLAGS")) {
+		switch strings.TrimPrefix(flag, "-") {
+		case "-v", "v", "-v=true", "v=true":
+			return true
+		}
+	}
+	return false
+}
+
 func main() {
 	// Too much happens between here and cobra's argument handling, for
 	// something so simple just do it immediately.
 	for _, arg := range os.Args {
 		if arg == "--version" {
-			fmt.Println("SQLBoiler v" + sqlBoilerVersion)
+			fmt.Println("SQLBoiler v" + boilingcore.Version)
 			return
 		}
 	}
 
 	// Set up the cobra root command
 	rootCmd := &cobra.Command{
-		Use:   "sqlboiler [flags] <driver>",
+		Use:   "sqlboiler [flags] [driver]",
 		Short: "SQL Boiler generates an ORM tailored to your database schema.",
 		Long: "SQL Boiler generates a Go ORM from template files, tailored to your database schema.\n" +
 			`Complete documentation is available at http://github.com/volatiletech/sqlboiler`,
-		Example:       `sqlboiler psql`,
-		PreRunE:       preRun,
-		RunE:          run,
-		PostRunE:      postRun,
-		SilenceErrors: true,
-		SilenceUsage:  true,
+		Example:           `sqlboiler psql`,
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: completeDrivers,
+		PreRunE:           preRun,
+		RunE:              run,
+		PostRunE:          postRun,
+		SilenceErrors:     true,
+		SilenceUsage:      true,
 	}
 
 	cobra.OnInitialize(initConfig)
@@ -101,21 +156,150 @@ func main() {
 	rootCmd.PersistentFlags().BoolP("no-auto-timestamps", "", false, "Disable automatic timestamps for created_at/updated_at")
 	rootCmd.PersistentFlags().BoolP("no-driver-templates", "", false, "Disable parsing of templates defined by the database driver")
 	rootCmd.PersistentFlags().BoolP("no-back-referencing", "", false, "Disable back referencing in the loaded relationship structs")
+	rootCmd.PersistentFlags().BoolP("no-relationship-setters", "", false, "Disable the set, add and remove methods of the relationships")
+	rootCmd.PersistentFlags().BoolP("infer-foreign-keys", "", false, "Treat columns like user_id matching users.id as foreign keys when the schema has no constraint, and report them")
+	rootCmd.PersistentFlags().BoolP("no-reload", "", false, "Disable the Reload methods of the models")
+	rootCmd.PersistentFlags().BoolP("no-exists", "", false, "Disable the Exists functions of the models and queries")
+	rootCmd.PersistentFlags().BoolP("no-upsert", "", false, "Disable the Upsert methods of the models")
 	rootCmd.PersistentFlags().BoolP("always-wrap-errors", "", false, "Wrap all returned errors with stacktraces, also sql.ErrNoRows")
 	rootCmd.PersistentFlags().BoolP("add-global-variants", "", false, "Enable generation for global variants")
 	rootCmd.PersistentFlags().BoolP("add-panic-variants", "", false, "Enable generation for panic variants")
 	rootCmd.PersistentFlags().BoolP("add-soft-deletes", "", false, "Enable soft deletion by updating deleted_at timestamp")
 	rootCmd.PersistentFlags().BoolP("add-enum-types", "", false, "Enable generation of types for enums")
+	rootCmd.PersistentFlags().BoolP("add-factories", "", false, "Enable generation of test factories that insert rows and their required parents, in the tests of the models")
+	rootCmd.PersistentFlags().BoolP("add-sqlmock-tests", "", false, "Enable generation of go-sqlmock tests that assert the SQL of each model's operations")
+	rootCmd.PersistentFlags().BoolP("add-csv", "", false, "Enable generation of CSV export and import helpers for the models")
+	rootCmd.PersistentFlags().BoolP("add-seeds", "", false, "Enable generation of a loader of YAML and JSON seed files")
+	rootCmd.PersistentFlags().BoolP("add-repositories", "", false, "Enable generation of a repository interface per model with an implementation over the database")
+	rootCmd.PersistentFlags().BoolP("add-immutable", "", false, "Enable generation of With methods returning changed copies of the models and update builders")
+	rootCmd.PersistentFlags().BoolP("add-interfaces", "", false, "Enable generation of the TableNamer, Identifiable, Timestamped and Model interfaces the models implement")
+	rootCmd.PersistentFlags().BoolP("add-diff", "", false, "Enable generation of a Diff method per model returning the columns changed between two of them")
+	rootCmd.PersistentFlags().BoolP("add-copy-equal", "", false, "Enable generation of Copy and Equal methods per model deep copying and comparing their columns")
+	rootCmd.PersistentFlags().BoolP("add-maps", "", false, "Enable generation of ToMap and FromMap methods per model converting them to and from maps by column name")
+	rootCmd.PersistentFlags().BoolP("add-validation", "", false, "Enable generation of a Validate method per model checking the lengths of text columns and the digits of decimal columns before inserts and updates")
+	rootCmd.PersistentFlags().BoolP("with-benchmarks", "", false, "Enable generation of benchmarks for each model that run against the test database")
+	rootCmd.PersistentFlags().BoolP("with-otel", "", false, "Enable tracing of every generated query through boil.SetQueryTracer")
+	rootCmd.PersistentFlags().BoolP("with-metrics", "", false, "Enable recording of every generated query through boil.SetQueryMetrics")
+	rootCmd.PersistentFlags().BoolP("with-proto", "", false, "Enable generation of a protobuf message per model with ToProto and FromProto conversions")
+	rootCmd.PersistentFlags().BoolP("with-grpc", "", false, "Enable generation of a gRPC service per model serving its protobuf message, needs --with-proto")
+	rootCmd.PersistentFlags().BoolP("with-graphql", "", false, "Enable generation of a GraphQL schema and gqlgen resolvers for the models")
+	rootCmd.PersistentFlags().BoolP("with-json-schema", "", false, "Enable generation of a JSON Schema of the JSON of each model")
+	rootCmd.PersistentFlags().BoolP("with-typescript", "", false, "Enable generation of TypeScript interfaces of the JSON of the models")
+	rootCmd.PersistentFlags().BoolP("with-http", "", false, "Enable generation of net/http handlers serving the models as JSON")
+	rootCmd.PersistentFlags().BoolP("with-docs", "", false, "Enable generation of a docs directory with a markdown page per table")
+	rootCmd.PersistentFlags().BoolP("with-generics", "", false, "Enable generation of finders sharing the generic runtime of the queries package, needs Go 1.18")
+	rootCmd.PersistentFlags().BoolP("with-dataloader", "", false, "Enable generation of a Loader batching the relationships loaded one model at a time")
+	rootCmd.PersistentFlags().BoolP("with-identity-map", "", false, "Enable keeping the models found by primary key in the boil.IdentityMap of their executor")
 	rootCmd.PersistentFlags().StringP("enum-null-prefix", "", "Null", "Name prefix of nullable enum types")
 	rootCmd.PersistentFlags().BoolP("version", "", false, "Print the version")
 	rootCmd.PersistentFlags().BoolP("wipe", "", false, "Delete the output folder (rm -rf) before generation to ensure sanity")
 	rootCmd.PersistentFlags().StringP("struct-tag-casing", "", "snake", "Decides the casing for go structure tag names. camel, title or snake (default snake)")
+	rootCmd.PersistentFlags().StringP("struct-field-order", "", "", "Order of the fields of the structs: "+strings.Join(boilingcore.StructFieldOrders, ", ")+" (default ordinal)")
+	rootCmd.PersistentFlags().BoolP("group-nullable-fields", "", false, "Put the nullable fields of the structs after the others")
+	rootCmd.PersistentFlags().BoolP("optimize-struct-layout", "", false, "Order the fields of the structs by descending alignment to minimize padding, and report the bytes saved per model")
 	rootCmd.PersistentFlags().StringP("relation-tag", "r", "-", "Relationship struct tag name")
 	rootCmd.PersistentFlags().StringSliceP("tag-ignore", "", nil, "List of column names that should have tags values set to '-' (ignored during parsing)")
+	rootCmd.PersistentFlags().StringP("queries-dir", "", "", "A directory of annotated .sql files to generate typed functions for")
+	rootCmd.PersistentFlags().BoolP("add-functions", "", false, "Enable generation of typed wrappers for stored functions and procedures")
+	rootCmd.PersistentFlags().StringP("tenant-column", "", "", "Column of the tenant of the rows, the queries of the tables with it are scoped to the tenant of their context")
+	rootCmd.PersistentFlags().StringP("rls-setting", "", "", "Session variable read by the row level security policies, such as app.current_user, set by the generated SetRLS and WithRLS")
+	rootCmd.PersistentFlags().StringSliceP("database", "", nil, "Names of the [databases.<name>] config sections to generate when no driver is given, all by default")
+	rootCmd.PersistentFlags().BoolP("go-generate", "", false, "Run from a //go:generate line: read the config and relative paths from the module root and only print errors")
+	rootCmd.PersistentFlags().StringP("schema-snapshot", "", "", "Snapshot written by sqlboiler dump-schema to read the schema from instead of the database")
+	rootCmd.PersistentFlags().BoolP("detailed-exit-code", "", false, "Exit with status 2 when the generation changed files, and 0 when it did not, cannot be used with --go-generate")
+	rootCmd.PersistentFlags().StringSliceP("tables", "", nil, "Names of the tables to regenerate, the files of the other tables are left untouched")
 
 	// hide flags not recommended for use
 	rootCmd.PersistentFlags().MarkHidden("replace")
 
+	generateCmd := &cobra.Command{
+		Use:               "generate [flags] [driver]",
+		Short:             "Generate the models of the database of the driver",
+		Long:              "Generate the models of the database of the driver, or of the [databases.<name>] sections of the\nconfig when no driver is given. Running sqlboiler with a driver and no command does the same.",
+		Example:           `sqlboiler generate psql`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeDrivers,
+		PreRunE:           preRun,
+		RunE:              run,
+		PostRunE:          postRun,
+		SilenceErrors:     true,
+		SilenceUsage:      true,
+	}
+	rootCmd.AddCommand(generateCmd)
+
+	versionCmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print the version",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Println("SQLBoiler v" + boilingcore.Version)
+		},
+	}
+	rootCmd.AddCommand(versionCmd)
+
+	erdCmd := &cobra.Command{
+		Use:               "erd [flags] <driver>",
+		Short:             "Render an ER diagram of the schema the generator sees",
+		Long:              "Render the tables, columns and primary, foreign and unique keys the driver reads, after the type\nreplacements, as an entity relationship diagram in DOT, Mermaid or PlantUML on stdout.",
+		Example:           `sqlboiler erd psql | dot -Tsvg > schema.svg`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDrivers,
+		RunE:              runERD,
+		SilenceErrors:     true,
+		SilenceUsage:      true,
+	}
+	erdCmd.Flags().StringP("format", "f", "dot", "Format of the diagram: "+strings.Join(boilingcore.ERDFormats, ", "))
+	rootCmd.AddCommand(erdCmd)
+
+	dumpSchemaCmd := &cobra.Command{
+		Use:               "dump-schema [flags] <driver>",
+		Aliases:           []string{"snapshot"},
+		Short:             "Write a JSON snapshot of the schema the generator sees",
+		Long:              "Write the schema the driver reads, after the type replacements, as JSON on stdout. Snapshots are\ncompared by the migration command and can be the schema_file of the mock driver.",
+		Example:           `sqlboiler dump-schema psql > schema.json`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDrivers,
+		RunE:              runSnapshot,
+		SilenceErrors:     true,
+		SilenceUsage:      true,
+	}
+	rootCmd.AddCommand(dumpSchemaCmd)
+
+	migrationCmd := &cobra.Command{
+		Use:               "migration [flags] [driver]",
+		Short:             "Write migration stubs for the changes between two schemas",
+		Long:              "Write the SQL migrating the schema of a snapshot to the schema of another snapshot, or of the\ndatabase of the driver, and back, as stubs to review in a goose or golang-migrate folder.",
+		Example:           `sqlboiler migration --from schema.json psql`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeDrivers,
+		RunE:              runMigration,
+		SilenceErrors:     true,
+		SilenceUsage:      true,
+	}
+	migrationCmd.Flags().StringP("from", "", "", "Snapshot of the schema to migrate from")
+	migrationCmd.Flags().StringP("to", "", "", "Snapshot of the schema to migrate to, instead of the database of the driver")
+	migrationCmd.Flags().StringP("dir", "", "migrations", "Folder of the migrations")
+	migrationCmd.Flags().StringP("name", "", "schema_changes", "Name of the migration, after its version")
+	migrationCmd.Flags().StringP("format", "f", "golang-migrate", "Layout of the migration files: "+strings.Join(boilingcore.MigrationFormats, ", "))
+	migrationCmd.MarkFlagRequired("from")
+	rootCmd.AddCommand(migrationCmd)
+
+	verifyCmd := &cobra.Command{
+		Use:               "verify [flags] [driver]",
+		Aliases:           []string{"check"},
+		Short:             "Check that the generated code matches the generator, config and schema",
+		Long:              "Compare the stamps in the headers of the generated Go files with the version, driver and hash of the\nconfig and schema of a generation, and fail if files were generated by another version or config.",
+		Example:           `sqlboiler verify psql`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeDrivers,
+		PreRunE:           preRunCheck,
+		RunE:              runCheck,
+		PostRunE:          postRun,
+		SilenceErrors:     true,
+		SilenceUsage:      true,
+	}
+	rootCmd.AddCommand(verifyCmd)
+
 	viper.BindPFlags(rootCmd.PersistentFlags())
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv()
@@ -132,6 +316,35 @@ func main() {
 
 		os.Exit(1)
 	}
+
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+}
+
+// completeDrivers completes the driver argument with the drivers whose
+// sqlboiler-<driver> executables are on the PATH
+func completeDrivers(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	found := make(map[string]bool)
+	var names []string
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		paths, _ := filepath.Glob(filepath.Join(dir, "sqlboiler-*"))
+		for _, path := range paths {
+			name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "sqlboiler-"), ".exe")
+			if found[name] || !strings.HasPrefix(name, toComplete) {
+				continue
+			}
+			found[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names, cobra.ShellCompDirectiveNoFileComp
 }
 
 type commandFailure string
@@ -141,9 +354,17 @@ func (c commandFailure) Error() string {
 }
 
 func preRun(_ *cobra.Command, args []string) error {
-	var err error
+	if len(viper.GetStringSlice("tables")) != 0 && viper.GetBool("wipe") {
+		return commandFailure("--tables regenerates some tables only and cannot be used with --wipe")
+	}
+	if viper.GetBool("go-generate") && viper.GetBool("detailed-exit-code") {
+		return commandFailure("--detailed-exit-code exits with status 2 when files changed, which fails go generate, and cannot be used with --go-generate")
+	}
 
 	if len(args) == 0 {
+		if viper.IsSet("databases") {
+			return preRunDatabases()
+		}
 		return commandFailure("must provide a driver name")
 	}
 
@@ -152,15 +373,125 @@ func preRun(_ *cobra.Command, args []string) error {
 		return errors.Wrap(err, "could not register driver")
 	}
 
-	cmdConfig = &boilingcore.Config{
+	cmdConfig := newConfig(driverName, driverName)
+	cmdConfig.OutFolder = viper.GetString("output")
+	cmdConfig.PkgName = viper.GetString("pkgname")
+
+	if cmdConfig.Debug {
+		fmt.Fprintln(os.Stderr, "using driver:", driverPath)
+	}
+
+	state, err := boilingcore.New(cmdConfig)
+	if err != nil {
+		return err
+	}
+
+	cmdStates = []*boilingcore.State{state}
+	return nil
+}
+
+// preRunDatabases creates a state for each of the [databases.<name>] sections
+// of the config, or only for the ones given with --database
+func preRunDatabases() error {
+	names := viper.GetStringSlice("database")
+	if len(names) == 0 {
+		for name := range viper.GetStringMap("databases") {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	driverNames := make(map[string]string)
+	outputs := make(map[string]string)
+	for _, name := range names {
+		section := "databases." + name
+		if !viper.IsSet(section) {
+			return errors.Errorf("database %s is not in the config", name)
+		}
+
+		arg := viper.GetString(section + ".driver")
+		if len(arg) == 0 {
+			return errors.Errorf("database %s must have a driver", name)
+		}
+
+		// A driver can only be registered once but may serve many databases
+		driverName, ok := driverNames[arg]
+		if !ok {
+			var driverPath string
+			var err error
+			driverName, driverPath, err = drivers.RegisterBinaryFromCmdArg(arg)
+			if err != nil {
+				return errors.Wrapf(err, "could not register driver of database %s", name)
+			}
+			driverNames[arg] = driverName
+
+			if viper.GetBool("debug") {
+				fmt.Fprintln(os.Stderr, "using driver:", driverPath)
+			}
+		}
+
+		cmdConfig := newConfig(driverName, section)
+		cmdConfig.OutFolder = name
+		if viper.IsSet(section + ".output") {
+			cmdConfig.OutFolder = viper.GetString(section + ".output")
+		}
+		cmdConfig.PkgName = name
+		if viper.IsSet(section + ".pkgname") {
+			cmdConfig.PkgName = viper.GetString(section + ".pkgname")
+		}
+
+		output := filepath.Clean(cmdConfig.OutFolder)
+		if other, ok := outputs[output]; ok {
+			return errors.Errorf("databases %s and %s cannot share the output folder %s", other, name, cmdConfig.OutFolder)
+		}
+		outputs[output] = name
+
+		state, err := boilingcore.New(cmdConfig)
+		if err != nil {
+			return errors.Wrapf(err, "database %s", name)
+		}
+		cmdStates = append(cmdStates, state)
+	}
+
+	return nil
+}
+
+// newConfig creates the config of a generation using the driver, the driver
+// config is read from the section of the config file, the driver name or
+// databases.<name>. The section may also override the aliases, types,
+// packages and foreign keys.
+func newConfig(driverName, section string) *boilingcore.Config {
+	cmdConfig := &boilingcore.Config{
 		DriverName:        driverName,
-		OutFolder:         viper.GetString("output"),
-		PkgName:           viper.GetString("pkgname"),
 		Debug:             viper.GetBool("debug"),
 		AddGlobal:         viper.GetBool("add-global-variants"),
 		AddPanic:          viper.GetBool("add-panic-variants"),
 		AddSoftDeletes:    viper.GetBool("add-soft-deletes"),
 		AddEnumTypes:      viper.GetBool("add-enum-types"),
+		AddFactories:      viper.GetBool("add-factories"),
+		AddSQLMockTests:   viper.GetBool("add-sqlmock-tests"),
+		AddCSV:            viper.GetBool("add-csv"),
+		AddSeeds:          viper.GetBool("add-seeds"),
+		AddRepositories:   viper.GetBool("add-repositories"),
+		AddImmutable:      viper.GetBool("add-immutable"),
+		AddInterfaces:     viper.GetBool("add-interfaces"),
+		AddDiff:           viper.GetBool("add-diff"),
+		AddCopyEqual:      viper.GetBool("add-copy-equal"),
+		AddMaps:           viper.GetBool("add-maps"),
+		AddValidation:     viper.GetBool("add-validation"),
+		WithBenchmarks:    viper.GetBool("with-benchmarks"),
+		WithOTel:          viper.GetBool("with-otel"),
+		WithMetrics:       viper.GetBool("with-metrics"),
+		WithProto:         viper.GetBool("with-proto"),
+		WithGRPC:          viper.GetBool("with-grpc"),
+		WithGraphQL:       viper.GetBool("with-graphql"),
+		WithJSONSchema:    viper.GetBool("with-json-schema"),
+		WithTypeScript:    viper.GetBool("with-typescript"),
+		WithHTTP:          viper.GetBool("with-http"),
+		WithDocs:          viper.GetBool("with-docs"),
+		WithGenerics:      viper.GetBool("with-generics"),
+		WithDataloader:    viper.GetBool("with-dataloader"),
+		WithIdentityMap:   viper.GetBool("with-identity-map"),
 		EnumNullPrefix:    viper.GetString("enum-null-prefix"),
 		NoContext:         viper.GetBool("no-context"),
 		NoTests:           viper.GetBool("no-tests"),
@@ -169,21 +500,70 @@ func preRun(_ *cobra.Command, args []string) error {
 		NoAutoTimestamps:  viper.GetBool("no-auto-timestamps"),
 		NoDriverTemplates: viper.GetBool("no-driver-templates"),
 		NoBackReferencing: viper.GetBool("no-back-referencing"),
+		NoReload:          viper.GetBool("no-reload"),
+		NoExists:          viper.GetBool("no-exists"),
+		NoUpsert:          viper.GetBool("no-upsert"),
 		AlwaysWrapErrors:  viper.GetBool("always-wrap-errors"),
 		Wipe:              viper.GetBool("wipe"),
 		StructTagCasing:   strings.ToLower(viper.GetString("struct-tag-casing")), // camel | snake | title
+		StructFieldOrder:  strings.ToLower(viper.GetString("struct-field-order")),
 		TagIgnore:         viper.GetStringSlice("tag-ignore"),
+		QueriesDir:        viper.GetString("queries-dir"),
+		AddFunctions:      viper.GetBool("add-functions"),
+		TenantColumn:      viper.GetString("tenant-column"),
+		RLSSetting:        viper.GetString("rls-setting"),
+		Quiet:             viper.GetBool("go-generate") && !goFlagsVerbose(),
+		SchemaSnapshot:    viper.GetString("schema-snapshot"),
 		RelationTag:       viper.GetString("relation-tag"),
 		TemplateDirs:      viper.GetStringSlice("templates"),
 		Tags:              viper.GetStringSlice("tag"),
 		Replacements:      viper.GetStringSlice("replace"),
-		Aliases:           boilingcore.ConvertAliases(viper.Get("aliases")),
-		TypeReplaces:      boilingcore.ConvertTypeReplace(viper.Get("types")),
+		Aliases:           boilingcore.ConvertAliases(viper.Get(sectionKey(section, "aliases"))),
+		TypeReplaces:      boilingcore.ConvertTypeReplace(viper.Get(sectionKey(section, "types"))),
+		Packages:          boilingcore.ConvertPackages(viper.Get(sectionKey(section, "packages"))),
+		DTOs:              boilingcore.ConvertDTOs(viper.Get(sectionKey(section, "dtos"))),
+		Joins:             boilingcore.ConvertJoins(viper.Get(sectionKey(section, "joins"))),
+		Polymorphics:      boilingcore.ConvertPolymorphics(viper.Get(sectionKey(section, "polymorphic"))),
+		Inheritances:      boilingcore.ConvertInheritances(viper.Get(sectionKey(section, "inheritance"))),
+		Meta:              viper.GetStringMap(sectionKey(section, "meta")),
+		TableMeta:         boilingcore.ConvertTableMeta(viper.Get(sectionKey(section, "table_meta"))),
 		AutoColumns: boilingcore.AutoColumns{
 			Created: viper.GetString("auto-columns.created"),
 			Updated: viper.GetString("auto-columns.updated"),
 			Deleted: viper.GetString("auto-columns.deleted"),
 		},
+		AuditLog: boilingcore.AuditLog{
+			Tables: viper.GetStringSlice("audit-log.tables"),
+			Table:  viper.GetString("audit-log.table"),
+		},
+		Outbox: boilingcore.Outbox{
+			Tables: viper.GetStringSlice("outbox.tables"),
+			Table:  viper.GetString("outbox.table"),
+		},
+		Encryption: boilingcore.Encryption{
+			Columns: viper.GetStringSlice("encryption.columns"),
+		},
+		Scrub: boilingcore.Scrub{
+			Columns: viper.GetStringSlice("scrub.columns"),
+		},
+		Masking: boilingcore.Masking{
+			Columns: viper.GetStringSlice("masking.columns"),
+		},
+		Streaming: boilingcore.Streaming{
+			Columns: viper.GetStringSlice("streaming.columns"),
+		},
+		Retention: boilingcore.Retention{
+			Columns: viper.GetStringSlice("retention.columns"),
+		},
+		History: boilingcore.History{
+			Tables: viper.GetStringSlice("history.tables"),
+			Suffix: viper.GetString("history.suffix"),
+		},
+		Proto: boilingcore.Proto{
+			Package:   viper.GetString("proto.package"),
+			GoPackage: viper.GetString("proto.go_package"),
+			Nullable:  viper.GetString("proto.nullable"),
+		},
 		Inflections: boilingcore.Inflections{
 			Plural:        viper.GetStringMapString("inflections.plural"),
 			PluralExact:   viper.GetStringMapString("inflections.plural_exact"),
@@ -191,36 +571,59 @@ func preRun(_ *cobra.Command, args []string) error {
 			SingularExact: viper.GetStringMapString("inflections.singular_exact"),
 			Irregular:     viper.GetStringMapString("inflections.irregular"),
 		},
+		Transliterations: viper.GetStringMapString("transliterations"),
 
-		Version: sqlBoilerVersion,
-	}
+		GroupNullableFields:  viper.GetBool("group-nullable-fields"),
+		OptimizeStructLayout: viper.GetBool("optimize-struct-layout"),
+		Version:              boilingcore.Version,
 
-	if cmdConfig.Debug {
-		fmt.Fprintln(os.Stderr, "using driver:", driverPath)
+		NoRelationshipSetters: viper.GetBool("no-relationship-setters"),
+		InferForeignKeys:      viper.GetBool("infer-foreign-keys"),
 	}
 
-	loadMissingConfigFromEnvs(driverName)
+	loadMissingConfigFromEnvs(section)
+	credentials := drivers.Credentials{
+		Source:   viper.GetString(section + ".credentials.source"),
+		Command:  viper.GetString(section + ".credentials.command"),
+		Region:   viper.GetString(section + ".credentials.region"),
+		SecretID: viper.GetString(section + ".credentials.secret_id"),
+		Path:     viper.GetString(section + ".credentials.path"),
+		Field:    viper.GetString(section + ".credentials.field"),
+	}
 	cmdConfig.DriverConfig = drivers.Config{
-		User:           viper.GetString(driverName + ".user"),
-		Pass:           viper.GetString(driverName + ".pass"),
-		Host:           viper.GetString(driverName + ".host"),
-		Port:           viper.GetInt(driverName + ".port"),
-		DBName:         viper.GetString(driverName + ".dbname"),
-		SSLMode:        viper.GetString(driverName + ".sslmode"),
-		BlackList:      viper.GetStringSlice(driverName + ".blacklist"),
-		WhiteList:      viper.GetStringSlice(driverName + ".whitelist"),
-		Schema:         viper.GetString(driverName + ".schema"),
+		User:           viper.GetString(section + ".user"),
+		Pass:           viper.GetString(section + ".pass"),
+		Host:           viper.GetString(section + ".host"),
+		Port:           viper.GetInt(section + ".port"),
+		DBName:         viper.GetString(section + ".dbname"),
+		SSLMode:        viper.GetString(section + ".sslmode"),
+		Credentials:    credentials,
+		BlackList:      viper.GetStringSlice(section + ".blacklist"),
+		WhiteList:      viper.GetStringSlice(section + ".whitelist"),
+		Schema:         viper.GetString(section + ".schema"),
 		AddEnumTypes:   cmdConfig.AddEnumTypes,
 		EnumNullPrefix: cmdConfig.EnumNullPrefix,
-		ForeignKeys:    boilingcore.ConvertForeignKeys(viper.Get("foreign_keys")),
-		Concurrency:    viper.GetInt(driverName + ".concurrency"),
-		TinyIntAsInt:   viper.GetBool(driverName + ".tinyint_as_int"),
+		AddFunctions:   cmdConfig.AddFunctions,
+		ForeignKeys:    boilingcore.ConvertForeignKeys(viper.Get(sectionKey(section, "foreign_keys"))),
+		Concurrency:    viper.GetInt(section + ".concurrency"),
+		TinyIntAsInt:   viper.GetBool(section + ".tinyint_as_int"),
+		Vitess:         viper.GetBool(section + ".vitess"),
+		MockTables:     boilingcore.ConvertMockTables(viper.Get(section + ".tables")),
+		MockSchemaFile: viper.GetString(section + ".schema_file"),
 	}
 
 	cmdConfig.Imports = configureImports()
 
-	cmdState, err = boilingcore.New(cmdConfig)
-	return err
+	return cmdConfig
+}
+
+// sectionKey returns the key in the section if it is set there, the top level
+// key otherwise
+func sectionKey(section, key string) string {
+	if viper.IsSet(section + "." + key) {
+		return section + "." + key
+	}
+	return key
 }
 
 func configureImports() importers.Collection {
@@ -260,11 +663,160 @@ func configureImports() importers.Collection {
 }
 
 func run(cmd *cobra.Command, args []string) error {
-	return cmdState.Run()
+	tables := viper.GetStringSlice("tables")
+
+	changed := 0
+	for _, state := range cmdStates {
+		var err error
+		if len(tables) != 0 {
+			err = state.RunTables(tables...)
+		} else {
+			err = state.Run()
+		}
+		if err != nil {
+			return err
+		}
+		changed += len(state.Changed())
+	}
+
+	if changed != 0 && viper.GetBool("detailed-exit-code") {
+		exitCode = exitCodeChanged
+	}
+	return nil
+}
+
+// preRunCheck creates the states of the generations to check, without
+// wiping their output folders
+func preRunCheck(cmd *cobra.Command, args []string) error {
+	viper.Set("wipe", false)
+	return preRun(cmd, args)
+}
+
+// runCheck prints the generated files whose stamps do not match the stamps of
+// the generations, it fails if there are any
+func runCheck(cmd *cobra.Command, args []string) error {
+	var problems []string
+	for _, state := range cmdStates {
+		stateProblems, err := state.Check()
+		if err != nil {
+			return err
+		}
+		problems = append(problems, stateProblems...)
+	}
+
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+	if len(problems) > 0 {
+		return errors.Errorf("%d generated files or folders do not match the generator, config and schema, regenerate them", len(problems))
+	}
+
+	return nil
+}
+
+// runERD writes the ER diagram of the schema of the driver to stdout
+func runERD(cmd *cobra.Command, args []string) error {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+
+	state, err := newSchemaState(args[0])
+	if err != nil {
+		return err
+	}
+
+	return state.WriteERD(os.Stdout, format)
+}
+
+// runSnapshot writes the schema of the driver as JSON to stdout
+func runSnapshot(cmd *cobra.Command, args []string) error {
+	state, err := newSchemaState(args[0])
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(state.DBInfo(), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal schema snapshot")
+	}
+
+	_, err = fmt.Printf("%s\n", b)
+	return err
+}
+
+// runMigration writes the migration from the schema of the --from snapshot to
+// the schema of the --to snapshot or of the driver
+func runMigration(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	fromFile, _ := flags.GetString("from")
+	toFile, _ := flags.GetString("to")
+	dir, _ := flags.GetString("dir")
+	name, _ := flags.GetString("name")
+	format, _ := flags.GetString("format")
+
+	if (len(toFile) == 0) == (len(args) == 0) {
+		return commandFailure("must provide either a driver or a --to snapshot")
+	}
+
+	from, err := boilingcore.ReadSchemaSnapshot(fromFile)
+	if err != nil {
+		return err
+	}
+
+	var to *drivers.DBInfo
+	if len(toFile) != 0 {
+		to, err = boilingcore.ReadSchemaSnapshot(toFile)
+	} else {
+		var state *boilingcore.State
+		if state, err = newSchemaState(args[0]); err == nil {
+			to = state.DBInfo()
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	migration := boilingcore.DiffSchemas(from, to)
+	if len(migration.Up) == 0 {
+		fmt.Println("no schema changes")
+		return nil
+	}
+
+	paths, err := migration.Write(dir, name, format, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		fmt.Println(path)
+	}
+
+	return nil
+}
+
+// newSchemaState registers the driver of the command line argument and reads
+// its schema with the config of its section
+func newSchemaState(arg string) (*boilingcore.State, error) {
+	driverName, driverPath, err := drivers.RegisterBinaryFromCmdArg(arg)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not register driver")
+	}
+
+	cmdConfig := newConfig(driverName, driverName)
+	if cmdConfig.Debug {
+		fmt.Fprintln(os.Stderr, "using driver:", driverPath)
+	}
+
+	return boilingcore.NewSchema(cmdConfig)
 }
 
 func postRun(cmd *cobra.Command, args []string) error {
-	return cmdState.Cleanup()
+	for _, state := range cmdStates {
+		if err := state.Cleanup(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func loadMissingConfigFromEnvs(prefix string) {
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestMain runs sqlboiler itself instead of the tests when the tests run
+// their binary with SQLBOILER_MAIN set, so they can check its exit status
+func TestMain(m *testing.M) {
+	if os.Getenv("SQLBOILER_MAIN") == "1" {
+		main()
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+// runMain runs sqlboiler in dir with args and returns its exit status and
+// output
+func runMain(t *testing.T, dir string, args ...string) (int, string) {
+	t.Helper()
+
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "SQLBOILER_MAIN=1")
+	out, err := cmd.CombinedOutput()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), string(out)
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	return 0, string(out)
+}
+
+func TestDetailedExitCode(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	dir := t.TempDir()
+	driver := filepath.Join(dir, "sqlboiler-mock")
+	build := exec.Command("go", "build", "-o", driver, "./drivers/sqlboiler-mock")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("unable to build the mock driver: %s\n%s", err, out)
+	}
+
+	config := "[mock]\nschema = \"schema\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "sqlboiler.toml"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	args := []string{"--no-tests", "--output", "models", "--detailed-exit-code", driver}
+	if status, out := runMain(t, dir, args...); status != exitCodeChanged {
+		t.Errorf("want status %d when the files changed, got: %d\n%s", exitCodeChanged, status, out)
+	}
+	if status, out := runMain(t, dir, args...); status != 0 {
+		t.Errorf("want status 0 when no file changed, got: %d\n%s", status, out)
+	}
+
+	// go generate fails on status 2, so the flags are not combined
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	status, out := runMain(t, dir, append([]string{"--go-generate"}, args...)...)
+	if status != 1 || !strings.Contains(out, "cannot be used with --go-generate") {
+		t.Errorf("want status 1 for --detailed-exit-code with --go-generate, got: %d\n%s", status, out)
+	}
+}
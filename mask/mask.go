@@ -0,0 +1,69 @@
+// Package mask hides parts of the values of sensitive columns, it backs the
+// Masked methods of models generated with a masking config section.
+//
+// Letters and digits are replaced by Char, everything else such as the
+// spaces and dashes of card numbers stays, so masked values keep their shape:
+// Last("4111 1111 1111 1234", 4) is "**** **** **** 1234".
+package mask
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Char replaces the hidden letters and digits
+const Char = '*'
+
+// Full hides every letter and digit of s
+func Full(s string) string {
+	return hide(s, 0, utf8.RuneCountInString(s))
+}
+
+// First shows the first n runes of s and hides the letters and digits of the
+// rest, all of s is hidden unless it has more than n runes
+func First(s string, n int) string {
+	count := utf8.RuneCountInString(s)
+	if count <= n {
+		return Full(s)
+	}
+	return hide(s, n, count)
+}
+
+// Last shows the last n runes of s and hides the letters and digits of the
+// rest, all of s is hidden unless it has more than n runes
+func Last(s string, n int) string {
+	count := utf8.RuneCountInString(s)
+	if count <= n {
+		return Full(s)
+	}
+	return hide(s, 0, count-n)
+}
+
+// Email shows the first rune of the local part and the domain of an email
+// address, like a***@example.com, other values are hidden by Full
+func Email(s string) string {
+	at := strings.LastIndexByte(s, '@')
+	if at <= 0 {
+		return Full(s)
+	}
+	return First(s[:at], 1) + s[at:]
+}
+
+// hide replaces the letters and digits of the runes of s from start up to end
+func hide(s string, start, end int) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	i := 0
+	for _, r := range s {
+		if i >= start && i < end && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(Char)
+		} else {
+			b.WriteRune(r)
+		}
+		i++
+	}
+
+	return b.String()
+}
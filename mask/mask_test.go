@@ -0,0 +1,32 @@
+package mask
+
+import "testing"
+
+func TestMask(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"full", Full("secret"), "******"},
+		{"full keeps separators", Full("12-34 ab"), "**-** **"},
+		{"full empty", Full(""), ""},
+		{"first", First("Ada Lovelace", 3), "Ada ********"},
+		{"first short", First("Ada", 3), "***"},
+		{"last", Last("4111 1111 1111 1234", 4), "**** **** **** 1234"},
+		{"last short", Last("1234", 4), "****"},
+		{"last runes", Last("ñandú", 2), "***dú"},
+		{"email", Email("ada@example.com"), "a**@example.com"},
+		{"email single", Email("a@example.com"), "*@example.com"},
+		{"email invalid", Email("ada"), "***"},
+		{"email empty local", Email("@example.com"), "@*******.***"},
+	}
+
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("%s: want %q, got %q", test.name, test.want, test.got)
+		}
+	}
+}
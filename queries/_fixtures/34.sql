@@ -0,0 +1 @@
+SELECT DISTINCT "a", "b" FROM "t" INNER JOIN dogs d on d.cat_id = t.id;
\ No newline at end of file
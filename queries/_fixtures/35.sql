@@ -0,0 +1 @@
+SELECT DISTINCT ON ("a", "t"."b") * FROM "t" ORDER BY a, t.b, c desc LIMIT 5;
\ No newline at end of file
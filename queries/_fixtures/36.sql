@@ -0,0 +1,2 @@
+-- distinct
+SELECT COUNT(*) FROM (SELECT DISTINCT * FROM "t" WHERE (a=$1)) AS "counted_rows";
\ No newline at end of file
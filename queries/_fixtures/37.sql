@@ -0,0 +1 @@
+SELECT "t".*, rank() OVER (ORDER BY a <-> $1) AS "r" FROM "t" INNER JOIN dogs d on d.cat_id = t.id and d.age > $2 WHERE (a=$3);
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- archive
+INSERT INTO "archive"."t" ("a", "b") SELECT "t"."a", "t"."b" FROM "t" WHERE (a=$1) LIMIT 5;
\ No newline at end of file
@@ -0,0 +1,147 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+)
+
+// BatchFunc loads the objects of a batch, it returns the result of each of
+// them in the same order
+type BatchFunc func(ctx context.Context, objs []interface{}) ([]interface{}, error)
+
+// Batcher collects the objects loaded within its wait of each other, at most
+// its max batch of them, and loads them with a single call of its BatchFunc,
+// like a dataloader. The results are cached by the keys of the objects, so
+// loading an object with the key of another returns its result.
+type Batcher struct {
+	wait     time.Duration
+	maxBatch int
+	fn       BatchFunc
+
+	mut   sync.Mutex
+	batch *batch
+	cache map[interface{}]*batchResult
+}
+
+// batch is a batch of objects waiting to be loaded
+type batch struct {
+	ctx     context.Context
+	objs    []interface{}
+	results []*batchResult
+	timer   *time.Timer
+}
+
+// batchResult is the result of an object, done is closed once it is loaded
+type batchResult struct {
+	key   interface{}
+	value interface{}
+	err   error
+	done  chan struct{}
+}
+
+// NewBatcher creates a batcher loading the objects with fn, every batch of
+// at most maxBatch objects, or unlimited when it is zero, once wait passes
+// after its first object.
+func NewBatcher(wait time.Duration, maxBatch int, fn BatchFunc) *Batcher {
+	return &Batcher{
+		wait:     wait,
+		maxBatch: maxBatch,
+		fn:       fn,
+		cache:    make(map[interface{}]*batchResult),
+	}
+}
+
+// Load adds the object to the current batch, or starts one, and returns its
+// result once the batch is loaded. The context of the first object of a batch
+// is the one the batch is loaded with. Failed loads are not cached.
+func (b *Batcher) Load(ctx context.Context, key, obj interface{}) (interface{}, error) {
+	key = batchKey(key)
+
+	b.mut.Lock()
+	res, ok := b.cache[key]
+	if !ok {
+		res = &batchResult{key: key, done: make(chan struct{})}
+		b.cache[key] = res
+
+		if b.batch == nil {
+			b.batch = &batch{ctx: ctx}
+			current := b.batch
+			b.batch.timer = time.AfterFunc(b.wait, func() { b.dispatch(current) })
+		}
+		b.batch.objs = append(b.batch.objs, obj)
+		b.batch.results = append(b.batch.results, res)
+		if b.maxBatch > 0 && len(b.batch.objs) >= b.maxBatch {
+			current := b.batch
+			current.timer.Stop()
+			b.batch = nil
+			go b.load(current)
+		}
+	}
+	b.mut.Unlock()
+
+	select {
+	case <-res.done:
+		return res.value, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Clear removes the result of the key from the cache, it is loaded again
+// the next time
+func (b *Batcher) Clear(key interface{}) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	delete(b.cache, batchKey(key))
+}
+
+// dispatch loads the batch once its wait passed, unless it was already
+// loaded because it was full
+func (b *Batcher) dispatch(current *batch) {
+	b.mut.Lock()
+	if b.batch != current {
+		b.mut.Unlock()
+		return
+	}
+	b.batch = nil
+	b.mut.Unlock()
+
+	b.load(current)
+}
+
+// load loads the objects of the batch and sets their results
+func (b *Batcher) load(current *batch) {
+	values, err := b.fn(current.ctx, current.objs)
+	if err == nil && len(values) != len(current.objs) {
+		err = errors.Errorf("batch of %d objects returned %d results", len(current.objs), len(values))
+	}
+
+	b.mut.Lock()
+	for i, res := range current.results {
+		if err != nil {
+			res.err = err
+			if b.cache[res.key] == res {
+				delete(b.cache, res.key)
+			}
+		} else {
+			res.value = values[i]
+		}
+		close(res.done)
+	}
+	b.mut.Unlock()
+}
+
+// batchKey returns the key as is when it can key a map, and its type and
+// value otherwise
+func batchKey(key interface{}) interface{} {
+	if key != nil && !reflect.TypeOf(key).Comparable() {
+		return fmt.Sprintf("%T:%v", key, key)
+	}
+	return key
+}
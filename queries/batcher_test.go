@@ -0,0 +1,82 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBatcher(t *testing.T) {
+	t.Parallel()
+
+	var mut sync.Mutex
+	var batches [][]interface{}
+	b := NewBatcher(10*time.Millisecond, 3, func(ctx context.Context, objs []interface{}) ([]interface{}, error) {
+		mut.Lock()
+		batches = append(batches, objs)
+		mut.Unlock()
+
+		results := make([]interface{}, len(objs))
+		for i, obj := range objs {
+			results[i] = obj.(int) * 10
+		}
+		return results, nil
+	})
+
+	var wg sync.WaitGroup
+	results := make([]interface{}, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := b.Load(context.Background(), i%4, i%4)
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = v
+		}(i)
+	}
+	wg.Wait()
+
+	for i, v := range results {
+		if v != (i%4)*10 {
+			t.Errorf("%d: want %d, got %v", i, (i%4)*10, v)
+		}
+	}
+	if len(batches) != 2 || len(batches[0])+len(batches[1]) != 4 {
+		t.Errorf("want the 4 keys in a full batch and another one, got: %v", batches)
+	}
+
+	v, err := b.Load(context.Background(), 2, 2)
+	if err != nil || v != 20 || len(batches) != 2 {
+		t.Errorf("want the cached result, got: %v %v %d", v, err, len(batches))
+	}
+
+	b.Clear(2)
+	if _, err := b.Load(context.Background(), 2, 2); err != nil || len(batches) != 3 {
+		t.Errorf("want the cleared key loaded again, got: %v %d", err, len(batches))
+	}
+}
+
+func TestBatcherErrors(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	b := NewBatcher(time.Millisecond, 0, func(ctx context.Context, objs []interface{}) ([]interface{}, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("failed")
+		}
+		return []interface{}{"ok"}, nil
+	})
+
+	key := []byte("key")
+	if _, err := b.Load(context.Background(), key, nil); err == nil || err.Error() != "failed" {
+		t.Errorf("want the error of the batch, got: %v", err)
+	}
+	if v, err := b.Load(context.Background(), key, nil); err != nil || v != "ok" {
+		t.Errorf("want the failed key loaded again, got: %v %v", v, err)
+	}
+}
@@ -0,0 +1,65 @@
+package queries
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// queryCache returns the cache and key for the query's result when it uses
+// qm.Cache and a boil.QueryCache is set, or a nil cache otherwise.
+func (q *Query) queryCache() (boil.QueryCache, string) {
+	cache := boil.GetQueryCache()
+	if q.cacheTTL <= 0 || cache == nil {
+		return nil, ""
+	}
+
+	qs, args := BuildQuery(q)
+	return cache, fmt.Sprintf("%s %#v", qs, args)
+}
+
+// getCached copies the cached result for key into obj, it reports false when
+// there is no result of obj's type in the cache.
+func getCached(cache boil.QueryCache, key string, obj interface{}) bool {
+	cached, ok := cache.Get(key)
+	if !ok {
+		return false
+	}
+
+	val := reflect.ValueOf(cached)
+	dst := reflect.ValueOf(obj).Elem()
+	if !val.IsValid() || val.Type() != dst.Type() {
+		return false
+	}
+
+	dst.Set(copyResult(val))
+	return true
+}
+
+// setCached stores a copy of the result bound to obj for key
+func setCached(cache boil.QueryCache, key string, obj interface{}, q *Query) {
+	cache.Set(key, copyResult(reflect.ValueOf(obj).Elem()).Interface(), q.cacheTTL)
+}
+
+// copyResult copies a bound struct, slice of structs or slice of struct
+// pointers so that results handed out from the cache can be modified without
+// changing the cached one.
+func copyResult(val reflect.Value) reflect.Value {
+	if val.Kind() != reflect.Slice {
+		return val
+	}
+
+	cp := reflect.MakeSlice(val.Type(), val.Len(), val.Len())
+	for i := 0; i < val.Len(); i++ {
+		elem := val.Index(i)
+		if elem.Kind() == reflect.Ptr && !elem.IsNil() {
+			ptr := reflect.New(elem.Type().Elem())
+			ptr.Elem().Set(elem.Elem())
+			elem = ptr
+		}
+		cp.Index(i).Set(elem)
+	}
+
+	return cp
+}
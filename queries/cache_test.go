@@ -0,0 +1,73 @@
+package queries
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+type mapCache map[string]interface{}
+
+func (m mapCache) Get(key string) (interface{}, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+
+func (m mapCache) Set(key string, value interface{}, ttl time.Duration) {
+	m[key] = value
+}
+
+func TestBindCache(t *testing.T) {
+	cache := mapCache{}
+	boil.SetQueryCache(cache)
+	defer boil.SetQueryCache(nil)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	type fun struct {
+		ID   int
+		Name string
+	}
+
+	newQuery := func() *Query {
+		q := &Query{
+			from:    []string{"fun"},
+			dialect: &drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true},
+		}
+		AppendWhere(q, "id > ?", 1)
+		SetCache(q, time.Minute)
+		return q
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "pat").AddRow(3, "hat")
+	mock.ExpectQuery(`SELECT \* FROM "fun" WHERE \(id > \$1\);`).WithArgs(1).WillReturnRows(rows)
+
+	var first []*fun
+	if err = newQuery().Bind(context.Background(), db, &first); err != nil {
+		t.Fatal(err)
+	}
+	first[0].Name = "changed"
+
+	var second []*fun
+	if err = newQuery().Bind(context.Background(), db, &second); err != nil {
+		t.Fatal(err)
+	}
+	if len(second) != 2 || second[0].Name != "pat" || second[1].Name != "hat" {
+		t.Errorf("want the cached rows unchanged, got: %#v %#v", second[0], second[1])
+	}
+	if len(cache) != 1 {
+		t.Error("want one cached result, got:", len(cache))
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
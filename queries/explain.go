@@ -0,0 +1,439 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+// Plan is the query plan of a query as explained by the database
+type Plan struct {
+	// SQL is the explained query and Args are its arguments
+	SQL  string
+	Args []interface{}
+	// Nodes are the top nodes of the plan
+	Nodes []*PlanNode
+	// Raw is the plan as returned by the database, the JSON of postgres and
+	// mysql, the tree of mysql with analyze and the details of sqlite
+	Raw string
+}
+
+// PlanNode is a step of a query plan. The estimates and measures the
+// database does not report are 0.
+type PlanNode struct {
+	// Operation is the kind of step, like Seq Scan for postgres, ALL for
+	// mysql or SCAN for sqlite
+	Operation string
+	// Table is the table the step reads and Index the index it reads it by
+	Table string
+	Index string
+	// Cost and Rows are the estimated total cost and rows of the step
+	Cost float64
+	Rows float64
+	// ActualTime in milliseconds and ActualRows are measured with analyze
+	ActualTime float64
+	ActualRows float64
+	// Detail is the condition of the step or its description by the database
+	Detail   string
+	Children []*PlanNode
+}
+
+// explainDialect is the EXPLAIN syntax of a database
+type explainDialect int
+
+const (
+	explainPostgres explainDialect = iota
+	explainMySQL
+	explainSQLite
+)
+
+// explainDialectOf picks the EXPLAIN syntax of the dialect of a query, the
+// dialects only differ by their features so they are told apart by them
+func explainDialectOf(d *drivers.Dialect) (explainDialect, error) {
+	switch {
+	case d == nil:
+		return 0, errors.New("the query has no dialect to explain it with")
+	case d.UseTopClause:
+		return 0, errors.New("explaining queries is not supported by mssql")
+	case d.LQ == '`':
+		return explainMySQL, nil
+	case d.UseIndexPlaceholders:
+		return explainPostgres, nil
+	default:
+		return explainSQLite, nil
+	}
+}
+
+// Explain runs EXPLAIN on the query and returns its plan. With analyze the
+// query is run to measure the plan, which sqlite does not support.
+func (q *Query) Explain(exec boil.Executor, analyze bool) (*Plan, error) {
+	return q.explain(func(query string, args ...interface{}) (*sql.Rows, error) {
+		return boil.DebugQuery(q.route(exec), query, args...)
+	}, analyze)
+}
+
+// ExplainContext runs EXPLAIN on the query and returns its plan. With analyze
+// the query is run to measure the plan, which sqlite does not support.
+func (q *Query) ExplainContext(ctx context.Context, exec boil.ContextExecutor, analyze bool) (*Plan, error) {
+	return q.explain(func(query string, args ...interface{}) (*sql.Rows, error) {
+		return boil.DebugQueryContext(ctx, q.routeContext(exec), query, args...)
+	}, analyze)
+}
+
+func (q *Query) explain(query func(string, ...interface{}) (*sql.Rows, error), analyze bool) (*Plan, error) {
+	dialect, err := explainDialectOf(q.dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	qs, args := BuildQuery(q)
+	plan := &Plan{SQL: qs, Args: args}
+
+	var prefix string
+	switch {
+	case dialect == explainPostgres && analyze:
+		prefix = "EXPLAIN (ANALYZE, FORMAT JSON) "
+	case dialect == explainPostgres:
+		prefix = "EXPLAIN (FORMAT JSON) "
+	case dialect == explainMySQL && analyze:
+		prefix = "EXPLAIN ANALYZE "
+	case dialect == explainMySQL:
+		prefix = "EXPLAIN FORMAT=JSON "
+	case analyze:
+		return nil, errors.New("explaining queries with analyze is not supported by sqlite")
+	default:
+		prefix = "EXPLAIN QUERY PLAN "
+	}
+
+	rows, err := query(prefix+qs, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to explain the query")
+	}
+	defer rows.Close()
+
+	if dialect == explainSQLite {
+		err = plan.scanSQLite(rows)
+	} else {
+		var raw string
+		if rows.Next() {
+			err = rows.Scan(&raw)
+		}
+		if err == nil {
+			err = rows.Err()
+		}
+		if err == nil {
+			plan.Raw = raw
+			switch {
+			case dialect == explainPostgres:
+				err = plan.parsePostgres()
+			case analyze:
+				plan.parseMySQLTree()
+			default:
+				err = plan.parseMySQL()
+			}
+		}
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the plan of the query")
+	}
+
+	return plan, nil
+}
+
+// Flatten returns the nodes of the plan, each before its children
+func (p *Plan) Flatten() []*PlanNode {
+	var nodes []*PlanNode
+	var walk func([]*PlanNode)
+	walk = func(children []*PlanNode) {
+		for _, n := range children {
+			nodes = append(nodes, n)
+			walk(n.Children)
+		}
+	}
+	walk(p.Nodes)
+	return nodes
+}
+
+// String formats the plan as an indented tree of its nodes
+func (p *Plan) String() string {
+	buf := &strings.Builder{}
+	var write func([]*PlanNode, int)
+	write = func(nodes []*PlanNode, depth int) {
+		for _, n := range nodes {
+			fmt.Fprintf(buf, "%s%s\n", strings.Repeat("  ", depth), n)
+			write(n.Children, depth+1)
+		}
+	}
+	write(p.Nodes, 0)
+	return buf.String()
+}
+
+// String formats the node on a line
+func (n *PlanNode) String() string {
+	buf := &strings.Builder{}
+	buf.WriteString(n.Operation)
+	if len(n.Table) != 0 {
+		fmt.Fprintf(buf, " on %s", n.Table)
+	}
+	if len(n.Index) != 0 {
+		fmt.Fprintf(buf, " using %s", n.Index)
+	}
+	if n.Cost != 0 || n.Rows != 0 {
+		fmt.Fprintf(buf, " (cost=%.2f rows=%g)", n.Cost, n.Rows)
+	}
+	if n.ActualTime != 0 || n.ActualRows != 0 {
+		fmt.Fprintf(buf, " (actual time=%.3f rows=%g)", n.ActualTime, n.ActualRows)
+	}
+	if len(n.Detail) != 0 {
+		fmt.Fprintf(buf, ": %s", n.Detail)
+	}
+	return buf.String()
+}
+
+// postgresPlanNode is a node of the JSON plan of postgres
+type postgresPlanNode struct {
+	NodeType        string              `json:"Node Type"`
+	RelationName    string              `json:"Relation Name"`
+	IndexName       string              `json:"Index Name"`
+	TotalCost       float64             `json:"Total Cost"`
+	PlanRows        float64             `json:"Plan Rows"`
+	ActualTotalTime float64             `json:"Actual Total Time"`
+	ActualRows      float64             `json:"Actual Rows"`
+	Filter          string              `json:"Filter"`
+	IndexCond       string              `json:"Index Cond"`
+	HashCond        string              `json:"Hash Cond"`
+	JoinFilter      string              `json:"Join Filter"`
+	Plans           []*postgresPlanNode `json:"Plans"`
+}
+
+func (p *Plan) parsePostgres() error {
+	var explained []struct {
+		Plan *postgresPlanNode `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(p.Raw), &explained); err != nil {
+		return err
+	}
+
+	var convert func(*postgresPlanNode) *PlanNode
+	convert = func(pn *postgresPlanNode) *PlanNode {
+		n := &PlanNode{
+			Operation:  pn.NodeType,
+			Table:      pn.RelationName,
+			Index:      pn.IndexName,
+			Cost:       pn.TotalCost,
+			Rows:       pn.PlanRows,
+			ActualTime: pn.ActualTotalTime,
+			ActualRows: pn.ActualRows,
+		}
+		var conds []string
+		for _, c := range []string{pn.IndexCond, pn.HashCond, pn.JoinFilter, pn.Filter} {
+			if len(c) != 0 {
+				conds = append(conds, c)
+			}
+		}
+		n.Detail = strings.Join(conds, " and ")
+		for _, child := range pn.Plans {
+			n.Children = append(n.Children, convert(child))
+		}
+		return n
+	}
+
+	for _, e := range explained {
+		if e.Plan != nil {
+			p.Nodes = append(p.Nodes, convert(e.Plan))
+		}
+	}
+	return nil
+}
+
+// mysqlOperations are the keys of the JSON plan of mysql whose objects are
+// steps of the plan
+var mysqlOperations = map[string]bool{
+	"query_block":        true,
+	"ordering_operation": true,
+	"grouping_operation": true,
+	"duplicates_removal": true,
+}
+
+func (p *Plan) parseMySQL() error {
+	var explained map[string]interface{}
+	if err := json.Unmarshal([]byte(p.Raw), &explained); err != nil {
+		return err
+	}
+
+	p.Nodes = mysqlNodes(explained)
+	return nil
+}
+
+// mysqlNodes returns the nodes of the steps and tables of an object of the
+// JSON plan of mysql
+func mysqlNodes(obj map[string]interface{}) []*PlanNode {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var nodes []*PlanNode
+	for _, key := range keys {
+		switch value := obj[key].(type) {
+		case map[string]interface{}:
+			switch {
+			case key == "table":
+				nodes = append(nodes, mysqlTable(value))
+			case mysqlOperations[key]:
+				n := &PlanNode{Operation: key, Children: mysqlNodes(value)}
+				if cost, ok := value["cost_info"].(map[string]interface{}); ok {
+					n.Cost = planFloat(cost["query_cost"]) + planFloat(cost["sort_cost"])
+				}
+				nodes = append(nodes, n)
+			}
+		case []interface{}:
+			// nested_loop and the subqueries are arrays of steps
+			for _, v := range value {
+				if o, ok := v.(map[string]interface{}); ok {
+					nodes = append(nodes, mysqlNodes(o)...)
+				}
+			}
+		}
+	}
+	return nodes
+}
+
+// mysqlTable returns the node of a table of the JSON plan of mysql
+func mysqlTable(table map[string]interface{}) *PlanNode {
+	n := &PlanNode{
+		Operation: fmt.Sprint(table["access_type"]),
+		Table:     fmt.Sprint(table["table_name"]),
+		Rows:      planFloat(table["rows_examined_per_scan"]),
+	}
+	if key, ok := table["key"].(string); ok {
+		n.Index = key
+	}
+	if cond, ok := table["attached_condition"].(string); ok {
+		n.Detail = cond
+	}
+	if cost, ok := table["cost_info"].(map[string]interface{}); ok {
+		n.Cost = planFloat(cost["prefix_cost"])
+	}
+	for _, key := range []string{"materialized_from_subquery", "attached_subqueries"} {
+		switch sub := table[key].(type) {
+		case map[string]interface{}:
+			n.Children = append(n.Children, mysqlNodes(sub)...)
+		case []interface{}:
+			for _, v := range sub {
+				if o, ok := v.(map[string]interface{}); ok {
+					n.Children = append(n.Children, mysqlNodes(o)...)
+				}
+			}
+		}
+	}
+	return n
+}
+
+var (
+	rgxMySQLTreeCost   = regexp.MustCompile(`\(cost=([0-9.e+]+)(?:\.\.[0-9.e+]+)? rows=([0-9.e+]+)\)`)
+	rgxMySQLTreeActual = regexp.MustCompile(`\(actual time=[0-9.e+]+\.\.([0-9.e+]+) rows=([0-9.e+]+)`)
+	rgxMySQLTreeTable  = regexp.MustCompile(` on (\S+)(?: using (\S+))?`)
+)
+
+// parseMySQLTree parses the tree of EXPLAIN ANALYZE of mysql, each line is a
+// node indented under its parent
+func (p *Plan) parseMySQLTree() {
+	type level struct {
+		indent int
+		node   *PlanNode
+	}
+	var parents []level
+
+	for _, line := range strings.Split(p.Raw, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if !strings.HasPrefix(trimmed, "-> ") {
+			continue
+		}
+		indent := len(line) - len(trimmed)
+		text := strings.TrimPrefix(trimmed, "-> ")
+
+		n := &PlanNode{Operation: text}
+		if i := strings.Index(text, "  ("); i >= 0 {
+			n.Operation = text[:i]
+		}
+		if i := strings.Index(n.Operation, ": "); i >= 0 {
+			n.Operation, n.Detail = n.Operation[:i], n.Operation[i+2:]
+		}
+		if m := rgxMySQLTreeTable.FindStringSubmatch(n.Operation); m != nil {
+			n.Table, n.Index = m[1], m[2]
+			n.Operation = strings.TrimSuffix(n.Operation[:strings.Index(n.Operation, m[0])], " ")
+		}
+		if m := rgxMySQLTreeCost.FindStringSubmatch(text); m != nil {
+			n.Cost, n.Rows = planFloat(m[1]), planFloat(m[2])
+		}
+		if m := rgxMySQLTreeActual.FindStringSubmatch(text); m != nil {
+			n.ActualTime, n.ActualRows = planFloat(m[1]), planFloat(m[2])
+		}
+
+		for len(parents) != 0 && parents[len(parents)-1].indent >= indent {
+			parents = parents[:len(parents)-1]
+		}
+		if len(parents) == 0 {
+			p.Nodes = append(p.Nodes, n)
+		} else {
+			parent := parents[len(parents)-1].node
+			parent.Children = append(parent.Children, n)
+		}
+		parents = append(parents, level{indent: indent, node: n})
+	}
+}
+
+var rgxSQLitePlan = regexp.MustCompile(`^(SCAN|SEARCH)(?: TABLE)? (\S+)(?: AS \S+)?(?: USING (?:COVERING )?INDEX (\S+))?`)
+
+// scanSQLite reads the rows of EXPLAIN QUERY PLAN of sqlite, each has the id
+// of its parent
+func (p *Plan) scanSQLite(rows *sql.Rows) error {
+	nodes := make(map[int]*PlanNode)
+	var details []string
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			return err
+		}
+		details = append(details, detail)
+
+		n := &PlanNode{Operation: detail}
+		if m := rgxSQLitePlan.FindStringSubmatch(detail); m != nil {
+			n.Operation, n.Table, n.Index, n.Detail = m[1], m[2], m[3], detail
+		}
+		nodes[id] = n
+
+		if parentNode, ok := nodes[parent]; ok {
+			parentNode.Children = append(parentNode.Children, n)
+		} else {
+			p.Nodes = append(p.Nodes, n)
+		}
+	}
+	p.Raw = strings.Join(details, "\n")
+	return rows.Err()
+}
+
+// planFloat reads a number of a plan, mysql quotes some of them
+func planFloat(v interface{}) float64 {
+	switch v := v.(type) {
+	case float64:
+		return v
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,176 @@
+package queries
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestExplainPostgres(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	q := &Query{
+		from:    []string{`"pilots"`},
+		dialect: &drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true},
+	}
+	AppendWhere(q, `"name" = ?`, "amelia")
+
+	raw := `[{"Plan": {"Node Type": "Hash Join", "Total Cost": 20.5, "Plan Rows": 3, "Actual Total Time": 0.25, "Actual Rows": 1,
+		"Hash Cond": "(jets.pilot_id = pilots.id)", "Plans": [
+		{"Node Type": "Seq Scan", "Relation Name": "jets", "Total Cost": 10.2, "Plan Rows": 20},
+		{"Node Type": "Index Scan", "Relation Name": "pilots", "Index Name": "pilots_name_idx", "Total Cost": 8.1, "Plan Rows": 1, "Index Cond": "(name = 'amelia'::text)"}
+	]}}]`
+	mock.ExpectQuery(`EXPLAIN \(ANALYZE, FORMAT JSON\) SELECT \* FROM "pilots" WHERE \("name" = \$1\);`).
+		WithArgs("amelia").
+		WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(driver.Value(raw)))
+
+	plan, err := q.Explain(db, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.SQL != `SELECT * FROM "pilots" WHERE ("name" = $1);` || len(plan.Args) != 1 {
+		t.Errorf("wrong query: %s %v", plan.SQL, plan.Args)
+	}
+
+	want := `Hash Join (cost=20.50 rows=3) (actual time=0.250 rows=1): (jets.pilot_id = pilots.id)
+  Seq Scan on jets (cost=10.20 rows=20)
+  Index Scan on pilots using pilots_name_idx (cost=8.10 rows=1): (name = 'amelia'::text)
+`
+	if got := plan.String(); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+	if nodes := plan.Flatten(); len(nodes) != 3 || nodes[2].Index != "pilots_name_idx" {
+		t.Errorf("wrong nodes: %v", nodes)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExplainMySQL(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	q := &Query{
+		from:    []string{"`pilots`"},
+		dialect: &drivers.Dialect{LQ: '`', RQ: '`'},
+	}
+
+	raw := `{"query_block": {"select_id": 1, "cost_info": {"query_cost": "4.50"}, "ordering_operation": {
+		"using_filesort": true, "cost_info": {"sort_cost": "3.00"}, "nested_loop": [
+		{"table": {"table_name": "pilots", "access_type": "ALL", "rows_examined_per_scan": 3, "cost_info": {"prefix_cost": "0.55"}}},
+		{"table": {"table_name": "jets", "access_type": "ref", "key": "jets_pilot_id_fk", "rows_examined_per_scan": 1,
+			"cost_info": {"prefix_cost": "1.50"}, "attached_condition": "(jets.color is not null)"}}
+	]}}}`
+	mock.ExpectQuery("EXPLAIN FORMAT=JSON SELECT \\* FROM `pilots`;").
+		WillReturnRows(sqlmock.NewRows([]string{"EXPLAIN"}).AddRow(driver.Value(raw)))
+
+	plan, err := q.Explain(db, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `query_block (cost=4.50 rows=0)
+  ordering_operation (cost=3.00 rows=0)
+    ALL on pilots (cost=0.55 rows=3)
+    ref on jets using jets_pilot_id_fk (cost=1.50 rows=1): (jets.color is not null)
+`
+	if got := plan.String(); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+
+	tree := "-> Nested loop inner join  (cost=1.60 rows=3) (actual time=0.045..0.061 rows=3 loops=1)\n" +
+		"    -> Table scan on pilots  (cost=0.55 rows=3) (actual time=0.021..0.026 rows=3 loops=1)\n" +
+		"    -> Filter: (jets.color is not null)  (cost=0.28 rows=1) (actual time=0.008..0.009 rows=1 loops=3)\n" +
+		"        -> Index lookup on jets using jets_pilot_id_fk (pilot_id=pilots.id)  (cost=0.28 rows=1) (actual time=0.006..0.008 rows=1 loops=3)\n"
+	mock.ExpectQuery("EXPLAIN ANALYZE SELECT \\* FROM `pilots`;").
+		WillReturnRows(sqlmock.NewRows([]string{"EXPLAIN"}).AddRow(driver.Value(tree)))
+
+	plan, err = q.Explain(db, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want = `Nested loop inner join (cost=1.60 rows=3) (actual time=0.061 rows=3)
+  Table scan on pilots (cost=0.55 rows=3) (actual time=0.026 rows=3)
+  Filter (cost=0.28 rows=1) (actual time=0.009 rows=1): (jets.color is not null)
+    Index lookup on jets using jets_pilot_id_fk (cost=0.28 rows=1) (actual time=0.008 rows=1)
+`
+	if got := plan.String(); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExplainSQLite(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	q := &Query{
+		from:    []string{`"pilots"`},
+		dialect: &drivers.Dialect{LQ: '"', RQ: '"', UseLastInsertID: true},
+	}
+
+	mock.ExpectQuery(`EXPLAIN QUERY PLAN SELECT \* FROM "pilots";`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "parent", "notused", "detail"}).
+			AddRow(driver.Value(int64(3)), driver.Value(int64(0)), driver.Value(int64(0)), driver.Value("SCAN pilots")).
+			AddRow(driver.Value(int64(5)), driver.Value(int64(0)), driver.Value(int64(0)), driver.Value("SEARCH jets USING INDEX jets_pilot_id_idx (pilot_id=?)")).
+			AddRow(driver.Value(int64(9)), driver.Value(int64(0)), driver.Value(int64(0)), driver.Value("USE TEMP B-TREE FOR ORDER BY")))
+
+	plan, err := q.Explain(db, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `SCAN on pilots: SCAN pilots
+SEARCH on jets using jets_pilot_id_idx: SEARCH jets USING INDEX jets_pilot_id_idx (pilot_id=?)
+USE TEMP B-TREE FOR ORDER BY
+`
+	if got := plan.String(); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+
+	if _, err := q.Explain(db, true); err == nil || !strings.Contains(err.Error(), "not supported by sqlite") {
+		t.Errorf("want an error for analyze, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExplainUnsupported(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{from: []string{"[pilots]"}, dialect: &drivers.Dialect{LQ: '[', RQ: ']', UseTopClause: true}}
+	if _, err := q.Explain(nil, false); err == nil || !strings.Contains(err.Error(), "mssql") {
+		t.Errorf("want an error for mssql, got: %v", err)
+	}
+
+	if _, err := Raw("select 1").Explain(nil, false); err == nil {
+		t.Error("want an error for a query without dialect")
+	}
+}
@@ -0,0 +1,76 @@
+//go:build go1.18
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// Finder runs the select queries of the model T of a table. The models
+// generated with generics share its code instead of repeating it per table.
+type Finder[T any] struct {
+	// Pkg is the name of the generated package and Table the name of the
+	// table, Model the name of the model, the errors name them
+	Pkg   string
+	Table string
+	Model string
+	// WrapNoRows wraps sql.ErrNoRows like the other errors instead of
+	// returning it as is
+	WrapNoRows bool
+	// AfterSelect runs the after select hooks of a model, nil without hooks.
+	// ctx is nil when the models do not use contexts.
+	AfterSelect func(ctx context.Context, exec boil.Executor, o *T) error
+}
+
+// One returns the first row of the query, ctx may be nil
+func (f Finder[T]) One(ctx context.Context, exec boil.Executor, q *Query) (*T, error) {
+	SetLimit(q, 1)
+	return f.bindOne(ctx, exec, q, "failed to execute a one query for "+f.Table)
+}
+
+// Find returns the row of a raw query selecting a row by its primary key,
+// ctx may be nil
+func (f Finder[T]) Find(ctx context.Context, exec boil.Executor, q *Query) (*T, error) {
+	return f.bindOne(ctx, exec, q, "unable to select from "+f.Table)
+}
+
+// All returns the rows of the query, ctx may be nil
+func (f Finder[T]) All(ctx context.Context, exec boil.Executor, q *Query) ([]*T, error) {
+	var o []*T
+	if err := q.Bind(ctx, exec, &o); err != nil {
+		return nil, errors.Wrapf(err, "%s: failed to assign all query results to %s slice", f.Pkg, f.Model)
+	}
+
+	if f.AfterSelect != nil {
+		for _, obj := range o {
+			if err := f.AfterSelect(ctx, exec, obj); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// bindOne binds the row of the query to a new T and runs its hooks
+func (f Finder[T]) bindOne(ctx context.Context, exec boil.Executor, q *Query, msg string) (*T, error) {
+	o := new(T)
+	if err := q.Bind(ctx, exec, o); err != nil {
+		if !f.WrapNoRows && errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrapf(err, "%s: %s", f.Pkg, msg)
+	}
+
+	if f.AfterSelect != nil {
+		if err := f.AfterSelect(ctx, exec, o); err != nil {
+			return o, err
+		}
+	}
+
+	return o, nil
+}
@@ -0,0 +1,91 @@
+//go:build go1.18
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+type finderPilot struct {
+	ID   int    `boil:"id"`
+	Name string `boil:"name"`
+}
+
+func TestFinder(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	hooked := 0
+	finder := Finder[finderPilot]{
+		Pkg:   "models",
+		Table: "pilots",
+		Model: "Pilot",
+		AfterSelect: func(ctx context.Context, exec boil.Executor, o *finderPilot) error {
+			hooked++
+			return nil
+		},
+	}
+	newQuery := func() *Query {
+		return &Query{
+			from:    []string{"pilots"},
+			dialect: &drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true},
+		}
+	}
+
+	mock.ExpectQuery(`SELECT \* FROM "pilots" LIMIT 1;`).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name"}).AddRow(driver.Value(int64(1)), driver.Value("amelia")),
+	)
+	one, err := finder.One(nil, db, newQuery())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if one.ID != 1 || one.Name != "amelia" {
+		t.Errorf("wrong pilot: %#v", one)
+	}
+
+	mock.ExpectQuery(`SELECT \* FROM "pilots";`).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(driver.Value(int64(1)), driver.Value("amelia")).
+			AddRow(driver.Value(int64(2)), driver.Value("bessie")),
+	)
+	all, err := finder.All(nil, db, newQuery())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || all[1].Name != "bessie" {
+		t.Errorf("wrong pilots: %#v", all)
+	}
+	if hooked != 3 {
+		t.Errorf("want the hooks to run 3 times, got: %d", hooked)
+	}
+
+	mock.ExpectQuery(`select \* from pilots where id = \$1`).WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	if _, err := finder.Find(nil, db, Raw("select * from pilots where id = $1", 3)); err != sql.ErrNoRows {
+		t.Errorf("want sql.ErrNoRows as is, got: %v", err)
+	}
+
+	finder.WrapNoRows = true
+	mock.ExpectQuery(`select \* from pilots where id = \$1`).WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	_, err = finder.Find(nil, db, Raw("select * from pilots where id = $1", 3))
+	if !errors.Is(err, sql.ErrNoRows) || !strings.HasPrefix(err.Error(), "models: unable to select from pilots") {
+		t.Errorf("want a wrapped sql.ErrNoRows, got: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,242 @@
+package queries
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/friendsofgo/errors"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonUnmarshalType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// AssignMapValue sets the column field dst points to from src, a value of a
+// map like the ones decoded from JSON, for the FromMap methods of the models.
+//
+// A nil src sets the zero value, the null of the nullable types, and the
+// other values set the nullable types valid. Values of the type of the field
+// are assigned as is, numbers convert to other numbers they fit in, strings
+// parse as numbers, bools and RFC 3339 times, slices convert element by
+// element, maps and slices are marshaled into the JSON columns and the
+// scanners scan the rest.
+func AssignMapValue(dst, src interface{}) error {
+	d := reflect.ValueOf(dst).Elem()
+	if src == nil {
+		d.Set(reflect.Zero(d.Type()))
+		return nil
+	}
+
+	s := reflect.ValueOf(src)
+	if s.Type().AssignableTo(d.Type()) {
+		d.Set(s)
+		return nil
+	}
+
+	if isJSONValue(s) && d.Addr().Type().Implements(jsonUnmarshalType) {
+		b, err := json.Marshal(src)
+		if err != nil {
+			return errors.Wrapf(err, "cannot marshal %T to JSON", src)
+		}
+		return d.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(b)
+	}
+
+	if isNullStruct(d) && !s.Type().ConvertibleTo(d.Type()) {
+		if err := assignMapValue(d.Field(0), s); err != nil {
+			return err
+		}
+		d.Field(1).SetBool(true)
+		return nil
+	}
+
+	return assignMapValue(d, s)
+}
+
+// assignMapValue sets d from s, a value that is not nil
+func assignMapValue(d, s reflect.Value) error {
+	if s.Kind() == reflect.Interface {
+		if s.IsNil() {
+			d.Set(reflect.Zero(d.Type()))
+			return nil
+		}
+		s = s.Elem()
+	}
+
+	switch {
+	case s.Type().AssignableTo(d.Type()):
+		d.Set(s)
+		return nil
+	case s.Kind() == d.Kind() && s.Type().ConvertibleTo(d.Type()):
+		d.Set(s.Convert(d.Type()))
+		return nil
+	case d.Kind() == reflect.Slice && d.Type().Elem().Kind() != reflect.Uint8 && s.Kind() == reflect.Slice:
+		slice := reflect.MakeSlice(d.Type(), s.Len(), s.Len())
+		for i := 0; i < s.Len(); i++ {
+			if err := assignMapValue(slice.Index(i), s.Index(i)); err != nil {
+				return errors.Wrapf(err, "element %d", i)
+			}
+		}
+		d.Set(slice)
+		return nil
+	}
+
+	if scanner, ok := d.Addr().Interface().(sql.Scanner); ok {
+		return scanner.Scan(scanValue(s))
+	}
+
+	switch d.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := mapInt(s)
+		if err == nil && d.OverflowInt(n) {
+			err = errors.Errorf("%v overflows %s", s.Interface(), d.Type())
+		}
+		if err != nil {
+			return err
+		}
+		d.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := mapInt(s)
+		if err == nil && (n < 0 || d.OverflowUint(uint64(n))) {
+			err = errors.Errorf("%v overflows %s", s.Interface(), d.Type())
+		}
+		if err != nil {
+			return err
+		}
+		d.SetUint(uint64(n))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := mapFloat(s)
+		if err != nil {
+			return err
+		}
+		d.SetFloat(f)
+		return nil
+	case reflect.Bool:
+		if s.Kind() == reflect.String {
+			b, err := strconv.ParseBool(s.String())
+			if err != nil {
+				return errors.Errorf("cannot parse %q as a bool", s.String())
+			}
+			d.SetBool(b)
+			return nil
+		}
+	case reflect.String:
+		if s.Kind() == reflect.Slice && s.Type().Elem().Kind() == reflect.Uint8 {
+			d.SetString(string(s.Bytes()))
+			return nil
+		}
+	case reflect.Slice:
+		if s.Kind() == reflect.String {
+			d.Set(reflect.ValueOf([]byte(s.String())).Convert(d.Type()))
+			return nil
+		}
+	case reflect.Struct:
+		if d.Type() == timeType && s.Kind() == reflect.String {
+			t, err := time.Parse(time.RFC3339Nano, s.String())
+			if err != nil {
+				return errors.Errorf("cannot parse %q as an RFC 3339 time", s.String())
+			}
+			d.Set(reflect.ValueOf(t))
+			return nil
+		}
+	}
+
+	return errors.Errorf("cannot assign %s to %s", s.Type(), d.Type())
+}
+
+// isJSONValue tells if the value is a map or a slice other than bytes, the
+// values decoded from JSON objects and arrays
+func isJSONValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Map:
+		return true
+	case reflect.Slice:
+		return v.Type().Elem().Kind() != reflect.Uint8
+	}
+	return false
+}
+
+// isNullStruct tells if the value is of a nullable type of a value and a
+// Valid field, like the types of the null package
+func isNullStruct(v reflect.Value) bool {
+	t := v.Type()
+	return t.Kind() == reflect.Struct && t.NumField() == 2 &&
+		t.Field(1).Name == "Valid" && t.Field(1).Type.Kind() == reflect.Bool
+}
+
+// scanValue returns the value scanners are given for s, the driver value of
+// a valuer or the widest type of its kind
+func scanValue(s reflect.Value) interface{} {
+	if valuer, ok := s.Interface().(driver.Valuer); ok {
+		if v, err := valuer.Value(); err == nil {
+			return v
+		}
+	}
+
+	switch s.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return s.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(s.Uint())
+	case reflect.Float32, reflect.Float64:
+		return s.Float()
+	case reflect.Bool:
+		return s.Bool()
+	case reflect.String:
+		return s.String()
+	}
+	return s.Interface()
+}
+
+// mapInt returns s as an integer, floats must be whole and strings must
+// parse as integers
+func mapInt(s reflect.Value) (int64, error) {
+	switch s.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return s.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if s.Uint() > math.MaxInt64 {
+			return 0, errors.Errorf("%d overflows int64", s.Uint())
+		}
+		return int64(s.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		f := s.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, errors.Errorf("%v is not an integer", f)
+		}
+		return int64(f), nil
+	case reflect.String:
+		n, err := strconv.ParseInt(s.String(), 10, 64)
+		if err != nil {
+			return 0, errors.Errorf("cannot parse %q as an integer", s.String())
+		}
+		return n, nil
+	}
+	return 0, errors.Errorf("cannot assign %s to an integer", s.Type())
+}
+
+// mapFloat returns s as a float, strings must parse as floats
+func mapFloat(s reflect.Value) (float64, error) {
+	switch s.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(s.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(s.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return s.Float(), nil
+	case reflect.String:
+		f, err := strconv.ParseFloat(s.String(), 64)
+		if err != nil {
+			return 0, errors.Errorf("cannot parse %q as a float", s.String())
+		}
+		return f, nil
+	}
+	return 0, errors.Errorf("cannot assign %s to a float", s.Type())
+}
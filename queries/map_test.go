@@ -0,0 +1,102 @@
+package queries
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+func TestAssignMapValue(t *testing.T) {
+	t.Parallel()
+
+	type color string
+
+	var obj struct {
+		ID       int
+		Age      uint8
+		Ratio    float32
+		Name     string
+		Color    color
+		Active   bool
+		Born     time.Time
+		Nick     null.String
+		Size     null.Int
+		Seen     null.Time
+		Cargo    []byte
+		Tags     types.StringArray
+		Ids      types.Int64Array
+		Details  types.JSON
+		Extra    null.JSON
+		Price    types.Decimal
+		Discount types.NullDecimal
+	}
+
+	born := time.Date(1990, 1, 2, 3, 4, 5, 0, time.UTC)
+	assign := []struct {
+		dst, src interface{}
+	}{
+		{&obj.ID, float64(7)},
+		{&obj.Age, json.Number("12")},
+		{&obj.Ratio, 0.5},
+		{&obj.Name, []byte("jet")},
+		{&obj.Color, "red"},
+		{&obj.Active, "true"},
+		{&obj.Born, born.Format(time.RFC3339)},
+		{&obj.Nick, "ace"},
+		{&obj.Size, 3},
+		{&obj.Seen, born},
+		{&obj.Cargo, "abc"},
+		{&obj.Tags, []interface{}{"a", "b"}},
+		{&obj.Ids, []interface{}{float64(1), float64(2)}},
+		{&obj.Details, map[string]interface{}{"a": 1}},
+		{&obj.Extra, []interface{}{true}},
+		{&obj.Price, "1.50"},
+		{&obj.Discount, float64(2)},
+	}
+	for _, a := range assign {
+		if err := AssignMapValue(a.dst, a.src); err != nil {
+			t.Fatalf("%T from %T: %v", a.dst, a.src, err)
+		}
+	}
+
+	if obj.ID != 7 || obj.Age != 12 || obj.Ratio != 0.5 || obj.Name != "jet" || obj.Color != "red" || !obj.Active {
+		t.Errorf("want the plain columns set, got: %+v", obj)
+	}
+	if !obj.Born.Equal(born) || obj.Nick != null.StringFrom("ace") || obj.Size != null.IntFrom(3) || !obj.Seen.Valid {
+		t.Errorf("want the times and nulls set, got: %+v", obj)
+	}
+	if string(obj.Cargo) != "abc" || len(obj.Tags) != 2 || obj.Ids[1] != 2 {
+		t.Errorf("want the slices set, got: %+v", obj)
+	}
+	if string(obj.Details) != `{"a":1}` || !obj.Extra.Valid || string(obj.Extra.JSON) != "[true]" {
+		t.Errorf("want the JSON set, got: %s %s", obj.Details, obj.Extra.JSON)
+	}
+	if obj.Price.String() != "1.50" || obj.Discount.Big == nil {
+		t.Errorf("want the decimals set, got: %v %v", obj.Price, obj.Discount)
+	}
+
+	if err := AssignMapValue(&obj.Nick, nil); err != nil || obj.Nick.Valid {
+		t.Errorf("want nil to set null, got: %v %v", obj.Nick, err)
+	}
+
+	fail := []struct {
+		dst, src interface{}
+	}{
+		{&obj.ID, 1.5},
+		{&obj.Age, 300},
+		{&obj.Age, -1},
+		{&obj.Active, "maybe"},
+		{&obj.Born, "yesterday"},
+		{&obj.Name, 5},
+		{&obj.Ids, []interface{}{"x"}},
+	}
+	for _, f := range fail {
+		if err := AssignMapValue(f.dst, f.src); err == nil {
+			t.Errorf("%T from %#v: want an error", f.dst, f.src)
+		}
+	}
+}
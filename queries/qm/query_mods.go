@@ -2,6 +2,7 @@ package qm
 
 import (
 	"strings"
+	"time"
 
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
@@ -183,16 +184,94 @@ type distinctQueryMod struct {
 
 // Apply implements QueryMod.Apply.
 func (qm distinctQueryMod) Apply(q *queries.Query) {
+	if len(qm.clause) == 0 {
+		queries.SetDistinctRows(q)
+		return
+	}
 	queries.SetDistinct(q, qm.clause)
 }
 
-// Distinct allows you to filter duplicates
-func Distinct(clause string) QueryMod {
+// Distinct allows you to filter duplicates. Without columns it selects the
+// distinct rows of the selected columns, keeping the select list, otherwise
+// it selects the distinct values of the columns in its place:
+//
+//   models.Pilots(qm.Select("name"), qm.Distinct())  // SELECT DISTINCT "name" FROM "pilots"
+//   models.Pilots(qm.Distinct("name"))               // SELECT DISTINCT name FROM "pilots"
+func Distinct(columns ...string) QueryMod {
 	return distinctQueryMod{
-		clause: clause,
+		clause: strings.Join(columns, ", "),
+	}
+}
+
+type distinctOnQueryMod struct {
+	columns []string
+}
+
+// Apply implements QueryMod.Apply.
+func (qm distinctOnQueryMod) Apply(q *queries.Query) {
+	queries.SetDistinctOn(q, qm.columns...)
+}
+
+// DistinctOn selects the first row of each distinct value of the columns, with
+// postgres' DISTINCT ON. Order by the columns first and then by what picks the
+// first row:
+//
+//   models.Jets(qm.DistinctOn("pilot_id"), qm.OrderBy("pilot_id, created_at desc"))
+func DistinctOn(columns ...string) QueryMod {
+	return distinctOnQueryMod{
+		columns: columns,
 	}
 }
 
+// Window selects a window function computed over the partition of each row
+// as the extra column named by Alias. Bind it to a struct embedding the model
+// with a field tagged with the alias:
+//
+//   type RankedJet struct {
+//     models.Jet `boil:",bind"`
+//     Rank       int `boil:"rank"`
+//   }
+//
+//   var jets []RankedJet
+//   err := models.Jets(qm.Window{
+//     Function:    "row_number()",
+//     PartitionBy: []string{"pilot_id"},
+//     OrderBy:     []string{"created_at desc"},
+//     Alias:       "rank",
+//   }).Bind(ctx, db, &jets)
+type Window struct {
+	// Function is the window function with its arguments, such as
+	// row_number() or sum(amount)
+	Function string
+	// PartitionBy and OrderBy are the expressions partitioning and ordering
+	// the rows of the window, it is the whole result without them
+	PartitionBy []string
+	OrderBy     []string
+	// Frame is the frame clause of the window, such as
+	// rows between unbounded preceding and current row
+	Frame string
+	// Alias names the column of the window function for Bind
+	Alias string
+	// Args are the arguments of the placeholders of the window
+	Args []interface{}
+}
+
+// Apply implements QueryMod.Apply.
+func (w Window) Apply(q *queries.Query) {
+	var over []string
+	if len(w.PartitionBy) != 0 {
+		over = append(over, "PARTITION BY "+strings.Join(w.PartitionBy, ", "))
+	}
+	if len(w.OrderBy) != 0 {
+		over = append(over, "ORDER BY "+strings.Join(w.OrderBy, ", "))
+	}
+	if len(w.Frame) != 0 {
+		over = append(over, w.Frame)
+	}
+
+	queries.AppendSelectExpr(q, w.Function+" OVER ("+strings.Join(over, " ")+")", w.Alias, w.Args...)
+}
+
 type withQueryMod struct {
 	clause string
 	args   []interface{}
@@ -200,10 +279,21 @@ type withQueryMod struct {
 
 // Apply implements QueryMod.Apply.
 func (qm withQueryMod) Apply(q *queries.Query) {
+	if len(qm.args) == 1 {
+		if sub, ok := qm.args[0].(*queries.Query); ok {
+			queries.AppendWithQuery(q, qm.clause, sub)
+			return
+		}
+	}
 	queries.AppendWith(q, qm.clause, qm.args...)
 }
 
-// With allows you to pass in a Common Table Expression clause (and args)
+// With allows you to pass in a Common Table Expression clause (and args), or
+// the name of one and the select query it names, whose arguments are numbered
+// with the query's:
+//
+//   qm.With("cte_0 AS (SELECT * FROM table_0 WHERE thing=?)", 3)
+//   qm.With("fast_jets", models.Jets(models.JetWhere.Speed.GT(900)).Query)
 func With(clause string, args ...interface{}) QueryMod {
 	return withQueryMod{
 		clause: clause,
@@ -211,6 +301,94 @@ func With(clause string, args ...interface{}) QueryMod {
 	}
 }
 
+type withRecursiveQueryMod struct {
+	name      string
+	base      *queries.Query
+	recursive *queries.Query
+}
+
+// Apply implements QueryMod.Apply.
+func (qm withRecursiveQueryMod) Apply(q *queries.Query) {
+	queries.AppendWithRecursive(q, qm.name, qm.base, qm.recursive)
+}
+
+// WithRecursive allows you to pass in a recursive Common Table Expression
+// named name, the rows of the base query and then those of the recursive
+// query joining the rows found so far by name, such as the ancestors of an
+// employee of a self-referential table:
+//
+//   qm.WithRecursive("chain",
+//     models.Employees(models.EmployeeWhere.ID.EQ(id)).Query,
+//     models.Employees(qm.InnerJoin("chain on employees.id = chain.manager_id")).Query,
+//   )
+//
+// Both queries must select the same columns, the recursive one can refer to
+// the common table expression only once.
+func WithRecursive(name string, base, recursive *queries.Query) QueryMod {
+	return withRecursiveQueryMod{
+		name:      name,
+		base:      base,
+		recursive: recursive,
+	}
+}
+
+type setOperationQueryMod struct {
+	operator string
+	other    *queries.Query
+}
+
+// Apply implements QueryMod.Apply.
+func (qm setOperationQueryMod) Apply(q *queries.Query) {
+	queries.AppendSetOperation(q, qm.operator, qm.other)
+}
+
+// Union combines the rows of the query with those of the other query, without
+// duplicates, in a single statement bound like the query. Both must select the
+// same columns, usually as queries of the same model, and the order by, limit
+// and offset of the query apply to the combined rows:
+//
+//   models.Pilots(
+//     models.PilotWhere.Active.EQ(true),
+//     qm.Union(models.Pilots(models.PilotWhere.UpdatedAt.GT(since)).Query),
+//     qm.OrderBy("name"),
+//   ).All(ctx, db)
+//
+// The scopes of the query, such as the tenant of a multi-tenant model, scope
+// the other query too.
+func Union(other *queries.Query) QueryMod {
+	return setOperationQueryMod{
+		operator: "UNION",
+		other:    other,
+	}
+}
+
+// UnionAll combines the rows of the query with those of the other query,
+// keeping duplicates, see Union.
+func UnionAll(other *queries.Query) QueryMod {
+	return setOperationQueryMod{
+		operator: "UNION ALL",
+		other:    other,
+	}
+}
+
+// Intersect keeps the rows of the query that the other query selects too,
+// see Union.
+func Intersect(other *queries.Query) QueryMod {
+	return setOperationQueryMod{
+		operator: "INTERSECT",
+		other:    other,
+	}
+}
+
+// Except keeps the rows of the query that the other query does not select,
+// see Union.
+func Except(other *queries.Query) QueryMod {
+	return setOperationQueryMod{
+		operator: "EXCEPT",
+		other:    other,
+	}
+}
+
 type selectQueryMod struct {
 	columns []string
 }
@@ -229,6 +407,11 @@ func Select(columns ...string) QueryMod {
 
 // Where allows you to specify a where clause for your statement. If multiple
 // Where statements are used they are combined with 'and'
+//
+// A select query as the only argument is inlined as a subquery with its
+// arguments, such as the subquery of an exists clause:
+//
+//   qm.Where("exists ?", models.Jets(qm.Select("1"), qm.Where("jets.pilot_id = pilots.id")).Query)
 func Where(clause string, args ...interface{}) QueryMod {
 	return qmhelper.WhereQueryMod{
 		Clause: clause,
@@ -306,6 +489,11 @@ func (qm whereInQueryMod) Apply(q *queries.Query) {
 
 // WhereIn allows you to specify a "x IN (set)" clause for your where statement
 // Example clauses: "column in ?", "(column1,column2) in ?"
+//
+// The set can be a select query as the only argument, which is inlined with its
+// arguments, and the in clauses of AndIn, OrIn and the not in ones too:
+//
+//   models.Pilots(qm.WhereIn("id in ?", models.Jets(qm.Select("pilot_id"), models.JetWhere.Age.GT(5)).Query))
 func WhereIn(clause string, args ...interface{}) QueryMod {
 	return whereInQueryMod{
 		clause: clause,
@@ -440,6 +628,60 @@ func (qm exprMod) Apply(q *queries.Query) {
 	queries.AppendWhereRightParen(q)
 }
 
+// The functions parsing the queries of a TextSearch, see the postgres
+// documentation of their syntaxes
+const (
+	PlainSearch  = "plainto_tsquery"
+	PhraseSearch = "phraseto_tsquery"
+	WebSearch    = "websearch_to_tsquery"
+	RawSearch    = "to_tsquery"
+)
+
+// TextSearch is a postgres full text search of a tsvector column, as a query
+// mod it matches the rows whose column matches the query and orders them by
+// their ts_rank, best first, after the order by clauses before it.
+type TextSearch struct {
+	Column string
+	Query  string
+	// Parser parses the query, PlainSearch when empty
+	Parser string
+	// Config is the text search configuration of the parser, english for
+	// example, the default_text_search_config of the database when empty
+	Config string
+	// Unranked leaves the order of the rows as it is
+	Unranked bool
+}
+
+// Apply implements QueryMod.Apply.
+func (s TextSearch) Apply(q *queries.Query) {
+	parser := s.Parser
+	if len(parser) == 0 {
+		parser = PlainSearch
+	}
+
+	tsquery := parser + "(?)"
+	args := []interface{}{s.Query}
+	if len(s.Config) != 0 {
+		tsquery = parser + "(?::regconfig, ?)"
+		args = []interface{}{s.Config, s.Query}
+	}
+
+	queries.AppendWhere(q, s.Column+" @@ "+tsquery, args...)
+	if !s.Unranked {
+		queries.AppendOrderBy(q, "ts_rank("+s.Column+", "+tsquery+") DESC", args...)
+	}
+}
+
+// Search matches the rows whose postgres tsvector column matches the full text
+// search query, parsed by plainto_tsquery, and orders them by their ts_rank,
+// best first. Use a TextSearch for the other parsers.
+func Search(column, query string) QueryMod {
+	return TextSearch{
+		Column: column,
+		Query:  query,
+	}
+}
+
 type groupByQueryMod struct {
 	clause string
 }
@@ -556,6 +798,60 @@ func For(clause string) QueryMod {
 	}
 }
 
+type usePrimaryQueryMod struct{}
+
+// Apply implements QueryMod.Apply.
+func (qm usePrimaryQueryMod) Apply(q *queries.Query) {
+	queries.SetUsePrimary(q)
+}
+
+// UsePrimary runs a read-only query and its eager loading on the primary
+// database when the executor is a boil.DBPool, for example to read rows
+// right after writing them.
+func UsePrimary() QueryMod {
+	return usePrimaryQueryMod{}
+}
+
+type cacheQueryMod struct {
+	ttl time.Duration
+}
+
+// Apply implements QueryMod.Apply.
+func (qm cacheQueryMod) Apply(q *queries.Query) {
+	queries.SetCache(q, qm.ttl)
+}
+
+// Cache keeps the result of All and One in the boil.QueryCache for ttl, and
+// returns it from the cache while it is there instead of querying the
+// database. Results are only cached when a cache is set with
+// boil.SetQueryCache. Writes do not invalidate cached results, use it for
+// tables that rarely change. Relationships given to Load are not cached.
+func Cache(ttl time.Duration) QueryMod {
+	return cacheQueryMod{
+		ttl: ttl,
+	}
+}
+
+type timeoutQueryMod struct {
+	timeout time.Duration
+}
+
+// Apply implements QueryMod.Apply.
+func (qm timeoutQueryMod) Apply(q *queries.Query) {
+	queries.SetTimeout(q, qm.timeout)
+}
+
+// Timeout limits how long the query may run, replacing the default timeout
+// set with boil.SetQueryTimeout. On postgres transactions it sets the
+// statement timeout of the transaction with SET LOCAL for the query, other
+// queries get a context deadline. Queries run without a context have no
+// timeout.
+func Timeout(timeout time.Duration) QueryMod {
+	return timeoutQueryMod{
+		timeout: timeout,
+	}
+}
+
 type commentQueryMod struct {
 	comment string
 }
@@ -0,0 +1,324 @@
+package qm
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+func TestTextSearch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		mods  []QueryMod
+		query string
+		args  []interface{}
+	}{
+		{
+			mods:  []QueryMod{Search(`"body"`, "fat cats")},
+			query: `SELECT * FROM "documents" WHERE ("body" @@ plainto_tsquery($1)) ORDER BY ts_rank("body", plainto_tsquery($2)) DESC;`,
+			args:  []interface{}{"fat cats", "fat cats"},
+		},
+		{
+			mods:  []QueryMod{OrderBy("id"), TextSearch{Column: `"body"`, Query: `"fat cats" -dogs`, Parser: WebSearch, Config: "english"}},
+			query: `SELECT * FROM "documents" WHERE ("body" @@ websearch_to_tsquery($1::regconfig, $2)) ORDER BY id, ts_rank("body", websearch_to_tsquery($3::regconfig, $4)) DESC;`,
+			args:  []interface{}{"english", `"fat cats" -dogs`, "english", `"fat cats" -dogs`},
+		},
+		{
+			mods:  []QueryMod{Where("id > ?", 1), TextSearch{Column: `"body"`, Query: "fat & cat", Parser: RawSearch, Unranked: true}},
+			query: `SELECT * FROM "documents" WHERE (id > $1) AND ("body" @@ to_tsquery($2));`,
+			args:  []interface{}{1, "fat & cat"},
+		},
+	}
+
+	for i, test := range tests {
+		q := &queries.Query{}
+		queries.SetDialect(q, &drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true})
+		queries.SetFrom(q, `"documents"`)
+		Apply(q, test.mods...)
+
+		query, args := queries.BuildQuery(q)
+		if query != test.query {
+			t.Errorf("%d: want query:\n%s\ngot:\n%s", i, test.query, query)
+		}
+		if !reflect.DeepEqual(args, test.args) {
+			t.Errorf("%d: want args %v, got %v", i, test.args, args)
+		}
+	}
+}
+
+func TestDistinctAndWindow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		mods  []QueryMod
+		count bool
+		query string
+		args  []interface{}
+	}{
+		{
+			mods:  []QueryMod{Distinct("name")},
+			query: `SELECT DISTINCT name FROM "jets";`,
+		},
+		{
+			mods:  []QueryMod{Select("name", "color"), Distinct()},
+			query: `SELECT DISTINCT "name", "color" FROM "jets";`,
+		},
+		{
+			mods:  []QueryMod{DistinctOn("pilot_id"), OrderBy("pilot_id, created_at desc")},
+			query: `SELECT DISTINCT ON ("pilot_id") * FROM "jets" ORDER BY pilot_id, created_at desc;`,
+		},
+		{
+			mods:  []QueryMod{With("recent AS (SELECT * FROM jets WHERE age < ?)", 3), Where("color = ?", "red"), DistinctOn("pilot_id")},
+			count: true,
+			query: `SELECT COUNT(*) FROM (WITH recent AS (SELECT * FROM jets WHERE age < $1) SELECT DISTINCT ON ("pilot_id") * FROM "jets" WHERE (color = $2)) AS "counted_rows";`,
+			args:  []interface{}{3, "red"},
+		},
+		{
+			mods: []QueryMod{
+				Where("color = ?", "red"),
+				Window{Function: "row_number()", PartitionBy: []string{"pilot_id"}, OrderBy: []string{"created_at desc"}, Alias: "rank"},
+				Window{Function: "sum(age) filter (where age > ?)", Frame: "rows between unbounded preceding and current row", Alias: "total", Args: []interface{}{1}},
+			},
+			query: `SELECT *, row_number() OVER (PARTITION BY pilot_id ORDER BY created_at desc) AS "rank", sum(age) filter (where age > $1) OVER (rows between unbounded preceding and current row) AS "total" FROM "jets" WHERE (color = $2);`,
+			args:  []interface{}{1, "red"},
+		},
+		{
+			mods:  []QueryMod{Window{Function: "count(*)", Alias: "total"}},
+			count: true,
+			query: `SELECT COUNT(*) FROM "jets";`,
+		},
+		{
+			mods:  []QueryMod{Select("name"), Distinct()},
+			count: true,
+			query: `SELECT COUNT(*) FROM (SELECT DISTINCT "name" FROM "jets") AS "counted_rows";`,
+		},
+		{
+			mods:  []QueryMod{Select("name"), Where("color = ?", "red")},
+			count: true,
+			query: `SELECT COUNT(*) FROM "jets" WHERE (color = $1);`,
+			args:  []interface{}{"red"},
+		},
+	}
+
+	for i, test := range tests {
+		q := &queries.Query{}
+		queries.SetDialect(q, &drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true})
+		queries.SetFrom(q, `"jets"`)
+		Apply(q, test.mods...)
+		if test.count {
+			queries.SetCountRows(q)
+		}
+
+		query, args := queries.BuildQuery(q)
+		if query != test.query {
+			t.Errorf("%d: want query:\n%s\ngot:\n%s", i, test.query, query)
+		}
+		if !reflect.DeepEqual(args, test.args) {
+			t.Errorf("%d: want args %v, got %v", i, test.args, args)
+		}
+	}
+}
+
+func TestWithQuery(t *testing.T) {
+	t.Parallel()
+
+	newQuery := func(from string, mods ...QueryMod) *queries.Query {
+		q := &queries.Query{}
+		queries.SetDialect(q, &drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true})
+		queries.SetFrom(q, from)
+		Apply(q, mods...)
+		return q
+	}
+
+	tests := []struct {
+		mods  []QueryMod
+		query string
+		args  []interface{}
+	}{
+		{
+			mods: []QueryMod{
+				With("cte_0 AS (SELECT * FROM table_0 WHERE thing=?)", 1),
+				With("fast", newQuery("jets", Where("speed > ?", 2), WhereIn("color in ?", "red", "blue"))),
+				Where("age < ?", 4),
+			},
+			query: `WITH cte_0 AS (SELECT * FROM table_0 WHERE thing=$1), fast AS (SELECT * FROM "jets" WHERE (speed > $2) AND ("color" IN ($3,$4))) SELECT * FROM "employees" WHERE (age < $5);`,
+			args:  []interface{}{1, 2, "red", "blue", 4},
+		},
+		{
+			mods: []QueryMod{
+				WithRecursive("chain",
+					newQuery("employees", Where("id = ?", 7)),
+					newQuery("employees", InnerJoin("chain on employees.id = chain.manager_id"), Where("employees.active = ?", true)),
+				),
+				Where("id in (select id from chain) and age > ?", 30),
+			},
+			query: `WITH RECURSIVE chain AS (SELECT * FROM "employees" WHERE (id = $1) UNION ALL SELECT "employees".* FROM "employees" INNER JOIN chain on employees.id = chain.manager_id WHERE (employees.active = $2)) SELECT * FROM "employees" WHERE (id in (select id from chain) and age > $3);`,
+			args:  []interface{}{7, true, 30},
+		},
+	}
+
+	for i, test := range tests {
+		q := newQuery("employees", test.mods...)
+
+		query, args := queries.BuildQuery(q)
+		if query != test.query {
+			t.Errorf("%d: want query:\n%s\ngot:\n%s", i, test.query, query)
+		}
+		if !reflect.DeepEqual(args, test.args) {
+			t.Errorf("%d: want args %v, got %v", i, test.args, args)
+		}
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	t.Parallel()
+
+	newQuery := func(mods ...QueryMod) *queries.Query {
+		q := &queries.Query{}
+		queries.SetDialect(q, &drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true})
+		queries.SetFrom(q, `"pilots"`)
+		Apply(q, mods...)
+		return q
+	}
+
+	jets := &queries.Query{}
+	queries.SetDialect(jets, &drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true})
+	queries.SetFrom(jets, `"jets"`)
+	Apply(jets, Select("name"))
+
+	tests := []struct {
+		mods  []QueryMod
+		scope bool
+		count bool
+		query string
+		args  []interface{}
+	}{
+		{
+			mods: []QueryMod{
+				Where("active = ?", true),
+				Union(newQuery(Where("updated_at > ?", 1))),
+				OrderBy("name"),
+				Limit(5),
+			},
+			query: `SELECT * FROM "pilots" WHERE (active = $1) UNION SELECT * FROM "pilots" WHERE (updated_at > $2) ORDER BY name LIMIT 5;`,
+			args:  []interface{}{true, 1},
+		},
+		{
+			mods: []QueryMod{
+				UnionAll(newQuery(Where("a = ?", 1))),
+				Intersect(newQuery(WhereIn("b in ?", 2, 3))),
+				Except(newQuery(Where("c = ?", 4))),
+			},
+			scope: true,
+			query: `SELECT * FROM "pilots" WHERE ("tenant_id" = $1) UNION ALL SELECT * FROM "pilots" WHERE ((a = $2)) AND ("tenant_id" = $3) INTERSECT SELECT * FROM "pilots" WHERE (("b" IN ($4,$5))) AND ("tenant_id" = $6) EXCEPT SELECT * FROM "pilots" WHERE ((c = $7)) AND ("tenant_id" = $8);`,
+			args:  []interface{}{9, 1, 9, 2, 3, 9, 4, 9},
+		},
+		{
+			mods:  []QueryMod{Where("active = ?", true), Union(newQuery(Where("updated_at > ?", 1)))},
+			count: true,
+			query: `SELECT COUNT(*) FROM (SELECT * FROM "pilots" WHERE (active = $1) UNION SELECT * FROM "pilots" WHERE (updated_at > $2)) AS "counted_rows";`,
+			args:  []interface{}{true, 1},
+		},
+		{
+			mods:  []QueryMod{Select("name"), Union(jets)},
+			count: true,
+			query: `SELECT COUNT(*) FROM (SELECT "name" FROM "pilots" UNION SELECT "name" FROM "jets") AS "counted_rows";`,
+		},
+	}
+
+	for i, test := range tests {
+		q := newQuery(test.mods...)
+		if test.scope {
+			queries.SetScope(q, "tenant", `"tenant_id" = ?`, 9)
+		}
+		if test.count {
+			queries.SetCountRows(q)
+		}
+
+		query, args := queries.BuildQuery(q)
+		if query != test.query {
+			t.Errorf("%d: want query:\n%s\ngot:\n%s", i, test.query, query)
+		}
+		if !reflect.DeepEqual(args, test.args) {
+			t.Errorf("%d: want args %v, got %v", i, test.args, args)
+		}
+	}
+}
+
+func TestWhereInSubquery(t *testing.T) {
+	t.Parallel()
+
+	newQuery := func(from string, mods ...QueryMod) *queries.Query {
+		q := &queries.Query{}
+		queries.SetDialect(q, &drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true})
+		queries.SetFrom(q, from)
+		Apply(q, mods...)
+		return q
+	}
+
+	tests := []struct {
+		mods  []QueryMod
+		query string
+		args  []interface{}
+	}{
+		{
+			mods: []QueryMod{
+				Where("active = ?", true),
+				WhereIn("id in ?", newQuery(`"jets"`, Select("pilot_id"), Where("age > ?", 5), WhereIn("color in ?", "red", "blue"))),
+				AndIn("name in ?", "a", "b"),
+			},
+			query: `SELECT * FROM "pilots" WHERE (active = $1) AND (id in (SELECT "pilot_id" FROM "jets" WHERE (age > $2) AND ("color" IN ($3,$4)))) AND ("name" IN ($5,$6));`,
+			args:  []interface{}{true, 5, "red", "blue", "a", "b"},
+		},
+		{
+			mods: []QueryMod{
+				WhereNotIn("(id, name) not in ?", newQuery(`"jets"`, Select("pilot_id", "name"), Where("jets.pilot_id = pilots.id and age > ?", 1))),
+				OrIn("id in ?", 2),
+			},
+			query: `SELECT * FROM "pilots" WHERE ((id, name) not in (SELECT "pilot_id", "name" FROM "jets" WHERE (jets.pilot_id = pilots.id and age > $1))) OR ("id" IN ($2));`,
+			args:  []interface{}{1, 2},
+		},
+	}
+
+	for i, test := range tests {
+		q := newQuery(`"pilots"`, test.mods...)
+
+		query, args := queries.BuildQuery(q)
+		if query != test.query {
+			t.Errorf("%d: want query:\n%s\ngot:\n%s", i, test.query, query)
+		}
+		if !reflect.DeepEqual(args, test.args) {
+			t.Errorf("%d: want args %v, got %v", i, test.args, args)
+		}
+	}
+}
+
+func TestWhereSubquery(t *testing.T) {
+	t.Parallel()
+
+	newQuery := func(from string, mods ...QueryMod) *queries.Query {
+		q := &queries.Query{}
+		queries.SetDialect(q, &drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true})
+		queries.SetFrom(q, from)
+		Apply(q, mods...)
+		return q
+	}
+
+	q := newQuery(`"pilots"`,
+		Where("active = ?", true),
+		Where("exists ?", newQuery(`"jets"`, Select("1"), Where("jets.pilot_id = pilots.id"), Where("age > ?", 5))),
+		Or("not exists ?", newQuery(`"licenses"`, Select("1"), Where("licenses.pilot_id = pilots.id"))),
+	)
+
+	want := `SELECT * FROM "pilots" WHERE (active = $1) AND (exists (SELECT 1 FROM "jets" WHERE (jets.pilot_id = pilots.id) AND (age > $2))) OR (not exists (SELECT 1 FROM "licenses" WHERE (licenses.pilot_id = pilots.id)));`
+	query, args := queries.BuildQuery(q)
+	if query != want {
+		t.Errorf("want query:\n%s\ngot:\n%s", want, query)
+	}
+	if wantArgs := []interface{}{true, 5}; !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("want args %v, got %v", wantArgs, args)
+	}
+}
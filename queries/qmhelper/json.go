@@ -0,0 +1,138 @@
+package qmhelper
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// jsonArg is an argument marshaled to JSON when the query runs, so that the
+// query returns the errors of marshaling it
+type jsonArg struct {
+	value interface{}
+}
+
+// Value implements driver.Valuer, []byte values are JSON already
+func (a jsonArg) Value() (driver.Value, error) {
+	if b, ok := a.value.([]byte); ok {
+		return string(b), nil
+	}
+
+	b, err := json.Marshal(a.value)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// textArray returns a text array of placeholders for the values, and the
+// values as arguments
+func textArray(values []string) (string, []interface{}) {
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return "array[" + strings.TrimSuffix(strings.Repeat("?,", len(values)), ",") + "]::text[]", args
+}
+
+// WhereJSONContains matches the rows whose postgres jsonb column contains the
+// value marshaled to JSON, with @>
+func WhereJSONContains(name string, value interface{}) WhereQueryMod {
+	return WhereQueryMod{
+		Clause: fmt.Sprintf("%s @> ?::jsonb", name),
+		Args:   []interface{}{jsonArg{value}},
+	}
+}
+
+// WhereJSONContainedBy matches the rows whose postgres jsonb column is
+// contained by the value marshaled to JSON, with <@
+func WhereJSONContainedBy(name string, value interface{}) WhereQueryMod {
+	return WhereQueryMod{
+		Clause: fmt.Sprintf("%s <@ ?::jsonb", name),
+		Args:   []interface{}{jsonArg{value}},
+	}
+}
+
+// WhereJSONHasKey matches the rows whose postgres jsonb column has the key at
+// its top level, or the string in its top level array, with ?
+func WhereJSONHasKey(name, key string) WhereQueryMod {
+	return WhereQueryMod{
+		Clause: fmt.Sprintf(`%s \? ?`, name),
+		Args:   []interface{}{key},
+	}
+}
+
+// WhereJSONHasAnyKey matches the rows whose postgres jsonb column has any of
+// the keys at its top level, with ?|
+func WhereJSONHasAnyKey(name string, keys []string) WhereQueryMod {
+	array, args := textArray(keys)
+	return WhereQueryMod{
+		Clause: fmt.Sprintf(`%s \?| %s`, name, array),
+		Args:   args,
+	}
+}
+
+// WhereJSONHasAllKeys matches the rows whose postgres jsonb column has all of
+// the keys at its top level, with ?&
+func WhereJSONHasAllKeys(name string, keys []string) WhereQueryMod {
+	array, args := textArray(keys)
+	return WhereQueryMod{
+		Clause: fmt.Sprintf(`%s \?& %s`, name, array),
+		Args:   args,
+	}
+}
+
+// WhereJSONPath compares the text at the path of a postgres json or jsonb
+// column, extracted with #>>, to the value. The path has the keys of objects
+// and the indexes of arrays.
+func WhereJSONPath(name string, path []string, operator operator, value interface{}) WhereQueryMod {
+	array, args := textArray(path)
+	return WhereQueryMod{
+		Clause: fmt.Sprintf("%s #>> %s %s ?", name, array, string(operator)),
+		Args:   append(args, value),
+	}
+}
+
+// WhereJSONPathIn matches the rows whose text at the path of a postgres json or
+// jsonb column, extracted with #>>, is one of the values, or none of them when
+// negated
+func WhereJSONPathIn(name string, path []string, negated bool, values []string) WhereQueryMod {
+	array, args := textArray(path)
+	in, valueArgs := textArray(values)
+
+	clause := "%s #>> %s = any(%s)"
+	if negated {
+		clause = "%s #>> %s != all(%s)"
+	}
+	return WhereQueryMod{
+		Clause: fmt.Sprintf(clause, name, array, in),
+		Args:   append(args, valueArgs...),
+	}
+}
+
+// WhereJSONPathIsNull matches the rows whose postgres json or jsonb column has
+// nothing or a JSON null at the path, or whose column is null
+func WhereJSONPathIsNull(name string, path []string, negated bool) WhereQueryMod {
+	array, args := textArray(path)
+
+	var not string
+	if negated {
+		not = "not "
+	}
+	return WhereQueryMod{
+		Clause: fmt.Sprintf("%s #>> %s is %snull", name, array, not),
+		Args:   args,
+	}
+}
+
+// WhereJSONPathContains matches the rows whose postgres jsonb column has a value
+// containing the value marshaled to JSON at the path, extracted with #> and
+// compared with @>
+func WhereJSONPathContains(name string, path []string, value interface{}) WhereQueryMod {
+	array, args := textArray(path)
+	return WhereQueryMod{
+		Clause: fmt.Sprintf("%s #> %s @> ?::jsonb", name, array),
+		Args:   append(args, jsonArg{value}),
+	}
+}
@@ -0,0 +1,99 @@
+package qmhelper
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+func TestWhereJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		mod   WhereQueryMod
+		where string
+		args  []interface{}
+	}{
+		{
+			WhereJSONContains(`"attrs"`, map[string]int{"size": 2}),
+			`"attrs" @> $1::jsonb`,
+			[]interface{}{`{"size":2}`},
+		},
+		{
+			WhereJSONContainedBy(`"attrs"`, []byte(`{"size":2}`)),
+			`"attrs" <@ $1::jsonb`,
+			[]interface{}{`{"size":2}`},
+		},
+		{
+			WhereJSONHasKey(`"attrs"`, "size"),
+			`"attrs" ? $1`,
+			[]interface{}{"size"},
+		},
+		{
+			WhereJSONHasAnyKey(`"attrs"`, []string{"size", "color"}),
+			`"attrs" ?| array[$1,$2]::text[]`,
+			[]interface{}{"size", "color"},
+		},
+		{
+			WhereJSONHasAllKeys(`"attrs"`, nil),
+			`"attrs" ?& array[]::text[]`,
+			nil,
+		},
+		{
+			WhereJSONPath(`"attrs"`, []string{"address", "city"}, EQ, "Paris"),
+			`"attrs" #>> array[$1,$2]::text[] = $3`,
+			[]interface{}{"address", "city", "Paris"},
+		},
+		{
+			WhereJSONPathIn(`"attrs"`, []string{"tags", "0"}, true, []string{"a", "b"}),
+			`"attrs" #>> array[$1,$2]::text[] != all(array[$3,$4]::text[])`,
+			[]interface{}{"tags", "0", "a", "b"},
+		},
+		{
+			WhereJSONPathIsNull(`"attrs"`, []string{"color"}, false),
+			`"attrs" #>> array[$1]::text[] is null`,
+			[]interface{}{"color"},
+		},
+		{
+			WhereJSONPathContains(`"attrs"`, []string{"tags"}, []string{"a"}),
+			`"attrs" #> array[$1]::text[] @> $2::jsonb`,
+			[]interface{}{"tags", `["a"]`},
+		},
+	}
+
+	for i, test := range tests {
+		q := &queries.Query{}
+		queries.SetDialect(q, &drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true})
+		queries.SetFrom(q, "things")
+		test.mod.Apply(q)
+
+		query, args := queries.BuildQuery(q)
+		if want := `SELECT * FROM "things" WHERE (` + test.where + ");"; query != want {
+			t.Errorf("%d: want query:\n%s\ngot:\n%s", i, want, query)
+		}
+
+		for j, arg := range args {
+			if valuer, ok := arg.(driver.Valuer); ok {
+				v, err := valuer.Value()
+				if err != nil {
+					t.Fatal(err)
+				}
+				args[j] = v
+			}
+		}
+		if !reflect.DeepEqual(args, test.args) {
+			t.Errorf("%d: want args %v, got %v", i, test.args, args)
+		}
+	}
+}
+
+func TestJSONArgError(t *testing.T) {
+	t.Parallel()
+
+	if _, err := (jsonArg{make(chan int)}).Value(); err == nil {
+		t.Error("want an error marshaling a channel")
+	}
+}
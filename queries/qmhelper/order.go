@@ -0,0 +1,75 @@
+package qmhelper
+
+import (
+	"fmt"
+
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// nullsOrder is where an order puts the nulls
+type nullsOrder string
+
+// Supported nulls orders
+const (
+	nullsDefault nullsOrder = ""
+	nullsFirst   nullsOrder = "FIRST"
+	nullsLast    nullsOrder = "LAST"
+)
+
+// Order is the typed order by clause of a column, the order helpers of the
+// generated models hold one per column. The orders of a query apply in the
+// order of their query mods.
+type Order struct {
+	Column     string
+	Descending bool
+	Nulls      nullsOrder
+}
+
+// Asc orders the column in ascending order.
+func (o Order) Asc() Order {
+	o.Descending = false
+	return o
+}
+
+// Desc orders the column in descending order.
+func (o Order) Desc() Order {
+	o.Descending = true
+	return o
+}
+
+// NullsFirst orders the nulls of the column before its other values.
+func (o Order) NullsFirst() Order {
+	o.Nulls = nullsFirst
+	return o
+}
+
+// NullsLast orders the nulls of the column after its other values.
+func (o Order) NullsLast() Order {
+	o.Nulls = nullsLast
+	return o
+}
+
+// Apply implements QueryMod.Apply. Dialects without NULLS FIRST and NULLS LAST
+// order the nulls with a CASE expression before the column.
+func (o Order) Apply(q *queries.Query) {
+	direction := "ASC"
+	if o.Descending {
+		direction = "DESC"
+	}
+
+	if o.Nulls == nullsDefault {
+		queries.AppendOrderBy(q, fmt.Sprintf("%s %s", o.Column, direction))
+		return
+	}
+
+	if dialect := queries.GetDialect(q); dialect == nil || dialect.UseNullsOrderClause {
+		queries.AppendOrderBy(q, fmt.Sprintf("%s %s NULLS %s", o.Column, direction, o.Nulls))
+		return
+	}
+
+	first, rest := 0, 1
+	if o.Nulls == nullsLast {
+		first, rest = 1, 0
+	}
+	queries.AppendOrderBy(q, fmt.Sprintf("CASE WHEN %s IS NULL THEN %d ELSE %d END, %s %s", o.Column, first, rest, o.Column, direction))
+}
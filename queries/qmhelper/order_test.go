@@ -0,0 +1,51 @@
+package qmhelper
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+func TestOrder(t *testing.T) {
+	t.Parallel()
+
+	name := Order{Column: `"pilots"."name"`}
+	age := Order{Column: `"pilots"."age"`}
+
+	tests := []struct {
+		dialect drivers.Dialect
+		orders  []Order
+		query   string
+	}{
+		{
+			drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true, UseNullsOrderClause: true},
+			[]Order{name, age.Desc()},
+			`SELECT * FROM "pilots" ORDER BY "pilots"."name" ASC, "pilots"."age" DESC;`,
+		},
+		{
+			drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true, UseNullsOrderClause: true},
+			[]Order{age.Desc().NullsLast(), name.NullsFirst().Desc().Asc()},
+			`SELECT * FROM "pilots" ORDER BY "pilots"."age" DESC NULLS LAST, "pilots"."name" ASC NULLS FIRST;`,
+		},
+		{
+			drivers.Dialect{LQ: '"', RQ: '"'},
+			[]Order{age.Desc().NullsLast(), name.NullsFirst()},
+			`SELECT * FROM "pilots" ORDER BY CASE WHEN "pilots"."age" IS NULL THEN 1 ELSE 0 END, "pilots"."age" DESC, CASE WHEN "pilots"."name" IS NULL THEN 0 ELSE 1 END, "pilots"."name" ASC;`,
+		},
+	}
+
+	for i, test := range tests {
+		dialect := test.dialect
+		q := &queries.Query{}
+		queries.SetDialect(q, &dialect)
+		queries.SetFrom(q, `"pilots"`)
+		for _, o := range test.orders {
+			o.Apply(q)
+		}
+
+		if query, _ := queries.BuildQuery(q); query != test.query {
+			t.Errorf("%d: want query:\n%s\ngot:\n%s", i, test.query, query)
+		}
+	}
+}
@@ -73,6 +73,9 @@ const (
 	LTE operator = "<="
 	GT  operator = ">"
 	GTE operator = ">="
+
+	LIKE  operator = "LIKE"
+	NLIKE operator = "NOT LIKE"
 )
 
 // Where is a helper for doing operations on primitive types
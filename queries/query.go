@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/drivers"
@@ -30,14 +31,20 @@ type Query struct {
 	load     []string
 	loadMods map[string]Applicator
 
+	// withRecursive makes the common table expressions recursive
+	withRecursive bool
+
 	delete     bool
 	update     map[string]interface{}
+	insertInto string
+	insertCols []string
 	withs      []argClause
 	selectCols []string
 	count      bool
 	from       []string
 	joins      []join
 	where      []where
+	scopes     []scope
 	groupBy    []string
 	orderBy    []argClause
 	having     []argClause
@@ -47,6 +54,31 @@ type Query struct {
 	distinct   string
 	comment    string
 
+	// distinctRows selects the distinct rows of the selected columns and
+	// distinctOn the first row of each distinct value of its columns,
+	// unlike distinct they keep the select list
+	distinctRows bool
+	distinctOn   []string
+
+	// selectExprs are selected after the columns, their aliases name them
+	// for Bind
+	selectExprs []selectExpr
+
+	// setOps combine the rows of the query with those of other queries
+	setOps []setOperation
+
+	// cacheTTL is how long the query's result is kept in the
+	// boil.QueryCache, it is not cached if zero
+	cacheTTL time.Duration
+
+	// timeout is how long the query may run, boil's default timeout is
+	// used if zero
+	timeout time.Duration
+
+	// usePrimary keeps read-only queries on the primary when the executor
+	// routes them to replicas
+	usePrimary bool
+
 	// This field is a hack to allow a query to strip out the reference
 	// to deleted at is null.
 	removeSoftDelete bool
@@ -88,6 +120,26 @@ type argClause struct {
 	args   []interface{}
 }
 
+// selectExpr is an expression selected as the column named by its alias
+type selectExpr struct {
+	argClause
+	alias string
+}
+
+// setOperation combines the rows of a query with those of another query with
+// its operator, such as UNION
+type setOperation struct {
+	operator string
+	query    *Query
+}
+
+// scope is a where clause and-ed with all of the other where clauses of a
+// query, by name
+type scope struct {
+	name string
+	argClause
+}
+
 type rawSQL struct {
 	sql  string
 	args []interface{}
@@ -117,64 +169,61 @@ func RawG(query string, args ...interface{}) *Query {
 // Exec executes a query that does not need a row returned
 func (q *Query) Exec(exec boil.Executor) (sql.Result, error) {
 	qs, args := BuildQuery(q)
-	if boil.DebugMode {
-		fmt.Fprintln(boil.DebugWriter, qs)
-		fmt.Fprintln(boil.DebugWriter, args)
-	}
-	return exec.Exec(qs, args...)
+	return boil.DebugExec(exec, qs, args...)
 }
 
 // QueryRow executes the query for the One finisher and returns a row
 func (q *Query) QueryRow(exec boil.Executor) *sql.Row {
 	qs, args := BuildQuery(q)
-	if boil.DebugMode {
-		fmt.Fprintln(boil.DebugWriter, qs)
-		fmt.Fprintln(boil.DebugWriter, args)
-	}
-	return exec.QueryRow(qs, args...)
+	return boil.DebugQueryRow(q.route(exec), qs, args...)
 }
 
 // Query executes the query for the All finisher and returns multiple rows
 func (q *Query) Query(exec boil.Executor) (*sql.Rows, error) {
 	qs, args := BuildQuery(q)
-	if boil.DebugMode {
-		fmt.Fprintln(boil.DebugWriter, qs)
-		fmt.Fprintln(boil.DebugWriter, args)
-	}
-	return exec.Query(qs, args...)
+	return boil.DebugQuery(q.route(exec), qs, args...)
 }
 
-// ExecContext executes a query that does not need a row returned
+// ExecContext executes a query that does not need a row returned, within
+// the timeout of the query
 func (q *Query) ExecContext(ctx context.Context, exec boil.ContextExecutor) (sql.Result, error) {
-	qs, args := BuildQuery(q)
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, qs)
-		fmt.Fprintln(writer, args)
+	ctx, done, err := q.WithTimeout(ctx, exec)
+	if err != nil {
+		return nil, err
 	}
-	return exec.ExecContext(ctx, qs, args...)
+	defer done()
+
+	qs, args := BuildQuery(q)
+	return boil.DebugExecContext(ctx, exec, qs, args...)
 }
 
 // QueryRowContext executes the query for the One finisher and returns a row
 func (q *Query) QueryRowContext(ctx context.Context, exec boil.ContextExecutor) *sql.Row {
 	qs, args := BuildQuery(q)
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, qs)
-		fmt.Fprintln(writer, args)
-	}
-	return exec.QueryRowContext(ctx, qs, args...)
+	return boil.DebugQueryRowContext(ctx, q.routeContext(exec), qs, args...)
 }
 
 // QueryContext executes the query for the All finisher and returns multiple rows
 func (q *Query) QueryContext(ctx context.Context, exec boil.ContextExecutor) (*sql.Rows, error) {
 	qs, args := BuildQuery(q)
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, qs)
-		fmt.Fprintln(writer, args)
+	return boil.DebugQueryContext(ctx, q.routeContext(exec), qs, args...)
+}
+
+// route picks the executor a query runs on when exec sends reads and writes
+// to different connections. Only queries that are built to select rows and
+// do not lock them go to a replica, raw queries may write.
+func (q *Query) route(exec boil.Executor) boil.Executor {
+	if q.usePrimary || len(q.rawSQL.sql) != 0 || q.delete || len(q.update) != 0 || len(q.insertInto) != 0 || len(q.forlock) != 0 {
+		return boil.RouteWrite(exec)
 	}
-	return exec.QueryContext(ctx, qs, args...)
+	return boil.RouteRead(exec)
+}
+
+func (q *Query) routeContext(exec boil.ContextExecutor) boil.ContextExecutor {
+	if routed, ok := q.route(exec).(boil.ContextExecutor); ok {
+		return routed
+	}
+	return exec
 }
 
 // ExecP executes a query that does not need a row returned
@@ -204,6 +253,11 @@ func SetDialect(q *Query, dialect *drivers.Dialect) {
 	q.dialect = dialect
 }
 
+// GetDialect from the query
+func GetDialect(q *Query) *drivers.Dialect {
+	return q.dialect
+}
+
 // SetSQL on the query.
 func SetSQL(q *Query, sql string, args ...interface{}) {
 	q.rawSQL = rawSQL{sql: sql, args: args}
@@ -251,16 +305,57 @@ func SetDistinct(q *Query, distinct string) {
 	q.distinct = distinct
 }
 
+// SetDistinctRows makes the query select only the distinct rows of its
+// columns.
+func SetDistinctRows(q *Query) {
+	q.distinctRows = true
+}
+
+// SetDistinctOn makes the query select the first row of each distinct value
+// of the columns, with postgres' DISTINCT ON.
+func SetDistinctOn(q *Query, columns ...string) {
+	q.distinctOn = append([]string(nil), columns...)
+}
+
 // SetCount on the query.
 func SetCount(q *Query) {
 	q.count = true
 }
 
+// SetCountRows makes the query count the rows it selects, as the Count of the
+// models does. Its select list is dropped, unless the query selects distinct
+// rows of it or combines them with the rows of other queries, which are
+// counted as a subquery keeping it.
+func SetCountRows(q *Query) {
+	if !q.countsSubquery() {
+		q.selectCols = nil
+	}
+	q.count = true
+}
+
 // SetDelete on the query.
 func SetDelete(q *Query) {
 	q.delete = true
 }
 
+// SetInsertInto makes the query insert the rows it selects into the table,
+// into the columns when some are given, with INSERT INTO ... SELECT.
+func SetInsertInto(q *Query, table string, columns ...string) {
+	q.insertInto = table
+	q.insertCols = append([]string(nil), columns...)
+}
+
+// SetUsePrimary makes the query run on the primary when the executor
+// routes read-only queries to replicas.
+func SetUsePrimary(q *Query) {
+	q.usePrimary = true
+}
+
+// SetCache makes the query's result be kept in the boil.QueryCache for ttl.
+func SetCache(q *Query, ttl time.Duration) {
+	q.cacheTTL = ttl
+}
+
 // SetLimit on the query.
 func SetLimit(q *Query, limit int) {
 	q.limit = &limit
@@ -291,6 +386,20 @@ func AppendSelect(q *Query, columns ...string) {
 	q.selectCols = append(q.selectCols, columns...)
 }
 
+// AppendSelectExpr selects the expression after the columns of the query, as
+// the column named by the alias.
+func AppendSelectExpr(q *Query, expr, alias string, args ...interface{}) {
+	q.selectExprs = append(q.selectExprs, selectExpr{argClause: argClause{clause: expr, args: args}, alias: alias})
+}
+
+// AppendSetOperation combines the rows of the query with those of the other
+// select query with the operator, one of UNION, UNION ALL, INTERSECT or EXCEPT.
+// The other query is built with the query, and the scopes of the query scope
+// it too.
+func AppendSetOperation(q *Query, operator string, other *Query) {
+	q.setOps = append(q.setOps, setOperation{operator: operator, query: other})
+}
+
 // AppendFrom on the query.
 func AppendFrom(q *Query, from ...string) {
 	q.from = append(q.from, from...)
@@ -331,6 +440,21 @@ func AppendWhere(q *Query, clause string, args ...interface{}) {
 	q.where = append(q.where, where{clause: clause, args: args})
 }
 
+// SetScope on the query. The where clause is and-ed with all of the other
+// where clauses of the query rather than the last of them, so that clauses
+// or-ed with them cannot select rows outside of it, which makes it fit to
+// restrict every row of the query, to a tenant for example. It replaces the
+// scope of the same name.
+func SetScope(q *Query, name, clause string, args ...interface{}) {
+	for i, s := range q.scopes {
+		if s.name == name {
+			q.scopes[i].argClause = argClause{clause: clause, args: args}
+			return
+		}
+	}
+	q.scopes = append(q.scopes, scope{name: name, argClause: argClause{clause: clause, args: args}})
+}
+
 // AppendIn on the query.
 func AppendIn(q *Query, clause string, args ...interface{}) {
 	q.where = append(q.where, where{kind: whereKindIn, clause: clause, args: args})
@@ -402,6 +526,28 @@ func AppendWith(q *Query, clause string, args ...interface{}) {
 	q.withs = append(q.withs, argClause{clause: clause, args: args})
 }
 
+// AppendWithQuery appends the select query as the common table expression
+// named name.
+func AppendWithQuery(q *Query, name string, sub *Query) {
+	clause, args := buildSubquery(sub, q.dialect)
+	q.withs = append(q.withs, argClause{clause: fmt.Sprintf("%s AS (%s)", name, clause), args: args})
+}
+
+// AppendWithRecursive appends the recursive common table expression named
+// name, the rows of the base query and then those of the recursive query
+// joining the rows found so far by name until it finds none, and makes the
+// common table expressions of the query recursive.
+func AppendWithRecursive(q *Query, name string, base, recursive *Query) {
+	baseClause, args := buildSubquery(base, q.dialect)
+	recursiveClause, recursiveArgs := buildSubquery(recursive, q.dialect)
+
+	q.withRecursive = true
+	q.withs = append(q.withs, argClause{
+		clause: fmt.Sprintf("%s AS (%s UNION ALL %s)", name, baseClause, recursiveClause),
+		args:   append(args, recursiveArgs...),
+	})
+}
+
 // RemoveSoftDeleteWhere prevents the automatic soft delete where clause
 // from being included when building the query.
 func RemoveSoftDeleteWhere(q *Query) {
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/volatiletech/sqlboiler/v4/drivers"
 	"github.com/volatiletech/strmangle"
 )
 
@@ -32,6 +33,8 @@ func BuildQuery(q *Query) (string, []interface{}) {
 		buf, args = buildDeleteQuery(q)
 	case len(q.update) > 0:
 		buf, args = buildUpdateQuery(q)
+	case len(q.insertInto) != 0:
+		buf, args = buildInsertSelectQuery(q)
 	default:
 		buf, args = buildSelectQuery(q)
 	}
@@ -47,6 +50,10 @@ func BuildQuery(q *Query) (string, []interface{}) {
 }
 
 func buildSelectQuery(q *Query) (*bytes.Buffer, []interface{}) {
+	if q.count && q.countsSubquery() {
+		return buildCountSubquery(q)
+	}
+
 	buf := strmangle.GetBuffer()
 	var args []interface{}
 
@@ -77,17 +84,29 @@ func buildSelectQuery(q *Query) (*bytes.Buffer, []interface{}) {
 		if q.count {
 			buf.WriteString(")")
 		}
-	} else if hasJoins && hasSelectCols && !q.count {
-		selectColsWithAs := writeAsStatements(q)
-		// Don't identQuoteSlice - writeAsStatements does this
-		buf.WriteString(strings.Join(selectColsWithAs, ", "))
-	} else if hasSelectCols {
-		buf.WriteString(strings.Join(strmangle.IdentQuoteSlice(q.dialect.LQ, q.dialect.RQ, q.selectCols), ", "))
-	} else if hasJoins && !q.count {
-		selectColsWithStars := writeStars(q)
-		buf.WriteString(strings.Join(selectColsWithStars, ", "))
 	} else {
-		buf.WriteByte('*')
+		if q.distinctRows {
+			buf.WriteString("DISTINCT ")
+		} else if len(q.distinctOn) != 0 {
+			fmt.Fprintf(buf, "DISTINCT ON (%s) ", strings.Join(strmangle.IdentQuoteSlice(q.dialect.LQ, q.dialect.RQ, q.distinctOn), ", "))
+		}
+
+		if hasJoins && hasSelectCols && !q.count {
+			selectColsWithAs := writeAsStatements(q)
+			// Don't identQuoteSlice - writeAsStatements does this
+			buf.WriteString(strings.Join(selectColsWithAs, ", "))
+		} else if hasSelectCols {
+			buf.WriteString(strings.Join(strmangle.IdentQuoteSlice(q.dialect.LQ, q.dialect.RQ, q.selectCols), ", "))
+		} else if hasJoins && !q.count {
+			selectColsWithStars := writeStars(q)
+			buf.WriteString(strings.Join(selectColsWithStars, ", "))
+		} else {
+			buf.WriteByte('*')
+		}
+
+		if !q.count {
+			writeSelectExprs(q, buf, &args)
+		}
 	}
 
 	// close SQL COUNT function
@@ -137,6 +156,60 @@ func buildSelectQuery(q *Query) (*bytes.Buffer, []interface{}) {
 	return buf, args
 }
 
+// countsSubquery is true when the count of the query is that of the rows of
+// the query as a subquery, as a COUNT of its select list cannot select
+// distinct rows or the first row of each distinct value, nor combine them
+// with the rows of other queries
+func (q *Query) countsSubquery() bool {
+	return len(q.setOps) != 0 || q.distinct == "" && (q.distinctRows || len(q.distinctOn) != 0)
+}
+
+// buildCountSubquery counts the rows of a query selecting distinct rows or
+// distinct on columns, or combining its rows with those of other queries,
+// which a COUNT of its select list cannot, as the rows of a subquery
+func buildCountSubquery(q *Query) (*bytes.Buffer, []interface{}) {
+	inner := *q
+	inner.count = false
+	inner.comment = ""
+	innerBuf, args := buildSelectQuery(&inner)
+	defer strmangle.PutBuffer(innerBuf)
+
+	buf := strmangle.GetBuffer()
+	writeComment(q, buf)
+	fmt.Fprintf(buf, "SELECT COUNT(*) FROM (%s) AS %s;",
+		strings.TrimSuffix(innerBuf.String(), ";"),
+		strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, "counted_rows"),
+	)
+
+	return buf, args
+}
+
+// buildSubquery builds the select query to be part of another query in the
+// dialect, or its own when it has one, with ? placeholders that the other
+// query numbers with its own. Raw queries are used as they are.
+func buildSubquery(q *Query, dialect *drivers.Dialect) (string, []interface{}) {
+	if len(q.rawSQL.sql) != 0 {
+		return q.rawSQL.sql, q.rawSQL.args
+	}
+
+	sub := *q
+	sub.comment = ""
+	sub.where = append([]where(nil), q.where...)
+	sub.removeSoftDeleteWhere()
+
+	if q.dialect != nil {
+		dialect = q.dialect
+	}
+	subDialect := *dialect
+	subDialect.UseIndexPlaceholders = false
+	sub.dialect = &subDialect
+
+	buf, args := buildSelectQuery(&sub)
+	defer strmangle.PutBuffer(buf)
+
+	return strings.TrimSuffix(buf.String(), ";"), args
+}
+
 func buildDeleteQuery(q *Query) (*bytes.Buffer, []interface{}) {
 	var args []interface{}
 	buf := strmangle.GetBuffer()
@@ -182,12 +255,12 @@ func buildUpdateQuery(q *Query) (*bytes.Buffer, []interface{}) {
 
 	for i := 0; i < len(cols); i++ {
 		args = append(args, q.update[cols[i]])
-		cols[i] = strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, cols[i])
+		cols[i] = q.dialect.Quote(cols[i])
 	}
 
 	setSlice := make([]string, len(cols))
 	for index, col := range cols {
-		setSlice[index] = fmt.Sprintf("%s = %s", col, strmangle.Placeholders(q.dialect.UseIndexPlaceholders, 1, index+1, 1))
+		setSlice[index] = fmt.Sprintf("%s = %s", col, q.dialect.Placeholder(index+1))
 	}
 	fmt.Fprintf(buf, " SET %s", strings.Join(setSlice, ", "))
 
@@ -204,6 +277,29 @@ func buildUpdateQuery(q *Query) (*bytes.Buffer, []interface{}) {
 	return buf, args
 }
 
+// buildInsertSelectQuery inserts the rows of the select query into the
+// table of insertInto, the comment goes before the INSERT
+func buildInsertSelectQuery(q *Query) (*bytes.Buffer, []interface{}) {
+	buf := strmangle.GetBuffer()
+
+	writeComment(q, buf)
+
+	buf.WriteString("INSERT INTO ")
+	buf.WriteString(q.dialect.Quote(q.insertInto))
+	if len(q.insertCols) != 0 {
+		fmt.Fprintf(buf, " (%s)", strings.Join(q.dialect.QuoteSlice(q.insertCols), ", "))
+	}
+	buf.WriteByte(' ')
+
+	sel := *q
+	sel.comment = ""
+	selBuf, args := buildSelectQuery(&sel)
+	buf.Write(selBuf.Bytes())
+	strmangle.PutBuffer(selBuf)
+
+	return buf, args
+}
+
 func writeParameterizedModifiers(q *Query, buf *bytes.Buffer, args *[]interface{}, keyword, delim string, clauses []argClause) {
 	argsLen := len(*args)
 	modBuf := strmangle.GetBuffer()
@@ -237,6 +333,8 @@ func writeModifiers(q *Query, buf *bytes.Buffer, args *[]interface{}) {
 		writeParameterizedModifiers(q, buf, args, " HAVING ", " AND ", q.having)
 	}
 
+	writeSetOperations(q, buf, args)
+
 	if len(q.orderBy) != 0 {
 		writeParameterizedModifiers(q, buf, args, " ORDER BY ", ", ", q.orderBy)
 	}
@@ -306,6 +404,48 @@ func writeStars(q *Query) []string {
 	return cols
 }
 
+// writeSetOperations writes the queries combined with the query with their
+// operators, scoped by the scopes of the query
+func writeSetOperations(q *Query, buf *bytes.Buffer, args *[]interface{}) {
+	for _, op := range q.setOps {
+		other := *op.query
+		other.scopes = append([]scope(nil), op.query.scopes...)
+		for _, s := range q.scopes {
+			SetScope(&other, s.name, s.clause, s.args...)
+		}
+
+		clause, otherArgs := buildSubquery(&other, q.dialect)
+		if q.dialect.UseIndexPlaceholders {
+			clause, _ = convertQuestionMarks(clause, len(*args)+1)
+		}
+		fmt.Fprintf(buf, " %s %s", op.operator, clause)
+		*args = append(*args, otherArgs...)
+	}
+}
+
+// writeSelectExprs writes the select expressions of the query after its
+// columns, as their aliases
+func writeSelectExprs(q *Query, buf *bytes.Buffer, args *[]interface{}) {
+	if len(q.selectExprs) == 0 {
+		return
+	}
+
+	argsLen := len(*args)
+	exprBuf := strmangle.GetBuffer()
+	for _, e := range q.selectExprs {
+		fmt.Fprintf(exprBuf, ", %s AS %s", e.clause, strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, e.alias))
+		*args = append(*args, e.args...)
+	}
+	var resp string
+	if q.dialect.UseIndexPlaceholders {
+		resp, _ = convertQuestionMarks(exprBuf.String(), argsLen+1)
+	} else {
+		resp = exprBuf.String()
+	}
+	buf.WriteString(resp)
+	strmangle.PutBuffer(exprBuf)
+}
+
 func writeAsStatements(q *Query) []string {
 	cols := make([]string, len(q.selectCols))
 	for i, col := range q.selectCols {
@@ -337,7 +477,7 @@ func writeAsStatements(q *Query) []string {
 //
 // startAt specifies what number placeholders start at
 func whereClause(q *Query, startAt int) (string, []interface{}) {
-	if len(q.where) == 0 {
+	if len(q.where) == 0 && len(q.scopes) == 0 {
 		return "", nil
 	}
 
@@ -357,6 +497,10 @@ ManualParen:
 
 	notFirstExpression := false
 	buf.WriteString(" WHERE ")
+	scoped := len(q.where) != 0 && len(q.scopes) != 0
+	if scoped {
+		buf.WriteByte('(')
+	}
 	for _, where := range q.where {
 		if notFirstExpression && where.kind != whereKindRightParen {
 			if where.orSeparator {
@@ -373,23 +517,43 @@ ManualParen:
 			if !manualParens {
 				buf.WriteByte('(')
 			}
-			if q.dialect.UseIndexPlaceholders {
+			if sub, ok := whereSubquery(where.args); ok {
+				clause, subArgs, count := subqueryInClause(q, where.clause, sub, startAt)
+				buf.WriteString(clause)
+				args = append(args, subArgs...)
+				startAt += count
+			} else if q.dialect.UseIndexPlaceholders {
 				replaced, n := convertQuestionMarks(where.clause, startAt)
 				buf.WriteString(replaced)
 				startAt += n
+				args = append(args, where.args...)
 			} else {
 				buf.WriteString(where.clause)
+				args = append(args, where.args...)
 			}
 			if !manualParens {
 				buf.WriteByte(')')
 			}
-			args = append(args, where.args...)
 		case whereKindLeftParen:
 			buf.WriteByte('(')
 			notFirstExpression = false
 		case whereKindRightParen:
 			buf.WriteByte(')')
 		case whereKindIn, whereKindNotIn:
+			if sub, ok := whereSubquery(where.args); ok {
+				clause, subArgs, count := subqueryInClause(q, where.clause, sub, startAt)
+				if !manualParens {
+					buf.WriteByte('(')
+				}
+				buf.WriteString(clause)
+				if !manualParens {
+					buf.WriteByte(')')
+				}
+				args = append(args, subArgs...)
+				startAt += count
+				break
+			}
+
 			ln := len(where.args)
 			// WHERE IN () is invalid sql, so it is difficult to simply run code like:
 			// for _, u := range model.Users(qm.WhereIn("id IN ?",uids...)).AllP(db) {
@@ -475,9 +639,57 @@ ManualParen:
 		}
 	}
 
+	if scoped {
+		buf.WriteString(") AND ")
+	}
+	for i, s := range q.scopes {
+		if i != 0 {
+			buf.WriteString(" AND ")
+		}
+		buf.WriteByte('(')
+		if q.dialect.UseIndexPlaceholders {
+			replaced, n := convertQuestionMarks(s.clause, startAt)
+			buf.WriteString(replaced)
+			startAt += n
+		} else {
+			buf.WriteString(s.clause)
+		}
+		buf.WriteByte(')')
+		args = append(args, s.args...)
+	}
+
 	return buf.String(), args
 }
 
+// whereSubquery returns the query of the arguments of a where or in clause
+// whose only argument is a query
+func whereSubquery(args []interface{}) (*Query, bool) {
+	if len(args) != 1 {
+		return nil, false
+	}
+	sub, ok := args[0].(*Query)
+	return sub, ok && sub != nil
+}
+
+// subqueryInClause swaps the first unescaped ? of a where or in clause with
+// the subquery, and numbers the placeholders of the subquery from startAt.
+// It returns the clause, the arguments of the subquery and their number.
+func subqueryInClause(q *Query, clause string, sub *Query, startAt int) (string, []interface{}, int) {
+	subClause, args := buildSubquery(sub, q.dialect)
+	for i := 0; i < len(clause); i++ {
+		if clause[i] == '?' && (i == 0 || clause[i-1] != '\\') {
+			clause = clause[:i] + "(" + subClause + ")" + clause[i+1:]
+			break
+		}
+	}
+
+	if !q.dialect.UseIndexPlaceholders {
+		return clause, args, len(args)
+	}
+	clause, count := convertQuestionMarks(clause, startAt)
+	return clause, args, count
+}
+
 // convertInQuestionMarks finds the first unescaped occurrence of ? and swaps it
 // with a list of numbered placeholders, starting at startAt.
 // It uses groupAt to determine how many placeholders should be in each group,
@@ -610,6 +822,10 @@ func writeCTEs(q *Query, buf *bytes.Buffer, args *[]interface{}) {
 	}
 
 	buf.WriteString("WITH")
+	// MS SQL recurses without the keyword
+	if q.withRecursive && !q.dialect.UseTopClause {
+		buf.WriteString(" RECURSIVE")
+	}
 	argsLen := len(*args)
 	withBuf := strmangle.GetBuffer()
 	lastPos := len(q.withs) - 1
@@ -130,6 +130,24 @@ func TestBuildQuery(t *testing.T) {
 		{&Query{from: []string{"t"}, distinct: "id, t.*", joins: []join{{JoinInner, "dogs d on d.cat_id = t.id", nil}}}, nil},
 		{&Query{from: []string{"t"}, distinct: "id, t.*", count: true, joins: []join{{JoinInner, "dogs d on d.cat_id = t.id", nil}}}, nil},
 		{&Query{from: []string{"t"}, where: []where{{clause: "deleted_at is null"}, {clause: "deleted_at = survives"}}, removeSoftDelete: true}, nil},
+		{&Query{from: []string{"t"}, selectCols: []string{"a", "b"}, distinctRows: true, joins: []join{{JoinInner, "dogs d on d.cat_id = t.id", nil}}}, nil},
+		{&Query{from: []string{"t"}, distinctOn: []string{"a", "t.b"}, orderBy: []argClause{{"a, t.b, c desc", nil}}, limit: newIntPtr(5)}, nil},
+		{&Query{from: []string{"t"}, distinctRows: true, count: true, comment: "distinct", where: []where{{clause: "a=?", args: []interface{}{1}}}}, []interface{}{1}},
+		{&Query{
+			from:        []string{"t"},
+			selectExprs: []selectExpr{{argClause{"rank() OVER (ORDER BY a <-> ?)", []interface{}{2}}, "r"}},
+			joins:       []join{{JoinInner, "dogs d on d.cat_id = t.id and d.age > ?", []interface{}{3}}},
+			where:       []where{{clause: "a=?", args: []interface{}{4}}},
+		}, []interface{}{2, 3, 4}},
+		{&Query{
+			insertInto: "archive.t",
+			insertCols: []string{"a", "b"},
+			from:       []string{"t"},
+			selectCols: []string{"t.a", "t.b"},
+			where:      []where{{clause: "a=?", args: []interface{}{1}}},
+			limit:      newIntPtr(5),
+			comment:    "archive",
+		}, []interface{}{1}},
 	}
 
 	for i, test := range tests {
@@ -323,6 +341,27 @@ func TestWhereClause(t *testing.T) {
 			},
 			expect: " WHERE a=$1 OR (b=$2 and c=$3)",
 		},
+		// Scope("t=?")
+		{
+			q: Query{
+				scopes: []scope{{name: "t", argClause: argClause{clause: "t=?"}}},
+			},
+			expect: " WHERE (t=$1)",
+		},
+		// Where("a=?"), Or("b=?"), Scope("t=?"), Scope("u=?")
+		{
+			q: Query{
+				where: []where{
+					{clause: "a=?"},
+					{clause: "b=?", orSeparator: true},
+				},
+				scopes: []scope{
+					{name: "t", argClause: argClause{clause: "t=?"}},
+					{name: "u", argClause: argClause{clause: "u=?"}},
+				},
+			},
+			expect: " WHERE ((a=$1) OR (b=$2)) AND (t=$3) AND (u=$4)",
+		},
 	}
 
 	for i, test := range tests {
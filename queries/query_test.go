@@ -368,6 +368,17 @@ func TestSetDelete(t *testing.T) {
 	}
 }
 
+func TestSetInsertInto(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{}
+	SetInsertInto(q, "archive", "a", "b")
+
+	if q.insertInto != "archive" || !reflect.DeepEqual(q.insertCols, []string{"a", "b"}) {
+		t.Errorf("Wrong insert, got %s %v", q.insertInto, q.insertCols)
+	}
+}
+
 func TestSetArgs(t *testing.T) {
 	t.Parallel()
 
@@ -654,6 +665,25 @@ func TestSetComment(t *testing.T) {
 	}
 }
 
+func TestSetScope(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{}
+	SetScope(q, "tenant", "org_id = ?", 1)
+	SetScope(q, "region", "region = ?", "eu")
+	SetScope(q, "tenant", "org_id = ?", 2)
+
+	if len(q.scopes) != 2 {
+		t.Fatalf("want two scopes, got: %#v", q.scopes)
+	}
+	if q.scopes[0].name != "tenant" || q.scopes[0].args[0].(int) != 2 {
+		t.Errorf("the tenant scope was not replaced: %#v", q.scopes[0])
+	}
+	if q.scopes[1].clause != "region = ?" {
+		t.Errorf("Got invalid scope: %#v", q.scopes[1])
+	}
+}
+
 func TestRemoveSoftDeleteWhere(t *testing.T) {
 	t.Parallel()
 
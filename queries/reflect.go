@@ -131,8 +131,40 @@ func (q *Query) Bind(ctx context.Context, exec boil.Executor, obj interface{}) e
 		return err
 	}
 
+	// Routed once so eager loading reads from the same connection
+	exec = q.route(exec)
+
+	// Cached results are stored before eager loading, relationships are
+	// always loaded fresh
+	cache, key := q.queryCache()
+	if cache == nil || !getCached(cache, key, obj) {
+		if err = q.bindRows(ctx, exec, obj, structType, sliceType, bkind); err != nil {
+			return err
+		}
+		if cache != nil {
+			setCached(cache, key, obj, q)
+		}
+	}
+
+	if len(q.load) != 0 {
+		return eagerLoad(ctx, exec, q.load, q.loadMods, obj, bkind)
+	}
+
+	return nil
+}
+
+// bindRows runs the query and binds its rows to obj
+func (q *Query) bindRows(ctx context.Context, exec boil.Executor, obj interface{}, structType, sliceType reflect.Type, bkind bindKind) error {
 	var rows *sql.Rows
+	var err error
 	if ctx != nil {
+		var done func()
+		ctx, done, err = q.WithTimeout(ctx, exec.(boil.ContextExecutor))
+		if err != nil {
+			return err
+		}
+		defer done()
+
 		rows, err = q.QueryContext(ctx, exec.(boil.ContextExecutor))
 	} else {
 		rows, err = q.Query(exec)
@@ -154,10 +186,6 @@ func (q *Query) Bind(ctx context.Context, exec boil.Executor, obj interface{}) e
 		return errors.Wrap(err, "error from rows in bind")
 	}
 
-	if len(q.load) != 0 {
-		return eagerLoad(ctx, exec, q.load, q.loadMods, obj, bkind)
-	}
-
 	return nil
 }
 
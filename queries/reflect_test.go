@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/drivers"
 	"github.com/volatiletech/null/v8"
 
@@ -81,6 +82,59 @@ func TestBindStruct(t *testing.T) {
 	}
 }
 
+func TestBindRoutesReads(t *testing.T) {
+	t.Parallel()
+
+	primary, primaryMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer primary.Close()
+	replica, replicaMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replica.Close()
+
+	pool := boil.NewDBPool(primary, replica)
+	dialect := &drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true}
+
+	var testResults []struct {
+		ID int
+	}
+
+	replicaMock.ExpectQuery(`SELECT \* FROM "fun";`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	query := &Query{from: []string{"fun"}, dialect: dialect}
+	if err = query.Bind(context.Background(), pool, &testResults); err != nil {
+		t.Error(err)
+	}
+
+	primaryMock.ExpectQuery(`SELECT \* FROM "fun";`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	query = &Query{from: []string{"fun"}, dialect: dialect}
+	SetUsePrimary(query)
+	if err = query.Bind(context.Background(), pool, &testResults); err != nil {
+		t.Error(err)
+	}
+
+	primaryMock.ExpectQuery(`SELECT \* FROM "fun" FOR UPDATE;`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	query = &Query{from: []string{"fun"}, forlock: "UPDATE", dialect: dialect}
+	if err = query.Bind(context.Background(), pool, &testResults); err != nil {
+		t.Error(err)
+	}
+
+	primaryMock.ExpectQuery(`select \* from fun returning id`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	if err = Raw("select * from fun returning id").Bind(context.Background(), pool, &testResults); err != nil {
+		t.Error(err)
+	}
+
+	if err := replicaMock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+	if err := primaryMock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestBindSlice(t *testing.T) {
 	t.Parallel()
 
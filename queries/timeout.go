@@ -0,0 +1,60 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// SetTimeout sets the timeout of the query, it replaces the default one set
+// with boil.SetQueryTimeout. See WithTimeout for how it is applied.
+func SetTimeout(q *Query, timeout time.Duration) {
+	q.timeout = timeout
+}
+
+// WithTimeout applies the timeout of the query, or else the default one set
+// with boil.SetQueryTimeout, to the queries run with the returned context on
+// exec. On the transactions of dialects using statement timeouts, postgres',
+// the statement timeout of the transaction is set with SET LOCAL, otherwise,
+// and for raw queries which have no dialect, the context gets a deadline.
+// done must be called once the rows of the query are read, it restores the
+// statement timeout the transaction had or cancels the context.
+//
+// Bind and ExecContext apply it themselves, it is for QueryContext and
+// QueryRowContext whose rows are read by their callers. There is no timeout
+// without a context.
+func (q *Query) WithTimeout(ctx context.Context, exec boil.ContextExecutor) (context.Context, func(), error) {
+	timeout := q.timeout
+	if timeout <= 0 {
+		timeout = boil.GetQueryTimeout()
+	}
+	if timeout <= 0 || ctx == nil {
+		return ctx, func() {}, nil
+	}
+
+	if boil.InTransaction(exec) && q.dialect != nil && q.dialect.UseStatementTimeout {
+		// The timeout the transaction had, set by the caller with SET LOCAL
+		// or the one of the session, is restored after the query
+		var prev string
+		if err := boil.DebugQueryRowContext(ctx, exec, "SHOW statement_timeout").Scan(&prev); err != nil {
+			return ctx, func() {}, errors.Wrap(err, "failed to read the statement timeout")
+		}
+
+		ms := timeout.Milliseconds()
+		if ms == 0 {
+			ms = 1
+		}
+		if _, err := boil.DebugExecContext(ctx, exec, fmt.Sprintf("SET LOCAL statement_timeout = %d", ms)); err != nil {
+			return ctx, func() {}, errors.Wrap(err, "failed to set the statement timeout")
+		}
+		return ctx, func() {
+			_, _ = boil.DebugExecContext(ctx, exec, "SELECT set_config('statement_timeout', $1, true)", prev)
+		}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, nil
+}
@@ -0,0 +1,117 @@
+package queries
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/drivers"
+)
+
+func TestWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	q := &Query{dialect: &drivers.Dialect{LQ: '"', RQ: '"', UseIndexPlaceholders: true, UseStatementTimeout: true}}
+
+	ctx, done, err := q.WithTimeout(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("expected no deadline without a timeout")
+	}
+	done()
+
+	SetTimeout(q, time.Minute)
+	ctx, done, err = q.WithTimeout(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("expected a deadline within a minute, got: %v %t", deadline, ok)
+	}
+	done()
+	if ctx.Err() == nil {
+		t.Error("expected done to cancel the context")
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SHOW statement_timeout`).WillReturnRows(sqlmock.NewRows([]string{"statement_timeout"}).AddRow("30s"))
+	mock.ExpectExec(`SET LOCAL statement_timeout = 1500`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT set_config('statement_timeout', $1, true)`)).WithArgs("30s").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetTimeout(q, 1500*time.Millisecond)
+	ctx, done, err = q.WithTimeout(context.Background(), tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("expected the statement timeout rather than a deadline in a postgres transaction")
+	}
+	done()
+
+	// a transaction wrapped by another executor of boil gets the statement
+	// timeout too
+	mock.ExpectQuery(`SHOW statement_timeout`).WillReturnRows(sqlmock.NewRows([]string{"statement_timeout"}).AddRow("0"))
+	mock.ExpectExec(`SET LOCAL statement_timeout = 1500`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT set_config('statement_timeout', $1, true)`)).WithArgs("0").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	ctx, done, err = q.WithTimeout(context.Background(), boil.WithRetry(tx, boil.RetryOptions{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("expected the statement timeout rather than a deadline in a wrapped postgres transaction")
+	}
+	done()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWithTimeoutMSSQL(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := &Query{dialect: &drivers.Dialect{LQ: '[', RQ: ']', UseIndexPlaceholders: true, UseTopClause: true, UseOutputClause: true}}
+	SetTimeout(q, time.Minute)
+
+	ctx, done, err := q.WithTimeout(context.Background(), tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("expected a deadline rather than a statement timeout in an mssql transaction, got: %v %t", deadline, ok)
+	}
+	done()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
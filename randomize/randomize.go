@@ -0,0 +1,321 @@
+// Package randomize fills generated model structs with random values that
+// the database will accept. It builds on github.com/volatiletech/randomize
+// but uses the column metadata known at generation time (nullability,
+// uniqueness, enums and length limits) instead of guessing from Go types.
+package randomize
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/strmangle"
+)
+
+// Seed is an atomic counter for pseudo-randomization structs. Using full
+// randomization leads to collisions in a domain where uniqueness is an
+// important factor.
+type Seed = randomize.Seed
+
+// Randomizer allows a field to be randomized, see randomize.Randomizer
+type Randomizer = randomize.Randomizer
+
+// NewSeed creates a new seed for pseudo-randomization.
+func NewSeed() *Seed {
+	return randomize.NewSeed()
+}
+
+// StableDBName takes a database name in, and generates
+// a random string using the database name as the rand Seed.
+func StableDBName(input string) string {
+	return randomize.StableDBName(input)
+}
+
+// Column holds the constraints of a database column that randomization
+// must respect to produce a value that can be inserted. The unique values of
+// CaseInsensitive columns never differ from each other only in case, the
+// strings of columns of a Charset only hold characters it has, and the
+// decimals of columns of a Precision and Scale fit them.
+type Column struct {
+	DBType          string
+	Nullable        bool
+	Unique          bool
+	CaseInsensitive bool
+	Charset         string
+	MaxLength       int
+	Precision       int
+	Scale           int
+}
+
+// charsetMaxRunes are the largest characters of the charsets that do not have
+// all of unicode, as the databases name them
+var charsetMaxRunes = map[string]rune{
+	"ascii":     0x7f,
+	"SQL_ASCII": 0x7f,
+	"latin1":    0xff,
+	"LATIN1":    0xff,
+	"iso_1":     0xff,
+	"ucs2":      0xffff,
+	"utf8":      0xffff,
+	"utf8mb3":   0xffff,
+	"UNICODE":   0xffff,
+}
+
+// Columns maps struct field names to their column constraints
+type Columns map[string]Column
+
+var typeTime = reflect.TypeOf(time.Time{})
+
+// Struct gets its fields filled with random data based on the seed.
+//
+// It will ignore the fields in the blacklist (struct field names or boil
+// tag names) and fields that have the struct tag boil:"-". canBeNull allows
+// nullable columns to be randomly set to null, columns that are not nullable
+// are always given a non-zero value.
+func Struct(s *Seed, str interface{}, cols Columns, canBeNull bool, blacklist ...string) error {
+	value := reflect.ValueOf(str)
+	if value.Kind() != reflect.Ptr {
+		return errors.Errorf("Outer element should be a pointer, given a non-pointer: %T", str)
+	}
+
+	value = value.Elem()
+	if value.Kind() != reflect.Struct {
+		return errors.Errorf("Inner element should be a struct, given a non-struct: %T", str)
+	}
+
+	// deleted_at is never randomized so soft deleted rows do not show up
+	blacklist = append(append([]string(nil), blacklist...), "deleted_at")
+
+	typ := value.Type()
+	for i := 0; i < value.NumField(); i++ {
+		fieldVal := value.Field(i)
+		fieldTyp := typ.Field(i)
+
+		tag := fieldTyp.Tag.Get("boil")
+		if tag == "-" || isBlacklisted(fieldTyp.Name, tag, blacklist) {
+			continue
+		}
+
+		col := cols[fieldTyp.Name]
+		if err := randomizeField(s, fieldVal, col, canBeNull && col.Nullable); err != nil {
+			return errors.Wrapf(err, "failed to randomize field %s", fieldTyp.Name)
+		}
+	}
+
+	return nil
+}
+
+func isBlacklisted(fieldName, tag string, blacklist []string) bool {
+	for _, v := range blacklist {
+		if v == tag || strmangle.TitleCase(v) == fieldName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// randomizeField changes the value at field to a "randomized" value.
+//
+// When canBeNull is true there is a 1 in 3 chance of the value being null
+// (or the zero value for types that have no null representation).
+func randomizeField(s *Seed, field reflect.Value, col Column, canBeNull bool) error {
+	shouldBeNull := canBeNull && s.NextInt()%3 == 0
+
+	if r, ok := field.Addr().Interface().(Randomizer); ok {
+		r.Randomize(s.NextInt, col.DBType, shouldBeNull)
+		if !shouldBeNull {
+			// Random strings can differ only in case, which case insensitive
+			// unique columns take as duplicates, or have characters the charset
+			// of the column does not, so they get the strings of randString
+			if str, ok := stringField(field); ok && !strings.HasPrefix(col.DBType, "enum") &&
+				((col.Unique && col.CaseInsensitive) || !fitsCharset(str.String(), col.Charset)) {
+				str.SetString(randString(s, col, false))
+			}
+			clampLength(field, col.MaxLength)
+			fitDecimal(field, col.Precision, col.Scale)
+		}
+		return nil
+	}
+
+	kind := field.Kind()
+	typ := field.Type()
+
+	var value interface{}
+	switch {
+	case typ == typeTime:
+		// MySQL does not support zero value times, so always use a date
+		value = randomize.Date(s.NextInt)
+	case kind == reflect.String:
+		value = randString(s, col, shouldBeNull)
+	case kind == reflect.Slice:
+		if typ.Elem().Kind() != reflect.Uint8 {
+			return errors.Errorf("unsupported slice type: %s, was expecting byte slice", typ.String())
+		}
+		// Byte slices never get zero values to stop mysql from being a jerk
+		value = randomize.ByteSlice(s.NextInt, 1)
+	case shouldBeNull:
+		value = reflect.Zero(typ).Interface()
+	default:
+		value = randNumeric(s, col.DBType, kind)
+	}
+
+	if value == nil {
+		return errors.Errorf("unsupported type: %s", typ.String())
+	}
+
+	newValue := reflect.ValueOf(value)
+	if newValue.Type() != typ {
+		newValue = newValue.Convert(typ)
+	}
+	field.Set(newValue)
+
+	return nil
+}
+
+// randString creates a string that respects enums, special formats (uuid,
+// json etc.), uniqueness and the maximum length of the column.
+func randString(s *Seed, col Column, shouldBeNull bool) string {
+	// Some of these formatted strings cannot tolerate zero values, so
+	// the request for a null value is ignored.
+	if str, ok := randomize.FormattedString(s.NextInt, col.DBType); ok {
+		return str
+	}
+
+	if shouldBeNull {
+		return ""
+	}
+
+	var str string
+	if col.Unique {
+		// The seed only ever grows, so a base36 rendering of it cannot collide
+		// with a previous value generated from the same seed.
+		str = strconv.FormatInt(s.NextInt(), 36)
+	} else {
+		str = randomize.Str(s.NextInt, 1)
+	}
+
+	return truncate(str, col.MaxLength)
+}
+
+// truncate shortens a string to max characters keeping the tail of the
+// string, which is where seed based values change the most.
+func truncate(str string, max int) string {
+	if max <= 0 || len(str) <= max {
+		return str
+	}
+
+	return str[len(str)-max:]
+}
+
+// clampLength truncates string values produced by Randomizer implementations
+// (null.String for example) that are longer than the column allows.
+func clampLength(field reflect.Value, max int) {
+	if max <= 0 {
+		return
+	}
+
+	if str, ok := stringField(field); ok {
+		str.SetString(truncate(str.String(), max))
+	}
+}
+
+// fitDecimal keeps the fraction of the decimals produced by Randomizer
+// implementations (types.Decimal for example) that have more digits before
+// the decimal point than the precision and scale of the column allow.
+func fitDecimal(field reflect.Value, precision, scale int) {
+	if precision <= 0 || field.Kind() != reflect.Struct {
+		return
+	}
+
+	big := field.FieldByName("Big")
+	if !big.IsValid() {
+		return
+	}
+	d, ok := big.Interface().(*decimal.Big)
+	if !ok || d == nil || d.Precision()-d.Scale() <= precision-scale {
+		return
+	}
+
+	str := d.String()
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		d.SetString("0" + str[i:])
+	} else {
+		d.SetUint64(0)
+	}
+}
+
+// fitsCharset tells if the charset has all the characters of str, the
+// charsets that are not known have them all
+func fitsCharset(str, charset string) bool {
+	max, ok := charsetMaxRunes[charset]
+	if !ok {
+		return true
+	}
+
+	for _, r := range str {
+		if r > max {
+			return false
+		}
+	}
+	return true
+}
+
+// stringField returns the string of the field, the field itself or the String
+// field of the nullable strings like null.String
+func stringField(field reflect.Value) (reflect.Value, bool) {
+	switch field.Kind() {
+	case reflect.String:
+		return field, true
+	case reflect.Struct:
+		if str := field.FieldByName("String"); str.IsValid() && str.Kind() == reflect.String {
+			return str, true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+func randNumeric(s *Seed, dbType string, kind reflect.Kind) interface{} {
+	switch kind {
+	case reflect.Float32:
+		return float32(s.NextInt()%10)/10.0 + float32(s.NextInt()%10)
+	case reflect.Float64:
+		return float64(s.NextInt()%10)/10.0 + float64(s.NextInt()%10)
+	case reflect.Int:
+		return int(s.NextInt())
+	case reflect.Int8:
+		return int8(s.NextInt() % math.MaxInt8)
+	case reflect.Int16:
+		return int16(s.NextInt() % math.MaxInt16)
+	case reflect.Int32:
+		if val, ok := randomize.MediumInt(s.NextInt, dbType); ok {
+			return val
+		}
+		return int32(s.NextInt() % math.MaxInt32)
+	case reflect.Int64:
+		return s.NextInt()
+	case reflect.Uint:
+		return uint(s.NextInt())
+	case reflect.Uint8:
+		return uint8(s.NextInt() % math.MaxUint8)
+	case reflect.Uint16:
+		return uint16(s.NextInt() % math.MaxUint16)
+	case reflect.Uint32:
+		if dbType == "mediumint" {
+			return uint32(s.NextInt()) % 16777215
+		}
+		return uint32(s.NextInt() % math.MaxUint32)
+	case reflect.Uint64:
+		return uint64(s.NextInt())
+	case reflect.Bool:
+		return true
+	}
+
+	return nil
+}
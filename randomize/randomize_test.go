@@ -0,0 +1,312 @@
+package randomize
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+type magicType struct {
+	Value      int
+	Randomized bool
+}
+
+func (m *magicType) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	m.Value = int(nextInt())
+	m.Randomized = true
+}
+
+func TestStruct(t *testing.T) {
+	t.Parallel()
+
+	s := NewSeed()
+
+	var testStruct = struct {
+		Int       int
+		Int64     int64
+		Float64   float64
+		Bool      bool
+		Time      time.Time
+		String    string
+		ByteSlice []byte
+		Interval  string
+		Magic     magicType
+		Ignore    int
+		Tagged    int `boil:"-"`
+	}{}
+
+	cols := Columns{
+		"Int":       {DBType: "integer"},
+		"Int64":     {DBType: "bigint"},
+		"Float64":   {DBType: "decimal"},
+		"Bool":      {DBType: "boolean"},
+		"Time":      {DBType: "date"},
+		"String":    {DBType: "character varying"},
+		"ByteSlice": {DBType: "bytea"},
+		"Interval":  {DBType: "interval"},
+		"Magic":     {DBType: "magic_type"},
+		"Ignore":    {DBType: "integer"},
+		"Tagged":    {DBType: "integer"},
+	}
+
+	if err := Struct(s, &testStruct, cols, true, "Ignore"); err != nil {
+		t.Fatal(err)
+	}
+
+	if testStruct.Ignore != 0 {
+		t.Error("blacklisted value was filled in:", testStruct.Ignore)
+	}
+	if testStruct.Tagged != 0 {
+		t.Error("boil:\"-\" value was filled in:", testStruct.Tagged)
+	}
+
+	// None of these columns are nullable so none of them may be zero
+	if testStruct.Int == 0 || testStruct.Int64 == 0 || !testStruct.Bool ||
+		testStruct.Time.IsZero() || testStruct.String == "" ||
+		testStruct.Interval == "" || len(testStruct.ByteSlice) == 0 {
+		t.Errorf("non-nullable values were not randomized: %#v", testStruct)
+	}
+
+	if !testStruct.Magic.Randomized {
+		t.Error("the randomize interface should have been used")
+	}
+}
+
+func TestStructErrors(t *testing.T) {
+	t.Parallel()
+
+	s := NewSeed()
+
+	var notStruct int
+	if err := Struct(s, &notStruct, nil, false); err == nil {
+		t.Error("expected an error for a non-struct")
+	}
+
+	if err := Struct(s, struct{}{}, nil, false); err == nil {
+		t.Error("expected an error for a non-pointer")
+	}
+}
+
+func TestStructNullable(t *testing.T) {
+	t.Parallel()
+
+	cols := Columns{
+		"Name":  {DBType: "character varying", Nullable: true},
+		"Count": {DBType: "integer", Nullable: false},
+	}
+
+	sawNull := false
+	// The seed is a counter, so every possible outcome of the null check is
+	// covered by starting from each residue
+	for i := 0; i < 3; i++ {
+		s := Seed(i)
+
+		var testStruct struct {
+			Name  null.String
+			Count int
+		}
+
+		if err := Struct(&s, &testStruct, cols, true); err != nil {
+			t.Fatal(err)
+		}
+
+		if testStruct.Count == 0 {
+			t.Fatal("non-nullable column received a zero value")
+		}
+		if !testStruct.Name.Valid {
+			sawNull = true
+		}
+	}
+
+	if !sawNull {
+		t.Error("nullable column was never null")
+	}
+}
+
+func TestStructEnum(t *testing.T) {
+	t.Parallel()
+
+	s := NewSeed()
+	cols := Columns{
+		"Mood": {DBType: "enum.workday('monday','tuesday')"},
+	}
+
+	for i := 0; i < 10; i++ {
+		var testStruct struct{ Mood string }
+		if err := Struct(s, &testStruct, cols, false); err != nil {
+			t.Fatal(err)
+		}
+
+		if testStruct.Mood != "monday" && testStruct.Mood != "tuesday" {
+			t.Errorf("got invalid enum value: %q", testStruct.Mood)
+		}
+	}
+}
+
+func TestStructUniqueAndMaxLength(t *testing.T) {
+	t.Parallel()
+
+	s := NewSeed()
+	cols := Columns{
+		"Email": {DBType: "character varying", Unique: true, MaxLength: 4},
+		"Code":  {DBType: "character varying", Nullable: true, MaxLength: 2},
+	}
+
+	seen := make(map[string]struct{})
+	for i := 0; i < 200; i++ {
+		var testStruct struct {
+			Email string
+			Code  null.String
+		}
+
+		if err := Struct(s, &testStruct, cols, false); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(testStruct.Email) > 4 {
+			t.Errorf("string was longer than max length: %q", testStruct.Email)
+		}
+		if len(testStruct.Code.String) > 2 {
+			t.Errorf("null string was longer than max length: %q", testStruct.Code.String)
+		}
+
+		if _, ok := seen[testStruct.Email]; ok {
+			t.Fatalf("unique column received a duplicate value: %q", testStruct.Email)
+		}
+		seen[testStruct.Email] = struct{}{}
+	}
+}
+
+func TestStructUniqueCaseInsensitive(t *testing.T) {
+	t.Parallel()
+
+	s := NewSeed()
+	cols := Columns{
+		"Email": {DBType: "citext", Nullable: true, Unique: true, CaseInsensitive: true},
+	}
+
+	seen := make(map[string]struct{})
+	for i := 0; i < 200; i++ {
+		var testStruct struct {
+			Email null.String
+		}
+
+		if err := Struct(s, &testStruct, cols, false); err != nil {
+			t.Fatal(err)
+		}
+
+		email := strings.ToLower(testStruct.Email.String)
+		if _, ok := seen[email]; ok {
+			t.Fatalf("case insensitive unique column received a duplicate value: %q", testStruct.Email.String)
+		}
+		seen[email] = struct{}{}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In  string
+		Max int
+		Out string
+	}{
+		{In: "abc", Max: 0, Out: "abc"},
+		{In: "abc", Max: 3, Out: "abc"},
+		{In: "abc", Max: 5, Out: "abc"},
+		{In: "abcdef", Max: 2, Out: "ef"},
+	}
+
+	for i, test := range tests {
+		if out := truncate(test.In, test.Max); out != test.Out {
+			t.Errorf("%d) want: %q, got: %q", i, test.Out, out)
+		}
+	}
+}
+
+type unicodeString struct {
+	String string
+	Valid  bool
+}
+
+func (u *unicodeString) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	u.String, u.Valid = "héllo 🚀", !shouldBeNull
+}
+
+func TestStructCharset(t *testing.T) {
+	t.Parallel()
+
+	s := NewSeed()
+	for charset, keep := range map[string]bool{"utf8mb4": true, "utf8": false, "latin1": false, "": true} {
+		var testStruct struct {
+			Name unicodeString
+		}
+
+		cols := Columns{"Name": {DBType: "varchar", Charset: charset}}
+		if err := Struct(s, &testStruct, cols, false); err != nil {
+			t.Fatal(err)
+		}
+
+		if kept := testStruct.Name.String == "héllo 🚀"; kept != keep {
+			t.Errorf("%q: want the string kept %t, got: %q", charset, keep, testStruct.Name.String)
+		}
+		if !fitsCharset(testStruct.Name.String, charset) {
+			t.Errorf("%q: string does not fit the charset: %q", charset, testStruct.Name.String)
+		}
+	}
+}
+
+func TestFitsCharset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Str, Charset string
+		Fits         bool
+	}{
+		{"abc", "ascii", true},
+		{"é", "ascii", false},
+		{"é", "latin1", true},
+		{"€", "latin1", false},
+		{"€", "utf8", true},
+		{"🚀", "utf8mb3", false},
+		{"🚀", "utf8mb4", true},
+		{"🚀", "", true},
+	}
+
+	for i, test := range tests {
+		if fits := fitsCharset(test.Str, test.Charset); fits != test.Fits {
+			t.Errorf("%d) want %t, got: %t", i, test.Fits, fits)
+		}
+	}
+}
+
+func TestStructDecimal(t *testing.T) {
+	t.Parallel()
+
+	s := NewSeed()
+	for i := 0; i < 20; i++ {
+		var testStruct struct {
+			Price    types.Decimal
+			Discount types.NullDecimal
+		}
+
+		cols := Columns{
+			"Price":    {DBType: "numeric", Precision: 1, Scale: 1},
+			"Discount": {DBType: "numeric", Precision: 3, Scale: 1},
+		}
+		if err := Struct(s, &testStruct, cols, false); err != nil {
+			t.Fatal(err)
+		}
+
+		if d := testStruct.Price.Big; d.Precision()-d.Scale() > 0 {
+			t.Errorf("want no digits before the decimal point, got: %s", d)
+		}
+		if d := testStruct.Discount.Big; d == nil || d.Precision()-d.Scale() > 2 {
+			t.Errorf("want the decimal kept, got: %v", d)
+		}
+	}
+}
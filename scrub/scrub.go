@@ -0,0 +1,268 @@
+// Package scrub replaces the values of sensitive columns with realistic fakes,
+// it backs the Scrub methods of models generated with a scrub config section.
+//
+// Fakes are derived from the values they replace with a keyed hash, so the
+// same value is replaced by the same fake in every column of its kind, and
+// unique values stay unique in all but rare cases. The key is random unless
+// it is set with SetKey, fakes can then only be linked to their values by
+// someone knowing the key.
+package scrub
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// The kinds of fakes of string values
+const (
+	Email      = "email"
+	Name       = "name"
+	FirstName  = "first_name"
+	LastName   = "last_name"
+	Username   = "username"
+	Phone      = "phone"
+	Address    = "address"
+	City       = "city"
+	PostalCode = "postal_code"
+	IP         = "ip"
+	URL        = "url"
+	Text       = "text"
+)
+
+// Kinds are the kinds of fakes of string values
+var Kinds = []string{Email, Name, FirstName, LastName, Username, Phone, Address, City, PostalCode, IP, URL, Text}
+
+var (
+	keyMut sync.RWMutex
+	key    []byte
+)
+
+func init() {
+	key = make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("scrub: unable to generate a key: %s", err))
+	}
+}
+
+// SetKey sets the key the fakes are derived with, the same key gives the same
+// fakes in every run
+func SetKey(k []byte) {
+	keyMut.Lock()
+	key = append([]byte(nil), k...)
+	keyMut.Unlock()
+}
+
+// IsKind checks if kind is one of the Kinds
+func IsKind(kind string) bool {
+	for _, k := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	firstNames = []string{
+		"Ada", "Alan", "Amelia", "Arthur", "Beatrice", "Bruno", "Carmen", "Cyrus",
+		"Dalia", "Dmitri", "Elena", "Emil", "Fatima", "Felix", "Greta", "Hugo",
+		"Ingrid", "Ivan", "Jonas", "Julia", "Kenji", "Lena", "Luca", "Maya",
+		"Milo", "Nadia", "Noah", "Olga", "Oscar", "Priya", "Rafael", "Sofia",
+	}
+	lastNames = []string{
+		"Abbott", "Bauer", "Castillo", "Dubois", "Eriksen", "Fischer", "Garcia", "Hansen",
+		"Ivanova", "Jensen", "Kowalski", "Larsen", "Moreau", "Nakamura", "Olsen", "Petrov",
+		"Quinn", "Rossi", "Schmidt", "Tanaka", "Urban", "Vasquez", "Weber", "Young",
+	}
+	streets = []string{
+		"Maple Street", "Oak Avenue", "Pine Road", "Cedar Lane", "Elm Street",
+		"Birch Way", "Willow Drive", "Harbor Road", "Mill Lane", "Station Road",
+	}
+	cities = []string{
+		"Springfield", "Riverton", "Fairview", "Lakewood", "Brookside", "Greenville",
+		"Hillcrest", "Maplewood", "Oakdale", "Westfield", "Ashford", "Kingsport",
+	}
+	words = []string{
+		"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit",
+		"sed", "do", "eiusmod", "tempor", "incididunt", "ut", "labore", "et",
+		"dolore", "magna", "aliqua", "enim", "ad", "minim", "veniam", "quis",
+	}
+)
+
+// fake draws the parts of a fake from the keyed hash of the value it replaces
+type fake struct {
+	sum  []byte
+	seed []byte
+	n    uint32
+}
+
+func newFake(kind, value string) *fake {
+	keyMut.RLock()
+	mac := hmac.New(sha256.New, key)
+	keyMut.RUnlock()
+
+	mac.Write([]byte(kind))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	seed := mac.Sum(nil)
+	return &fake{sum: seed, seed: seed}
+}
+
+// read fills p from the hash, hashing the seed again once the hash is used up
+func (f *fake) read(p []byte) {
+	for len(p) != 0 {
+		if len(f.sum) == 0 {
+			f.n++
+			var n [4]byte
+			binary.BigEndian.PutUint32(n[:], f.n)
+			h := sha256.New()
+			h.Write(f.seed)
+			h.Write(n[:])
+			f.sum = h.Sum(nil)
+		}
+		c := copy(p, f.sum)
+		p, f.sum = p[c:], f.sum[c:]
+	}
+}
+
+func (f *fake) uint32() uint32 {
+	var b [4]byte
+	f.read(b[:])
+	return binary.BigEndian.Uint32(b[:])
+}
+
+func (f *fake) intn(n int) int {
+	return int(f.uint32() % uint32(n))
+}
+
+func (f *fake) pick(list []string) string {
+	return list[f.intn(len(list))]
+}
+
+// hex returns a hex number of n digits, it makes fakes of unique values unique
+func (f *fake) hex(n int) string {
+	var b strings.Builder
+	for b.Len() < n {
+		fmt.Fprintf(&b, "%08x", f.uint32())
+	}
+	return b.String()[:n]
+}
+
+// String returns the fake of the kind replacing value, at most maxLength
+// characters long unless maxLength is 0. Empty values stay empty, and values
+// of unknown kinds are replaced by Text fakes.
+func String(kind, value string, maxLength int) string {
+	return fakeString(kind, value, maxLength, false)
+}
+
+// Unique returns the fake of String for a column of a unique index, fakes of
+// different values get a hex number long enough to keep them different
+func Unique(kind, value string, maxLength int) string {
+	return fakeString(kind, value, maxLength, true)
+}
+
+func fakeString(kind, value string, maxLength int, unique bool) string {
+	if len(value) == 0 {
+		return value
+	}
+
+	hexLen := 6
+	if unique {
+		hexLen = 12
+	}
+
+	f := newFake(kind, value)
+	var s string
+	switch kind {
+	case Email:
+		s = fmt.Sprintf("%s.%s.%s@example.com", strings.ToLower(f.pick(firstNames)), strings.ToLower(f.pick(lastNames)), f.hex(hexLen))
+		return truncate(s, maxLength)
+	case Username:
+		s = fmt.Sprintf("%s_%s", strings.ToLower(f.pick(firstNames)), f.hex(hexLen))
+		return truncate(s, maxLength)
+	case URL:
+		s = fmt.Sprintf("https://example.com/%s", f.hex(hexLen*2))
+		return truncate(s, maxLength)
+	case Name:
+		s = f.pick(firstNames) + " " + f.pick(lastNames)
+	case FirstName:
+		s = f.pick(firstNames)
+	case LastName:
+		s = f.pick(lastNames)
+	case Phone:
+		// 555 numbers are reserved for fiction
+		s = fmt.Sprintf("+1-555-%03d-%04d", f.intn(1000), f.intn(10000))
+	case Address:
+		s = fmt.Sprintf("%d %s", 1+f.intn(999), f.pick(streets))
+	case City:
+		s = f.pick(cities)
+	case PostalCode:
+		s = fmt.Sprintf("%05d", f.intn(100000))
+	case IP:
+		// 198.18.0.0/15 is reserved for testing
+		s = fmt.Sprintf("198.%d.%d.%d", 18+f.intn(2), f.intn(256), 1+f.intn(254))
+	default:
+		s = text(f, utf8.RuneCountInString(value))
+	}
+
+	if !unique {
+		return truncate(s, maxLength)
+	}
+
+	// The fakes of the other kinds are few, the suffix keeps them unique
+	suffix := "-" + f.hex(hexLen)
+	if maxLength > 0 && maxLength <= len(suffix) {
+		return suffix[len(suffix)-maxLength:]
+	}
+	return truncate(s, maxLength-len(suffix)) + suffix
+}
+
+// text returns words about as long as a value of length characters
+func text(f *fake, length int) string {
+	var b strings.Builder
+	for b.Len() < length {
+		if b.Len() != 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(f.pick(words))
+	}
+	return truncate(b.String(), length)
+}
+
+func truncate(s string, maxLength int) string {
+	if maxLength <= 0 || utf8.RuneCountInString(s) <= maxLength {
+		return s
+	}
+	return string([]rune(s)[:maxLength])
+}
+
+// Time returns the fake replacing t, a time up to half a year before or after
+// it so that fakes of dates like birthdays stay plausible. Zero times stay
+// zero.
+func Time(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+
+	f := newFake("time", t.UTC().Format(time.RFC3339Nano))
+	return t.AddDate(0, 0, f.intn(365)-182)
+}
+
+// Bytes returns the fake replacing b, random bytes of the same length
+func Bytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+
+	f := newFake("bytes", string(b))
+	out := make([]byte, len(b))
+	f.read(out)
+	return out
+}
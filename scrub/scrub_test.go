@@ -0,0 +1,130 @@
+package scrub
+
+import (
+	"bytes"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestString(t *testing.T) {
+	SetKey([]byte("test"))
+
+	tests := []struct {
+		kind  string
+		match string
+	}{
+		{Email, `^[a-z]+\.[a-z]+\.[0-9a-f]{6}@example\.com$`},
+		{Name, `^[A-Z][a-z]+ [A-Z][a-z]+$`},
+		{FirstName, `^[A-Z][a-z]+$`},
+		{LastName, `^[A-Z][a-z]+$`},
+		{Username, `^[a-z]+_[0-9a-f]{6}$`},
+		{Phone, `^\+1-555-[0-9]{3}-[0-9]{4}$`},
+		{Address, `^[0-9]+ [A-Z][a-z]+ [A-Z][a-z]+$`},
+		{City, `^[A-Z][a-z]+$`},
+		{PostalCode, `^[0-9]{5}$`},
+		{IP, `^198\.1[89]\.[0-9]+\.[0-9]+$`},
+		{URL, `^https://example\.com/[0-9a-f]{12}$`},
+		{Text, `^[a-z ]+$`},
+	}
+
+	for _, test := range tests {
+		value := "someone@private.example"
+		got := String(test.kind, value, 0)
+		if !regexp.MustCompile(test.match).MatchString(got) {
+			t.Errorf("%s: %q does not match %s", test.kind, got, test.match)
+		}
+		if again := String(test.kind, value, 0); again != got {
+			t.Errorf("%s: fakes of the same value differ: %q and %q", test.kind, got, again)
+		}
+		if String(test.kind, "", 0) != "" {
+			t.Errorf("%s: empty value was replaced", test.kind)
+		}
+	}
+
+	if _, err := mail.ParseAddress(String(Email, "a@b.c", 0)); err != nil {
+		t.Error(err)
+	}
+	if got := String(Text, "a secret note", 0); utf8.RuneCountInString(got) != len("a secret note") {
+		t.Errorf("text fake %q is not as long as its value", got)
+	}
+	if got := String(Email, "a@b.c", 10); utf8.RuneCountInString(got) != 10 {
+		t.Errorf("fake %q is longer than its column", got)
+	}
+	if String(Email, "a@b.c", 0) == String(Email, "d@e.f", 0) {
+		t.Error("fakes of different emails are the same")
+	}
+}
+
+func TestSetKey(t *testing.T) {
+	SetKey([]byte("one"))
+	one := String(Email, "a@b.c", 0)
+	SetKey([]byte("two"))
+	two := String(Email, "a@b.c", 0)
+	if one == two {
+		t.Error("fakes do not depend on the key")
+	}
+}
+
+func TestTime(t *testing.T) {
+	SetKey([]byte("test"))
+
+	if !Time(time.Time{}).IsZero() {
+		t.Error("zero time was replaced")
+	}
+
+	born := time.Date(1990, 6, 1, 0, 0, 0, 0, time.UTC)
+	got := Time(born)
+	if diff := got.Sub(born); diff < -183*24*time.Hour || diff > 183*24*time.Hour {
+		t.Errorf("fake %s is too far from %s", got, born)
+	}
+	if !Time(born).Equal(got) {
+		t.Error("fakes of the same time differ")
+	}
+}
+
+func TestBytes(t *testing.T) {
+	SetKey([]byte("test"))
+
+	if Bytes(nil) != nil {
+		t.Error("nil bytes were replaced")
+	}
+
+	b := bytes.Repeat([]byte("secret"), 20)
+	got := Bytes(b)
+	if len(got) != len(b) || bytes.Equal(got, b) {
+		t.Errorf("fake %x does not replace %x", got, b)
+	}
+	if !bytes.Equal(Bytes(b), got) {
+		t.Error("fakes of the same bytes differ")
+	}
+}
+
+func TestUnique(t *testing.T) {
+	SetKey([]byte("test"))
+
+	seen := make(map[string]bool)
+	for i := 0; i < 2000; i++ {
+		got := Unique(City, strconv.Itoa(i), 0)
+		if !regexp.MustCompile(`^[A-Z][a-z]+-[0-9a-f]{12}$`).MatchString(got) {
+			t.Fatalf("%q is not a city with a suffix", got)
+		}
+		if seen[got] {
+			t.Fatalf("fake %q is not unique", got)
+		}
+		seen[got] = true
+	}
+
+	if got := Unique(Name, "someone", 20); utf8.RuneCountInString(got) != 20 || !regexp.MustCompile(`-[0-9a-f]{12}$`).MatchString(got) {
+		t.Errorf("fake %q does not keep its suffix when it is cut", got)
+	}
+	if got := Unique(Text, "someone", 5); len(got) != 5 {
+		t.Errorf("fake %q is longer than its column", got)
+	}
+	if got := Unique(Email, "a@b.c", 0); !regexp.MustCompile(`\.[0-9a-f]{12}@example\.com$`).MatchString(got) {
+		t.Errorf("email %q has no long hex number", got)
+	}
+}
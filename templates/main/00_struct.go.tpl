@@ -2,8 +2,30 @@
 {{- $orig_tbl_name := .Table.Name -}}
 
 // {{$alias.UpSingular}} is an object representing the database table.
+{{- with .Table.RowSecurity}}
+//
+// Row level security is enabled on {{$.Table.Name}}{{if .Forced}}, for its owner too{{end}}, the rows its
+// queries see and change are limited by its policies:
+{{- range .Policies}}
+//   - {{.Name}} for {{.Command}}{{if .Roles}} to {{.Roles | join ", "}}{{end}}
+{{- if .Using}}, using {{.Using | splitLines | join " "}}{{end}}
+{{- if .Check}}, with check {{.Check | splitLines | join " "}}{{end}}
+{{- end}}
+{{- if $.RLS.Tables}}
+//
+// Run its queries in a transaction with {{$.RLS.Setting}} set by WithRLS or SetRLS.
+{{- end}}
+{{- end}}
+{{- with .Table.Hypertable}}
+//
+{{- if .IsContinuousAggregate}}
+// {{$.Table.Name}} is a TimescaleDB continuous aggregate of {{.Aggregates}}, bucketed by {{.TimeColumn}}.
+{{- else}}
+// {{$.Table.Name}} is a TimescaleDB hypertable, partitioned by {{.TimeColumn}}{{if .ChunkInterval}} in chunks of {{.ChunkInterval}}{{end}}.
+{{- end}}
+{{- end}}
 type {{$alias.UpSingular}} struct {
-	{{- range $column := .Table.Columns -}}
+	{{- range $column := .StructColumns -}}
 	{{- $colAlias := $alias.Column $column.Name -}}
 	{{- $orig_col_name := $column.Name -}}
 	{{- range $column.Comment | splitLines -}} // {{ . }}
@@ -57,6 +79,8 @@ var {{$alias.UpSingular}}TableColumns = struct {
 	{{- if (oncePut $.DBTypes .Type)}}
 		{{$name := printf "whereHelper%s" (goVarname .Type)}}
 type {{$name}} struct { field string }
+		{{- /* encrypted values have a random nonce, the database cannot compare them */}}
+		{{if not (isEncrypted .Type) -}}
 func (w {{$name}}) EQ(x {{.Type}}) qm.QueryMod { return qmhelper.Where{{if .Nullable}}NullEQ(w.field, false, x){{else}}(w.field, qmhelper.EQ, x){{end}} }
 func (w {{$name}}) NEQ(x {{.Type}}) qm.QueryMod { return qmhelper.Where{{if .Nullable}}NullEQ(w.field, true, x){{else}}(w.field, qmhelper.NEQ, x){{end}} }
 func (w {{$name}}) LT(x {{.Type}}) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LT, x) }
@@ -66,6 +90,7 @@ func (w {{$name}}) GTE(x {{.Type}}) qm.QueryMod { return qmhelper.Where(w.field,
 		{{if or (eq .Type "string") (eq .Type "null.String") -}}
 func (w {{$name}}) LIKE(x {{.Type}}) qm.QueryMod { return qm.Where(w.field+" LIKE ?", x) }
 func (w {{$name}}) NLIKE(x {{.Type}}) qm.QueryMod { return qm.Where(w.field+" NOT LIKE ?", x) }
+func (w {{$name}}) EQFold(x {{.Type}}) qm.QueryMod { return qm.Where("lower("+w.field+") = lower(?)", x) }
 			{{- block "where_ilike_override" . }}{{- end}}
 		{{end -}}
 		{{if or (isPrimitive .Type) (isNullPrimitive .Type) (isEnumDBType .DBType) -}}
@@ -84,6 +109,7 @@ func (w {{$name}}) NIN(slice []{{convertNullToPrimitive .Type}}) qm.QueryMod {
 	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
 }
 		{{end -}}
+		{{end -}}
 	{{end -}}
 	{{if .Nullable -}}
 		{{- if (oncePut $.DBTypes (printf "%s.null" .Type))}}
@@ -92,17 +118,82 @@ func (w {{$name}}) IsNull() qm.QueryMod { return qmhelper.WhereIsNull(w.field) }
 func (w {{$name}}) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 		{{end -}}
 	{{end -}}
+	{{- /* case insensitive columns are compared as they are, the database ignores their case */}}
+	{{if and .CaseInsensitive (or (eq .Type "string") (eq .Type "null.String")) -}}
+		{{- if (oncePut $.DBTypes (printf "%s.ci" .Type))}}
+		{{$name := printf "whereHelperci_%s" (goVarname .Type)}}
+type {{$name}} struct { whereHelper{{goVarname .Type}} }
+
+func (w {{$name}}) EQFold(x {{.Type}}) qm.QueryMod { return qm.Where(w.field+" = ?", x) }
+		{{end -}}
+	{{end -}}
+	{{- /* jsonb columns get their own helpers, json columns have no containment or key operators */}}
+	{{if eq .DBType "jsonb" -}}
+		{{- if (oncePut $.DBTypes (printf "%s.jsonb" .Type))}}
+		{{$name := printf "whereHelperjsonb_%s" (goVarname .Type)}}
+type {{$name}} struct { whereHelper{{goVarname .Type}} }
+
+func (w {{$name}}) Contains(x interface{}) qm.QueryMod { return qmhelper.WhereJSONContains(w.field, x) }
+func (w {{$name}}) ContainedBy(x interface{}) qm.QueryMod { return qmhelper.WhereJSONContainedBy(w.field, x) }
+func (w {{$name}}) HasKey(key string) qm.QueryMod { return qmhelper.WhereJSONHasKey(w.field, key) }
+func (w {{$name}}) HasAnyKey(keys ...string) qm.QueryMod { return qmhelper.WhereJSONHasAnyKey(w.field, keys) }
+func (w {{$name}}) HasAllKeys(keys ...string) qm.QueryMod { return qmhelper.WhereJSONHasAllKeys(w.field, keys) }
+func (w {{$name}}) Path(path ...string) whereHelperjsonPath { return whereHelperjsonPath{field: w.field, path: path} }
+		{{end -}}
+		{{- if (oncePut $.DBTypes "jsonb.path")}}
+// whereHelperjsonPath compares the text at a path of keys and array indexes of
+// a jsonb column
+type whereHelperjsonPath struct {
+	field string
+	path  []string
+}
+
+func (w whereHelperjsonPath) EQ(x string) qm.QueryMod { return qmhelper.WhereJSONPath(w.field, w.path, qmhelper.EQ, x) }
+func (w whereHelperjsonPath) NEQ(x string) qm.QueryMod { return qmhelper.WhereJSONPath(w.field, w.path, qmhelper.NEQ, x) }
+func (w whereHelperjsonPath) LIKE(x string) qm.QueryMod { return qmhelper.WhereJSONPath(w.field, w.path, qmhelper.LIKE, x) }
+func (w whereHelperjsonPath) NLIKE(x string) qm.QueryMod { return qmhelper.WhereJSONPath(w.field, w.path, qmhelper.NLIKE, x) }
+func (w whereHelperjsonPath) IN(slice []string) qm.QueryMod { return qmhelper.WhereJSONPathIn(w.field, w.path, false, slice) }
+func (w whereHelperjsonPath) NIN(slice []string) qm.QueryMod { return qmhelper.WhereJSONPathIn(w.field, w.path, true, slice) }
+func (w whereHelperjsonPath) IsNull() qm.QueryMod { return qmhelper.WhereJSONPathIsNull(w.field, w.path, false) }
+func (w whereHelperjsonPath) IsNotNull() qm.QueryMod { return qmhelper.WhereJSONPathIsNull(w.field, w.path, true) }
+func (w whereHelperjsonPath) Contains(x interface{}) qm.QueryMod { return qmhelper.WhereJSONPathContains(w.field, w.path, x) }
+		{{end -}}
+	{{end -}}
 {{- end}}
 
 var {{$alias.UpSingular}}Where = struct {
 	{{range $column := .Table.Columns -}}
 	{{- $colAlias := $alias.Column $column.Name -}}
+	{{if eq $column.DBType "jsonb" -}}
+	{{$colAlias}} whereHelperjsonb_{{goVarname $column.Type}}
+	{{- else if and $column.CaseInsensitive (or (eq $column.Type "string") (eq $column.Type "null.String")) -}}
+	{{$colAlias}} whereHelperci_{{goVarname $column.Type}}
+	{{- else -}}
 	{{$colAlias}} whereHelper{{goVarname $column.Type}}
+	{{- end}}
 	{{end -}}
 }{
 	{{range $column := .Table.Columns -}}
 	{{- $colAlias := $alias.Column $column.Name -}}
+	{{if eq $column.DBType "jsonb" -}}
+	{{$colAlias}}: whereHelperjsonb_{{goVarname $column.Type}}{whereHelper{{goVarname $column.Type}}{field: "{{$.Table.Name | $.SchemaTable}}.{{$column.Name | $.Quotes}}"}},
+	{{- else if and $column.CaseInsensitive (or (eq $column.Type "string") (eq $column.Type "null.String")) -}}
+	{{$colAlias}}: whereHelperci_{{goVarname $column.Type}}{whereHelper{{goVarname $column.Type}}{field: "{{$.Table.Name | $.SchemaTable}}.{{$column.Name | $.Quotes}}"}},
+	{{- else -}}
 	{{$colAlias}}: whereHelper{{goVarname $column.Type}}{field: "{{$.Table.Name | $.SchemaTable}}.{{$column.Name | $.Quotes}}"},
+	{{- end}}
+	{{end -}}
+}
+
+// {{$alias.UpSingular}}Order has the orders of the columns of {{$alias.DownPlural}}, ascending unless made
+// descending with Desc, and their nulls ordered with NullsFirst or NullsLast.
+var {{$alias.UpSingular}}Order = struct {
+	{{range $column := .Table.Columns -}}
+	{{$alias.Column $column.Name}} qmhelper.Order
+	{{end -}}
+}{
+	{{range $column := .Table.Columns -}}
+	{{$alias.Column $column.Name}}: qmhelper.Order{Column: "{{$.Table.Name | $.SchemaTable}}.{{$column.Name | $.Quotes}}"},
 	{{end -}}
 }
 
@@ -125,6 +216,12 @@ var {{$alias.UpSingular}}Rels = struct {
 	{{- $relAlias := $.Aliases.ManyRelationship .ForeignTable .Name .JoinTable .JoinLocalFKeyName -}}
 	{{$relAlias.Local}} string
 	{{end -}}{{/* range tomany */}}
+
+	{{range $.Polymorphics -}}
+	{{- if eq .Table $.Table.Name -}}
+	{{.Name}} string
+	{{end -}}
+	{{end -}}{{/* range polymorphics */}}
 }{
 	{{range .Table.FKeys -}}
 	{{- $relAlias := $alias.Relationship .Name -}}
@@ -141,6 +238,12 @@ var {{$alias.UpSingular}}Rels = struct {
 	{{- $relAlias := $.Aliases.ManyRelationship .ForeignTable .Name .JoinTable .JoinLocalFKeyName -}}
 	{{$relAlias.Local}}: "{{$relAlias.Local}}",
 	{{end -}}{{/* range tomany */}}
+
+	{{range $.Polymorphics -}}
+	{{- if eq .Table $.Table.Name -}}
+	{{.Name}}: "{{.Name}}",
+	{{end -}}
+	{{end -}}{{/* range polymorphics */}}
 }
 
 // {{$alias.DownSingular}}R is where relationships are stored.
@@ -162,6 +265,16 @@ type {{$alias.DownSingular}}R struct {
 	{{- $relAlias := $.Aliases.ManyRelationship .ForeignTable .Name .JoinTable .JoinLocalFKeyName -}}
 	{{$relAlias.Local}} {{printf "%sSlice" $ftable.UpSingular}} `{{generateTags $.Tags $relAlias.Local}}boil:"{{$relAlias.Local}}" json:"{{$relAlias.Local}}" toml:"{{$relAlias.Local}}" yaml:"{{$relAlias.Local}}"`
 	{{end -}}{{/* range tomany */}}
+
+	{{range $poly := $.Polymorphics -}}
+	{{- if eq $poly.Table $.Table.Name -}}
+	{{- range $poly.Targets -}}
+	{{- $ftable := $.Aliases.Table .Table -}}
+	{{- $field := printf "%s%s" $poly.Name $ftable.UpSingular -}}
+	{{$field}} *{{$ftable.UpSingular}} `{{generateTags $.Tags $field}}boil:"{{$field}}" json:"{{$field}}" toml:"{{$field}}" yaml:"{{$field}}"`
+	{{end -}}
+	{{- end -}}
+	{{end -}}{{/* range polymorphics */}}
 }
 
 // NewStruct creates a new relationship struct
@@ -205,6 +318,22 @@ func (r *{{$alias.DownSingular}}R) Get{{$relAlias.Local}}() {{printf "%sSlice" $
 
 {{end -}}
 
+{{- range $poly := $.Polymorphics -}}
+{{- if eq $poly.Table $.Table.Name -}}
+{{- range $poly.Targets -}}
+{{- $ftable := $.Aliases.Table .Table -}}
+{{- $field := printf "%s%s" $poly.Name $ftable.UpSingular -}}
+func (r *{{$alias.DownSingular}}R) Get{{$field}}() *{{$ftable.UpSingular}} {
+	if (r == nil) {
+    return nil
+	}
+  return r.{{$field}}
+}
+
+{{end -}}
+{{- end -}}
+{{- end -}}
+
 // {{$alias.DownSingular}}L is where Load methods for each relationship are stored.
 type {{$alias.DownSingular}}L struct{}
 {{end -}}
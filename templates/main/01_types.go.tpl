@@ -11,6 +11,9 @@ var (
 	{{$alias.DownSingular}}PrimaryKeyColumns     = []string{{"{"}}{{.Table.PKey.Columns | stringMap .StringFuncs.quoteWrap | join ", "}}{{"}"}}
 	{{end -}}
 	{{$alias.DownSingular}}GeneratedColumns = []string{{"{"}}{{.Table.Columns | filterColumnsByAuto true | columnNames | stringMap .StringFuncs.quoteWrap | join ","}}{{"}"}}
+	{{- with filterColumnsByIdentity .Table.Columns}}
+	{{$alias.DownSingular}}IdentityColumns = []string{{"{"}}{{. | columnNames | stringMap $.StringFuncs.quoteWrap | join ","}}{{"}"}}
+	{{- end}}
 )
 
 type (
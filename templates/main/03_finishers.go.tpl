@@ -1,4 +1,24 @@
 {{- $alias := .Aliases.Table .Table.Name}}
+{{- $schemaTable := .Table.Name | .SchemaTable}}
+{{- $tenant := .Tenancy.Scopes .Table.Name}}
+
+{{if .WithGenerics -}}
+// {{$alias.DownSingular}}Finder runs the select queries of the {{$alias.DownPlural}}
+var {{$alias.DownSingular}}Finder = queries.Finder[{{$alias.UpSingular}}]{
+	Pkg:   "{{.PkgName}}",
+	Table: "{{.Table.Name}}",
+	Model: "{{$alias.UpSingular}}",
+	{{- if .AlwaysWrapErrors}}
+	WrapNoRows: true,
+	{{- end}}
+	{{- if not .NoHooks}}
+	AfterSelect: func({{if .NoContext}}_{{else}}ctx{{end}} context.Context, exec boil.Executor, o *{{$alias.UpSingular}}) error {
+		return o.doAfterSelectHooks({{if .NoContext}}exec{{else}}ctx, exec.(boil.ContextExecutor){{end}})
+	},
+	{{- end}}
+}
+
+{{end -}}
 
 {{if .AddGlobal -}}
 // OneG returns a single {{$alias.DownSingular}} record from the query using the global executor.
@@ -34,8 +54,30 @@ func (q {{$alias.DownSingular}}Query) OneP({{if .NoContext}}exec boil.Executor{{
 
 {{end -}}
 
+{{if and .AlwaysWrapErrors .Table.IsView -}}
+// This is a dummy variable to prevent unused database/sql import error
+var _ = sql.ErrNoRows
+
+{{end -}}
 // One returns a single {{$alias.DownSingular}} record from the query.
 func (q {{$alias.DownSingular}}Query) One({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) (*{{$alias.UpSingular}}, error) {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{.Table.Name}}", "one")
+
+	{{end -}}
+	{{if $tenant -}}
+	if err := tenantScope(ctx, q.Query, "{{$schemaTable}}.{{$.Tenancy.Column | $.Quotes}}"); err != nil {
+		return nil, err
+	}
+
+	{{end -}}
+	{{if .WithGenerics -}}
+	return {{$alias.DownSingular}}Finder.One({{if .NoContext}}nil{{else}}ctx{{end}}, exec, q.Query)
+	{{- else -}}
 	o := &{{$alias.UpSingular}}{}
 
 	queries.SetLimit(q.Query, 1)
@@ -57,6 +99,7 @@ func (q {{$alias.DownSingular}}Query) One({{if .NoContext}}exec boil.Executor{{e
 	{{- end}}
 
 	return o, nil
+	{{- end}}
 }
 
 {{if .AddGlobal -}}
@@ -95,6 +138,23 @@ func (q {{$alias.DownSingular}}Query) AllP({{if .NoContext}}exec boil.Executor{{
 
 // All returns all {{$alias.UpSingular}} records from the query.
 func (q {{$alias.DownSingular}}Query) All({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) ({{$alias.UpSingular}}Slice, error) {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{.Table.Name}}", "all")
+
+	{{end -}}
+	{{if $tenant -}}
+	if err := tenantScope(ctx, q.Query, "{{$schemaTable}}.{{$.Tenancy.Column | $.Quotes}}"); err != nil {
+		return nil, err
+	}
+
+	{{end -}}
+	{{if .WithGenerics -}}
+	return {{$alias.DownSingular}}Finder.All({{if .NoContext}}nil{{else}}ctx{{end}}, exec, q.Query)
+	{{- else -}}
 	var o []*{{$alias.UpSingular}}
 
 	err := q.Bind({{if .NoContext}}nil{{else}}ctx{{end}}, exec, &o)
@@ -113,8 +173,96 @@ func (q {{$alias.DownSingular}}Query) All({{if .NoContext}}exec boil.Executor{{e
 	{{- end}}
 
 	return o, nil
+	{{- end}}
 }
 
+// OneOrNil returns a single {{$alias.DownSingular}} record from the query, or nil when the
+// query finds none.
+func (q {{$alias.DownSingular}}Query) OneOrNil({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) (*{{$alias.UpSingular}}, error) {
+	o, err := q.One({{if not .NoContext}}ctx, {{end -}} exec)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return o, err
+}
+
+{{if and .Table.PKey (eq (len .Table.PKey.Columns) 1) -}}
+{{- $pkColumn := .Table.GetColumn (index .Table.PKey.Columns 0) -}}
+{{- if isPrimitive $pkColumn.Type -}}
+{{- $pkField := $alias.Column $pkColumn.Name -}}
+// AllBy{{$pkField}} returns all {{$alias.UpSingular}} records from the query by their {{$pkColumn.Name}}.
+func (q {{$alias.DownSingular}}Query) AllBy{{$pkField}}({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) (map[{{$pkColumn.Type}}]*{{$alias.UpSingular}}, error) {
+	o, err := q.All({{if not .NoContext}}ctx, {{end -}} exec)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[{{$pkColumn.Type}}]*{{$alias.UpSingular}}, len(o))
+	for _, obj := range o {
+		m[obj.{{$pkField}}] = obj
+	}
+
+	return m, nil
+}
+
+{{end -}}
+{{- end -}}
+
+{{range .Table.Columns -}}
+{{- $field := $alias.Column .Name -}}
+// Pluck{{$field}} returns the {{.Name}} of the {{$alias.UpSingular}} records from the query,
+// without loading the rest of the records.
+func (q {{$alias.DownSingular}}Query) Pluck{{$field}}({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) ([]{{.Type}}, error) {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{$.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{$.Table.Name}}", "pluck")
+
+	{{end -}}
+	{{if $tenant -}}
+	if err := tenantScope(ctx, q.Query, "{{$schemaTable}}.{{$.Tenancy.Column | $.Quotes}}"); err != nil {
+		return nil, err
+	}
+
+	{{end -}}
+	queries.SetSelect(q.Query, []string{"{{$schemaTable}}.{{.Name | $.Quotes}}"})
+
+	{{if $.NoContext -}}
+	rows, err := q.Query.Query(exec)
+	{{else -}}
+	ctx, done, err := q.Query.WithTimeout(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	rows, err := q.Query.QueryContext(ctx, exec)
+	{{end -}}
+	if err != nil {
+		return nil, errors.Wrap(err, "{{$.PkgName}}: failed to pluck {{$.Table.Name}} {{.Name}}")
+	}
+	defer rows.Close()
+
+	var values []{{.Type}}
+	for rows.Next() {
+		var v {{.Type}}
+		if err := rows.Scan(&v); err != nil {
+			return nil, errors.Wrap(err, "{{$.PkgName}}: failed to scan plucked {{$.Table.Name}} {{.Name}}")
+		}
+		values = append(values, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "{{$.PkgName}}: failed to pluck {{$.Table.Name}} {{.Name}}")
+	}
+
+	return values, nil
+}
+
+{{end -}}
+
 {{if .AddGlobal -}}
 // CountG returns the count of all {{$alias.UpSingular}} records in the query using the global executor
 func (q {{$alias.DownSingular}}Query) CountG({{if not .NoContext}}ctx context.Context{{end}}) (int64, error) {
@@ -151,15 +299,34 @@ func (q {{$alias.DownSingular}}Query) CountP({{if .NoContext}}exec boil.Executor
 
 // Count returns the count of all {{$alias.UpSingular}} records in the query.
 func (q {{$alias.DownSingular}}Query) Count({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) (int64, error) {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{.Table.Name}}", "count")
+
+	{{end -}}
+	{{if $tenant -}}
+	if err := tenantScope(ctx, q.Query, "{{$schemaTable}}.{{$.Tenancy.Column | $.Quotes}}"); err != nil {
+		return 0, err
+	}
+
+	{{end -}}
 	var count int64
 
-	queries.SetSelect(q.Query, nil)
-	queries.SetCount(q.Query)
+	queries.SetCountRows(q.Query)
 
 	{{if .NoContext -}}
 	err := q.Query.QueryRow(exec).Scan(&count)
 	{{else -}}
-	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	ctx, done, err := q.Query.WithTimeout(ctx, exec)
+	if err != nil {
+		return 0, err
+	}
+	defer done()
+
+	err = q.Query.QueryRowContext(ctx, exec).Scan(&count)
 	{{end -}}
 	if err != nil {
 		return 0, errors.Wrap(err, "{{.PkgName}}: failed to count {{.Table.Name}} rows")
@@ -168,6 +335,23 @@ func (q {{$alias.DownSingular}}Query) Count({{if .NoContext}}exec boil.Executor{
 	return count, nil
 }
 
+// Explain runs EXPLAIN on the query and returns its plan, with analyze the query
+// is run to measure the plan too.
+func (q {{$alias.DownSingular}}Query) Explain({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, analyze bool) (*queries.Plan, error) {
+	{{if $tenant -}}
+	if err := tenantScope(ctx, q.Query, "{{$schemaTable}}.{{$.Tenancy.Column | $.Quotes}}"); err != nil {
+		return nil, err
+	}
+
+	{{end -}}
+	{{if .NoContext -}}
+	return q.Query.Explain(exec, analyze)
+	{{- else -}}
+	return q.Query.ExplainContext(ctx, exec, analyze)
+	{{- end}}
+}
+
+{{if not .NoExists -}}
 {{if .AddGlobal -}}
 // ExistsG checks if the row exists in the table using the global executor.
 func (q {{$alias.DownSingular}}Query) ExistsG({{if not .NoContext}}ctx context.Context{{end}}) (bool, error) {
@@ -204,16 +388,35 @@ func (q {{$alias.DownSingular}}Query) ExistsP({{if .NoContext}}exec boil.Executo
 
 // Exists checks if the row exists in the table.
 func (q {{$alias.DownSingular}}Query) Exists({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) (bool, error) {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{.Table.Name}}", "exists")
+
+	{{end -}}
+	{{if $tenant -}}
+	if err := tenantScope(ctx, q.Query, "{{$schemaTable}}.{{$.Tenancy.Column | $.Quotes}}"); err != nil {
+		return false, err
+	}
+
+	{{end -}}
 	var count int64
 
-	queries.SetSelect(q.Query, nil)
-	queries.SetCount(q.Query)
+	queries.SetCountRows(q.Query)
 	queries.SetLimit(q.Query, 1)
 
 	{{if .NoContext -}}
 	err := q.Query.QueryRow(exec).Scan(&count)
 	{{else -}}
-	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	ctx, done, err := q.Query.WithTimeout(ctx, exec)
+	if err != nil {
+		return false, err
+	}
+	defer done()
+
+	err = q.Query.QueryRowContext(ctx, exec).Scan(&count)
 	{{end -}}
 	if err != nil {
 		return false, errors.Wrap(err, "{{.PkgName}}: failed to check if {{.Table.Name}} exists")
@@ -221,3 +424,4 @@ func (q {{$alias.DownSingular}}Query) Exists({{if .NoContext}}exec boil.Executor
 
 	return count > 0, nil
 }
+{{- end}}
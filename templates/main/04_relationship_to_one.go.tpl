@@ -15,5 +15,17 @@ func (o *{{$ltable.UpSingular}}) {{$rel.Foreign}}(mods ...qm.QueryMod) ({{$ftabl
 
 	return {{$ftable.UpPlural}}(queryMods...)
 }
+		{{- if $fkey.Nullable}}
+
+// Find{{$rel.Foreign}} returns the {{$ftable.DownSingular}} pointed to by the foreign key, nil without an error
+// when the foreign key is null or points to no {{$ftable.DownSingular}} matching the query mods.
+func (o *{{$ltable.UpSingular}}) Find{{$rel.Foreign}}({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, mods ...qm.QueryMod) (*{{$ftable.UpSingular}}, error) {
+	if queries.IsNil(o.{{$ltable.Column $fkey.Column}}) {
+		return nil, nil
+	}
+
+	return o.{{$rel.Foreign}}(mods...).OneOrNil({{if not $.NoContext}}ctx, {{end -}} exec)
+}
+		{{- end -}}
 {{- end -}}
 {{- end -}}
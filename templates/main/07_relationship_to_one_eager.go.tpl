@@ -12,6 +12,14 @@
 // Load{{$rel.Foreign}} allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func ({{$ltable.DownSingular}}L) Load{{$rel.Foreign}}({{if $.NoContext}}e boil.Executor{{else}}ctx context.Context, e boil.ContextExecutor{{end}}, singular bool, {{$arg}} interface{}, mods queries.Applicator) error {
+	{{if $.WithOTel -}}
+	e = boil.TraceExecutor(e, "{{$fkey.ForeignTable}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	e = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(e, "{{$fkey.ForeignTable}}", "load")
+
+	{{end -}}
 	var slice []*{{$ltable.UpSingular}}
 	var object *{{$ltable.UpSingular}}
 
@@ -42,6 +50,9 @@ func ({{$ltable.DownSingular}}L) Load{{$rel.Foreign}}({{if $.NoContext}}e boil.E
 		if object.R == nil {
 			object.R = &{{$ltable.DownSingular}}R{}
 		}
+		{{if $fkey.Nullable -}}
+		object.R.{{$rel.Foreign}} = nil
+		{{end -}}
 		{{if $usesPrimitives -}}
 		args = append(args, object.{{$col}})
 		{{else -}}
@@ -55,6 +66,9 @@ func ({{$ltable.DownSingular}}L) Load{{$rel.Foreign}}({{if $.NoContext}}e boil.E
 			if obj.R == nil {
 				obj.R = &{{$ltable.DownSingular}}R{}
 			}
+			{{if $fkey.Nullable -}}
+			obj.R.{{$rel.Foreign}} = nil
+			{{end}}
 
 			for _, a := range args {
 				{{if $usesPrimitives -}}
@@ -94,6 +108,12 @@ func ({{$ltable.DownSingular}}L) Load{{$rel.Foreign}}({{if $.NoContext}}e boil.E
 	{{if $.NoContext -}}
 	results, err := query.Query(e)
 	{{else -}}
+	ctx, done, err := query.WithTimeout(ctx, e)
+	if err != nil {
+		return err
+	}
+	defer done()
+
 	results, err := query.QueryContext(ctx, e)
 	{{end -}}
 	if err != nil {
@@ -7,12 +7,21 @@
 		{{- $col := $ltable.Column $rel.Column -}}
 		{{- $fcol := $ftable.Column $rel.ForeignColumn -}}
 		{{- $usesPrimitives := usesPrimitives $.Tables $rel.Table $rel.Column $rel.ForeignTable $rel.ForeignColumn -}}
+		{{- $nullable := ((getTable $.Tables $rel.Table).GetColumn $rel.Column).Nullable -}}
 		{{- $arg := printf "maybe%s" $ltable.UpSingular -}}
 		{{- $schemaForeignTable := $rel.ForeignTable | $.SchemaTable -}}
 		{{- $canSoftDelete := (getTable $.Tables $rel.ForeignTable).CanSoftDelete $.AutoColumns.Deleted }}
 // Load{{$relAlias.Local}} allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func ({{$ltable.DownSingular}}L) Load{{$relAlias.Local}}({{if $.NoContext}}e boil.Executor{{else}}ctx context.Context, e boil.ContextExecutor{{end}}, singular bool, {{$arg}} interface{}, mods queries.Applicator) error {
+	{{if $.WithOTel -}}
+	e = boil.TraceExecutor(e, "{{$rel.ForeignTable}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	e = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(e, "{{$rel.ForeignTable}}", "load")
+
+	{{end -}}
 	var slice []*{{$ltable.UpSingular}}
 	var object *{{$ltable.UpSingular}}
 
@@ -43,7 +52,13 @@ func ({{$ltable.DownSingular}}L) Load{{$relAlias.Local}}({{if $.NoContext}}e boi
 		if object.R == nil {
 			object.R = &{{$ltable.DownSingular}}R{}
 		}
+		{{if not $nullable -}}
 		args = append(args, object.{{$col}})
+		{{else -}}
+		if !queries.IsNil(object.{{$col}}) {
+			args = append(args, object.{{$col}})
+		}
+		{{end -}}
 	} else {
 		Outer:
 		for _, obj := range slice {
@@ -61,7 +76,13 @@ func ({{$ltable.DownSingular}}L) Load{{$relAlias.Local}}({{if $.NoContext}}e boi
 				}
 			}
 
+			{{if not $nullable -}}
 			args = append(args, obj.{{$col}})
+			{{else -}}
+			if !queries.IsNil(obj.{{$col}}) {
+				args = append(args, obj.{{$col}})
+			}
+			{{end -}}
 		}
 	}
 
@@ -97,6 +118,12 @@ func ({{$ltable.DownSingular}}L) Load{{$relAlias.Local}}({{if $.NoContext}}e boi
 	{{if $.NoContext -}}
 	results, err := query.Query(e)
 	{{else -}}
+	ctx, done, err := query.WithTimeout(ctx, e)
+	if err != nil {
+		return err
+	}
+	defer done()
+
 	results, err := query.QueryContext(ctx, e)
 	{{end -}}
 	if err != nil {
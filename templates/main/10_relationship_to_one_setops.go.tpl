@@ -1,4 +1,4 @@
-{{- if or .Table.IsJoinTable .Table.IsView -}}
+{{- if or .Table.IsJoinTable .Table.IsView .NoRelationshipSetters -}}
 {{- else -}}
 	{{- range $fkey := .Table.FKeys -}}
 		{{- $ltable := $.Aliases.Table $fkey.Table -}}
@@ -57,41 +57,45 @@ func (o *{{$ltable.UpSingular}}) Set{{$rel.Foreign}}GP({{if not $.NoContext}}ctx
 {{- if not $.NoBackReferencing}}
 // Adds o to related.R.{{$rel.Local}}.
 {{- end}}
+{{- if .Nullable}}
+// A nil related sets the foreign key to null, as Remove{{$rel.Foreign}} does.
+{{- end}}
 func (o *{{$ltable.UpSingular}}) Set{{$rel.Foreign}}({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, insert bool, related *{{$ftable.UpSingular}}) error {
+	{{if .Nullable -}}
+	if related == nil {
+		return o.Remove{{$rel.Foreign}}({{if not $.NoContext}}ctx, {{end -}} exec, o.R.Get{{$rel.Foreign}}())
+	}
+
+	{{end -}}
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{$fkey.Table}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{$fkey.Table}}", "set")
+
+	{{end -}}
 	var err error
 	if insert {
 		if err = related.Insert({{if not $.NoContext}}ctx, {{end -}} exec, boil.Infer()); err != nil {
-			return errors.Wrap(err, "failed to insert into foreign table")
+			return errors.Wrap(boil.ConvertError(err), "failed to insert into foreign table")
 		}
 	}
 
 	updateQuery := fmt.Sprintf(
 		"UPDATE {{$schemaTable}} SET %s WHERE %s",
-		strmangle.SetParamNames("{{$.LQ}}", "{{$.RQ}}", {{if $.Dialect.UseIndexPlaceholders}}1{{else}}0{{end}}, []string{{"{"}}"{{.Column}}"{{"}"}}),
-		strmangle.WhereClause("{{$.LQ}}", "{{$.RQ}}", {{if $.Dialect.UseIndexPlaceholders}}2{{else}}0{{end}}, {{$ltable.DownSingular}}PrimaryKeyColumns),
+		strmangle.SetParamNames("{{$.LQ}}", "{{$.RQ}}", {{$.Dialect.PlaceholderStart 1}}, []string{{"{"}}"{{.Column}}"{{"}"}}),
+		strmangle.WhereClause("{{$.LQ}}", "{{$.RQ}}", {{$.Dialect.PlaceholderStart 2}}, {{$ltable.DownSingular}}PrimaryKeyColumns),
 	)
 	values := []interface{}{related.{{$fcol}}, o.{{$.Table.PKey.Columns | stringMap (aliasCols $ltable) | join ", o."}}{{"}"}}
 
 	{{if $.NoContext -}}
-	if boil.DebugMode {
-		fmt.Fprintln(boil.DebugWriter, updateQuery)
-		fmt.Fprintln(boil.DebugWriter, values)
-	}
-	{{else -}}
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, updateQuery)
-		fmt.Fprintln(writer, values)
-	}
-	{{end -}}
-
-	{{if $.NoContext -}}
-	if _, err = exec.Exec(updateQuery, values...); err != nil {
-		return errors.Wrap(err, "failed to update local table")
+	if _, err = boil.DebugExec(exec, updateQuery, values...); err != nil {
+		return errors.Wrap(boil.ConvertError(err), "failed to update local table")
 	}
 	{{- else -}}
-	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-		return errors.Wrap(err, "failed to update local table")
+	if _, err = boil.DebugExecContext(ctx, exec, updateQuery, values...); err != nil {
+		return errors.Wrap(boil.ConvertError(err), "failed to update local table")
 	}
 	{{- end}}
 
@@ -182,6 +186,14 @@ func (o *{{$ltable.UpSingular}}) Remove{{$rel.Foreign}}GP({{if not $.NoContext}}
 // Removes o from all passed in related items' relationships struct.
 {{- end}}
 func (o *{{$ltable.UpSingular}}) Remove{{$rel.Foreign}}({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, related *{{$ftable.UpSingular}}) error {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{$fkey.Table}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{$fkey.Table}}", "remove")
+
+	{{end -}}
 	var err error
 
 	queries.SetScanner(&o.{{$col}}, nil)
@@ -190,7 +202,7 @@ func (o *{{$ltable.UpSingular}}) Remove{{$rel.Foreign}}({{if $.NoContext}}exec b
 	{{else -}}
 	if {{if not $.NoRowsAffected}}_, {{end -}} err = o.Update(ctx, exec, boil.Whitelist("{{.Column}}")); err != nil {
 	{{end -}}
-		return errors.Wrap(err, "failed to update local table")
+		return errors.Wrap(boil.ConvertError(err), "failed to update local table")
 	}
 
 	if o.R != nil {
@@ -205,11 +217,7 @@ func (o *{{$ltable.UpSingular}}) Remove{{$rel.Foreign}}({{if $.NoContext}}exec b
 	related.R.{{$rel.Local}} = nil
 	{{else -}}
 	for i, ri := range related.R.{{$rel.Local}} {
-		{{if $usesPrimitives -}}
-		if o.{{$col}} != ri.{{$col}} {
-		{{else -}}
-		if queries.Equal(o.{{$col}}, ri.{{$col}}) {
-		{{end -}}
+		if ri != o {
 			continue
 		}
 
@@ -1,4 +1,4 @@
-{{- if or .Table.IsJoinTable .Table.IsView -}}
+{{- if or .Table.IsJoinTable .Table.IsView .NoRelationshipSetters -}}
 {{- else -}}
 	{{- range $rel := .Table.ToOneRelationships -}}
 		{{- $ltable := $.Aliases.Table $rel.Table -}}
@@ -58,6 +58,14 @@ func (o *{{$ltable.UpSingular}}) Set{{$relAlias.Local}}GP({{if not $.NoContext}}
 // Adds o to related.R.{{$relAlias.Foreign}}.
 {{- end}}
 func (o *{{$ltable.UpSingular}}) Set{{$relAlias.Local}}({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, insert bool, related *{{$ftable.UpSingular}}) error {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{$rel.ForeignTable}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{$rel.ForeignTable}}", "set")
+
+	{{end -}}
 	var err error
 
 	if insert {
@@ -68,35 +76,22 @@ func (o *{{$ltable.UpSingular}}) Set{{$relAlias.Local}}({{if $.NoContext}}exec b
 		{{- end}}
 
 		if err = related.Insert({{if not $.NoContext}}ctx, {{end -}} exec, boil.Infer()); err != nil {
-			return errors.Wrap(err, "failed to insert into foreign table")
+			return errors.Wrap(boil.ConvertError(err), "failed to insert into foreign table")
 		}
 	} else {
 		updateQuery := fmt.Sprintf(
 			"UPDATE {{$schemaForeignTable}} SET %s WHERE %s",
-			strmangle.SetParamNames("{{$.LQ}}", "{{$.RQ}}", {{if $.Dialect.UseIndexPlaceholders}}1{{else}}0{{end}}, []string{{"{"}}"{{.ForeignColumn}}"{{"}"}}),
-			strmangle.WhereClause("{{$.LQ}}", "{{$.RQ}}", {{if $.Dialect.UseIndexPlaceholders}}2{{else}}0{{end}}, {{$ftable.DownSingular}}PrimaryKeyColumns),
+			strmangle.SetParamNames("{{$.LQ}}", "{{$.RQ}}", {{$.Dialect.PlaceholderStart 1}}, []string{{"{"}}"{{.ForeignColumn}}"{{"}"}}),
+			strmangle.WhereClause("{{$.LQ}}", "{{$.RQ}}", {{$.Dialect.PlaceholderStart 2}}, {{$ftable.DownSingular}}PrimaryKeyColumns),
 		)
 		values := []interface{}{o.{{$col}}, related.{{$foreignPKeyCols | stringMap (aliasCols $ftable) | join ", related."}}{{"}"}}
 
 		{{if $.NoContext -}}
-		if boil.DebugMode {
-			fmt.Fprintln(boil.DebugWriter, updateQuery)
-			fmt.Fprintln(boil.DebugWriter, values)
-		}
-		{{else -}}
-		if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-			fmt.Fprintln(writer, updateQuery)
-			fmt.Fprintln(writer, values)
-		}
-		{{end -}}
-
-		{{if $.NoContext -}}
-		if _, err = exec.Exec(updateQuery, values...); err != nil {
+		if _, err = boil.DebugExec(exec, updateQuery, values...); err != nil {
 		{{else -}}
-		if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		if _, err = boil.DebugExecContext(ctx, exec, updateQuery, values...); err != nil {
 		{{end -}}
-			return errors.Wrap(err, "failed to update foreign table")
+			return errors.Wrap(boil.ConvertError(err), "failed to update foreign table")
 		}
 
 		{{if $usesPrimitives -}}
@@ -178,11 +173,19 @@ func (o *{{$ltable.UpSingular}}) Remove{{$relAlias.Local}}GP({{if not $.NoContex
 // Removes o from all passed in related items' relationships struct.
 {{- end}}
 func (o *{{$ltable.UpSingular}}) Remove{{$relAlias.Local}}({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, related *{{$ftable.UpSingular}}) error {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{$rel.ForeignTable}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{$rel.ForeignTable}}", "remove")
+
+	{{end -}}
 	var err error
 
 	queries.SetScanner(&related.{{$fcol}}, nil)
 	if {{if not $.NoRowsAffected}}_, {{end -}} err = related.Update({{if not $.NoContext}}ctx, {{end -}} exec, boil.Whitelist("{{.ForeignColumn}}")); err != nil {
-		return errors.Wrap(err, "failed to update local table")
+		return errors.Wrap(boil.ConvertError(err), "failed to update local table")
 	}
 
 	if o.R != nil {
@@ -1,4 +1,4 @@
-{{- if or .Table.IsJoinTable .Table.IsView -}}
+{{- if or .Table.IsJoinTable .Table.IsView .NoRelationshipSetters -}}
 {{- else -}}
 	{{- $table := .Table -}}
 	{{- range $rel := .Table.ToManyRelationships -}}
@@ -63,6 +63,14 @@ func (o *{{$ltable.UpSingular}}) Add{{$relAlias.Local}}GP({{if not $.NoContext}}
 // Sets related.R.{{$relAlias.Foreign}} appropriately.
 {{- end}}
 func (o *{{$ltable.UpSingular}}) Add{{$relAlias.Local}}({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, insert bool, related ...*{{$ftable.UpSingular}}) error {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{if $rel.ToJoinTable}}{{$rel.JoinTable}}{{else}}{{$rel.ForeignTable}}{{end}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{if $rel.ToJoinTable}}{{$rel.JoinTable}}{{else}}{{$rel.ForeignTable}}{{end}}", "add")
+
+	{{end -}}
 	var err error
 	for _, rel := range related {
 		if insert {
@@ -75,35 +83,22 @@ func (o *{{$ltable.UpSingular}}) Add{{$relAlias.Local}}({{if $.NoContext}}exec b
 			{{end -}}
 
 			if err = rel.Insert({{if not $.NoContext}}ctx, {{end -}} exec, boil.Infer()); err != nil {
-				return errors.Wrap(err, "failed to insert into foreign table")
+				return errors.Wrap(boil.ConvertError(err), "failed to insert into foreign table")
 			}
 		}{{if not .ToJoinTable}} else {
 			updateQuery := fmt.Sprintf(
 				"UPDATE {{$schemaForeignTable}} SET %s WHERE %s",
-				strmangle.SetParamNames("{{$.LQ}}", "{{$.RQ}}", {{if $.Dialect.UseIndexPlaceholders}}1{{else}}0{{end}}, []string{{"{"}}"{{.ForeignColumn}}"{{"}"}}),
-				strmangle.WhereClause("{{$.LQ}}", "{{$.RQ}}", {{if $.Dialect.UseIndexPlaceholders}}2{{else}}0{{end}}, {{$ftable.DownSingular}}PrimaryKeyColumns),
+				strmangle.SetParamNames("{{$.LQ}}", "{{$.RQ}}", {{$.Dialect.PlaceholderStart 1}}, []string{{"{"}}"{{.ForeignColumn}}"{{"}"}}),
+				strmangle.WhereClause("{{$.LQ}}", "{{$.RQ}}", {{$.Dialect.PlaceholderStart 2}}, {{$ftable.DownSingular}}PrimaryKeyColumns),
 			)
 			values := []interface{}{o.{{$col}}, rel.{{$foreignPKeyCols | stringMap (aliasCols $ftable) | join ", rel."}}{{"}"}}
 
 			{{if $.NoContext -}}
-			if boil.DebugMode {
-				fmt.Fprintln(boil.DebugWriter, updateQuery)
-				fmt.Fprintln(boil.DebugWriter, values)
-			}
-			{{else -}}
-			if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-				fmt.Fprintln(writer, updateQuery)
-				fmt.Fprintln(writer, values)
-			}
-			{{end -}}
-
-			{{if $.NoContext -}}
-			if _, err = exec.Exec(updateQuery, values...); err != nil {
+			if _, err = boil.DebugExec(exec, updateQuery, values...); err != nil {
 			{{else -}}
-			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+			if _, err = boil.DebugExecContext(ctx, exec, updateQuery, values...); err != nil {
 			{{end -}}
-				return errors.Wrap(err, "failed to update foreign table")
+				return errors.Wrap(boil.ConvertError(err), "failed to update foreign table")
 			}
 
 			{{if $usesPrimitives -}}
@@ -116,29 +111,16 @@ func (o *{{$ltable.UpSingular}}) Add{{$relAlias.Local}}({{if $.NoContext}}exec b
 
 	{{if .ToJoinTable -}}
 	for _, rel := range related {
-		query := "insert into {{.JoinTable | $.SchemaTable}} ({{.JoinLocalColumn | $.Quotes}}, {{.JoinForeignColumn | $.Quotes}}) values {{if $.Dialect.UseIndexPlaceholders}}($1, $2){{else}}(?, ?){{end}}"
+		query := "insert into {{.JoinTable | $.SchemaTable}} ({{.JoinLocalColumn | $.Quotes}}, {{.JoinForeignColumn | $.Quotes}}) values ({{$.Dialect.Placeholder 1}}, {{$.Dialect.Placeholder 2}})"
 		values := []interface{}{{"{"}}o.{{$col}}, rel.{{$fcol}}}
 
 		{{if $.NoContext -}}
-		if boil.DebugMode {
-			fmt.Fprintln(boil.DebugWriter, query)
-			fmt.Fprintln(boil.DebugWriter, values)
-		}
+		_, err = boil.DebugExec(exec, query, values...)
 		{{else -}}
-		if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-			fmt.Fprintln(writer, query)
-			fmt.Fprintln(writer, values)
-		}
-		{{end -}}
-
-		{{if $.NoContext -}}
-		_, err = exec.Exec(query, values...)
-		{{else -}}
-		_, err = exec.ExecContext(ctx, query, values...)
+		_, err = boil.DebugExecContext(ctx, exec, query, values...)
 		{{end -}}
 		if err != nil {
-			return errors.Wrap(err, "failed to insert into join table")
+			return errors.Wrap(boil.ConvertError(err), "failed to insert into join table")
 		}
 	}
 	{{end -}}
@@ -240,33 +222,28 @@ func (o *{{$ltable.UpSingular}}) Set{{$relAlias.Local}}GP({{if not $.NoContext}}
 // Sets related.R.{{$relAlias.Foreign}}'s {{$relAlias.Local}} accordingly.
 {{- end}}
 func (o *{{$ltable.UpSingular}}) Set{{$relAlias.Local}}({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, insert bool, related ...*{{$ftable.UpSingular}}) error {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{if $rel.ToJoinTable}}{{$rel.JoinTable}}{{else}}{{$rel.ForeignTable}}{{end}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{if $rel.ToJoinTable}}{{$rel.JoinTable}}{{else}}{{$rel.ForeignTable}}{{end}}", "set")
+
+	{{end -}}
 	{{if .ToJoinTable -}}
-	query := "delete from {{.JoinTable | $.SchemaTable}} where {{.JoinLocalColumn | $.Quotes}} = {{if $.Dialect.UseIndexPlaceholders}}$1{{else}}?{{end}}"
+	query := "delete from {{.JoinTable | $.SchemaTable}} where {{.JoinLocalColumn | $.Quotes}} = {{$.Dialect.Placeholder 1}}"
 	values := []interface{}{{"{"}}o.{{$col}}}
 	{{else -}}
-	query := "update {{.ForeignTable | $.SchemaTable}} set {{.ForeignColumn | $.Quotes}} = null where {{.ForeignColumn | $.Quotes}} = {{if $.Dialect.UseIndexPlaceholders}}$1{{else}}?{{end}}"
+	query := "update {{.ForeignTable | $.SchemaTable}} set {{.ForeignColumn | $.Quotes}} = null where {{.ForeignColumn | $.Quotes}} = {{$.Dialect.Placeholder 1}}"
 	values := []interface{}{{"{"}}o.{{$col}}}
 	{{end -}}
 	{{if $.NoContext -}}
-	if boil.DebugMode {
-		fmt.Fprintln(boil.DebugWriter, query)
-		fmt.Fprintln(boil.DebugWriter, values)
-	}
+	_, err := boil.DebugExec(exec, query, values...)
 	{{else -}}
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, query)
-		fmt.Fprintln(writer, values)
-	}
-	{{end -}}
-
-	{{if $.NoContext -}}
-	_, err := exec.Exec(query, values...)
-	{{else -}}
-	_, err := exec.ExecContext(ctx, query, values...)
+	_, err := boil.DebugExecContext(ctx, exec, query, values...)
 	{{end -}}
 	if err != nil {
-		return errors.Wrap(err, "failed to remove relationships before set")
+		return errors.Wrap(boil.ConvertError(err), "failed to remove relationships before set")
 	}
 
 	{{if and .ToJoinTable (not $.NoBackReferencing) -}}
@@ -343,6 +320,14 @@ func (o *{{$ltable.UpSingular}}) Remove{{$relAlias.Local}}GP({{if not $.NoContex
 // Sets related.R.{{$relAlias.Foreign}}.
 {{- end}}
 func (o *{{$ltable.UpSingular}}) Remove{{$relAlias.Local}}({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, related ...*{{$ftable.UpSingular}}) error {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{if $rel.ToJoinTable}}{{$rel.JoinTable}}{{else}}{{$rel.ForeignTable}}{{end}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{if $rel.ToJoinTable}}{{$rel.JoinTable}}{{else}}{{$rel.ForeignTable}}{{end}}", "remove")
+
+	{{end -}}
 	if len(related) == 0 {
 		return nil
 	}
@@ -350,8 +335,8 @@ func (o *{{$ltable.UpSingular}}) Remove{{$relAlias.Local}}({{if $.NoContext}}exe
 	var err error
 	{{if .ToJoinTable -}}
 	query := fmt.Sprintf(
-		"delete from {{.JoinTable | $.SchemaTable}} where {{.JoinLocalColumn | $.Quotes}} = {{if $.Dialect.UseIndexPlaceholders}}$1{{else}}?{{end}} and {{.JoinForeignColumn | $.Quotes}} in (%s)",
-		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+		"delete from {{.JoinTable | $.SchemaTable}} where {{.JoinLocalColumn | $.Quotes}} = {{$.Dialect.Placeholder 1}} and {{.JoinForeignColumn | $.Quotes}} in (%s)",
+		dialect.Placeholders(len(related), 2, 1),
 	)
 	values := []interface{}{{"{"}}o.{{$col}}}
 	for _, rel := range related {
@@ -359,25 +344,12 @@ func (o *{{$ltable.UpSingular}}) Remove{{$relAlias.Local}}({{if $.NoContext}}exe
 	}
 
 	{{if $.NoContext -}}
-	if boil.DebugMode {
-		fmt.Fprintln(boil.DebugWriter, query)
-		fmt.Fprintln(boil.DebugWriter, values)
-	}
-	{{else -}}
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, query)
-		fmt.Fprintln(writer, values)
-	}
-	{{end -}}
-
-	{{if $.NoContext -}}
-	_, err = exec.Exec(query, values...)
+	_, err = boil.DebugExec(exec, query, values...)
 	{{else -}}
-	_, err = exec.ExecContext(ctx, query, values...)
+	_, err = boil.DebugExecContext(ctx, exec, query, values...)
 	{{end -}}
 	if err != nil {
-		return errors.Wrap(err, "failed to remove relationships before set")
+		return errors.Wrap(boil.ConvertError(err), "failed to remove relationships before set")
 	}
 	{{else -}}
 	for _, rel := range related {
@@ -4,7 +4,10 @@
 {{- $colDefs := sqlColDefinitions .Table.Columns .Table.PKey.Columns -}}
 {{- $pkNames := $colDefs.Names | stringMap (aliasCols $alias) | stringMap .StringFuncs.camelCase | stringMap .StringFuncs.replaceReserved -}}
 {{- $pkArgs := joinSlices " " $pkNames $colDefs.Types | join ", " -}}
-{{- $canSoftDelete := .Table.CanSoftDelete $.AutoColumns.Deleted }}
+{{- $canSoftDelete := .Table.CanSoftDelete $.AutoColumns.Deleted -}}
+{{- $tenant := .Tenancy.Scopes .Table.Name -}}
+{{- /* the identity map is not keyed by tenant, so the rows of other tenants are never found in it */ -}}
+{{- $identityMap := and .WithIdentityMap (not $tenant) }}
 {{if .AddGlobal -}}
 // Find{{$alias.UpSingular}}G retrieves a single record by ID.
 func Find{{$alias.UpSingular}}G({{if not .NoContext}}ctx context.Context, {{end -}} {{$pkArgs}}, selectCols ...string) (*{{$alias.UpSingular}}, error) {
@@ -42,19 +45,60 @@ func Find{{$alias.UpSingular}}GP({{if not .NoContext}}ctx context.Context, {{end
 // Find{{$alias.UpSingular}} retrieves a single record by ID with an executor.
 // If selectCols is empty Find will return all columns.
 func Find{{$alias.UpSingular}}({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, {{$pkArgs}}, selectCols ...string) (*{{$alias.UpSingular}}, error) {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{.Table.Name}}", "find")
+
+	{{end -}}
+	{{if $tenant -}}
+	tenantCond, tenantArgs, err := tenantWhere(ctx, "{{$.Tenancy.Column | $.Quotes}}", {{len .Table.PKey.Columns | add 1}})
+	if err != nil {
+		return nil, err
+	}
+
+	{{end -}}
+	{{if $identityMap -}}
+	identityMap := boil.IdentityMapFrom(exec)
+	if len(selectCols) == 0 {
+		if o, ok := identityMap.Get("{{.Table.Name}}", {{$pkNames | join ", "}}); ok {
+			return o.(*{{$alias.UpSingular}}), nil
+		}
+	}
+
+	{{end -}}
+	{{if not .WithGenerics -}}
 	{{$alias.DownSingular}}Obj := &{{$alias.UpSingular}}{}
 
+	{{end -}}
 	sel := "*"
 	if len(selectCols) > 0 {
-		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+		sel = strings.Join(dialect.QuoteSlice(selectCols), ",")
 	}
 	query := fmt.Sprintf(
-		"select %s from {{.Table.Name | .SchemaTable}} where {{if .Dialect.UseIndexPlaceholders}}{{whereClause .LQ .RQ 1 .Table.PKey.Columns}}{{else}}{{whereClause .LQ .RQ 0 .Table.PKey.Columns}}{{end}}{{if and .AddSoftDeletes $canSoftDelete}} and {{or $.AutoColumns.Deleted "deleted_at" | $.Quotes}} is null{{end}}", sel,
+		"select %s from {{.Table.Name | .SchemaTable}} where {{whereClause .LQ .RQ (.Dialect.PlaceholderStart 1) .Table.PKey.Columns}}{{if $tenant}}%s{{end}}{{if and .AddSoftDeletes $canSoftDelete}} and {{or $.AutoColumns.Deleted "deleted_at" | $.Quotes}} is null{{end}}", sel,{{if $tenant}} tenantCond,{{end}}
 	)
 
+	{{if $tenant -}}
+	q := queries.Raw(query, append([]interface{}{ {{- $pkNames | join ", " -}} }, tenantArgs...)...)
+	{{- else -}}
 	q := queries.Raw(query, {{$pkNames | join ", "}})
+	{{- end}}
+	{{- if and .WithGenerics $identityMap}}
+
+	o, err := {{$alias.DownSingular}}Finder.Find({{if .NoContext}}nil{{else}}ctx{{end}}, exec, q)
+	if err == nil && len(selectCols) == 0 {
+		identityMap.Set("{{.Table.Name}}", o, {{$pkNames | join ", "}})
+	}
+	return o, err
+	{{- else if .WithGenerics}}
+
+	return {{$alias.DownSingular}}Finder.Find({{if .NoContext}}nil{{else}}ctx{{end}}, exec, q)
+	{{- else}}
 
-	err := q.Bind({{if not .NoContext}}ctx{{else}}nil{{end}}, exec, {{$alias.DownSingular}}Obj)
+	{{if $tenant}}err = {{else}}err := {{end}}q.Bind({{if not .NoContext}}ctx{{else}}nil{{end}}, exec, {{$alias.DownSingular}}Obj)
 	if err != nil {
 		{{if not .AlwaysWrapErrors -}}
 		if errors.Is(err, sql.ErrNoRows) {
@@ -69,8 +113,15 @@ func Find{{$alias.UpSingular}}({{if .NoContext}}exec boil.Executor{{else}}ctx co
 		return {{$alias.DownSingular}}Obj, err
 	}
 	{{- end}}
+	{{- if $identityMap}}
+
+	if len(selectCols) == 0 {
+		identityMap.Set("{{.Table.Name}}", {{$alias.DownSingular}}Obj, {{$pkNames | join ", "}})
+	}
+	{{- end}}
 
 	return {{$alias.DownSingular}}Obj, nil
+	{{- end}}
 }
 
 {{- end -}}
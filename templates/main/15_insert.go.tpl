@@ -1,6 +1,7 @@
 {{- if or (not .Table.IsView) (.Table.ViewCapabilities.CanInsert) -}}
 {{- $alias := .Aliases.Table .Table.Name}}
 {{- $schemaTable := .Table.Name | .SchemaTable}}
+{{- $tenant := .Tenancy.Scopes .Table.Name}}
 {{if .AddGlobal -}}
 // InsertG a single record. See Insert for whitelist behavior description.
 func (o *{{$alias.UpSingular}}) InsertG({{if not .NoContext}}ctx context.Context, {{end -}} columns boil.Columns) error {
@@ -34,6 +35,14 @@ func (o *{{$alias.UpSingular}}) InsertGP({{if not .NoContext}}ctx context.Contex
 // Insert a single record using an executor.
 // See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
 func (o *{{$alias.UpSingular}}) Insert({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, columns boil.Columns) error {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{.Table.Name}}", "insert")
+
+	{{end -}}
 	if o == nil {
 		return errors.New("{{.PkgName}}: no {{.Table.Name}} provided for insertion")
 	}
@@ -46,6 +55,18 @@ func (o *{{$alias.UpSingular}}) Insert({{if .NoContext}}exec boil.Executor{{else
 		return err
 	}
 	{{- end}}
+	{{- if $tenant}}
+
+	if err := tenantCheck(ctx, o.{{$alias.Column $.Tenancy.Column}}); err != nil {
+		return err
+	}
+	{{- end}}
+	{{- if .AddValidation}}
+
+	if err := o.Validate(); err != nil {
+		return err
+	}
+	{{- end}}
 
 	nzDefaults := queries.NonZeroDefaultSet({{$alias.DownSingular}}ColumnsWithDefault, o)
 
@@ -61,7 +82,9 @@ func (o *{{$alias.UpSingular}}) Insert({{if .NoContext}}exec boil.Executor{{else
 			{{$alias.DownSingular}}ColumnsWithoutDefault,
 			nzDefaults,
 		)
-		{{- if filterColumnsByAuto true .Table.Columns }}
+		{{- if filterColumnsByIdentity .Table.Columns}}
+		wl = strmangle.SetComplement(wl, strmangle.SetComplement({{$alias.DownSingular}}GeneratedColumns, {{$alias.DownSingular}}IdentityColumns))
+		{{- else if filterColumnsByAuto true .Table.Columns }}
 		wl = strmangle.SetComplement(wl, {{$alias.DownSingular}}GeneratedColumns)
 		{{- end}}
 
@@ -74,7 +97,16 @@ func (o *{{$alias.UpSingular}}) Insert({{if .NoContext}}exec boil.Executor{{else
 			return err
 		}
 		if len(wl) != 0 {
-			cache.query = fmt.Sprintf("INSERT INTO {{$schemaTable}} ({{.LQ}}%s{{.RQ}}) %%sVALUES (%s)%%s", strings.Join(wl, "{{.RQ}},{{.LQ}}"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+			{{- if filterColumnsByIdentity .Table.Columns}}
+			// Identity columns generated always are only set overriding the system value
+			overriding := ""
+			if len(strmangle.SetComplement(wl, {{$alias.DownSingular}}IdentityColumns)) != len(wl) {
+				overriding = "OVERRIDING SYSTEM VALUE "
+			}
+			cache.query = fmt.Sprintf("INSERT INTO {{$schemaTable}} ({{.LQ}}%s{{.RQ}}) %%s%sVALUES (%s)%%s", strings.Join(wl, "{{.RQ}},{{.LQ}}"), overriding, dialect.Placeholders(len(wl), 1, 1))
+			{{- else}}
+			cache.query = fmt.Sprintf("INSERT INTO {{$schemaTable}} ({{.LQ}}%s{{.RQ}}) %%sVALUES (%s)%%s", strings.Join(wl, "{{.RQ}},{{.LQ}}"), dialect.Placeholders(len(wl), 1, 1))
+			{{- end}}
 		} else {
 			{{if .Dialect.UseDefaultKeyword -}}
 			cache.query = "INSERT INTO {{$schemaTable}} %sDEFAULT VALUES%s"
@@ -87,13 +119,11 @@ func (o *{{$alias.UpSingular}}) Insert({{if .NoContext}}exec boil.Executor{{else
 
 		if len(cache.retMapping) != 0 {
 			{{if .Dialect.UseLastInsertID -}}
-			cache.retQuery = fmt.Sprintf("SELECT {{.LQ}}%s{{.RQ}} FROM {{$schemaTable}} WHERE %s", strings.Join(returnColumns, "{{.RQ}},{{.LQ}}"), strmangle.WhereClause("{{.LQ}}", "{{.RQ}}", {{if .Dialect.UseIndexPlaceholders}}1{{else}}0{{end}}, {{$alias.DownSingular}}PrimaryKeyColumns))
-			{{else -}}
-				{{if .Dialect.UseOutputClause -}}
+			cache.retQuery = fmt.Sprintf("SELECT {{.LQ}}%s{{.RQ}} FROM {{$schemaTable}} WHERE %s", strings.Join(returnColumns, "{{.RQ}},{{.LQ}}"), strmangle.WhereClause("{{.LQ}}", "{{.RQ}}", {{.Dialect.PlaceholderStart 1}}, {{$alias.DownSingular}}PrimaryKeyColumns))
+			{{else if .Dialect.UseOutputClause -}}
 			queryOutput = fmt.Sprintf("OUTPUT INSERTED.{{.LQ}}%s{{.RQ}} ", strings.Join(returnColumns, "{{.RQ}},INSERTED.{{.LQ}}"))
-				{{else -}}
+			{{else if .Dialect.UseReturningClause -}}
 			queryReturning = fmt.Sprintf(" RETURNING {{.LQ}}%s{{.RQ}}", strings.Join(returnColumns, "{{.RQ}},{{.LQ}}"))
-				{{end -}}
 			{{end -}}
 		}
 
@@ -103,36 +133,23 @@ func (o *{{$alias.UpSingular}}) Insert({{if .NoContext}}exec boil.Executor{{else
 	value := reflect.Indirect(reflect.ValueOf(o))
 	vals := queries.ValuesFromMapping(value, cache.valueMapping)
 
-	{{if .NoContext -}}
-	if boil.DebugMode {
-		fmt.Fprintln(boil.DebugWriter, cache.query)
-		fmt.Fprintln(boil.DebugWriter, vals)
-	}
-	{{else -}}
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, cache.query)
-		fmt.Fprintln(writer, vals)
-	}
-	{{end -}}
-
 	{{if .Dialect.UseLastInsertID -}}
 	{{- $canLastInsertID := .Table.CanLastInsertID -}}
 	{{if $canLastInsertID -}}
 		{{if .NoContext -}}
-	result, err := exec.Exec(cache.query, vals...)
+	result, err := boil.DebugExec(exec, cache.query, vals...)
 		{{else -}}
-	result, err := exec.ExecContext(ctx, cache.query, vals...)
+	result, err := boil.DebugExecContext(ctx, exec, cache.query, vals...)
 		{{end -}}
 	{{else -}}
 		{{if .NoContext -}}
-	_, err = exec.Exec(cache.query, vals...)
+	_, err = boil.DebugExec(exec, cache.query, vals...)
 		{{else -}}
-	_, err = exec.ExecContext(ctx, cache.query, vals...)
+	_, err = boil.DebugExecContext(ctx, exec, cache.query, vals...)
 		{{end -}}
 	{{- end}}
 	if err != nil {
-		return errors.Wrap(err, "{{.PkgName}}: unable to insert into {{.Table.Name}}")
+		return errors.Wrap(boil.ConvertError(err), "{{.PkgName}}: unable to insert into {{.Table.Name}}")
 	}
 
 	{{if $canLastInsertID -}}
@@ -166,43 +183,30 @@ func (o *{{$alias.UpSingular}}) Insert({{if .NoContext}}exec boil.Executor{{else
 	}
 
 	{{if .NoContext -}}
-	if boil.DebugMode {
-		fmt.Fprintln(boil.DebugWriter, cache.retQuery)
-		fmt.Fprintln(boil.DebugWriter, identifierCols...)
-	}
+	err = boil.DebugQueryRow(exec, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
 	{{else -}}
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, cache.retQuery)
-		fmt.Fprintln(writer, identifierCols...)
-	}
-	{{end -}}
-
-	{{if .NoContext -}}
-	err = exec.QueryRow(cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
-	{{else -}}
-	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	err = boil.DebugQueryRowContext(ctx, exec, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
 	{{end -}}
 	if err != nil {
-		return errors.Wrap(err, "{{.PkgName}}: unable to populate default values for {{.Table.Name}}")
+		return errors.Wrap(boil.ConvertError(err), "{{.PkgName}}: unable to populate default values for {{.Table.Name}}")
 	}
 	{{else}}
 	if len(cache.retMapping) != 0 {
 		{{if .NoContext -}}
-		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+		err = boil.DebugQueryRow(exec, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
 		{{else -}}
-		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+		err = boil.DebugQueryRowContext(ctx, exec, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
 		{{end -}}
 	} else {
 		{{if .NoContext -}}
-		_, err = exec.Exec(cache.query, vals...)
+		_, err = boil.DebugExec(exec, cache.query, vals...)
 		{{else -}}
-		_, err = exec.ExecContext(ctx, cache.query, vals...)
+		_, err = boil.DebugExecContext(ctx, exec, cache.query, vals...)
 		{{end -}}
 	}
 
 	if err != nil {
-		return errors.Wrap(err, "{{.PkgName}}: unable to insert into {{.Table.Name}}")
+		return errors.Wrap(boil.ConvertError(err), "{{.PkgName}}: unable to insert into {{.Table.Name}}")
 	}
 	{{end}}
 
@@ -222,4 +226,97 @@ CacheNoHooks:
 	{{- end}}
 }
 
+
+{{if .AddGlobal -}}
+// Insert{{$alias.UpPlural}}FromQueryG inserts the rows selected by the query. See
+// Insert{{$alias.UpPlural}}FromQuery for the table and columns.
+func Insert{{$alias.UpPlural}}FromQueryG({{if not .NoContext}}ctx context.Context, {{end -}} into string, q {{$alias.DownSingular}}Query, columns ...string) {{if .NoRowsAffected}}error{{else}}(int64, error){{end -}} {
+	return Insert{{$alias.UpPlural}}FromQuery({{if .NoContext}}boil.GetDB(){{else}}ctx, boil.GetContextDB(){{end}}, into, q, columns...)
+}
+
+{{end -}}
+
+{{if .AddPanic -}}
+// Insert{{$alias.UpPlural}}FromQueryP inserts the rows selected by the query, and panics
+// on error. See Insert{{$alias.UpPlural}}FromQuery for the table and columns.
+func Insert{{$alias.UpPlural}}FromQueryP({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, into string, q {{$alias.DownSingular}}Query, columns ...string) {{if not .NoRowsAffected}}int64{{end -}} {
+	{{if not .NoRowsAffected}}rowsAff, {{end -}} err := Insert{{$alias.UpPlural}}FromQuery({{if not .NoContext}}ctx, {{end -}} exec, into, q, columns...)
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
+	{{- if not .NoRowsAffected}}
+
+	return rowsAff
+	{{end -}}
+}
+
+{{end -}}
+
+// Insert{{$alias.UpPlural}}FromQuery inserts the {{$alias.DownPlural}} selected by the query into
+// the table named by into, or into {{.Table.Name}} when it is empty, with a single
+// INSERT INTO ... SELECT, like when archiving rows into a table of the same
+// columns. The columns copied are all the columns of {{.Table.Name}} unless some are
+// given, leave out the generated keys to copy rows within {{.Table.Name}}. The select
+// list of the query is replaced and no hooks are run.
+func Insert{{$alias.UpPlural}}FromQuery({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, into string, q {{$alias.DownSingular}}Query, columns ...string) {{if .NoRowsAffected}}error{{else}}(int64, error){{end -}} {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{.Table.Name}}", "insert_from_query")
+
+	{{end -}}
+	if q.Query == nil {
+		return {{if not .NoRowsAffected}}0, {{end -}} errors.New("{{.PkgName}}: no {{$alias.DownSingular}}Query provided for insert from query")
+	}
+	{{- if $tenant}}
+
+	if err := tenantScope(ctx, q.Query, "{{$schemaTable}}.{{$.Tenancy.Column | $.Quotes}}"); err != nil {
+		return {{if not .NoRowsAffected}}0, {{end -}} err
+	}
+	{{- end}}
+
+	if len(into) == 0 {
+		into = "{{$schemaTable}}"
+	}
+	if len(columns) == 0 {
+		columns = {{$alias.DownSingular}}AllColumns
+	}
+
+	selectCols := make([]string, len(columns))
+	for i, c := range columns {
+		selectCols[i] = "{{$schemaTable}}." + c
+	}
+	queries.SetSelect(q.Query, selectCols)
+	queries.SetInsertInto(q.Query, into, columns...)
+
+	{{if .NoRowsAffected -}}
+		{{if .NoContext -}}
+	_, err := q.Query.Exec(exec)
+		{{else -}}
+	_, err := q.Query.ExecContext(ctx, exec)
+		{{end -}}
+	{{else -}}
+		{{if .NoContext -}}
+	result, err := q.Query.Exec(exec)
+		{{else -}}
+	result, err := q.Query.ExecContext(ctx, exec)
+		{{end -}}
+	{{end -}}
+	if err != nil {
+		return {{if not .NoRowsAffected}}0, {{end -}} errors.Wrap(boil.ConvertError(err), "{{.PkgName}}: unable to insert from query into {{.Table.Name}}")
+	}
+
+	{{if not .NoRowsAffected -}}
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "{{.PkgName}}: unable to retrieve rows affected for {{.Table.Name}}")
+	}
+
+	{{end -}}
+
+	return {{if not .NoRowsAffected}}rowsAff, {{end -}} nil
+}
+
 {{- end -}}
@@ -1,7 +1,12 @@
 {{- if .Table.IsView -}}
 {{- else -}}
 {{- $alias := .Aliases.Table .Table.Name -}}
-{{- $schemaTable := .Table.Name | .SchemaTable}}
+{{- $schemaTable := .Table.Name | .SchemaTable -}}
+{{- $tenant := .Tenancy.Scopes .Table.Name -}}
+{{- $keyVar := printf "%sPrimaryKey" $alias.DownSingular -}}
+{{- if $tenant -}}
+{{- $keyVar = printf "%sTenantKey" $alias.DownSingular -}}
+{{- end}}
 {{if .AddGlobal -}}
 // UpdateG a single {{$alias.UpSingular}} record using the global executor.
 // See Update for more documentation.
@@ -49,12 +54,30 @@ func (o *{{$alias.UpSingular}}) UpdateGP({{if not .NoContext}}ctx context.Contex
 func (o *{{$alias.UpSingular}}) Update({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, columns boil.Columns) {{if .NoRowsAffected}}error{{else}}(int64, error){{end -}} {
 	{{- template "timestamp_update_helper" . -}}
 
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{.Table.Name}}", "update")
+
+	{{end -}}
 	var err error
 	{{if not .NoHooks -}}
 	if err = o.doBeforeUpdateHooks({{if not .NoContext}}ctx, {{end -}} exec); err != nil {
 		return {{if not .NoRowsAffected}}0, {{end -}} err
 	}
 	{{end -}}
+	{{if $tenant -}}
+	if err = tenantCheck(ctx, o.{{$alias.Column $.Tenancy.Column}}); err != nil {
+		return {{if not .NoRowsAffected}}0, {{end -}} err
+	}
+	{{end -}}
+	{{if .AddValidation -}}
+	if err = o.Validate(); err != nil {
+		return {{if not .NoRowsAffected}}0, {{end -}} err
+	}
+	{{end -}}
 
 	key := makeCacheKey(columns, nil)
 	{{$alias.DownSingular}}UpdateCacheMut.RLock()
@@ -79,10 +102,10 @@ func (o *{{$alias.UpSingular}}) Update({{if .NoContext}}exec boil.Executor{{else
 		}
 
 		cache.query = fmt.Sprintf("UPDATE {{$schemaTable}} SET %s WHERE %s",
-			strmangle.SetParamNames("{{.LQ}}", "{{.RQ}}", {{if .Dialect.UseIndexPlaceholders}}1{{else}}0{{end}}, wl),
-			strmangle.WhereClause("{{.LQ}}", "{{.RQ}}", {{if .Dialect.UseIndexPlaceholders}}len(wl)+1{{else}}0{{end}}, {{$alias.DownSingular}}PrimaryKeyColumns),
+			strmangle.SetParamNames("{{.LQ}}", "{{.RQ}}", {{.Dialect.PlaceholderStart 1}}, wl),
+			strmangle.WhereClause("{{.LQ}}", "{{.RQ}}", dialect.PlaceholderStart(len(wl)+1), {{$keyVar}}Columns),
 		)
-		cache.valueMapping, err = queries.BindMapping({{$alias.DownSingular}}Type, {{$alias.DownSingular}}Mapping, append(wl, {{$alias.DownSingular}}PrimaryKeyColumns...))
+		cache.valueMapping, err = queries.BindMapping({{$alias.DownSingular}}Type, {{$alias.DownSingular}}Mapping, append(wl, {{$keyVar}}Columns...))
 		if err != nil {
 			return {{if not .NoRowsAffected}}0, {{end -}} err
 		}
@@ -90,37 +113,28 @@ func (o *{{$alias.UpSingular}}) Update({{if .NoContext}}exec boil.Executor{{else
 
 	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
 
-	{{if .NoContext -}}
-	if boil.DebugMode {
-		fmt.Fprintln(boil.DebugWriter, cache.query)
-		fmt.Fprintln(boil.DebugWriter, values)
-	}
-	{{else -}}
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, cache.query)
-		fmt.Fprintln(writer, values)
-	}
-	{{end -}}
-
 	{{if .NoRowsAffected -}}
 		{{if .NoContext -}}
-	_, err = exec.Exec(cache.query, values...)
+	_, err = boil.DebugExec(exec, cache.query, values...)
 		{{else -}}
-	_, err = exec.ExecContext(ctx, cache.query, values...)
+	_, err = boil.DebugExecContext(ctx, exec, cache.query, values...)
 		{{end -}}
 	{{else -}}
 	var result sql.Result
 		{{if .NoContext -}}
-	result, err = exec.Exec(cache.query, values...)
+	result, err = boil.DebugExec(exec, cache.query, values...)
 		{{else -}}
-	result, err = exec.ExecContext(ctx, cache.query, values...)
+	result, err = boil.DebugExecContext(ctx, exec, cache.query, values...)
 		{{end -}}
 	{{end -}}
 	if err != nil {
-		return {{if not .NoRowsAffected}}0, {{end -}} errors.Wrap(err, "{{.PkgName}}: unable to update {{.Table.Name}} row")
+		return {{if not .NoRowsAffected}}0, {{end -}} errors.Wrap(boil.ConvertError(err), "{{.PkgName}}: unable to update {{.Table.Name}} row")
 	}
 
+	{{if .WithIdentityMap -}}
+	boil.IdentityMapFrom(exec).Invalidate("{{.Table.Name}}", {{.Table.PKey.Columns | stringMap (aliasCols $alias) | prefixStringSlice "o." | join ", "}})
+
+	{{end -}}
 	{{if not .NoRowsAffected -}}
 	rowsAff, err := result.RowsAffected()
 	if err != nil {
@@ -185,6 +199,25 @@ func (q {{$alias.DownSingular}}Query) UpdateAllGP({{if not .NoContext}}ctx conte
 
 // UpdateAll updates all rows with the specified column values.
 func (q {{$alias.DownSingular}}Query) UpdateAll({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, cols M) {{if .NoRowsAffected}}error{{else}}(int64, error){{end -}} {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{.Table.Name}}", "update_all")
+
+	{{end -}}
+	{{if $tenant -}}
+	if err := tenantScope(ctx, q.Query, "{{$schemaTable}}.{{$.Tenancy.Column | $.Quotes}}"); err != nil {
+		return {{if not .NoRowsAffected}}0, {{end -}} err
+	}
+	if v, ok := cols["{{$.Tenancy.Column}}"]; ok {
+		if err := tenantCheck(ctx, v); err != nil {
+			return {{if not .NoRowsAffected}}0, {{end -}} err
+		}
+	}
+
+	{{end -}}
 	queries.SetUpdate(q.Query, cols)
 
 	{{if .NoRowsAffected -}}
@@ -201,9 +234,13 @@ func (q {{$alias.DownSingular}}Query) UpdateAll({{if .NoContext}}exec boil.Execu
 		{{end -}}
 	{{end -}}
 	if err != nil {
-		return {{if not .NoRowsAffected}}0, {{end -}} errors.Wrap(err, "{{.PkgName}}: unable to update all for {{.Table.Name}}")
+		return {{if not .NoRowsAffected}}0, {{end -}} errors.Wrap(boil.ConvertError(err), "{{.PkgName}}: unable to update all for {{.Table.Name}}")
 	}
 
+	{{if .WithIdentityMap -}}
+	boil.IdentityMapFrom(exec).InvalidateTable("{{.Table.Name}}")
+
+	{{end -}}
 	{{if not .NoRowsAffected -}}
 	rowsAff, err := result.RowsAffected()
 	if err != nil {
@@ -255,6 +292,14 @@ func (o {{$alias.UpSingular}}Slice) UpdateAllP({{if .NoContext}}exec boil.Execut
 
 // UpdateAll updates all rows with the specified column values, using an executor.
 func (o {{$alias.UpSingular}}Slice) UpdateAll({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, cols M) {{if .NoRowsAffected}}error{{else}}(int64, error){{end -}} {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{.Table.Name}}", "update_all")
+
+	{{end -}}
 	ln := int64(len(o))
 	if ln == 0 {
 		return {{if not .NoRowsAffected}}0, {{end -}} nil
@@ -263,6 +308,17 @@ func (o {{$alias.UpSingular}}Slice) UpdateAll({{if .NoContext}}exec boil.Executo
 	if len(cols) == 0 {
 		return {{if not .NoRowsAffected}}0, {{end -}} errors.New("{{.PkgName}}: update all requires at least one column argument")
 	}
+	{{- if $tenant}}
+
+	if err := o.tenantCheck(ctx); err != nil {
+		return {{if not .NoRowsAffected}}0, {{end -}} err
+	}
+	if v, ok := cols["{{$.Tenancy.Column}}"]; ok {
+		if err := tenantCheck(ctx, v); err != nil {
+			return {{if not .NoRowsAffected}}0, {{end -}} err
+		}
+	}
+	{{- end}}
 
 	colNames := make([]string, len(cols))
 	args := make([]interface{}, len(cols))
@@ -276,44 +332,35 @@ func (o {{$alias.UpSingular}}Slice) UpdateAll({{if .NoContext}}exec boil.Executo
 
 	// Append all of the primary key values for each column
 	for _, obj := range o {
-		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), {{$alias.DownSingular}}PrimaryKeyMapping)
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), {{$keyVar}}Mapping)
 		args = append(args, pkeyArgs...)
 	}
 
 	sql := fmt.Sprintf("UPDATE {{$schemaTable}} SET %s WHERE %s",
-		strmangle.SetParamNames("{{.LQ}}", "{{.RQ}}", {{if .Dialect.UseIndexPlaceholders}}1{{else}}0{{end}}, colNames),
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), {{if .Dialect.UseIndexPlaceholders}}len(colNames)+1{{else}}0{{end}}, {{$alias.DownSingular}}PrimaryKeyColumns, len(o)))
-
-	{{if .NoContext -}}
-	if boil.DebugMode {
-		fmt.Fprintln(boil.DebugWriter, sql)
-		fmt.Fprintln(boil.DebugWriter, args...)
-	}
-	{{else -}}
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, sql)
-		fmt.Fprintln(writer, args...)
-	}
-	{{end -}}
+		strmangle.SetParamNames("{{.LQ}}", "{{.RQ}}", {{.Dialect.PlaceholderStart 1}}, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), dialect.PlaceholderStart(len(colNames)+1), {{$keyVar}}Columns, len(o)))
 
 	{{if .NoRowsAffected -}}
 		{{if .NoContext -}}
-	_, err := exec.Exec(sql, args...)
+	_, err := boil.DebugExec(exec, sql, args...)
 		{{else -}}
-	_, err := exec.ExecContext(ctx, sql, args...)
+	_, err := boil.DebugExecContext(ctx, exec, sql, args...)
 		{{end -}}
 	{{else -}}
 		{{if .NoContext -}}
-	result, err := exec.Exec(sql, args...)
+	result, err := boil.DebugExec(exec, sql, args...)
 		{{else -}}
-	result, err := exec.ExecContext(ctx, sql, args...)
+	result, err := boil.DebugExecContext(ctx, exec, sql, args...)
 		{{end -}}
 	{{end -}}
 	if err != nil {
-		return {{if not .NoRowsAffected}}0, {{end -}} errors.Wrap(err, "{{.PkgName}}: unable to update all in {{$alias.DownSingular}} slice")
+		return {{if not .NoRowsAffected}}0, {{end -}} errors.Wrap(boil.ConvertError(err), "{{.PkgName}}: unable to update all in {{$alias.DownSingular}} slice")
 	}
 
+	{{if .WithIdentityMap -}}
+	boil.IdentityMapFrom(exec).InvalidateTable("{{.Table.Name}}")
+
+	{{end -}}
 	{{if not .NoRowsAffected -}}
 	rowsAff, err := result.RowsAffected()
 	if err != nil {
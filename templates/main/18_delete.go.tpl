@@ -3,7 +3,14 @@
 {{- $alias := .Aliases.Table .Table.Name -}}
 {{- $schemaTable := .Table.Name | .SchemaTable -}}
 {{- $canSoftDelete := .Table.CanSoftDelete $.AutoColumns.Deleted -}}
-{{- $soft := and .AddSoftDeletes $canSoftDelete }}
+{{- $soft := and .AddSoftDeletes $canSoftDelete -}}
+{{- $tenant := .Tenancy.Scopes .Table.Name -}}
+{{- $keyColumns := .Table.PKey.Columns -}}
+{{- $keyVar := printf "%sPrimaryKey" $alias.DownSingular -}}
+{{- if $tenant -}}
+{{- $keyColumns = .Tenancy.KeyColumns .Table -}}
+{{- $keyVar = printf "%sTenantKey" $alias.DownSingular -}}
+{{- end}}
 {{- $softDelCol := or $.AutoColumns.Deleted "deleted_at"}}
 {{if .AddGlobal -}}
 // DeleteG deletes a single {{$alias.UpSingular}} record.
@@ -51,6 +58,14 @@ func (o *{{$alias.UpSingular}}) DeleteGP({{if not .NoContext}}ctx context.Contex
 // Delete deletes a single {{$alias.UpSingular}} record with an executor.
 // Delete will match against the primary key column to find the record to delete.
 func (o *{{$alias.UpSingular}}) Delete({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}{{if $soft}}, hardDelete bool{{end}}) {{if .NoRowsAffected}}error{{else}}(int64, error){{end -}} {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{.Table.Name}}", "delete")
+
+	{{end -}}
 	if o == nil {
 		return {{if not .NoRowsAffected}}0, {{end -}} errors.New("{{.PkgName}}: no {{$alias.UpSingular}} provided for delete")
 	}
@@ -60,6 +75,12 @@ func (o *{{$alias.UpSingular}}) Delete({{if .NoContext}}exec boil.Executor{{else
 		return {{if not .NoRowsAffected}}0, {{end -}} err
 	}
 	{{- end}}
+	{{- if $tenant}}
+
+	if err := tenantCheck(ctx, o.{{$alias.Column $.Tenancy.Column}}); err != nil {
+		return {{if not .NoRowsAffected}}0, {{end -}} err
+	}
+	{{- end}}
 
 	{{if $soft -}}
 	var (
@@ -67,56 +88,47 @@ func (o *{{$alias.UpSingular}}) Delete({{if .NoContext}}exec boil.Executor{{else
 		args []interface{}
 	)
 	if hardDelete {
-		args = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), {{$alias.DownSingular}}PrimaryKeyMapping)
-		sql = "DELETE FROM {{$schemaTable}} WHERE {{if .Dialect.UseIndexPlaceholders}}{{whereClause .LQ .RQ 1 .Table.PKey.Columns}}{{else}}{{whereClause .LQ .RQ 0 .Table.PKey.Columns}}{{end}}"
+		args = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), {{$keyVar}}Mapping)
+		sql = "DELETE FROM {{$schemaTable}} WHERE {{whereClause .LQ .RQ (.Dialect.PlaceholderStart 1) $keyColumns}}"
 	} else {
 		currTime := time.Now().In(boil.GetLocation())
 		o.{{$alias.Column $softDelCol}} = null.TimeFrom(currTime)
 		wl := []string{"{{$softDelCol}}"}
-		sql = fmt.Sprintf("UPDATE {{$schemaTable}} SET %s WHERE {{if .Dialect.UseIndexPlaceholders}}{{whereClause .LQ .RQ 2 .Table.PKey.Columns}}{{else}}{{whereClause .LQ .RQ 0 .Table.PKey.Columns}}{{end}}",
-			strmangle.SetParamNames("{{.LQ}}", "{{.RQ}}", {{if .Dialect.UseIndexPlaceholders}}1{{else}}0{{end}}, wl),
+		sql = fmt.Sprintf("UPDATE {{$schemaTable}} SET %s WHERE {{whereClause .LQ .RQ (.Dialect.PlaceholderStart 2) $keyColumns}}",
+			strmangle.SetParamNames("{{.LQ}}", "{{.RQ}}", {{.Dialect.PlaceholderStart 1}}, wl),
 		)
-		valueMapping, err := queries.BindMapping({{$alias.DownSingular}}Type, {{$alias.DownSingular}}Mapping, append(wl, {{$alias.DownSingular}}PrimaryKeyColumns...))
+		valueMapping, err := queries.BindMapping({{$alias.DownSingular}}Type, {{$alias.DownSingular}}Mapping, append(wl, {{$keyVar}}Columns...))
 		if err != nil {
 			return {{if not .NoRowsAffected}}0, {{end -}} err
 		}
 		args = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), valueMapping)
 	}
 	{{else -}}
-	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), {{$alias.DownSingular}}PrimaryKeyMapping)
-	sql := "DELETE FROM {{$schemaTable}} WHERE {{if .Dialect.UseIndexPlaceholders}}{{whereClause .LQ .RQ 1 .Table.PKey.Columns}}{{else}}{{whereClause .LQ .RQ 0 .Table.PKey.Columns}}{{end}}"
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), {{$keyVar}}Mapping)
+	sql := "DELETE FROM {{$schemaTable}} WHERE {{whereClause .LQ .RQ (.Dialect.PlaceholderStart 1) $keyColumns}}"
 	{{- end}}
 
-	{{if .NoContext -}}
-	if boil.DebugMode {
-		fmt.Fprintln(boil.DebugWriter, sql)
-		fmt.Fprintln(boil.DebugWriter, args...)
-	}
-	{{else -}}
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, sql)
-		fmt.Fprintln(writer, args...)
-	}
-	{{end -}}
-
 	{{if .NoRowsAffected -}}
 		{{if .NoContext -}}
-	_, err := exec.Exec(sql, args...)
+	_, err := boil.DebugExec(exec, sql, args...)
 		{{else -}}
-	_, err := exec.ExecContext(ctx, sql, args...)
+	_, err := boil.DebugExecContext(ctx, exec, sql, args...)
 		{{end -}}
 	{{else -}}
 		{{if .NoContext -}}
-	result, err := exec.Exec(sql, args...)
+	result, err := boil.DebugExec(exec, sql, args...)
 		{{else -}}
-	result, err := exec.ExecContext(ctx, sql, args...)
+	result, err := boil.DebugExecContext(ctx, exec, sql, args...)
 		{{end -}}
 	{{end -}}
 	if err != nil {
-		return {{if not .NoRowsAffected}}0, {{end -}} errors.Wrap(err, "{{.PkgName}}: unable to delete from {{.Table.Name}}")
+		return {{if not .NoRowsAffected}}0, {{end -}} errors.Wrap(boil.ConvertError(err), "{{.PkgName}}: unable to delete from {{.Table.Name}}")
 	}
 
+	{{if .WithIdentityMap -}}
+	boil.IdentityMapFrom(exec).Invalidate("{{.Table.Name}}", {{.Table.PKey.Columns | stringMap (aliasCols $alias) | prefixStringSlice "o." | join ", "}})
+
+	{{end -}}
 	{{if not .NoRowsAffected -}}
 	rowsAff, err := result.RowsAffected()
 	if err != nil {
@@ -173,9 +185,23 @@ func (q {{$alias.DownSingular}}Query) DeleteAllGP({{if not .NoContext}}ctx conte
 
 // DeleteAll deletes all matching rows.
 func (q {{$alias.DownSingular}}Query) DeleteAll({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}{{if $soft}}, hardDelete bool{{end}}) {{if .NoRowsAffected}}error{{else}}(int64, error){{end -}} {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{.Table.Name}}", "delete_all")
+
+	{{end -}}
 	if q.Query == nil {
 		return {{if not .NoRowsAffected}}0, {{end -}} errors.New("{{.PkgName}}: no {{$alias.DownSingular}}Query provided for delete all")
 	}
+	{{- if $tenant}}
+
+	if err := tenantScope(ctx, q.Query, "{{$schemaTable}}.{{$.Tenancy.Column | $.Quotes}}"); err != nil {
+		return {{if not .NoRowsAffected}}0, {{end -}} err
+	}
+	{{- end}}
 
 	{{if $soft -}}
 	if hardDelete {
@@ -202,9 +228,13 @@ func (q {{$alias.DownSingular}}Query) DeleteAll({{if .NoContext}}exec boil.Execu
 		{{end -}}
 	{{end -}}
 	if err != nil {
-		return {{if not .NoRowsAffected}}0, {{end -}} errors.Wrap(err, "{{.PkgName}}: unable to delete all from {{.Table.Name}}")
+		return {{if not .NoRowsAffected}}0, {{end -}} errors.Wrap(boil.ConvertError(err), "{{.PkgName}}: unable to delete all from {{.Table.Name}}")
 	}
 
+	{{if .WithIdentityMap -}}
+	boil.IdentityMapFrom(exec).InvalidateTable("{{.Table.Name}}")
+
+	{{end -}}
 	{{if not .NoRowsAffected -}}
 	rowsAff, err := result.RowsAffected()
 	if err != nil {
@@ -256,6 +286,14 @@ func (o {{$alias.UpSingular}}Slice) DeleteAllGP({{if not .NoContext}}ctx context
 
 // DeleteAll deletes all rows in the slice, using an executor.
 func (o {{$alias.UpSingular}}Slice) DeleteAll({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}{{if $soft}}, hardDelete bool{{end}}) {{if .NoRowsAffected}}error{{else}}(int64, error){{end -}} {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{.Table.Name}}", "delete_all")
+
+	{{end -}}
 	if len(o) == 0 {
 		return {{if not .NoRowsAffected}}0, {{end -}} nil
 	}
@@ -269,6 +307,12 @@ func (o {{$alias.UpSingular}}Slice) DeleteAll({{if .NoContext}}exec boil.Executo
 		}
 	}
 	{{- end}}
+	{{- if $tenant}}
+
+	if err := o.tenantCheck(ctx); err != nil {
+		return {{if not .NoRowsAffected}}0, {{end -}} err
+	}
+	{{- end}}
 
 	{{if $soft -}}
 	var (
@@ -277,66 +321,57 @@ func (o {{$alias.UpSingular}}Slice) DeleteAll({{if .NoContext}}exec boil.Executo
 	)
 	if hardDelete {
 		for _, obj := range o {
-    		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), {{$alias.DownSingular}}PrimaryKeyMapping)
+    		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), {{$keyVar}}Mapping)
     		args = append(args, pkeyArgs...)
     	}
 		sql = "DELETE FROM {{$schemaTable}} WHERE " +
-			strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), {{if .Dialect.UseIndexPlaceholders}}1{{else}}0{{end}}, {{$alias.DownSingular}}PrimaryKeyColumns, len(o))
+			strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), {{.Dialect.PlaceholderStart 1}}, {{$keyVar}}Columns, len(o))
 	} else {
 		currTime := time.Now().In(boil.GetLocation())
 		for _, obj := range o {
-			pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), {{$alias.DownSingular}}PrimaryKeyMapping)
+			pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), {{$keyVar}}Mapping)
 			args = append(args, pkeyArgs...)
 			obj.{{$alias.Column $softDelCol}} = null.TimeFrom(currTime)
 		}
 		wl := []string{"{{$softDelCol}}"}
 		sql = fmt.Sprintf("UPDATE {{$schemaTable}} SET %s WHERE " +
-			strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), {{if .Dialect.UseIndexPlaceholders}}2{{else}}0{{end}}, {{$alias.DownSingular}}PrimaryKeyColumns, len(o)),
-			strmangle.SetParamNames("{{.LQ}}", "{{.RQ}}", {{if .Dialect.UseIndexPlaceholders}}1{{else}}0{{end}}, wl),
+			strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), {{.Dialect.PlaceholderStart 2}}, {{$keyVar}}Columns, len(o)),
+			strmangle.SetParamNames("{{.LQ}}", "{{.RQ}}", {{.Dialect.PlaceholderStart 1}}, wl),
 		)
 		args = append([]interface{}{currTime}, args...)
 	}
 	{{else -}}
 	var args []interface{}
 	for _, obj := range o {
-		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), {{$alias.DownSingular}}PrimaryKeyMapping)
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), {{$keyVar}}Mapping)
 		args = append(args, pkeyArgs...)
 	}
 
 	sql := "DELETE FROM {{$schemaTable}} WHERE " +
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), {{if .Dialect.UseIndexPlaceholders}}1{{else}}0{{end}}, {{$alias.DownSingular}}PrimaryKeyColumns, len(o))
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), {{.Dialect.PlaceholderStart 1}}, {{$keyVar}}Columns, len(o))
 	{{- end}}
 
-	{{if .NoContext -}}
-	if boil.DebugMode {
-		fmt.Fprintln(boil.DebugWriter, sql)
-		fmt.Fprintln(boil.DebugWriter, args)
-	}
-	{{else -}}
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, sql)
-		fmt.Fprintln(writer, args)
-	}
-	{{end -}}
-
 	{{if .NoRowsAffected -}}
 		{{if .NoContext -}}
-	_, err := exec.Exec(sql, args...)
+	_, err := boil.DebugExec(exec, sql, args...)
 		{{else -}}
-	_, err := exec.ExecContext(ctx, sql, args...)
+	_, err := boil.DebugExecContext(ctx, exec, sql, args...)
 		{{end -}}
 	{{else -}}
 		{{if .NoContext -}}
-	result, err := exec.Exec(sql, args...)
+	result, err := boil.DebugExec(exec, sql, args...)
 		{{else -}}
-	result, err := exec.ExecContext(ctx, sql, args...)
+	result, err := boil.DebugExecContext(ctx, exec, sql, args...)
 		{{end -}}
 	{{end -}}
 	if err != nil {
-		return {{if not .NoRowsAffected}}0, {{end -}} errors.Wrap(err, "{{.PkgName}}: unable to delete all from {{$alias.DownSingular}} slice")
+		return {{if not .NoRowsAffected}}0, {{end -}} errors.Wrap(boil.ConvertError(err), "{{.PkgName}}: unable to delete all from {{$alias.DownSingular}} slice")
 	}
 
+	{{if .WithIdentityMap -}}
+	boil.IdentityMapFrom(exec).InvalidateTable("{{.Table.Name}}")
+
+	{{end -}}
 	{{if not .NoRowsAffected -}}
 	rowsAff, err := result.RowsAffected()
 	if err != nil {
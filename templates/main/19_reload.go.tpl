@@ -1,8 +1,14 @@
-{{- if .Table.IsView -}}
+{{- if or .Table.IsView .NoReload -}}
 {{- else -}}
 {{- $alias := .Aliases.Table .Table.Name -}}
 {{- $schemaTable := .Table.Name | .SchemaTable -}}
-{{- $canSoftDelete := .Table.CanSoftDelete $.AutoColumns.Deleted }}
+{{- $canSoftDelete := .Table.CanSoftDelete $.AutoColumns.Deleted -}}
+{{- $tenant := .Tenancy.Scopes .Table.Name -}}
+{{- $identityMap := and .WithIdentityMap (not $tenant) -}}
+{{- $keyVar := printf "%sPrimaryKey" $alias.DownSingular -}}
+{{- if $tenant -}}
+{{- $keyVar = printf "%sTenantKey" $alias.DownSingular -}}
+{{- end}}
 {{if .AddGlobal -}}
 // ReloadG refetches the object from the database using the primary keys.
 func (o *{{$alias.UpSingular}}) ReloadG({{if not .NoContext}}ctx context.Context{{end}}) error {
@@ -38,12 +44,20 @@ func (o *{{$alias.UpSingular}}) ReloadGP({{if not .NoContext}}ctx context.Contex
 // Reload refetches the object from the database
 // using the primary keys with an executor.
 func (o *{{$alias.UpSingular}}) Reload({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) error {
+	{{if $identityMap -}}
+	identityMap := boil.IdentityMapFrom(exec)
+	identityMap.Invalidate("{{.Table.Name}}", {{.Table.PKey.Columns | stringMap (aliasCols $alias) | prefixStringSlice "o." | join ", "}})
+
+	{{end -}}
 	ret, err := Find{{$alias.UpSingular}}({{if not .NoContext}}ctx, {{end -}} exec, {{.Table.PKey.Columns | stringMap (aliasCols $alias) | prefixStringSlice "o." | join ", "}})
 	if err != nil {
 		return err
 	}
 
 	*o = *ret
+	{{- if $identityMap}}
+	identityMap.Set("{{.Table.Name}}", o, {{.Table.PKey.Columns | stringMap (aliasCols $alias) | prefixStringSlice "o." | join ", "}})
+	{{- end}}
 	return nil
 }
 
@@ -87,19 +101,33 @@ func (o *{{$alias.UpSingular}}Slice) ReloadAllGP({{if not .NoContext}}ctx contex
 // ReloadAll refetches every row with matching primary key column values
 // and overwrites the original object slice with the newly updated slice.
 func (o *{{$alias.UpSingular}}Slice) ReloadAll({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) error {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{.Table.Name}}", "reload_all")
+
+	{{end -}}
 	if o == nil || len(*o) == 0 {
 		return nil
 	}
+	{{- if $tenant}}
+
+	if err := o.tenantCheck(ctx); err != nil {
+		return err
+	}
+	{{- end}}
 
 	slice := {{$alias.UpSingular}}Slice{}
 	var args []interface{}
 	for _, obj := range *o {
-		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), {{$alias.DownSingular}}PrimaryKeyMapping)
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), {{$keyVar}}Mapping)
 		args = append(args, pkeyArgs...)
 	}
 
 	sql := "SELECT {{$schemaTable}}.* FROM {{$schemaTable}} WHERE " +
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), {{if .Dialect.UseIndexPlaceholders}}1{{else}}0{{end}}, {{$alias.DownSingular}}PrimaryKeyColumns, len(*o)){{if and .AddSoftDeletes $canSoftDelete}} +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), {{.Dialect.PlaceholderStart 1}}, {{$keyVar}}Columns, len(*o)){{if and .AddSoftDeletes $canSoftDelete}} +
 		"and {{or $.AutoColumns.Deleted "deleted_at" | $.Quotes}} is null"
 		{{- end}}
 
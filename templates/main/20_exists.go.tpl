@@ -1,11 +1,12 @@
-{{- if .Table.IsView -}}
+{{- if or .Table.IsView .NoExists -}}
 {{- else -}}
 {{- $alias := .Aliases.Table .Table.Name -}}
 {{- $colDefs := sqlColDefinitions .Table.Columns .Table.PKey.Columns -}}
 {{- $pkNames := $colDefs.Names | stringMap (aliasCols $alias) | stringMap .StringFuncs.camelCase | stringMap .StringFuncs.replaceReserved -}}
 {{- $pkArgs := joinSlices " " $pkNames $colDefs.Types | join ", " -}}
 {{- $schemaTable := .Table.Name | .SchemaTable -}}
-{{- $canSoftDelete := .Table.CanSoftDelete $.AutoColumns.Deleted }}
+{{- $canSoftDelete := .Table.CanSoftDelete $.AutoColumns.Deleted -}}
+{{- $tenant := .Tenancy.Scopes .Table.Name }}
 {{if .AddGlobal -}}
 // {{$alias.UpSingular}}ExistsG checks if the {{$alias.UpSingular}} row exists.
 func {{$alias.UpSingular}}ExistsG({{if not .NoContext}}ctx context.Context, {{end -}} {{$pkArgs}}) (bool, error) {
@@ -42,33 +43,39 @@ func {{$alias.UpSingular}}ExistsGP({{if not .NoContext}}ctx context.Context, {{e
 
 // {{$alias.UpSingular}}Exists checks if the {{$alias.UpSingular}} row exists.
 func {{$alias.UpSingular}}Exists({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, {{$pkArgs}}) (bool, error) {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{.Table.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{.Table.Name}}", "exists")
+
+	{{end -}}
+	{{if $tenant -}}
+	tenantCond, tenantArgs, err := tenantWhere(ctx, "{{$.Tenancy.Column | $.Quotes}}", {{len .Table.PKey.Columns | add 1}})
+	if err != nil {
+		return false, err
+	}
+
+	{{end -}}
 	var exists bool
 	{{if .Dialect.UseCaseWhenExistsClause -}}
-	sql := "select case when exists(select top(1) 1 from {{$schemaTable}} where {{if .Dialect.UseIndexPlaceholders}}{{whereClause .LQ .RQ 1 .Table.PKey.Columns}}{{else}}{{whereClause .LQ .RQ 0 .Table.PKey.Columns}}{{end}}) then 1 else 0 end"
+	sql := "select case when exists(select top(1) 1 from {{$schemaTable}} where {{whereClause .LQ .RQ (.Dialect.PlaceholderStart 1) .Table.PKey.Columns}}{{if $tenant}}" + tenantCond + "{{end}}) then 1 else 0 end"
 	{{- else -}}
-	sql := "select exists(select 1 from {{$schemaTable}} where {{if .Dialect.UseIndexPlaceholders}}{{whereClause .LQ .RQ 1 .Table.PKey.Columns}}{{else}}{{whereClause .LQ .RQ 0 .Table.PKey.Columns}}{{end}}{{if and .AddSoftDeletes $canSoftDelete}} and {{or $.AutoColumns.Deleted "deleted_at" | $.Quotes}} is null{{end}} limit 1)"
+	sql := "select exists(select 1 from {{$schemaTable}} where {{whereClause .LQ .RQ (.Dialect.PlaceholderStart 1) .Table.PKey.Columns}}{{if $tenant}}" + tenantCond + "{{end}}{{if and .AddSoftDeletes $canSoftDelete}} and {{or $.AutoColumns.Deleted "deleted_at" | $.Quotes}} is null{{end}} limit 1)"
 	{{- end}}
 
 	{{if .NoContext -}}
-	if boil.DebugMode {
-		fmt.Fprintln(boil.DebugWriter, sql)
-		fmt.Fprintln(boil.DebugWriter, {{$pkNames | join ", "}})
-	}
-	{{else -}}
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, sql)
-		fmt.Fprintln(writer, {{$pkNames | join ", "}})
-	}
-	{{end -}}
-
-	{{if .NoContext -}}
-	row := exec.QueryRow(sql, {{$pkNames | join ", "}})
+	row := boil.DebugQueryRow(exec, sql, {{$pkNames | join ", "}})
 	{{else -}}
-	row := exec.QueryRowContext(ctx, sql, {{$pkNames | join ", "}})
+	row := boil.DebugQueryRowContext(ctx, exec, sql, {{if $tenant}}append([]interface{}{ {{- $pkNames | join ", " -}} }, tenantArgs...)...{{else}}{{$pkNames | join ", "}}{{end}})
 	{{- end}}
 
+	{{if $tenant -}}
+	err = row.Scan(&exists)
+	{{- else -}}
 	err := row.Scan(&exists)
+	{{- end}}
 	if err != nil {
 		return false, errors.Wrap(err, "{{.PkgName}}: unable to check if {{.Table.Name}} exists")
 	}
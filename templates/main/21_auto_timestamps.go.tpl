@@ -42,7 +42,8 @@
 	{{- if not .NoAutoTimestamps -}}
 	{{- $alias := .Aliases.Table .Table.Name -}}
 	{{- $colNames := .Table.Columns | columnNames -}}
-	{{if containsAny $colNames (or $.AutoColumns.Updated "updated_at")}}
+	{{- /* A trigger keeping the column current makes setting it pointless */ -}}
+	{{if and (containsAny $colNames (or $.AutoColumns.Updated "updated_at")) (not (.Table.TriggerSets "UPDATE" (or $.AutoColumns.Updated "updated_at")))}}
 		{{if not .NoContext -}}
 	if !boil.TimestampsAreSkipped(ctx) {
 		{{end -}}
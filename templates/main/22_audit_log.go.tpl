@@ -0,0 +1,138 @@
+{{- if .AuditLog.Audits .Table.Name -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $ctxParam := "" -}}
+{{- $ctxArg := "" -}}
+{{- $execType := "boil.Executor" -}}
+{{- if not .NoContext -}}
+{{- $ctxParam = "ctx context.Context, " -}}
+{{- $ctxArg = "ctx, " -}}
+{{- $execType = "boil.ContextExecutor" -}}
+{{- end}}
+// {{$alias.UpSingular}}Audit is a change of a {{$alias.DownSingular}} recorded in {{.AuditLog.Table}}.
+// Before is nil for inserts and After is nil for deletes.
+type {{$alias.UpSingular}}Audit struct {
+	Operation string
+	Before    *{{$alias.UpSingular}}
+	After     *{{$alias.UpSingular}}
+	ChangedAt time.Time
+}
+
+// {{$alias.DownSingular}}AuditBefore keeps the rows loaded before they are changed until
+// the change is recorded, by object.
+var {{$alias.DownSingular}}AuditBefore sync.Map
+
+func init() {
+	Add{{$alias.UpSingular}}Hook(boil.AfterInsertHook, {{$alias.DownSingular}}AuditInsert)
+	Add{{$alias.UpSingular}}Hook(boil.BeforeUpdateHook, {{$alias.DownSingular}}AuditLoad)
+	Add{{$alias.UpSingular}}Hook(boil.AfterUpdateHook, {{$alias.DownSingular}}AuditUpdate)
+	Add{{$alias.UpSingular}}Hook(boil.BeforeDeleteHook, {{$alias.DownSingular}}AuditLoad)
+	Add{{$alias.UpSingular}}Hook(boil.AfterDeleteHook, {{$alias.DownSingular}}AuditDelete)
+	Add{{$alias.UpSingular}}Hook(boil.BeforeUpsertHook, {{$alias.DownSingular}}AuditLoad)
+	Add{{$alias.UpSingular}}Hook(boil.AfterUpsertHook, {{$alias.DownSingular}}AuditUpsert)
+}
+
+// Audits returns the changes of the {{$alias.DownSingular}} recorded in {{.AuditLog.Table}} in the
+// order they were made.
+func (o *{{$alias.UpSingular}}) Audits({{$ctxParam}}exec {{$execType}}) ([]*{{$alias.UpSingular}}Audit, error) {
+	rowKey, err := {{$alias.DownSingular}}AuditRowKey(o)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := auditRecords({{$ctxArg}}exec, "{{.Table.Name}}", rowKey)
+	if err != nil {
+		return nil, err
+	}
+
+	audits := make([]*{{$alias.UpSingular}}Audit, len(records))
+	for i, r := range records {
+		audit := &{{$alias.UpSingular}}Audit{Operation: r.operation, ChangedAt: r.changedAt}
+		if r.before != nil {
+			audit.Before = &{{$alias.UpSingular}}{}
+			if err := auditImage(r.before, audit.Before); err != nil {
+				return nil, err
+			}
+		}
+		if r.after != nil {
+			audit.After = &{{$alias.UpSingular}}{}
+			if err := auditImage(r.after, audit.After); err != nil {
+				return nil, err
+			}
+		}
+		audits[i] = audit
+	}
+
+	return audits, nil
+}
+
+// {{$alias.DownSingular}}AuditRowKey returns the key of the {{$alias.DownSingular}} in {{.AuditLog.Table}}
+func {{$alias.DownSingular}}AuditRowKey(o *{{$alias.UpSingular}}) (string, error) {
+	return auditRowKey(map[string]interface{}{
+		{{range .Table.PKey.Columns -}}
+		"{{.}}": o.{{$alias.Column .}},
+		{{end -}}
+	})
+}
+
+// {{$alias.DownSingular}}AuditLoad keeps the row of the {{$alias.DownSingular}} as it is before it is changed
+func {{$alias.DownSingular}}AuditLoad({{$ctxParam}}exec {{$execType}}, o *{{$alias.UpSingular}}) error {
+	before, err := Find{{$alias.UpSingular}}({{$ctxArg}}exec{{range .Table.PKey.Columns}}, o.{{$alias.Column .}}{{end}})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return errors.Wrap(err, "{{.PkgName}}: unable to load the {{.Table.Name}} row before it is changed")
+	}
+
+	{{$alias.DownSingular}}AuditBefore.Store(o, before)
+	return nil
+}
+
+// {{$alias.DownSingular}}AuditLoaded returns the row kept by {{$alias.DownSingular}}AuditLoad, or nil if it was
+// not found
+func {{$alias.DownSingular}}AuditLoaded(o *{{$alias.UpSingular}}) *{{$alias.UpSingular}} {
+	before, _ := {{$alias.DownSingular}}AuditBefore.LoadAndDelete(o)
+	loaded, _ := before.(*{{$alias.UpSingular}})
+	return loaded
+}
+
+// {{$alias.DownSingular}}AuditRecord records a change of the {{$alias.DownSingular}} in {{.AuditLog.Table}}
+func {{$alias.DownSingular}}AuditRecord({{$ctxParam}}exec {{$execType}}, operation string, o, before, after *{{$alias.UpSingular}}) error {
+	rowKey, err := {{$alias.DownSingular}}AuditRowKey(o)
+	if err != nil {
+		return err
+	}
+
+	var beforeImage, afterImage interface{}
+	if before != nil {
+		beforeImage = before
+	}
+	if after != nil {
+		afterImage = after
+	}
+
+	return auditLog({{$ctxArg}}exec, "{{.Table.Name}}", operation, rowKey, beforeImage, afterImage)
+}
+
+func {{$alias.DownSingular}}AuditInsert({{$ctxParam}}exec {{$execType}}, o *{{$alias.UpSingular}}) error {
+	return {{$alias.DownSingular}}AuditRecord({{$ctxArg}}exec, "INSERT", o, nil, o)
+}
+
+func {{$alias.DownSingular}}AuditUpdate({{$ctxParam}}exec {{$execType}}, o *{{$alias.UpSingular}}) error {
+	return {{$alias.DownSingular}}AuditRecord({{$ctxArg}}exec, "UPDATE", o, {{$alias.DownSingular}}AuditLoaded(o), o)
+}
+
+func {{$alias.DownSingular}}AuditDelete({{$ctxParam}}exec {{$execType}}, o *{{$alias.UpSingular}}) error {
+	before := {{$alias.DownSingular}}AuditLoaded(o)
+	if before == nil {
+		before = o
+	}
+	return {{$alias.DownSingular}}AuditRecord({{$ctxArg}}exec, "DELETE", o, before, nil)
+}
+
+func {{$alias.DownSingular}}AuditUpsert({{$ctxParam}}exec {{$execType}}, o *{{$alias.UpSingular}}) error {
+	before := {{$alias.DownSingular}}AuditLoaded(o)
+	operation := "UPDATE"
+	if before == nil {
+		operation = "INSERT"
+	}
+	return {{$alias.DownSingular}}AuditRecord({{$ctxArg}}exec, operation, o, before, o)
+}
+{{end -}}
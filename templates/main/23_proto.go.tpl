@@ -0,0 +1,22 @@
+{{- if .WithProto -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $msg := index .ProtoMessages .Table.Name}}
+// ToProto converts the {{$alias.DownSingular}} to its protobuf message
+func (o *{{$alias.UpSingular}}) ToProto() *pb.{{$msg.Name}} {
+	return &pb.{{$msg.Name}}{
+		{{- range $msg.Fields}}
+		{{.GoName}}: {{.ToProto}},
+		{{- end}}
+	}
+}
+
+// FromProto sets the columns of the {{$alias.DownSingular}} from its protobuf message
+func (o *{{$alias.UpSingular}}) FromProto(m *pb.{{$msg.Name}}) {
+	if m == nil {
+		return
+	}
+	{{range $msg.Fields}}
+	o.{{$alias.Column .Name}} = {{.FromProto}}
+	{{- end}}
+}
+{{end -}}
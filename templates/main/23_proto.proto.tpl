@@ -0,0 +1,87 @@
+{{- if .WithProto -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $msg := index .ProtoMessages .Table.Name -}}
+// Code generated by SQLBoiler (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+syntax = "proto3";
+
+package {{.Proto.Package}};
+
+option go_package = "{{.Proto.GoPackage}}";
+{{if $msg.Imports}}
+{{range $msg.Imports -}}
+import "{{.}}";
+{{end -}}
+{{end}}
+// {{$msg.Name}} mirrors a row of the {{.Table.Name}} {{if .Table.IsView}}view{{else}}table{{end}}
+message {{$msg.Name}} {
+  {{- range $msg.Fields}}
+  {{if .Optional}}optional {{else if .Repeated}}repeated {{end}}{{.Type}} {{.Name}} = {{.Number}};
+  {{- end}}
+  {{- range $msg.Skipped}}
+  // {{.Name}} is left out, {{.Type}} has no protobuf type
+  {{- end}}
+}
+{{- if .WithGRPC}}
+
+// {{$msg.Name}}Service serves the {{.Table.Name}} {{if .Table.IsView}}view{{else}}table{{end}}
+service {{$msg.Name}}Service {
+  rpc List{{$alias.UpPlural}}(List{{$alias.UpPlural}}Request) returns (List{{$alias.UpPlural}}Response);
+  {{- if not .Table.IsView}}
+  rpc Create{{$msg.Name}}(Create{{$msg.Name}}Request) returns ({{$msg.Name}});
+  {{- end}}
+  {{- if $msg.Keys}}
+  rpc Get{{$msg.Name}}(Get{{$msg.Name}}Request) returns ({{$msg.Name}});
+  {{- if $msg.Updates}}
+  rpc Update{{$msg.Name}}(Update{{$msg.Name}}Request) returns ({{$msg.Name}});
+  {{- end}}
+  rpc Delete{{$msg.Name}}(Delete{{$msg.Name}}Request) returns (Delete{{$msg.Name}}Response);
+  {{- end}}
+}
+
+// List{{$alias.UpPlural}}Request asks for a page of at most page_size rows, page_token
+// is the next_page_token of the previous page
+message List{{$alias.UpPlural}}Request {
+  int32 page_size = 1;
+  string page_token = 2;
+}
+
+message List{{$alias.UpPlural}}Response {
+  repeated {{$msg.Name}} items = 1;
+  // next_page_token is empty on the last page
+  string next_page_token = 2;
+}
+{{- if not .Table.IsView}}
+
+message Create{{$msg.Name}}Request {
+  {{$msg.Name}} item = 1;
+}
+{{- end}}
+{{- if $msg.Keys}}
+
+message Get{{$msg.Name}}Request {
+  {{- range $msg.Keys}}
+  {{if .Optional}}optional {{end}}{{.Type}} {{.Name}} = {{.Number}};
+  {{- end}}
+}
+{{- if $msg.Updates}}
+
+// Update{{$msg.Name}}Request updates the row of the primary key of item, the paths
+// of update_mask are the fields to update, all of them when it is empty or "*"
+message Update{{$msg.Name}}Request {
+  {{$msg.Name}} item = 1;
+  google.protobuf.FieldMask update_mask = 2;
+}
+{{- end}}
+
+message Delete{{$msg.Name}}Request {
+  {{- range $msg.Keys}}
+  {{if .Optional}}optional {{end}}{{.Type}} {{.Name}} = {{.Number}};
+  {{- end}}
+}
+
+message Delete{{$msg.Name}}Response {}
+{{- end}}
+{{- end}}
+{{end -}}
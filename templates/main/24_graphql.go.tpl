@@ -0,0 +1,187 @@
+{{- if .WithGraphQL -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $typ := index .GraphQLTypes .Table.Name -}}
+{{- $schemaTable := .Table.Name | .SchemaTable}}
+{{range $typ.Fields -}}
+{{- if .Getter -}}
+// {{.GoName}} returns the {{.Column}} column as the {{.Name}} field of the GraphQL type
+func (o *{{$alias.UpSingular}}) {{.GoName}}() {{if .Valid}}*{{end}}{{.GoType}} {
+	{{- if .Valid}}
+	if !{{.Valid}} {
+		return nil
+	}
+	v := {{.Getter}}
+	return &v
+	{{- else}}
+	return {{.Getter}}
+	{{- end}}
+}
+
+{{end -}}
+{{- end -}}
+
+// {{$alias.UpSingular}}Connection is a page of {{$alias.DownPlural}}, the GraphQL connection of the {{$typ.Name}} type
+type {{$alias.UpSingular}}Connection struct {
+	Edges    []*{{$alias.UpSingular}}Edge
+	PageInfo *PageInfo
+}
+
+// {{$alias.UpSingular}}Edge is a {{$alias.DownSingular}} in a page with its cursor
+type {{$alias.UpSingular}}Edge struct {
+	Cursor string
+	Node   *{{$alias.UpSingular}}
+}
+
+// new{{$alias.UpSingular}}Connection returns the page of the rows at the offset, rows has one
+// more row than the limit when there is a next page
+func new{{$alias.UpSingular}}Connection(rows {{$alias.UpSingular}}Slice, limit, offset int) *{{$alias.UpSingular}}Connection {
+	conn := &{{$alias.UpSingular}}Connection{PageInfo: &PageInfo{}}
+	if limit >= 0 && len(rows) > limit {
+		rows = rows[:limit]
+		conn.PageInfo.HasNextPage = true
+	}
+
+	conn.Edges = make([]*{{$alias.UpSingular}}Edge, len(rows))
+	for i, o := range rows {
+		conn.Edges[i] = &{{$alias.UpSingular}}Edge{Cursor: graphQLCursor(offset + i), Node: o}
+	}
+	if len(rows) != 0 {
+		conn.PageInfo.EndCursor = &conn.Edges[len(rows)-1].Cursor
+	}
+
+	return conn
+}
+{{if $typ.Keys}}
+// {{$alias.UpSingular}} resolves the {{$alias.DownSingular}} with the primary key, nil if it does not exist
+func (r *GraphQLQueryResolver) {{$alias.UpSingular}}(ctx context.Context, {{range $i, $k := $typ.Keys}}{{if $i}}, {{end}}{{$k.Param}} {{$k.GoType}}{{end}}) (*{{$alias.UpSingular}}, error) {
+	mods := append([]qm.QueryMod{
+		{{- range $typ.Keys}}
+		qm.Where("{{$schemaTable}}.{{.Column | $.Quotes}} = ?", {{.Arg}}),
+		{{- end}}
+	}, r.Mods["{{.Table.Name}}"]...)
+
+	o, err := {{$alias.UpPlural}}(mods...).One(ctx, r.Exec)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return o, err
+}
+{{end}}
+// {{$alias.UpPlural}} resolves the page of {{$alias.DownPlural}} after the cursor
+{{- if .Table.PKey}}, ordered by their primary key{{end}}
+func (r *GraphQLQueryResolver) {{$alias.UpPlural}}(ctx context.Context, first *int, after *string) (*{{$alias.UpSingular}}Connection, error) {
+	limit, offset, err := graphQLPage(first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	var mods []qm.QueryMod
+	{{- if .Table.PKey}}
+	mods = append(mods, qm.OrderBy("{{range $i, $c := .Table.PKey.Columns}}{{if $i}}, {{end}}{{$schemaTable}}.{{$c | $.Quotes}}{{end}}"))
+	{{- end}}
+	mods = append(mods, r.Mods["{{.Table.Name}}"]...)
+	mods = append(mods, graphQLPageMods(limit, offset)...)
+
+	rows, err := {{$alias.UpPlural}}(mods...).All(ctx, r.Exec)
+	if err != nil {
+		return nil, err
+	}
+	return new{{$alias.UpSingular}}Connection(rows, limit, offset), nil
+}
+{{- if and (not .Table.IsView) (or .Table.FKeys .Table.ToOneRelationships .Table.ToManyRelationships)}}
+
+// {{$alias.UpSingular}}GraphQLResolver resolves the relationships of the {{$typ.Name}} type
+type {{$alias.UpSingular}}GraphQLResolver struct {
+	*GraphQLResolver
+}
+
+// {{$alias.UpSingular}} returns the resolver of the relationships of the {{$typ.Name}} type
+func (r *GraphQLResolver) {{$alias.UpSingular}}() *{{$alias.UpSingular}}GraphQLResolver {
+	return &{{$alias.UpSingular}}GraphQLResolver{GraphQLResolver: r}
+}
+{{range $fkey := .Table.FKeys -}}
+{{- $ftable := $.Aliases.Table $fkey.ForeignTable -}}
+{{- $rel := $alias.Relationship $fkey.Name}}
+// {{$rel.Foreign}} resolves the {{$ftable.DownSingular}} of the {{$alias.DownSingular}}, the eager loaded one if it was loaded
+func (r *{{$alias.UpSingular}}GraphQLResolver) {{$rel.Foreign}}(ctx context.Context, obj *{{$alias.UpSingular}}) (*{{$ftable.UpSingular}}, error) {
+	if obj.R != nil && obj.R.{{$rel.Foreign}} != nil {
+		return obj.R.{{$rel.Foreign}}, nil
+	}
+	{{- if $.WithDataloader}}
+	if l := LoaderFrom(ctx); l != nil {
+		return l.{{$alias.UpSingular}}{{$rel.Foreign}}(ctx, obj)
+	}
+	{{- end}}
+
+	o, err := obj.{{$rel.Foreign}}(r.Mods["{{$fkey.ForeignTable}}"]...).One(ctx, r.Exec)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return o, err
+}
+{{end -}}
+{{- range $rel := .Table.ToOneRelationships -}}
+{{- $ftable := $.Aliases.Table $rel.ForeignTable -}}
+{{- $relAlias := $ftable.Relationship $rel.Name}}
+// {{$relAlias.Local}} resolves the {{$ftable.DownSingular}} of the {{$alias.DownSingular}}, the eager loaded one if it was loaded
+func (r *{{$alias.UpSingular}}GraphQLResolver) {{$relAlias.Local}}(ctx context.Context, obj *{{$alias.UpSingular}}) (*{{$ftable.UpSingular}}, error) {
+	if obj.R != nil && obj.R.{{$relAlias.Local}} != nil {
+		return obj.R.{{$relAlias.Local}}, nil
+	}
+	{{- if $.WithDataloader}}
+	if l := LoaderFrom(ctx); l != nil {
+		return l.{{$alias.UpSingular}}{{$relAlias.Local}}(ctx, obj)
+	}
+	{{- end}}
+
+	o, err := obj.{{$relAlias.Local}}(r.Mods["{{$rel.ForeignTable}}"]...).One(ctx, r.Exec)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return o, err
+}
+{{end -}}
+{{- range $rel := .Table.ToManyRelationships -}}
+{{- $ftable := $.Aliases.Table $rel.ForeignTable -}}
+{{- $relAlias := $.Aliases.ManyRelationship $rel.ForeignTable $rel.Name $rel.JoinTable $rel.JoinLocalFKeyName -}}
+{{- $fpkey := (getTable $.Tables $rel.ForeignTable).PKey -}}
+{{- $schemaForeignTable := $rel.ForeignTable | $.SchemaTable}}
+// {{$relAlias.Local}} resolves the page of {{$ftable.DownPlural}} of the {{$alias.DownSingular}} after the cursor, from the eager
+// loaded ones if they were loaded
+func (r *{{$alias.UpSingular}}GraphQLResolver) {{$relAlias.Local}}(ctx context.Context, obj *{{$alias.UpSingular}}, first *int, after *string) (*{{$ftable.UpSingular}}Connection, error) {
+	limit, offset, err := graphQLPage(first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	if obj.R != nil && obj.R.{{$relAlias.Local}} != nil {
+		start, end := graphQLBounds(len(obj.R.{{$relAlias.Local}}), limit, offset)
+		return new{{$ftable.UpSingular}}Connection(obj.R.{{$relAlias.Local}}[start:end], limit, offset), nil
+	}
+	{{- if $.WithDataloader}}
+	if l := LoaderFrom(ctx); l != nil {
+		rows, err := l.{{$alias.UpSingular}}{{$relAlias.Local}}(ctx, obj)
+		if err != nil {
+			return nil, err
+		}
+		start, end := graphQLBounds(len(rows), limit, offset)
+		return new{{$ftable.UpSingular}}Connection(rows[start:end], limit, offset), nil
+	}
+	{{- end}}
+
+	var mods []qm.QueryMod
+	{{- if $fpkey}}
+	mods = append(mods, qm.OrderBy("{{range $i, $c := $fpkey.Columns}}{{if $i}}, {{end}}{{$schemaForeignTable}}.{{$c | $.Quotes}}{{end}}"))
+	{{- end}}
+	mods = append(mods, r.Mods["{{$rel.ForeignTable}}"]...)
+	mods = append(mods, graphQLPageMods(limit, offset)...)
+
+	rows, err := obj.{{$relAlias.Local}}(mods...).All(ctx, r.Exec)
+	if err != nil {
+		return nil, err
+	}
+	return new{{$ftable.UpSingular}}Connection(rows, limit, offset), nil
+}
+{{end -}}
+{{- end}}
+{{end -}}
@@ -0,0 +1,49 @@
+{{- if .WithGraphQL -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $typ := index .GraphQLTypes .Table.Name -}}
+# Code generated by SQLBoiler (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+# This file is meant to be re-generated in place and/or deleted at any time.
+
+"A row of the {{.Table.Name}} {{if .Table.IsView}}view{{else}}table{{end}}"
+type {{$typ.Name}} {
+  {{- range $typ.Fields}}
+  {{.Name}}: {{.Type}}{{if .GoName}} @goField(name: "{{.GoName}}"){{end}}
+  {{- end}}
+  {{- range $typ.Skipped}}
+  # {{.Name}} is left out, {{.Type}} has no GraphQL type
+  {{- end}}
+  {{- if not .Table.IsView}}
+  {{- range $fkey := .Table.FKeys}}
+  {{- $rel := $alias.Relationship $fkey.Name}}
+  {{$rel.Foreign | graphQLName}}: {{($.Aliases.Table $fkey.ForeignTable).UpSingular}}
+  {{- end}}
+  {{- range $rel := .Table.ToOneRelationships}}
+  {{- $relAlias := ($.Aliases.Table $rel.ForeignTable).Relationship $rel.Name}}
+  {{$relAlias.Local | graphQLName}}: {{($.Aliases.Table $rel.ForeignTable).UpSingular}}
+  {{- end}}
+  {{- range $rel := .Table.ToManyRelationships}}
+  {{- $relAlias := $.Aliases.ManyRelationship $rel.ForeignTable $rel.Name $rel.JoinTable $rel.JoinLocalFKeyName}}
+  {{$relAlias.Local | graphQLName}}(first: Int, after: String): {{($.Aliases.Table $rel.ForeignTable).UpSingular}}Connection!
+  {{- end}}
+  {{- end}}
+}
+
+"A page of {{$alias.DownPlural}}"
+type {{$alias.UpSingular}}Connection {
+  edges: [{{$alias.UpSingular}}Edge!]!
+  pageInfo: PageInfo!
+}
+
+"A {{$alias.DownSingular}} in a page with its cursor"
+type {{$alias.UpSingular}}Edge {
+  cursor: String!
+  node: {{$alias.UpSingular}}!
+}
+
+extend type Query {
+  {{- if $typ.Keys}}
+  {{$alias.UpSingular | graphQLName}}({{range $i, $k := $typ.Keys}}{{if $i}}, {{end}}{{$k.Name}}: {{$k.Type}}{{end}}): {{$alias.UpSingular}}
+  {{- end}}
+  {{$alias.UpPlural | graphQLName}}(first: Int, after: String): {{$alias.UpSingular}}Connection!
+}
+{{end -}}
@@ -0,0 +1,19 @@
+{{- if .WithJSONSchema -}}
+{{- $schema := index .JSONSchemas .Table.Name -}}
+{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "{{.Table.Name}}.schema.json",
+  "title": "{{$schema.Title}}",
+  "description": "A row of the {{.Table.Name}} {{if .Table.IsView}}view{{else}}table{{end}}",
+  "type": "object",
+  "properties": {
+    {{- range $i, $p := $schema.Properties}}{{if $i}},{{end}}
+    "{{$p.Name}}": {{$p.Schema}}
+    {{- end}}
+  },
+  "required": [{{range $i, $name := $schema.Required}}{{if $i}}, {{end}}"{{$name}}"{{end}}]
+  {{- if eq .RelationTag "-"}},
+  "additionalProperties": false
+  {{- end}}
+}
+{{end -}}
@@ -0,0 +1,131 @@
+{{- if .AddCSV -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $record := index .CSVRecords .Table.Name -}}
+{{- $cols := $record.Columns -}}
+// {{$alias.DownSingular}}CSVHeader are the columns of the CSV records of {{$alias.DownPlural}}
+var {{$alias.DownSingular}}CSVHeader = []string{
+	{{- range $cols}}
+	"{{.Name}}",
+	{{- end}}
+}
+
+// ToCSVHeader returns the header of the CSV records of {{$alias.DownPlural}}, the names of
+// their columns
+func (*{{$alias.UpSingular}}) ToCSVHeader() []string {
+	return append([]string(nil), {{$alias.DownSingular}}CSVHeader...)
+}
+
+// ToCSVRecord returns the {{$alias.DownSingular}} as a CSV record. Null values are empty,
+// times are RFC 3339, bytes are base64 and arrays and decimals are JSON.
+func (o *{{$alias.UpSingular}}) ToCSVRecord() []string {
+	return []string{
+		{{- range $cols}}
+		{{.Format}},
+		{{- end}}
+	}
+}
+
+// FromCSVRecord sets the {{$alias.DownSingular}} from a CSV record written by ToCSVRecord
+func (o *{{$alias.UpSingular}}) FromCSVRecord(record []string) error {
+	if len(record) != len({{$alias.DownSingular}}CSVHeader) {
+		return errors.Errorf("{{.PkgName}}: want %d fields in a {{.Table.Name}} CSV record, got: %d", len({{$alias.DownSingular}}CSVHeader), len(record))
+	}
+
+	p := &csvParser{header: {{$alias.DownSingular}}CSVHeader, record: record}
+	{{- if $record.Required}}
+	p.required({{$record.Required}})
+	{{- end}}
+	{{- range $cols}}
+	{{.Parse}}
+	{{- if .Check}}
+	{{.Check}}
+	{{- end}}
+	{{- end}}
+
+	if p.err != nil {
+		return errors.Wrap(p.err, "{{.PkgName}}: unable to read a {{.Table.Name}} CSV record")
+	}
+
+	return nil
+}
+
+// {{$alias.UpSingular}}CSVWriter writes {{$alias.DownPlural}} as CSV records after their header
+type {{$alias.UpSingular}}CSVWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+// New{{$alias.UpSingular}}CSVWriter returns a writer of {{$alias.DownPlural}} to w
+func New{{$alias.UpSingular}}CSVWriter(w io.Writer) *{{$alias.UpSingular}}CSVWriter {
+	return &{{$alias.UpSingular}}CSVWriter{w: csv.NewWriter(w)}
+}
+
+// Write writes the {{$alias.DownSingular}}, after the header for the first one. The records
+// are buffered until Flush.
+func (w *{{$alias.UpSingular}}CSVWriter) Write(o *{{$alias.UpSingular}}) error {
+	if !w.wroteHeader {
+		if err := w.w.Write({{$alias.DownSingular}}CSVHeader); err != nil {
+			return err
+		}
+		w.wroteHeader = true
+	}
+
+	return w.w.Write(o.ToCSVRecord())
+}
+
+// Flush writes the buffered records, and the header if no {{$alias.DownSingular}} was written
+func (w *{{$alias.UpSingular}}CSVWriter) Flush() error {
+	if !w.wroteHeader {
+		if err := w.w.Write({{$alias.DownSingular}}CSVHeader); err != nil {
+			return err
+		}
+		w.wroteHeader = true
+	}
+
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// {{$alias.UpSingular}}CSVReader reads {{$alias.DownPlural}} from CSV records after their header,
+// the columns of the header can be in any order
+type {{$alias.UpSingular}}CSVReader struct {
+	r *csv.Reader
+	// indexes are the indexes in the records of the columns of the header
+	indexes []int
+}
+
+// New{{$alias.UpSingular}}CSVReader returns a reader of {{$alias.DownPlural}} from r
+func New{{$alias.UpSingular}}CSVReader(r io.Reader) *{{$alias.UpSingular}}CSVReader {
+	return &{{$alias.UpSingular}}CSVReader{r: csv.NewReader(r)}
+}
+
+// Read returns the next {{$alias.DownSingular}}, or io.EOF when there are none left
+func (r *{{$alias.UpSingular}}CSVReader) Read() (*{{$alias.UpSingular}}, error) {
+	if r.indexes == nil {
+		header, err := r.r.Read()
+		if err != nil {
+			return nil, err
+		}
+		if r.indexes, err = csvIndexes({{$alias.DownSingular}}CSVHeader, header); err != nil {
+			return nil, errors.Wrap(err, "{{.PkgName}}: unable to read the {{.Table.Name}} CSV header")
+		}
+	}
+
+	fields, err := r.r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	record := make([]string, len(r.indexes))
+	for i, j := range r.indexes {
+		record[i] = fields[j]
+	}
+
+	o := &{{$alias.UpSingular}}{}
+	if err := o.FromCSVRecord(record); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+{{end -}}
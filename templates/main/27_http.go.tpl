@@ -0,0 +1,187 @@
+{{- if .WithHTTP -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $handler := index .HTTPHandlers .Table.Name -}}
+{{- $soft := and .AddSoftDeletes (.Table.CanSoftDelete .AutoColumns.Deleted) -}}
+{{- $execType := "boil.ContextExecutor" -}}
+{{- $ctxArg := "r.Context(), " -}}
+{{- if .NoContext -}}
+{{- $execType = "boil.Executor" -}}
+{{- $ctxArg = "" -}}
+{{- end -}}
+// {{$alias.DownSingular}}HTTPFilters are the columns the {{$alias.DownPlural}} are filtered by in lists,
+// by the query parameters named after them
+var {{$alias.DownSingular}}HTTPFilters = map[string]string{
+	{{- range $handler.Filters}}
+	"{{.}}": "{{$.Quotes .}}",
+	{{- end}}
+}
+
+// {{$alias.UpSingular}}Handler serves {{$alias.DownPlural}} as JSON. Mounted at a prefix with
+// http.StripPrefix it lists them at / with GET{{if not .Table.IsView}} and creates them with POST{{end}}.
+{{- if $handler.Keys}}
+// It gets, updates and deletes them at /{{range $i, $col := .Table.PKey.Columns}}{{if ne $i 0}}/{{end}}{{"{"}}{{$col}}{{"}"}}{{end}} with GET, PUT or PATCH and DELETE.
+{{- end}}
+// Its methods can be routed on their own too, see HTTPKey.
+type {{$alias.UpSingular}}Handler struct {
+	Exec {{$execType}}
+}
+
+// New{{$alias.UpSingular}}Handler returns a handler of {{$alias.DownPlural}} running its queries on exec
+func New{{$alias.UpSingular}}Handler(exec {{$execType}}) *{{$alias.UpSingular}}Handler {
+	return &{{$alias.UpSingular}}Handler{Exec: exec}
+}
+
+// ServeHTTP routes the request to the method of the handler
+func (h *{{$alias.UpSingular}}Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch httpSegments(r) {
+	case 0:
+		switch r.Method {
+		case http.MethodGet:
+			h.List(w, r)
+		{{- if not .Table.IsView}}
+		case http.MethodPost:
+			h.Create(w, r)
+		{{- end}}
+		default:
+			httpMethodNotAllowed(w, r, "{{if .Table.IsView}}GET{{else}}GET, POST{{end}}")
+		}
+	{{- if $handler.Keys}}
+	case {{len .Table.PKey.Columns}}:
+		switch r.Method {
+		case http.MethodGet:
+			h.Get(w, r)
+		case http.MethodPut, http.MethodPatch:
+			h.Update(w, r)
+		case http.MethodDelete:
+			h.Delete(w, r)
+		default:
+			httpMethodNotAllowed(w, r, "GET, PUT, PATCH, DELETE")
+		}
+	{{- end}}
+	default:
+		HTTPError(w, r, http.StatusNotFound, errors.New("not found"))
+	}
+}
+
+// List writes a page of {{$alias.DownPlural}}, see HTTPPage. The query parameters limit and
+// offset choose the page, order_by a column to order by, descending with a - prefix,
+// and the parameters named after columns the values to filter by.
+func (h *{{$alias.UpSingular}}Handler) List(w http.ResponseWriter, r *http.Request) {
+	limit, offset, mods, err := httpListMods(r, {{$alias.DownSingular}}HTTPFilters, "{{if not .Table.IsView}}{{range $i, $col := .Table.PKey.Columns}}{{if ne $i 0}}, {{end}}{{$.Quotes $col}}{{end}}{{end}}")
+	if err != nil {
+		HTTPError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	slice, err := {{$alias.UpPlural}}(mods...).All({{$ctxArg}}h.Exec)
+	if err != nil {
+		HTTPError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if slice == nil {
+		slice = {{$alias.UpSingular}}Slice{}
+	}
+
+	page := HTTPPage{Items: slice}
+	if len(slice) > limit {
+		page.Items = slice[:limit]
+		next := offset + limit
+		page.NextOffset = &next
+	}
+	httpJSON(w, http.StatusOK, page)
+}
+{{- if not .Table.IsView}}
+
+// Create inserts the {{$alias.DownSingular}} of the JSON of the request body and writes it
+func (h *{{$alias.UpSingular}}Handler) Create(w http.ResponseWriter, r *http.Request) {
+	o := &{{$alias.UpSingular}}{}
+	if err := httpDecode(r, o); err != nil {
+		HTTPError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := o.Insert({{$ctxArg}}h.Exec, boil.Infer()); err != nil {
+		HTTPError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	httpJSON(w, http.StatusCreated, o)
+}
+{{- end}}
+{{- if $handler.Keys}}
+
+// Get writes the {{$alias.DownSingular}} of the primary key of the request
+func (h *{{$alias.UpSingular}}Handler) Get(w http.ResponseWriter, r *http.Request) {
+	o, ok := h.find(w, r)
+	if !ok {
+		return
+	}
+	httpJSON(w, http.StatusOK, o)
+}
+
+// Update sets the columns of the JSON of the request body on the {{$alias.DownSingular}} of the
+// primary key of the request, other than its primary key, and writes it
+func (h *{{$alias.UpSingular}}Handler) Update(w http.ResponseWriter, r *http.Request) {
+	o, ok := h.find(w, r)
+	if !ok {
+		return
+	}
+
+	key := *o
+	if err := httpDecode(r, o); err != nil {
+		HTTPError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	{{- range .Table.PKey.Columns}}
+	o.{{$alias.Column .}} = key.{{$alias.Column .}}
+	{{- end}}
+
+	if {{if not .NoRowsAffected}}_, {{end -}} err := o.Update({{$ctxArg}}h.Exec, boil.Infer()); err != nil {
+		HTTPError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	httpJSON(w, http.StatusOK, o)
+}
+
+// Delete deletes the {{$alias.DownSingular}} of the primary key of the request
+func (h *{{$alias.UpSingular}}Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	o, ok := h.find(w, r)
+	if !ok {
+		return
+	}
+
+	if {{if not .NoRowsAffected}}_, {{end -}} err := o.Delete({{$ctxArg}}h.Exec{{if $soft}}, false{{end}}); err != nil {
+		HTTPError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// find finds the {{$alias.DownSingular}} of the primary key of the request, it writes the
+// error when there is none
+func (h *{{$alias.UpSingular}}Handler) find(w http.ResponseWriter, r *http.Request) (*{{$alias.UpSingular}}, bool) {
+	key := HTTPKey(r, {{$alias.DownSingular}}PrimaryKeyColumns)
+	if len(key) != {{len .Table.PKey.Columns}} {
+		HTTPError(w, r, http.StatusNotFound, errors.New("not found"))
+		return nil, false
+	}
+	{{- range $handler.Keys}}
+	{{- if .Parse}}
+	{{.Var}}, err := {{.Parse}}
+	if err != nil {
+		HTTPError(w, r, http.StatusBadRequest, err)
+		return nil, false
+	}
+	{{- end}}
+	{{- end}}
+
+	o, err := Find{{$alias.UpSingular}}({{$ctxArg}}h.Exec{{range $handler.Keys}}, {{.Arg}}{{end}})
+	if err != nil {
+		httpFindError(w, r, err)
+		return nil, false
+	}
+
+	return o, true
+}
+{{- end}}
+{{- end}}
@@ -0,0 +1,123 @@
+{{- if .WithGRPC -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $msg := index .ProtoMessages .Table.Name -}}
+{{- $soft := and .AddSoftDeletes (.Table.CanSoftDelete .AutoColumns.Deleted) -}}
+{{- if $msg.Updates -}}
+// {{$alias.DownSingular}}GRPCFields are the fields updated by Update{{$msg.Name}} when its request has
+// no field mask, by name
+var {{$alias.DownSingular}}GRPCFields = []string{
+	{{- range $msg.Updates}}
+	"{{.Name}}",
+	{{- end}}
+}
+
+{{end -}}
+// {{$alias.UpSingular}}Server implements pb.{{$msg.Name}}ServiceServer with the {{$alias.DownPlural}} of the
+// database, register it with pb.Register{{$msg.Name}}ServiceServer
+type {{$alias.UpSingular}}Server struct {
+	pb.Unimplemented{{$msg.Name}}ServiceServer
+
+	Exec boil.ContextExecutor
+}
+
+// New{{$alias.UpSingular}}Server returns a server of {{$alias.DownPlural}} running its queries on exec
+func New{{$alias.UpSingular}}Server(exec boil.ContextExecutor) *{{$alias.UpSingular}}Server {
+	return &{{$alias.UpSingular}}Server{Exec: exec}
+}
+
+// List{{$alias.UpPlural}} returns a page of {{$alias.DownPlural}}{{if not .Table.IsView}} ordered by their primary key{{end}}
+func (s *{{$alias.UpSingular}}Server) List{{$alias.UpPlural}}(ctx context.Context, req *pb.List{{$alias.UpPlural}}Request) (*pb.List{{$alias.UpPlural}}Response, error) {
+	limit, offset, mods, err := grpcListMods(req.GetPageSize(), req.GetPageToken(), "{{if not .Table.IsView}}{{range $i, $col := .Table.PKey.Columns}}{{if ne $i 0}}, {{end}}{{$.Quotes $col}}{{end}}{{end}}")
+	if err != nil {
+		return nil, err
+	}
+
+	slice, err := {{$alias.UpPlural}}(mods...).All(ctx, s.Exec)
+	if err != nil {
+		return nil, GRPCError(err)
+	}
+
+	res := &pb.List{{$alias.UpPlural}}Response{}
+	if len(slice) > limit {
+		slice = slice[:limit]
+		res.NextPageToken = grpcPageToken(offset + limit)
+	}
+	res.Items = make([]*pb.{{$msg.Name}}, len(slice))
+	for i, o := range slice {
+		res.Items[i] = o.ToProto()
+	}
+
+	return res, nil
+}
+{{- if not .Table.IsView}}
+
+// Create{{$msg.Name}} inserts the {{$alias.DownSingular}} of the item of the request and returns it
+func (s *{{$alias.UpSingular}}Server) Create{{$msg.Name}}(ctx context.Context, req *pb.Create{{$msg.Name}}Request) (*pb.{{$msg.Name}}, error) {
+	o := &{{$alias.UpSingular}}{}
+	o.FromProto(req.GetItem())
+
+	if err := o.Insert(ctx, s.Exec, boil.Infer()); err != nil {
+		return nil, GRPCError(err)
+	}
+	return o.ToProto(), nil
+}
+{{- end}}
+{{- if $msg.Keys}}
+
+// Get{{$msg.Name}} returns the {{$alias.DownSingular}} of the primary key of the request
+func (s *{{$alias.UpSingular}}Server) Get{{$msg.Name}}(ctx context.Context, req *pb.Get{{$msg.Name}}Request) (*pb.{{$msg.Name}}, error) {
+	m := req
+	o, err := Find{{$alias.UpSingular}}(ctx, s.Exec{{range $msg.Keys}}, {{.FromProto}}{{end}})
+	if err != nil {
+		return nil, GRPCError(err)
+	}
+	return o.ToProto(), nil
+}
+{{- if $msg.Updates}}
+
+// Update{{$msg.Name}} sets the fields of the update mask of the request on the {{$alias.DownSingular}}
+// of the primary key of its item, and returns it. Fields of the primary key
+// cannot be updated.
+func (s *{{$alias.UpSingular}}Server) Update{{$msg.Name}}(ctx context.Context, req *pb.Update{{$msg.Name}}Request) (*pb.{{$msg.Name}}, error) {
+	m := req.GetItem()
+	o, err := Find{{$alias.UpSingular}}(ctx, s.Exec{{range $msg.Keys}}, {{.FromProto}}{{end}})
+	if err != nil {
+		return nil, GRPCError(err)
+	}
+
+	paths := grpcMaskPaths(req.GetUpdateMask().GetPaths(), {{$alias.DownSingular}}GRPCFields)
+	columns := make([]string, len(paths))
+	for i, path := range paths {
+		switch path {
+		{{- range $msg.Updates}}
+		case "{{.Name}}":
+			o.{{$alias.Column .Name}} = {{.FromProto}}
+			columns[i] = {{$alias.UpSingular}}Columns.{{$alias.Column .Name}}
+		{{- end}}
+		default:
+			return nil, grpcUnknownPath(path)
+		}
+	}
+
+	if {{if not .NoRowsAffected}}_, {{end -}} err := o.Update(ctx, s.Exec, boil.Whitelist(columns...)); err != nil {
+		return nil, GRPCError(err)
+	}
+	return o.ToProto(), nil
+}
+{{- end}}
+
+// Delete{{$msg.Name}} deletes the {{$alias.DownSingular}} of the primary key of the request
+func (s *{{$alias.UpSingular}}Server) Delete{{$msg.Name}}(ctx context.Context, req *pb.Delete{{$msg.Name}}Request) (*pb.Delete{{$msg.Name}}Response, error) {
+	m := req
+	o, err := Find{{$alias.UpSingular}}(ctx, s.Exec{{range $msg.Keys}}, {{.FromProto}}{{end}})
+	if err != nil {
+		return nil, GRPCError(err)
+	}
+
+	if {{if not .NoRowsAffected}}_, {{end -}} err := o.Delete(ctx, s.Exec{{if $soft}}, false{{end}}); err != nil {
+		return nil, GRPCError(err)
+	}
+	return &pb.Delete{{$msg.Name}}Response{}, nil
+}
+{{- end}}
+{{- end}}
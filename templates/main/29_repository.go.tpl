@@ -0,0 +1,93 @@
+{{- if .AddRepositories -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $soft := and .AddSoftDeletes (.Table.CanSoftDelete .AutoColumns.Deleted) -}}
+{{- $execType := "boil.ContextExecutor" -}}
+{{- $ctxParam := "ctx context.Context, " -}}
+{{- $ctxArg := "ctx, " -}}
+{{- if .NoContext -}}
+{{- $execType = "boil.Executor" -}}
+{{- $ctxParam = "" -}}
+{{- $ctxArg = "" -}}
+{{- end -}}
+{{- $pkNames := "" -}}
+{{- $pkArgs := "" -}}
+{{- if not .Table.IsView -}}
+{{- $colDefs := sqlColDefinitions .Table.Columns .Table.PKey.Columns -}}
+{{- $pkNames = $colDefs.Names | stringMap (aliasCols $alias) | stringMap .StringFuncs.camelCase | stringMap .StringFuncs.replaceReserved -}}
+{{- $pkArgs = joinSlices " " $pkNames $colDefs.Types | join ", " -}}
+{{- end -}}
+// {{$alias.UpSingular}}Repository reads and writes {{$alias.DownPlural}}, {{$alias.UpSingular}}Store implements it with
+// the database. Code depending on the interface can be tested without a database,
+// with a fake or a mock generated by gomock or moq.
+type {{$alias.UpSingular}}Repository interface {
+	{{- if not .Table.IsView}}
+	// Find returns the {{$alias.DownSingular}} of the primary key, see Find{{$alias.UpSingular}}
+	Find({{$ctxParam}}{{$pkArgs}}, selectCols ...string) (*{{$alias.UpSingular}}, error)
+	{{- end}}
+	// All returns every {{$alias.DownSingular}}
+	All({{if not .NoContext}}ctx context.Context{{end}}) ({{$alias.UpSingular}}Slice, error)
+	// List returns the {{$alias.DownPlural}} of the query mods
+	List({{$ctxParam}}mods ...qm.QueryMod) ({{$alias.UpSingular}}Slice, error)
+	// Count returns the number of {{$alias.DownPlural}} of the query mods
+	Count({{$ctxParam}}mods ...qm.QueryMod) (int64, error)
+	{{- if not .Table.IsView}}
+	// Insert inserts the columns of the {{$alias.DownSingular}}, see {{$alias.UpSingular}}.Insert
+	Insert({{$ctxParam}}o *{{$alias.UpSingular}}, columns boil.Columns) error
+	// Update updates the columns of the {{$alias.DownSingular}}, see {{$alias.UpSingular}}.Update
+	Update({{$ctxParam}}o *{{$alias.UpSingular}}, columns boil.Columns) {{if .NoRowsAffected}}error{{else}}(int64, error){{end}}
+	// Delete deletes the {{$alias.DownSingular}}{{if $soft}}, softly{{end}}, see {{$alias.UpSingular}}.Delete
+	Delete({{$ctxParam}}o *{{$alias.UpSingular}}) {{if .NoRowsAffected}}error{{else}}(int64, error){{end}}
+	{{- end}}
+}
+
+// {{$alias.UpSingular}}Store is the {{$alias.UpSingular}}Repository running its queries on Exec
+type {{$alias.UpSingular}}Store struct {
+	Exec {{$execType}}
+}
+
+var _ {{$alias.UpSingular}}Repository = (*{{$alias.UpSingular}}Store)(nil)
+
+// New{{$alias.UpSingular}}Store returns a store of {{$alias.DownPlural}} running its queries on exec
+func New{{$alias.UpSingular}}Store(exec {{$execType}}) *{{$alias.UpSingular}}Store {
+	return &{{$alias.UpSingular}}Store{Exec: exec}
+}
+{{- if not .Table.IsView}}
+
+// Find returns the {{$alias.DownSingular}} of the primary key
+func (s *{{$alias.UpSingular}}Store) Find({{$ctxParam}}{{$pkArgs}}, selectCols ...string) (*{{$alias.UpSingular}}, error) {
+	return Find{{$alias.UpSingular}}({{$ctxArg}}s.Exec, {{$pkNames | join ", "}}, selectCols...)
+}
+{{- end}}
+
+// All returns every {{$alias.DownSingular}}
+func (s *{{$alias.UpSingular}}Store) All({{if not .NoContext}}ctx context.Context{{end}}) ({{$alias.UpSingular}}Slice, error) {
+	return {{$alias.UpPlural}}().All({{$ctxArg}}s.Exec)
+}
+
+// List returns the {{$alias.DownPlural}} of the query mods
+func (s *{{$alias.UpSingular}}Store) List({{$ctxParam}}mods ...qm.QueryMod) ({{$alias.UpSingular}}Slice, error) {
+	return {{$alias.UpPlural}}(mods...).All({{$ctxArg}}s.Exec)
+}
+
+// Count returns the number of {{$alias.DownPlural}} of the query mods
+func (s *{{$alias.UpSingular}}Store) Count({{$ctxParam}}mods ...qm.QueryMod) (int64, error) {
+	return {{$alias.UpPlural}}(mods...).Count({{$ctxArg}}s.Exec)
+}
+{{- if not .Table.IsView}}
+
+// Insert inserts the columns of the {{$alias.DownSingular}}
+func (s *{{$alias.UpSingular}}Store) Insert({{$ctxParam}}o *{{$alias.UpSingular}}, columns boil.Columns) error {
+	return o.Insert({{$ctxArg}}s.Exec, columns)
+}
+
+// Update updates the columns of the {{$alias.DownSingular}}
+func (s *{{$alias.UpSingular}}Store) Update({{$ctxParam}}o *{{$alias.UpSingular}}, columns boil.Columns) {{if .NoRowsAffected}}error{{else}}(int64, error){{end}} {
+	return o.Update({{$ctxArg}}s.Exec, columns)
+}
+
+// Delete deletes the {{$alias.DownSingular}}
+func (s *{{$alias.UpSingular}}Store) Delete({{$ctxParam}}o *{{$alias.UpSingular}}) {{if .NoRowsAffected}}error{{else}}(int64, error){{end}} {
+	return o.Delete({{$ctxArg}}s.Exec{{if $soft}}, false{{end}})
+}
+{{- end}}
+{{- end}}
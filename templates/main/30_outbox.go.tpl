@@ -0,0 +1,42 @@
+{{- if .Outbox.Publishes .Table.Name -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $ctxParam := "" -}}
+{{- $ctxArg := "" -}}
+{{- $execType := "boil.Executor" -}}
+{{- if not .NoContext -}}
+{{- $ctxParam = "ctx context.Context, " -}}
+{{- $ctxArg = "ctx, " -}}
+{{- $execType = "boil.ContextExecutor" -}}
+{{- end -}}
+func init() {
+	Add{{$alias.UpSingular}}Hook(boil.AfterInsertHook, {{$alias.DownSingular}}OutboxInsert)
+	Add{{$alias.UpSingular}}Hook(boil.AfterUpdateHook, {{$alias.DownSingular}}OutboxUpdate)
+	Add{{$alias.UpSingular}}Hook(boil.AfterDeleteHook, {{$alias.DownSingular}}OutboxDelete)
+	Add{{$alias.UpSingular}}Hook(boil.AfterUpsertHook, {{$alias.DownSingular}}OutboxUpsert)
+}
+
+// {{$alias.DownSingular}}OutboxWrite writes a change of the {{$alias.DownSingular}} to {{.Outbox.Table}}
+func {{$alias.DownSingular}}OutboxWrite({{$ctxParam}}exec {{$execType}}, operation string, o *{{$alias.UpSingular}}) error {
+	return outboxWrite({{$ctxArg}}exec, "{{.Table.Name}}", operation, map[string]interface{}{
+		{{range .Table.PKey.Columns -}}
+		"{{.}}": o.{{$alias.Column .}},
+		{{end -}}
+	}, o)
+}
+
+func {{$alias.DownSingular}}OutboxInsert({{$ctxParam}}exec {{$execType}}, o *{{$alias.UpSingular}}) error {
+	return {{$alias.DownSingular}}OutboxWrite({{$ctxArg}}exec, "INSERT", o)
+}
+
+func {{$alias.DownSingular}}OutboxUpdate({{$ctxParam}}exec {{$execType}}, o *{{$alias.UpSingular}}) error {
+	return {{$alias.DownSingular}}OutboxWrite({{$ctxArg}}exec, "UPDATE", o)
+}
+
+func {{$alias.DownSingular}}OutboxDelete({{$ctxParam}}exec {{$execType}}, o *{{$alias.UpSingular}}) error {
+	return {{$alias.DownSingular}}OutboxWrite({{$ctxArg}}exec, "DELETE", o)
+}
+
+func {{$alias.DownSingular}}OutboxUpsert({{$ctxParam}}exec {{$execType}}, o *{{$alias.UpSingular}}) error {
+	return {{$alias.DownSingular}}OutboxWrite({{$ctxArg}}exec, "UPSERT", o)
+}
+{{end -}}
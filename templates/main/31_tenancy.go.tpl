@@ -0,0 +1,19 @@
+{{- if and (.Tenancy.Scopes .Table.Name) (not .Table.IsView) -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+// {{$alias.DownSingular}}TenantKeyColumns identify the {{$alias.DownPlural}} changed by their primary key
+// and their tenant, so that no row of another tenant is changed
+var (
+	{{$alias.DownSingular}}TenantKeyColumns    = []string{"{{.Tenancy.KeyColumns .Table | join "\", \""}}"}
+	{{$alias.DownSingular}}TenantKeyMapping, _ = queries.BindMapping({{$alias.DownSingular}}Type, {{$alias.DownSingular}}Mapping, {{$alias.DownSingular}}TenantKeyColumns)
+)
+
+// tenantCheck checks that the {{$alias.DownPlural}} belong to the tenant of the context
+func (o {{$alias.UpSingular}}Slice) tenantCheck(ctx context.Context) error {
+	for _, obj := range o {
+		if err := tenantCheck(ctx, obj.{{$alias.Column .Tenancy.Column}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+{{end -}}
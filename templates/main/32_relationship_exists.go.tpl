@@ -0,0 +1,115 @@
+{{- if or .Table.IsJoinTable .Table.IsView -}}
+{{- else -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $schemaTable := .Table.Name | .SchemaTable -}}
+{{- $hasRelationships := false -}}
+{{- range .Table.FKeys}}{{if ne .ForeignTable .Table}}{{$hasRelationships = true}}{{end}}{{end -}}
+{{- range .Table.ToOneRelationships}}{{if ne .ForeignTable .Table}}{{$hasRelationships = true}}{{end}}{{end -}}
+{{- range .Table.ToManyRelationships}}{{if ne .ForeignTable .Table}}{{$hasRelationships = true}}{{end}}{{end -}}
+{{- if $hasRelationships -}}
+// {{$alias.DownSingular}}QM has the query mods filtering {{$alias.DownPlural}} by their relationships
+type {{$alias.DownSingular}}QM struct{}
+
+// {{$alias.UpSingular}}QM filters {{$alias.DownPlural}} by their relationships with exists subqueries over
+// their foreign keys, the relationships of a table with itself are left out
+var {{$alias.UpSingular}}QM {{$alias.DownSingular}}QM
+{{range $fkey := .Table.FKeys -}}
+{{- if ne $fkey.ForeignTable $fkey.Table -}}
+{{- $ftable := $.Aliases.Table $fkey.ForeignTable -}}
+{{- $relAlias := $alias.Relationship $fkey.Name -}}
+{{- $schemaForeignTable := $fkey.ForeignTable | $.SchemaTable}}
+
+// exists{{$relAlias.Foreign}} returns the subquery of the {{$ftable.DownSingular}} of a {{$alias.DownSingular}} matching the query mods
+func ({{$alias.DownSingular}}QM) exists{{$relAlias.Foreign}}(mods []qm.QueryMod) *queries.Query {
+	queryMods := []qm.QueryMod{
+		qm.Select("1"),
+		qm.Where("{{$schemaForeignTable}}.{{$fkey.ForeignColumn | $.Quotes}} = {{$schemaTable}}.{{$fkey.Column | $.Quotes}}"),
+	}
+	queryMods = append(queryMods, mods...)
+
+	return {{$ftable.UpPlural}}(queryMods...).Query
+}
+
+// Has{{$relAlias.Foreign}} filters the {{$alias.DownPlural}} to those whose {{$ftable.DownSingular}} matches the query mods.
+func (m {{$alias.DownSingular}}QM) Has{{$relAlias.Foreign}}(mods ...qm.QueryMod) qm.QueryMod {
+	return qm.Where("exists ?", m.exists{{$relAlias.Foreign}}(mods))
+}
+
+// HasNo{{$relAlias.Foreign}} filters the {{$alias.DownPlural}} to those without a {{$ftable.DownSingular}}
+// matching the query mods.
+func (m {{$alias.DownSingular}}QM) HasNo{{$relAlias.Foreign}}(mods ...qm.QueryMod) qm.QueryMod {
+	return qm.Where("not exists ?", m.exists{{$relAlias.Foreign}}(mods))
+}
+{{end -}}
+{{- end -}}
+
+{{- range $rel := .Table.ToOneRelationships -}}
+{{- if ne $rel.ForeignTable $rel.Table -}}
+{{- $ftable := $.Aliases.Table $rel.ForeignTable -}}
+{{- $relAlias := $ftable.Relationship $rel.Name -}}
+{{- $schemaForeignTable := $rel.ForeignTable | $.SchemaTable}}
+
+// exists{{$relAlias.Local}} returns the subquery of the {{$ftable.DownSingular}} of a {{$alias.DownSingular}} matching the query mods
+func ({{$alias.DownSingular}}QM) exists{{$relAlias.Local}}(mods []qm.QueryMod) *queries.Query {
+	queryMods := []qm.QueryMod{
+		qm.Select("1"),
+		qm.Where("{{$schemaForeignTable}}.{{$rel.ForeignColumn | $.Quotes}} = {{$schemaTable}}.{{$rel.Column | $.Quotes}}"),
+	}
+	queryMods = append(queryMods, mods...)
+
+	return {{$ftable.UpPlural}}(queryMods...).Query
+}
+
+// Has{{$relAlias.Local}} filters the {{$alias.DownPlural}} to those whose {{$ftable.DownSingular}} matches the query mods.
+func (m {{$alias.DownSingular}}QM) Has{{$relAlias.Local}}(mods ...qm.QueryMod) qm.QueryMod {
+	return qm.Where("exists ?", m.exists{{$relAlias.Local}}(mods))
+}
+
+// HasNo{{$relAlias.Local}} filters the {{$alias.DownPlural}} to those without a {{$ftable.DownSingular}}
+// matching the query mods.
+func (m {{$alias.DownSingular}}QM) HasNo{{$relAlias.Local}}(mods ...qm.QueryMod) qm.QueryMod {
+	return qm.Where("not exists ?", m.exists{{$relAlias.Local}}(mods))
+}
+{{end -}}
+{{- end -}}
+
+{{- range $rel := .Table.ToManyRelationships -}}
+{{- if ne $rel.ForeignTable $rel.Table -}}
+{{- $ftable := $.Aliases.Table $rel.ForeignTable -}}
+{{- $relAlias := $.Aliases.ManyRelationship $rel.ForeignTable $rel.Name $rel.JoinTable $rel.JoinLocalFKeyName -}}
+{{- $schemaForeignTable := $rel.ForeignTable | $.SchemaTable}}
+
+// exists{{$relAlias.Local}} returns the subquery of the {{$ftable.DownPlural}} of a {{$alias.DownSingular}} matching the query mods
+func ({{$alias.DownSingular}}QM) exists{{$relAlias.Local}}(mods []qm.QueryMod) *queries.Query {
+	{{if $rel.ToJoinTable -}}
+	{{- $schemaJoinTable := $rel.JoinTable | $.SchemaTable -}}
+	queryMods := []qm.QueryMod{
+		qm.Select("1"),
+		qm.InnerJoin("{{$schemaJoinTable}} on {{$schemaForeignTable}}.{{$rel.ForeignColumn | $.Quotes}} = {{$schemaJoinTable}}.{{$rel.JoinForeignColumn | $.Quotes}}"),
+		qm.Where("{{$schemaJoinTable}}.{{$rel.JoinLocalColumn | $.Quotes}} = {{$schemaTable}}.{{$rel.Column | $.Quotes}}"),
+	}
+	{{- else -}}
+	queryMods := []qm.QueryMod{
+		qm.Select("1"),
+		qm.Where("{{$schemaForeignTable}}.{{$rel.ForeignColumn | $.Quotes}} = {{$schemaTable}}.{{$rel.Column | $.Quotes}}"),
+	}
+	{{- end}}
+	queryMods = append(queryMods, mods...)
+
+	return {{$ftable.UpPlural}}(queryMods...).Query
+}
+
+// Has{{$relAlias.Local}} filters the {{$alias.DownPlural}} to those with {{$ftable.DownPlural}} matching the query mods.
+func (m {{$alias.DownSingular}}QM) Has{{$relAlias.Local}}(mods ...qm.QueryMod) qm.QueryMod {
+	return qm.Where("exists ?", m.exists{{$relAlias.Local}}(mods))
+}
+
+// HasNo{{$relAlias.Local}} filters the {{$alias.DownPlural}} to those without {{$ftable.DownPlural}}
+// matching the query mods.
+func (m {{$alias.DownSingular}}QM) HasNo{{$relAlias.Local}}(mods ...qm.QueryMod) qm.QueryMod {
+	return qm.Where("not exists ?", m.exists{{$relAlias.Local}}(mods))
+}
+{{end -}}
+{{- end -}}
+{{- end -}}
+{{- end -}}
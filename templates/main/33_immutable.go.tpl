@@ -0,0 +1,80 @@
+{{- if .AddImmutable -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $updated := or .AutoColumns.Updated "updated_at" -}}
+{{- $colNames := .Table.Columns | columnNames -}}
+{{- range $column := .Table.Columns -}}
+{{- $colAlias := $alias.Column $column.Name}}
+// With{{$colAlias}} returns a copy of the {{$alias.DownSingular}} with {{$colAlias}} set to v, the {{$alias.DownSingular}} itself is left unchanged
+func (o {{$alias.UpSingular}}) With{{$colAlias}}(v {{$column.Type}}) {{$alias.UpSingular}} {
+	o.{{$colAlias}} = v
+	return o
+}
+{{end}}
+// Values returns copies of the {{$alias.DownPlural}} of the slice
+func (o {{$alias.UpSingular}}Slice) Values() []{{$alias.UpSingular}} {
+	values := make([]{{$alias.UpSingular}}, len(o))
+	for i, v := range o {
+		values[i] = *v
+	}
+	return values
+}
+{{- if not .Table.IsView}}
+
+// {{$alias.UpSingular}}UpdateBuilder collects the columns of an update of a {{$alias.DownSingular}}, Exec
+// writes them and returns the updated copy, the {{$alias.DownSingular}} it was made from is
+// left unchanged
+type {{$alias.UpSingular}}UpdateBuilder struct {
+	o       {{$alias.UpSingular}}
+	columns []string
+}
+
+// UpdateBuilder returns a builder of an update of the {{$alias.DownSingular}}
+func (o {{$alias.UpSingular}}) UpdateBuilder() *{{$alias.UpSingular}}UpdateBuilder {
+	return &{{$alias.UpSingular}}UpdateBuilder{o: o}
+}
+
+// set adds the column to the columns of the update
+func (b *{{$alias.UpSingular}}UpdateBuilder) set(column string) {
+	for _, c := range b.columns {
+		if c == column {
+			return
+		}
+	}
+	b.columns = append(b.columns, column)
+}
+{{- range $column := .Table.Columns -}}
+{{- if not (containsAny $.Table.PKey.Columns $column.Name) -}}
+{{- $colAlias := $alias.Column $column.Name}}
+
+// Set{{$colAlias}} sets {{$colAlias}} to v in the update
+func (b *{{$alias.UpSingular}}UpdateBuilder) Set{{$colAlias}}(v {{$column.Type}}) *{{$alias.UpSingular}}UpdateBuilder {
+	b.o.{{$colAlias}} = v
+	b.set({{$alias.UpSingular}}Columns.{{$colAlias}})
+	return b
+}
+{{- end -}}
+{{- end}}
+
+// Exec updates the set columns of the {{$alias.DownSingular}} and returns the updated copy,
+// with the columns the hooks and automatic timestamps set. Without set columns
+// nothing is written.
+func (b *{{$alias.UpSingular}}UpdateBuilder) Exec({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) ({{$alias.UpSingular}}, error) {
+	o := b.o
+	if len(b.columns) == 0 {
+		return o, nil
+	}
+
+	columns := b.columns
+	{{- if and (not .NoAutoTimestamps) (containsAny $colNames $updated) (not (.Table.TriggerSets "UPDATE" $updated))}}
+	if !strmangle.ContainsAny(columns, {{$alias.UpSingular}}Columns.{{$alias.Column $updated}}) {
+		columns = append(columns[:len(columns):len(columns)], {{$alias.UpSingular}}Columns.{{$alias.Column $updated}})
+	}
+	{{- end}}
+	if {{if not .NoRowsAffected}}_, {{end}}err := o.Update({{if not .NoContext}}ctx, {{end}}exec, boil.Whitelist(columns...)); err != nil {
+		return b.o, err
+	}
+
+	return o, nil
+}
+{{- end}}
+{{end -}}
@@ -0,0 +1,52 @@
+{{- if and .AddInterfaces (not .Table.IsJoinTable) -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $soft := and .AddSoftDeletes (.Table.CanSoftDelete .AutoColumns.Deleted) -}}
+{{- $created := or .AutoColumns.Created "created_at" -}}
+{{- $updated := or .AutoColumns.Updated "updated_at" -}}
+{{- $timestamps := 0 -}}
+// TableName returns the name of the {{if .Table.IsView}}view{{else}}table{{end}} of the {{$alias.DownPlural}}
+func (o *{{$alias.UpSingular}}) TableName() string {
+	return "{{.Table.Name}}"
+}
+{{- if and .Table.PKey (eq (len .Table.PKey.Columns) 1)}}
+
+// GetID returns the primary key of the {{$alias.DownSingular}}
+func (o *{{$alias.UpSingular}}) GetID() interface{} {
+	return o.{{$alias.Column (index .Table.PKey.Columns 0)}}
+}
+{{- end}}
+{{- range $column := .Table.Columns}}
+{{- $getter := "" -}}
+{{- if eq $column.Name $created}}{{$getter = "GetCreatedAt"}}{{end -}}
+{{- if eq $column.Name $updated}}{{$getter = "GetUpdatedAt"}}{{end -}}
+{{- if and $getter (or (eq $column.Type "time.Time") (eq $column.Type "null.Time"))}}
+{{- $timestamps = add $timestamps 1 -}}
+{{- $colAlias := $alias.Column $column.Name}}
+
+// {{$getter}} returns {{$colAlias}}{{if $column.Nullable}}, the zero time for null{{end}}
+func (o *{{$alias.UpSingular}}) {{$getter}}() time.Time {
+	{{- if eq $column.Type "null.Time"}}
+	if !o.{{$colAlias}}.Valid {
+		return time.Time{}
+	}
+	return o.{{$colAlias}}.Time
+	{{- else}}
+	return o.{{$colAlias}}
+	{{- end}}
+}
+{{- end}}
+{{- end}}
+
+var (
+	_ TableNamer = (*{{$alias.UpSingular}})(nil)
+	{{- if and .Table.PKey (eq (len .Table.PKey.Columns) 1)}}
+	_ Identifiable = (*{{$alias.UpSingular}})(nil)
+	{{- end}}
+	{{- if eq $timestamps 2}}
+	_ Timestamped = (*{{$alias.UpSingular}})(nil)
+	{{- end}}
+	{{- if not .Table.IsView}}
+	_ {{if $soft}}SoftModel{{else}}Model{{end}} = (*{{$alias.UpSingular}})(nil)
+	{{- end}}
+)
+{{end -}}
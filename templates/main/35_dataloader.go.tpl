@@ -0,0 +1,83 @@
+{{- if and .WithDataloader (not .Table.IsJoinTable) (not .Table.IsView) (or .Table.FKeys .Table.ToOneRelationships .Table.ToManyRelationships) -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+// {{$alias.DownSingular}}LoaderSlice returns the {{$alias.DownPlural}} of a batch of the loader
+func {{$alias.DownSingular}}LoaderSlice(objs []interface{}) []*{{$alias.UpSingular}} {
+	slice := make([]*{{$alias.UpSingular}}, len(objs))
+	for i, obj := range objs {
+		slice[i] = obj.(*{{$alias.UpSingular}})
+	}
+	return slice
+}
+{{range $fkey := .Table.FKeys -}}
+{{- $ftable := $.Aliases.Table $fkey.ForeignTable -}}
+{{- $rel := $alias.Relationship $fkey.Name}}
+// {{$alias.UpSingular}}{{$rel.Foreign}} loads the {{$ftable.DownSingular}} of the {{$alias.DownSingular}} in a batch with the ones of the
+// other {{$alias.DownPlural}} loaded within the wait of the loader, nil if it has none
+func (l *Loader) {{$alias.UpSingular}}{{$rel.Foreign}}(ctx context.Context, o *{{$alias.UpSingular}}) (*{{$ftable.UpSingular}}, error) {
+	v, err := l.batcher("{{$.Table.Name}}.{{$rel.Foreign}}", func(ctx context.Context, objs []interface{}) ([]interface{}, error) {
+		slice := {{$alias.DownSingular}}LoaderSlice(objs)
+		if err := ({{$alias.DownSingular}}L{}).Load{{$rel.Foreign}}(ctx, l.Exec, false, &slice, l.mods("{{$fkey.ForeignTable}}")); err != nil {
+			return nil, err
+		}
+
+		values := make([]interface{}, len(slice))
+		for i, obj := range slice {
+			values[i] = obj.R.{{$rel.Foreign}}
+		}
+		return values, nil
+	}).Load(ctx, o.{{$alias.Column $fkey.Column}}, o)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*{{$ftable.UpSingular}}), nil
+}
+{{end -}}
+{{- range $rel := .Table.ToOneRelationships -}}
+{{- $ftable := $.Aliases.Table $rel.ForeignTable -}}
+{{- $relAlias := $ftable.Relationship $rel.Name}}
+// {{$alias.UpSingular}}{{$relAlias.Local}} loads the {{$ftable.DownSingular}} of the {{$alias.DownSingular}} in a batch with the ones of the
+// other {{$alias.DownPlural}} loaded within the wait of the loader, nil if it has none
+func (l *Loader) {{$alias.UpSingular}}{{$relAlias.Local}}(ctx context.Context, o *{{$alias.UpSingular}}) (*{{$ftable.UpSingular}}, error) {
+	v, err := l.batcher("{{$.Table.Name}}.{{$relAlias.Local}}", func(ctx context.Context, objs []interface{}) ([]interface{}, error) {
+		slice := {{$alias.DownSingular}}LoaderSlice(objs)
+		if err := ({{$alias.DownSingular}}L{}).Load{{$relAlias.Local}}(ctx, l.Exec, false, &slice, l.mods("{{$rel.ForeignTable}}")); err != nil {
+			return nil, err
+		}
+
+		values := make([]interface{}, len(slice))
+		for i, obj := range slice {
+			values[i] = obj.R.{{$relAlias.Local}}
+		}
+		return values, nil
+	}).Load(ctx, o.{{$alias.Column $rel.Column}}, o)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*{{$ftable.UpSingular}}), nil
+}
+{{end -}}
+{{- range $rel := .Table.ToManyRelationships -}}
+{{- $ftable := $.Aliases.Table $rel.ForeignTable -}}
+{{- $relAlias := $.Aliases.ManyRelationship $rel.ForeignTable $rel.Name $rel.JoinTable $rel.JoinLocalFKeyName}}
+// {{$alias.UpSingular}}{{$relAlias.Local}} loads the {{$ftable.DownPlural}} of the {{$alias.DownSingular}} in a batch with the ones of the
+// other {{$alias.DownPlural}} loaded within the wait of the loader
+func (l *Loader) {{$alias.UpSingular}}{{$relAlias.Local}}(ctx context.Context, o *{{$alias.UpSingular}}) ({{$ftable.UpSingular}}Slice, error) {
+	v, err := l.batcher("{{$.Table.Name}}.{{$relAlias.Local}}", func(ctx context.Context, objs []interface{}) ([]interface{}, error) {
+		slice := {{$alias.DownSingular}}LoaderSlice(objs)
+		if err := ({{$alias.DownSingular}}L{}).Load{{$relAlias.Local}}(ctx, l.Exec, false, &slice, l.mods("{{$rel.ForeignTable}}")); err != nil {
+			return nil, err
+		}
+
+		values := make([]interface{}, len(slice))
+		for i, obj := range slice {
+			values[i] = obj.R.{{$relAlias.Local}}
+		}
+		return values, nil
+	}).Load(ctx, o.{{$alias.Column $rel.Column}}, o)
+	if err != nil {
+		return nil, err
+	}
+	return v.({{$ftable.UpSingular}}Slice), nil
+}
+{{end -}}
+{{- end -}}
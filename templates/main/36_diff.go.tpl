@@ -0,0 +1,18 @@
+{{- if .AddDiff -}}
+{{- $alias := .Aliases.Table .Table.Name}}
+// Diff returns the columns whose values differ between the {{$alias.DownSingular}} and other, in the
+// order of the columns, with the value of the {{$alias.DownSingular}} as Old and the one of other as
+// New. Null values are equal whatever their zero values hold. Update the changes
+// alone with boil.Whitelist(changes.Columns()...).
+func (o *{{$alias.UpSingular}}) Diff(other *{{$alias.UpSingular}}) boil.Changes {
+	var changes boil.Changes
+	{{- range $column := .Table.Columns}}
+	{{- $colAlias := $alias.Column $column.Name}}
+	if {{diffChanged (printf "o.%s" $colAlias) (printf "other.%s" $colAlias) $column}} {
+		changes = append(changes, boil.Change{Column: {{$alias.UpSingular}}Columns.{{$colAlias}}, Old: o.{{$colAlias}}, New: other.{{$colAlias}}})
+	}
+	{{- end}}
+
+	return changes
+}
+{{end -}}
@@ -0,0 +1,47 @@
+{{- if .AddCopyEqual -}}
+{{- $alias := .Aliases.Table .Table.Name}}
+// Copy returns a deep copy of the {{$alias.DownSingular}}, its columns share no memory with the
+// ones of the {{$alias.DownSingular}}{{if not (or .Table.IsJoinTable .Table.IsView)}}. The loaded relationships of R are not copied{{end}}.
+func (o *{{$alias.UpSingular}}) Copy() *{{$alias.UpSingular}} {
+	if o == nil {
+		return nil
+	}
+
+	c := *o
+	{{- if not (or .Table.IsJoinTable .Table.IsView)}}
+	c.R = nil
+	{{- end}}
+	{{- range $column := .Table.Columns}}
+	{{- $colAlias := $alias.Column $column.Name}}
+	{{- with copyColumn (printf "c.%s" $colAlias) (printf "o.%s" $colAlias) $column}}
+	{{.}}
+	{{- end}}
+	{{- end}}
+
+	return &c
+}
+
+// Equal tells if the columns of the {{$alias.DownSingular}} and other hold the same values, null
+// values are equal whatever their zero values hold and times are equal when they
+// are the same instant. It compares loaded rows with constructed ones where
+// reflect.DeepEqual does not, and cmp.Equal uses it.
+func (o *{{$alias.UpSingular}}) Equal(other *{{$alias.UpSingular}}) bool {
+	return o.EqualWith(other, boil.EqualOptions{})
+}
+
+// EqualWith is Equal with a tolerance of the times and columns left out of the
+// comparison.
+func (o *{{$alias.UpSingular}}) EqualWith(other *{{$alias.UpSingular}}, opts boil.EqualOptions) bool {
+	if o == nil || other == nil {
+		return o == other
+	}
+	{{- range $column := .Table.Columns}}
+	{{- $colAlias := $alias.Column $column.Name}}
+	if !opts.Ignores({{$alias.UpSingular}}Columns.{{$colAlias}}) && ({{equalChanged (printf "o.%s" $colAlias) (printf "other.%s" $colAlias) $column}}) {
+		return false
+	}
+	{{- end}}
+
+	return true
+}
+{{end -}}
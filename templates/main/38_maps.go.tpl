@@ -0,0 +1,44 @@
+{{- if .AddMaps -}}
+{{- $alias := .Aliases.Table .Table.Name}}
+// ToMap returns the columns of the {{$alias.DownSingular}} by name. Null columns are nil and the
+// valid nullable columns hold their values, like a string for a null.String.
+func (o *{{$alias.UpSingular}}) ToMap() map[string]interface{} {
+	m := make(map[string]interface{}, {{len .Table.Columns}})
+	{{- range $column := .Table.Columns}}
+	{{- $colAlias := $alias.Column $column.Name}}
+	{{toMapColumn "m" (printf "%sColumns.%s" $alias.UpSingular $colAlias) (printf "o.%s" $colAlias) $column}}
+	{{- end}}
+
+	return m
+}
+
+// FromMap sets the columns of the {{$alias.DownSingular}} named by the keys of m, like the maps of
+// ToMap or the ones decoded from JSON, the other columns are left unchanged. Nil
+// sets the nullable columns null and the values are converted to the types of
+// the columns by queries.AssignMapValue. Unknown columns and nil for the columns
+// that are not nullable are errors.
+func (o *{{$alias.UpSingular}}) FromMap(m map[string]interface{}) error {
+	for column, v := range m {
+		var err error
+		switch column {
+		{{- range $column := .Table.Columns}}
+		{{- $colAlias := $alias.Column $column.Name}}
+		case {{$alias.UpSingular}}Columns.{{$colAlias}}:
+			{{- if not $column.Nullable}}
+			if v == nil {
+				return errors.New("{{$.PkgName}}: {{$.Table.Name}}.{{$column.Name}} cannot be null")
+			}
+			{{- end}}
+			err = queries.AssignMapValue(&o.{{$colAlias}}, v)
+		{{- end}}
+		default:
+			return errors.Errorf("{{.PkgName}}: {{.Table.Name}} has no column %s", column)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "{{.PkgName}}: unable to set {{.Table.Name}}.%s", column)
+		}
+	}
+
+	return nil
+}
+{{end -}}
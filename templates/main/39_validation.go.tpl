@@ -0,0 +1,19 @@
+{{- if .AddValidation -}}
+{{- $alias := .Aliases.Table .Table.Name}}
+// Validate returns a *boil.ErrValidation when a column of the {{$alias.DownSingular}} holds a value the
+// database would reject, a text longer than the column or a decimal with more
+// digits before the decimal point than its precision and scale allow. Insert,
+// Update and Upsert call it before running their queries.
+func (o *{{$alias.UpSingular}}) Validate() error {
+	{{- $validated := false}}
+	{{- range $column := .Table.Columns}}
+	{{- with validateColumn $.Table.Name (printf "o.%s" ($alias.Column $column.Name)) $column}}
+	{{- $validated = true}}
+	{{.}}
+	{{- end}}
+	{{- end}}
+	{{- if $validated}}
+{{end}}
+	return nil
+}
+{{end -}}
@@ -0,0 +1,284 @@
+{{- if or .Table.IsJoinTable .Table.IsView -}}
+{{- else -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $schemaTable := .Table.Name | .SchemaTable -}}
+{{- range $poly := .Polymorphics -}}
+{{- if eq $poly.Table $.Table.Name -}}
+{{- $idCol := $alias.Column $poly.IDColumn -}}
+{{- $typeCol := $alias.Column $poly.TypeColumn -}}
+{{- $typeValue := $typeCol -}}
+{{- if eq ($.Table.GetColumn $poly.TypeColumn).Type "null.String"}}{{$typeValue = printf "%s.String" $typeCol}}{{end -}}
+{{- $types := printf "%s%sTypes" $alias.UpSingular $poly.Name -}}
+{{- $arg := printf "maybe%s" $alias.UpSingular}}
+// {{$types}} are the values of {{$poly.TypeColumn}} naming the table {{$poly.IDColumn}} refers to
+var {{$types}} = struct {
+	{{range $poly.Targets -}}
+	{{($.Aliases.Table .Table).UpSingular}} string
+	{{end -}}
+}{
+	{{range $poly.Targets -}}
+	{{($.Aliases.Table .Table).UpSingular}}: {{printf "%q" .Type}},
+	{{end -}}
+}
+{{range $target := $poly.Targets -}}
+{{- $ftable := $.Aliases.Table $target.Table -}}
+{{- $rel := printf "%s%s" $poly.Name $ftable.UpSingular -}}
+{{- $fcol := $ftable.Column $target.Column -}}
+{{- $schemaForeignTable := $target.Table | $.SchemaTable -}}
+{{- $usesPrimitives := usesPrimitives $.Tables $poly.Table $poly.IDColumn $target.Table $target.Column -}}
+{{- $canSoftDelete := (getTable $.Tables $target.Table).CanSoftDelete $.AutoColumns.Deleted}}
+
+// {{$rel}} returns the {{$ftable.DownSingular}} the {{$alias.DownSingular}} refers to by its {{$poly.IDColumn}}, the query finds
+// none when its {{$poly.TypeColumn}} is not {{$types}}.{{$ftable.UpSingular}}.
+func (o *{{$alias.UpSingular}}) {{$rel}}(mods ...qm.QueryMod) {{$ftable.DownSingular}}Query {
+	queryMods := []qm.QueryMod{
+		qm.Where("{{$schemaForeignTable}}.{{$target.Column | $.Quotes}} = ?", o.{{$idCol}}),
+	}
+	if o.{{$typeValue}} != {{$types}}.{{$ftable.UpSingular}} {
+		queryMods = append(queryMods, qm.Where("1 = 0"))
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return {{$ftable.UpPlural}}(queryMods...)
+}
+{{- if not $.NoRelationshipSetters}}
+
+// Set{{$rel}} of the {{$alias.DownSingular}} to the related item, setting its {{$poly.IDColumn}} and
+// its {{$poly.TypeColumn}} to {{$types}}.{{$ftable.UpSingular}}.
+// Sets o.R.{{$rel}} to related and the other targets of o.R to nil.
+func (o *{{$alias.UpSingular}}) Set{{$rel}}({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, insert bool, related *{{$ftable.UpSingular}}) error {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{$poly.Table}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{$poly.Table}}", "set")
+
+	{{end -}}
+	var err error
+	if insert {
+		if err = related.Insert({{if not $.NoContext}}ctx, {{end -}} exec, boil.Infer()); err != nil {
+			return errors.Wrap(boil.ConvertError(err), "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE {{$schemaTable}} SET %s WHERE %s",
+		strmangle.SetParamNames("{{$.LQ}}", "{{$.RQ}}", {{$.Dialect.PlaceholderStart 1}}, []string{{"{"}}"{{$poly.IDColumn}}", "{{$poly.TypeColumn}}"{{"}"}}),
+		strmangle.WhereClause("{{$.LQ}}", "{{$.RQ}}", {{$.Dialect.PlaceholderStart 3}}, {{$alias.DownSingular}}PrimaryKeyColumns),
+	)
+	values := []interface{}{related.{{$fcol}}, {{$types}}.{{$ftable.UpSingular}}, o.{{$.Table.PKey.Columns | stringMap (aliasCols $alias) | join ", o."}}{{"}"}}
+
+	{{if $.NoContext -}}
+	if _, err = boil.DebugExec(exec, updateQuery, values...); err != nil {
+		return errors.Wrap(boil.ConvertError(err), "failed to update local table")
+	}
+	{{- else -}}
+	if _, err = boil.DebugExecContext(ctx, exec, updateQuery, values...); err != nil {
+		return errors.Wrap(boil.ConvertError(err), "failed to update local table")
+	}
+	{{- end}}
+
+	{{if $usesPrimitives -}}
+	o.{{$idCol}} = related.{{$fcol}}
+	{{else -}}
+	queries.Assign(&o.{{$idCol}}, related.{{$fcol}})
+	{{end -}}
+	{{if eq $typeValue $typeCol -}}
+	o.{{$typeCol}} = {{$types}}.{{$ftable.UpSingular}}
+	{{else -}}
+	queries.Assign(&o.{{$typeCol}}, {{$types}}.{{$ftable.UpSingular}})
+	{{end}}
+	if o.R == nil {
+		o.R = &{{$alias.DownSingular}}R{}
+	}
+	{{range $poly.Targets -}}
+	{{- $other := printf "%s%s" $poly.Name ($.Aliases.Table .Table).UpSingular -}}
+	{{- if ne $other $rel -}}
+	o.R.{{$other}} = nil
+	{{end -}}
+	{{end -}}
+	o.R.{{$rel}} = related
+
+	return nil
+}
+{{- end}}
+
+// load{{$rel}} eager loads the {{$ftable.DownPlural}} of the {{$alias.DownPlural}} whose {{$poly.TypeColumn}} is
+// {{$types}}.{{$ftable.UpSingular}} in a single query
+func ({{$alias.DownSingular}}L) load{{$rel}}({{if $.NoContext}}e boil.Executor{{else}}ctx context.Context, e boil.ContextExecutor{{end}}, slice []*{{$alias.UpSingular}}, mods queries.Applicator) error {
+	{{if $.WithOTel -}}
+	e = boil.TraceExecutor(e, "{{$target.Table}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	e = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(e, "{{$target.Table}}", "load")
+
+	{{end -}}
+	args := make([]interface{}, 0, len(slice))
+Outer:
+	for _, obj := range slice {
+		if obj.{{$typeValue}} != {{$types}}.{{$ftable.UpSingular}} {
+			continue
+		}
+		{{if ($.Table.GetColumn $poly.IDColumn).Nullable -}}
+		if queries.IsNil(obj.{{$idCol}}) {
+			continue
+		}
+		{{end}}
+		for _, a := range args {
+			{{if $usesPrimitives -}}
+			if a == obj.{{$idCol}} {
+			{{else -}}
+			if queries.Equal(a, obj.{{$idCol}}) {
+			{{end -}}
+				continue Outer
+			}
+		}
+
+		args = append(args, obj.{{$idCol}})
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`{{if $.Dialect.UseSchema}}{{$.Schema}}.{{end}}{{$target.Table}}`),
+		qm.WhereIn(`{{if $.Dialect.UseSchema}}{{$.Schema}}.{{end}}{{$target.Table}}.{{$target.Column}} in ?`, args...),
+		{{if and $.AddSoftDeletes $canSoftDelete -}}
+		qmhelper.WhereIsNull(`{{if $.Dialect.UseSchema}}{{$.Schema}}.{{end}}{{$target.Table}}.{{or $.AutoColumns.Deleted "deleted_at"}}`),
+		{{- end}}
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	{{if $.NoContext -}}
+	results, err := query.Query(e)
+	{{else -}}
+	ctx, done, err := query.WithTimeout(ctx, e)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	results, err := query.QueryContext(ctx, e)
+	{{end -}}
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load {{$ftable.UpSingular}}")
+	}
+
+	var resultSlice []*{{$ftable.UpSingular}}
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice {{$ftable.UpSingular}}")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for {{$target.Table}}")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for {{$target.Table}}")
+	}
+
+	{{if not $.NoHooks -}}
+	if len({{$ftable.DownSingular}}AfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks({{if $.NoContext}}e{{else}}ctx, e{{end}}); err != nil {
+				return err
+			}
+		}
+	}
+
+	{{end -}}
+	for _, local := range slice {
+		if local.{{$typeValue}} != {{$types}}.{{$ftable.UpSingular}} {
+			continue
+		}
+		for _, foreign := range resultSlice {
+			{{if $usesPrimitives -}}
+			if local.{{$idCol}} == foreign.{{$fcol}} {
+			{{else -}}
+			if queries.Equal(local.{{$idCol}}, foreign.{{$fcol}}) {
+			{{end -}}
+				local.R.{{$rel}} = foreign
+				break
+			}
+		}
+	}
+
+	return nil
+}
+{{end}}
+// Load{{$poly.Name}} allows an eager lookup of the {{$poly.Name}} of the objects, cached into
+// o.R.{{range $i, $target := $poly.Targets}}{{if ne $i 0}}, o.R.{{end}}{{$poly.Name}}{{($.Aliases.Table $target.Table).UpSingular}}{{end}} by their {{$poly.TypeColumn}}.
+// Each table is queried once for the objects of its type, with the mods.
+func ({{$alias.DownSingular}}L) Load{{$poly.Name}}({{if $.NoContext}}e boil.Executor{{else}}ctx context.Context, e boil.ContextExecutor{{end}}, singular bool, {{$arg}} interface{}, mods queries.Applicator) error {
+	var slice []*{{$alias.UpSingular}}
+
+	if singular {
+		object, ok := {{$arg}}.(*{{$alias.UpSingular}})
+		if !ok {
+			object = new({{$alias.UpSingular}})
+			ok = queries.SetFromEmbeddedStruct(&object, &{{$arg}})
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, {{$arg}}))
+			}
+		}
+		slice = []*{{$alias.UpSingular}}{object}
+	} else {
+		s, ok := {{$arg}}.(*[]*{{$alias.UpSingular}})
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, {{$arg}})
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, {{$arg}}))
+			}
+		}
+	}
+
+	for _, obj := range slice {
+		if obj.R == nil {
+			obj.R = &{{$alias.DownSingular}}R{}
+		}
+		{{range $poly.Targets -}}
+		obj.R.{{$poly.Name}}{{($.Aliases.Table .Table).UpSingular}} = nil
+		{{end -}}
+	}
+
+	var l {{$alias.DownSingular}}L
+	{{range $poly.Targets -}}
+	if err := l.load{{$poly.Name}}{{($.Aliases.Table .Table).UpSingular}}({{if $.NoContext}}e{{else}}ctx, e{{end}}, slice, mods); err != nil {
+		return err
+	}
+	{{end}}
+	return nil
+}
+{{end -}}{{/* polymorphic of the table */}}
+{{- end -}}{{/* range polymorphics */}}
+
+{{- range $poly := .Polymorphics -}}
+{{- range $target := $poly.Targets -}}
+{{- if eq $target.Table $.Table.Name -}}
+{{- $ltable := $.Aliases.Table $poly.Table -}}
+{{- $schemaLocalTable := $poly.Table | $.SchemaTable -}}
+{{- $types := printf "%s%sTypes" $ltable.UpSingular $poly.Name}}
+
+// {{$poly.Name}}{{$ltable.UpPlural}} returns the {{$ltable.DownPlural}} referring to the {{$alias.DownSingular}} by their
+// {{$poly.IDColumn}}, whose {{$poly.TypeColumn}} is {{$types}}.{{$alias.UpSingular}}.
+func (o *{{$alias.UpSingular}}) {{$poly.Name}}{{$ltable.UpPlural}}(mods ...qm.QueryMod) {{$ltable.DownSingular}}Query {
+	queryMods := []qm.QueryMod{
+		qm.Where("{{$schemaLocalTable}}.{{$poly.IDColumn | $.Quotes}} = ?", o.{{$alias.Column $target.Column}}),
+		qm.Where("{{$schemaLocalTable}}.{{$poly.TypeColumn | $.Quotes}} = ?", {{$types}}.{{$alias.UpSingular}}),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return {{$ltable.UpPlural}}(queryMods...)
+}
+{{end -}}
+{{- end -}}
+{{- end -}}
+{{- end -}}
@@ -0,0 +1,126 @@
+{{- if or .Table.IsJoinTable .Table.IsView -}}
+{{- else -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $schemaTable := .Table.Name | .SchemaTable -}}
+{{- range $inh := .Inheritances -}}
+{{- if eq $inh.Table $.Table.Name -}}
+{{- $col := $alias.Column $inh.Column -}}
+{{- $nullable := eq ($.Table.GetColumn $inh.Column).Type "null.String" -}}
+{{- $value := $col -}}
+{{- if $nullable}}{{$value = printf "%s.String" $col}}{{end -}}
+{{- $values := printf "%s%s" $alias.UpSingular (plural $col) -}}
+{{- $colDefs := sqlColDefinitions $.Table.Columns $.Table.PKey.Columns -}}
+{{- $pkNames := $colDefs.Names | stringMap (aliasCols $alias) | stringMap $.StringFuncs.camelCase | stringMap $.StringFuncs.replaceReserved -}}
+{{- $pkArgs := joinSlices " " $pkNames $colDefs.Types | join ", "}}
+// {{$values}} are the values of {{$inh.Column}} naming the subtype of the {{$alias.DownPlural}}
+var {{$values}} = struct {
+	{{range $inh.Subtypes -}}
+	{{.Name}} string
+	{{end -}}
+}{
+	{{range $inh.Subtypes -}}
+	{{.Name}}: {{printf "%q" .Value}},
+	{{end -}}
+}
+{{range $sub := $inh.Subtypes -}}
+{{- $query := printf "%sQuery" (camelCase $sub.Name) -}}
+{{- $plural := plural $sub.Name}}
+
+// {{$sub.Name}} is a {{$alias.DownSingular}} whose {{$inh.Column}} is {{$values}}.{{$sub.Name}}, the methods of the
+// {{$alias.UpSingular}} are those of the {{$sub.Name}}
+type {{$sub.Name}} struct {
+	*{{$alias.UpSingular}}
+}
+
+// {{$sub.Name}}Slice is an alias for a slice of pointers to {{$sub.Name}}.
+type {{$sub.Name}}Slice []*{{$sub.Name}}
+
+// New{{$sub.Name}} returns a {{$sub.Name}} whose {{$inh.Column}} is {{$values}}.{{$sub.Name}}, so that it is
+// inserted as one.
+func New{{$sub.Name}}() *{{$sub.Name}} {
+	return &{{$sub.Name}}{&{{$alias.UpSingular}}{
+		{{if $nullable -}}
+		{{$col}}: null.StringFrom({{$values}}.{{$sub.Name}}),
+		{{- else -}}
+		{{$col}}: {{$values}}.{{$sub.Name}},
+		{{- end}}
+	}}
+}
+
+// As{{$sub.Name}} returns the {{$alias.DownSingular}} as a {{$sub.Name}} sharing its fields, and false when its
+// {{$inh.Column}} is not {{$values}}.{{$sub.Name}}.
+func (o *{{$alias.UpSingular}}) As{{$sub.Name}}() (*{{$sub.Name}}, bool) {
+	if o.{{$value}} != {{$values}}.{{$sub.Name}} {
+		return nil, false
+	}
+
+	return &{{$sub.Name}}{o}, true
+}
+
+// {{$query}} is a query of the {{$alias.DownPlural}} whose {{$inh.Column}} is {{$values}}.{{$sub.Name}}, the
+// finishers besides One, OneOrNil and All are those of the {{$alias.DownPlural}}
+type {{$query}} struct {
+	{{$alias.DownSingular}}Query
+}
+
+// {{$plural}} retrieves all the {{$alias.DownPlural}} whose {{$inh.Column}} is {{$values}}.{{$sub.Name}}.
+func {{$plural}}(mods ...qm.QueryMod) {{$query}} {
+	mods = append([]qm.QueryMod{qm.Where("{{$schemaTable}}.{{$inh.Column | $.Quotes}} = ?", {{$values}}.{{$sub.Name}})}, mods...)
+	return {{$query}}{ {{- $alias.UpPlural}}(mods...)}
+}
+
+// Find{{$sub.Name}} retrieves a single {{$sub.Name}} by ID with an executor, it is not found when the
+// {{$alias.DownSingular}} is another subtype.
+// If selectCols is empty Find will return all columns.
+func Find{{$sub.Name}}({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, {{$pkArgs}}, selectCols ...string) (*{{$sub.Name}}, error) {
+	mods := []qm.QueryMod{
+		{{range $i, $pk := $.Table.PKey.Columns -}}
+		qm.Where("{{$schemaTable}}.{{$pk | $.Quotes}} = ?", {{index $pkNames $i}}),
+		{{end -}}
+	}
+	if len(selectCols) > 0 {
+		mods = append(mods, qm.Select(selectCols...))
+	}
+
+	return {{$plural}}(mods...).One({{if not $.NoContext}}ctx, {{end -}} exec)
+}
+
+// One returns a single {{$sub.Name}} from the query.
+func (q {{$query}}) One({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) (*{{$sub.Name}}, error) {
+	o, err := q.{{$alias.DownSingular}}Query.One({{if not $.NoContext}}ctx, {{end -}} exec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &{{$sub.Name}}{o}, nil
+}
+
+// OneOrNil returns a single {{$sub.Name}} from the query, nil without an error when
+// the query finds none.
+func (q {{$query}}) OneOrNil({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) (*{{$sub.Name}}, error) {
+	o, err := q.{{$alias.DownSingular}}Query.OneOrNil({{if not $.NoContext}}ctx, {{end -}} exec)
+	if err != nil || o == nil {
+		return nil, err
+	}
+
+	return &{{$sub.Name}}{o}, nil
+}
+
+// All returns all {{$sub.Name}} records from the query.
+func (q {{$query}}) All({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) ({{$sub.Name}}Slice, error) {
+	all, err := q.{{$alias.DownSingular}}Query.All({{if not $.NoContext}}ctx, {{end -}} exec)
+	if err != nil {
+		return nil, err
+	}
+
+	slice := make({{$sub.Name}}Slice, len(all))
+	for i, o := range all {
+		slice[i] = &{{$sub.Name}}{o}
+	}
+
+	return slice, nil
+}
+{{end -}}
+{{- end -}}
+{{- end -}}
+{{- end -}}
@@ -0,0 +1,50 @@
+{{- if .WithDocs -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+# {{.Table.Name}}
+
+The {{if .Table.IsView}}view{{else}}table{{end}} `{{.Table.Name}}`{{if .Schema}} of the `{{.Schema}}` schema{{end}}, the `{{$alias.UpSingular}}` model of the `{{.PkgName}}` package. [All tables](index.md)
+
+## Columns
+
+| Column | Field | Type | Database type | Null | Unique | Default | Comment |
+| ------ | ----- | ---- | ------------- | ---- | ------ | ------- | ------- |
+{{range $column := .Table.Columns -}}
+| `{{$column.Name}}` | `{{$alias.Column $column.Name}}` | `{{$column.Type}}` | `{{$column.DBType}}` | {{if $column.Nullable}}yes{{else}}no{{end}} | {{if $column.Unique}}yes{{else}}no{{end}} | {{with $column.Default}}`{{markdownCell .}}`{{end}} | {{markdownCell $column.Comment}} |
+{{end}}
+{{- if .Table.PKey}}
+## Primary key
+
+{{range $i, $column := .Table.PKey.Columns}}{{if $i}}, {{end}}`{{$column}}`{{end}}
+{{end}}
+{{- if .Table.FKeys}}
+## Foreign keys
+
+| Name | Column | References |
+| ---- | ------ | ---------- |
+{{range $fkey := .Table.FKeys -}}
+| `{{$fkey.Name}}` | `{{$fkey.Column}}` | [{{$fkey.ForeignTable}}]({{docsPage $fkey.ForeignTable}}) `{{$fkey.ForeignColumn}}` |
+{{end}}
+{{- end}}
+{{- if and (not .Table.IsView) (or .Table.FKeys .Table.ToOneRelationships .Table.ToManyRelationships)}}
+## Relationships
+
+| Field | Kind | Table | Columns |
+| ----- | ---- | ----- | ------- |
+{{range $fkey := .Table.FKeys -}}
+{{- $rel := $alias.Relationship $fkey.Name -}}
+| `{{$rel.Foreign}}` | to one | [{{$fkey.ForeignTable}}]({{docsPage $fkey.ForeignTable}}) | `{{$fkey.Column}}` = `{{$fkey.ForeignTable}}.{{$fkey.ForeignColumn}}` |
+{{end}}
+{{- range $rel := .Table.ToOneRelationships -}}
+{{- $relAlias := ($.Aliases.Table $rel.ForeignTable).Relationship $rel.Name -}}
+| `{{$relAlias.Local}}` | one to one | [{{$rel.ForeignTable}}]({{docsPage $rel.ForeignTable}}) | `{{$rel.Column}}` = `{{$rel.ForeignTable}}.{{$rel.ForeignColumn}}` |
+{{end}}
+{{- range $rel := .Table.ToManyRelationships -}}
+{{- $relAlias := $.Aliases.ManyRelationship $rel.ForeignTable $rel.Name $rel.JoinTable $rel.JoinLocalFKeyName -}}
+{{- if $rel.ToJoinTable -}}
+| `{{$relAlias.Local}}` | many to many | [{{$rel.ForeignTable}}]({{docsPage $rel.ForeignTable}}) | `{{$rel.Column}}` = `{{$rel.JoinTable}}.{{$rel.JoinLocalColumn}}`, `{{$rel.JoinTable}}.{{$rel.JoinForeignColumn}}` = `{{$rel.ForeignTable}}.{{$rel.ForeignColumn}}` |
+{{else -}}
+| `{{$relAlias.Local}}` | to many | [{{$rel.ForeignTable}}]({{docsPage $rel.ForeignTable}}) | `{{$rel.Column}}` = `{{$rel.ForeignTable}}.{{$rel.ForeignColumn}}` |
+{{end}}
+{{- end}}
+{{- end}}
+{{- end -}}
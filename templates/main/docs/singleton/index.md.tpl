@@ -0,0 +1,27 @@
+{{- if .WithDocs -}}
+# {{if .Schema}}{{.Schema}}{{else}}Schema{{end}}
+
+The tables and views of the models of the `{{.PkgName}}` package.
+
+| Table | Model | Columns |
+| ----- | ----- | ------- |
+{{range $table := .Tables -}}
+{{- if not $table.IsJoinTable -}}
+{{- $alias := $.Aliases.Table $table.Name -}}
+| [{{$table.Name}}]({{docsPage $table.Name}}){{if $table.IsView}} (view){{end}} | `{{$alias.UpSingular}}` | {{len $table.Columns}} |
+{{end}}
+{{- end}}
+{{- range $table := .Tables}}
+{{- if $table.IsJoinTable}}
+## {{$table.Name}}
+
+The join table of {{range $i, $fkey := $table.FKeys}}{{if $i}} and {{end}}[{{$fkey.ForeignTable}}]({{docsPage $fkey.ForeignTable}}){{end}}.
+
+| Column | References |
+| ------ | ---------- |
+{{range $fkey := $table.FKeys -}}
+| `{{$fkey.Column}}` | `{{$fkey.ForeignTable}}.{{$fkey.ForeignColumn}}` |
+{{end}}
+{{- end}}
+{{- end}}
+{{- end -}}
@@ -0,0 +1,86 @@
+{{- if .AuditLog.Tables -}}
+// auditLogInsertSQL records a change of a row in {{.AuditLog.Table}}
+const auditLogInsertSQL = "INSERT INTO {{.AuditLog.Table | $.SchemaTable}} ({{"table_name" | $.Quotes}}, {{"operation" | $.Quotes}}, {{"row_key" | $.Quotes}}, {{"before" | $.Quotes}}, {{"after" | $.Quotes}}, {{"changed_at" | $.Quotes}}) VALUES ({{.Dialect.Placeholders 6 1 1}})"
+
+// auditLogSelectSQL selects the changes of a row from {{.AuditLog.Table}}
+const auditLogSelectSQL = "SELECT {{"operation" | $.Quotes}}, {{"before" | $.Quotes}}, {{"after" | $.Quotes}}, {{"changed_at" | $.Quotes}} FROM {{.AuditLog.Table | $.SchemaTable}} WHERE {{"table_name" | $.Quotes}} = {{.Dialect.Placeholder 1}} AND {{"row_key" | $.Quotes}} = {{.Dialect.Placeholder 2}} ORDER BY {{"changed_at" | $.Quotes}}"
+
+// auditRecord is a change of a row read from {{.AuditLog.Table}}
+type auditRecord struct {
+	operation string
+	before    []byte
+	after     []byte
+	changedAt time.Time
+}
+
+// auditRowKey returns the key of a row in {{.AuditLog.Table}}, the JSON of its
+// primary key
+func auditRowKey(pkey map[string]interface{}) (string, error) {
+	b, err := json.Marshal(pkey)
+	if err != nil {
+		return "", errors.Wrap(err, "{{.PkgName}}: unable to marshal the key of an audited row")
+	}
+	return string(b), nil
+}
+
+// auditLog records a change of a row in {{.AuditLog.Table}}, before is nil for
+// inserts and after is nil for deletes
+func auditLog({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, table, operation, rowKey string, before, after interface{}) error {
+	images := make([]interface{}, 2)
+	for i, image := range []interface{}{before, after} {
+		if image == nil {
+			continue
+		}
+		b, err := json.Marshal(image)
+		if err != nil {
+			return errors.Wrapf(err, "{{.PkgName}}: unable to marshal the audited %s row", table)
+		}
+		images[i] = string(b)
+	}
+
+	changedAt := time.Now().In(boil.GetLocation())
+
+	{{if .NoContext -}}
+	_, err := boil.DebugExec(exec, auditLogInsertSQL, table, operation, rowKey, images[0], images[1], changedAt)
+	{{- else -}}
+	_, err := boil.DebugExecContext(ctx, exec, auditLogInsertSQL, table, operation, rowKey, images[0], images[1], changedAt)
+	{{- end}}
+	if err != nil {
+		return errors.Wrapf(boil.ConvertError(err), "{{.PkgName}}: unable to record the change of a %s row in {{.AuditLog.Table}}", table)
+	}
+
+	return nil
+}
+
+// auditRecords reads the changes of a row from {{.AuditLog.Table}} in the order
+// they were made
+func auditRecords({{if .NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}, table, rowKey string) ([]auditRecord, error) {
+	rows, err := boil.DebugQuery{{if not .NoContext}}Context{{end}}({{if not .NoContext}}ctx, {{end}}exec, auditLogSelectSQL, table, rowKey)
+	if err != nil {
+		return nil, errors.Wrapf(boil.ConvertError(err), "{{.PkgName}}: unable to read the changes of a %s row from {{.AuditLog.Table}}", table)
+	}
+	defer rows.Close()
+
+	var records []auditRecord
+	for rows.Next() {
+		var r auditRecord
+		if err := rows.Scan(&r.operation, &r.before, &r.after, &r.changedAt); err != nil {
+			return nil, errors.Wrapf(err, "{{.PkgName}}: failed to scan a change of a %s row", table)
+		}
+		records = append(records, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "{{.PkgName}}: failed to read the changes of a %s row", table)
+	}
+
+	return records, nil
+}
+
+// auditImage unmarshals an image of a row recorded in {{.AuditLog.Table}}
+func auditImage(image []byte, o interface{}) error {
+	if err := json.Unmarshal(image, o); err != nil {
+		return errors.Wrap(err, "{{.PkgName}}: unable to unmarshal an audited row")
+	}
+	return nil
+}
+{{- end -}}
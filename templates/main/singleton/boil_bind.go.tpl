@@ -0,0 +1,27 @@
+// Bind runs the query with the args and binds its rows into obj, a pointer to
+// any struct whose fields are tagged `boil:"column"`, or to a slice of them,
+// like the rows of a one-off report query. It follows the binding rules of
+// the models, see queries.Bind, and returns sql.ErrNoRows when obj is a
+// struct and the query has no row.
+{{- if .NoContext}}
+func Bind(exec boil.Executor, obj interface{}, query string, args ...interface{}) error {
+	return queries.Raw(query, args...).Bind(nil, exec, obj)
+}
+{{- else}}
+func Bind(ctx context.Context, exec boil.ContextExecutor, obj interface{}, query string, args ...interface{}) error {
+	return queries.Raw(query, args...).Bind(ctx, exec, obj)
+}
+{{- end}}
+
+// BindRows binds rows queried by other means into obj like Bind, then closes
+// them and returns their error.
+func BindRows(rows *sql.Rows, obj interface{}) error {
+	err := queries.Bind(rows, obj)
+	if closeErr := rows.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = rows.Err()
+	}
+	return err
+}
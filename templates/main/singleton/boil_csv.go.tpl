@@ -0,0 +1,195 @@
+{{- if .AddCSV -}}
+// csvParser parses the fields of a CSV record, it keeps the first error so
+// the columns of a record can be parsed one after another
+type csvParser struct {
+	header []string
+	record []string
+	err    error
+}
+
+// fail keeps the first error of the record
+func (p *csvParser) fail(i int, err error) {
+	if p.err == nil {
+		p.err = errors.Wrapf(err, "column %s", p.header[i])
+	}
+}
+
+// required fails on the first empty field of the columns that cannot be null
+func (p *csvParser) required(indexes ...int) {
+	for _, i := range indexes {
+		if len(p.record[i]) == 0 {
+			p.fail(i, errors.New("cannot be empty"))
+			return
+		}
+	}
+}
+
+// check fails when a valid field has an invalid value
+func (p *csvParser) check(i int, valid, ok bool) {
+	if valid && !ok {
+		p.fail(i, errors.Errorf("invalid value %q", p.record[i]))
+	}
+}
+
+// valid is false when the field is empty, for null values
+func (p *csvParser) valid(i int) bool {
+	return len(p.record[i]) != 0
+}
+
+func (p *csvParser) string(i int) string {
+	return p.record[i]
+}
+
+func (p *csvParser) int(i int, bits int) int64 {
+	if !p.valid(i) {
+		return 0
+	}
+	v, err := strconv.ParseInt(p.record[i], 10, bits)
+	if err != nil {
+		p.fail(i, err)
+	}
+	return v
+}
+
+func (p *csvParser) uint(i int, bits int) uint64 {
+	if !p.valid(i) {
+		return 0
+	}
+	v, err := strconv.ParseUint(p.record[i], 10, bits)
+	if err != nil {
+		p.fail(i, err)
+	}
+	return v
+}
+
+func (p *csvParser) float(i int, bits int) float64 {
+	if !p.valid(i) {
+		return 0
+	}
+	v, err := strconv.ParseFloat(p.record[i], bits)
+	if err != nil {
+		p.fail(i, err)
+	}
+	return v
+}
+
+func (p *csvParser) bool(i int) bool {
+	if !p.valid(i) {
+		return false
+	}
+	v, err := strconv.ParseBool(p.record[i])
+	if err != nil {
+		p.fail(i, err)
+	}
+	return v
+}
+
+func (p *csvParser) time(i int) time.Time {
+	if !p.valid(i) {
+		return time.Time{}
+	}
+	v, err := time.Parse(time.RFC3339Nano, p.record[i])
+	if err != nil {
+		p.fail(i, err)
+	}
+	return v
+}
+
+func (p *csvParser) bytes(i int) []byte {
+	if !p.valid(i) {
+		return nil
+	}
+	v, err := base64.StdEncoding.DecodeString(p.record[i])
+	if err != nil {
+		p.fail(i, err)
+	}
+	return v
+}
+
+// json returns the JSON of the field, nil when it is empty
+func (p *csvParser) json(i int) []byte {
+	if !p.valid(i) {
+		return nil
+	}
+	if !json.Valid([]byte(p.record[i])) {
+		p.fail(i, errors.New("invalid JSON"))
+		return nil
+	}
+	return []byte(p.record[i])
+}
+
+// unmarshal unmarshals the JSON of the field into v, empty fields are null
+func (p *csvParser) unmarshal(i int, v interface{}) {
+	field := p.record[i]
+	if !p.valid(i) {
+		field = "null"
+	}
+	if err := json.Unmarshal([]byte(field), v); err != nil {
+		p.fail(i, err)
+	}
+}
+
+// csvIndexes returns the indexes in the header of every column, the header
+// can have other columns
+func csvIndexes(columns, header []string) ([]int, error) {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		if _, ok := positions[name]; ok {
+			return nil, errors.Errorf("column %s is repeated", name)
+		}
+		positions[name] = i
+	}
+
+	indexes := make([]int, len(columns))
+	for i, name := range columns {
+		j, ok := positions[name]
+		if !ok {
+			return nil, errors.Errorf("column %s is missing", name)
+		}
+		indexes[i] = j
+	}
+
+	return indexes, nil
+}
+
+// csvNull returns the field of a value, which is empty when it is null
+func csvNull(valid bool, field string) string {
+	if !valid {
+		return ""
+	}
+	return field
+}
+
+func csvInt(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
+
+func csvUint(v uint64) string {
+	return strconv.FormatUint(v, 10)
+}
+
+func csvFloat(v float64, bits int) string {
+	return strconv.FormatFloat(v, 'g', -1, bits)
+}
+
+func csvBool(v bool) string {
+	return strconv.FormatBool(v)
+}
+
+func csvTime(v time.Time) string {
+	return v.Format(time.RFC3339Nano)
+}
+
+func csvBytes(v []byte) string {
+	return base64.StdEncoding.EncodeToString(v)
+}
+
+// csvJSON returns the JSON of an array, nil arrays are empty
+func csvJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil || string(b) == "null" {
+		return ""
+	}
+	return string(b)
+}
+{{- end -}}
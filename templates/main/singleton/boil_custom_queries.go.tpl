@@ -0,0 +1,79 @@
+{{- range $q := .CustomQueries -}}
+{{- $alias := ""}}
+{{- if $q.Table}}{{$alias = $.Aliases.Table $q.Table}}{{end}}
+// {{$q.Name}}SQL is the SQL of {{$q.Name}}, read from {{$q.File}}
+const {{$q.Name}}SQL = {{printf "%q" $q.SQL}}
+
+{{range $q.Comment -}}
+// {{.}}
+{{else -}}
+// {{$q.Name}} runs the query {{$q.Name}} from {{$q.File}}
+{{end -}}
+func {{$q.Name}}({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}{{range $q.Params}}, {{.GoName}} {{.Type}}{{end}}) {{if eq $q.Kind "one"}}(*{{$alias.UpSingular}}, error){{else if eq $q.Kind "many"}}({{$alias.UpSingular}}Slice, error){{else if eq $q.Kind "execrows"}}(int64, error){{else}}error{{end}} {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{$q.Table}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{$q.Table}}", "{{$q.Name}}")
+
+	{{end -}}
+	{{if eq $q.Kind "one" -}}
+	o := &{{$alias.UpSingular}}{}
+
+	err := queries.Raw({{$q.Name}}SQL{{range $q.Args}}, {{.}}{{end}}).Bind({{if $.NoContext}}nil{{else}}ctx{{end}}, exec, o)
+	if err != nil {
+		{{if not $.AlwaysWrapErrors -}}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		{{end -}}
+		return nil, errors.Wrap(err, "{{$.PkgName}}: unable to run {{$q.Name}}")
+	}
+
+	{{if not $.NoHooks -}}
+	if err := o.doAfterSelectHooks({{if not $.NoContext}}ctx, {{end -}} exec); err != nil {
+		return o, err
+	}
+
+	{{end -}}
+	return o, nil
+	{{- else if eq $q.Kind "many" -}}
+	var o []*{{$alias.UpSingular}}
+
+	err := queries.Raw({{$q.Name}}SQL{{range $q.Args}}, {{.}}{{end}}).Bind({{if $.NoContext}}nil{{else}}ctx{{end}}, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "{{$.PkgName}}: unable to run {{$q.Name}}")
+	}
+
+	{{if not $.NoHooks -}}
+	for _, obj := range o {
+		if err := obj.doAfterSelectHooks({{if not $.NoContext}}ctx, {{end -}} exec); err != nil {
+			return o, err
+		}
+	}
+
+	{{end -}}
+	return o, nil
+	{{- else if eq $q.Kind "execrows" -}}
+	result, err := queries.Raw({{$q.Name}}SQL{{range $q.Args}}, {{.}}{{end}}).Exec{{if not $.NoContext}}Context{{end}}({{if not $.NoContext}}ctx, {{end}}exec)
+	if err != nil {
+		return 0, errors.Wrap(boil.ConvertError(err), "{{$.PkgName}}: unable to run {{$q.Name}}")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "{{$.PkgName}}: failed to get rows affected by {{$q.Name}}")
+	}
+
+	return rowsAff, nil
+	{{- else -}}
+	_, err := queries.Raw({{$q.Name}}SQL{{range $q.Args}}, {{.}}{{end}}).Exec{{if not $.NoContext}}Context{{end}}({{if not $.NoContext}}ctx, {{end}}exec)
+	if err != nil {
+		return errors.Wrap(boil.ConvertError(err), "{{$.PkgName}}: unable to run {{$q.Name}}")
+	}
+
+	return nil
+	{{- end}}
+}
+{{end -}}
@@ -0,0 +1,74 @@
+{{- if .WithDataloader -}}
+// Loader batches the relationships of the models loaded one model at a time,
+// like by the resolvers of a GraphQL server, into the queries of eager
+// loading. The loaded relationships are cached, so a Loader is meant to live
+// for a single request.
+type Loader struct {
+	Exec boil.ContextExecutor
+	// Wait is how long the loader waits for more models before loading a
+	// batch, a millisecond if zero
+	Wait time.Duration
+	// MaxBatch is how many models a batch loads at most, unlimited if zero
+	MaxBatch int
+	// Mods are added to the queries of the tables by name, like Where to
+	// filter the loaded rows
+	Mods map[string][]qm.QueryMod
+
+	mut      sync.Mutex
+	batchers map[string]*queries.Batcher
+}
+
+// batcher returns the batcher of the relationship, fn loads its batches
+func (l *Loader) batcher(name string, fn queries.BatchFunc) *queries.Batcher {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	if l.batchers == nil {
+		l.batchers = make(map[string]*queries.Batcher)
+	}
+	b, ok := l.batchers[name]
+	if !ok {
+		wait := l.Wait
+		if wait <= 0 {
+			wait = time.Millisecond
+		}
+		b = queries.NewBatcher(wait, l.MaxBatch, fn)
+		l.batchers[name] = b
+	}
+	return b
+}
+
+// mods returns the mods of the table for the queries of eager loading
+func (l *Loader) mods(table string) queries.Applicator {
+	mods := l.Mods[table]
+	if len(mods) == 0 {
+		return nil
+	}
+	return loaderMods(mods)
+}
+
+// loaderMods are the mods of a table applied to the queries of eager loading
+type loaderMods []qm.QueryMod
+
+// Apply implements queries.Applicator
+func (m loaderMods) Apply(q *queries.Query) {
+	qm.Apply(q, m...)
+}
+
+// loaderContextKey is the key of the loader of a context
+type loaderContextKey struct{}
+
+// WithLoader returns a context carrying the loader
+{{- if .WithGraphQL}}, the relationships the GraphQL
+// resolvers resolve with the context are loaded with it
+{{- end}}
+func WithLoader(ctx context.Context, l *Loader) context.Context {
+	return context.WithValue(ctx, loaderContextKey{}, l)
+}
+
+// LoaderFrom returns the loader of the context, nil if it has none
+func LoaderFrom(ctx context.Context) *Loader {
+	l, _ := ctx.Value(loaderContextKey{}).(*Loader)
+	return l
+}
+{{- end -}}
@@ -0,0 +1,35 @@
+{{- if .DTOMappers -}}
+{{- range $i, $dto := .DTOMappers}}
+{{- $alias := $.Aliases.Table $dto.Table}}
+{{- if ne $i 0}}
+
+{{end -}}
+// To{{$dto.Name}} converts the {{$alias.DownSingular}} to its {{$dto.Type}}
+func (o *{{$alias.UpSingular}}) To{{$dto.Name}}() *{{$dto.Type}} {
+	d := &{{$dto.Type}}{}
+	{{- range $dto.Fields}}
+	{{.ToDTO}}
+	{{- end}}
+	return d
+}
+
+// From{{$dto.Name}} sets the columns of the {{$alias.DownSingular}} from its {{$dto.Type}}
+func (o *{{$alias.UpSingular}}) From{{$dto.Name}}(d *{{$dto.Type}}) {
+	if d == nil {
+		return
+	}
+	{{range $dto.Fields}}
+	{{.FromDTO}}
+	{{- end}}
+}
+
+// To{{$dto.Name}}Slice converts the {{$alias.DownPlural}} to {{$dto.Type}}s
+func (o {{$alias.UpSingular}}Slice) To{{$dto.Name}}Slice() []*{{$dto.Type}} {
+	s := make([]*{{$dto.Type}}, len(o))
+	for i, v := range o {
+		s[i] = v.To{{$dto.Name}}()
+	}
+	return s
+}
+{{- end}}
+{{- end -}}
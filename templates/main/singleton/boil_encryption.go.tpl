@@ -0,0 +1,147 @@
+{{- if .Encryption.Columns -}}
+// columnEncryptor encrypts the values of the encrypted columns, set by SetEncryptor
+var columnEncryptor encryption.Encryptor
+
+// SetEncryptor sets the encryptor of the values of the encrypted columns, their
+// models cannot be read or written before it is set. Changing it makes the values
+// encrypted by the previous one unreadable.
+func SetEncryptor(e encryption.Encryptor) {
+	columnEncryptor = e
+}
+
+// EncryptedString is the string of an encrypted column, stored as its base64
+// encoded ciphertext
+type EncryptedString string
+
+// Value encrypts the string
+func (s EncryptedString) Value() (driver.Value, error) {
+	return encryption.EncryptString(columnEncryptor, string(s))
+}
+
+// Scan decrypts the string
+func (s *EncryptedString) Scan(value interface{}) error {
+	plaintext, err := encryption.DecryptString(columnEncryptor, value)
+	if err != nil {
+		return err
+	}
+
+	*s = EncryptedString(plaintext)
+	return nil
+}
+
+// NullEncryptedString is the nullable string of an encrypted column, stored as
+// its base64 encoded ciphertext
+type NullEncryptedString null.String
+
+// NullEncryptedStringFrom creates a valid NullEncryptedString
+func NullEncryptedStringFrom(s string) NullEncryptedString {
+	return NullEncryptedString(null.StringFrom(s))
+}
+
+// Value encrypts the string, unless it is null
+func (s NullEncryptedString) Value() (driver.Value, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	return encryption.EncryptString(columnEncryptor, s.String)
+}
+
+// Scan decrypts the string, unless it is null
+func (s *NullEncryptedString) Scan(value interface{}) error {
+	if value == nil {
+		*s = NullEncryptedString{}
+		return nil
+	}
+
+	plaintext, err := encryption.DecryptString(columnEncryptor, value)
+	if err != nil {
+		return err
+	}
+
+	*s = NullEncryptedStringFrom(plaintext)
+	return nil
+}
+
+// MarshalJSON marshals the string as null.String does
+func (s NullEncryptedString) MarshalJSON() ([]byte, error) {
+	return null.String(s).MarshalJSON()
+}
+
+// UnmarshalJSON unmarshals the string as null.String does
+func (s *NullEncryptedString) UnmarshalJSON(data []byte) error {
+	return (*null.String)(s).UnmarshalJSON(data)
+}
+
+// Randomize for sqlboiler
+func (s *NullEncryptedString) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	(*null.String)(s).Randomize(nextInt, fieldType, shouldBeNull)
+}
+
+// EncryptedBytes are the bytes of an encrypted column, stored as their
+// ciphertext
+type EncryptedBytes []byte
+
+// Value encrypts the bytes
+func (b EncryptedBytes) Value() (driver.Value, error) {
+	return encryption.EncryptBytes(columnEncryptor, b)
+}
+
+// Scan decrypts the bytes
+func (b *EncryptedBytes) Scan(value interface{}) error {
+	plaintext, err := encryption.DecryptBytes(columnEncryptor, value)
+	if err != nil {
+		return err
+	}
+
+	*b = plaintext
+	return nil
+}
+
+// NullEncryptedBytes are the nullable bytes of an encrypted column, stored as
+// their ciphertext
+type NullEncryptedBytes null.Bytes
+
+// NullEncryptedBytesFrom creates a valid NullEncryptedBytes
+func NullEncryptedBytesFrom(b []byte) NullEncryptedBytes {
+	return NullEncryptedBytes(null.BytesFrom(b))
+}
+
+// Value encrypts the bytes, unless they are null
+func (b NullEncryptedBytes) Value() (driver.Value, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+	return encryption.EncryptBytes(columnEncryptor, b.Bytes)
+}
+
+// Scan decrypts the bytes, unless they are null
+func (b *NullEncryptedBytes) Scan(value interface{}) error {
+	if value == nil {
+		*b = NullEncryptedBytes{}
+		return nil
+	}
+
+	plaintext, err := encryption.DecryptBytes(columnEncryptor, value)
+	if err != nil {
+		return err
+	}
+
+	*b = NullEncryptedBytesFrom(plaintext)
+	return nil
+}
+
+// MarshalJSON marshals the bytes as null.Bytes does
+func (b NullEncryptedBytes) MarshalJSON() ([]byte, error) {
+	return null.Bytes(b).MarshalJSON()
+}
+
+// UnmarshalJSON unmarshals the bytes as null.Bytes does
+func (b *NullEncryptedBytes) UnmarshalJSON(data []byte) error {
+	return (*null.Bytes)(b).UnmarshalJSON(data)
+}
+
+// Randomize for sqlboiler
+func (b *NullEncryptedBytes) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	(*null.Bytes)(b).Randomize(nextInt, fieldType, shouldBeNull)
+}
+{{end -}}
@@ -0,0 +1,119 @@
+{{- range $fn := .Functions -}}
+{{- $alias := "" -}}
+{{- if eq $fn.Kind "model" -}}
+{{- $alias = $.Aliases.Table $fn.Table -}}
+{{- end -}}
+{{- $ctxArg := "" -}}
+{{- if not $.NoContext -}}
+{{- $ctxArg = "ctx, " -}}
+{{- end -}}
+{{if eq $fn.Kind "row"}}
+// {{$fn.RowName}} is a row returned by the function {{$fn.Name}}
+type {{$fn.RowName}} struct {
+	{{range $fn.Fields -}}
+	{{.GoName}} {{.Type}} `boil:"{{.Name}}" json:"{{.Name}}"`
+	{{end -}}
+}
+{{end}}
+// {{$fn.GoName}}SQL calls the {{if $fn.IsProcedure}}procedure{{else}}function{{end}} {{$fn.Name}}
+const {{$fn.GoName}}SQL = {{printf "%q" $fn.SQL}}
+
+// {{$fn.GoName}} calls the {{if $fn.IsProcedure}}procedure{{else}}function{{end}} {{$fn.Name}}
+func {{$fn.GoName}}({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}{{range $fn.Args}}, {{.GoName}} {{.Type}}{{end}}) {{if eq $fn.Kind "model"}}({{if $fn.ReturnsSet}}{{$alias.UpSingular}}Slice{{else}}*{{$alias.UpSingular}}{{end}}, error){{else if eq $fn.Kind "row"}}({{if $fn.ReturnsSet}}[]*{{else}}*{{end}}{{$fn.RowName}}, error){{else if eq $fn.Kind "value"}}({{if $fn.ReturnsSet}}[]{{end}}{{$fn.Return.Type}}, error){{else}}error{{end}} {
+	{{if $.WithOTel -}}
+	exec = boil.TraceExecutor(exec, "{{$fn.Name}}")
+
+	{{end -}}
+	{{if $.WithMetrics -}}
+	exec = boil.Measure{{if not $.NoContext}}Context{{end}}Executor(exec, "{{$fn.Name}}", "{{$fn.GoName}}")
+
+	{{end -}}
+	{{if eq $fn.Kind "model" -}}
+	{{if $fn.ReturnsSet -}}
+	var o []*{{$alias.UpSingular}}
+
+	err := queries.Raw({{$fn.GoName}}SQL{{range $fn.Args}}, {{.GoName}}{{end}}).Bind({{if $.NoContext}}nil{{else}}ctx{{end}}, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(boil.ConvertError(err), "{{$.PkgName}}: unable to call {{$fn.Name}}")
+	}
+
+	{{if not $.NoHooks -}}
+	for _, obj := range o {
+		if err := obj.doAfterSelectHooks({{$ctxArg}}exec); err != nil {
+			return o, err
+		}
+	}
+
+	{{end -}}
+	{{- else -}}
+	o := &{{$alias.UpSingular}}{}
+
+	err := queries.Raw({{$fn.GoName}}SQL{{range $fn.Args}}, {{.GoName}}{{end}}).Bind({{if $.NoContext}}nil{{else}}ctx{{end}}, exec, o)
+	if err != nil {
+		return nil, errors.Wrap(boil.ConvertError(err), "{{$.PkgName}}: unable to call {{$fn.Name}}")
+	}
+
+	{{if not $.NoHooks -}}
+	if err := o.doAfterSelectHooks({{$ctxArg}}exec); err != nil {
+		return o, err
+	}
+
+	{{end -}}
+	{{- end -}}
+	return o, nil
+	{{- else if eq $fn.Kind "row" -}}
+	{{if $fn.ReturnsSet -}}
+	var o []*{{$fn.RowName}}
+
+	err := queries.Raw({{$fn.GoName}}SQL{{range $fn.Args}}, {{.GoName}}{{end}}).Bind({{if $.NoContext}}nil{{else}}ctx{{end}}, exec, &o)
+	{{- else -}}
+	o := &{{$fn.RowName}}{}
+
+	err := queries.Raw({{$fn.GoName}}SQL{{range $fn.Args}}, {{.GoName}}{{end}}).Bind({{if $.NoContext}}nil{{else}}ctx{{end}}, exec, o)
+	{{- end}}
+	if err != nil {
+		return nil, errors.Wrap(boil.ConvertError(err), "{{$.PkgName}}: unable to call {{$fn.Name}}")
+	}
+
+	return o, nil
+	{{- else if eq $fn.Kind "value" -}}
+	{{if $fn.ReturnsSet -}}
+	rows, err := queries.Raw({{$fn.GoName}}SQL{{range $fn.Args}}, {{.GoName}}{{end}}).Query{{if not $.NoContext}}Context{{end}}({{$ctxArg}}exec)
+	if err != nil {
+		return nil, errors.Wrap(boil.ConvertError(err), "{{$.PkgName}}: unable to call {{$fn.Name}}")
+	}
+	defer rows.Close()
+
+	var values []{{$fn.Return.Type}}
+	for rows.Next() {
+		var value {{$fn.Return.Type}}
+		if err := rows.Scan(&value); err != nil {
+			return nil, errors.Wrap(err, "{{$.PkgName}}: failed to scan a value returned by {{$fn.Name}}")
+		}
+		values = append(values, value)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "{{$.PkgName}}: failed to read the values returned by {{$fn.Name}}")
+	}
+
+	return values, nil
+	{{- else -}}
+	var value {{$fn.Return.Type}}
+
+	err := queries.Raw({{$fn.GoName}}SQL{{range $fn.Args}}, {{.GoName}}{{end}}).QueryRow{{if not $.NoContext}}Context{{end}}({{$ctxArg}}exec).Scan(&value)
+	if err != nil {
+		return value, errors.Wrap(boil.ConvertError(err), "{{$.PkgName}}: unable to call {{$fn.Name}}")
+	}
+
+	return value, nil
+	{{- end -}}
+	{{- else -}}
+	_, err := queries.Raw({{$fn.GoName}}SQL{{range $fn.Args}}, {{.GoName}}{{end}}).Exec{{if not $.NoContext}}Context{{end}}({{$ctxArg}}exec)
+	if err != nil {
+		return errors.Wrap(boil.ConvertError(err), "{{$.PkgName}}: unable to call {{$fn.Name}}")
+	}
+
+	return nil
+	{{- end}}
+}
+{{end -}}
@@ -0,0 +1,89 @@
+{{- if .WithGraphQL -}}
+// GraphQLResolver resolves the GraphQL queries and relationships of the models
+// with their finders and eager loaded relationships, gqlgen resolvers can
+// return the resolvers of its Query method and of the methods named after the
+// models.
+type GraphQLResolver struct {
+	Exec boil.ContextExecutor
+	// Mods are added to the queries of the tables by name, like qm.Load to
+	// eager load the relationships of the rows
+	Mods map[string][]qm.QueryMod
+}
+
+// GraphQLQueryResolver resolves the fields of the Query type
+type GraphQLQueryResolver struct {
+	*GraphQLResolver
+}
+
+// Query returns the resolver of the fields of the Query type
+func (r *GraphQLResolver) Query() *GraphQLQueryResolver {
+	return &GraphQLQueryResolver{GraphQLResolver: r}
+}
+
+// PageInfo is the page of a GraphQL connection
+type PageInfo struct {
+	HasNextPage bool
+	EndCursor   *string
+}
+
+// graphQLCursorPrefix starts the cursors of the rows of a connection, which
+// are the offsets of the rows
+const graphQLCursorPrefix = "cursor:"
+
+// graphQLCursor returns the cursor of the row at the offset
+func graphQLCursor(offset int) string {
+	return base64.StdEncoding.EncodeToString([]byte(graphQLCursorPrefix + strconv.Itoa(offset)))
+}
+
+// graphQLPage returns the number of rows of the page after the cursor, -1 for
+// all of them, and its offset
+func graphQLPage(first *int, after *string) (limit int, offset int, err error) {
+	limit = -1
+	if first != nil {
+		if *first < 0 {
+			return 0, 0, errors.New("{{.PkgName}}: first cannot be negative")
+		}
+		limit = *first
+	}
+
+	if after != nil {
+		b, err := base64.StdEncoding.DecodeString(*after)
+		if err != nil || !strings.HasPrefix(string(b), graphQLCursorPrefix) {
+			return 0, 0, errors.Errorf("{{.PkgName}}: invalid cursor %q", *after)
+		}
+		n, err := strconv.Atoi(strings.TrimPrefix(string(b), graphQLCursorPrefix))
+		if err != nil || n < 0 {
+			return 0, 0, errors.Errorf("{{.PkgName}}: invalid cursor %q", *after)
+		}
+		offset = n + 1
+	}
+
+	return limit, offset, nil
+}
+
+// graphQLPageMods returns the mods selecting the page, with one more row to
+// tell if there is a next page
+func graphQLPageMods(limit, offset int) []qm.QueryMod {
+	var mods []qm.QueryMod
+	if limit >= 0 {
+		mods = append(mods, qm.Limit(limit+1))
+	}
+	if offset > 0 {
+		mods = append(mods, qm.Offset(offset))
+	}
+	return mods
+}
+
+// graphQLBounds returns the bounds of the page in n loaded rows, with one more
+// row to tell if there is a next page
+func graphQLBounds(n, limit, offset int) (start, end int) {
+	start, end = offset, n
+	if start > n {
+		start = n
+	}
+	if limit >= 0 && start+limit+1 < end {
+		end = start + limit + 1
+	}
+	return start, end
+}
+{{- end -}}
@@ -0,0 +1,74 @@
+{{- if .WithGRPC -}}
+// GRPCMaxPageSize is the largest number of rows the list methods of the
+// services return at a time, and the number they return without a page size
+var GRPCMaxPageSize = 100
+
+// GRPCError converts the error of a query of a service to a status error, not
+// found when there is no row. The message of other errors is the name of the
+// code so that errors of the database are not exposed, set it to log them.
+var GRPCError = func(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Error(codes.NotFound, "not found")
+	}
+	return status.Error(codes.Internal, codes.Internal.String())
+}
+
+// grpcListMods returns the limit, offset and query mods of the page size and
+// token of a list request, order is the order of the rows
+func grpcListMods(size int32, token string, order string) (int, int, []qm.QueryMod, error) {
+	if size < 0 {
+		return 0, 0, nil, status.Errorf(codes.InvalidArgument, "invalid page size %d", size)
+	}
+	limit := GRPCMaxPageSize
+	if size > 0 && int(size) < limit {
+		limit = int(size)
+	}
+
+	offset := 0
+	if len(token) != 0 {
+		n, err := strconv.Atoi(token)
+		if err != nil || n < 0 {
+			return 0, 0, nil, status.Errorf(codes.InvalidArgument, "invalid page token %q", token)
+		}
+		offset = n
+	}
+
+	var mods []qm.QueryMod
+	if len(order) != 0 {
+		mods = append(mods, qm.OrderBy(order))
+	}
+	// One more row than the limit tells whether there is a next page
+	mods = append(mods, qm.Limit(limit+1), qm.Offset(offset))
+
+	return limit, offset, mods, nil
+}
+
+// grpcPageToken returns the page token of the page at the offset
+func grpcPageToken(offset int) string {
+	return strconv.Itoa(offset)
+}
+
+// grpcMaskPaths returns the paths of an update mask without duplicates, the
+// fields when it has none or is "*"
+func grpcMaskPaths(paths []string, fields []string) []string {
+	if len(paths) == 0 || (len(paths) == 1 && paths[0] == "*") {
+		return fields
+	}
+
+	seen := make(map[string]bool, len(paths))
+	unique := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if !seen[path] {
+			seen[path] = true
+			unique = append(unique, path)
+		}
+	}
+	return unique
+}
+
+// grpcUnknownPath returns the error of a path of an update mask that is not a
+// field that can be updated
+func grpcUnknownPath(path string) error {
+	return status.Errorf(codes.InvalidArgument, "field %q of the update mask cannot be updated", path)
+}
+{{- end -}}
@@ -0,0 +1,132 @@
+{{- if .HistoryTables -}}
+{{- $execArgs := "ctx context.Context, exec boil.ContextExecutor" -}}
+{{- $execParams := "ctx, exec" -}}
+{{- $bindCtx := "ctx" -}}
+{{- $debugExec := "boil.DebugExecContext(ctx, exec, " -}}
+{{- if .NoContext -}}
+{{- $execArgs = "exec boil.Executor" -}}
+{{- $execParams = "exec" -}}
+{{- $bindCtx = "nil" -}}
+{{- $debugExec = "boil.DebugExec(exec, " -}}
+{{- end -}}
+{{range $i, $h := .HistoryTables}}
+{{- $table := getTable $.Tables .Name}}
+{{- $alias := $.Aliases.Table .Name}}
+{{- $colDefs := sqlColDefinitions $table.Columns $table.PKey.Columns}}
+{{- $pkNames := $colDefs.Names | stringMap (aliasCols $alias) | stringMap $.StringFuncs.camelCase | stringMap $.StringFuncs.replaceReserved}}
+{{- $pkArgs := joinSlices " " $pkNames $colDefs.Types | join ", "}}
+{{- $hist := printf "%sHistory" $alias.UpSingular}}
+{{- $histQuery := printf "%sHistoryQuery" $alias.DownSingular}}
+{{- if $i}}
+
+{{end -}}
+// {{$hist}} is a version of a {{$alias.DownSingular}} kept in {{.Table}}, valid from ValidFrom
+// until ValidTo, or still valid when ValidTo is null.
+type {{$hist}} struct {
+	{{$alias.UpSingular}} {{$alias.UpSingular}} `boil:",bind" json:"{{$alias.DownSingular}}" toml:"{{$alias.DownSingular}}" yaml:"{{$alias.DownSingular}}"`
+	ValidFrom time.Time `boil:"valid_from" json:"valid_from" toml:"valid_from" yaml:"valid_from"`
+	ValidTo null.Time `boil:"valid_to" json:"valid_to,omitempty" toml:"valid_to" yaml:"valid_to,omitempty"`
+}
+
+// {{$histQuery}} queries the versions of the {{$alias.DownPlural}} in {{.Table}}
+type {{$histQuery}} struct {
+	*queries.Query
+}
+
+func init() {
+	Add{{$alias.UpSingular}}Hook(boil.AfterInsertHook, {{$alias.DownSingular}}HistoryInsert)
+	Add{{$alias.UpSingular}}Hook(boil.AfterUpdateHook, {{$alias.DownSingular}}HistoryUpdate)
+	Add{{$alias.UpSingular}}Hook(boil.AfterUpsertHook, {{$alias.DownSingular}}HistoryUpdate)
+	Add{{$alias.UpSingular}}Hook(boil.AfterDeleteHook, {{$alias.DownSingular}}HistoryDelete)
+}
+
+// {{$alias.UpPlural}}AsOf queries the versions of the {{$alias.DownPlural}} that were valid at the time.
+// The where helpers of {{$alias.UpSingular}} name the columns of {{.Name}}, filter the versions
+// with qm.Where on the columns instead.
+func {{$alias.UpPlural}}AsOf(at time.Time, mods ...qm.QueryMod) {{$histQuery}} {
+	mods = append(mods, qm.From({{printf "%q" .From}}), qm.Where({{printf "%q" .AsOf}}, at, at))
+	return {{$histQuery}}{NewQuery(mods...)}
+}
+
+// Find{{$alias.UpSingular}}AsOf returns the version of the {{$alias.DownSingular}} that was valid at the time,
+// sql.ErrNoRows when there was none.
+func Find{{$alias.UpSingular}}AsOf({{$execArgs}}, at time.Time, {{$pkArgs}}) (*{{$hist}}, error) {
+	return {{$alias.UpPlural}}AsOf(at, qm.Where({{printf "%q" .Key}}, {{$pkNames | join ", "}})).One({{$execParams}})
+}
+
+// Versions returns the versions of the {{$alias.DownSingular}} kept in {{.Table}}, the oldest first.
+func (o *{{$alias.UpSingular}}) Versions({{$execArgs}}) ([]*{{$hist}}, error) {
+	q := NewQuery(
+		qm.From({{printf "%q" .From}}),
+		qm.Where({{printf "%q" .Key}}{{range $table.PKey.Columns}}, o.{{$alias.Column .}}{{end}}),
+		qm.OrderBy({{printf "%q" .Order}}),
+	)
+	return {{$histQuery}}{q}.All({{$execParams}})
+}
+
+// One returns a single version of a {{$alias.DownSingular}} from the query.
+func (q {{$histQuery}}) One({{$execArgs}}) (*{{$hist}}, error) {
+	o := &{{$hist}}{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind({{$bindCtx}}, exec, o)
+	if err != nil {
+		{{if not $.AlwaysWrapErrors -}}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		{{end -}}
+		return nil, errors.Wrap(err, "{{$.PkgName}}: failed to execute a one query for {{.Table}}")
+	}
+
+	return o, nil
+}
+
+// All returns all the versions of {{$alias.DownPlural}} from the query.
+func (q {{$histQuery}}) All({{$execArgs}}) ([]*{{$hist}}, error) {
+	var o []*{{$hist}}
+
+	err := q.Bind({{$bindCtx}}, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "{{$.PkgName}}: failed to assign all query results to {{$hist}} slice")
+	}
+
+	return o, nil
+}
+
+// {{$alias.DownSingular}}HistoryOpen inserts the version of the {{$alias.DownSingular}} valid from the time
+func {{$alias.DownSingular}}HistoryOpen({{$execArgs}}, o *{{$alias.UpSingular}}, from time.Time) error {
+	_, err := {{$debugExec}}{{printf "%q" .Insert}}{{range $table.Columns}}, o.{{$alias.Column .Name}}{{end}}, from)
+	if err != nil {
+		return errors.Wrap(boil.ConvertError(err), "{{$.PkgName}}: unable to insert a version into {{.Table}}")
+	}
+	return nil
+}
+
+// {{$alias.DownSingular}}HistoryClose ends the current version of the {{$alias.DownSingular}} at the time
+func {{$alias.DownSingular}}HistoryClose({{$execArgs}}, o *{{$alias.UpSingular}}, to time.Time) error {
+	_, err := {{$debugExec}}{{printf "%q" .Close}}, to{{range $table.PKey.Columns}}, o.{{$alias.Column .}}{{end}})
+	if err != nil {
+		return errors.Wrap(boil.ConvertError(err), "{{$.PkgName}}: unable to end a version in {{.Table}}")
+	}
+	return nil
+}
+
+func {{$alias.DownSingular}}HistoryInsert({{$execArgs}}, o *{{$alias.UpSingular}}) error {
+	return {{$alias.DownSingular}}HistoryOpen({{$execParams}}, o, time.Now().In(boil.GetLocation()))
+}
+
+func {{$alias.DownSingular}}HistoryUpdate({{$execArgs}}, o *{{$alias.UpSingular}}) error {
+	now := time.Now().In(boil.GetLocation())
+	if err := {{$alias.DownSingular}}HistoryClose({{$execParams}}, o, now); err != nil {
+		return err
+	}
+	return {{$alias.DownSingular}}HistoryOpen({{$execParams}}, o, now)
+}
+
+func {{$alias.DownSingular}}HistoryDelete({{$execArgs}}, o *{{$alias.UpSingular}}) error {
+	return {{$alias.DownSingular}}HistoryClose({{$execParams}}, o, time.Now().In(boil.GetLocation()))
+}
+{{- end}}
+{{end -}}
@@ -0,0 +1,165 @@
+{{- if .WithHTTP -}}
+// HTTPMaxLimit is the largest number of rows the list handlers write at a time,
+// and the number they write without a limit query parameter
+var HTTPMaxLimit = 100
+
+// HTTPKey returns the values of the primary key columns of the row a request
+// is for. They are the last segments of its path by default, set it to read
+// the path parameters of a router instead, like chi.URLParam for routes with
+// a parameter named after each column.
+var HTTPKey = func(r *http.Request, columns []string) []string {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) < len(columns) {
+		return nil
+	}
+	return segments[len(segments)-len(columns):]
+}
+
+// HTTPError writes the error of a handler with the status as a JSON object,
+// {"error": "message"}. The message of server errors is the status text so
+// that errors of the database are not exposed, set it to log them.
+var HTTPError = func(w http.ResponseWriter, r *http.Request, status int, err error) {
+	msg := http.StatusText(status)
+	if status < http.StatusInternalServerError {
+		msg = err.Error()
+	}
+	httpJSON(w, status, map[string]string{"error": msg})
+}
+
+// HTTPPage is a page of rows written by the list handlers, NextOffset is the
+// offset of the next page when there is one
+type HTTPPage struct {
+	Items      interface{} `json:"items"`
+	NextOffset *int        `json:"next_offset,omitempty"`
+}
+
+// httpJSON writes v as JSON with the status
+func httpJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// httpDecode decodes the JSON of the request body into o, it rejects the keys
+// o has no field for
+func httpDecode(r *http.Request, o interface{}) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(o); err != nil {
+		return errors.Wrap(err, "invalid JSON body")
+	}
+	return nil
+}
+
+// httpFindError writes the error of finding the row of a request, not found
+// when it does not exist
+func httpFindError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		HTTPError(w, r, http.StatusNotFound, errors.New("not found"))
+		return
+	}
+	HTTPError(w, r, http.StatusInternalServerError, err)
+}
+
+// httpMethodNotAllowed writes the methods the path of the request allows
+func httpMethodNotAllowed(w http.ResponseWriter, r *http.Request, allow string) {
+	w.Header().Set("Allow", allow)
+	HTTPError(w, r, http.StatusMethodNotAllowed, errors.Errorf("method %s not allowed", r.Method))
+}
+
+// httpSegments returns the number of segments of the path of the request
+func httpSegments(r *http.Request) int {
+	path := strings.Trim(r.URL.Path, "/")
+	if len(path) == 0 {
+		return 0
+	}
+	return strings.Count(path, "/") + 1
+}
+
+// httpListMods returns the limit, offset and query mods of the query
+// parameters of a list request. The columns are the columns the rows can be
+// filtered and ordered by, by name, and order is the order of the rows when
+// the request does not choose one.
+func httpListMods(r *http.Request, columns map[string]string, order string) (int, int, []qm.QueryMod, error) {
+	limit, offset := HTTPMaxLimit, 0
+	var mods []qm.QueryMod
+	for name, values := range r.URL.Query() {
+		value := values[len(values)-1]
+		switch name {
+		case "limit":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return 0, 0, nil, errors.Errorf("invalid limit %q", value)
+			}
+			if n < limit {
+				limit = n
+			}
+		case "offset":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return 0, 0, nil, errors.Errorf("invalid offset %q", value)
+			}
+			offset = n
+		case "order_by":
+			desc := strings.HasPrefix(value, "-")
+			column, ok := columns[strings.TrimPrefix(value, "-")]
+			if !ok {
+				return 0, 0, nil, errors.Errorf("unknown column %q to order by", value)
+			}
+			order = column
+			if desc {
+				order += " DESC"
+			}
+		default:
+			column, ok := columns[name]
+			if !ok {
+				return 0, 0, nil, errors.Errorf("unknown query parameter %q", name)
+			}
+			args := make([]interface{}, len(values))
+			for i, v := range values {
+				args[i] = v
+			}
+			if len(args) == 1 {
+				mods = append(mods, qm.Where(column+" = ?", args...))
+			} else {
+				mods = append(mods, qm.WhereIn(column+" in ?", args...))
+			}
+		}
+	}
+
+	if len(order) != 0 {
+		mods = append(mods, qm.OrderBy(order))
+	}
+	// One more row than the limit tells whether there is a next page
+	mods = append(mods, qm.Limit(limit+1), qm.Offset(offset))
+
+	return limit, offset, mods, nil
+}
+
+// httpInt parses an integer of a path
+func httpInt(s string, bits int) (int64, error) {
+	v, err := strconv.ParseInt(s, 10, bits)
+	if err != nil {
+		return 0, errors.Errorf("invalid key %q", s)
+	}
+	return v, nil
+}
+
+// httpUint parses an unsigned integer of a path
+func httpUint(s string, bits int) (uint64, error) {
+	v, err := strconv.ParseUint(s, 10, bits)
+	if err != nil {
+		return 0, errors.Errorf("invalid key %q", s)
+	}
+	return v, nil
+}
+
+// httpBool parses a bool of a path
+func httpBool(s string) (bool, error) {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, errors.Errorf("invalid key %q", s)
+	}
+	return v, nil
+}
+{{- end -}}
@@ -0,0 +1,44 @@
+{{- if .AddInterfaces -}}
+{{- $execParams := "ctx context.Context, exec boil.ContextExecutor" -}}
+{{- if .NoContext}}{{$execParams = "exec boil.Executor"}}{{end -}}
+{{- $rowsAffected := "(int64, error)" -}}
+{{- if .NoRowsAffected}}{{$rowsAffected = "error"}}{{end -}}
+// TableNamer is implemented by every model, TableName returns the name of its
+// table or view
+type TableNamer interface {
+	TableName() string
+}
+
+// Identifiable is implemented by the models whose primary key is a single
+// column, GetID returns its value
+type Identifiable interface {
+	GetID() interface{}
+}
+
+// Timestamped is implemented by the models with created and updated timestamp
+// columns, the getters return the zero time for null
+type Timestamped interface {
+	GetCreatedAt() time.Time
+	GetUpdatedAt() time.Time
+}
+
+// Model is implemented by the models of the tables, generic code can insert,
+// update and delete them
+type Model interface {
+	TableNamer
+	Insert({{$execParams}}, columns boil.Columns) error
+	Update({{$execParams}}, columns boil.Columns) {{$rowsAffected}}
+	Delete({{$execParams}}) {{$rowsAffected}}
+}
+{{- if .AddSoftDeletes}}
+
+// SoftModel is implemented instead of Model by the models of the tables that
+// delete softly, hardDelete deletes their rows
+type SoftModel interface {
+	TableNamer
+	Insert({{$execParams}}, columns boil.Columns) error
+	Update({{$execParams}}, columns boil.Columns) {{$rowsAffected}}
+	Delete({{$execParams}}, hardDelete bool) {{$rowsAffected}}
+}
+{{- end}}
+{{- end -}}
@@ -0,0 +1,143 @@
+{{- if .JoinViews -}}
+{{- range $i, $join := .JoinViews}}
+{{- $table := getTable $.Tables $join.Table}}
+{{- $ftable := getTable $.Tables $join.ForeignTable}}
+{{- $alias := $.Aliases.Table $join.Table}}
+{{- $falias := $.Aliases.Table $join.ForeignTable}}
+{{- $schemaTable := $join.Table | $.SchemaTable}}
+{{- $schemaForeignTable := $join.ForeignTable | $.SchemaTable}}
+{{- $varName := camelCase $join.Name}}
+{{- $deleted := or $.AutoColumns.Deleted "deleted_at"}}
+{{- $tag := singular $join.Table}}
+{{- $ftag := singular $join.ForeignTable}}
+{{- if eq $.StructTagCasing "title"}}{{$tag = titleCase $tag}}{{$ftag = titleCase $ftag}}
+{{- else if eq $.StructTagCasing "camel"}}{{$tag = camelCase $tag}}{{$ftag = camelCase $ftag}}
+{{- else if eq $.StructTagCasing "alias"}}{{$tag = $alias.UpSingular}}{{$ftag = $falias.UpSingular}}
+{{- end}}
+{{- if ne $i 0}}
+
+{{end -}}
+// {{$join.Name}} is a {{$alias.DownSingular}} with its {{$falias.DownSingular}}, selected in a single query by {{plural $join.Name}}
+type {{$join.Name}} struct {
+	{{$alias.UpSingular}} {{$alias.UpSingular}} `boil:"{{$join.Table}},bind" json:"{{$tag}}" toml:"{{$tag}}" yaml:"{{$tag}}"`
+	{{$falias.UpSingular}} {{$falias.UpSingular}} `boil:"{{$join.ForeignTable}},bind" json:"{{$ftag}}" toml:"{{$ftag}}" yaml:"{{$ftag}}"`
+}
+
+// {{$join.Name}}Slice is a slice of {{$join.Name}}
+type {{$join.Name}}Slice []*{{$join.Name}}
+
+// {{$varName}}Columns are the columns of both tables, named by their table
+// for the binding of {{$join.Name}}
+var {{$varName}}Columns = []string{
+	{{- range $table.Columns}}
+	"{{$schemaTable}}.{{.Name | $.Quotes}} as {{printf "%s.%s" $join.Table .Name | $.Quotes}}",
+	{{- end}}
+	{{- range $ftable.Columns}}
+	"{{$schemaForeignTable}}.{{.Name | $.Quotes}} as {{printf "%s.%s" $join.ForeignTable .Name | $.Quotes}}",
+	{{- end}}
+}
+
+// {{$varName}}Query is the query of the {{$alias.DownPlural}} joined with their {{$falias.DownPlural}}
+type {{$varName}}Query struct {
+	*queries.Query
+}
+
+// {{plural $join.Name}} selects the {{$alias.DownPlural}} joined with their {{$falias.DownPlural}}, the columns of
+// the query mods should name their table since both tables are selected.
+// The {{$alias.DownPlural}} without {{$falias.DownPlural}} are left out.
+func {{plural $join.Name}}(mods ...qm.QueryMod) {{$varName}}Query {
+	mods = append([]qm.QueryMod{
+		qm.Select({{$varName}}Columns...),
+		qm.From("{{$schemaTable}}"),
+		qm.InnerJoin("{{$schemaForeignTable}} on {{$schemaForeignTable}}.{{$join.ForeignColumn | $.Quotes}} = {{$schemaTable}}.{{$join.Column | $.Quotes}}"),
+		{{- if and $.AddSoftDeletes ($table.CanSoftDelete $.AutoColumns.Deleted)}}
+		qmhelper.WhereIsNull("{{$schemaTable}}.{{$deleted | $.Quotes}}"),
+		{{- end}}
+		{{- if and $.AddSoftDeletes ($ftable.CanSoftDelete $.AutoColumns.Deleted)}}
+		qmhelper.WhereIsNull("{{$schemaForeignTable}}.{{$deleted | $.Quotes}}"),
+		{{- end}}
+	}, mods...)
+
+	return {{$varName}}Query{NewQuery(mods...)}
+}
+
+// One returns a single {{$join.Name}} from the query.
+func (q {{$varName}}Query) One({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) (*{{$join.Name}}, error) {
+	{{if $.Tenancy.Scopes $join.Table -}}
+	if err := tenantScope(ctx, q.Query, "{{$schemaTable}}.{{$.Tenancy.Column | $.Quotes}}"); err != nil {
+		return nil, err
+	}
+	{{end -}}
+	{{if $.Tenancy.Scopes $join.ForeignTable -}}
+	if err := tenantScope(ctx, q.Query, "{{$schemaForeignTable}}.{{$.Tenancy.Column | $.Quotes}}"); err != nil {
+		return nil, err
+	}
+	{{end -}}
+	{{if or ($.Tenancy.Scopes $join.Table) ($.Tenancy.Scopes $join.ForeignTable)}}
+	{{end -}}
+	o := &{{$join.Name}}{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind({{if $.NoContext}}nil{{else}}ctx{{end}}, exec, o)
+	if err != nil {
+		{{if not $.AlwaysWrapErrors -}}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		{{end -}}
+		return nil, errors.Wrap(err, "{{$.PkgName}}: failed to execute a one query for {{$join.Name}}")
+	}
+
+	{{if not $.NoHooks -}}
+	if err := o.doAfterSelectHooks({{if not $.NoContext}}ctx, {{end -}} exec); err != nil {
+		return o, err
+	}
+
+	{{end -}}
+	return o, nil
+}
+
+// All returns all the {{$join.Name}} rows of the query.
+func (q {{$varName}}Query) All({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) ({{$join.Name}}Slice, error) {
+	{{if $.Tenancy.Scopes $join.Table -}}
+	if err := tenantScope(ctx, q.Query, "{{$schemaTable}}.{{$.Tenancy.Column | $.Quotes}}"); err != nil {
+		return nil, err
+	}
+	{{end -}}
+	{{if $.Tenancy.Scopes $join.ForeignTable -}}
+	if err := tenantScope(ctx, q.Query, "{{$schemaForeignTable}}.{{$.Tenancy.Column | $.Quotes}}"); err != nil {
+		return nil, err
+	}
+	{{end -}}
+	{{if or ($.Tenancy.Scopes $join.Table) ($.Tenancy.Scopes $join.ForeignTable)}}
+	{{end -}}
+	var o []*{{$join.Name}}
+
+	err := q.Bind({{if $.NoContext}}nil{{else}}ctx{{end}}, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "{{$.PkgName}}: failed to assign all query results to {{$join.Name}} slice")
+	}
+
+	{{if not $.NoHooks -}}
+	for _, obj := range o {
+		if err := obj.doAfterSelectHooks({{if not $.NoContext}}ctx, {{end -}} exec); err != nil {
+			return o, err
+		}
+	}
+
+	{{end -}}
+	return o, nil
+}
+{{- if not $.NoHooks}}
+
+// doAfterSelectHooks runs the after select hooks of the {{$alias.DownSingular}} and of its {{$falias.DownSingular}}
+func (o *{{$join.Name}}) doAfterSelectHooks({{if $.NoContext}}exec boil.Executor{{else}}ctx context.Context, exec boil.ContextExecutor{{end}}) error {
+	if err := o.{{$alias.UpSingular}}.doAfterSelectHooks({{if not $.NoContext}}ctx, {{end -}} exec); err != nil {
+		return err
+	}
+	return o.{{$falias.UpSingular}}.doAfterSelectHooks({{if not $.NoContext}}ctx, {{end -}} exec)
+}
+{{- end}}
+{{- end}}
+{{- end -}}
@@ -0,0 +1,41 @@
+{{- if .MaskTables -}}
+{{range .MaskTables}}
+{{- $alias := $.Aliases.Table .Name}}
+
+// Masked returns a copy of the {{$alias.DownSingular}} with its sensitive columns masked, for the
+// read paths not allowed to see them: {{range $i, $col := .Columns}}{{if ne $i 0}}, {{end}}{{$col.Name}} ({{$col.Rule}}){{end}}.
+// The copy leaves out the loaded relationships, mask them with their own Masked
+// methods, and must not be saved.
+func (o *{{$alias.UpSingular}}) Masked() *{{$alias.UpSingular}} {
+	if o == nil {
+		return nil
+	}
+
+	m := *o
+	m.R = nil
+	{{- if .Zero}}
+	var zero {{$alias.UpSingular}}
+	{{- end}}
+	{{- range .Columns}}
+	{{.Mask}}
+	{{- end}}
+
+	return &m
+}
+
+// Masked returns copies of the {{$alias.DownPlural}} with their sensitive columns masked, see
+// {{$alias.UpSingular}}.Masked.
+func (o {{$alias.UpSingular}}Slice) Masked() {{$alias.UpSingular}}Slice {
+	if o == nil {
+		return nil
+	}
+
+	slice := make({{$alias.UpSingular}}Slice, len(o))
+	for i, obj := range o {
+		slice[i] = obj.Masked()
+	}
+
+	return slice
+}
+{{- end}}
+{{- end -}}
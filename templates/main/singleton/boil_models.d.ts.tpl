@@ -0,0 +1,41 @@
+{{- if .WithTypeScript -}}
+{{- $relTag := or .RelationTag "R" -}}
+// Code generated by SQLBoiler (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+{{range $table := .Tables}}
+{{- if not $table.IsJoinTable}}
+{{- $alias := $.Aliases.Table $table.Name}}
+{{- $iface := index $.TypeScriptInterfaces $table.Name}}
+{{- $hasRels := and (ne $relTag "-") (not $table.IsView)}}
+/** A row of the {{$table.Name}} {{if $table.IsView}}view{{else}}table{{end}} */
+export interface {{$iface.Name}} {
+  {{- range $iface.Fields}}
+  {{- if .Comment}}
+  /** {{.Comment}} */
+  {{- end}}
+  {{.Name}}: {{.Type}};
+  {{- end}}
+  {{- if $hasRels}}
+  {{$relTag | typeScriptName}}: {{$iface.Name}}R | null;
+  {{- end}}
+}
+{{if $hasRels}}
+/** The loaded relationships of {{$iface.Name}} */
+export interface {{$iface.Name}}R {
+  {{- range $fkey := $table.FKeys}}
+  {{- $rel := $alias.Relationship $fkey.Name}}
+  {{$rel.Foreign | typeScriptName}}: {{($.Aliases.Table $fkey.ForeignTable).UpSingular}} | null;
+  {{- end}}
+  {{- range $rel := $table.ToOneRelationships}}
+  {{- $relAlias := ($.Aliases.Table $rel.ForeignTable).Relationship $rel.Name}}
+  {{$relAlias.Local | typeScriptName}}: {{($.Aliases.Table $rel.ForeignTable).UpSingular}} | null;
+  {{- end}}
+  {{- range $rel := $table.ToManyRelationships}}
+  {{- $relAlias := $.Aliases.ManyRelationship $rel.ForeignTable $rel.Name $rel.JoinTable $rel.JoinLocalFKeyName}}
+  {{$relAlias.Local | typeScriptName}}: {{($.Aliases.Table $rel.ForeignTable).UpSingular}}[] | null;
+  {{- end}}
+}
+{{end}}
+{{- end}}
+{{- end}}
+{{- end -}}
@@ -0,0 +1,91 @@
+{{- if .Outbox.Tables -}}
+{{- $execArgs := "ctx context.Context, exec boil.ContextExecutor" -}}
+{{- $ctxArg := "ctx, " -}}
+{{- $publishType := "func(ctx context.Context, e *OutboxEvent) error" -}}
+{{- if .NoContext -}}
+{{- $execArgs = "exec boil.Executor" -}}
+{{- $ctxArg = "" -}}
+{{- $publishType = "func(e *OutboxEvent) error" -}}
+{{- end -}}
+// outboxInsertSQL writes an event to {{.Outbox.Table}}
+const outboxInsertSQL = "INSERT INTO {{.Outbox.Table | $.SchemaTable}} ({{"topic" | $.Quotes}}, {{"operation" | $.Quotes}}, {{"event_key" | $.Quotes}}, {{"payload" | $.Quotes}}, {{"created_at" | $.Quotes}}) VALUES ({{.Dialect.Placeholders 5 1 1}})"
+
+// outboxSentSQL marks an event of {{.Outbox.Table}} sent
+const outboxSentSQL = "UPDATE {{.Outbox.Table | $.SchemaTable}} SET {{"sent_at" | $.Quotes}} = {{.Dialect.Placeholder 1}} WHERE {{"id" | $.Quotes}} = {{.Dialect.Placeholder 2}}"
+
+// OutboxEvent is a change of a row written to {{.Outbox.Table}} in the transaction of the
+// change. Topic is the table of the row and Operation INSERT, UPDATE, DELETE or
+// UPSERT. Key is the JSON of the primary key of the row and Payload the JSON of the
+// row, as it was before it was deleted for deletes.
+type OutboxEvent struct {
+	ID        int64      `boil:"id" json:"id"`
+	Topic     string     `boil:"topic" json:"topic"`
+	Operation string     `boil:"operation" json:"operation"`
+	Key       string     `boil:"event_key" json:"event_key"`
+	Payload   types.JSON `boil:"payload" json:"payload"`
+	CreatedAt time.Time  `boil:"created_at" json:"created_at"`
+	SentAt    null.Time  `boil:"sent_at" json:"sent_at,omitempty"`
+}
+
+// outboxWrite writes a change of a row to {{.Outbox.Table}}, pkey is its primary key
+func outboxWrite({{$execArgs}}, topic, operation string, pkey map[string]interface{}, row interface{}) error {
+	key, err := json.Marshal(pkey)
+	if err != nil {
+		return errors.Wrapf(err, "{{.PkgName}}: unable to marshal the key of a %s row for the outbox", topic)
+	}
+	payload, err := json.Marshal(row)
+	if err != nil {
+		return errors.Wrapf(err, "{{.PkgName}}: unable to marshal a %s row for the outbox", topic)
+	}
+
+	createdAt := time.Now().In(boil.GetLocation())
+
+	{{if .NoContext -}}
+	_, err = boil.DebugExec(exec, outboxInsertSQL, topic, operation, string(key), string(payload), createdAt)
+	{{- else -}}
+	_, err = boil.DebugExecContext(ctx, exec, outboxInsertSQL, topic, operation, string(key), string(payload), createdAt)
+	{{- end}}
+	if err != nil {
+		return errors.Wrapf(boil.ConvertError(err), "{{.PkgName}}: unable to write the change of a %s row to {{.Outbox.Table}}", topic)
+	}
+
+	return nil
+}
+
+// RelayOutbox publishes the events of {{.Outbox.Table}} that were not sent, at most limit
+// of them in the order they were written, and marks each of them sent once it is
+// published. It stops at the first event publish fails for and returns the number
+// of events it sent. Events are sent at least once: an event published but not
+// marked sent is published again by the next relay. Relays must not run concurrently.
+func RelayOutbox({{$execArgs}}, limit int, publish {{$publishType}}) (int, error) {
+	var events []*OutboxEvent
+	err := NewQuery(
+		qm.From("{{.Outbox.Table | $.SchemaTable}}"),
+		qm.Where("{{"sent_at" | $.Quotes}} IS NULL"),
+		qm.OrderBy("{{"id" | $.Quotes}}"),
+		qm.Limit(limit),
+	).Bind({{if .NoContext}}nil{{else}}ctx{{end}}, exec, &events)
+	if err != nil {
+		return 0, errors.Wrap(err, "{{.PkgName}}: unable to read the events of {{.Outbox.Table}}")
+	}
+
+	for i, e := range events {
+		if err := publish({{$ctxArg}}e); err != nil {
+			return i, errors.Wrapf(err, "{{.PkgName}}: unable to publish the event %d of {{.Outbox.Table}}", e.ID)
+		}
+
+		sentAt := time.Now().In(boil.GetLocation())
+		{{if .NoContext -}}
+		_, err = boil.DebugExec(exec, outboxSentSQL, sentAt, e.ID)
+		{{- else -}}
+		_, err = boil.DebugExecContext(ctx, exec, outboxSentSQL, sentAt, e.ID)
+		{{- end}}
+		if err != nil {
+			return i, errors.Wrapf(boil.ConvertError(err), "{{.PkgName}}: unable to mark the event %d of {{.Outbox.Table}} sent", e.ID)
+		}
+		e.SentAt = null.TimeFrom(sentAt)
+	}
+
+	return len(events), nil
+}
+{{- end -}}
@@ -0,0 +1,158 @@
+{{- if .WithProto -}}
+// protoTimestamp converts a time to a protobuf timestamp, nil if it is not valid
+func protoTimestamp(t time.Time, valid bool) *timestamppb.Timestamp {
+	if !valid {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+{{if eq .Proto.Nullable "wrappers"}}
+// protoInt32Value converts a value to a protobuf wrapper, nil if it is not valid
+func protoInt32Value(v int32, valid bool) *wrapperspb.Int32Value {
+	if !valid {
+		return nil
+	}
+	return wrapperspb.Int32(v)
+}
+
+// protoInt64Value converts a value to a protobuf wrapper, nil if it is not valid
+func protoInt64Value(v int64, valid bool) *wrapperspb.Int64Value {
+	if !valid {
+		return nil
+	}
+	return wrapperspb.Int64(v)
+}
+
+// protoUInt32Value converts a value to a protobuf wrapper, nil if it is not valid
+func protoUInt32Value(v uint32, valid bool) *wrapperspb.UInt32Value {
+	if !valid {
+		return nil
+	}
+	return wrapperspb.UInt32(v)
+}
+
+// protoUInt64Value converts a value to a protobuf wrapper, nil if it is not valid
+func protoUInt64Value(v uint64, valid bool) *wrapperspb.UInt64Value {
+	if !valid {
+		return nil
+	}
+	return wrapperspb.UInt64(v)
+}
+
+// protoFloatValue converts a value to a protobuf wrapper, nil if it is not valid
+func protoFloatValue(v float32, valid bool) *wrapperspb.FloatValue {
+	if !valid {
+		return nil
+	}
+	return wrapperspb.Float(v)
+}
+
+// protoDoubleValue converts a value to a protobuf wrapper, nil if it is not valid
+func protoDoubleValue(v float64, valid bool) *wrapperspb.DoubleValue {
+	if !valid {
+		return nil
+	}
+	return wrapperspb.Double(v)
+}
+
+// protoBoolValue converts a value to a protobuf wrapper, nil if it is not valid
+func protoBoolValue(v bool, valid bool) *wrapperspb.BoolValue {
+	if !valid {
+		return nil
+	}
+	return wrapperspb.Bool(v)
+}
+
+// protoStringValue converts a value to a protobuf wrapper, nil if it is not valid
+func protoStringValue(v string, valid bool) *wrapperspb.StringValue {
+	if !valid {
+		return nil
+	}
+	return wrapperspb.String(v)
+}
+
+// protoBytesValue converts a value to a protobuf wrapper, nil if it is not valid
+func protoBytesValue(v []byte, valid bool) *wrapperspb.BytesValue {
+	if !valid {
+		return nil
+	}
+	return wrapperspb.Bytes(v)
+}
+{{- else}}
+// protoOptionalInt32 converts a value to an optional field, nil if it is not valid
+func protoOptionalInt32(v int32, valid bool) *int32 {
+	if !valid {
+		return nil
+	}
+	return &v
+}
+
+// protoOptionalInt64 converts a value to an optional field, nil if it is not valid
+func protoOptionalInt64(v int64, valid bool) *int64 {
+	if !valid {
+		return nil
+	}
+	return &v
+}
+
+// protoOptionalUint32 converts a value to an optional field, nil if it is not valid
+func protoOptionalUint32(v uint32, valid bool) *uint32 {
+	if !valid {
+		return nil
+	}
+	return &v
+}
+
+// protoOptionalUint64 converts a value to an optional field, nil if it is not valid
+func protoOptionalUint64(v uint64, valid bool) *uint64 {
+	if !valid {
+		return nil
+	}
+	return &v
+}
+
+// protoOptionalFloat32 converts a value to an optional field, nil if it is not valid
+func protoOptionalFloat32(v float32, valid bool) *float32 {
+	if !valid {
+		return nil
+	}
+	return &v
+}
+
+// protoOptionalFloat64 converts a value to an optional field, nil if it is not valid
+func protoOptionalFloat64(v float64, valid bool) *float64 {
+	if !valid {
+		return nil
+	}
+	return &v
+}
+
+// protoOptionalBool converts a value to an optional field, nil if it is not valid
+func protoOptionalBool(v bool, valid bool) *bool {
+	if !valid {
+		return nil
+	}
+	return &v
+}
+
+// protoOptionalString converts a value to an optional field, nil if it is not valid
+func protoOptionalString(v string, valid bool) *string {
+	if !valid {
+		return nil
+	}
+	return &v
+}
+
+// protoOptionalBytes converts a value to an optional field, nil if it is not
+// valid and never nil if it is
+func protoOptionalBytes(v []byte, valid bool) []byte {
+	if !valid {
+		return nil
+	}
+	if v == nil {
+		return []byte{}
+	}
+	return v
+}
+{{- end}}
+{{- end -}}
@@ -6,6 +6,8 @@ var dialect = drivers.Dialect{
 	UseLastInsertID:         {{.Dialect.UseLastInsertID}},
 	UseSchema:               {{.Dialect.UseSchema}},
 	UseDefaultKeyword:       {{.Dialect.UseDefaultKeyword}},
+	UseNullsOrderClause:     {{.Dialect.UseNullsOrderClause}},
+	UseStatementTimeout:     {{.Dialect.UseStatementTimeout}},
 	UseAutoColumns:          {{.Dialect.UseAutoColumns}},
 	UseTopClause:            {{.Dialect.UseTopClause}},
 	UseOutputClause:         {{.Dialect.UseOutputClause}},
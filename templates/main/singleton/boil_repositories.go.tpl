@@ -0,0 +1,21 @@
+{{- if .AddRepositories -}}
+{{- $execType := "boil.ContextExecutor" -}}
+{{- if .NoContext}}{{$execType = "boil.Executor"}}{{end -}}
+// Repositories are the repositories of the models, to pass them around as one
+type Repositories struct {
+	{{- range .Tables}}{{if not .IsJoinTable}}
+	{{- $alias := $.Aliases.Table .Name}}
+	{{$alias.UpPlural}} {{$alias.UpSingular}}Repository
+	{{- end}}{{end}}
+}
+
+// NewRepositories returns the stores of the models running their queries on exec
+func NewRepositories(exec {{$execType}}) Repositories {
+	return Repositories{
+		{{- range .Tables}}{{if not .IsJoinTable}}
+		{{- $alias := $.Aliases.Table .Name}}
+		{{$alias.UpPlural}}: New{{$alias.UpSingular}}Store(exec),
+		{{- end}}{{end}}
+	}
+}
+{{- end -}}
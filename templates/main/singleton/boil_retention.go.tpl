@@ -0,0 +1,180 @@
+{{- if .RetentionTables -}}
+{{- $execArgs := "ctx context.Context, exec boil.ContextExecutor" -}}
+{{- $execParams := "ctx, exec" -}}
+{{- if .NoContext -}}
+{{- $execArgs = "exec boil.Executor" -}}
+{{- $execParams = "exec" -}}
+{{- end -}}
+// RetentionBatchSize is the number of rows the purge and archive helpers of the
+// retained tables delete or move per batch
+var RetentionBatchSize = 1000
+
+// retention is a table whose rows are purged or archived by the age of a
+// timestamp column, the table and the column are quoted
+type retention struct {
+	name    string
+	table   string
+	column  string
+	columns []string
+	pkey    []string
+}
+
+// retain deletes the rows of the table older than age in batches, inserting
+// them into the archive table first when it is not empty. The batches run in
+// their own transactions when exec begins them and progress is called with the
+// number of rows done after each batch. No hooks are run and the rows of soft
+// deleted tables are deleted for good.
+func retain({{$execArgs}}, r retention, archive string, age time.Duration, progress func(done int64)) (int64, error) {
+	before := time.Now().In(boil.GetLocation()).Add(-age)
+
+	var done int64
+	for {
+		n, selected, err := retainBatch({{$execParams}}, r, archive, before)
+		done += n
+		if err != nil {
+			return done, err
+		}
+		if selected == 0 {
+			return done, nil
+		}
+		if progress != nil {
+			progress(done)
+		}
+		if selected < RetentionBatchSize {
+			return done, nil
+		}
+	}
+}
+
+// retainBatch deletes, or archives, the oldest batch of the rows of the table
+// from before and returns the number of rows deleted and selected
+func retainBatch({{$execArgs}}, r retention, archive string, before time.Time) (int64, int, error) {
+	q := NewQuery(
+		qm.Select(r.pkey...),
+		qm.From(r.table),
+		qm.Where(r.column+" < ?", before),
+		qm.OrderBy(r.column),
+		qm.Limit(RetentionBatchSize),
+	)
+	queries.SetUsePrimary(q)
+
+	{{if .NoContext -}}
+	rows, err := q.Query(exec)
+	{{- else -}}
+	rows, err := q.QueryContext(ctx, exec)
+	{{- end}}
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "{{.PkgName}}: unable to select the old rows of %s", r.name)
+	}
+
+	var args []interface{}
+	for rows.Next() {
+		key := make([]interface{}, len(r.pkey))
+		ptrs := make([]interface{}, len(r.pkey))
+		for i := range key {
+			ptrs[i] = &key[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			_ = rows.Close()
+			return 0, 0, errors.Wrapf(err, "{{.PkgName}}: unable to scan the old rows of %s", r.name)
+		}
+		args = append(args, key...)
+	}
+	if err := rows.Close(); err != nil {
+		return 0, 0, errors.Wrapf(err, "{{.PkgName}}: unable to close the old rows of %s", r.name)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, 0, errors.Wrapf(err, "{{.PkgName}}: unable to select the old rows of %s", r.name)
+	}
+
+	selected := len(args) / len(r.pkey)
+	if selected == 0 {
+		return 0, 0, nil
+	}
+
+	tx := exec
+	var commit func() error
+	{{if .NoContext -}}
+	if beginner, ok := exec.(boil.Beginner); ok {
+		t, err := beginner.Begin()
+	{{- else -}}
+	if beginner, ok := exec.(boil.ContextBeginner); ok {
+		t, err := beginner.BeginTx(ctx, nil)
+	{{- end}}
+		if err != nil {
+			return 0, selected, errors.Wrapf(err, "{{.PkgName}}: unable to begin the batch of %s", r.name)
+		}
+		defer func() { _ = t.Rollback() }()
+		tx, commit = t, t.Commit
+	}
+
+	where := strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, r.pkey, selected)
+	if len(archive) != 0 {
+		selectCols := make([]string, len(r.columns))
+		for i, c := range r.columns {
+			selectCols[i] = r.table + "." + c
+		}
+		ins := NewQuery(qm.Select(selectCols...), qm.From(r.table), qm.Where(where, args...))
+		queries.SetInsertInto(ins, archive, r.columns...)
+		{{if .NoContext -}}
+		_, err = ins.Exec(tx)
+		{{- else -}}
+		_, err = ins.ExecContext(ctx, tx)
+		{{- end}}
+		if err != nil {
+			return 0, selected, errors.Wrapf(boil.ConvertError(err), "{{.PkgName}}: unable to archive the old rows of %s", r.name)
+		}
+	}
+
+	del := NewQuery(qm.From(r.table), qm.Where(where, args...))
+	queries.SetDelete(del)
+	{{if .NoContext -}}
+	result, err := del.Exec(tx)
+	{{- else -}}
+	result, err := del.ExecContext(ctx, tx)
+	{{- end}}
+	if err != nil {
+		return 0, selected, errors.Wrapf(boil.ConvertError(err), "{{.PkgName}}: unable to delete the old rows of %s", r.name)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, selected, errors.Wrapf(err, "{{.PkgName}}: unable to retrieve rows affected for %s", r.name)
+	}
+
+	if commit != nil {
+		if err := commit(); err != nil {
+			return 0, selected, errors.Wrapf(err, "{{.PkgName}}: unable to commit the batch of %s", r.name)
+		}
+	}
+
+	return n, selected, nil
+}
+{{range .RetentionTables}}
+{{- $alias := $.Aliases.Table .Name}}
+
+// {{$alias.DownSingular}}Retention reads the age of the {{$alias.DownPlural}} from {{.Column}}
+var {{$alias.DownSingular}}Retention = retention{
+	name:    "{{.Name}}",
+	table:   "{{.Name | $.SchemaTable}}",
+	column:  "{{.Column | $.Quotes}}",
+	columns: {{$alias.DownSingular}}AllColumns,
+	pkey:    {{$alias.DownSingular}}PrimaryKeyColumns,
+}
+
+// Purge{{$alias.UpPlural}}OlderThan deletes the {{$alias.DownPlural}} whose {{.Column}} is older than age,
+// in batches of RetentionBatchSize rows, and returns the number of rows deleted.
+// progress is called with the rows deleted so far after each batch when it is
+// not nil.
+func Purge{{$alias.UpPlural}}OlderThan({{$execArgs}}, age time.Duration, progress func(done int64)) (int64, error) {
+	return retain({{$execParams}}, {{$alias.DownSingular}}Retention, "", age, progress)
+}
+
+// Archive{{$alias.UpPlural}}To moves the {{$alias.DownPlural}} whose {{.Column}} is older than age into
+// the table, of the same columns, in batches of RetentionBatchSize rows and
+// returns the number of rows moved. progress is called with the rows moved so
+// far after each batch when it is not nil.
+func Archive{{$alias.UpPlural}}To({{$execArgs}}, table string, age time.Duration, progress func(done int64)) (int64, error) {
+	return retain({{$execParams}}, {{$alias.DownSingular}}Retention, table, age, progress)
+}
+{{- end}}
+{{end -}}
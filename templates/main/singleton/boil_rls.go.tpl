@@ -0,0 +1,51 @@
+{{- if .RLS.Tables -}}
+// RLSSetting is the session variable read by the row level security policies of
+// {{.RLS.Tables | join ", "}}
+const RLSSetting = "{{.RLS.Setting}}"
+
+// SetRLS sets {{.RLS.Setting}} to value until the end of the transaction, as SET LOCAL
+// does, so that the row level security policies of the queries run in the
+// transaction afterwards read it. Outside of a transaction it is only set for the
+// statement, so run it in one.
+func SetRLS({{if not .NoContext}}ctx context.Context, exec boil.ContextExecutor{{else}}exec boil.Executor{{end}}, value string) error {
+	// SET LOCAL takes no parameters, set_config with is_local does the same
+	query := "select set_config($1, $2, true)"
+	{{if .NoContext -}}
+	_, err := boil.DebugExec(exec, query, RLSSetting, value)
+	{{- else -}}
+	_, err := boil.DebugExecContext(ctx, exec, query, RLSSetting, value)
+	{{- end}}
+	if err != nil {
+		return errors.Wrap(err, "{{.PkgName}}: unable to set {{.RLS.Setting}}")
+	}
+
+	return nil
+}
+
+// WithRLS runs fn in a transaction of db with {{.RLS.Setting}} set to value by SetRLS,
+// it commits the transaction if fn returns nil and rolls it back otherwise.
+func WithRLS({{if not .NoContext}}ctx context.Context, db boil.ContextBeginner{{else}}db boil.Beginner{{end}}, value string, fn func(tx *sql.Tx) error) (err error) {
+	{{if .NoContext -}}
+	tx, err := db.Begin()
+	{{- else -}}
+	tx, err := db.BeginTx(ctx, nil)
+	{{- end}}
+	if err != nil {
+		return errors.Wrap(err, "{{.PkgName}}: unable to begin the transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = SetRLS({{if not .NoContext}}ctx, {{end}}tx, value); err != nil {
+		return err
+	}
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+{{end -}}
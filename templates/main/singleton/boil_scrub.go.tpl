@@ -0,0 +1,82 @@
+{{- if .ScrubTables -}}
+{{- $execArgs := "ctx context.Context, exec boil.ContextExecutor" -}}
+{{- $ctxArg := "ctx, " -}}
+{{- if .NoContext -}}
+{{- $execArgs = "exec boil.Executor" -}}
+{{- $ctxArg = "" -}}
+{{- end -}}
+// ScrubBatchSize is the number of rows the Scrub functions load at a time
+var ScrubBatchSize = 1000
+
+// ScrubAll overwrites the sensitive columns of every table with fakes, see
+// the Scrub functions of the tables.
+func ScrubAll({{$execArgs}}) error {
+	{{- range .ScrubTables}}
+	{{- $alias := $.Aliases.Table .Name}}
+	if _, err := Scrub{{$alias.UpPlural}}({{$ctxArg}}exec); err != nil {
+		return errors.Wrap(err, "{{$.PkgName}}: unable to scrub table {{.Name}}")
+	}
+	{{- end}}
+
+	return nil
+}
+{{range .ScrubTables}}
+{{- $table := getTable $.Tables .Name}}
+{{- $alias := $.Aliases.Table .Name}}
+{{- $canSoftDelete := $table.CanSoftDelete $.AutoColumns.Deleted}}
+
+// {{$alias.DownSingular}}ScrubColumns are the sensitive columns of {{$alias.DownPlural}}
+var {{$alias.DownSingular}}ScrubColumns = []string{
+	{{- range .Columns}}
+	"{{.Name}}",
+	{{- end}}
+}
+
+// Scrub overwrites the sensitive columns of the {{$alias.DownSingular}} with fakes, without
+// saving them: {{range $i, $col := .Columns}}{{if ne $i 0}}, {{end}}{{$col.Name}}{{end}}.
+func (o *{{$alias.UpSingular}}) Scrub() {
+	{{- range .Columns}}
+	{{.Scrub}}
+	{{- end}}
+}
+
+// Scrub{{$alias.UpPlural}} overwrites the sensitive columns of every {{$alias.DownSingular}} in the
+// database with fakes, in batches of ScrubBatchSize rows ordered by primary key,
+// and returns the number of rows.
+{{- if not $.NoContext}} Hooks are skipped so that they do not record the
+// values, like the audit log does.
+{{- end}}
+func Scrub{{$alias.UpPlural}}({{$execArgs}}) (int64, error) {
+	{{- if not $.NoContext}}
+	ctx = boil.SkipHooks(ctx)
+	{{- end}}
+
+	var n int64
+	for {
+		slice, err := {{$alias.UpPlural}}(
+			qm.OrderBy("{{range $i, $col := $table.PKey.Columns}}{{if ne $i 0}}, {{end}}{{$.Quotes $col}}{{end}}"),
+			qm.Limit(ScrubBatchSize),
+			qm.Offset(int(n)),
+			{{- if and $.AddSoftDeletes $canSoftDelete}}
+			qm.WithDeleted(),
+			{{- end}}
+		).All({{$ctxArg}}exec)
+		if err != nil {
+			return n, err
+		}
+
+		for _, o := range slice {
+			o.Scrub()
+			if {{if not $.NoRowsAffected}}_, {{end -}} err = o.Update({{$ctxArg}}exec, boil.Whitelist({{$alias.DownSingular}}ScrubColumns...)); err != nil {
+				return n, err
+			}
+			n++
+		}
+
+		if len(slice) < ScrubBatchSize {
+			return n, nil
+		}
+	}
+}
+{{- end}}
+{{- end -}}
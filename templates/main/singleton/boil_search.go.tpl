@@ -0,0 +1,56 @@
+{{- if .SearchTables -}}
+// TextSearchConfig is the text search configuration the queries of the
+// full text search helpers are parsed with, english for example, the
+// default_text_search_config of the database when empty
+var TextSearchConfig = ""
+
+// searchHelper builds the full text searches of a tsvector column
+type searchHelper struct{ field string }
+
+// Search matches the rows whose column matches the query, parsed by
+// plainto_tsquery, and orders them by rank, best first.
+func (s searchHelper) Search(query string) qm.QueryMod {
+	return qm.TextSearch{Column: s.field, Query: query, Config: TextSearchConfig}
+}
+
+// WebSearch matches the rows whose column matches the query, parsed by
+// websearch_to_tsquery with its "quoted phrases", or and -excluded words, and
+// orders them by rank, best first.
+func (s searchHelper) WebSearch(query string) qm.QueryMod {
+	return qm.TextSearch{Column: s.field, Query: query, Parser: qm.WebSearch, Config: TextSearchConfig}
+}
+
+// PhraseSearch matches the rows whose column has the words of the query in
+// their order, parsed by phraseto_tsquery, and orders them by rank, best first.
+func (s searchHelper) PhraseSearch(query string) qm.QueryMod {
+	return qm.TextSearch{Column: s.field, Query: query, Parser: qm.PhraseSearch, Config: TextSearchConfig}
+}
+
+// RawSearch matches the rows whose column matches the query, parsed by
+// to_tsquery with its &, |, ! and <-> operators, and orders them by rank, best
+// first.
+func (s searchHelper) RawSearch(query string) qm.QueryMod {
+	return qm.TextSearch{Column: s.field, Query: query, Parser: qm.RawSearch, Config: TextSearchConfig}
+}
+
+// Matches matches the rows whose column matches the query, parsed by
+// plainto_tsquery, without ordering them.
+func (s searchHelper) Matches(query string) qm.QueryMod {
+	return qm.TextSearch{Column: s.field, Query: query, Config: TextSearchConfig, Unranked: true}
+}
+{{range .SearchTables}}
+{{- $alias := $.Aliases.Table .Name}}
+{{- $name := .Name}}
+
+// {{$alias.UpSingular}}Search has the full text searches of the tsvector columns of {{$alias.DownPlural}}
+var {{$alias.UpSingular}}Search = struct {
+	{{range .Columns -}}
+	{{$alias.Column .}} searchHelper
+	{{end -}}
+}{
+	{{range .Columns -}}
+	{{$alias.Column .}}: searchHelper{field: "{{$name | $.SchemaTable}}.{{. | $.Quotes}}"},
+	{{end -}}
+}
+{{- end}}
+{{- end -}}
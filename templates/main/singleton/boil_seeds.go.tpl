@@ -0,0 +1,143 @@
+{{- if .AddSeeds -}}
+{{- $execArgs := "ctx context.Context, exec boil.ContextExecutor" -}}
+{{- $ctxArg := "ctx, " -}}
+{{- if .NoContext -}}
+{{- $execArgs = "exec boil.Executor" -}}
+{{- $ctxArg = "" -}}
+{{- end -}}
+// SeedTables are the tables Seed loads the seed files of, in the order they
+// are seeded so that the rows foreign keys refer to are seeded first
+var SeedTables = []string{
+{{- range .SeedTables}}
+	"{{.Name}}",
+{{- end}}
+}
+
+// seedColumns maps the keys of the rows of the seed files of every table to
+// their columns
+var seedColumns = map[string]map[string]string{
+{{- range .SeedTables}}
+	"{{.Name}}": {{"{"}}{{range $i, $key := .Keys}}{{if ne $i 0}}, {{end}}"{{$key.Key}}": "{{$key.Column}}"{{end}}{{"}"}},
+{{- end}}
+}
+
+// seeders seed the rows of the seed files of every table
+var seeders = map[string]func({{$execArgs}}, rows []map[string]interface{}) error{
+{{- range .SeedTables}}
+{{- $alias := $.Aliases.Table .Name}}
+	"{{.Name}}": seed{{$alias.UpPlural}},
+{{- end}}
+}
+
+// Seed loads the seed files of the directory into the database, see SeedFS.
+func Seed({{$execArgs}}, dir string) error {
+	return SeedFS({{$ctxArg}}exec, os.DirFS(dir))
+}
+
+// SeedFS loads the seed files of the file system into the database. The seed
+// file of a table is named after it with a .yaml, .yml or .json extension and
+// holds a list of rows keyed like the JSON of the models. Tables are seeded in
+// the order of SeedTables and tables without a seed file are skipped. Rows
+// that exist already are updated with the columns of the seed file, the
+// others are inserted.
+func SeedFS({{$execArgs}}, fsys fs.FS) error {
+	for _, table := range SeedTables {
+		rows, err := seedRead(fsys, table)
+		if err != nil {
+			return errors.Wrapf(err, "{{.PkgName}}: unable to seed table %s", table)
+		}
+		if err = seeders[table]({{$ctxArg}}exec, rows); err != nil {
+			return errors.Wrapf(err, "{{.PkgName}}: unable to seed table %s", table)
+		}
+	}
+
+	return nil
+}
+
+// seedRead reads the rows of the seed file of the table, there are none when
+// it has no seed file
+func seedRead(fsys fs.FS, table string) ([]map[string]interface{}, error) {
+	var rows []map[string]interface{}
+	var found string
+	for _, ext := range []string{".yaml", ".yml", ".json"} {
+		name := table + ext
+		b, err := fs.ReadFile(fsys, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		if len(found) != 0 {
+			return nil, errors.Errorf("both %s and %s are seed files of the table", found, name)
+		}
+		found = name
+
+		if ext == ".json" {
+			err = json.Unmarshal(b, &rows)
+		} else {
+			err = yaml.Unmarshal(b, &rows)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse %s", name)
+		}
+	}
+
+	return rows, nil
+}
+
+// seedRow sets the model o from a row of a seed file of the table and returns
+// the columns of the row that are not part of the primary key
+func seedRow(table string, row map[string]interface{}, o interface{}, pkey []string) ([]string, error) {
+	columns := make([]string, 0, len(row))
+	for key := range row {
+		column, ok := seedColumns[table][key]
+		if !ok {
+			return nil, errors.Errorf("unknown key %q", key)
+		}
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	b, err := json.Marshal(row)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(b, o); err != nil {
+		return nil, err
+	}
+
+	return strmangle.SetComplement(columns, pkey), nil
+}
+{{range .SeedTables}}
+{{- $table := getTable $.Tables .Name}}
+{{- $alias := $.Aliases.Table .Name}}
+
+// seed{{$alias.UpPlural}} inserts the rows of the seed file of the {{$table.Name}} table,
+// or updates them when they exist
+func seed{{$alias.UpPlural}}({{$execArgs}}, rows []map[string]interface{}) error {
+	for i, row := range rows {
+		o := &{{$alias.UpSingular}}{}
+		columns, err := seedRow("{{$table.Name}}", row, o, {{$alias.DownSingular}}PrimaryKeyColumns)
+		if err != nil {
+			return errors.Wrapf(err, "row %d", i)
+		}
+
+		exists, err := {{$alias.UpSingular}}Exists({{$ctxArg}}exec{{range $table.PKey.Columns}}, o.{{$alias.Column .}}{{end}})
+		if err != nil {
+			return errors.Wrapf(err, "row %d", i)
+		}
+		if !exists {
+			err = o.Insert({{$ctxArg}}exec, boil.Infer())
+		} else if len(columns) != 0 {
+			{{if not $.NoRowsAffected}}_, {{end -}} err = o.Update({{$ctxArg}}exec, boil.Whitelist(columns...))
+		}
+		if err != nil {
+			return errors.Wrapf(err, "row %d", i)
+		}
+	}
+
+	return nil
+}
+{{- end}}
+{{- end -}}
@@ -0,0 +1,203 @@
+{{- if .StreamTables -}}
+{{- $execArgs := "ctx context.Context, exec boil.ContextExecutor" -}}
+{{- $queryRow := "boil.DebugQueryRowContext(ctx, exec, " -}}
+{{- $exec := "boil.DebugExecContext(ctx, exec, " -}}
+{{- if .NoContext -}}
+{{- $execArgs = "exec boil.Executor" -}}
+{{- $queryRow = "boil.DebugQueryRow(exec, " -}}
+{{- $exec = "boil.DebugExec(exec, " -}}
+{{- end -}}
+// StreamChunkSize is the number of bytes the readers and writers of the streamed
+// columns read or write per query
+var StreamChunkSize = 1 << 20
+
+// streamReader reads a streamed column in chunks of StreamChunkSize bytes, the
+// column ends at the first chunk shorter than that
+type streamReader struct {
+	read   func(offset int64, n int) ([]byte, error)
+	offset int64
+	chunk  []byte
+	eof    bool
+	err    error
+}
+
+// Read implements io.Reader
+func (r *streamReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, r.err
+	}
+
+	for len(r.chunk) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.eof {
+			return 0, io.EOF
+		}
+
+		n := StreamChunkSize
+		r.chunk, r.err = r.read(r.offset, n)
+		r.offset += int64(len(r.chunk))
+		r.eof = len(r.chunk) < n
+	}
+
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
+
+// streamWrite reads r in chunks of StreamChunkSize bytes and writes each one
+// at its offset, the first chunk is written even when r is empty. It returns
+// the number of bytes written.
+func streamWrite(r io.Reader, write func(offset int64, chunk []byte) error) (int64, error) {
+	buf := make([]byte, StreamChunkSize)
+
+	var offset int64
+	for {
+		n, err := io.ReadFull(r, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return offset, err
+		}
+
+		if n != 0 || offset == 0 {
+			if werr := write(offset, buf[:n]); werr != nil {
+				return offset, werr
+			}
+			offset += int64(n)
+		}
+
+		if err != nil {
+			return offset, nil
+		}
+	}
+}
+{{range .StreamTables}}
+{{- $table := getTable $.Tables .Name}}
+{{- $alias := $.Aliases.Table .Name}}
+{{- $pkArgs := "" -}}
+{{- range $table.PKey.Columns}}{{$pkArgs = printf "%s, o.%s" $pkArgs ($alias.Column .)}}{{end}}
+
+// {{$alias.UpSingular}}StreamColumns are the columns of {{$alias.DownPlural}} read and written by streams,
+// leave them out of updates with boil.Blacklist so that their fields do not
+// overwrite them.
+var {{$alias.UpSingular}}StreamColumns = []string{
+	{{- range .Columns}}
+	"{{.Name}}",
+	{{- end}}
+}
+
+// {{$alias.UpSingular}}ColumnsWithoutStreams are the columns of {{$alias.DownPlural}} other than the
+// streamed ones, select them with qm.Select so that queries do not load the
+// streamed columns at once.
+var {{$alias.UpSingular}}ColumnsWithoutStreams = []string{
+	{{- range .NonStreamed}}
+	"{{.}}",
+	{{- end}}
+}
+{{- range .Columns}}
+{{- $field := $alias.Column .Name}}
+{{if .LargeObject}}
+{{- $oid := printf "o.%s" $field}}
+{{- if .Nullable}}{{$oid = printf "o.%s.Uint32" $field}}{{end}}
+
+// {{$field}}Reader returns a reader of the large object of the {{$alias.DownSingular}}'s {{.Name}}, read
+// from the database in chunks of StreamChunkSize bytes rather than loaded at
+// once.{{if .Nullable}} A null {{.Name}} reads as empty.{{end}}
+func (o *{{$alias.UpSingular}}) {{$field}}Reader({{$execArgs}}) io.Reader {
+	return &streamReader{read: func(offset int64, n int) ([]byte, error) {
+		{{- if .Nullable}}
+		if !o.{{$field}}.Valid {
+			return nil, nil
+		}
+		{{- end}}
+
+		var chunk []byte
+		err := {{$queryRow}}"select lo_get($1, $2, $3)", {{$oid}}, offset, n).Scan(&chunk)
+		if err != nil {
+			return nil, errors.Wrap(err, "{{$.PkgName}}: unable to read the {{.Name}} large object of {{$table.Name}}")
+		}
+		return chunk, nil
+	}}
+}
+
+// Write{{$field}} writes the bytes read from r to a new large object in chunks of
+// StreamChunkSize bytes rather than held at once, sets the {{$alias.DownSingular}}'s {{.Name}} to it
+// in the database and in {{$field}}, and unlinks the large object it replaces. It
+// returns the number of bytes. Write in a transaction so that a failure leaves
+// no large object behind.
+func (o *{{$alias.UpSingular}}) Write{{$field}}({{$execArgs}}, r io.Reader) (int64, error) {
+	var oid uint32
+	n, err := streamWrite(r, func(offset int64, chunk []byte) error {
+		if offset == 0 {
+			return {{$queryRow}}"select lo_from_bytea(0, $1)", chunk).Scan(&oid)
+		}
+		_, err := {{$exec}}"select lo_put($1, $2, $3)", oid, offset, chunk)
+		return err
+	})
+	if err != nil {
+		return n, errors.Wrap(err, "{{$.PkgName}}: unable to write the {{.Name}} large object of {{$table.Name}}")
+	}
+
+	if _, err = {{$exec}}{{printf "%q" .Set}}, oid{{$pkArgs}}); err != nil {
+		return n, errors.Wrap(err, "{{$.PkgName}}: unable to set the {{.Name}} large object of {{$table.Name}}")
+	}
+
+	{{if .Nullable -}}
+	old := o.{{$field}}
+	o.{{$field}} = null.Uint32From(oid)
+	if !old.Valid || old.Uint32 == 0 {
+		return n, nil
+	}
+	if _, err = {{$exec}}"select lo_unlink($1)", old.Uint32); err != nil {
+	{{- else -}}
+	old := o.{{$field}}
+	o.{{$field}} = oid
+	if old == 0 {
+		return n, nil
+	}
+	if _, err = {{$exec}}"select lo_unlink($1)", old); err != nil {
+	{{- end}}
+		return n, errors.Wrap(err, "{{$.PkgName}}: unable to unlink the replaced {{.Name}} large object of {{$table.Name}}")
+	}
+
+	return n, nil
+}
+{{- else}}
+
+// {{$field}}Reader returns a reader of the {{$alias.DownSingular}}'s {{.Name}}, read from the database
+// in chunks of StreamChunkSize bytes rather than loaded at once. The {{$field}}
+// field is neither read nor set{{if .Nullable}}, and a null {{.Name}} reads as empty{{end}}.
+func (o *{{$alias.UpSingular}}) {{$field}}Reader({{$execArgs}}) io.Reader {
+	return &streamReader{read: func(offset int64, n int) ([]byte, error) {
+		var chunk []byte
+		err := {{$queryRow}}{{printf "%q" .Read}}, offset+1, n{{$pkArgs}}).Scan(&chunk)
+		if err != nil {
+			return nil, errors.Wrap(err, "{{$.PkgName}}: unable to read the {{.Name}} of {{$table.Name}}")
+		}
+		return chunk, nil
+	}}
+}
+
+// Write{{$field}} replaces the {{$alias.DownSingular}}'s {{.Name}} in the database with the bytes read
+// from r, written in chunks of StreamChunkSize bytes rather than held at once,
+// and returns their number. The {{$field}} field is not set and hooks are not
+// run. Write in a transaction so that readers never see part of the bytes.
+func (o *{{$alias.UpSingular}}) Write{{$field}}({{$execArgs}}, r io.Reader) (int64, error) {
+	n, err := streamWrite(r, func(offset int64, chunk []byte) error {
+		query := {{printf "%q" .Set}}
+		if offset != 0 {
+			query = {{printf "%q" .Append}}
+		}
+		_, err := {{$exec}}query, chunk{{$pkArgs}})
+		return err
+	})
+	if err != nil {
+		return n, errors.Wrap(err, "{{$.PkgName}}: unable to write the {{.Name}} of {{$table.Name}}")
+	}
+
+	return n, nil
+}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end -}}
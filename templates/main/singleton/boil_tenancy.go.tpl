@@ -0,0 +1,60 @@
+{{- if .Tenancy.Tables -}}
+// contextTenant returns the tenant of the context, the {{.Tenancy.Column}} of the rows its
+// queries are scoped to
+func contextTenant(ctx context.Context) (interface{}, error) {
+	tenant, ok := boil.TenantFrom(ctx)
+	if !ok {
+		return nil, boil.ErrNoTenant
+	}
+	return tenant, nil
+}
+
+// tenantScope scopes the query to the rows of the tenant of the context by their
+// column, which is and-ed with all of the other where clauses of the query
+func tenantScope(ctx context.Context, q *queries.Query, column string) error {
+	if boil.TenancyIsSkipped(ctx) {
+		return nil
+	}
+
+	tenant, err := contextTenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	queries.SetScope(q, "tenant", column+" = ?", tenant)
+	return nil
+}
+
+// tenantCheck checks that the {{.Tenancy.Column}} of a row is the tenant of the context
+func tenantCheck(ctx context.Context, value interface{}) error {
+	if boil.TenancyIsSkipped(ctx) {
+		return nil
+	}
+
+	tenant, err := contextTenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !queries.Equal(tenant, value) {
+		return boil.ErrOtherTenant
+	}
+	return nil
+}
+
+// tenantWhere returns the condition scoping a query by primary key to the
+// tenant of the context and its argument, which are empty when tenancy is
+// skipped. The condition has the placeholder of the argument at index.
+func tenantWhere(ctx context.Context, column string, index int) (string, []interface{}, error) {
+	if boil.TenancyIsSkipped(ctx) {
+		return "", nil, nil
+	}
+
+	tenant, err := contextTenant(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf(" and %s=%s", column, dialect.Placeholder(index)), []interface{}{tenant}, nil
+}
+{{- end -}}
@@ -1,3 +1,10 @@
+{{- define "test_context" -}}
+{{- if .Tenancy.Tables -}}
+boil.SkipTenancy(context.Background())
+{{- else -}}
+context.Background()
+{{- end -}}
+{{- end -}}
 var (
     // Relationships sometimes use the reflection helper queries.Equal/queries.Assign
     // so force a package dependency in case they don't.
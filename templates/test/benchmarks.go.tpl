@@ -0,0 +1,123 @@
+{{- if .WithBenchmarks -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+func benchmark{{$alias.UpPlural}}Insert(b *testing.B) {
+	seed := randomize.NewSeed()
+	{{if not .NoContext}}ctx := {{template "test_context" $}}{{end}}
+	tx := MustTx({{if .NoContext}}boil.Begin(){{else}}boil.BeginTx(ctx, nil){{end}})
+	defer func() { _ = tx.Rollback() }()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		o := &{{$alias.UpSingular}}{}
+		if err := randomize.Struct(seed, o, {{$alias.DownSingular}}DBTypes, true, {{$alias.DownSingular}}ColumnsWithDefault...); err != nil {
+			b.Fatalf("Unable to randomize {{$alias.UpSingular}} struct: %s", err)
+		}
+		b.StartTimer()
+
+		if err := o.Insert({{if not .NoContext}}ctx, {{end -}} tx, boil.Infer()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmark{{$alias.UpPlural}}BulkInsert(b *testing.B) {
+	seed := randomize.NewSeed()
+	{{if not .NoContext}}ctx := {{template "test_context" $}}{{end}}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		o := make({{$alias.UpSingular}}Slice, benchmarkBulkSize)
+		for j := range o {
+			o[j] = &{{$alias.UpSingular}}{}
+			if err := randomize.Struct(seed, o[j], {{$alias.DownSingular}}DBTypes, true, {{$alias.DownSingular}}ColumnsWithDefault...); err != nil {
+				b.Fatalf("Unable to randomize {{$alias.UpSingular}} struct: %s", err)
+			}
+		}
+		b.StartTimer()
+
+		tx := MustTx({{if .NoContext}}boil.Begin(){{else}}boil.BeginTx(ctx, nil){{end}})
+		for _, row := range o {
+			if err := row.Insert({{if not .NoContext}}ctx, {{end -}} tx, boil.Infer()); err != nil {
+				_ = tx.Rollback()
+				b.Fatal(err)
+			}
+		}
+		_ = tx.Rollback()
+	}
+}
+
+func benchmark{{$alias.UpPlural}}Find(b *testing.B) {
+	seed := randomize.NewSeed()
+	o := &{{$alias.UpSingular}}{}
+	if err := randomize.Struct(seed, o, {{$alias.DownSingular}}DBTypes, true, {{$alias.DownSingular}}ColumnsWithDefault...); err != nil {
+		b.Fatalf("Unable to randomize {{$alias.UpSingular}} struct: %s", err)
+	}
+
+	{{if not .NoContext}}ctx := {{template "test_context" $}}{{end}}
+	tx := MustTx({{if .NoContext}}boil.Begin(){{else}}boil.BeginTx(ctx, nil){{end}})
+	defer func() { _ = tx.Rollback() }()
+	if err := o.Insert({{if not .NoContext}}ctx, {{end -}} tx, boil.Infer()); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Find{{$alias.UpSingular}}({{if not .NoContext}}ctx, {{end -}} tx, {{.Table.PKey.Columns | stringMap (aliasCols $alias) | prefixStringSlice "o." | join ", "}}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+{{range $fkey := .Table.FKeys -}}
+{{- $ltable := $.Aliases.Table $fkey.Table -}}
+{{- $ftable := $.Aliases.Table $fkey.ForeignTable -}}
+{{- $rel := $ltable.Relationship $fkey.Name -}}
+{{- $colField := $ltable.Column $fkey.Column -}}
+{{- $fcolField := $ftable.Column $fkey.ForeignColumn -}}
+{{- $usesPrimitives := usesPrimitives $.Tables $fkey.Table $fkey.Column $fkey.ForeignTable $fkey.ForeignColumn -}}
+func benchmark{{$ltable.UpSingular}}EagerLoad{{$rel.Foreign}}(b *testing.B) {
+	seed := randomize.NewSeed()
+	{{if not $.NoContext}}ctx := {{template "test_context" $}}{{end}}
+	tx := MustTx({{if $.NoContext}}boil.Begin(){{else}}boil.BeginTx(ctx, nil){{end}})
+	defer func() { _ = tx.Rollback() }()
+
+	var foreign {{$ftable.UpSingular}}
+	if err := randomize.Struct(seed, &foreign, {{$ftable.DownSingular}}DBTypes, {{if $fkey.ForeignColumnNullable}}true{{else}}false{{end}}, {{$ftable.DownSingular}}ColumnsWithDefault...); err != nil {
+		b.Fatalf("Unable to randomize {{$ftable.UpSingular}} struct: %s", err)
+	}
+	if err := foreign.Insert({{if not $.NoContext}}ctx, {{end -}} tx, boil.Infer()); err != nil {
+		b.Fatal(err)
+	}
+
+	slice := make({{$ltable.UpSingular}}Slice, {{if $fkey.Unique}}1{{else}}benchmarkBulkSize{{end}})
+	for i := range slice {
+		slice[i] = &{{$ltable.UpSingular}}{}
+		if err := randomize.Struct(seed, slice[i], {{$ltable.DownSingular}}DBTypes, {{if $fkey.Nullable}}true{{else}}false{{end}}, {{$ltable.DownSingular}}ColumnsWithDefault...); err != nil {
+			b.Fatalf("Unable to randomize {{$ltable.UpSingular}} struct: %s", err)
+		}
+		{{if $usesPrimitives -}}
+		slice[i].{{$colField}} = foreign.{{$fcolField}}
+		{{else -}}
+		queries.Assign(&slice[i].{{$colField}}, foreign.{{$fcolField}})
+		{{end -}}
+		if err := slice[i].Insert({{if not $.NoContext}}ctx, {{end -}} tx, boil.Infer()); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := slice[0].L.Load{{$rel.Foreign}}({{if not $.NoContext}}ctx, {{end -}} tx, false, (*[]*{{$ltable.UpSingular}})(&slice), nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+{{end -}}
+{{- end -}}
@@ -0,0 +1,121 @@
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- if and (not .Table.IsView) (hasExampleNames $alias) -}}
+{{- $pkNames := .Table.PKey.Columns | stringMap (aliasCols $alias) | prefixStringSlice "row." | join ", " -}}
+{{- $orderBy := index .Table.PKey.Columns 0 | $alias.Column -}}
+{{- $ctx := "" -}}{{- if not .NoContext}}{{$ctx = "ctx, "}}{{end -}}
+// This example lists the last ten {{$alias.DownPlural}} with query mods, the
+// executor is the one set with boil.SetDB.
+func Example{{$alias.UpPlural}}() {
+	{{if not .NoContext -}}
+	ctx := context.Background()
+	{{end -}}
+	db := boil.Get{{if not .NoContext}}Context{{end}}DB()
+
+	rows, err := {{$alias.UpPlural}}(
+		qm.OrderBy({{$alias.UpSingular}}Columns.{{$orderBy}}+" desc"),
+		qm.Limit(10),
+	).All({{$ctx}}db)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, row := range rows {
+		fmt.Println(row.{{$orderBy}})
+	}
+}
+
+// This example finds a {{$alias.DownSingular}} again by its primary key.
+func ExampleFind{{$alias.UpSingular}}() {
+	{{if not .NoContext -}}
+	ctx := context.Background()
+	{{end -}}
+	db := boil.Get{{if not .NoContext}}Context{{end}}DB()
+
+	row, err := {{$alias.UpPlural}}().One({{$ctx}}db)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	found, err := Find{{$alias.UpSingular}}({{$ctx}}db, {{$pkNames}})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(found.{{$orderBy}})
+}
+{{- $rel := "" -}}
+{{- $relMany := false -}}
+{{- range $fkey := .Table.FKeys -}}
+	{{- if not $rel -}}
+	{{- $rel = ($alias.Relationship $fkey.Name).Foreign -}}
+	{{- end -}}
+{{- end -}}
+{{- range $toOne := .Table.ToOneRelationships -}}
+	{{- if not $rel -}}
+	{{- $rel = (($.Aliases.Table $toOne.ForeignTable).Relationship $toOne.Name).Local -}}
+	{{- end -}}
+{{- end -}}
+{{- range $toMany := .Table.ToManyRelationships -}}
+	{{- if not $rel -}}
+	{{- $rel = ($.Aliases.ManyRelationship $toMany.ForeignTable $toMany.Name $toMany.JoinTable $toMany.JoinLocalFKeyName).Local -}}
+	{{- $relMany = true -}}
+	{{- end -}}
+{{- end -}}
+{{- if $rel}}
+
+// This example eager loads the {{$rel}} of the {{$alias.DownPlural}} with qm.Load, in one
+// query for all of them instead of one per {{$alias.DownSingular}}.
+func Example{{$alias.UpPlural}}_eagerLoading() {
+	{{if not .NoContext -}}
+	ctx := context.Background()
+	{{end -}}
+	db := boil.Get{{if not .NoContext}}Context{{end}}DB()
+
+	rows, err := {{$alias.UpPlural}}(qm.Load({{$alias.UpSingular}}Rels.{{$rel}})).All({{$ctx}}db)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, row := range rows {
+		{{if $relMany -}}
+		fmt.Println(row.{{$orderBy}}, len(row.R.Get{{$rel}}()))
+		{{- else -}}
+		fmt.Println(row.{{$orderBy}}, row.R.Get{{$rel}}() != nil)
+		{{- end}}
+	}
+}
+{{- end}}
+
+// This example inserts a {{$alias.DownSingular}} in a transaction, the columns with
+// defaults are set from the database after the insert.
+func Example{{$alias.UpSingular}}_Insert() {
+	{{if not .NoContext -}}
+	ctx := context.Background()
+	tx, err := boil.BeginTx(ctx, nil)
+	{{- else -}}
+	tx, err := boil.Begin()
+	{{- end}}
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var row {{$alias.UpSingular}}
+	if err := row.Insert({{$ctx}}tx, boil.Infer()); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(row.{{$orderBy}})
+}
+{{- end -}}
@@ -1,3 +1,4 @@
+{{- if not .NoExists -}}
 {{- $alias := .Aliases.Table .Table.Name}}
 func test{{$alias.UpPlural}}Exists(t *testing.T) {
 	t.Parallel()
@@ -9,7 +10,7 @@ func test{{$alias.UpPlural}}Exists(t *testing.T) {
 		t.Errorf("Unable to randomize {{$alias.UpSingular}} struct: %s", err)
 	}
 
-	{{if not .NoContext}}ctx := context.Background(){{end}}
+	{{if not .NoContext}}ctx := {{template "test_context" $}}{{end}}
 	tx := MustTx({{if .NoContext}}boil.Begin(){{else}}boil.BeginTx(ctx, nil){{end}})
 	defer func() { _ = tx.Rollback() }()
 	if err = o.Insert({{if not .NoContext}}ctx, {{end -}} tx, boil.Infer()); err != nil {
@@ -25,3 +26,4 @@ func test{{$alias.UpPlural}}Exists(t *testing.T) {
 		t.Errorf("Expected {{$alias.UpSingular}}Exists to return true, but got false.")
 	}
 }
+{{end -}}
@@ -50,10 +50,22 @@ func test{{$alias.UpPlural}}Hooks(t *testing.T) {
 
 	var err error
 
-	{{if not .NoContext}}ctx := context.Background(){{end}}
+	{{if not .NoContext}}ctx := {{template "test_context" $}}{{end}}
 	empty := &{{$alias.UpSingular}}{}
 	o := &{{$alias.UpSingular}}{}
 
+	// The hooks are run without an executor, hooks registered by the generated
+	// code such as those of the audit log and the outbox need one
+	{{$alias.DownSingular}}BeforeInsertHooks = []{{$alias.UpSingular}}Hook{}
+	{{$alias.DownSingular}}AfterInsertHooks = []{{$alias.UpSingular}}Hook{}
+	{{$alias.DownSingular}}AfterSelectHooks = []{{$alias.UpSingular}}Hook{}
+	{{$alias.DownSingular}}BeforeUpdateHooks = []{{$alias.UpSingular}}Hook{}
+	{{$alias.DownSingular}}AfterUpdateHooks = []{{$alias.UpSingular}}Hook{}
+	{{$alias.DownSingular}}BeforeDeleteHooks = []{{$alias.UpSingular}}Hook{}
+	{{$alias.DownSingular}}AfterDeleteHooks = []{{$alias.UpSingular}}Hook{}
+	{{$alias.DownSingular}}BeforeUpsertHooks = []{{$alias.UpSingular}}Hook{}
+	{{$alias.DownSingular}}AfterUpsertHooks = []{{$alias.UpSingular}}Hook{}
+
 	seed := randomize.NewSeed()
 	if err = randomize.Struct(seed, o, {{$alias.DownSingular}}DBTypes, false); err != nil {
 		t.Errorf("Unable to randomize {{$alias.UpSingular}} object: %s", err)
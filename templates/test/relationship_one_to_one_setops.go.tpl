@@ -1,4 +1,4 @@
-{{- if .Table.IsJoinTable -}}
+{{- if or .Table.IsJoinTable .NoRelationshipSetters .NoReload .NoExists -}}
 {{- else -}}
 	{{- range $rel := .Table.ToOneRelationships -}}
 		{{- $ltable := $.Aliases.Table $rel.Table -}}
@@ -12,7 +12,7 @@
 func test{{$ltable.UpSingular}}OneToOneSetOp{{$ftable.UpSingular}}Using{{$relAlias.Local}}(t *testing.T) {
 	var err error
 
-	{{if not $.NoContext}}ctx := context.Background(){{end}}
+	{{if not $.NoContext}}ctx := {{template "test_context" $}}{{end}}
 	tx := MustTx({{if $.NoContext}}boil.Begin(){{else}}boil.BeginTx(ctx, nil){{end}})
 	defer func() { _ = tx.Rollback() }()
 
@@ -46,9 +46,11 @@ func test{{$ltable.UpSingular}}OneToOneSetOp{{$ftable.UpSingular}}Using{{$relAli
 		if a.R.{{$relAlias.Local}} != x {
 			t.Error("relationship struct not set to correct value")
 		}
+		{{if not $.NoBackReferencing -}}
 		if x.R.{{$relAlias.Foreign}} != &a {
 			t.Error("failed to append to foreign relationship struct")
 		}
+		{{end -}}
 
 		{{if $usesPrimitives -}}
 		if a.{{$colField}} != x.{{$fcolField}} {
@@ -91,7 +93,7 @@ func test{{$ltable.UpSingular}}OneToOneSetOp{{$ftable.UpSingular}}Using{{$relAli
 func test{{$ltable.UpSingular}}OneToOneRemoveOp{{$ftable.UpSingular}}Using{{$relAlias.Local}}(t *testing.T) {
 	var err error
 
-	{{if not $.NoContext}}ctx := context.Background(){{end}}
+	{{if not $.NoContext}}ctx := {{template "test_context" $}}{{end}}
 	tx := MustTx({{if $.NoContext}}boil.Begin(){{else}}boil.BeginTx(ctx, nil){{end}})
 	defer func() { _ = tx.Rollback() }()
 
@@ -134,9 +136,12 @@ func test{{$ltable.UpSingular}}OneToOneRemoveOp{{$ftable.UpSingular}}Using{{$rel
 		t.Error("foreign key column should be nil")
 	}
 
+	{{- if not $.NoBackReferencing}}
+
 	if b.R.{{$relAlias.Foreign}} != nil {
 		t.Error("failed to remove a from b's relationships")
 	}
+	{{- end}}
 }
 {{end -}}{{/* end if foreign key nullable */}}
 {{- end -}}{{/* range */}}
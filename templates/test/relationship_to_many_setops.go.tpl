@@ -1,4 +1,4 @@
-{{- if .Table.IsJoinTable -}}
+{{- if or .Table.IsJoinTable .NoRelationshipSetters -}}
 {{- else -}}
 	{{- $table := .Table -}}
 	{{- range $rel := .Table.ToManyRelationships -}}
@@ -11,7 +11,7 @@
 func test{{$ltable.UpSingular}}ToManyAddOp{{$relAlias.Local}}(t *testing.T) {
 	var err error
 
-	{{if not $.NoContext}}ctx := context.Background(){{end}}
+	{{if not $.NoContext}}ctx := {{template "test_context" $}}{{end}}
 	tx := MustTx({{if $.NoContext}}boil.Begin(){{else}}boil.BeginTx(ctx, nil){{end}})
 	defer func() { _ = tx.Rollback() }()
 
@@ -53,6 +53,7 @@ func test{{$ltable.UpSingular}}ToManyAddOp{{$relAlias.Local}}(t *testing.T) {
 		first := x[0]
 		second := x[1]
 		{{- if .ToJoinTable}}
+		{{- if not $.NoBackReferencing}}
 
 		if first.R.{{$relAlias.Foreign}}[0] != &a {
 			t.Error("relationship was not added properly to the slice")
@@ -60,6 +61,7 @@ func test{{$ltable.UpSingular}}ToManyAddOp{{$relAlias.Local}}(t *testing.T) {
 		if second.R.{{$relAlias.Foreign}}[0] != &a {
 			t.Error("relationship was not added properly to the slice")
 		}
+		{{- end}}
 		{{- else}}
 
 		{{if $usesPrimitives -}}
@@ -77,6 +79,7 @@ func test{{$ltable.UpSingular}}ToManyAddOp{{$relAlias.Local}}(t *testing.T) {
 			t.Error("foreign key was wrong value", a.{{$colField}}, second.{{$fcolField}})
 		}
 		{{- end}}
+		{{- if not $.NoBackReferencing}}
 
 		if first.R.{{$relAlias.Foreign}} != &a {
 			t.Error("relationship was not added properly to the foreign slice")
@@ -85,6 +88,7 @@ func test{{$ltable.UpSingular}}ToManyAddOp{{$relAlias.Local}}(t *testing.T) {
 			t.Error("relationship was not added properly to the foreign slice")
 		}
 		{{- end}}
+		{{- end}}
 
 		if a.R.{{$relAlias.Local}}[i*2] != first {
 			t.Error("relationship struct slice not set to correct value")
@@ -107,7 +111,7 @@ func test{{$ltable.UpSingular}}ToManyAddOp{{$relAlias.Local}}(t *testing.T) {
 func test{{$ltable.UpSingular}}ToManySetOp{{$relAlias.Local}}(t *testing.T) {
 	var err error
 
-	{{if not $.NoContext}}ctx := context.Background(){{end}}
+	{{if not $.NoContext}}ctx := {{template "test_context" $}}{{end}}
 	tx := MustTx({{if $.NoContext}}boil.Begin(){{else}}boil.BeginTx(ctx, nil){{end}})
 	defer func() { _ = tx.Rollback() }()
 
@@ -173,20 +177,24 @@ func test{{$ltable.UpSingular}}ToManySetOp{{$relAlias.Local}}(t *testing.T) {
 	// if len(c.R.{{$relAlias.Foreign}}) != 0 {
 	// 	t.Error("relationship was not removed properly from the slice")
 	// }
+	{{- if not $.NoBackReferencing}}
 	if d.R.{{$relAlias.Foreign}}[0] != &a {
 		t.Error("relationship was not added properly to the slice")
 	}
 	if e.R.{{$relAlias.Foreign}}[0] != &a {
 		t.Error("relationship was not added properly to the slice")
 	}
+	{{- end}}
 	{{- else}}
 
+	{{if not $.NoBackReferencing -}}
 	if !queries.IsValuerNil(b.{{$fcolField}}) {
 		t.Error("want b's foreign key value to be nil")
 	}
 	if !queries.IsValuerNil(c.{{$fcolField}}) {
 		t.Error("want c's foreign key value to be nil")
 	}
+	{{end -}}
 	{{if $usesPrimitives -}}
 	if a.{{$colField}} != d.{{$fcolField}} {
 		t.Error("foreign key was wrong value", a.{{$colField}}, d.{{$fcolField}})
@@ -202,6 +210,7 @@ func test{{$ltable.UpSingular}}ToManySetOp{{$relAlias.Local}}(t *testing.T) {
 		t.Error("foreign key was wrong value", a.{{$colField}}, e.{{$fcolField}})
 	}
 	{{- end}}
+	{{- if not $.NoBackReferencing}}
 
 	if b.R.{{$relAlias.Foreign}} != nil {
 		t.Error("relationship was not removed properly from the foreign struct")
@@ -216,6 +225,7 @@ func test{{$ltable.UpSingular}}ToManySetOp{{$relAlias.Local}}(t *testing.T) {
 		t.Error("relationship was not added properly to the foreign struct")
 	}
 	{{- end}}
+	{{- end}}
 
 	if a.R.{{$relAlias.Local}}[0] != &d {
 		t.Error("relationship struct slice not set to correct value")
@@ -228,7 +238,7 @@ func test{{$ltable.UpSingular}}ToManySetOp{{$relAlias.Local}}(t *testing.T) {
 func test{{$ltable.UpSingular}}ToManyRemoveOp{{$relAlias.Local}}(t *testing.T) {
 	var err error
 
-	{{if not $.NoContext}}ctx := context.Background(){{end}}
+	{{if not $.NoContext}}ctx := {{template "test_context" $}}{{end}}
 	tx := MustTx({{if $.NoContext}}boil.Begin(){{else}}boil.BeginTx(ctx, nil){{end}})
 	defer func() { _ = tx.Rollback() }()
 
@@ -277,6 +287,7 @@ func test{{$ltable.UpSingular}}ToManyRemoveOp{{$relAlias.Local}}(t *testing.T) {
 	}
 
 	{{- if .ToJoinTable}}
+	{{- if not $.NoBackReferencing}}
 
 	if len(b.R.{{$relAlias.Foreign}}) != 0 {
 		t.Error("relationship was not removed properly from the slice")
@@ -290,6 +301,7 @@ func test{{$ltable.UpSingular}}ToManyRemoveOp{{$relAlias.Local}}(t *testing.T) {
 	if e.R.{{$relAlias.Foreign}}[0] != &a {
 		t.Error("relationship was not added properly to the foreign struct")
 	}
+	{{- end}}
 	{{- else}}
 
 	if !queries.IsValuerNil(b.{{$fcolField}}) {
@@ -298,6 +310,7 @@ func test{{$ltable.UpSingular}}ToManyRemoveOp{{$relAlias.Local}}(t *testing.T) {
 	if !queries.IsValuerNil(c.{{$fcolField}}) {
 		t.Error("want c's foreign key value to be nil")
 	}
+	{{- if not $.NoBackReferencing}}
 
 	if b.R.{{$relAlias.Foreign}} != nil {
 		t.Error("relationship was not removed properly from the foreign struct")
@@ -312,6 +325,7 @@ func test{{$ltable.UpSingular}}ToManyRemoveOp{{$relAlias.Local}}(t *testing.T) {
 		t.Error("relationship to a should have been preserved")
 	}
 	{{- end}}
+	{{- end}}
 
 	if len(a.R.{{$relAlias.Local}}) != 2 {
 		t.Error("should have preserved two relationships")
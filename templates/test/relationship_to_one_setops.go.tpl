@@ -1,4 +1,4 @@
-{{- if .Table.IsJoinTable -}}
+{{- if or .Table.IsJoinTable .NoRelationshipSetters .NoReload .NoExists -}}
 {{- else -}}
 	{{- range $fkey := .Table.FKeys -}}
 		{{- $ltable := $.Aliases.Table $fkey.Table -}}
@@ -10,7 +10,7 @@
 func test{{$ltable.UpSingular}}ToOneSetOp{{$ftable.UpSingular}}Using{{$rel.Foreign}}(t *testing.T) {
 	var err error
 
-	{{if not $.NoContext}}ctx := context.Background(){{end}}
+	{{if not $.NoContext}}ctx := {{template "test_context" $}}{{end}}
 	tx := MustTx({{if $.NoContext}}boil.Begin(){{else}}boil.BeginTx(ctx, nil){{end}})
 	defer func() { _ = tx.Rollback() }()
 
@@ -45,6 +45,7 @@ func test{{$ltable.UpSingular}}ToOneSetOp{{$ftable.UpSingular}}Using{{$rel.Forei
 			t.Error("relationship struct not set to correct value")
 		}
 
+		{{if not $.NoBackReferencing -}}
 		{{if $fkey.Unique -}}
 		if x.R.{{$rel.Local}} != &a {
 			t.Error("failed to append to foreign relationship struct")
@@ -54,6 +55,7 @@ func test{{$ltable.UpSingular}}ToOneSetOp{{$ftable.UpSingular}}Using{{$rel.Forei
 			t.Error("failed to append to foreign relationship struct")
 		}
 		{{end -}}
+		{{end -}}
 
 		{{if $usesPrimitives -}}
 		if a.{{$colField}} != x.{{$fcolField}} {
@@ -92,7 +94,7 @@ func test{{$ltable.UpSingular}}ToOneSetOp{{$ftable.UpSingular}}Using{{$rel.Forei
 func test{{$ltable.UpSingular}}ToOneRemoveOp{{$ftable.UpSingular}}Using{{$rel.Foreign}}(t *testing.T) {
 	var err error
 
-	{{if not $.NoContext}}ctx := context.Background(){{end}}
+	{{if not $.NoContext}}ctx := {{template "test_context" $}}{{end}}
 	tx := MustTx({{if $.NoContext}}boil.Begin(){{else}}boil.BeginTx(ctx, nil){{end}})
 	defer func() { _ = tx.Rollback() }()
 
@@ -135,6 +137,7 @@ func test{{$ltable.UpSingular}}ToOneRemoveOp{{$ftable.UpSingular}}Using{{$rel.Fo
 		t.Error("foreign key value should be nil")
 	}
 
+	{{if not $.NoBackReferencing -}}
 	{{if $fkey.Unique -}}
 	if b.R.{{$rel.Local}} != nil {
 		t.Error("failed to remove a from b's relationships")
@@ -144,6 +147,24 @@ func test{{$ltable.UpSingular}}ToOneRemoveOp{{$ftable.UpSingular}}Using{{$rel.Fo
 		t.Error("failed to remove a from b's relationships")
 	}
 	{{- end}}
+	{{- end}}
+
+	if found, err := a.Find{{$rel.Foreign}}({{if not $.NoContext}}ctx, {{end -}} tx); found != nil || err != nil {
+		t.Errorf("want no {{$ftable.DownSingular}} and no error for a null foreign key, got: %v %v", found, err)
+	}
+
+	if err = a.Set{{$rel.Foreign}}({{if not $.NoContext}}ctx, {{end -}} tx, false, &b); err != nil {
+		t.Fatal(err)
+	}
+	if found, err := a.Find{{$rel.Foreign}}({{if not $.NoContext}}ctx, {{end -}} tx); found == nil || err != nil {
+		t.Errorf("want the {{$ftable.DownSingular}}, got: %v %v", found, err)
+	}
+	if err = a.Set{{$rel.Foreign}}({{if not $.NoContext}}ctx, {{end -}} tx, false, nil); err != nil {
+		t.Fatal(err)
+	}
+	if a.R.{{$rel.Foreign}} != nil || !queries.IsValuerNil(a.{{$colField}}) {
+		t.Error("want setting nil to remove the relationship")
+	}
 }
 {{end -}}{{/* end if foreign key nullable */}}
 {{- end -}}{{/* range */}}
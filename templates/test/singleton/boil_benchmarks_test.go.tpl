@@ -0,0 +1,50 @@
+{{- if .WithBenchmarks -}}
+// benchmarkBulkSize is the number of rows inserted per iteration of the bulk
+// insert benchmarks and loaded per iteration of the eager load benchmarks.
+const benchmarkBulkSize = 100
+
+// The benchmarks run against the test database, run them with:
+// go test -run XXX -bench .
+func BenchmarkInsert(b *testing.B) {
+  {{- range .Tables}}
+  {{- if or .IsJoinTable .IsView -}}
+  {{- else -}}
+  {{- $alias := $.Aliases.Table .Name -}}
+  b.Run("{{$alias.UpPlural}}", benchmark{{$alias.UpPlural}}Insert)
+  {{end -}}
+  {{- end -}}
+}
+
+func BenchmarkBulkInsert(b *testing.B) {
+  {{- range .Tables}}
+  {{- if or .IsJoinTable .IsView -}}
+  {{- else -}}
+  {{- $alias := $.Aliases.Table .Name -}}
+  b.Run("{{$alias.UpPlural}}", benchmark{{$alias.UpPlural}}BulkInsert)
+  {{end -}}
+  {{- end -}}
+}
+
+func BenchmarkFind(b *testing.B) {
+  {{- range .Tables}}
+  {{- if or .IsJoinTable .IsView -}}
+  {{- else -}}
+  {{- $alias := $.Aliases.Table .Name -}}
+  b.Run("{{$alias.UpPlural}}", benchmark{{$alias.UpPlural}}Find)
+  {{end -}}
+  {{- end -}}
+}
+
+func BenchmarkEagerLoad(b *testing.B) {
+{{- range .Tables}}
+  {{- if or .IsJoinTable .IsView -}}
+  {{- else -}}
+    {{- range $fkey := .FKeys -}}
+      {{- $ltable := $.Aliases.Table $fkey.Table -}}
+      {{- $relAlias := $ltable.Relationship $fkey.Name -}}
+  b.Run("{{$ltable.UpSingular}}{{$relAlias.Foreign}}", benchmark{{$ltable.UpSingular}}EagerLoad{{$relAlias.Foreign}})
+    {{end -}}{{- /* fkey range */ -}}
+  {{- end -}}{{- /* if join table */ -}}
+{{- end -}}{{- /* tables range */ -}}
+}
+{{- end -}}
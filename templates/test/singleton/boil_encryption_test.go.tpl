@@ -0,0 +1,12 @@
+{{- if .Encryption.Columns -}}
+func init() {
+	// The tests encrypt with a fixed key unless an encryptor is set
+	if columnEncryptor == nil {
+		e, err := encryption.NewAESGCM(make([]byte, 32))
+		if err != nil {
+			panic(err)
+		}
+		SetEncryptor(e)
+	}
+}
+{{end -}}
@@ -0,0 +1,78 @@
+{{- if .AddFactories -}}
+// factorySeed is used by factories that were not given their own seed
+var factorySeed = randomize.NewSeed()
+
+{{if .NoContext -}}
+// This is a dummy variable to prevent unused context import error
+var _ = context.Background
+{{- end}}
+
+// This is a dummy variable to prevent unused queries import error when no
+// table has a non-nullable foreign key left after column exclusions
+var _ = queries.Assign
+
+// Factory inserts rows filled with random data for use in tests. Each Create
+// method first inserts the parent rows required by non-nullable foreign keys.
+// Cycles of non-nullable foreign keys must be broken by setting the key in a
+// mod. The zero value is ready to use.
+type Factory struct {
+	// Seed is used to generate the random values, a package wide seed is
+	// used when it is nil.
+	Seed *randomize.Seed
+}
+
+func (f Factory) seed() *randomize.Seed {
+	if f.Seed != nil {
+		return f.Seed
+	}
+
+	return factorySeed
+}
+
+// factoryIsZero reports whether a mod has left a foreign key unset
+func factoryIsZero(v interface{}) bool {
+	return reflect.ValueOf(v).IsZero()
+}
+
+{{range $table := .Tables}}
+{{- if not (or $table.IsJoinTable $table.IsView)}}
+{{- $alias := $.Aliases.Table $table.Name}}
+// Create{{$alias.UpSingular}} inserts a {{$alias.UpSingular}} filled with random data and
+// fails the test if that is not possible. The mods are applied before the
+// insert and may override any field, parents are only created for foreign
+// keys the mods left unset.
+func (f Factory) Create{{$alias.UpSingular}}(t testing.TB, {{if $.NoContext}}exec boil.Executor{{else}}exec boil.ContextExecutor{{end}}, mods ...func(o *{{$alias.UpSingular}})) *{{$alias.UpSingular}} {
+	t.Helper()
+
+	blacklist := []string{{"{"}}{{range $i, $fkey := $table.FKeys}}{{if ne $i 0}}, {{end}}"{{$fkey.Column}}"{{end}}{{"}"}}
+	blacklist = append(blacklist, {{$alias.DownSingular}}ColumnsWithDefault...)
+
+	o := &{{$alias.UpSingular}}{}
+	if err := randomize.Struct(f.seed(), o, {{$alias.DownSingular}}DBTypes, false, blacklist...); err != nil {
+		t.Fatalf("unable to randomize {{$alias.UpSingular}} struct: %s", err)
+	}
+
+	for _, mod := range mods {
+		mod(o)
+	}
+	{{range $fkey := $table.FKeys}}
+	{{- $ftable := getTable $.Tables $fkey.ForeignTable}}
+	{{- if not (or $fkey.Nullable (eq $fkey.ForeignTable $table.Name) $ftable.IsJoinTable $ftable.IsView)}}
+	{{- $fAlias := $.Aliases.Table $fkey.ForeignTable}}
+
+	if factoryIsZero(o.{{$alias.Column $fkey.Column}}) {
+		parent := f.Create{{$fAlias.UpSingular}}(t, exec)
+		queries.Assign(&o.{{$alias.Column $fkey.Column}}, parent.{{$fAlias.Column $fkey.ForeignColumn}})
+	}
+	{{- end}}
+	{{- end}}
+
+	if err := o.Insert({{if not $.NoContext}}context.Background(), {{end -}} exec, boil.Infer()); err != nil {
+		t.Fatalf("unable to insert {{$alias.UpSingular}}: %s", err)
+	}
+
+	return o
+}
+{{end}}
+{{- end}}
+{{- end -}}
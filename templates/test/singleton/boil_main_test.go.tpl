@@ -13,7 +13,55 @@ type tester interface {
 	teardown() error
 }
 
+// harnessTester adapts a testharness.Driver to the tester interface, drivers
+// only need to set dbMain = &harnessTester{driver: ...} in an init function.
+type harnessTester struct {
+	driver testharness.Driver
+	*testharness.Tester
+}
+
+func (h *harnessTester) setup() error {
+	h.Tester = testharness.New(testConfig(), h.driver)
+	return h.Setup()
+}
+
+func (h *harnessTester) conn() (*sql.DB, error) {
+	return h.Conn()
+}
+
+func (h *harnessTester) teardown() error {
+	return h.Teardown()
+}
+
+// testConfig reads the test database configuration from the
+// [{{.DriverName}}] section of the config file.
+func testConfig() testharness.Config {
+	return testharness.Config{
+		DBName:     viper.GetString("{{.DriverName}}.dbname"),
+		TestDBName: viper.GetString("{{.DriverName}}.testdbname"),
+		Host:       viper.GetString("{{.DriverName}}.host"),
+		Port:       viper.GetInt("{{.DriverName}}.port"),
+		User:       viper.GetString("{{.DriverName}}.user"),
+		Pass:       viper.GetString("{{.DriverName}}.pass"),
+		SSLMode:    viper.GetString("{{.DriverName}}.sslmode"),
+		SkipSQLCmd: viper.GetBool("{{.DriverName}}.skipsqlcmd"),
+	}
+}
+
 func TestMain(m *testing.M) {
+	flag.Parse()
+
+	{{if .AddSQLMockTests -}}
+	if *flagSQLMock {
+		// The sqlmock tests do not need a database, so skip the setup and
+		// every test that does
+		if run := flag.Lookup("test.run"); run.Value.String() == "" {
+			_ = run.Value.Set("^TestSQLMock$")
+		}
+		os.Exit(m.Run())
+	}
+
+	{{end -}}
 	if dbMain == nil {
 		fmt.Println("no dbMain tester interface was ready")
 		os.Exit(-1)
@@ -21,8 +69,6 @@ func TestMain(m *testing.M) {
 
 	rand.Seed(time.Now().UnixNano())
 
-	flag.Parse()
-
 	var err error
 
 	// Load configuration
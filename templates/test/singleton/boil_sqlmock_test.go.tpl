@@ -0,0 +1,57 @@
+{{- if .AddSQLMockTests -}}
+var flagSQLMock = flag.Bool("test.sqlmock", false, "Runs only the sqlmock tests, which do not need a database")
+
+// TestSQLMock asserts the exact SQL each model sends to the database for
+// its basic operations. Run it without a database using -test.sqlmock.
+func TestSQLMock(t *testing.T) {
+  {{- range .Tables}}
+  {{- if or .IsJoinTable .IsView -}}
+  {{- else -}}
+  {{- $alias := $.Aliases.Table .Name -}}
+  t.Run("{{$alias.UpPlural}}", test{{$alias.UpPlural}}SQLMock)
+  {{end -}}
+  {{- end -}}
+}
+
+// newSQLMock creates a connection that only accepts the expected queries,
+// compared as exact strings.
+func newSQLMock(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("unable to create sqlmock: %s", err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+		_ = db.Close()
+	})
+
+	return db, mock
+}
+
+// sqlMockArgs matches n arguments of any value
+func sqlMockArgs(n int) []driver.Value {
+	args := make([]driver.Value, n)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+	return args
+}
+
+func sqlMockResult() driver.Result {
+	return sqlmock.NewResult(0, 1)
+}
+
+func sqlMockRows(columns ...string) *sqlmock.Rows {
+	return sqlmock.NewRows(columns)
+}
+
+// sqlMockNoRows reports whether err came from a query that matched but
+// returned no rows
+func sqlMockNoRows(err error) bool {
+	return errors.Is(err, sql.ErrNoRows)
+}
+{{- end -}}
@@ -82,6 +82,7 @@ func TestSliceDeleteAll(t *testing.T) {
   {{- end -}}
 }
 
+{{if not .NoExists -}}
 func TestExists(t *testing.T) {
   {{- range .Tables}}
   {{- if or .IsJoinTable .IsView -}}
@@ -92,6 +93,7 @@ func TestExists(t *testing.T) {
   {{- end -}}
 }
 
+{{end -}}
 func TestFind(t *testing.T) {
   {{- range .Tables}}
   {{- if or .IsJoinTable .IsView -}}
@@ -122,6 +124,16 @@ func TestOne(t *testing.T) {
   {{- end -}}
 }
 
+func TestOneOrNil(t *testing.T) {
+  {{- range .Tables}}
+  {{- if or .IsJoinTable .IsView -}}
+  {{- else -}}
+  {{- $alias := $.Aliases.Table .Name -}}
+  t.Run("{{$alias.UpPlural}}", test{{$alias.UpPlural}}OneOrNil)
+  {{end -}}
+  {{- end -}}
+}
+
 func TestAll(t *testing.T) {
   {{- range .Tables}}
   {{- if or .IsJoinTable .IsView -}}
@@ -212,6 +224,7 @@ func TestToMany(t *testing.T) {
   {{- end -}}{{- /* outer tables range */ -}}
 }
 
+{{if not (or .NoRelationshipSetters .NoReload .NoExists) -}}
 // TestToOneSet tests cannot be run in parallel
 // or deadlocks can occur.
 func TestToOneSet(t *testing.T) {
@@ -228,6 +241,8 @@ func TestToOneSet(t *testing.T) {
 {{- end -}}{{- /* tables range */ -}}
 }
 
+{{end -}}
+{{if not (or .NoRelationshipSetters .NoReload .NoExists) -}}
 // TestToOneRemove tests cannot be run in parallel
 // or deadlocks can occur.
 func TestToOneRemove(t *testing.T) {
@@ -246,6 +261,8 @@ func TestToOneRemove(t *testing.T) {
 {{- end -}}{{- /* tables range */ -}}
 }
 
+{{end -}}
+{{if not (or .NoRelationshipSetters .NoReload .NoExists) -}}
 // TestOneToOneSet tests cannot be run in parallel
 // or deadlocks can occur.
 func TestOneToOneSet(t *testing.T) {
@@ -262,6 +279,8 @@ func TestOneToOneSet(t *testing.T) {
   {{- end -}}{{- /* outer tables range */ -}}
 }
 
+{{end -}}
+{{if not (or .NoRelationshipSetters .NoReload .NoExists) -}}
 // TestOneToOneRemove tests cannot be run in parallel
 // or deadlocks can occur.
 func TestOneToOneRemove(t *testing.T) {
@@ -280,6 +299,8 @@ func TestOneToOneRemove(t *testing.T) {
   {{- end -}}{{- /* outer tables range */ -}}
 }
 
+{{end -}}
+{{if not .NoRelationshipSetters -}}
 // TestToManyAdd tests cannot be run in parallel
 // or deadlocks can occur.
 func TestToManyAdd(t *testing.T) {
@@ -295,6 +316,8 @@ func TestToManyAdd(t *testing.T) {
   {{- end -}}{{- /* outer tables range */ -}}
 }
 
+{{end -}}
+{{if not .NoRelationshipSetters -}}
 // TestToManySet tests cannot be run in parallel
 // or deadlocks can occur.
 func TestToManySet(t *testing.T) {
@@ -313,6 +336,8 @@ func TestToManySet(t *testing.T) {
   {{- end -}}{{- /* outer tables range */ -}}
 }
 
+{{end -}}
+{{if not .NoRelationshipSetters -}}
 // TestToManyRemove tests cannot be run in parallel
 // or deadlocks can occur.
 func TestToManyRemove(t *testing.T) {
@@ -331,6 +356,8 @@ func TestToManyRemove(t *testing.T) {
   {{- end -}}{{- /* outer tables range */ -}}
 }
 
+{{end -}}
+{{if not .NoReload -}}
 func TestReload(t *testing.T) {
   {{- range .Tables}}
   {{- if or .IsJoinTable .IsView -}}
@@ -341,6 +368,8 @@ func TestReload(t *testing.T) {
   {{- end -}}
 }
 
+{{end -}}
+{{if not .NoReload -}}
 func TestReloadAll(t *testing.T) {
   {{- range .Tables}}
   {{- if or .IsJoinTable .IsView -}}
@@ -351,6 +380,7 @@ func TestReloadAll(t *testing.T) {
   {{- end -}}
 }
 
+{{end -}}
 func TestSelect(t *testing.T) {
   {{- range .Tables}}
   {{- if or .IsJoinTable .IsView -}}
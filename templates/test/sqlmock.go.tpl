@@ -0,0 +1,316 @@
+{{- if .AddSQLMockTests -}}
+{{- $alias := .Aliases.Table .Table.Name -}}
+{{- $schemaTable := .Table.Name | .SchemaTable -}}
+{{- $pkCols := .Table.PKey.Columns -}}
+{{- $keyCols := $pkCols -}}
+{{- $tenant := and (.Tenancy.Scopes .Table.Name) (not .Table.IsView) -}}
+{{- if $tenant -}}{{- $keyCols = .Tenancy.KeyColumns .Table -}}{{- end -}}
+{{- $pkArgs := $pkCols | stringMap (aliasCols $alias) | prefixStringSlice "o." | join ", " -}}
+{{- $soft := and .AddSoftDeletes (.Table.CanSoftDelete $.AutoColumns.Deleted) -}}
+{{- $insertCols := filterColumnsByAuto false .Table.Columns | columnNames -}}
+{{- $returnCols := setComplement (filterColumnsByDefault true .Table.Columns | columnNames) $insertCols -}}
+{{- $updateCols := setComplement $insertCols $pkCols -}}
+{{- $start := .Dialect.PlaceholderStart 1 -}}
+{{- $colSep := printf "%s,%s" .RQ .LQ -}}
+{{- $findQuery := printf "select * from %s where %s" $schemaTable (whereClause .LQ .RQ $start $pkCols) -}}
+{{- $tenantFindQuery := printf "%s and %s=%s" $findQuery (.Tenancy.Column | .Quotes) (.Dialect.Placeholder (add (len $pkCols) 1)) -}}
+{{- if $soft -}}
+{{- $findQuery = printf "%s and %s is null" $findQuery (or $.AutoColumns.Deleted "deleted_at" | $.Quotes) -}}
+{{- $tenantFindQuery = printf "%s and %s is null" $tenantFindQuery (or $.AutoColumns.Deleted "deleted_at" | $.Quotes) -}}
+{{- end -}}
+{{- $audited := .AuditLog.Audits .Table.Name -}}
+{{- $published := .Outbox.Publishes .Table.Name -}}
+{{- $history := "" -}}
+{{- range .HistoryTables}}{{if eq .Name $.Table.Name}}{{$history = .}}{{end}}{{end -}}
+func test{{$alias.UpPlural}}SQLMock(t *testing.T) {
+	t.Parallel()
+
+	{{if $insertCols -}}
+	{{- $output := "" -}}
+	{{- $returning := "" -}}
+	{{- if and $returnCols .Dialect.UseOutputClause -}}
+	{{- $output = printf "OUTPUT INSERTED.%s%s%s " .LQ (join (printf "%s,INSERTED.%s" .RQ .LQ) $returnCols) .RQ -}}
+	{{- else if and $returnCols .Dialect.UseReturningClause -}}
+	{{- $returning = printf " RETURNING %s%s%s" .LQ (join $colSep $returnCols) .RQ -}}
+	{{- end -}}
+	{{- $insertQuery := printf "INSERT INTO %s (%s%s%s) %sVALUES (%s)%s" $schemaTable .LQ (join $colSep $insertCols) .RQ $output (.Dialect.Placeholders (len $insertCols) 1 1) $returning -}}
+	t.Run("Insert", func(t *testing.T) {
+		t.Parallel()
+		{{- if and $returnCols .Dialect.UseLastInsertID}}
+
+		t.Skip("{{.Table.Name}} has columns that are only known after a select of the inserted row")
+		{{- else}}
+
+		db, mock := newSQLMock(t)
+		{{- if $history}}
+		// the history hooks are registered by another file, in no set order with the others
+		mock.MatchExpectationsInOrder(false)
+		{{- end}}
+		{{if $returnCols -}}
+		mock.ExpectQuery("{{$insertQuery}}").
+			WithArgs(sqlMockArgs({{len $insertCols}})...).
+			WillReturnRows(sqlMockRows({{$returnCols | stringMap .StringFuncs.quoteWrap | join ", "}}))
+		{{- else -}}
+		mock.ExpectExec("{{$insertQuery}}").
+			WithArgs(sqlMockArgs({{len $insertCols}})...).
+			WillReturnResult(sqlMockResult())
+		{{- if $audited}}
+		mock.ExpectExec(auditLogInsertSQL).
+			WithArgs(sqlMockArgs(6)...).
+			WillReturnResult(sqlMockResult())
+		{{- end}}
+		{{- if $published}}
+		mock.ExpectExec(outboxInsertSQL).
+			WithArgs(sqlMockArgs(5)...).
+			WillReturnResult(sqlMockResult())
+		{{- end}}
+		{{- if $history}}
+		mock.ExpectExec({{printf "%q" $history.Insert}}).
+			WithArgs(sqlMockArgs({{add (len .Table.Columns) 1}})...).
+			WillReturnResult(sqlMockResult())
+		{{- end}}
+		{{- end}}
+
+		o := &{{$alias.UpSingular}}{}
+		err := o.Insert({{if not .NoContext}}{{template "test_context" $}}, {{end -}} db, boil.Whitelist({{$insertCols | stringMap .StringFuncs.quoteWrap | join ", "}}))
+		{{if $returnCols -}}
+		if err != nil && !sqlMockNoRows(err) {
+		{{- else -}}
+		if err != nil {
+		{{- end}}
+			t.Error(err)
+		}
+		{{- end}}
+	})
+
+	{{end -}}
+
+	{{if $updateCols -}}
+	{{- $whereStart := .Dialect.PlaceholderStart (add (len $updateCols) 1) -}}
+	{{- $updateQuery := printf "UPDATE %s SET %s WHERE %s" $schemaTable (setParamNames .LQ .RQ $start $updateCols) (whereClause .LQ .RQ $whereStart $keyCols) -}}
+	t.Run("Update", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock := newSQLMock(t)
+		{{- if $history}}
+		// the history hooks are registered by another file, in no set order with the others
+		mock.MatchExpectationsInOrder(false)
+		{{- end}}
+		{{if $audited -}}
+		mock.ExpectQuery("{{$findQuery}}").
+			WithArgs(sqlMockArgs({{len $pkCols}})...).
+			WillReturnRows(sqlMockRows({{.Table.Columns | columnNames | stringMap .StringFuncs.quoteWrap | join ", "}}))
+		{{end -}}
+		mock.ExpectExec("{{$updateQuery}}").
+			WithArgs(sqlMockArgs({{add (len $updateCols) (len $keyCols)}})...).
+			WillReturnResult(sqlMockResult())
+		{{- if $audited}}
+		mock.ExpectExec(auditLogInsertSQL).
+			WithArgs(sqlMockArgs(6)...).
+			WillReturnResult(sqlMockResult())
+		{{- end}}
+		{{- if $published}}
+		mock.ExpectExec(outboxInsertSQL).
+			WithArgs(sqlMockArgs(5)...).
+			WillReturnResult(sqlMockResult())
+		{{- end}}
+		{{- if $history}}
+		mock.ExpectExec({{printf "%q" $history.Close}}).
+			WithArgs(sqlMockArgs({{add (len $pkCols) 1}})...).
+			WillReturnResult(sqlMockResult())
+		mock.ExpectExec({{printf "%q" $history.Insert}}).
+			WithArgs(sqlMockArgs({{add (len .Table.Columns) 1}})...).
+			WillReturnResult(sqlMockResult())
+		{{- end}}
+
+		o := &{{$alias.UpSingular}}{}
+		{{if not .NoRowsAffected}}_, {{end -}} err := o.Update({{if not .NoContext}}{{template "test_context" $}}, {{end -}} db, boil.Whitelist({{$updateCols | stringMap .StringFuncs.quoteWrap | join ", "}}))
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	{{end -}}
+
+	{{- $deleteQuery := printf "DELETE FROM %s WHERE %s" $schemaTable (whereClause .LQ .RQ $start $keyCols) -}}
+	t.Run("Delete", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock := newSQLMock(t)
+		{{- if $history}}
+		// the history hooks are registered by another file, in no set order with the others
+		mock.MatchExpectationsInOrder(false)
+		{{- end}}
+		{{if $audited -}}
+		mock.ExpectQuery("{{$findQuery}}").
+			WithArgs(sqlMockArgs({{len $pkCols}})...).
+			WillReturnRows(sqlMockRows({{.Table.Columns | columnNames | stringMap .StringFuncs.quoteWrap | join ", "}}))
+		{{end -}}
+		mock.ExpectExec("{{$deleteQuery}}").
+			WithArgs(sqlMockArgs({{len $keyCols}})...).
+			WillReturnResult(sqlMockResult())
+		{{- if $audited}}
+		mock.ExpectExec(auditLogInsertSQL).
+			WithArgs(sqlMockArgs(6)...).
+			WillReturnResult(sqlMockResult())
+		{{- end}}
+		{{- if $published}}
+		mock.ExpectExec(outboxInsertSQL).
+			WithArgs(sqlMockArgs(5)...).
+			WillReturnResult(sqlMockResult())
+		{{- end}}
+		{{- if $history}}
+		mock.ExpectExec({{printf "%q" $history.Close}}).
+			WithArgs(sqlMockArgs({{add (len $pkCols) 1}})...).
+			WillReturnResult(sqlMockResult())
+		{{- end}}
+
+		o := &{{$alias.UpSingular}}{}
+		{{if not .NoRowsAffected}}_, {{end -}} err := o.Delete({{if not .NoContext}}{{template "test_context" $}}, {{end -}} db{{if $soft}}, true{{end}})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	{{if $soft -}}
+	{{- $softDelCol := or $.AutoColumns.Deleted "deleted_at" -}}
+	{{- $whereStart := .Dialect.PlaceholderStart 2 -}}
+	{{- $softDeleteQuery := printf "UPDATE %s SET %s WHERE %s" $schemaTable (printf "%s%s%s=%s" .LQ $softDelCol .RQ (.Dialect.Placeholder 1)) (whereClause .LQ .RQ $whereStart $keyCols) -}}
+	t.Run("SoftDelete", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock := newSQLMock(t)
+		{{- if $history}}
+		// the history hooks are registered by another file, in no set order with the others
+		mock.MatchExpectationsInOrder(false)
+		{{- end}}
+		{{if $audited -}}
+		mock.ExpectQuery("{{$findQuery}}").
+			WithArgs(sqlMockArgs({{len $pkCols}})...).
+			WillReturnRows(sqlMockRows({{.Table.Columns | columnNames | stringMap .StringFuncs.quoteWrap | join ", "}}))
+		{{end -}}
+		mock.ExpectExec("{{$softDeleteQuery}}").
+			WithArgs(sqlMockArgs({{add (len $keyCols) 1}})...).
+			WillReturnResult(sqlMockResult())
+		{{- if $audited}}
+		mock.ExpectExec(auditLogInsertSQL).
+			WithArgs(sqlMockArgs(6)...).
+			WillReturnResult(sqlMockResult())
+		{{- end}}
+		{{- if $published}}
+		mock.ExpectExec(outboxInsertSQL).
+			WithArgs(sqlMockArgs(5)...).
+			WillReturnResult(sqlMockResult())
+		{{- end}}
+		{{- if $history}}
+		mock.ExpectExec({{printf "%q" $history.Close}}).
+			WithArgs(sqlMockArgs({{add (len $pkCols) 1}})...).
+			WillReturnResult(sqlMockResult())
+		{{- end}}
+
+		o := &{{$alias.UpSingular}}{}
+		{{if not .NoRowsAffected}}_, {{end -}} err := o.Delete({{if not .NoContext}}{{template "test_context" $}}, {{end -}} db, false)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	{{end -}}
+
+	t.Run("Find", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock := newSQLMock(t)
+		mock.ExpectQuery("{{$findQuery}}").
+			WithArgs(sqlMockArgs({{len $pkCols}})...).
+			WillReturnRows(sqlMockRows({{.Table.Columns | columnNames | stringMap .StringFuncs.quoteWrap | join ", "}}))
+
+		o := &{{$alias.UpSingular}}{}
+		_, err := Find{{$alias.UpSingular}}({{if not .NoContext}}{{template "test_context" $}}, {{end -}} db, {{$pkArgs}})
+		if !sqlMockNoRows(err) {
+			t.Error("expected no rows, got:", err)
+		}
+	})
+	{{- $pkColumn := .Table.GetColumn (index $pkCols 0) -}}
+	{{- $pkValues := list -}}
+	{{- if has $pkColumn.Type (list "int" "int16" "int32" "int64" "uint" "uint16" "uint32" "uint64") -}}
+	{{- $pkValues = list "1" "2" -}}
+	{{- else if eq $pkColumn.Type "string" -}}
+	{{- $pkValues = list `"1"` `"2"` -}}
+	{{- end -}}
+	{{- if $pkValues -}}
+	{{- $pkField := $alias.Column $pkColumn.Name -}}
+	{{- $from := $schemaTable -}}
+	{{- if $soft -}}
+	{{- $from = printf "%s WHERE (%s.%s is null)" $schemaTable $schemaTable (or $.AutoColumns.Deleted "deleted_at" | $.Quotes) -}}
+	{{- end}}
+
+	t.Run("Pluck{{$pkField}}", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock := newSQLMock(t)
+		mock.ExpectQuery("SELECT {{$schemaTable}}.{{$pkColumn.Name | $.Quotes}} FROM {{$from}};").
+			WillReturnRows(sqlMockRows("{{$pkColumn.Name}}").AddRow({{index $pkValues 0}}).AddRow({{index $pkValues 1}}))
+
+		values, err := {{$alias.UpPlural}}().Pluck{{$pkField}}({{if not .NoContext}}{{template "test_context" $}}, {{end -}} db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []{{$pkColumn.Type}}{ {{- index $pkValues 0}}, {{index $pkValues 1 -}} }
+		if len(values) != len(want) || values[0] != want[0] || values[1] != want[1] {
+			t.Errorf("want: %v, got: %v", want, values)
+		}
+	})
+	{{- if and (eq (len $pkCols) 1) (isPrimitive $pkColumn.Type)}}
+
+	t.Run("AllBy{{$pkField}}", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock := newSQLMock(t)
+		mock.ExpectQuery("SELECT {{$schemaTable}}.* FROM {{$from}};").
+			WillReturnRows(sqlMockRows("{{$pkColumn.Name}}").AddRow({{index $pkValues 0}}).AddRow({{index $pkValues 1}}))
+
+		m, err := {{$alias.UpPlural}}().AllBy{{$pkField}}({{if not .NoContext}}{{template "test_context" $}}, {{end -}} db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(m) != 2 {
+			t.Errorf("want 2 {{$alias.DownPlural}}, got: %d", len(m))
+		}
+		for _, key := range []{{$pkColumn.Type}}{ {{- index $pkValues 0}}, {{index $pkValues 1 -}} } {
+			if o, ok := m[key]; !ok || o.{{$pkField}} != key {
+				t.Errorf("want the {{$alias.DownSingular}} of {{$pkColumn.Name}} %v, got: %#v", key, o)
+			}
+		}
+	})
+	{{- end}}
+	{{- if and .WithIdentityMap $tenant (eq (len $pkCols) 1)}}
+
+	t.Run("FindTenants", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock := newSQLMock(t)
+		for i := 0; i < 2; i++ {
+			mock.ExpectQuery("{{$tenantFindQuery}}").
+				WithArgs(sqlMockArgs(2)...).
+				WillReturnRows(sqlMockRows("{{$pkColumn.Name}}").AddRow({{index $pkValues 0}}))
+		}
+
+		exec := boil.IdentityMapContextExecutor(db, &boil.IdentityMap{})
+		first, err := Find{{$alias.UpSingular}}(boil.WithTenant(context.Background(), 1), exec, {{index $pkValues 0}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, err := Find{{$alias.UpSingular}}(boil.WithTenant(context.Background(), 2), exec, {{index $pkValues 0}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if first == second {
+			t.Error("the {{$alias.DownSingular}} of one tenant was found in the identity map for another")
+		}
+	})
+	{{- end}}
+	{{- end}}
+}
+
+{{end -}}
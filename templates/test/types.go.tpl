@@ -1,5 +1,5 @@
 {{- $alias := .Aliases.Table .Table.Name}}
 var (
-	{{$alias.DownSingular}}DBTypes = map[string]string{{"{"}}{{range $i, $col := .Table.Columns -}}{{- if ne $i 0}},{{end}}`{{$alias.Column $col.Name}}`: `{{$col.DBType}}`{{end}}{{"}"}}
+	{{$alias.DownSingular}}DBTypes = randomize.Columns{{"{"}}{{range $i, $col := .Table.Columns -}}{{- if ne $i 0}},{{end}}`{{$alias.Column $col.Name}}`: {DBType: `{{$col.DBType}}`, Nullable: {{$col.Nullable}}, Unique: {{$col.Unique}}{{if $col.CaseInsensitive}}, CaseInsensitive: true{{end}}{{with $col.Charset}}, Charset: `{{.}}`{{end}}{{with columnMaxLength $col}}, MaxLength: {{.}}{{end}}{{with $col.Precision}}, Precision: {{.}}, Scale: {{$col.Scale}}{{end}}}{{end}}{{"}"}}
 	_ = bytes.MinRead
 )
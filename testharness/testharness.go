@@ -0,0 +1,137 @@
+// Package testharness manages the database that generated tests run
+// against. The generated TestMain reads a Config from the sqlboiler config
+// file, and each database driver only has to supply a Driver that knows how
+// to create, drop and connect to a test database.
+package testharness
+
+import (
+	"database/sql"
+	"io"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/randomize"
+)
+
+// Config holds the connection information for the test database. It is
+// generated from the driver's section of the sqlboiler config file.
+type Config struct {
+	// DBName is the database whose schema is copied into the test database
+	DBName string
+	// TestDBName is the database the tests run against, a name based on
+	// DBName is chosen if it is empty
+	TestDBName string
+
+	Host    string
+	Port    int
+	User    string
+	Pass    string
+	SSLMode string
+
+	// SkipSQLCmd assumes the test database already exists and stops it from
+	// being created and dropped
+	SkipSQLCmd bool
+}
+
+// Driver creates and drops test databases for a specific database engine.
+//
+// Drivers that hold resources between calls (temporary credential files for
+// example) may also implement io.Closer, which is called during Teardown.
+type Driver interface {
+	// CreateTestDB creates the test database and loads the schema of the
+	// source database into it.
+	CreateTestDB(cfg Config) error
+	// DropTestDB drops the test database, it must not fail if the test
+	// database does not exist.
+	DropTestDB(cfg Config) error
+	// Open a connection to the test database.
+	Open(cfg Config) (*sql.DB, error)
+}
+
+// Validator is implemented by drivers that require more configuration than
+// a database name.
+type Validator interface {
+	Validate(cfg Config) error
+}
+
+// Tester sets up and tears down the test database using a Driver
+type Tester struct {
+	Config Config
+	Driver Driver
+
+	dbConn *sql.DB
+}
+
+// New creates a tester for the driver
+func New(cfg Config, driver Driver) *Tester {
+	return &Tester{
+		Config: cfg,
+		Driver: driver,
+	}
+}
+
+// Setup validates the configuration and creates a fresh test database
+func (t *Tester) Setup() error {
+	if len(t.Config.DBName) == 0 {
+		return errors.New("no dbname specified")
+	}
+
+	if v, ok := t.Driver.(Validator); ok {
+		if err := v.Validate(t.Config); err != nil {
+			return err
+		}
+	}
+
+	if len(t.Config.TestDBName) == 0 {
+		t.Config.TestDBName = randomize.StableDBName(t.Config.DBName)
+	}
+
+	if t.Config.SkipSQLCmd {
+		return nil
+	}
+
+	if err := t.Driver.DropTestDB(t.Config); err != nil {
+		return errors.Wrap(err, "failed to drop test database")
+	}
+	if err := t.Driver.CreateTestDB(t.Config); err != nil {
+		return errors.Wrap(err, "failed to create test database")
+	}
+
+	return nil
+}
+
+// Conn returns the connection to the test database, opening it on first use
+func (t *Tester) Conn() (*sql.DB, error) {
+	if t.dbConn != nil {
+		return t.dbConn, nil
+	}
+
+	var err error
+	t.dbConn, err = t.Driver.Open(t.Config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open test database")
+	}
+
+	return t.dbConn, nil
+}
+
+// Teardown closes the connection and drops the test database
+func (t *Tester) Teardown() error {
+	if t.dbConn != nil {
+		if err := t.dbConn.Close(); err != nil {
+			return err
+		}
+		t.dbConn = nil
+	}
+
+	if !t.Config.SkipSQLCmd {
+		if err := t.Driver.DropTestDB(t.Config); err != nil {
+			return errors.Wrap(err, "failed to drop test database")
+		}
+	}
+
+	if c, ok := t.Driver.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
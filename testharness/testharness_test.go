@@ -0,0 +1,99 @@
+package testharness
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type fakeDriver struct {
+	calls   []string
+	invalid bool
+}
+
+func (f *fakeDriver) CreateTestDB(cfg Config) error {
+	f.calls = append(f.calls, "create "+cfg.TestDBName)
+	return nil
+}
+
+func (f *fakeDriver) DropTestDB(cfg Config) error {
+	f.calls = append(f.calls, "drop "+cfg.TestDBName)
+	return nil
+}
+
+func (f *fakeDriver) Open(cfg Config) (*sql.DB, error) {
+	f.calls = append(f.calls, "open "+cfg.TestDBName)
+	return nil, errors.New("cannot open")
+}
+
+func (f *fakeDriver) Validate(cfg Config) error {
+	if f.invalid {
+		return errors.New("invalid")
+	}
+	return nil
+}
+
+func (f *fakeDriver) Close() error {
+	f.calls = append(f.calls, "close")
+	return nil
+}
+
+func TestTester(t *testing.T) {
+	t.Parallel()
+
+	d := &fakeDriver{}
+	tester := New(Config{DBName: "db", TestDBName: "test"}, d)
+
+	if err := tester.Setup(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tester.Conn(); err == nil {
+		t.Error("expected an error opening the connection")
+	}
+	if err := tester.Teardown(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"drop test", "create test", "open test", "drop test", "close"}
+	if !reflect.DeepEqual(d.calls, want) {
+		t.Errorf("want: %v, got: %v", want, d.calls)
+	}
+}
+
+func TestTesterSkipSQLCmd(t *testing.T) {
+	t.Parallel()
+
+	d := &fakeDriver{}
+	tester := New(Config{DBName: "db", SkipSQLCmd: true}, d)
+
+	if err := tester.Setup(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tester.Teardown(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tester.Config.TestDBName) == 0 {
+		t.Error("test database name should have been generated")
+	}
+	if want := []string{"close"}; !reflect.DeepEqual(d.calls, want) {
+		t.Errorf("want: %v, got: %v", want, d.calls)
+	}
+}
+
+func TestTesterValidation(t *testing.T) {
+	t.Parallel()
+
+	if err := New(Config{}, &fakeDriver{}).Setup(); err == nil {
+		t.Error("expected an error for a missing dbname")
+	}
+
+	d := &fakeDriver{invalid: true}
+	if err := New(Config{DBName: "db"}, d).Setup(); err == nil {
+		t.Error("expected a validation error")
+	}
+	if len(d.calls) != 0 {
+		t.Error("nothing should be done when validation fails:", d.calls)
+	}
+}
@@ -81,6 +81,14 @@ func (d *Decimal) UnmarshalJSON(data []byte) error {
 	return d.Big.UnmarshalJSON(data)
 }
 
+// Clone returns a copy of the decimal that shares no memory with it
+func (d Decimal) Clone() Decimal {
+	if d.Big == nil {
+		return d
+	}
+	return Decimal{Big: new(decimal.Big).Copy(d.Big)}
+}
+
 // Randomize implements sqlboiler's randomize interface
 func (d *Decimal) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
 	d.Big = randomDecimal(nextInt, fieldType, false)
@@ -148,6 +156,14 @@ func (n NullDecimal) IsZero() bool {
 	return n.Big == nil
 }
 
+// Clone returns a copy of the decimal that shares no memory with it
+func (n NullDecimal) Clone() NullDecimal {
+	if n.Big == nil {
+		return n
+	}
+	return NullDecimal{Big: new(decimal.Big).Copy(n.Big)}
+}
+
 // Randomize implements sqlboiler's randomize interface
 func (n *NullDecimal) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
 	n.Big = randomDecimal(nextInt, fieldType, shouldBeNull)
@@ -215,3 +215,26 @@ func TestNullDecimal_IsZero(t *testing.T) {
 		t.Error("it should not be zero")
 	}
 }
+
+func TestDecimal_Clone(t *testing.T) {
+	t.Parallel()
+
+	d := NewDecimal(decimal.New(15, 1))
+	c := d.Clone()
+	if c.Big == d.Big || c.Cmp(d.Big) != 0 {
+		t.Errorf("want an equal copy, got: %v", c)
+	}
+	d.Add(d.Big, decimal.New(1, 0))
+	if c.String() != "1.5" {
+		t.Errorf("want the copy unchanged, got: %v", c)
+	}
+
+	var n NullDecimal
+	if n.Clone().Big != nil {
+		t.Error("want a null copy of null")
+	}
+	n = NewNullDecimal(decimal.New(2, 0))
+	if c := n.Clone(); c.Big == n.Big || c.Cmp(n.Big) != 0 {
+		t.Errorf("want an equal copy, got: %v", c)
+	}
+}
@@ -0,0 +1,118 @@
+// Package duckdb implements the DuckDB types that go-duckdb scans into Go
+// values database/sql cannot assign to the fields of the models, the 128 bits
+// HUGEINT and the nested LIST and STRUCT.
+//
+// The nested values are written as their JSON text, which DuckDB casts to the
+// type of the column.
+package duckdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// scanJSON decodes the text of a nested value into dst
+func scanJSON(dst interface{}, src interface{}) error {
+	var b []byte
+	switch t := src.(type) {
+	case string:
+		b = []byte(t)
+	case []byte:
+		b = t
+	default:
+		return fmt.Errorf("duckdb: cannot scan %T into %T", src, dst)
+	}
+
+	return json.Unmarshal(b, dst)
+}
+
+// valueJSON encodes a nested value as the JSON text DuckDB casts
+func valueJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// randomValue returns a random value of a DuckDB type for the tests of the
+// models, nil for the types it does not know
+func randomValue(nextInt func() int64, dbType string) interface{} {
+	dbType = strings.TrimSpace(dbType)
+	upper := strings.ToUpper(dbType)
+	switch {
+	case strings.HasSuffix(upper, "[]"):
+		var l List
+		l.Randomize(nextInt, dbType, false)
+		return l
+	case strings.HasPrefix(upper, "STRUCT("):
+		var s Struct
+		s.Randomize(nextInt, dbType, false)
+		return s
+	}
+
+	switch strings.Split(upper, "(")[0] {
+	case "TINYINT", "UTINYINT":
+		return nextInt() % 100
+	case "SMALLINT", "USMALLINT", "INTEGER", "UINTEGER", "BIGINT", "UBIGINT", "HUGEINT", "UHUGEINT":
+		return nextInt() % 10000
+	case "FLOAT", "DOUBLE", "DECIMAL":
+		return float64(nextInt()%10000) / 100
+	case "BOOLEAN":
+		return nextInt()%2 == 0
+	case "VARCHAR":
+		return "str" + strconv.FormatInt(nextInt(), 10)
+	default:
+		return nil
+	}
+}
+
+// structFields splits the fields of a STRUCT type into their names and
+// types, at the commas outside of the parentheses of nested types
+func structFields(dbType string) ([]string, []string) {
+	inner := strings.TrimSpace(dbType)
+	inner = strings.TrimPrefix(strings.TrimSuffix(inner, ")"), "STRUCT(")
+
+	var names, types []string
+	depth, start := 0, 0
+	for i := 0; i <= len(inner); i++ {
+		if i < len(inner) {
+			switch inner[i] {
+			case '(':
+				depth++
+				continue
+			case ')':
+				depth--
+				continue
+			case ',':
+				if depth != 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+
+		field := strings.TrimSpace(inner[start:i])
+		start = i + 1
+		if len(field) == 0 {
+			continue
+		}
+
+		name, typ := field, ""
+		if strings.HasPrefix(field, `"`) {
+			if end := strings.Index(field[1:], `"`); end >= 0 {
+				name, typ = field[1:end+1], field[end+2:]
+			}
+		} else if space := strings.IndexByte(field, ' '); space >= 0 {
+			name, typ = field[:space], field[space+1:]
+		}
+
+		names = append(names, name)
+		types = append(types, strings.TrimSpace(typ))
+	}
+
+	return names, types
+}
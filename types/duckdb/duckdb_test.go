@@ -0,0 +1,116 @@
+package duckdb
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestHugeInt(t *testing.T) {
+	t.Parallel()
+
+	huge, _ := new(big.Int).SetString("170141183460469231731687303715884105727", 10)
+
+	var h HugeInt
+	if err := h.Scan(huge); err != nil {
+		t.Fatal(err)
+	}
+	if h.Cmp(huge) != 0 {
+		t.Errorf("want %s, got %s", huge, h)
+	}
+	if err := h.Scan("-42"); err != nil || h.Int64() != -42 {
+		t.Errorf("want -42, got %s %v", h, err)
+	}
+	if err := h.Scan(nil); err == nil {
+		t.Error("want an error scanning null")
+	}
+
+	v, err := NewHugeInt(huge).Value()
+	if err != nil || v != "170141183460469231731687303715884105727" {
+		t.Errorf("want the integer as text, got %v %v", v, err)
+	}
+
+	var n NullHugeInt
+	if err := n.Scan(nil); err != nil || !n.IsZero() {
+		t.Errorf("want null, got %s %v", n, err)
+	}
+	if v, err := n.Value(); err != nil || v != nil {
+		t.Errorf("want a null value, got %v %v", v, err)
+	}
+}
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	var l List
+	if err := l.Scan([]interface{}{int32(1), int32(2)}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(l, List{int32(1), int32(2)}) {
+		t.Errorf("want the elements, got %#v", l)
+	}
+
+	if err := l.Scan(`["a","b"]`); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(l, List{"a", "b"}) {
+		t.Errorf("want the elements of the text, got %#v", l)
+	}
+
+	if v, err := l.Value(); err != nil || v != `["a","b"]` {
+		t.Errorf("want the JSON text, got %v %v", v, err)
+	}
+	if v, err := List(nil).Value(); err != nil || v != nil {
+		t.Errorf("want a null value, got %v %v", v, err)
+	}
+}
+
+func TestStruct(t *testing.T) {
+	t.Parallel()
+
+	var s Struct
+	if err := s.Scan(map[string]interface{}{"a": int32(1)}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s, Struct{"a": int32(1)}) {
+		t.Errorf("want the fields, got %#v", s)
+	}
+
+	if v, err := (Struct{"a": 1, "b": "x"}).Value(); err != nil || v != `{"a":1,"b":"x"}` {
+		t.Errorf("want the JSON text, got %v %v", v, err)
+	}
+}
+
+func TestStructFields(t *testing.T) {
+	t.Parallel()
+
+	names, types := structFields(`STRUCT(a INTEGER, "b c" DECIMAL(18,3), d STRUCT(e VARCHAR, f INTEGER[]))`)
+	if want := []string{"a", "b c", "d"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want the names %v, got %v", want, names)
+	}
+	if want := []string{"INTEGER", "DECIMAL(18,3)", "STRUCT(e VARCHAR, f INTEGER[])"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("want the types %v, got %v", want, types)
+	}
+}
+
+func TestRandomize(t *testing.T) {
+	t.Parallel()
+
+	i := int64(0)
+	nextInt := func() int64 { i++; return i }
+
+	var s Struct
+	s.Randomize(nextInt, "STRUCT(a INTEGER, b VARCHAR[], c INTERVAL)", false)
+	if len(s) != 3 || s["c"] != nil {
+		t.Errorf("want a value of each field, got %#v", s)
+	}
+	if b, ok := s["b"].(List); !ok || len(b) != 2 {
+		t.Errorf("want a list of strings, got %#v", s["b"])
+	}
+
+	var l List
+	l.Randomize(nextInt, "INTEGER[]", true)
+	if l != nil {
+		t.Errorf("want null, got %#v", l)
+	}
+}
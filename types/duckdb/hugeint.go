@@ -0,0 +1,117 @@
+package duckdb
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// HugeInt is a HUGEINT or UHUGEINT, an integer of 128 bits
+type HugeInt struct {
+	*big.Int
+}
+
+// NullHugeInt is a HUGEINT or UHUGEINT that can be null, a nil Int is null
+type NullHugeInt struct {
+	*big.Int
+}
+
+// NewHugeInt creates a new HugeInt from a big.Int
+func NewHugeInt(i *big.Int) HugeInt {
+	return HugeInt{Int: i}
+}
+
+// NewNullHugeInt creates a new NullHugeInt from a big.Int
+func NewNullHugeInt(i *big.Int) NullHugeInt {
+	return NullHugeInt{Int: i}
+}
+
+// Value implements driver.Valuer, the integer is written as text
+func (h HugeInt) Value() (driver.Value, error) {
+	if h.Int == nil {
+		return nil, errors.New("cannot use a nil *big.Int as a hugeint")
+	}
+	return h.Int.String(), nil
+}
+
+// Scan implements sql.Scanner.
+func (h *HugeInt) Scan(val interface{}) error {
+	if val == nil {
+		return errors.New("null cannot be scanned into hugeint")
+	}
+
+	i, err := hugeIntScan(val)
+	if err != nil {
+		return err
+	}
+	h.Int = i
+	return nil
+}
+
+// Randomize implements sqlboiler's randomize interface
+func (h *HugeInt) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	h.Int = big.NewInt(nextInt() % 10000)
+}
+
+// Value implements driver.Valuer, the integer is written as text
+func (n NullHugeInt) Value() (driver.Value, error) {
+	if n.Int == nil {
+		return nil, nil
+	}
+	return n.Int.String(), nil
+}
+
+// Scan implements sql.Scanner.
+func (n *NullHugeInt) Scan(val interface{}) error {
+	if val == nil {
+		n.Int = nil
+		return nil
+	}
+
+	i, err := hugeIntScan(val)
+	if err != nil {
+		return err
+	}
+	n.Int = i
+	return nil
+}
+
+// IsZero implements qmhelper.Nullable
+func (n NullHugeInt) IsZero() bool {
+	return n.Int == nil
+}
+
+// Randomize implements sqlboiler's randomize interface
+func (n *NullHugeInt) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		n.Int = nil
+		return
+	}
+	n.Int = big.NewInt(nextInt() % 10000)
+}
+
+func hugeIntScan(val interface{}) (*big.Int, error) {
+	switch t := val.(type) {
+	case *big.Int:
+		return new(big.Int).Set(t), nil
+	case big.Int:
+		return new(big.Int).Set(&t), nil
+	case int64:
+		return big.NewInt(t), nil
+	case string:
+		return parseHugeInt(t)
+	case []byte:
+		return parseHugeInt(string(t))
+	default:
+		return nil, fmt.Errorf("cannot scan hugeint value: %#v", val)
+	}
+}
+
+func parseHugeInt(s string) (*big.Int, error) {
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid hugeint syntax: %q", s)
+	}
+	return i, nil
+}
@@ -0,0 +1,62 @@
+package duckdb
+
+import (
+	"database/sql/driver"
+	"strings"
+)
+
+// List is a LIST, the elements are the values go-duckdb scans them into. A
+// nil List is null.
+type List []interface{}
+
+// Value implements driver.Valuer, the list is written as JSON text
+func (l List) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	return valueJSON([]interface{}(l))
+}
+
+// Scan implements sql.Scanner.
+func (l *List) Scan(src interface{}) error {
+	switch t := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []interface{}:
+		*l = List(t)
+		return nil
+	}
+
+	var elements []interface{}
+	if err := scanJSON(&elements, src); err != nil {
+		return err
+	}
+	if elements == nil {
+		elements = []interface{}{}
+	}
+	*l = List(elements)
+	return nil
+}
+
+// IsZero implements qmhelper.Nullable
+func (l List) IsZero() bool {
+	return l == nil
+}
+
+// Randomize implements sqlboiler's randomize interface, the elements are of
+// the type of the elements of the list in fieldType, like INTEGER[]
+func (l *List) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*l = nil
+		return
+	}
+
+	element := strings.TrimSuffix(strings.TrimSpace(fieldType), "[]")
+	if randomValue(nextInt, element) == nil {
+		*l = List{}
+		return
+	}
+
+	*l = List{randomValue(nextInt, element), randomValue(nextInt, element)}
+}
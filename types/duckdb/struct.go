@@ -0,0 +1,58 @@
+package duckdb
+
+import (
+	"database/sql/driver"
+)
+
+// Struct is a STRUCT, the values of the fields are the values go-duckdb
+// scans them into. A nil Struct is null.
+type Struct map[string]interface{}
+
+// Value implements driver.Valuer, the struct is written as JSON text
+func (s Struct) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return valueJSON(map[string]interface{}(s))
+}
+
+// Scan implements sql.Scanner.
+func (s *Struct) Scan(src interface{}) error {
+	switch t := src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case map[string]interface{}:
+		*s = Struct(t)
+		return nil
+	}
+
+	var fields map[string]interface{}
+	if err := scanJSON(&fields, src); err != nil {
+		return err
+	}
+	*s = Struct(fields)
+	return nil
+}
+
+// IsZero implements qmhelper.Nullable
+func (s Struct) IsZero() bool {
+	return s == nil
+}
+
+// Randomize implements sqlboiler's randomize interface, every field of the
+// struct in fieldType, like STRUCT(a INTEGER, b VARCHAR), gets a value of its
+// type, or null for the types it does not know
+func (s *Struct) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*s = nil
+		return
+	}
+
+	names, types := structFields(fieldType)
+	fields := make(Struct, len(names))
+	for i, name := range names {
+		fields[name] = randomValue(nextInt, types[i])
+	}
+	*s = fields
+}